@@ -0,0 +1,76 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package conversioncheck
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	use = "conversion-check"
+
+	dirFlagName = "dir"
+)
+
+var dirFlagValue string
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          use,
+		Short:        "Reports fields of custom resources that would be lost in older API versions",
+		Long:         "Converts every DynaKube and EdgeConnect found in the manifests of a directory into all older API versions and back, and reports the fields that don't survive the conversion",
+		RunE:         run,
+		SilenceUsage: true,
+	}
+
+	addFlags(cmd)
+
+	return cmd
+}
+
+func addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&dirFlagValue, dirFlagName, "", "Directory with the manifests to check, searched recursively for .yaml, .yml and .json files")
+
+	_ = cmd.MarkPersistentFlagRequired(dirFlagName)
+}
+
+func run(cmd *cobra.Command, _ []string) error {
+	reports, err := checkDirectory(dirFlagValue)
+	if err != nil {
+		return err
+	}
+
+	lossyObjects := printReports(cmd.OutOrStdout(), reports)
+	if lossyObjects > 0 {
+		return errors.Errorf("found lossy fields in %d of %d objects", lossyObjects, len(reports))
+	}
+
+	return nil
+}
+
+func printReports(out io.Writer, reports []report) int {
+	lossyObjects := 0
+
+	for _, r := range reports {
+		if len(r.diffs) == 0 {
+			continue
+		}
+
+		lossyObjects++
+
+		_, _ = fmt.Fprintf(out, "%s: %s %s\n", r.file, r.kind, r.key)
+
+		for _, diff := range r.diffs {
+			_, _ = fmt.Fprintf(out, "  %s\n", diff)
+		}
+	}
+
+	_, _ = fmt.Fprintf(out, "checked %d objects, %d with lossy fields\n", len(reports), lossyObjects)
+
+	return lossyObjects
+}
@@ -0,0 +1,119 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package conversioncheck
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	lossyManifest = `apiVersion: dynatrace.com/v1beta6
+kind: DynaKube
+metadata:
+  name: lossy
+  namespace: dynatrace
+spec:
+  apiUrl: https://test.dev.dynatracelabs.com/api
+  oneAgent:
+    hostMonitoring:
+      nodePools:
+        - name: gpu
+          nodeSelector:
+            pool: gpu
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ignored
+  namespace: dynatrace
+`
+	validManifest = `apiVersion: dynatrace.com/v1beta5
+kind: DynaKube
+metadata:
+  name: valid
+  namespace: dynatrace
+spec:
+  apiUrl: https://test.dev.dynatracelabs.com/api
+  oneAgent:
+    cloudNativeFullStack: {}
+---
+apiVersion: dynatrace.com/v1alpha2
+kind: EdgeConnect
+metadata:
+  name: edgeconnect
+  namespace: dynatrace
+spec:
+  apiServer: test.dev.apps.dynatracelabs.com
+`
+)
+
+func writeManifests(t *testing.T, manifests map[string]string) string {
+	dir := t.TempDir()
+
+	for name, content := range manifests {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+
+	return dir
+}
+
+func TestNew(t *testing.T) {
+	cmd := New()
+	require.NotNil(t, cmd)
+	assert.Equal(t, use, cmd.Use)
+	assert.NotNil(t, cmd.PersistentFlags().Lookup(dirFlagName))
+}
+
+func TestRun(t *testing.T) {
+	t.Run("lossy fields are reported", func(t *testing.T) {
+		dir := writeManifests(t, map[string]string{
+			"lossy.yaml":       lossyManifest,
+			"nested/valid.yml": validManifest,
+			"README.md":        "not a manifest",
+		})
+
+		out := &bytes.Buffer{}
+		cmd := New()
+		cmd.SetOut(out)
+		cmd.SetArgs([]string{"--dir", dir})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "found lossy fields in 1 of 3 objects")
+		assert.Contains(t, out.String(), filepath.Join(dir, "lossy.yaml")+": DynaKube dynatrace/lossy")
+		assert.Contains(t, out.String(), "  spec.oneAgent.hostMonitoring.nodePools is lost in v1beta5")
+		assert.NotContains(t, out.String(), "valid")
+	})
+
+	t.Run("no lossy fields", func(t *testing.T) {
+		dir := writeManifests(t, map[string]string{"valid.yaml": validManifest})
+
+		out := &bytes.Buffer{}
+		cmd := New()
+		cmd.SetOut(out)
+		cmd.SetArgs([]string{"--dir", dir})
+
+		require.NoError(t, cmd.Execute())
+		assert.Equal(t, "checked 2 objects, 0 with lossy fields\n", out.String())
+	})
+
+	t.Run("invalid manifest", func(t *testing.T) {
+		dir := writeManifests(t, map[string]string{"invalid.yaml": "apiVersion: [\n"})
+
+		cmd := New()
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetArgs([]string{"--dir", dir})
+
+		assert.Error(t, cmd.Execute())
+	})
+}
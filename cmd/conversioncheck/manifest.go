@@ -0,0 +1,119 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package conversioncheck
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/conversion/roundtrip"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const decoderBufferSize = 4096
+
+var manifestExtensions = []string{".yaml", ".yml", ".json"}
+
+type report struct {
+	file  string
+	kind  string
+	key   types.NamespacedName
+	diffs []roundtrip.Diff
+}
+
+func checkDirectory(dir string) ([]report, error) {
+	var reports []report
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() || !slices.Contains(manifestExtensions, filepath.Ext(path)) {
+			return nil
+		}
+
+		fileReports, err := checkFile(path)
+		if err != nil {
+			return errors.WithMessagef(err, "failed to check %s", path)
+		}
+
+		reports = append(reports, fileReports...)
+
+		return nil
+	})
+
+	return reports, errors.WithStack(err)
+}
+
+// checkFile checks every document of a (multi-document) manifest, objects of kinds without conversion are skipped.
+func checkFile(path string) ([]report, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var reports []report
+
+	decoder := yaml.NewYAMLOrJSONDecoder(file, decoderBufferSize)
+
+	for {
+		manifest := &unstructured.Unstructured{}
+
+		err := decoder.Decode(&manifest.Object)
+		if errors.Is(err, io.EOF) {
+			return reports, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		if len(manifest.Object) == 0 || !scheme.Scheme.Recognizes(manifest.GroupVersionKind()) {
+			continue
+		}
+
+		diffs, checked, err := checkManifest(manifest)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "%s %s/%s", manifest.GetKind(), manifest.GetNamespace(), manifest.GetName())
+		}
+
+		if checked {
+			reports = append(reports, report{
+				file:  path,
+				kind:  manifest.GetKind(),
+				key:   types.NamespacedName{Name: manifest.GetName(), Namespace: manifest.GetNamespace()},
+				diffs: diffs,
+			})
+		}
+	}
+}
+
+func checkManifest(manifest *unstructured.Unstructured) ([]roundtrip.Diff, bool, error) {
+	obj, err := scheme.Scheme.New(manifest.GroupVersionKind())
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(manifest.Object, obj)
+	if err != nil {
+		return nil, false, err
+	}
+
+	hub, err := roundtrip.ToHub(obj)
+	if err != nil || hub == nil {
+		return nil, false, err
+	}
+
+	diffs, err := roundtrip.Check(hub)
+
+	return diffs, true, err
+}
@@ -9,13 +9,21 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8senv"
 	"github.com/Dynatrace/dynatrace-operator/pkg/version"
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 )
 
-const use = "crd-storage-migration"
+const (
+	use       = "crd-storage-migration"
+	component = "dynatrace-crd-storage-migration"
+)
 
-var retryFlagValue bool
+var (
+	retryFlagValue  bool
+	dryRunFlagValue bool
+)
 
 func New() *cobra.Command {
 	cmd := &cobra.Command{
@@ -31,6 +39,7 @@ func New() *cobra.Command {
 
 func addFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().BoolVar(&retryFlagValue, "retry", false, "Retry until completion")
+	cmd.PersistentFlags().BoolVar(&dryRunFlagValue, "dry-run", false, "Only report the objects that would be migrated")
 }
 
 func run(cmd *cobra.Command, args []string) error {
@@ -46,9 +55,29 @@ func run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	clientSet, err := kubernetes.NewForConfig(kubeCfg)
+	if err != nil {
+		return err
+	}
+
+	eventBroadcaster := events.NewBroadcaster(&events.EventSinkImpl{Interface: clientSet.EventsV1()})
+	defer eventBroadcaster.Shutdown()
+
+	err = eventBroadcaster.StartRecordingToSinkWithContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	migrator := crdstoragemigration.NewMigrator(
+		clt,
+		eventBroadcaster.NewRecorder(scheme.Scheme, component),
+		k8senv.DefaultNamespace(),
+		crdstoragemigration.WithDryRun(dryRunFlagValue),
+	)
+
 	if retryFlagValue {
-		return crdstoragemigration.InitReconcile(cmd.Context(), clt, k8senv.DefaultNamespace())
+		return crdstoragemigration.InitReconcile(cmd.Context(), migrator)
 	}
 
-	return crdstoragemigration.Run(cmd.Context(), clt, k8senv.DefaultNamespace())
+	return migrator.Run(cmd.Context())
 }
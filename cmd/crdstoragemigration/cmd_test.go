@@ -24,4 +24,13 @@ func TestNew(t *testing.T) {
 		flag := cmd.PersistentFlags().Lookup("retry")
 		require.NotNil(t, flag)
 	})
+
+	t.Run("has dry-run flag", func(t *testing.T) {
+		cmd := New()
+		require.NotNil(t, cmd)
+
+		flag := cmd.PersistentFlags().Lookup("dry-run")
+		require.NotNil(t, flag)
+		assert.Equal(t, "false", flag.DefValue)
+	})
 }
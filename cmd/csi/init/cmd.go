@@ -10,6 +10,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/installconfig"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8senv"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/watchnamespaces"
 	"github.com/Dynatrace/dynatrace-operator/pkg/version"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -55,9 +56,7 @@ func run(*cobra.Command, []string) error {
 
 	managerOptions := ctrl.Options{
 		Cache: cache.Options{
-			DefaultNamespaces: map[string]cache.Config{
-				k8senv.DefaultNamespace(): {},
-			},
+			DefaultNamespaces: watchnamespaces.CacheConfig(k8senv.DefaultNamespace()),
 		},
 		Scheme: scheme.Scheme,
 	}
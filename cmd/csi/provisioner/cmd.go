@@ -12,6 +12,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/installconfig"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8senv"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/watchnamespaces"
 	"github.com/Dynatrace/dynatrace-operator/pkg/version"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -88,9 +89,7 @@ func run(*cobra.Command, []string) error {
 func createManager(config *rest.Config, namespace string) (manager.Manager, error) {
 	options := ctrl.Options{
 		Cache: cache.Options{
-			DefaultNamespaces: map[string]cache.Config{
-				namespace: {},
-			},
+			DefaultNamespaces: watchnamespaces.CacheConfig(namespace),
 		},
 		Scheme: scheme.Scheme,
 		Metrics: server.Options{
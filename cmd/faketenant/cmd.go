@@ -0,0 +1,75 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package faketenant
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/faketenant"
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/spf13/cobra"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	use = "fake-tenant"
+
+	addressFlagName    = "address"
+	certFileFlagName   = "tls-cert-file"
+	keyFileFlagName    = "tls-key-file"
+	tenantUUIDFlagName = "tenant-uuid"
+	apiTokenFlagName   = "api-token"
+)
+
+var (
+	addressFlagValue    string
+	certFileFlagValue   string
+	keyFileFlagValue    string
+	tenantUUIDFlagValue string
+	apiTokenFlagValue   string
+
+	log = logd.Get().WithName("fake-tenant")
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: "Serves an in-memory Dynatrace tenant for local development and tests",
+		Long: "Serves the part of the Dynatrace API used by the operator from memory, so the operator can run without a real tenant. " +
+			"Point the apiUrl of a DynaKube to <address>" + faketenant.APIPath + " and use " + faketenant.DefaultAPIToken + " as API token. " +
+			"EdgeConnects need TLS and can authenticate with the client " + faketenant.DefaultOAuthClientID + " at " + faketenant.OAuthTokenPath + ". " +
+			"The state can be inspected at " + faketenant.ControlPath + "/state and failures are injected by posting to " + faketenant.ControlPath + "/failures.",
+		RunE:         run,
+		SilenceUsage: true,
+	}
+
+	addFlags(cmd)
+
+	return cmd
+}
+
+func addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&addressFlagValue, addressFlagName, ":8080", "Address the fake tenant listens on")
+	cmd.PersistentFlags().StringVar(&certFileFlagValue, certFileFlagName, "", "Certificate for serving TLS, required for EdgeConnects")
+	cmd.PersistentFlags().StringVar(&keyFileFlagValue, keyFileFlagName, "", "Private key for serving TLS")
+	cmd.PersistentFlags().StringVar(&tenantUUIDFlagValue, tenantUUIDFlagName, faketenant.DefaultTenantUUID, "Tenant UUID returned in the connection info")
+	cmd.PersistentFlags().StringVar(&apiTokenFlagValue, apiTokenFlagName, "", "Additional API token with all scopes")
+
+	cmd.MarkFlagsRequiredTogether(certFileFlagName, keyFileFlagName)
+}
+
+func options() []faketenant.Option {
+	options := []faketenant.Option{faketenant.WithTenantUUID(tenantUUIDFlagValue)}
+
+	if apiTokenFlagValue != "" {
+		options = append(options, faketenant.WithToken(apiTokenFlagValue, faketenant.AllScopes...))
+	}
+
+	return options
+}
+
+func run(*cobra.Command, []string) error {
+	logd.LogBaseLoggerSettings()
+	log.Info("starting fake tenant", "address", addressFlagValue, "tls", certFileFlagValue != "", "tenantUUID", tenantUUIDFlagValue)
+
+	return faketenant.New(options()...).ListenAndServe(ctrl.SetupSignalHandler(), addressFlagValue, certFileFlagValue, keyFileFlagValue)
+}
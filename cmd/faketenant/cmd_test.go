@@ -0,0 +1,30 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package faketenant
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	cmd := New()
+	require.NotNil(t, cmd)
+	assert.Equal(t, use, cmd.Use)
+
+	for _, flag := range []string{addressFlagName, certFileFlagName, keyFileFlagName, tenantUUIDFlagName, apiTokenFlagName} {
+		assert.NotNil(t, cmd.PersistentFlags().Lookup(flag), flag)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	apiTokenFlagValue = ""
+	assert.Len(t, options(), 1)
+
+	apiTokenFlagValue = "dt0c01.CUSTOM"
+	t.Cleanup(func() { apiTokenFlagValue = "" })
+	assert.Len(t, options(), 2)
+}
@@ -9,13 +9,16 @@ import (
 
 	"github.com/Dynatrace/dynatrace-operator/cmd/bootstrapper"
 	"github.com/Dynatrace/dynatrace-operator/cmd/certgen"
+	"github.com/Dynatrace/dynatrace-operator/cmd/conversioncheck"
 	"github.com/Dynatrace/dynatrace-operator/cmd/crdstoragemigration"
 	csiInit "github.com/Dynatrace/dynatrace-operator/cmd/csi/init"
 	"github.com/Dynatrace/dynatrace-operator/cmd/csi/livenessprobe"
 	csiProvisioner "github.com/Dynatrace/dynatrace-operator/cmd/csi/provisioner"
 	"github.com/Dynatrace/dynatrace-operator/cmd/csi/registrar"
 	csiServer "github.com/Dynatrace/dynatrace-operator/cmd/csi/server"
+	"github.com/Dynatrace/dynatrace-operator/cmd/faketenant"
 	"github.com/Dynatrace/dynatrace-operator/cmd/metadata"
+	"github.com/Dynatrace/dynatrace-operator/cmd/mirror"
 	"github.com/Dynatrace/dynatrace-operator/cmd/operator"
 	startupProbe "github.com/Dynatrace/dynatrace-operator/cmd/startupprobe"
 	supportArchive "github.com/Dynatrace/dynatrace-operator/cmd/supportarchive"
@@ -52,6 +55,9 @@ func main() {
 		webhook.New(),
 		operator.New(),
 		crdstoragemigration.New(),
+		conversioncheck.New(),
+		mirror.New(),
+		faketenant.New(),
 		certgen.New(),
 		troubleshoot.New(),
 		supportArchive.New(),
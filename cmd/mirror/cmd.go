@@ -0,0 +1,122 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package mirror
+
+import (
+	"io"
+	"os"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/oci/registry"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	use = "mirror"
+
+	fileFlagName     = "file"
+	targetFlagName   = "target"
+	apiTokenFlagName = "api-token"
+	outputFlagName   = "output"
+	dryRunFlagName   = "dry-run"
+
+	apiTokenEnvVar = "DT_API_TOKEN"
+	stdoutOutput   = "-"
+)
+
+var (
+	fileFlagValue     []string
+	targetFlagValue   string
+	apiTokenFlagValue string
+	outputFlagValue   string
+	dryRunFlagValue   bool
+
+	newImageCopier = func() (registry.ImageCopier, error) {
+		return registry.NewImageCopier(registry.WithKeychain(authn.DefaultKeychain))
+	}
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: "Copies the images needed by custom resources to a private registry",
+		Long: "Resolves every image needed by the DynaKubes, EdgeConnects and DTPrometheuses found in the manifests and copies them to the target registry. " +
+			"Images that aren't set in a DynaKube are resolved via the Dynatrace API of its tenant. " +
+			"Optionally writes the manifests in their latest version, rewritten to use the mirrored images pinned by digest. " +
+			"The credentials for both registries are taken from the local docker config.",
+		RunE:         run,
+		SilenceUsage: true,
+	}
+
+	addFlags(cmd)
+
+	return cmd
+}
+
+func addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringSliceVarP(&fileFlagValue, fileFlagName, "f", nil, "Manifests with the custom resources, can be repeated")
+	cmd.PersistentFlags().StringVar(&targetFlagValue, targetFlagName, "", "Registry and optional path prefix the images are copied to, e.g. registry.example.com/dynatrace")
+	cmd.PersistentFlags().StringVar(&apiTokenFlagValue, apiTokenFlagName, os.Getenv(apiTokenEnvVar), "API token for resolving images that aren't set in a DynaKube, defaults to $"+apiTokenEnvVar)
+	cmd.PersistentFlags().StringVarP(&outputFlagValue, outputFlagName, "o", "", "File the rewritten manifests are written to, - for stdout")
+	cmd.PersistentFlags().BoolVar(&dryRunFlagValue, dryRunFlagName, false, "Only print the images that would be mirrored")
+
+	_ = cmd.MarkPersistentFlagRequired(fileFlagName)
+	_ = cmd.MarkPersistentFlagRequired(targetFlagName)
+}
+
+func run(cmd *cobra.Command, _ []string) error {
+	objects, err := readManifests(fileFlagValue)
+	if err != nil {
+		return err
+	}
+
+	resolver := newImageResolver(apiTokenFlagValue)
+
+	var fields []imageField
+
+	for _, obj := range objects {
+		objectFields, err := collectImages(cmd.Context(), resolver, obj)
+		if err != nil {
+			return err
+		}
+
+		fields = append(fields, objectFields...)
+	}
+
+	copier, err := newImageCopier()
+	if err != nil {
+		return err
+	}
+
+	// the progress goes to stderr when the manifests are written to stdout
+	progress := cmd.OutOrStdout()
+	if outputFlagValue == stdoutOutput {
+		progress = cmd.ErrOrStderr()
+	}
+
+	err = mirrorImages(cmd.Context(), progress, copier, targetFlagValue, fields, dryRunFlagValue)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(cmd.OutOrStdout(), objects)
+}
+
+func writeOutput(stdout io.Writer, objects []runtime.Object) error {
+	switch outputFlagValue {
+	case "":
+		return nil
+	case stdoutOutput:
+		return writeManifests(stdout, objects)
+	}
+
+	file, err := os.Create(outputFlagValue)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return writeManifests(file, objects)
+}
@@ -0,0 +1,201 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package mirror
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testManifest = `apiVersion: dynatrace.com/v1beta5
+kind: DynaKube
+metadata:
+  name: dynakube
+  namespace: dynatrace
+spec:
+  apiUrl: https://test.dev.dynatracelabs.com/api
+  oneAgent:
+    cloudNativeFullStack:
+      image: {{registry}}/dynatrace/dynatrace-oneagent:1.0.0
+      codeModulesImage: {{registry}}/dynatrace/dynatrace-codemodules:1.0.0
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ignored
+  namespace: dynatrace
+---
+apiVersion: dynatrace.com/v1alpha2
+kind: EdgeConnect
+metadata:
+  name: edgeconnect
+  namespace: dynatrace
+spec:
+  apiServer: test.dev.apps.dynatracelabs.com
+  imageRef:
+    repository: {{registry}}/dynatrace/edgeconnect
+    tag: 1.0.0
+`
+
+// setupRegistry starts an in-memory registry with the images of testManifest and returns its host.
+func setupRegistry(t *testing.T) string {
+	t.Helper()
+
+	server := httptest.NewServer(ggcrregistry.New(ggcrregistry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(server.Close)
+
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	for _, repository := range []string{"dynatrace-oneagent", "dynatrace-codemodules", "edgeconnect"} {
+		img, err := random.Image(1024, 1)
+		require.NoError(t, err)
+
+		ref, err := name.ParseReference(host + "/dynatrace/" + repository + ":1.0.0")
+		require.NoError(t, err)
+		require.NoError(t, remote.Write(ref, img))
+	}
+
+	return host
+}
+
+func writeManifest(t *testing.T, host string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "dynakube.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(strings.ReplaceAll(testManifest, "{{registry}}", host)), 0600))
+
+	return path
+}
+
+func TestNew(t *testing.T) {
+	cmd := New()
+	require.NotNil(t, cmd)
+	assert.Equal(t, use, cmd.Use)
+
+	for _, flag := range []string{fileFlagName, targetFlagName, apiTokenFlagName, outputFlagName, dryRunFlagName} {
+		assert.NotNil(t, cmd.PersistentFlags().Lookup(flag), flag)
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Run("images are copied and manifests rewritten", func(t *testing.T) {
+		host := setupRegistry(t)
+		path := writeManifest(t, host)
+		output := filepath.Join(t.TempDir(), "mirrored.yaml")
+
+		out := &bytes.Buffer{}
+		cmd := New()
+		cmd.SetOut(out)
+		cmd.SetArgs([]string{"-f", path, "--target", host + "/mirror", "--output", output})
+
+		require.NoError(t, cmd.Execute())
+		assert.Contains(t, out.String(), host+"/dynatrace/dynatrace-oneagent:1.0.0 (oneagent) -> "+host+"/mirror/dynatrace/dynatrace-oneagent:1.0.0@sha256:")
+		assert.Contains(t, out.String(), "(codemodules)")
+		assert.Contains(t, out.String(), "(edgeconnect)")
+
+		objects, err := readManifests([]string{output})
+		require.NoError(t, err)
+		require.Len(t, objects, 2)
+
+		dk, ok := objects[0].(*dynakube.DynaKube)
+		require.True(t, ok)
+
+		for _, image := range []string{dk.Spec.OneAgent.CloudNativeFullStack.Image, dk.Spec.OneAgent.CloudNativeFullStack.CodeModulesImage} {
+			assert.True(t, strings.HasPrefix(image, host+"/mirror/dynatrace/"), image)
+
+			_, err := remote.Head(mustParseReference(t, image))
+			require.NoError(t, err)
+		}
+
+		ec, ok := objects[1].(*edgeconnect.EdgeConnect)
+		require.True(t, ok)
+		assert.Equal(t, host+"/mirror/dynatrace/edgeconnect", ec.Spec.ImageRef.Repository)
+		assert.Equal(t, "1.0.0", ec.Spec.ImageRef.Tag)
+		assert.NotEmpty(t, ec.Spec.ImageRef.Digest)
+
+		// the tag exists in the target registry as well
+		_, err = remote.Head(mustParseReference(t, host+"/mirror/dynatrace/edgeconnect:1.0.0"))
+		require.NoError(t, err)
+	})
+
+	t.Run("dry run doesn't copy images", func(t *testing.T) {
+		host := setupRegistry(t)
+		path := writeManifest(t, host)
+
+		out := &bytes.Buffer{}
+		cmd := New()
+		cmd.SetOut(out)
+		cmd.SetArgs([]string{"-f", path, "--target", host + "/mirror", "--dry-run"})
+
+		require.NoError(t, cmd.Execute())
+		assert.Equal(t, 3, strings.Count(out.String(), " -> "))
+
+		_, err := remote.Head(mustParseReference(t, host+"/mirror/dynatrace/edgeconnect:1.0.0"))
+		require.Error(t, err)
+	})
+
+	t.Run("manifests are written to stdout and progress to stderr", func(t *testing.T) {
+		host := setupRegistry(t)
+		path := writeManifest(t, host)
+
+		out := &bytes.Buffer{}
+		errOut := &bytes.Buffer{}
+		cmd := New()
+		cmd.SetOut(out)
+		cmd.SetErr(errOut)
+		cmd.SetArgs([]string{"-f", path, "--target", host + "/mirror", "--dry-run", "-o", "-"})
+
+		require.NoError(t, cmd.Execute())
+		assert.Contains(t, errOut.String(), " -> ")
+		assert.Contains(t, out.String(), "apiVersion: dynatrace.com/v1beta6\nkind: DynaKube\n")
+		assert.Contains(t, out.String(), "---\napiVersion: dynatrace.com/v1alpha2\nkind: EdgeConnect\n")
+		assert.NotContains(t, out.String(), "status:")
+	})
+
+	t.Run("missing image without api token", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "dynakube.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(`apiVersion: dynatrace.com/v1beta6
+kind: DynaKube
+metadata:
+  name: dynakube
+  namespace: dynatrace
+spec:
+  apiUrl: https://test.dev.dynatracelabs.com/api
+  oneAgent:
+    hostMonitoring: {}
+`), 0600))
+
+		cmd := New()
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetArgs([]string{"-f", path, "--target", "registry.example.com", "--api-token", ""})
+
+		err := cmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no oneagent image set in DynaKube dynatrace/dynakube")
+	})
+}
+
+func mustParseReference(t *testing.T, ref string) name.Reference {
+	t.Helper()
+
+	parsed, err := name.ParseReference(ref)
+	require.NoError(t, err)
+
+	return parsed
+}
@@ -0,0 +1,267 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package mirror
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/logmonitoring"
+	sharedimage "github.com/Dynatrace/dynatrace-operator/pkg/api/shared/image"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha1/dtprometheus"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/image"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// imageField is an image needed by an operand of a manifest.
+// set writes the reference of the mirrored image back into the manifest.
+type imageField struct {
+	set       func(mirrored mirroredImage)
+	component string
+	source    string
+}
+
+// imageResolver resolves the images that aren't set in a DynaKube via the Dynatrace API of its tenant.
+type imageResolver struct {
+	clients   map[string]image.Client
+	newClient func(apiURL string) (image.Client, error)
+}
+
+func newImageResolver(apiToken string) *imageResolver {
+	resolver := &imageResolver{clients: map[string]image.Client{}}
+
+	if apiToken != "" {
+		resolver.newClient = func(apiURL string) (image.Client, error) {
+			dtClient, err := dynatrace.NewClient(dynatrace.WithBaseURL(apiURL), dynatrace.WithAPIToken(apiToken))
+			if err != nil {
+				return nil, err
+			}
+
+			return dtClient.Images, nil
+		}
+	}
+
+	return resolver
+}
+
+func (r *imageResolver) resolve(ctx context.Context, dk *dynakube.DynaKube, components ...image.ComponentType) (string, error) {
+	if r.newClient == nil {
+		return "", errors.Errorf("no %s image set in DynaKube %s/%s, set the image in the manifest or pass --%s to resolve it via the Dynatrace API", components[0], dk.Namespace, dk.Name, apiTokenFlagName)
+	}
+
+	imageClient, ok := r.clients[dk.Spec.APIURL]
+	if !ok {
+		var err error
+
+		imageClient, err = r.newClient(dk.Spec.APIURL)
+		if err != nil {
+			return "", errors.WithMessagef(err, "failed to create Dynatrace API client for %s", dk.Spec.APIURL)
+		}
+
+		r.clients[dk.Spec.APIURL] = imageClient
+	}
+
+	// later components are fallbacks for older names of the same component
+	var err error
+
+	for _, component := range components {
+		var info *image.Info
+
+		info, err = imageClient.GetComponentLatestInfo(ctx, component, dk.PublicRegistryOverride())
+		if err == nil {
+			return info.URI, nil
+		}
+	}
+
+	return "", err
+}
+
+// collectImages returns the images needed by the operands of a manifest, the same rules as in the controllers decide which operands are deployed.
+func collectImages(ctx context.Context, resolver *imageResolver, obj runtime.Object) ([]imageField, error) {
+	switch typed := obj.(type) {
+	case *dynakube.DynaKube:
+		return collectDynakubeImages(ctx, resolver, typed)
+	case *edgeconnect.EdgeConnect:
+		return []imageField{{
+			component: "edgeconnect",
+			source:    typed.Image(),
+			set:       setImageRef(&typed.Spec.ImageRef),
+		}}, nil
+	case *dtprometheus.DTPrometheus:
+		return collectDTPrometheusImages(typed), nil
+	}
+
+	return nil, nil
+}
+
+func collectDynakubeImages(ctx context.Context, resolver *imageResolver, dk *dynakube.DynaKube) ([]imageField, error) {
+	var fields []imageField
+
+	add := func(component string, source string, set func(mirroredImage)) {
+		fields = append(fields, imageField{component: component, source: source, set: set})
+	}
+
+	addResolved := func(component image.ComponentType, custom string, set func(mirroredImage), fallbacks ...image.ComponentType) error {
+		if custom != "" {
+			add(string(component), custom, set)
+
+			return nil
+		}
+
+		source, err := resolver.resolve(ctx, dk, append([]image.ComponentType{component}, fallbacks...)...)
+		if err != nil {
+			return err
+		}
+
+		add(string(component), source, set)
+
+		return nil
+	}
+
+	addResolvedRef := func(component image.ComponentType, ref *sharedimage.Ref, fallbacks ...image.ComponentType) error {
+		custom := ""
+		if ref.HasImage() {
+			custom = ref.String()
+		}
+
+		return addResolved(component, custom, setImageRef(ref), fallbacks...)
+	}
+
+	oa := dk.OneAgent()
+
+	if oa.IsDaemonsetRequired() {
+		if err := addResolved(image.OneAgent, oa.GetCustomImage(), setOneAgentImage(dk)); err != nil {
+			return nil, err
+		}
+	}
+
+	if oa.IsCloudNativeFullstackMode() || oa.IsApplicationMonitoringMode() {
+		if err := addResolved(image.CodeModules, oa.GetCustomCodeModulesImage(), setCodeModulesImage(dk)); err != nil {
+			return nil, err
+		}
+	}
+
+	if dk.ActiveGate().IsEnabled() {
+		if err := addResolved(image.ActiveGate, dk.Spec.ActiveGate.GetCustomImage(), func(mirrored mirroredImage) {
+			dk.Spec.ActiveGate.Image = mirrored.String()
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if dk.KubernetesMonitoring().IsEnabled() {
+		if err := addResolved(image.ActiveGate, dk.KubernetesMonitoring().GetCustomImage(), func(mirrored mirroredImage) {
+			dk.Spec.KubernetesMonitoring.Image = mirrored.String()
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if dk.Extensions().IsAnyEnabled() {
+		if err := addResolvedRef(image.EEC, &dk.Spec.Templates.ExtensionExecutionController.ImageRef); err != nil {
+			return nil, err
+		}
+	}
+
+	if dk.Extensions().IsDatabasesEnabled() {
+		if err := addResolvedRef(image.DBExecutor, &dk.Spec.Templates.SQLExtensionExecutor.ImageRef, image.DBExecutorOldName); err != nil {
+			return nil, err
+		}
+	}
+
+	if dk.LogMonitoring().IsStandalone() {
+		if dk.Spec.Templates.LogMonitoring == nil {
+			dk.Spec.Templates.LogMonitoring = &logmonitoring.TemplateSpec{}
+		}
+
+		if err := addResolvedRef(image.LogModule, &dk.Spec.Templates.LogMonitoring.ImageRef); err != nil {
+			return nil, err
+		}
+	}
+
+	if dk.KSPM().IsEnabled() {
+		add("kspm", dk.KSPM().Image(), setImageRef(&dk.Spec.Templates.KSPMNodeConfigurationCollector.ImageRef))
+	}
+
+	if dk.Extensions().IsPrometheusEnabled() || dk.TelemetryIngest().IsEnabled() {
+		ref := &dk.Spec.Templates.OpenTelemetryCollector.ImageRef
+		if !ref.HasImage() {
+			return nil, errors.Errorf("no otel collector image set in DynaKube %s/%s", dk.Namespace, dk.Name)
+		}
+
+		add("otel-collector", ref.String(), setImageRef(ref))
+	}
+
+	return fields, nil
+}
+
+// collectDTPrometheusImages returns the images of the DTPrometheus components, they have no defaults and are only mirrored when set.
+func collectDTPrometheusImages(dtp *dtprometheus.DTPrometheus) []imageField {
+	var fields []imageField
+
+	components := []struct {
+		podSpec *dtprometheus.PodSpec
+		name    string
+	}{
+		{name: "target-allocator", podSpec: &dtp.Spec.TargetAllocator.PodSpec},
+		{name: "scraper", podSpec: &dtp.Spec.Scraper.PodSpec},
+		{name: "gateway", podSpec: &dtp.Spec.Gateway.PodSpec},
+	}
+
+	for _, component := range components {
+		if component.podSpec.Image == "" {
+			continue
+		}
+
+		fields = append(fields, imageField{
+			component: component.name,
+			source:    component.podSpec.Image,
+			set: func(mirrored mirroredImage) {
+				component.podSpec.Image = mirrored.String()
+			},
+		})
+	}
+
+	return fields
+}
+
+func setImageRef(ref *sharedimage.Ref) func(mirroredImage) {
+	return func(mirrored mirroredImage) {
+		ref.Repository = mirrored.Repository
+		ref.Tag = mirrored.Tag
+		ref.Digest = mirrored.Digest.String()
+	}
+}
+
+func setOneAgentImage(dk *dynakube.DynaKube) func(mirroredImage) {
+	return func(mirrored mirroredImage) {
+		switch {
+		case dk.OneAgent().IsClassicFullStackMode():
+			dk.Spec.OneAgent.ClassicFullStack.Image = mirrored.String()
+		case dk.OneAgent().IsHostMonitoringMode():
+			dk.Spec.OneAgent.HostMonitoring.Image = mirrored.String()
+		case dk.OneAgent().IsCloudNativeFullstackMode():
+			dk.Spec.OneAgent.CloudNativeFullStack.Image = mirrored.String()
+		}
+	}
+}
+
+func setCodeModulesImage(dk *dynakube.DynaKube) func(mirroredImage) {
+	return func(mirrored mirroredImage) {
+		switch {
+		case dk.OneAgent().IsCloudNativeFullstackMode():
+			dk.Spec.OneAgent.CloudNativeFullStack.CodeModulesImage = mirrored.String()
+		case dk.OneAgent().IsApplicationMonitoringMode():
+			dk.Spec.OneAgent.ApplicationMonitoring.CodeModulesImage = mirrored.String()
+		}
+	}
+}
+
+func (f imageField) String() string {
+	return fmt.Sprintf("%s (%s)", f.source, f.component)
+}
@@ -0,0 +1,208 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package mirror
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/extensions"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/kspm"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
+	sharedimage "github.com/Dynatrace/dynatrace-operator/pkg/api/shared/image"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha1/dtprometheus"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/image"
+	imagemock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/clients/dynatrace/image"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testAPIURL = "https://test.dev.dynatracelabs.com/api"
+
+func newMockResolver(t *testing.T, images map[image.ComponentType]string) *imageResolver {
+	imageClient := imagemock.NewClient(t)
+
+	for component, uri := range images {
+		imageClient.EXPECT().GetComponentLatestInfo(mock.Anything, component, "").Return(&image.Info{URI: uri}, nil).Maybe()
+	}
+
+	imageClient.EXPECT().GetComponentLatestInfo(mock.Anything, mock.Anything, "").Return(nil, errors.New("not found")).Maybe()
+
+	return &imageResolver{
+		clients: map[string]image.Client{},
+		newClient: func(apiURL string) (image.Client, error) {
+			assert.Equal(t, testAPIURL, apiURL)
+
+			return imageClient, nil
+		},
+	}
+}
+
+func sources(fields []imageField) map[string]string {
+	result := map[string]string{}
+	for _, field := range fields {
+		result[field.component] = field.source
+	}
+
+	return result
+}
+
+func TestCollectImages(t *testing.T) {
+	t.Run("images of all dynakube operands", func(t *testing.T) {
+		dk := &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{Name: "dynakube", Namespace: "dynatrace"},
+			Spec: dynakube.DynaKubeSpec{
+				APIURL:     testAPIURL,
+				OneAgent:   oneagent.Spec{CloudNativeFullStack: &oneagent.CloudNativeFullStackSpec{}},
+				ActiveGate: activegate.Spec{Capabilities: []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName}},
+				Extensions: &extensions.Spec{Prometheus: &extensions.PrometheusSpec{}, Databases: []extensions.DatabaseSpec{{ID: "db"}}},
+				KSPM:       &kspm.Spec{},
+				Templates: dynakube.TemplatesSpec{
+					OpenTelemetryCollector: dynakube.OpenTelemetryCollectorSpec{ImageRef: sharedimage.Ref{Repository: "registry.example.com/otel", Tag: "1.0.0"}},
+					SQLExtensionExecutor:   extensions.DatabaseExecutorSpec{ImageRef: sharedimage.Ref{Repository: "registry.example.com/sql", Tag: "1.0.0"}},
+				},
+			},
+		}
+
+		resolver := newMockResolver(t, map[image.ComponentType]string{
+			image.OneAgent:    "public.ecr.aws/dynatrace/dynatrace-oneagent:1.0.0",
+			image.CodeModules: "public.ecr.aws/dynatrace/dynatrace-codemodules:1.0.0",
+			image.ActiveGate:  "public.ecr.aws/dynatrace/dynatrace-activegate:1.0.0",
+			image.EEC:         "public.ecr.aws/dynatrace/dynatrace-eec:1.0.0",
+		})
+
+		fields, err := collectImages(t.Context(), resolver, dk)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"oneagent":               "public.ecr.aws/dynatrace/dynatrace-oneagent:1.0.0",
+			"codemodules":            "public.ecr.aws/dynatrace/dynatrace-codemodules:1.0.0",
+			"activegate":             "public.ecr.aws/dynatrace/dynatrace-activegate:1.0.0",
+			"eec":                    "public.ecr.aws/dynatrace/dynatrace-eec:1.0.0",
+			"sql-extension-executor": "registry.example.com/sql:1.0.0",
+			"kspm":                   "public.ecr.aws/dynatrace/dynatrace-k8s-node-config-collector:latest",
+			"otel-collector":         "registry.example.com/otel:1.0.0",
+		}, sources(fields))
+
+		for _, field := range fields {
+			field.set(mirroredImage{Repository: "mirror.example.com/" + field.component, Tag: "1.0.0", Digest: "sha256:abc"})
+		}
+
+		assert.Equal(t, "mirror.example.com/oneagent:1.0.0@sha256:abc", dk.Spec.OneAgent.CloudNativeFullStack.Image)
+		assert.Equal(t, "mirror.example.com/codemodules:1.0.0@sha256:abc", dk.Spec.OneAgent.CloudNativeFullStack.CodeModulesImage)
+		assert.Equal(t, "mirror.example.com/activegate:1.0.0@sha256:abc", dk.Spec.ActiveGate.Image)
+		assert.Equal(t, sharedimage.Ref{Repository: "mirror.example.com/eec", Tag: "1.0.0", Digest: "sha256:abc"}, dk.Spec.Templates.ExtensionExecutionController.ImageRef)
+		assert.Equal(t, "mirror.example.com/kspm", dk.Spec.Templates.KSPMNodeConfigurationCollector.ImageRef.Repository)
+	})
+
+	t.Run("custom images don't need the api", func(t *testing.T) {
+		dk := &dynakube.DynaKube{
+			Spec: dynakube.DynaKubeSpec{
+				APIURL: testAPIURL,
+				OneAgent: oneagent.Spec{ApplicationMonitoring: &oneagent.ApplicationMonitoringSpec{
+					AppInjectionSpec: oneagent.AppInjectionSpec{CodeModulesImage: "registry.example.com/codemodules:1.0.0"},
+				}},
+			},
+		}
+
+		fields, err := collectImages(t.Context(), newImageResolver(""), dk)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"codemodules": "registry.example.com/codemodules:1.0.0"}, sources(fields))
+	})
+
+	t.Run("sql executor falls back to old component name", func(t *testing.T) {
+		dk := &dynakube.DynaKube{
+			Spec: dynakube.DynaKubeSpec{
+				APIURL:     testAPIURL,
+				Extensions: &extensions.Spec{Databases: []extensions.DatabaseSpec{{ID: "db"}}},
+				Templates: dynakube.TemplatesSpec{
+					OpenTelemetryCollector: dynakube.OpenTelemetryCollectorSpec{ImageRef: sharedimage.Ref{Repository: "registry.example.com/otel", Tag: "1.0.0"}},
+				},
+			},
+		}
+
+		resolver := newMockResolver(t, map[image.ComponentType]string{
+			image.DBExecutorOldName: "public.ecr.aws/dynatrace/dynatrace-sql-extension-executor:1.0.0",
+			image.EEC:               "public.ecr.aws/dynatrace/dynatrace-eec:1.0.0",
+			image.ActiveGate:        "public.ecr.aws/dynatrace/dynatrace-activegate:1.0.0",
+		})
+
+		fields, err := collectImages(t.Context(), resolver, dk)
+		require.NoError(t, err)
+		assert.Equal(t, "public.ecr.aws/dynatrace/dynatrace-sql-extension-executor:1.0.0", sources(fields)["sql-extension-executor"])
+		// the extension controller is also required for databases only
+		assert.Equal(t, "public.ecr.aws/dynatrace/dynatrace-eec:1.0.0", sources(fields)["eec"])
+	})
+
+	t.Run("otel collector image is required", func(t *testing.T) {
+		dk := &dynakube.DynaKube{
+			Spec: dynakube.DynaKubeSpec{
+				APIURL:     testAPIURL,
+				ActiveGate: activegate.Spec{CapabilityProperties: activegate.CapabilityProperties{Image: "registry.example.com/activegate:1.0.0"}},
+				Extensions: &extensions.Spec{Prometheus: &extensions.PrometheusSpec{}},
+				Templates: dynakube.TemplatesSpec{
+					ExtensionExecutionController: extensions.ExecutionControllerSpec{ImageRef: sharedimage.Ref{Repository: "registry.example.com/eec", Tag: "1.0.0"}},
+				},
+			},
+		}
+
+		_, err := collectImages(t.Context(), newImageResolver(""), dk)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no otel collector image set")
+	})
+
+	t.Run("edgeconnect default image", func(t *testing.T) {
+		fields, err := collectImages(t.Context(), newImageResolver(""), &edgeconnect.EdgeConnect{})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"edgeconnect": "docker.io/dynatrace/edgeconnect:latest"}, sources(fields))
+	})
+
+	t.Run("only set dtprometheus images", func(t *testing.T) {
+		dtp := &dtprometheus.DTPrometheus{}
+		dtp.Spec.Scraper.Image = "registry.example.com/otel:1.0.0"
+
+		fields, err := collectImages(t.Context(), newImageResolver(""), dtp)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"scraper": "registry.example.com/otel:1.0.0"}, sources(fields))
+
+		fields[0].set(mirroredImage{Repository: "mirror.example.com/otel", Tag: "1.0.0"})
+		assert.Equal(t, "mirror.example.com/otel:1.0.0", dtp.Spec.Scraper.Image)
+	})
+}
+
+func TestTargetImage(t *testing.T) {
+	tests := []struct {
+		source   string
+		expected mirroredImage
+	}{
+		{
+			source:   "public.ecr.aws/dynatrace/dynatrace-oneagent:1.0.0",
+			expected: mirroredImage{Repository: "mirror.example.com/dt/dynatrace/dynatrace-oneagent", Tag: "1.0.0"},
+		},
+		{
+			source:   "public.ecr.aws/dynatrace/dynatrace-oneagent:1.0.0@sha256:abc",
+			expected: mirroredImage{Repository: "mirror.example.com/dt/dynatrace/dynatrace-oneagent", Tag: "1.0.0", Digest: "sha256:abc"},
+		},
+		{
+			source:   "public.ecr.aws/dynatrace/dynatrace-oneagent@sha256:abc",
+			expected: mirroredImage{Repository: "mirror.example.com/dt/dynatrace/dynatrace-oneagent", Digest: "sha256:abc"},
+		},
+		{
+			source:   "dynatrace/edgeconnect:latest",
+			expected: mirroredImage{Repository: "mirror.example.com/dt/dynatrace/edgeconnect", Tag: "latest"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.source, func(t *testing.T) {
+			mirrored, err := targetImage("mirror.example.com/dt/", test.source)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, mirrored)
+		})
+	}
+}
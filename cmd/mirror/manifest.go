@@ -0,0 +1,147 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package mirror
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/conversion/roundtrip"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha1/dtprometheus"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	sigyaml "sigs.k8s.io/yaml"
+)
+
+const decoderBufferSize = 4096
+
+// readManifests returns the DynaKubes, EdgeConnects and DTPrometheuses of the (multi-document) manifests.
+// DynaKubes and EdgeConnects are converted into their latest version, all other documents are skipped.
+func readManifests(paths []string) ([]runtime.Object, error) {
+	var objects []runtime.Object
+
+	for _, path := range paths {
+		fileObjects, err := readFile(path)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to read %s", path)
+		}
+
+		objects = append(objects, fileObjects...)
+	}
+
+	return objects, nil
+}
+
+func readFile(path string) ([]runtime.Object, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var objects []runtime.Object
+
+	decoder := yaml.NewYAMLOrJSONDecoder(file, decoderBufferSize)
+
+	for {
+		manifest := &unstructured.Unstructured{}
+
+		err := decoder.Decode(&manifest.Object)
+		if errors.Is(err, io.EOF) {
+			return objects, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		if len(manifest.Object) == 0 || !scheme.Scheme.Recognizes(manifest.GroupVersionKind()) {
+			continue
+		}
+
+		obj, err := toLatest(manifest)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "%s %s/%s", manifest.GetKind(), manifest.GetNamespace(), manifest.GetName())
+		}
+
+		if obj != nil {
+			objects = append(objects, obj)
+		}
+	}
+}
+
+func toLatest(manifest *unstructured.Unstructured) (runtime.Object, error) {
+	obj, err := scheme.Scheme.New(manifest.GroupVersionKind())
+	if err != nil {
+		return nil, err
+	}
+
+	// decoded via json like the API server does, the unstructured converter can't handle the unexported fields of the operand specs
+	encoded, err := manifest.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(encoded, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	// DTPrometheus has a single version, so there is nothing to convert
+	if _, ok := obj.(*dtprometheus.DTPrometheus); ok {
+		return obj, nil
+	}
+
+	hub, err := roundtrip.ToHub(obj)
+	if err != nil || hub == nil {
+		return nil, err
+	}
+
+	return hub, nil
+}
+
+// writeManifests writes the objects as multi-document YAML, without their status.
+func writeManifests(out io.Writer, objects []runtime.Object) error {
+	for i, obj := range objects {
+		gvk, err := apiutil.GVKForObject(obj, scheme.Scheme)
+		if err != nil {
+			return err
+		}
+
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+
+		encoded, err := json.Marshal(obj)
+		if err != nil {
+			return err
+		}
+
+		manifest := &unstructured.Unstructured{}
+		if err := json.Unmarshal(encoded, &manifest.Object); err != nil {
+			return err
+		}
+
+		unstructured.RemoveNestedField(manifest.Object, "status")
+		unstructured.RemoveNestedField(manifest.Object, "metadata", "creationTimestamp")
+
+		data, err := sigyaml.Marshal(manifest.Object)
+		if err != nil {
+			return err
+		}
+
+		if i > 0 {
+			if _, err := io.WriteString(out, "---\n"); err != nil {
+				return err
+			}
+		}
+
+		if _, err := out.Write(data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
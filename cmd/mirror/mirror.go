@@ -0,0 +1,104 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package mirror
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/oci/registry"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+// mirroredImage is the reference of an image in the target registry.
+type mirroredImage struct {
+	Repository string
+	Tag        string
+	Digest     digest.Digest
+}
+
+// String returns the reference with tag and digest, the tag is kept for readability and the digest pins the image.
+func (m mirroredImage) String() string {
+	ref := m.Repository
+
+	if m.Tag != "" {
+		ref += ":" + m.Tag
+	}
+
+	if m.Digest != "" {
+		ref += registry.DigestDelimiter + m.Digest.String()
+	}
+
+	return ref
+}
+
+// targetImage maps a source image into the target registry, e.g. public.ecr.aws/dynatrace/dynatrace-oneagent:1.0.0
+// becomes <target>/dynatrace/dynatrace-oneagent:1.0.0. Tags and digests of the source are kept.
+func targetImage(target, source string) (mirroredImage, error) {
+	// a reference with tag and digest is parsed as digest, so the tag is cut off first
+	imagePart, sourceDigest, _ := strings.Cut(source, registry.DigestDelimiter)
+
+	ref, err := name.ParseReference(imagePart, name.WithDefaultTag(""))
+	if err != nil {
+		return mirroredImage{}, errors.WithMessagef(err, "parsing reference %q", source)
+	}
+
+	mirrored := mirroredImage{
+		Repository: strings.TrimSuffix(target, "/") + "/" + ref.Context().RepositoryStr(),
+		Digest:     digest.Digest(sourceDigest),
+	}
+
+	if tag, ok := ref.(name.Tag); ok {
+		mirrored.Tag = tag.TagStr()
+	}
+
+	return mirrored, nil
+}
+
+// mirrorImages copies every image of the fields into the target registry and writes the mirrored references back into the manifests.
+// Images used by several fields are only copied once.
+func mirrorImages(ctx context.Context, out io.Writer, copier registry.ImageCopier, target string, fields []imageField, dryRun bool) error {
+	mirrored := map[string]mirroredImage{}
+
+	for _, field := range fields {
+		image, ok := mirrored[field.source]
+		if !ok {
+			var err error
+
+			image, err = targetImage(target, field.source)
+			if err != nil {
+				return err
+			}
+
+			if dryRun {
+				_, _ = fmt.Fprintf(out, "%s -> %s\n", field, image)
+			} else {
+				// copy by tag if there is one, so the tag exists in the target registry as well
+				dst := image
+				dst.Digest = ""
+
+				if dst.Tag == "" {
+					dst = image
+				}
+
+				image.Digest, err = copier.CopyImage(ctx, field.source, dst.String())
+				if err != nil {
+					return errors.WithMessagef(err, "failed to mirror %s", field)
+				}
+
+				_, _ = fmt.Fprintf(out, "%s -> %s\n", field, image)
+			}
+
+			mirrored[field.source] = image
+		}
+
+		field.set(image)
+	}
+
+	return nil
+}
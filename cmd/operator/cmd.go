@@ -45,6 +45,13 @@ func run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	rateLimits, err := middleware.ParseRateLimits(k8senv.GetDTClientRateLimits())
+	if err != nil {
+		return err
+	}
+
+	middleware.SetRateLimits(rateLimits)
+
 	if system.IsRunLocally() {
 		log.Info("running locally in debug mode")
 
@@ -16,6 +16,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/edgeconnect"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/nodes"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/envvars"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/watchnamespaces"
 	"github.com/pkg/errors"
 	_ "k8s.io/client-go/plugin/pkg/client/auth" // important for running operator locally
 	"k8s.io/client-go/rest"
@@ -77,9 +78,7 @@ func createOptions(namespace string) ctrl.Options {
 			FieldOwner: "dynatrace-operator",
 		},
 		Cache: cache.Options{
-			DefaultNamespaces: map[string]cache.Config{
-				namespace: {},
-			},
+			DefaultNamespaces: watchnamespaces.CacheConfig(namespace),
 		},
 		Scheme: scheme.Scheme,
 		Metrics: server.Options{
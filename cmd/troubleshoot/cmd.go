@@ -14,7 +14,9 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8senv"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/oci/dockerkeychain"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/oci/registry"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/oci/workloadidentity"
 	"github.com/Dynatrace/dynatrace-operator/pkg/version"
+	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"k8s.io/client-go/rest"
@@ -129,11 +131,13 @@ func runChecksForDynakube(ctx context.Context, baseLog logd.Logger, apiReader cl
 
 	logOkf(log, "'%s:%s' Dynakube is valid", dk.Namespace, dk.Name)
 
-	keychain, err := dockerkeychain.NewDockerKeychain(ctx, apiReader, pullSecret)
+	dockerKeychain, err := dockerkeychain.NewDockerKeychain(ctx, apiReader, pullSecret)
 	if err != nil {
 		return err
 	}
 
+	keychain := authn.NewMultiKeychain(dockerKeychain, workloadidentity.NewKeychain(dk.Spec.WorkloadIdentityRegistries))
+
 	transport, err := createTransport(ctx, apiReader, &dk, httpClient)
 	if err != nil {
 		return err
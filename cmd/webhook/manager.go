@@ -9,6 +9,7 @@ import (
 	"strconv"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/watchnamespaces"
 	"github.com/pkg/errors"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -76,9 +77,7 @@ func createOptions(namespace string) ctrl.Options {
 		LivenessEndpointName:   livenessEndpointName,
 		HealthProbeBindAddress: healthProbeBindAddress,
 		Cache: cache.Options{
-			DefaultNamespaces: map[string]cache.Config{
-				namespace: {},
-			},
+			DefaultNamespaces: watchnamespaces.CacheConfig(namespace),
 		},
 		WebhookServer: webhook.NewServer(webhook.Options{
 			Port: port,
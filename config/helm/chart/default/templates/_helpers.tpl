@@ -203,3 +203,10 @@ topologySpreadConstraints:
   value: {{ . | quote }}
   {{- end }}
 {{- end -}}
+
+{{- define "dynatrace-operator.cluster-domain-env" -}}
+  {{- with .Values.clusterDomain }}
+- name: DT_CLUSTER_DOMAIN
+  value: {{ . | quote }}
+  {{- end }}
+{{- end -}}
@@ -0,0 +1,216 @@
+# Copyright Dynatrace LLC
+# SPDX-License-Identifier: Apache-2.0
+
+{{/*
+Rules the operator needs in every namespace it reconciles DynaKube and EdgeConnect objects in.
+Used by the Role in the release namespace and by the ClusterRole bound in the additional watch namespaces.
+*/}}
+{{- define "dynatrace-operator.operatorWatchNamespaceRules" -}}
+- apiGroups:
+    - dynatrace.com
+  resources:
+    - dynakubes
+    - edgeconnects
+  verbs:
+    - get
+    - list
+    - watch
+    - update
+- apiGroups:
+    - dynatrace.com
+  resources:
+    - dynakubes/finalizers
+    - edgeconnects/finalizers
+    - dynakubes/status
+    - edgeconnects/status
+  verbs:
+    - update
+- apiGroups:
+    - apps
+  resources:
+    - statefulsets
+    - daemonsets
+    - replicasets
+    - deployments
+  verbs:
+    - get
+    - list
+    - watch
+    - create
+    - update
+    - delete
+- apiGroups:
+    - apps
+  resources:
+    - deployments/finalizers
+  verbs:
+    - update
+- apiGroups:
+    - autoscaling
+  resources:
+    - horizontalpodautoscalers
+  verbs:
+    - get
+    - list
+    - watch
+    - create
+    - update
+    - delete
+- apiGroups:
+    - autoscaling.k8s.io
+  resources:
+    - verticalpodautoscalers
+  verbs:
+    - get
+    - list
+    - watch
+    - create
+    - update
+    - delete
+- apiGroups:
+    - policy
+  resources:
+    - poddisruptionbudgets
+  verbs:
+    - get
+    - list
+    - watch
+    - create
+    - update
+    - delete
+- apiGroups:
+    - networking.k8s.io
+  resources:
+    - networkpolicies
+  verbs:
+    - get
+    - list
+    - watch
+    - create
+    - update
+    - delete
+- apiGroups:
+    - ""
+  resources:
+    - configmaps
+    - secrets
+    - services
+  verbs:
+    - get
+    - list
+    - watch
+    - create
+    - update
+    - delete
+- apiGroups:
+    - ""
+  resources:
+    - pods
+  verbs:
+    - get
+    - list
+    - watch
+- apiGroups:
+    - ""
+  resources:
+    - events
+  verbs:
+    - create
+- apiGroups:
+    - events.k8s.io
+  resources:
+    - events
+  verbs:
+    - create
+    - patch
+    - get
+    - list
+- apiGroups:
+    - networking.istio.io
+  resources:
+    - serviceentries
+    - virtualservices
+    - destinationrules
+  verbs:
+    - get
+    - list
+    - create
+    - update
+    - delete
+- apiGroups:
+    - security.istio.io
+  resources:
+    - peerauthentications
+    - authorizationpolicies
+  verbs:
+    - get
+    - list
+    - create
+    - update
+    - delete
+{{- end -}}
+
+{{/*
+Additional namespaces in which custom resources are reconciled, "*" if all namespaces are watched. Empty if only the release namespace is watched.
+*/}}
+{{- define "dynatrace-operator.watchNamespaces" -}}
+{{- $namespaces := .Values.watchNamespaces | default list -}}
+{{- if has "*" $namespaces -}}
+*
+{{- else -}}
+{{- without ($namespaces | uniq) .Release.Namespace | join "," -}}
+{{- end -}}
+{{- end -}}
+
+{{- define "dynatrace-operator.watch-namespaces-env" -}}
+{{- $namespaces := include "dynatrace-operator.watchNamespaces" . -}}
+{{- if $namespaces }}
+- name: DT_WATCH_NAMESPACES
+  value: {{ ternary "*" (printf "%s,%s" .Release.Namespace $namespaces) (eq $namespaces "*") | quote }}
+{{- end }}
+{{- end -}}
+
+{{/*
+Binds the given ClusterRole to the given ServiceAccount in every additional watch namespace, or cluster wide if all namespaces are watched.
+Expects a dict with "context", "name", "serviceAccount" and "labels".
+*/}}
+{{- define "dynatrace-operator.watchNamespaceBindings" -}}
+{{- $ctx := .context -}}
+{{- $namespaces := include "dynatrace-operator.watchNamespaces" $ctx -}}
+{{- if eq $namespaces "*" }}
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: {{ .name }}
+  labels:
+    {{- .labels | nindent 4 }}
+subjects:
+  - kind: ServiceAccount
+    name: {{ .serviceAccount }}
+    namespace: {{ $ctx.Release.Namespace }}
+roleRef:
+  kind: ClusterRole
+  name: {{ .name }}
+  apiGroup: rbac.authorization.k8s.io
+{{- else }}
+{{- range $namespace := splitList "," $namespaces }}
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  name: {{ $.name }}
+  namespace: {{ $namespace }}
+  labels:
+    {{- $.labels | nindent 4 }}
+subjects:
+  - kind: ServiceAccount
+    name: {{ $.serviceAccount }}
+    namespace: {{ $ctx.Release.Namespace }}
+roleRef:
+  kind: ClusterRole
+  name: {{ $.name }}
+  apiGroup: rbac.authorization.k8s.io
+{{- end }}
+{{- end }}
+{{- end -}}
@@ -19,3 +19,13 @@ Validate if the required related RBACs were enabled for kspm
 {{- fail "rbac.kubernetesMonitoring.create = true is required to enable rbac.kspm.create"}}
 {{- end }}
 {{- end -}}
+
+
+{{/*
+Validate that the egress to the Kubernetes API can be restricted by the webhook NetworkPolicy
+*/}}
+{{- define "validation.webhook.networkPolicy" -}}
+{{- if not .Values.webhook.networkPolicy.kubernetesApiCidrs }}
+{{- fail "webhook.networkPolicy.kubernetesApiCidrs is required to enable webhook.networkPolicy.enabled"}}
+{{- end }}
+{{- end -}}
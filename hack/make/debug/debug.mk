@@ -4,6 +4,10 @@ debug/operator:
 	kubectl -n dynatrace scale --replicas=0 deployment/dynatrace-operator
 	POD_NAMESPACE=dynatrace RUN_LOCAL=true go run ./cmd operator
 
+## Run a fake Dynatrace tenant locally, point the apiUrl of the DynaKube to http://<your-ip>:8080/api
+debug/fake-tenant:
+	go run ./cmd fake-tenant --address=:8080
+
 ## Run the webhook locally (requires running telepresence)
 debug/webhook:
 	env $$(cat local/telepresence.env | xargs) go run ./cmd webhook-server --certs-dir=./local/certs/
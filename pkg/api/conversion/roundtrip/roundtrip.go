@@ -0,0 +1,283 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+// Package roundtrip checks whether an object survives the conversion into the older API versions of its CRD.
+// The hub (latest) version is converted into every spoke version and back, any field that differs afterward would be lost
+// if the object was written by a client that uses the older version.
+package roundtrip
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/conversion"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlconversion "sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// Diff is a field that doesn't survive the round trip through a spoke version.
+type Diff struct {
+	// Version is the spoke version the object was converted to.
+	Version string `json:"version"`
+	// Path of the field, e.g. spec.oneAgent.cloudNativeFullStack.nodePools[0].
+	Path string `json:"path"`
+	// Original is the JSON encoded value before the conversion.
+	Original string `json:"original"`
+	// RoundTripped is the JSON encoded value after the conversion, empty if the field was lost.
+	RoundTripped string `json:"roundTripped,omitempty"`
+}
+
+func (d Diff) String() string {
+	if d.RoundTripped == "" {
+		return fmt.Sprintf("%s is lost in %s", d.Path, d.Version)
+	}
+
+	return fmt.Sprintf("%s is changed in %s: %s -> %s", d.Path, d.Version, d.Original, d.RoundTripped)
+}
+
+// Check converts the hub object into every spoke version that is registered in the scheme and back.
+// Only fields that are lost or changed are reported, fields that are added by the conversion (e.g. defaults of the older version) are not.
+// The status and all metadata except for labels and annotations are ignored, as well as the annotations used to stash removed fields.
+func Check(hub ctrlconversion.Hub) ([]Diff, error) {
+	hubGVK, err := getGVK(hub)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := toComparable(hub)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []Diff
+
+	for _, spokeGVK := range spokeKinds(hubGVK.GroupKind()) {
+		spoke, err := newObject[ctrlconversion.Convertible](spokeGVK)
+		if err != nil {
+			return nil, err
+		}
+
+		roundTripped, err := newObject[ctrlconversion.Hub](hubGVK)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := spoke.ConvertFrom(hub.DeepCopyObject().(ctrlconversion.Hub)); err != nil {
+			return nil, errors.WithMessagef(err, "failed to convert to %s", spokeGVK.Version)
+		}
+
+		if err := spoke.ConvertTo(roundTripped); err != nil {
+			return nil, errors.WithMessagef(err, "failed to convert from %s", spokeGVK.Version)
+		}
+
+		result, err := toComparable(roundTripped)
+		if err != nil {
+			return nil, err
+		}
+
+		diffs = append(diffs, compare(spokeGVK.Version, "", original, result)...)
+	}
+
+	return diffs, nil
+}
+
+// ToHub converts an object of any served version into the hub version of its kind.
+// Objects whose kind has no conversion are returned as nil without an error.
+func ToHub(obj runtime.Object) (ctrlconversion.Hub, error) {
+	switch typed := obj.(type) {
+	case ctrlconversion.Hub:
+		return typed, nil
+	case ctrlconversion.Convertible:
+		gvk, err := getGVK(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		hubGVK, ok := hubKind(gvk.GroupKind())
+		if !ok {
+			return nil, errors.Errorf("no hub version found for %s", gvk.GroupKind())
+		}
+
+		hub, err := newObject[ctrlconversion.Hub](hubGVK)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := typed.ConvertTo(hub); err != nil {
+			return nil, errors.WithMessagef(err, "failed to convert %s to %s", gvk.Version, hubGVK.Version)
+		}
+
+		return hub, nil
+	}
+
+	return nil, nil
+}
+
+func getGVK(obj runtime.Object) (schema.GroupVersionKind, error) {
+	gvks, _, err := scheme.Scheme.ObjectKinds(obj)
+	if err != nil {
+		return schema.GroupVersionKind{}, errors.WithStack(err)
+	}
+
+	return gvks[0], nil
+}
+
+func newObject[T runtime.Object](gvk schema.GroupVersionKind) (T, error) {
+	var typed T
+
+	obj, err := scheme.Scheme.New(gvk)
+	if err != nil {
+		return typed, errors.WithStack(err)
+	}
+
+	typed, ok := obj.(T)
+	if !ok {
+		return typed, errors.Errorf("%s doesn't support conversion", gvk)
+	}
+
+	return typed, nil
+}
+
+// spokeKinds returns all versions of the kind that can be converted to the hub, sorted for a stable output.
+func spokeKinds(groupKind schema.GroupKind) []schema.GroupVersionKind {
+	var spokes []schema.GroupVersionKind
+
+	for _, gv := range scheme.Scheme.VersionsForGroupKind(groupKind) {
+		gvk := gv.WithKind(groupKind.Kind)
+		if _, err := newObject[ctrlconversion.Convertible](gvk); err == nil {
+			spokes = append(spokes, gvk)
+		}
+	}
+
+	slices.SortFunc(spokes, func(a, b schema.GroupVersionKind) int {
+		return strings.Compare(a.Version, b.Version)
+	})
+
+	return spokes
+}
+
+func hubKind(groupKind schema.GroupKind) (schema.GroupVersionKind, bool) {
+	for _, gv := range scheme.Scheme.VersionsForGroupKind(groupKind) {
+		gvk := gv.WithKind(groupKind.Kind)
+		if _, err := newObject[ctrlconversion.Hub](gvk); err == nil {
+			return gvk, true
+		}
+	}
+
+	return schema.GroupVersionKind{}, false
+}
+
+func toComparable(obj runtime.Object) (map[string]any, error) {
+	// JSON is used instead of the unstructured converter, as it only takes the serialized fields into account
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var content map[string]any
+	if err := json.Unmarshal(raw, &content); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result := map[string]any{}
+	if spec, ok := content["spec"]; ok {
+		result["spec"] = spec
+	}
+
+	metadata, _ := content["metadata"].(map[string]any)
+	if labels, ok := metadata["labels"]; ok {
+		result["labels"] = labels
+	}
+
+	if annotations, ok := metadata["annotations"].(map[string]any); ok {
+		annotations = maps.Clone(annotations)
+		maps.DeleteFunc(annotations, func(key string, _ any) bool {
+			return strings.HasPrefix(key, conversion.Prefix)
+		})
+
+		if len(annotations) > 0 {
+			result["annotations"] = annotations
+		}
+	}
+
+	return result, nil
+}
+
+func compare(version, path string, original, roundTripped any) []Diff {
+	switch originalValue := original.(type) {
+	case map[string]any:
+		roundTrippedValue, ok := roundTripped.(map[string]any)
+		if !ok {
+			break
+		}
+
+		var diffs []Diff
+
+		keys := slices.Concat(slices.Collect(maps.Keys(originalValue)), slices.Collect(maps.Keys(roundTrippedValue)))
+		slices.Sort(keys)
+
+		for _, key := range slices.Compact(keys) {
+			diffs = append(diffs, compare(version, joinPath(path, key), originalValue[key], roundTrippedValue[key])...)
+		}
+
+		return diffs
+	case []any:
+		roundTrippedValue, ok := roundTripped.([]any)
+		if !ok {
+			break
+		}
+
+		var diffs []Diff
+
+		for i := range max(len(originalValue), len(roundTrippedValue)) {
+			var originalItem, roundTrippedItem any
+			if i < len(originalValue) {
+				originalItem = originalValue[i]
+			}
+
+			if i < len(roundTrippedValue) {
+				roundTrippedItem = roundTrippedValue[i]
+			}
+
+			diffs = append(diffs, compare(version, path+"["+strconv.Itoa(i)+"]", originalItem, roundTrippedItem)...)
+		}
+
+		return diffs
+	}
+
+	originalJSON, roundTrippedJSON := encode(original), encode(roundTripped)
+	if originalJSON == "" || originalJSON == roundTrippedJSON {
+		return nil
+	}
+
+	return []Diff{{Version: version, Path: path, Original: originalJSON, RoundTripped: roundTrippedJSON}}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	if strings.ContainsAny(key, "./") {
+		return path + "[" + strconv.Quote(key) + "]"
+	}
+
+	return path + "." + key
+}
+
+func encode(value any) string {
+	if value == nil {
+		return ""
+	}
+
+	raw, _ := json.Marshal(value)
+
+	return string(raw)
+}
@@ -0,0 +1,135 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package roundtrip
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/exp"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
+	edgeconnectv1alpha1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha1/edgeconnect"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
+	dynakubev1beta5 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta5/dynakube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestDynakube() *dynakube.DynaKube {
+	return &dynakube.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "dynakube",
+			Namespace:   "dynatrace",
+			Annotations: map[string]string{exp.NoProxyKey: "localhost"},
+			Labels:      map[string]string{"app": "test"},
+		},
+		Spec: dynakube.DynaKubeSpec{
+			APIURL: "https://test.dev.dynatracelabs.com/api",
+			OneAgent: oneagent.Spec{
+				CloudNativeFullStack: &oneagent.CloudNativeFullStackSpec{},
+			},
+			ActiveGate: activegate.Spec{
+				Capabilities: []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName},
+			},
+		},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	t.Run("no diff for fields that exist in all versions", func(t *testing.T) {
+		diffs, err := Check(newTestDynakube())
+
+		require.NoError(t, err)
+		assert.Empty(t, diffs)
+	})
+
+	t.Run("status is ignored", func(t *testing.T) {
+		dk := newTestDynakube()
+		dk.Status.Components = map[dynakube.ComponentName]dynakube.ComponentStatus{dynakube.OneAgentComponent: {}}
+
+		diffs, err := Check(dk)
+
+		require.NoError(t, err)
+		assert.Empty(t, diffs)
+	})
+
+	t.Run("fields that only exist in the hub are reported", func(t *testing.T) {
+		dk := newTestDynakube()
+		dk.Spec.OneAgent.CloudNativeFullStack.NodePools = []oneagent.NodePoolSpec{
+			{Name: "gpu", NodeSelector: map[string]string{"gpu": "true"}},
+		}
+		dk.Spec.Remediation = &dynakube.RemediationSpec{
+			MaxMemoryLimit: resource.MustParse("2Gi"),
+			Components:     []dynakube.ComponentName{dynakube.ActiveGateComponent},
+		}
+
+		diffs, err := Check(dk)
+
+		require.NoError(t, err)
+		require.Len(t, diffs, 2)
+
+		assert.Equal(t, "v1beta5", diffs[0].Version)
+		assert.Equal(t, "spec.oneAgent.cloudNativeFullStack.nodePools", diffs[0].Path)
+		assert.JSONEq(t, `[{"name":"gpu","nodeSelector":{"gpu":"true"}}]`, diffs[0].Original)
+		assert.Empty(t, diffs[0].RoundTripped)
+		assert.Equal(t, "spec.oneAgent.cloudNativeFullStack.nodePools is lost in v1beta5", diffs[0].String())
+
+		assert.Equal(t, "spec.remediation", diffs[1].Path)
+	})
+
+	t.Run("edgeconnect", func(t *testing.T) {
+		ec := &edgeconnect.EdgeConnect{
+			ObjectMeta: metav1.ObjectMeta{Name: "edgeconnect", Namespace: "dynatrace"},
+			Spec: edgeconnect.EdgeConnectSpec{
+				APIServer: "test.dev.apps.dynatracelabs.com",
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+				},
+			},
+		}
+
+		diffs, err := Check(ec)
+
+		require.NoError(t, err)
+		assert.Empty(t, diffs)
+	})
+}
+
+func TestToHub(t *testing.T) {
+	t.Run("hub is returned as is", func(t *testing.T) {
+		dk := newTestDynakube()
+
+		hub, err := ToHub(dk)
+
+		require.NoError(t, err)
+		assert.Same(t, dk, hub)
+	})
+
+	t.Run("spoke is converted", func(t *testing.T) {
+		dk := &dynakubev1beta5.DynaKube{ObjectMeta: metav1.ObjectMeta{Name: "dynakube"}}
+		dk.Spec.APIURL = "https://test.dev.dynatracelabs.com/api"
+
+		hub, err := ToHub(dk)
+
+		require.NoError(t, err)
+		require.IsType(t, &dynakube.DynaKube{}, hub)
+		assert.Equal(t, dk.Spec.APIURL, hub.(*dynakube.DynaKube).Spec.APIURL)
+
+		ec, err := ToHub(&edgeconnectv1alpha1.EdgeConnect{})
+
+		require.NoError(t, err)
+		assert.IsType(t, &edgeconnect.EdgeConnect{}, ec)
+	})
+
+	t.Run("other objects are ignored", func(t *testing.T) {
+		hub, err := ToHub(&corev1.ConfigMap{})
+
+		require.NoError(t, err)
+		assert.Nil(t, hub)
+	})
+}
@@ -13,6 +13,10 @@ const (
 
 	UseEECLegacyMountsKey = FFPrefix + "use-eec-legacy-mounts"
 	UsePublicRegistryKey  = FFPrefix + "use-public-registry"
+	ConversionCheckKey    = FFPrefix + "conversion-check"
+
+	ConversionCheckWarn   = "warn"
+	ConversionCheckReject = "reject"
 
 	silentPhrase = "silent"
 	failPhrase   = "fail"
@@ -49,6 +53,17 @@ func (ff *FeatureFlags) IsPublicRegistry() bool {
 	return ff.hasPlatformToken || ff.getBoolWithDefault(UsePublicRegistryKey, false)
 }
 
+// GetConversionCheck is a feature flag to let the validation webhook warn about (warn) or reject (reject) fields that would be lost in older API versions.
+// Any other value disables the check.
+func (ff *FeatureFlags) GetConversionCheck() string {
+	switch raw := ff.getRaw(ConversionCheckKey); raw {
+	case ConversionCheckWarn, ConversionCheckReject:
+		return raw
+	default:
+		return ""
+	}
+}
+
 // Deprecated: Do not use "disable" feature flags.
 func (ff *FeatureFlags) getDisableFlagWithDeprecatedAnnotation(annotation string, deprecatedAnnotation string) bool {
 	if ff.getRaw(annotation) != "" {
@@ -31,6 +31,13 @@ func TestIsPublicRegistry(t *testing.T) {
 	})
 }
 
+func TestGetConversionCheck(t *testing.T) {
+	assert.Empty(t, NewFlags(nil, false).GetConversionCheck())
+	assert.Empty(t, NewFlags(map[string]string{ConversionCheckKey: "true"}, false).GetConversionCheck())
+	assert.Equal(t, ConversionCheckWarn, NewFlags(map[string]string{ConversionCheckKey: ConversionCheckWarn}, false).GetConversionCheck())
+	assert.Equal(t, ConversionCheckReject, NewFlags(map[string]string{ConversionCheckKey: ConversionCheckReject}, false).GetConversionCheck())
+}
+
 func TestGetNoProxy(t *testing.T) {
 	type testCase struct {
 		title string
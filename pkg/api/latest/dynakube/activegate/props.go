@@ -10,6 +10,7 @@ import (
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/dtversion"
+	"k8s.io/utils/ptr"
 )
 
 const (
@@ -19,6 +20,8 @@ const (
 	DeploymentPropertiesConfigMapSuffix = "-activegate-deployment-properties"
 	AuthTokenSecretSuffix               = "-activegate-authtoken-secret"
 	DefaultImageRegistrySubPath         = "/linux/activegate"
+
+	defaultSyntheticEngineRepository = "public.ecr.aws/dynatrace/dynatrace-synthetic"
 )
 
 func (ag *Spec) SetAPIURL(apiURL string) {
@@ -47,7 +50,7 @@ func (ag *Spec) apiURLHost() string {
 
 // IsEnabled returns true when a feature requires ActiveGate instances.
 func (ag *Spec) IsEnabled() bool {
-	return len(ag.Capabilities) > 0 || ag.enabledDependencies.Any()
+	return len(ag.Capabilities) > 0 || len(ag.Groups) > 0 || ag.enabledDependencies.Any()
 }
 
 func (ag *Spec) IsMode(mode CapabilityDisplayName) bool {
@@ -122,6 +125,16 @@ func (ag *Spec) GetTLSSecretName() string {
 	return ""
 }
 
+// GetGroupTLSSecretName returns the name of the TLS secret of the ActiveGate group, which defaults to the AG TLS secret.
+// An empty group name refers to the ActiveGate configured in the spec itself.
+func (ag *Spec) GetGroupTLSSecretName(groupName string) string {
+	if group := ag.GetGroup(groupName); group != nil && group.TLSSecretName != "" {
+		return group.TLSSecretName
+	}
+
+	return ag.GetTLSSecretName()
+}
+
 // GetAutoTLSSecretName returns the name of the automatically created AG TLS secret.
 func (ag *Spec) GetAutoTLSSecretName() string {
 	return ag.name + TLSSecretSuffix
@@ -160,3 +173,97 @@ func (ag *Spec) GetCustomImage() string {
 
 // GetTerminationGracePeriodSeconds provides the configured value for the terminatGracePeriodSeconds parameter of the pod.
 func (ag *Spec) GetTerminationGracePeriodSeconds() *int64 { return ag.TerminationGracePeriodSeconds }
+
+// GetGroup returns the ActiveGate group with the given name, or nil if there is none.
+func (ag *Spec) GetGroup(name string) *GroupSpec {
+	if name == "" {
+		return nil
+	}
+
+	for i := range ag.Groups {
+		if ag.Groups[i].Name == name {
+			return &ag.Groups[i]
+		}
+	}
+
+	return nil
+}
+
+func (group *GroupSpec) IsMode(mode CapabilityDisplayName) bool {
+	return slices.Contains(group.Capabilities, mode)
+}
+
+func (group *GroupSpec) IsRoutingEnabled() bool {
+	return group.IsMode(RoutingCapability.DisplayName)
+}
+
+// IsGroupMode returns true if the capability is enabled for the ActiveGate group.
+// An empty group name refers to the ActiveGate configured in the spec itself.
+func (ag *Spec) IsGroupMode(groupName string, mode CapabilityDisplayName) bool {
+	if groupName == "" {
+		return ag.IsMode(mode)
+	}
+
+	group := ag.GetGroup(groupName)
+
+	return group != nil && group.IsMode(mode)
+}
+
+// IsSyntheticEnabled returns true if the ActiveGate or one of its groups has the synthetic capability.
+func (ag *Spec) IsSyntheticEnabled() bool {
+	_, ok := ag.GetSyntheticGroupName()
+
+	return ok
+}
+
+// GetSyntheticGroupName returns the name of the ActiveGate group with the synthetic capability.
+// An empty group name refers to the ActiveGate configured in the spec itself.
+func (ag *Spec) GetSyntheticGroupName() (string, bool) {
+	if ag.IsMode(SyntheticCapability.DisplayName) {
+		return "", true
+	}
+
+	for _, group := range ag.Groups {
+		if group.IsMode(SyntheticCapability.DisplayName) {
+			return group.Name, true
+		}
+	}
+
+	return "", false
+}
+
+// GetSyntheticLocationName returns the name of the private Synthetic location, which defaults to the name of the DynaKube.
+func (ag *Spec) GetSyntheticLocationName() string {
+	if ag.Synthetic != nil && ag.Synthetic.LocationName != "" {
+		return ag.Synthetic.LocationName
+	}
+
+	return ag.name
+}
+
+// GetSyntheticLoadProfile returns the load profile of the synthetic-enabled ActiveGates, which defaults to S.
+func (ag *Spec) GetSyntheticLoadProfile() SyntheticLoadProfile {
+	if ag.Synthetic != nil && ag.Synthetic.LoadProfile != "" {
+		return ag.Synthetic.LoadProfile
+	}
+
+	return SyntheticLoadProfileS
+}
+
+// GetSyntheticMonitorExecutionsPerMinute returns the expected number of monitor executions per minute on the location.
+func (ag *Spec) GetSyntheticMonitorExecutionsPerMinute() int32 {
+	if ag.Synthetic == nil {
+		return 0
+	}
+
+	return ptr.Deref(ag.Synthetic.MonitorExecutionsPerMinute, 0)
+}
+
+// GetSyntheticEngineImage returns the image of the Synthetic browser engine, the public image is used if no image is set.
+func (ag *Spec) GetSyntheticEngineImage() string {
+	if ag.Synthetic == nil {
+		return defaultSyntheticEngineRepository + ":" + api.LatestTag
+	}
+
+	return ag.Synthetic.ImageRef.StringWithDefaults(defaultSyntheticEngineRepository, api.LatestTag)
+}
@@ -6,6 +6,7 @@ package activegate
 import (
 	"testing"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/image"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -55,3 +56,90 @@ func TestSpec_IsModeHelpers(t *testing.T) {
 		})
 	}
 }
+
+func TestSpec_Groups(t *testing.T) {
+	ag := &Spec{
+		TLSSecretName: "ag-tls",
+		Groups: []GroupSpec{
+			{Name: "routing", Capabilities: []CapabilityDisplayName{RoutingCapability.DisplayName}},
+			{Name: "api", Capabilities: []CapabilityDisplayName{DynatraceAPICapability.DisplayName}, TLSSecretName: "api-tls"},
+		},
+	}
+
+	t.Run("groups enable the ActiveGate", func(t *testing.T) {
+		assert.True(t, ag.IsEnabled())
+		assert.False(t, (&Spec{}).IsEnabled())
+	})
+
+	t.Run("get group by name", func(t *testing.T) {
+		assert.Equal(t, "api", ag.GetGroup("api").Name)
+		assert.Nil(t, ag.GetGroup("unknown"))
+		assert.Nil(t, ag.GetGroup(""))
+	})
+
+	t.Run("group TLS secret defaults to the ActiveGate TLS secret", func(t *testing.T) {
+		assert.Equal(t, "ag-tls", ag.GetGroupTLSSecretName(""))
+		assert.Equal(t, "ag-tls", ag.GetGroupTLSSecretName("routing"))
+		assert.Equal(t, "api-tls", ag.GetGroupTLSSecretName("api"))
+	})
+
+	t.Run("group capabilities", func(t *testing.T) {
+		assert.True(t, ag.GetGroup("routing").IsRoutingEnabled())
+		assert.False(t, ag.GetGroup("api").IsRoutingEnabled())
+		assert.False(t, ag.IsRoutingEnabled())
+	})
+}
+
+func TestSpec_Synthetic(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		ag := &Spec{Capabilities: []CapabilityDisplayName{RoutingCapability.DisplayName}}
+
+		assert.False(t, ag.IsSyntheticEnabled())
+		assert.False(t, ag.IsGroupMode("", SyntheticCapability.DisplayName))
+	})
+
+	t.Run("enabled in group", func(t *testing.T) {
+		ag := &Spec{
+			Groups: []GroupSpec{
+				{Name: "routing", Capabilities: []CapabilityDisplayName{RoutingCapability.DisplayName}},
+				{Name: "synthetic", Capabilities: []CapabilityDisplayName{SyntheticCapability.DisplayName}},
+			},
+		}
+
+		groupName, ok := ag.GetSyntheticGroupName()
+		assert.True(t, ok)
+		assert.Equal(t, "synthetic", groupName)
+		assert.True(t, ag.IsGroupMode("synthetic", SyntheticCapability.DisplayName))
+		assert.False(t, ag.IsGroupMode("routing", SyntheticCapability.DisplayName))
+		assert.False(t, ag.IsGroupMode("unknown", SyntheticCapability.DisplayName))
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		ag := &Spec{Capabilities: []CapabilityDisplayName{SyntheticCapability.DisplayName}}
+		ag.SetName("dynakube")
+
+		assert.True(t, ag.IsSyntheticEnabled())
+		assert.Equal(t, "dynakube", ag.GetSyntheticLocationName())
+		assert.Equal(t, SyntheticLoadProfileS, ag.GetSyntheticLoadProfile())
+		assert.Equal(t, int32(0), ag.GetSyntheticMonitorExecutionsPerMinute())
+		assert.Equal(t, defaultSyntheticEngineRepository+":latest", ag.GetSyntheticEngineImage())
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		executions := int32(120)
+		ag := &Spec{
+			Capabilities: []CapabilityDisplayName{SyntheticCapability.DisplayName},
+			Synthetic: &SyntheticSpec{
+				LocationName:               "in-cluster",
+				LoadProfile:                SyntheticLoadProfileM,
+				MonitorExecutionsPerMinute: &executions,
+				ImageRef:                   image.Ref{Repository: "registry/synthetic", Tag: "1.2.3"},
+			},
+		}
+
+		assert.Equal(t, "in-cluster", ag.GetSyntheticLocationName())
+		assert.Equal(t, SyntheticLoadProfileM, ag.GetSyntheticLoadProfile())
+		assert.Equal(t, int32(120), ag.GetSyntheticMonitorExecutionsPerMinute())
+		assert.Equal(t, "registry/synthetic:1.2.3", ag.GetSyntheticEngineImage())
+	})
+}
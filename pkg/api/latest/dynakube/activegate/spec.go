@@ -4,6 +4,8 @@
 package activegate
 
 import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/image"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/podtemplate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/value"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -44,6 +46,11 @@ var (
 		DisplayName:  "debugging",
 		ArgumentName: "debugging",
 	}
+
+	SyntheticCapability = Capability{
+		DisplayName:  "synthetic",
+		ArgumentName: "synthetic,beacon_forwarder,beacon_forwarder_synthetic",
+	}
 )
 
 var CapabilityDisplayNames = map[CapabilityDisplayName]struct{}{
@@ -52,8 +59,17 @@ var CapabilityDisplayNames = map[CapabilityDisplayName]struct{}{
 	MetricsIngestCapability.DisplayName: {},
 	DynatraceAPICapability.DisplayName:  {},
 	DebuggingCapability.DisplayName:     {},
+	SyntheticCapability.DisplayName:     {},
 }
 
+type SyntheticLoadProfile string
+
+const (
+	SyntheticLoadProfileXS SyntheticLoadProfile = "XS"
+	SyntheticLoadProfileS  SyntheticLoadProfile = "S"
+	SyntheticLoadProfileM  SyntheticLoadProfile = "M"
+)
+
 type ActiveGate struct {
 	*Spec
 	*Status
@@ -108,7 +124,7 @@ type Spec struct {
 
 	CapabilityProperties `json:",inline"`
 
-	// Activegate capabilities enabled (routing, kubernetes-monitoring, metrics-ingest, dynatrace-api)
+	// Activegate capabilities enabled (routing, kubernetes-monitoring, metrics-ingest, dynatrace-api, synthetic)
 	// +listType=set
 	Capabilities []CapabilityDisplayName `json:"capabilities,omitempty"`
 
@@ -118,6 +134,102 @@ type Spec struct {
 
 	// UseEphemeralVolume
 	UseEphemeralVolume *bool `json:"useEphemeralVolume,omitempty"`
+
+	// ActiveGate groups that are deployed next to the ActiveGate configured above, each as a separate StatefulSet and Service.
+	// Settings that are not set for a group are taken from the ActiveGate configured above.
+	// Kubernetes monitoring, extensions, KSPM and telemetry ingest are only served by the ActiveGate configured above.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=10
+	// +listType=map
+	// +listMapKey=name
+	Groups []GroupSpec `json:"groups,omitempty"`
+
+	// Configuration of the private Synthetic location served by the ActiveGate (or group) with the synthetic capability.
+	// +kubebuilder:validation:Optional
+	Synthetic *SyntheticSpec `json:"synthetic,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+type SyntheticSpec struct {
+	// Name of the private Synthetic location, defaults to the name of the DynaKube.
+	// +kubebuilder:validation:Optional
+	LocationName string `json:"locationName,omitempty"`
+
+	// Size of the synthetic-enabled ActiveGates, defines the default resources and the monitor executions a single replica can handle.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=XS;S;M
+	LoadProfile SyntheticLoadProfile `json:"loadProfile,omitempty"`
+
+	// Number of monitor executions per minute expected on the location, used to recommend the number of replicas.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	MonitorExecutionsPerMinute *int32 `json:"monitorExecutionsPerMinute,omitempty"`
+
+	// Overrides the default image of the Synthetic browser engine.
+	// +kubebuilder:validation:Optional
+	ImageRef image.Ref `json:"imageRef,omitzero"`
+
+	// Define resources requests and limits for the Synthetic browser engine container, defaults depend on the load profile.
+	// +kubebuilder:validation:Optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+type GroupSpec struct {
+	// Name of the group, it is appended to the names of the StatefulSet and Service of the group.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// ActiveGate capabilities enabled for the group (routing, metrics-ingest, dynatrace-api, debugging, synthetic)
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	Capabilities []CapabilityDisplayName `json:"capabilities"`
+
+	// Amount of replicas for the ActiveGates of the group
+	// +kubebuilder:validation:Optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Define resources requests and limits for single ActiveGate pods of the group, replaces resources.
+	// +kubebuilder:validation:Optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Node selector for the ActiveGate pods of the group, replaces nodeSelector.
+	// +kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Set tolerations for the ActiveGate pods of the group, replaces tolerations.
+	// +kubebuilder:validation:Optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Adds TopologySpreadConstraints for the ActiveGate pods of the group, replaces topologySpreadConstraints.
+	// +kubebuilder:validation:Optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// Pins the ActiveGates of the group to a topology zone listed in spec.topologyZones, they use the network zone of the topology zone.
+	// +kubebuilder:validation:Optional
+	TopologyZone string `json:"topologyZone,omitempty"`
+
+	// Set activation group for the ActiveGates of the group, replaces group.
+	// +kubebuilder:validation:Optional
+	Group string `json:"group,omitempty"`
+
+	// The name of a secret containing the TLS cert+key and password for the ActiveGates of the group, replaces tlsSecretName.
+	// +kubebuilder:validation:Optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+
+	// Add a custom properties file for the ActiveGates of the group, replaces customProperties.
+	// If referenced from a secret, make sure the key is called 'customProperties'
+	// +kubebuilder:validation:Optional
+	CustomProperties *value.Source `json:"customProperties,omitempty"`
+
+	// Patch applied to the pod template of the ActiveGates of the group, replaces podTemplatePatch.
+	// +kubebuilder:validation:Optional
+	PodTemplatePatch *podtemplate.Patch `json:"podTemplatePatch,omitempty"`
 }
 
 // +kubebuilder:object:generate=true
@@ -195,4 +307,8 @@ type CapabilityProperties struct {
 	// Adds additional VolumeMounts to the ActiveGate container
 	// +kubebuilder:validation:Optional
 	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+
+	// Patch applied to the pod template after the operator has built it, e.g. to add a sidecar, host aliases or a runtime class.
+	// +kubebuilder:validation:Optional
+	PodTemplatePatch *podtemplate.Patch `json:"podTemplatePatch,omitempty"`
 }
@@ -19,6 +19,43 @@ type Status struct {
 
 	// The ClusterIPs set by Kubernetes on the ActiveGate Service created by the Operator
 	ServiceIPs []string `json:"serviceIPs,omitempty"`
+
+	// Status of the StatefulSets of the configured ActiveGate groups
+	// +kubebuilder:validation:Optional
+	Groups map[string]GroupStatus `json:"groups,omitempty"`
+
+	// Status of the private Synthetic location
+	// +kubebuilder:validation:Optional
+	Synthetic *SyntheticStatus `json:"synthetic,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+type SyntheticStatus struct {
+	// Name of the registered private Synthetic location
+	LocationName string `json:"locationName,omitempty"`
+
+	// ID of the settings object of the private Synthetic location
+	LocationID string `json:"locationId,omitempty"`
+
+	// Number of replicas recommended for the configured monitor load
+	RecommendedReplicas int32 `json:"recommendedReplicas,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+type GroupStatus struct {
+	// Name of the StatefulSet of the group
+	StatefulSetName string `json:"statefulSetName"`
+
+	// The ClusterIPs set by Kubernetes on the Service of the group
+	ServiceIPs []string `json:"serviceIPs,omitempty"`
+
+	// Number of ActiveGate pods of the group
+	Replicas int32 `json:"replicas"`
+
+	// Number of ready ActiveGate pods of the group
+	ReadyReplicas int32 `json:"readyReplicas"`
 }
 
 // IsZero reports whether every field is zero. It is required for the `omitzero`
@@ -28,7 +65,9 @@ type Status struct {
 func (ag *Status) IsZero() bool {
 	return ag.VersionStatus.IsZero() &&
 		ag.ConnectionInfo == communication.ConnectionInfo{} &&
-		len(ag.ServiceIPs) == 0
+		len(ag.ServiceIPs) == 0 &&
+		len(ag.Groups) == 0 &&
+		ag.Synthetic == nil
 }
 
 // GetImage provides the image reference set in Status for the ActiveGate.
@@ -0,0 +1,123 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package dynakube
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:Enum=activeGate;kubernetesMonitoring;extensionExecutionController;extensionDatabases;oneAgent;logMonitoring;kspm;otelCollector
+type ComponentName string
+
+const (
+	ActiveGateComponent                   ComponentName = "activeGate"
+	KubernetesMonitoringComponent         ComponentName = "kubernetesMonitoring"
+	ExtensionExecutionControllerComponent ComponentName = "extensionExecutionController"
+	ExtensionDatabasesComponent           ComponentName = "extensionDatabases"
+	OneAgentComponent                     ComponentName = "oneAgent"
+	LogMonitoringComponent                ComponentName = "logMonitoring"
+	KSPMComponent                         ComponentName = "kspm"
+	OTelCollectorComponent                ComponentName = "otelCollector"
+)
+
+type PhasePolicy struct {
+	// Components that don't affect the phase of the DynaKube, their state is still reported in status.components.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	OptionalComponents []ComponentName `json:"optionalComponents,omitempty"`
+}
+
+type ComponentStatus struct {
+	// Phase of the component
+	Phase status.DeploymentPhase `json:"phase"`
+
+	// Image of the first container of the component
+	// +kubebuilder:validation:Optional
+	Image string `json:"image,omitempty"`
+
+	// Version of the component, taken from its version label
+	// +kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
+
+	// Error that occurred while reconciling the component or determining its state, it is cleared by the next successful reconcile
+	// +kubebuilder:validation:Optional
+	LastError string `json:"lastError,omitempty"`
+
+	// Time of the last change of the phase
+	// +kubebuilder:validation:Optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitzero"`
+
+	// Number of ready pods
+	ReadyReplicas int32 `json:"readyReplicas"`
+
+	// Number of desired pods
+	DesiredReplicas int32 `json:"desiredReplicas"`
+}
+
+// IsOptionalComponent checks if the phase of the component is ignored for the phase of the DynaKube.
+func (dk *DynaKube) IsOptionalComponent(name ComponentName) bool {
+	return dk.Spec.PhasePolicy != nil && slices.Contains(dk.Spec.PhasePolicy.OptionalComponents, name)
+}
+
+// SetComponentStatus stores the state of a component, the transition time is only updated if the phase changed.
+func (dk *DynaKubeStatus) SetComponentStatus(name ComponentName, componentStatus ComponentStatus, now metav1.Time) {
+	if dk.Components == nil {
+		dk.Components = map[ComponentName]ComponentStatus{}
+	}
+
+	componentStatus.LastTransitionTime = now
+	if previous, ok := dk.Components[name]; ok && previous.Phase == componentStatus.Phase && !previous.LastTransitionTime.IsZero() {
+		componentStatus.LastTransitionTime = previous.LastTransitionTime
+	}
+
+	dk.Components[name] = componentStatus
+}
+
+// RemoveComponentStatus removes the state of a disabled component.
+func (dk *DynaKubeStatus) RemoveComponentStatus(name ComponentName) {
+	delete(dk.Components, name)
+
+	if len(dk.Components) == 0 {
+		dk.Components = nil
+	}
+}
+
+// AggregatePhase determines the phase of the DynaKube from the phases of all required components.
+// An Error takes precedence over Deploying, optional components are ignored.
+// It also updates the summary of ready components that is shown by kubectl.
+func (dk *DynaKube) AggregatePhase() status.DeploymentPhase {
+	phase := status.Running
+
+	var running int
+
+	for _, name := range slices.Sorted(maps.Keys(dk.Status.Components)) {
+		componentPhase := dk.Status.Components[name].Phase
+		if componentPhase == status.Running {
+			running++
+		}
+
+		if dk.IsOptionalComponent(name) {
+			continue
+		}
+
+		switch {
+		case componentPhase == status.Error:
+			phase = status.Error
+		case componentPhase == status.Deploying && phase != status.Error:
+			phase = status.Deploying
+		}
+	}
+
+	dk.Status.ReadyComponents = ""
+	if len(dk.Status.Components) > 0 {
+		dk.Status.ReadyComponents = strconv.Itoa(running) + "/" + strconv.Itoa(len(dk.Status.Components))
+	}
+
+	return phase
+}
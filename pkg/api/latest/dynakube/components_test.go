@@ -0,0 +1,93 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package dynakube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetComponentStatus(t *testing.T) {
+	first := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	second := metav1.NewTime(first.Add(time.Minute))
+
+	t.Run("transition time is kept if phase doesn't change", func(t *testing.T) {
+		dk := &DynaKube{}
+		dk.Status.SetComponentStatus(OneAgentComponent, ComponentStatus{Phase: status.Deploying, ReadyReplicas: 1}, first)
+		dk.Status.SetComponentStatus(OneAgentComponent, ComponentStatus{Phase: status.Deploying, ReadyReplicas: 2}, second)
+
+		assert.Equal(t, first, dk.Status.Components[OneAgentComponent].LastTransitionTime)
+		assert.Equal(t, int32(2), dk.Status.Components[OneAgentComponent].ReadyReplicas)
+	})
+
+	t.Run("transition time is updated on phase change", func(t *testing.T) {
+		dk := &DynaKube{}
+		dk.Status.SetComponentStatus(OneAgentComponent, ComponentStatus{Phase: status.Deploying}, first)
+		dk.Status.SetComponentStatus(OneAgentComponent, ComponentStatus{Phase: status.Running}, second)
+
+		assert.Equal(t, second, dk.Status.Components[OneAgentComponent].LastTransitionTime)
+	})
+
+	t.Run("remove component", func(t *testing.T) {
+		dk := &DynaKube{}
+		dk.Status.SetComponentStatus(OneAgentComponent, ComponentStatus{Phase: status.Running}, first)
+		dk.Status.RemoveComponentStatus(OneAgentComponent)
+
+		assert.Nil(t, dk.Status.Components)
+	})
+}
+
+func TestAggregatePhase(t *testing.T) {
+	newDynakube := func(phases map[ComponentName]status.DeploymentPhase) *DynaKube {
+		dk := &DynaKube{}
+		for name, phase := range phases {
+			dk.Status.SetComponentStatus(name, ComponentStatus{Phase: phase}, metav1.Now())
+		}
+
+		return dk
+	}
+
+	t.Run("no components => running", func(t *testing.T) {
+		dk := newDynakube(nil)
+
+		assert.Equal(t, status.Running, dk.AggregatePhase())
+		assert.Empty(t, dk.Status.ReadyComponents)
+	})
+
+	t.Run("all running", func(t *testing.T) {
+		dk := newDynakube(map[ComponentName]status.DeploymentPhase{
+			ActiveGateComponent: status.Running,
+			OneAgentComponent:   status.Running,
+		})
+
+		assert.Equal(t, status.Running, dk.AggregatePhase())
+		assert.Equal(t, "2/2", dk.Status.ReadyComponents)
+	})
+
+	t.Run("error takes precedence over deploying", func(t *testing.T) {
+		dk := newDynakube(map[ComponentName]status.DeploymentPhase{
+			ActiveGateComponent: status.Deploying,
+			KSPMComponent:       status.Error,
+			OneAgentComponent:   status.Running,
+		})
+
+		assert.Equal(t, status.Error, dk.AggregatePhase())
+		assert.Equal(t, "1/3", dk.Status.ReadyComponents)
+	})
+
+	t.Run("optional components are ignored", func(t *testing.T) {
+		dk := newDynakube(map[ComponentName]status.DeploymentPhase{
+			OneAgentComponent: status.Running,
+			KSPMComponent:     status.Error,
+		})
+		dk.Spec.PhasePolicy = &PhasePolicy{OptionalComponents: []ComponentName{KSPMComponent}}
+
+		assert.Equal(t, status.Running, dk.AggregatePhase())
+		assert.Equal(t, "1/2", dk.Status.ReadyComponents)
+	})
+}
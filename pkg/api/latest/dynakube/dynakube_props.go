@@ -26,7 +26,8 @@ const (
 	// The limit is necessary because kubernetes uses the name of some resources (ActiveGate StatefulSet) for the label value, which has a limit of 63 characters. (see https://kubernetes.io/docs/concepts/overview/working-with-objects/labels/#syntax-and-character-set)
 	MaxNameLength = 40
 
-	PullSecretSuffix = "-pull-secret"
+	PullSecretSuffix                 = "-pull-secret"
+	WorkloadIdentityPullSecretSuffix = "-workload-identity-pull-secret"
 
 	DefaultMinRequestThresholdMinutes = 15
 )
@@ -88,6 +89,11 @@ func (dk *DynaKube) TenantRegistryPullSecretName() string {
 	return dk.Name + PullSecretSuffix
 }
 
+// WorkloadIdentityPullSecretName is the name of the pull secret generated for spec.workloadIdentityRegistries.
+func (dk *DynaKube) WorkloadIdentityPullSecretName() string {
+	return dk.Name + WorkloadIdentityPullSecretSuffix
+}
+
 func (dk *DynaKube) PullSecretNames() []string {
 	var names []string
 
@@ -109,6 +115,10 @@ func (dk *DynaKube) customPullSecretNames() []string {
 		names = append(names, helmPullSecret)
 	}
 
+	if len(dk.Spec.WorkloadIdentityRegistries) > 0 {
+		names = append(names, dk.WorkloadIdentityPullSecretName())
+	}
+
 	return names
 }
 
@@ -216,3 +226,37 @@ func (dk *DynaKube) IsCACertificateNeeded() bool {
 
 	return false
 }
+
+// GetTopologyNetworkZone returns the network zone mapped to the topology zone, spec.networkZone is used for unmapped zones.
+func (dk *DynaKube) GetTopologyNetworkZone(zone string) string {
+	if zone != "" {
+		for _, topologyZone := range dk.Spec.TopologyZones {
+			if topologyZone.Zone == zone {
+				return topologyZone.NetworkZone
+			}
+		}
+	}
+
+	return dk.Spec.NetworkZone
+}
+
+// GetAdditionalNetworkZones returns the network zones of the topology zones and OneAgent node pools that differ from spec.networkZone.
+// The result is sorted and contains no duplicates.
+func (dk *DynaKube) GetAdditionalNetworkZones() []string {
+	var networkZones []string
+
+	for _, topologyZone := range dk.Spec.TopologyZones {
+		networkZones = append(networkZones, topologyZone.NetworkZone)
+	}
+
+	for _, nodePool := range dk.OneAgent().GetNodePools() {
+		networkZones = append(networkZones, nodePool.NetworkZone)
+	}
+
+	networkZones = slices.DeleteFunc(networkZones, func(networkZone string) bool {
+		return networkZone == "" || networkZone == dk.Spec.NetworkZone
+	})
+	slices.Sort(networkZones)
+
+	return slices.Compact(networkZones)
+}
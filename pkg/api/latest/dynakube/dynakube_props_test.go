@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/exp"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/communication"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8senv"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -274,4 +276,58 @@ func TestCustomPullSecretReferences(t *testing.T) {
 			assert.NotEqual(t, dk.TenantRegistryPullSecretName(), ref.Name)
 		}
 	})
+
+	t.Run("includes workload identity pull secret when registries are set", func(t *testing.T) {
+		t.Setenv(k8senv.DTOperatorPullSecretEnvName, "")
+		dk := DynaKube{
+			ObjectMeta: metav1.ObjectMeta{Name: testDKName},
+			Spec: DynaKubeSpec{
+				CustomPullSecret:           testCustomPullSecret,
+				WorkloadIdentityRegistries: []string{"123456789012.dkr.ecr.eu-west-1.amazonaws.com"},
+			},
+		}
+		refs := dk.CustomPullSecretReferences()
+		assert.Len(t, refs, 2)
+		assert.Equal(t, testCustomPullSecret, refs[0].Name)
+		assert.Equal(t, dk.WorkloadIdentityPullSecretName(), refs[1].Name)
+	})
+}
+
+func TestNetworkZones(t *testing.T) {
+	dk := DynaKube{
+		Spec: DynaKubeSpec{
+			NetworkZone: "default",
+			TopologyZones: []communication.TopologyZone{
+				{Zone: "zone-a", NetworkZone: "network-zone-a"},
+				{Zone: "zone-b", NetworkZone: "default"},
+				{Zone: "zone-c", NetworkZone: "network-zone-a"},
+			},
+			OneAgent: oneagent.Spec{
+				HostMonitoring: &oneagent.HostInjectSpec{
+					NodePools: []oneagent.NodePoolSpec{
+						{Name: "gpu", NetworkZone: "gpu"},
+						{Name: "other"},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("topology zones are mapped to their network zone", func(t *testing.T) {
+		assert.Equal(t, "network-zone-a", dk.GetTopologyNetworkZone("zone-a"))
+		assert.Equal(t, "default", dk.GetTopologyNetworkZone("zone-b"))
+	})
+
+	t.Run("unknown topology zones use spec.networkZone", func(t *testing.T) {
+		assert.Equal(t, "default", dk.GetTopologyNetworkZone("zone-d"))
+		assert.Equal(t, "default", dk.GetTopologyNetworkZone(""))
+	})
+
+	t.Run("additional network zones are sorted and unique", func(t *testing.T) {
+		assert.Equal(t, []string{"gpu", "network-zone-a"}, dk.GetAdditionalNetworkZones())
+	})
+
+	t.Run("no additional network zones", func(t *testing.T) {
+		assert.Empty(t, (&DynaKube{}).GetAdditionalNetworkZones())
+	})
 }
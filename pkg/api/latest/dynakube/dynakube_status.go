@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/extensions"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/kspm"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/kubemon"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/metadataenrichment"
@@ -47,6 +48,10 @@ type DynaKubeStatus struct { //nolint:revive
 	// +kubebuilder:validation:Optional
 	KSPM kspm.Status `json:"kspm,omitzero"`
 
+	// Observed state of Extensions
+	// +kubebuilder:validation:Optional
+	Extensions extensions.Status `json:"extensions,omitzero"`
+
 	// UpdatedTimestamp indicates when the instance was last updated
 	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Last Updated"
@@ -62,6 +67,18 @@ type DynaKubeStatus struct { //nolint:revive
 	// Defines the current state (Running, Updating, Error, ...)
 	Phase status.DeploymentPhase `json:"phase,omitempty"`
 
+	// Observed state of every enabled component, the phase of the DynaKube is derived from it
+	// +kubebuilder:validation:Optional
+	Components map[ComponentName]ComponentStatus `json:"components,omitempty"`
+
+	// Number of running components out of all enabled components, e.g. 3/4
+	// +kubebuilder:validation:Optional
+	ReadyComponents string `json:"readyComponents,omitempty"`
+
+	// Remediations of the components that were repeatedly OOMKilled or in CrashLoopBackOff
+	// +kubebuilder:validation:Optional
+	Remediations map[ComponentName]RemediationStatus `json:"remediations,omitempty"`
+
 	// KubeSystemUUID contains the UUID of the current Kubernetes cluster
 	KubeSystemUUID string `json:"kubeSystemUUID,omitempty"`
 
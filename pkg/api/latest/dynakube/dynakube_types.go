@@ -17,6 +17,8 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/otlp"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/telemetryingest"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/communication"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/istio"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/value"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -56,6 +58,7 @@ const (
 // +kubebuilder:resource:path=dynakubes,scope=Namespaced,categories=dynatrace,shortName={dk,dks}
 // +kubebuilder:printcolumn:name="ApiUrl",type=string,JSONPath=`.spec.apiUrl`
 // +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Components",type=string,JSONPath=`.status.readyComponents`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +operator-sdk:csv:customresourcedefinitions:displayName="Dynatrace DynaKube"
 // +operator-sdk:csv:customresourcedefinitions:resources={{StatefulSet,v1,},{DaemonSet,v1,},{Pod,v1,}}
@@ -155,6 +158,15 @@ type DynaKubeSpec struct { //nolint:revive
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Network Zone",order=7,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
 	NetworkZone string `json:"networkZone,omitempty"`
 
+	// Maps Kubernetes topology zones to Dynatrace network zones, so the OneAgents prefer the ActiveGates of their zone.
+	// A OneAgent DaemonSet is deployed per topology zone, injected pods get the network zone of the zone they are pinned to.
+	// spec.networkZone is used for the nodes and pods outside of the listed topology zones.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=10
+	// +listType=map
+	// +listMapKey=zone
+	TopologyZones []communication.TopologyZone `json:"topologyZones,omitempty"`
+
 	// Defines a custom pull secret in case you use a private registry when pulling images from the Dynatrace environment.
 	// To define a custom pull secret and learn about the expected behavior, see Configure customPullSecret
 	// (https://www.dynatrace.com/support/help/setup-and-configuration/setup-on-container-platforms/kubernetes/get-started-with-kubernetes-monitoring/dto-config-options-k8s#custompullsecret).
@@ -162,6 +174,15 @@ type DynaKubeSpec struct { //nolint:revive
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Custom PullSecret",order=8,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:io.kubernetes:Secret"}
 	CustomPullSecret string `json:"customPullSecret,omitempty"`
 
+	// Registries for which the operator gets short-lived credentials with the workload identity of its service account,
+	// supported are Amazon ECR, Google Container Registry/Artifact Registry and Azure Container Registry.
+	// The credentials are used by the operator and the CSI driver and are written to a generated pull secret used by the operands,
+	// which is refreshed before the credentials expire.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=10
+	// +listType=set
+	WorkloadIdentityRegistries []string `json:"workloadIdentityRegistries,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	Templates TemplatesSpec `json:"templates,omitzero"`
 
@@ -188,6 +209,25 @@ type DynaKubeSpec struct { //nolint:revive
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enable Istio automatic management",order=9,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	EnableIstio *bool `json:"enableIstio,omitempty"`
 
+	// Configures how the components are integrated into the Istio service mesh, only considered if enableIstio is true.
+	// +kubebuilder:validation:Optional
+	Istio *istio.Spec `json:"istio,omitempty"`
+
+	// Configures how the phase of the DynaKube is determined from the phases of its components.
+	// By default all enabled components have to be running.
+	// +kubebuilder:validation:Optional
+	PhasePolicy *PhasePolicy `json:"phasePolicy,omitempty"`
+
+	// Configures the automatic remediation of ActiveGate, ExtensionExecutionController and OpenTelemetry collector pods
+	// that are repeatedly OOMKilled or in CrashLoopBackOff. Disabled by default.
+	// +kubebuilder:validation:Optional
+	Remediation *RemediationSpec `json:"remediation,omitempty"`
+
+	// When present, the operator creates a NetworkPolicy for each of its operands, which only allows the traffic the operand needs,
+	// e.g. from the OneAgents to the ActiveGate and from the ActiveGate to the Dynatrace environment. Disabled by default.
+	// +kubebuilder:validation:Optional
+	NetworkPolicies *NetworkPoliciesSpec `json:"networkPolicies,omitempty"`
+
 	// Overrides the default registry from which Dynatrace images are pulled.
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Public Registry Override",order=10,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
@@ -5,9 +5,15 @@ package extensions
 
 import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
+	"k8s.io/utils/ptr"
 )
 
-const SQLExecutorInfix = "-sql-ext-exec-"
+const (
+	SQLExecutorInfix = "-sql-ext-exec-"
+
+	// DatasourceServicePrefix is the component part of the names of the datasource Services.
+	DatasourceServicePrefix = "eec-"
+)
 
 func (e *Extensions) SetName(name string) {
 	e.name = name
@@ -76,3 +82,32 @@ func (e *Extensions) GetServiceName() string {
 func (e *Extensions) GetDatabaseDatasourceName(id string) string {
 	return e.name + SQLExecutorInfix + id
 }
+
+// GetDatabasesNetworkPolicyName returns the name of the NetworkPolicy shared by the SQL executors of all databases.
+func (e *Extensions) GetDatabasesNetworkPolicyName() string {
+	return e.name + "-sql-ext-exec"
+}
+
+// GetExecutionControllerReplicas returns the desired number of ExtensionExecutionController replicas.
+func (e *Extensions) GetExecutionControllerReplicas() int32 {
+	if e.ExecutionController == nil {
+		return 1
+	}
+
+	return max(ptr.Deref(e.ExecutionController.Replicas, 1), 1)
+}
+
+// IsExecutionControllerSharded returns true if the datasources are sharded between multiple ExtensionExecutionController replicas.
+func (e *Extensions) IsExecutionControllerSharded() bool {
+	return e.GetExecutionControllerReplicas() > 1
+}
+
+// GetDatasourceServiceName returns the name of the Service a datasource connects to if the datasources are sharded.
+// The name doesn't depend on the replica the datasource is assigned to, so the address stays stable on failover.
+func (e *Extensions) GetDatasourceServiceName(datasource string) string {
+	return e.name + "-" + DatasourceServicePrefix + datasource
+}
+
+func (e *Extensions) GetDatasourceServiceNameFQDN(datasource string) string {
+	return e.GetDatasourceServiceName(datasource) + "." + e.namespace
+}
@@ -5,7 +5,10 @@ package extensions
 
 import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/image"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/podtemplate"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 type Extensions struct {
@@ -79,6 +82,30 @@ type ExecutionControllerSpec struct {
 	// Selects EmptyDir volume to be storage device
 	// +kubebuilder:validation:Optional
 	UseEphemeralVolume *bool `json:"useEphemeralVolume,omitempty"`
+
+	// Number of ExtensionExecutionController replicas, the datasources are sharded between the available replicas (the default value is: 1)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Configures the PodDisruptionBudget that is created if more than one replica is configured
+	// +kubebuilder:validation:Optional
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+
+	// Patch applied to the pod template after the operator has built it, e.g. to add a sidecar, host aliases or a runtime class.
+	// +kubebuilder:validation:Optional
+	PodTemplatePatch *podtemplate.Patch `json:"podTemplatePatch,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+type PodDisruptionBudgetSpec struct {
+	// Amount or percentage of pods that have to be available during a voluntary disruption, mutually exclusive with maxUnavailable
+	// +kubebuilder:validation:Optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+	// Amount or percentage of pods that can be unavailable during a voluntary disruption (the default value is: 1)
+	// +kubebuilder:validation:Optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
 }
 
 // +kubebuilder:object:generate=true
@@ -98,6 +125,16 @@ type DatabaseSpec struct {
 	// +kubebuilder:validation:Optional
 	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
 
+	// Name of a Secret containing the credentials of the database, its keys are mounted as files to /var/run/dynatrace/executor/credentials.
+	// Changes to the Secret restart the executor pods.
+	// +kubebuilder:validation:Optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+
+	// Name of a Secret containing the TLS certificates for the connection to the database, its keys are mounted as files to /var/ssl-certs/database.
+	// Changes to the Secret restart the executor pods.
+	// +kubebuilder:validation:Optional
+	TLSSecretRef *corev1.LocalObjectReference `json:"tlsSecretRef,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	ServiceAccountName string `json:"serviceAccountName,omitempty"`
 
@@ -128,4 +165,79 @@ type DatabaseExecutorSpec struct {
 
 	// +kubebuilder:validation:Optional
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Patch applied to the pod template after the operator has built it, e.g. to add a sidecar, host aliases or a runtime class.
+	// +kubebuilder:validation:Optional
+	PodTemplatePatch *podtemplate.Patch `json:"podTemplatePatch,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+type Status struct {
+	// Observed state of the SQL extension executors, one entry per database
+	// +listType=map
+	// +listMapKey=id
+	// +kubebuilder:validation:Optional
+	Databases []DatabaseStatus `json:"databases,omitempty"`
+
+	// Observed state of the ExtensionExecutionController replicas and the assignment of the datasources to them
+	// +kubebuilder:validation:Optional
+	ExecutionController ExecutionControllerStatus `json:"executionController,omitzero"`
+}
+
+// +kubebuilder:object:generate=true
+
+type ExecutionControllerStatus struct {
+	// Assignment of the datasources to the replicas, only set if more than one replica is configured
+	// +listType=map
+	// +listMapKey=datasource
+	// +kubebuilder:validation:Optional
+	Assignments []DatasourceAssignment `json:"assignments,omitempty"`
+
+	// Number of desired replicas
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// Number of ready replicas
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+}
+
+type DatasourceAssignment struct {
+	// Name of the datasource
+	Datasource string `json:"datasource"`
+
+	// Ordinal of the ExtensionExecutionController replica that serves the datasource
+	Replica int32 `json:"replica"`
+}
+
+type DatabaseConnectionState string
+
+const (
+	DatabaseConnected    DatabaseConnectionState = "Connected"
+	DatabaseDisconnected DatabaseConnectionState = "Disconnected"
+	DatabaseUnknown      DatabaseConnectionState = "Unknown"
+)
+
+// +kubebuilder:object:generate=true
+
+type DatabaseStatus struct {
+	// ID of the database in spec.extensions.databases
+	ID string `json:"id"`
+
+	// Connection state as reported by the readiness endpoint of the executors
+	// +kubebuilder:validation:Enum=Connected;Disconnected;Unknown
+	ConnectionState DatabaseConnectionState `json:"connectionState"`
+
+	// Number of executor pods whose readiness endpoint reports a working connection
+	ConnectedReplicas int32 `json:"connectedReplicas"`
+
+	// Number of probed executor pods
+	Replicas int32 `json:"replicas"`
+
+	// Response of the readiness endpoint of an executor that isn't connected
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+
+	// Time of the last probe of the readiness endpoints
+	// +kubebuilder:validation:Optional
+	LastProbeTimestamp metav1.Time `json:"lastProbeTimestamp,omitzero"`
 }
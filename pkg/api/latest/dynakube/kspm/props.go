@@ -6,9 +6,12 @@ package kspm
 import (
 	"slices"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api"
 	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
 )
 
+const defaultNodeConfigurationCollectorRepository = "public.ecr.aws/dynatrace/dynatrace-k8s-node-config-collector"
+
 func (kspm *KSPM) SetName(name string) {
 	kspm.name = name
 }
@@ -31,3 +34,8 @@ func (kspm *KSPM) GetUniqueMappedHostPaths() []string {
 
 	return slices.Compact(tmpMappedHostPaths)
 }
+
+// Image returns the image of the node configuration collector, the public image is used if no image is set.
+func (kspm *KSPM) Image() string {
+	return kspm.ImageRef.StringWithDefaults(defaultNodeConfigurationCollectorRepository, api.LatestTag)
+}
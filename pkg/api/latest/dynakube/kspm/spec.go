@@ -4,7 +4,9 @@
 package kspm
 
 import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/autoscaling"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/image"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/podtemplate"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -68,6 +70,11 @@ type NodeConfigurationCollectorSpec struct {
 	// +kubebuilder:validation:Optional
 	Resources corev1.ResourceRequirements `json:"resources,omitzero"`
 
+	// Creates a VerticalPodAutoscaler for the DaemonSet of the NodeConfigurationCollector, so the resources can follow the size of the nodes.
+	// Requires the VerticalPodAutoscaler to be installed in the cluster.
+	// +kubebuilder:validation:Optional
+	VerticalPodAutoscaler *autoscaling.VerticalPodAutoscalerSpec `json:"verticalPodAutoscaler,omitempty"`
+
 	// Define the nodeAffinity for the DaemonSet of the NodeConfigurationCollector
 	// +kubebuilder:validation:Optional
 	NodeAffinity *corev1.NodeAffinity `json:"nodeAffinity,omitempty"`
@@ -83,4 +90,8 @@ type NodeConfigurationCollectorSpec struct {
 	// Set additional environment variables for the NodeConfigurationCollector pods
 	// +kubebuilder:validation:Optional
 	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Patch applied to the pod template after the operator has built it, e.g. to add a sidecar, host aliases or a runtime class.
+	// +kubebuilder:validation:Optional
+	PodTemplatePatch *podtemplate.Patch `json:"podTemplatePatch,omitempty"`
 }
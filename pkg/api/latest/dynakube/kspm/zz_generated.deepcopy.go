@@ -8,6 +8,8 @@
 package kspm
 
 import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/autoscaling"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/podtemplate"
 	"k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -43,6 +45,11 @@ func (in *NodeConfigurationCollectorSpec) DeepCopyInto(out *NodeConfigurationCol
 	}
 	out.ImageRef = in.ImageRef
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.VerticalPodAutoscaler != nil {
+		in, out := &in.VerticalPodAutoscaler, &out.VerticalPodAutoscaler
+		*out = new(autoscaling.VerticalPodAutoscalerSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.NodeAffinity != nil {
 		in, out := &in.NodeAffinity, &out.NodeAffinity
 		*out = new(corev1.NodeAffinity)
@@ -67,6 +74,11 @@ func (in *NodeConfigurationCollectorSpec) DeepCopyInto(out *NodeConfigurationCol
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PodTemplatePatch != nil {
+		in, out := &in.PodTemplatePatch, &out.PodTemplatePatch
+		*out = new(podtemplate.Patch)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConfigurationCollectorSpec.
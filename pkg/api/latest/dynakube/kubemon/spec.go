@@ -4,6 +4,7 @@
 package kubemon
 
 import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/podtemplate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/value"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -70,6 +71,10 @@ type StatefulSetProperties struct {
 	// Configures the terminationGracePeriodSeconds parameter of the KubernetesMonitoring pod.
 	// +kubebuilder:validation:Optional
 	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+
+	// Patch applied to the pod template after the operator has built it, e.g. to add a sidecar, host aliases or a runtime class.
+	// +kubebuilder:validation:Optional
+	PodTemplatePatch *podtemplate.Patch `json:"podTemplatePatch,omitempty"`
 }
 
 // +kubebuilder:object:generate=true
@@ -8,6 +8,7 @@
 package kubemon
 
 import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/podtemplate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/value"
 	"k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -105,6 +106,11 @@ func (in *StatefulSetProperties) DeepCopyInto(out *StatefulSetProperties) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.PodTemplatePatch != nil {
+		in, out := &in.PodTemplatePatch, &out.PodTemplatePatch
+		*out = new(podtemplate.Patch)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatefulSetProperties.
@@ -4,7 +4,9 @@
 package logmonitoring
 
 import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/autoscaling"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/image"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/podtemplate"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -62,6 +64,11 @@ type TemplateSpec struct {
 	// +kubebuilder:validation:Optional
 	Resources corev1.ResourceRequirements `json:"resources,omitzero"`
 
+	// Creates a VerticalPodAutoscaler for the LogMonitoring DaemonSet, so the resources can follow the size of the nodes.
+	// Requires the VerticalPodAutoscaler to be installed in the cluster.
+	// +kubebuilder:validation:Optional
+	VerticalPodAutoscaler *autoscaling.VerticalPodAutoscalerSpec `json:"verticalPodAutoscaler,omitempty"`
+
 	// Define the strategy for updating the LogMonitoring pods
 	// +kubebuilder:validation:Optional
 	RollingUpdate *appsv1.RollingUpdateDaemonSet `json:"rollingUpdate,omitempty"`
@@ -73,4 +80,8 @@ type TemplateSpec struct {
 	// Set additional arguments to the LogMonitoring init container
 	// +kubebuilder:validation:Optional
 	Args []string `json:"args,omitempty"`
+
+	// Patch applied to the pod template after the operator has built it, e.g. to add a sidecar, host aliases or a runtime class.
+	// +kubebuilder:validation:Optional
+	PodTemplatePatch *podtemplate.Patch `json:"podTemplatePatch,omitempty"`
 }
@@ -8,6 +8,8 @@
 package logmonitoring
 
 import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/autoscaling"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/podtemplate"
 	"k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -80,6 +82,11 @@ func (in *TemplateSpec) DeepCopyInto(out *TemplateSpec) {
 	}
 	out.ImageRef = in.ImageRef
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.VerticalPodAutoscaler != nil {
+		in, out := &in.VerticalPodAutoscaler, &out.VerticalPodAutoscaler
+		*out = new(autoscaling.VerticalPodAutoscalerSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RollingUpdate != nil {
 		in, out := &in.RollingUpdate, &out.RollingUpdate
 		*out = new(v1.RollingUpdateDaemonSet)
@@ -97,6 +104,11 @@ func (in *TemplateSpec) DeepCopyInto(out *TemplateSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PodTemplatePatch != nil {
+		in, out := &in.PodTemplatePatch, &out.PodTemplatePatch
+		*out = new(podtemplate.Patch)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateSpec.
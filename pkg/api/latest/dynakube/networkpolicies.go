@@ -0,0 +1,26 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package dynakube
+
+type NetworkPoliciesSpec struct {
+	// CIDRs of the Kubernetes API server, used for the egress to the Kubernetes API.
+	// Required, as the address of the API server depends on the distribution and the egress to it must not be open to all destinations.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	KubernetesAPICIDRs []string `json:"kubernetesApiCidrs,omitempty"`
+}
+
+// IsNetworkPolicyEnabled returns true if the operator has to create a NetworkPolicy for each of its operands.
+func (dk *DynaKube) IsNetworkPolicyEnabled() bool {
+	return dk.Spec.NetworkPolicies != nil
+}
+
+// GetKubernetesAPICIDRs returns the configured CIDRs of the Kubernetes API server, if any.
+func (dk *DynaKube) GetKubernetesAPICIDRs() []string {
+	if dk.Spec.NetworkPolicies == nil {
+		return nil
+	}
+
+	return dk.Spec.NetworkPolicies.KubernetesAPICIDRs
+}
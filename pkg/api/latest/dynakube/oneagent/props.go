@@ -5,9 +5,12 @@ package oneagent
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/autoscaling"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/communication"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/resourceattributes"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/dtversion"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/installconfig"
@@ -21,12 +24,17 @@ const (
 	PodNameOSAgent                        = "oneagent"
 	DefaultOneAgentImageRegistrySubPath   = "/linux/oneagent"
 	StorageVolumeDefaultHostPath          = "/var/opt/dynatrace"
+
+	// TopologyZoneNodePoolPrefix is prepended to the names of the node pools generated for the topology zones of the DynaKube.
+	TopologyZoneNodePoolPrefix = "zone-"
+	maxNodePoolNameLength      = 32
 )
 
 func NewOneAgent(spec *Spec, status *Status, codeModulesStatus *CodeModulesStatus, //nolint:revive
 	name, apiURLHost string,
 	featureOneAgentPrivileged, featureOneAgentSkipLivenessProbe bool,
-	globalResourceAttributes map[string]string) *OneAgent {
+	globalResourceAttributes map[string]string,
+	topologyZones []communication.TopologyZone) *OneAgent {
 	return &OneAgent{
 		Spec:              spec,
 		Status:            status,
@@ -36,6 +44,7 @@ func NewOneAgent(spec *Spec, status *Status, codeModulesStatus *CodeModulesStatu
 		apiURLHost: apiURLHost,
 
 		globalResourceAttributes: globalResourceAttributes,
+		topologyZones:            topologyZones,
 
 		featureOneAgentPrivileged:        featureOneAgentPrivileged,
 		featureOneAgentSkipLivenessProbe: featureOneAgentSkipLivenessProbe,
@@ -139,6 +148,88 @@ func (oa *OneAgent) GetNamespaceSelector() *metav1.LabelSelector {
 	}
 }
 
+// GetProcessModuleConfig returns the process module config overrides of the code modules.
+func (oa *OneAgent) GetProcessModuleConfig() *ProcessModuleConfigSpec {
+	switch {
+	case oa.IsCloudNativeFullstackMode():
+		return oa.CloudNativeFullStack.ProcessModuleConfig
+	case oa.IsApplicationMonitoringMode():
+		return oa.ApplicationMonitoring.ProcessModuleConfig
+	default:
+		return nil
+	}
+}
+
+// GetHostInjectSpec returns the settings of the OneAgent DaemonSet of the configured mode.
+func (oa *OneAgent) GetHostInjectSpec() *HostInjectSpec {
+	switch {
+	case oa.IsCloudNativeFullstackMode():
+		return &oa.CloudNativeFullStack.HostInjectSpec
+	case oa.IsHostMonitoringMode():
+		return oa.HostMonitoring
+	case oa.IsClassicFullStackMode():
+		return oa.ClassicFullStack
+	default:
+		return nil
+	}
+}
+
+func (oa *OneAgent) GetVerticalPodAutoscaler() *autoscaling.VerticalPodAutoscalerSpec {
+	if hostInjectSpec := oa.GetHostInjectSpec(); hostInjectSpec != nil {
+		return hostInjectSpec.VerticalPodAutoscaler
+	}
+
+	return nil
+}
+
+// GetNodePools returns the node pools that get a separate OneAgent DaemonSet.
+// The configured node pools are followed by a node pool per topology zone, which sets the network zone of the zone.
+func (oa *OneAgent) GetNodePools() []NodePoolSpec {
+	hostInjectSpec := oa.GetHostInjectSpec()
+	if hostInjectSpec == nil {
+		return nil
+	}
+
+	if len(oa.topologyZones) == 0 {
+		return hostInjectSpec.NodePools
+	}
+
+	nodePools := slices.Clone(hostInjectSpec.NodePools)
+
+	for _, topologyZone := range oa.topologyZones {
+		nodePools = append(nodePools, NodePoolSpec{
+			Name:         GetTopologyZoneNodePoolName(topologyZone.Zone),
+			NodeSelector: map[string]string{corev1.LabelTopologyZone: topologyZone.Zone},
+			NetworkZone:  topologyZone.NetworkZone,
+		})
+	}
+
+	return nodePools
+}
+
+// GetTopologyZoneNodePoolName returns the name of the node pool generated for a topology zone,
+// the zone is sanitized to fit the naming rules of node pools.
+func GetTopologyZoneNodePoolName(zone string) string {
+	name := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			return r
+		}
+
+		return '-'
+	}, strings.ToLower(zone))
+
+	name = TopologyZoneNodePoolPrefix + name
+	if len(name) > maxNodePoolNameLength {
+		name = name[:maxNodePoolNameLength]
+	}
+
+	return strings.TrimRight(name, "-")
+}
+
+func (oa *OneAgent) GetNodePoolDaemonSetName(nodePool string) string {
+	return fmt.Sprintf("%s-%s", oa.GetDaemonsetName(), nodePool)
+}
+
 func (oa *OneAgent) GetSecCompProfile() string {
 	switch {
 	case oa.IsCloudNativeFullstackMode():
@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"testing"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/communication"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/installconfig"
 	"github.com/stretchr/testify/assert"
@@ -55,13 +56,13 @@ func TestDefaultOneAgentImage(t *testing.T) {
 
 	t.Run("OneAgentImage adds raw postfix", func(t *testing.T) {
 		hostURL, _ := url.Parse(testAPIURL)
-		oneAgent := NewOneAgent(&Spec{}, &Status{}, &CodeModulesStatus{}, "", hostURL.Host, false, false, nil)
+		oneAgent := NewOneAgent(&Spec{}, &Status{}, &CodeModulesStatus{}, "", hostURL.Host, false, false, nil, nil)
 		assert.Equal(t, "test-endpoint/linux/oneagent:1.234.5-raw", oneAgent.GetDefaultImage("1.234.5"))
 	})
 
 	t.Run("OneAgentImage doesn't add 'raw' postfix if present", func(t *testing.T) {
 		hostURL, _ := url.Parse(testAPIURL)
-		oneAgent := NewOneAgent(&Spec{}, &Status{}, &CodeModulesStatus{}, "", hostURL.Host, false, false, nil)
+		oneAgent := NewOneAgent(&Spec{}, &Status{}, &CodeModulesStatus{}, "", hostURL.Host, false, false, nil, nil)
 		assert.Equal(t, "test-endpoint/linux/oneagent:1.234.5-raw", oneAgent.GetDefaultImage("1.234.5-raw"))
 	})
 
@@ -69,7 +70,7 @@ func TestDefaultOneAgentImage(t *testing.T) {
 		version := "1.239.14.20220325-164521"
 		expectedImage := "test-endpoint/linux/oneagent:1.239.14-raw"
 		hostURL, _ := url.Parse(testAPIURL)
-		oneAgent := NewOneAgent(&Spec{}, &Status{}, &CodeModulesStatus{}, "", hostURL.Host, false, false, nil)
+		oneAgent := NewOneAgent(&Spec{}, &Status{}, &CodeModulesStatus{}, "", hostURL.Host, false, false, nil, nil)
 		assert.Equal(t, expectedImage, oneAgent.GetDefaultImage(version))
 	})
 }
@@ -126,7 +127,7 @@ func TestCodeModulesVersion(t *testing.T) {
 
 	t.Run("use status", func(t *testing.T) {
 		codeModulesStatus := &CodeModulesStatus{VersionStatus: status.VersionStatus{Version: testVersion}}
-		oneAgent := NewOneAgent(&Spec{}, &Status{}, codeModulesStatus, "", "", false, false, nil)
+		oneAgent := NewOneAgent(&Spec{}, &Status{}, codeModulesStatus, "", "", false, false, nil, nil)
 		version := oneAgent.GetCodeModulesVersion()
 		assert.Equal(t, testVersion, version)
 	})
@@ -134,7 +135,7 @@ func TestCodeModulesVersion(t *testing.T) {
 		codeModulesStatus := &CodeModulesStatus{VersionStatus: status.VersionStatus{Version: "other"}}
 		oneAgent := NewOneAgent(&Spec{
 			ApplicationMonitoring: &ApplicationMonitoringSpec{Version: testVersion},
-		}, &Status{}, codeModulesStatus, "", "", false, false, nil)
+		}, &Status{}, codeModulesStatus, "", "", false, false, nil, nil)
 		version := oneAgent.GetCustomCodeModulesVersion()
 
 		assert.Equal(t, testVersion, version)
@@ -501,7 +502,7 @@ func TestOneAgent_IsAutoUpdateEnabled(t *testing.T) {
 		}
 
 		for _, tc := range tcs {
-			oa := NewOneAgent(tc.spec, nil, nil, "", "", false, false, nil)
+			oa := NewOneAgent(tc.spec, nil, nil, "", "", false, false, nil, nil)
 			assert.Equal(t, tc.autoUpdateEnabled, oa.IsAutoUpdateEnabled(), tc.name)
 		}
 	})
@@ -515,7 +516,7 @@ func TestOneAgent_IsAutoUpdateEnabled(t *testing.T) {
 			autoUpdateEnabled: false,
 		}
 
-		oa := NewOneAgent(tc.spec, nil, nil, "", "", false, false, nil)
+		oa := NewOneAgent(tc.spec, nil, nil, "", "", false, false, nil, nil)
 		assert.Equal(t, tc.autoUpdateEnabled, oa.IsAutoUpdateEnabled(), tc.name)
 	})
 }
@@ -644,3 +645,57 @@ func TestOneAgent_GetResourceAttributes(t *testing.T) {
 		})
 	}
 }
+
+func TestOneAgent_GetNodePools(t *testing.T) {
+	topologyZones := []communication.TopologyZone{
+		{Zone: "us-east-1a", NetworkZone: "aws.us-east-1a"},
+	}
+	nodePool := NodePoolSpec{Name: "gpu", NodeSelector: map[string]string{"gpu": "true"}}
+
+	t.Run("no node pools without host injection", func(t *testing.T) {
+		oa := NewOneAgent(&Spec{ApplicationMonitoring: &ApplicationMonitoringSpec{}}, nil, nil, "", "", false, false, nil, topologyZones)
+
+		assert.Empty(t, oa.GetNodePools())
+	})
+
+	t.Run("node pools are followed by a node pool per topology zone", func(t *testing.T) {
+		spec := &Spec{CloudNativeFullStack: &CloudNativeFullStackSpec{HostInjectSpec: HostInjectSpec{NodePools: []NodePoolSpec{nodePool}}}}
+		oa := NewOneAgent(spec, nil, nil, "", "", false, false, nil, topologyZones)
+
+		nodePools := oa.GetNodePools()
+
+		require.Len(t, nodePools, 2)
+		assert.Equal(t, nodePool, nodePools[0])
+		assert.Equal(t, NodePoolSpec{
+			Name:         "zone-us-east-1a",
+			NodeSelector: map[string]string{corev1.LabelTopologyZone: "us-east-1a"},
+			NetworkZone:  "aws.us-east-1a",
+		}, nodePools[1])
+		assert.Len(t, spec.CloudNativeFullStack.NodePools, 1)
+	})
+
+	t.Run("topology zone names are sanitized", func(t *testing.T) {
+		assert.Equal(t, "zone-europe-west1-b", GetTopologyZoneNodePoolName("europe-west1-b"))
+		assert.Equal(t, "zone-az-1", GetTopologyZoneNodePoolName("AZ_1"))
+		assert.Equal(t, "zone-a-very-long-topology-zone-n", GetTopologyZoneNodePoolName("a-very-long-topology-zone-name-exceeding-the-limit"))
+	})
+}
+
+func TestOneAgent_GetProcessModuleConfig(t *testing.T) {
+	pmcSpec := &ProcessModuleConfigSpec{
+		Profiles: []ProcessModuleProfile{{Name: "debug"}},
+	}
+
+	t.Run("returns config of the injection mode", func(t *testing.T) {
+		oa := NewOneAgent(&Spec{CloudNativeFullStack: &CloudNativeFullStackSpec{AppInjectionSpec: AppInjectionSpec{ProcessModuleConfig: pmcSpec}}}, &Status{}, &CodeModulesStatus{}, "", "", false, false, nil, nil)
+		assert.Equal(t, pmcSpec, oa.GetProcessModuleConfig())
+
+		oa = NewOneAgent(&Spec{ApplicationMonitoring: &ApplicationMonitoringSpec{AppInjectionSpec: AppInjectionSpec{ProcessModuleConfig: pmcSpec}}}, &Status{}, &CodeModulesStatus{}, "", "", false, false, nil, nil)
+		assert.Equal(t, pmcSpec, oa.GetProcessModuleConfig())
+	})
+	t.Run("returns nil without app injection", func(t *testing.T) {
+		oa := NewOneAgent(&Spec{HostMonitoring: &HostInjectSpec{}}, &Status{}, &CodeModulesStatus{}, "", "", false, false, nil, nil)
+
+		assert.Nil(t, oa.GetProcessModuleConfig())
+	})
+}
@@ -4,6 +4,9 @@
 package oneagent
 
 import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/autoscaling"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/communication"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/podtemplate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -20,6 +23,8 @@ type OneAgent struct {
 
 	globalResourceAttributes map[string]string
 
+	topologyZones []communication.TopologyZone
+
 	featureOneAgentPrivileged        bool
 	featureOneAgentSkipLivenessProbe bool
 }
@@ -123,6 +128,11 @@ type HostInjectSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Requirements",order=20,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:resourceRequirements"}
 	OneAgentResources corev1.ResourceRequirements `json:"oneAgentResources,omitzero"`
 
+	// Creates a VerticalPodAutoscaler for the OneAgent DaemonSet, so the resources can follow the size of the nodes.
+	// Requires the VerticalPodAutoscaler to be installed in the cluster.
+	// +kubebuilder:validation:Optional
+	VerticalPodAutoscaler *autoscaling.VerticalPodAutoscalerSpec `json:"verticalPodAutoscaler,omitempty"`
+
 	// Rolling update settings for the OneAgent DaemonSet.
 	// +kubebuilder:validation:Optional
 	RollingUpdate *appsv1.RollingUpdateDaemonSet `json:"rollingUpdate,omitempty"`
@@ -149,6 +159,64 @@ type HostInjectSpec struct {
 	// If the same key exists in both, the value from additionalResourceAttributes takes precedence.
 	// +kubebuilder:validation:Optional
 	AdditionalResourceAttributes map[string]string `json:"additionalResourceAttributes,omitempty"`
+
+	// Node pools that need different OneAgent settings. A separate DaemonSet is deployed for each node pool,
+	// the nodes of the node pools are excluded from the main OneAgent DaemonSet.
+	// If a node matches multiple node pools, the first one in the list is used.
+	// The verticalPodAutoscaler only manages the main OneAgent DaemonSet.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=10
+	// +listType=map
+	// +listMapKey=name
+	NodePools []NodePoolSpec `json:"nodePools,omitempty"`
+
+	// Patch applied to the pod template after the operator has built it, e.g. to add a sidecar, host aliases or a runtime class.
+	// +kubebuilder:validation:Optional
+	PodTemplatePatch *podtemplate.Patch `json:"podTemplatePatch,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+type NodePoolSpec struct {
+	// Name of the node pool, it is appended to the name of the OneAgent DaemonSet.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Selects the nodes of the node pool, it is merged with the nodeSelector of the OneAgent.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinProperties=1
+	NodeSelector map[string]string `json:"nodeSelector"`
+
+	// Resource settings for the OneAgent container on the nodes of the node pool, replaces oneAgentResources.
+	// +kubebuilder:validation:Optional
+	OneAgentResources *corev1.ResourceRequirements `json:"oneAgentResources,omitempty"`
+
+	// Additional arguments to the OneAgent installer, they take precedence over the args of the OneAgent.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	Args []string `json:"args,omitempty"`
+
+	// Additional environment variables for the OneAgent pods, they take precedence over the env of the OneAgent.
+	// +kubebuilder:validation:Optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Sets the host group of the OneAgents in the node pool, replaces spec.oneAgent.hostGroup.
+	// +kubebuilder:validation:Optional
+	HostGroup string `json:"hostGroup,omitempty"`
+
+	// Sets the network zone of the OneAgents in the node pool, replaces spec.networkZone.
+	// +kubebuilder:validation:Optional
+	NetworkZone string `json:"networkZone,omitempty"`
+
+	// Tolerations for the nodes of the node pool, they are added to the tolerations of the OneAgent.
+	// +kubebuilder:validation:Optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Assign a priority class to the OneAgent pods of the node pool, replaces priorityClassName.
+	// +kubebuilder:validation:Optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
 }
 
 // +kubebuilder:object:generate=true
@@ -191,10 +259,70 @@ type AppInjectionSpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Namespace Selector",order=17,xDescriptors="urn:alm:descriptor:com.tectonic.ui:selector:core:v1:Namespace"
 	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector,omitzero"`
+
+	// Overrides properties of the process module config (ruxitagentproc.conf) of the injected code modules.
+	// +kubebuilder:validation:Optional
+	ProcessModuleConfig *ProcessModuleConfigSpec `json:"processModuleConfig,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+type ProcessModuleConfigSpec struct {
+	// Properties that are merged on top of the process module config received from the tenant.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=100
+	Properties []ProcessModuleProperty `json:"properties,omitempty"`
+
+	// Named sets of properties that are merged on top of properties for the namespaces that select the profile
+	// with the oneagent.dynatrace.com/process-module-profile annotation.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=map
+	// +listMapKey=name
+	Profiles []ProcessModuleProfile `json:"profiles,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+type ProcessModuleProfile struct {
+	// Name of the profile, referenced by the namespace annotation.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Properties of the profile.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=100
+	Properties []ProcessModuleProperty `json:"properties,omitempty"`
+}
+
+type ProcessModuleProperty struct {
+	// Section of the property, for example general.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Section string `json:"section"`
+
+	// Key of the property, for example logLevel.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+
+	// Value of the property, an empty value removes the property.
+	// +kubebuilder:validation:Optional
+	Value string `json:"value,omitempty"`
 }
 
 // +kubebuilder:object:generate=true
 
 type CodeModulesStatus struct {
 	status.VersionStatus `json:",inline"`
+
+	// Hash of the effective process module config of the code modules.
+	// +kubebuilder:validation:Optional
+	ProcessModuleConfigHash string `json:"processModuleConfigHash,omitempty"`
+
+	// Hashes of the effective process module configs of the profiles, by profile name.
+	// +kubebuilder:validation:Optional
+	ProcessModuleProfileHashes map[string]string `json:"processModuleProfileHashes,omitempty"`
 }
@@ -30,6 +30,14 @@ type Status struct {
 	// Information about OneAgent's connections
 	// +kubebuilder:validation:Optional
 	ConnectionInfo communication.ConnectionInfo `json:"connectionInfoStatus,omitzero"` // Left the "Status" suffix for compatibility
+
+	// Communication endpoints of the network zones used by the node pools and topology zones, by network zone
+	// +kubebuilder:validation:Optional
+	NetworkZoneEndpoints map[string]string `json:"networkZoneEndpoints,omitempty"`
+
+	// Status of the DaemonSets of the configured node pools
+	// +kubebuilder:validation:Optional
+	NodePools map[string]NodePoolStatus `json:"nodePools,omitempty"`
 }
 
 // IsZero reports whether every field is zero. It is required for the `omitzero`
@@ -41,7 +49,9 @@ func (s *Status) IsZero() bool {
 		len(s.Instances) == 0 &&
 		s.LastInstanceStatusUpdate == nil &&
 		s.Healthcheck == nil &&
-		s.ConnectionInfo == communication.ConnectionInfo{}
+		s.ConnectionInfo == communication.ConnectionInfo{} &&
+		len(s.NetworkZoneEndpoints) == 0 &&
+		len(s.NodePools) == 0
 }
 
 // +kubebuilder:object:generate=true
@@ -53,3 +63,16 @@ type Instance struct {
 	// IP address of the pod
 	IPAddress string `json:"ipAddress,omitempty"`
 }
+
+// +kubebuilder:object:generate=true
+
+type NodePoolStatus struct {
+	// Name of the DaemonSet of the node pool
+	DaemonSetName string `json:"daemonSetName"`
+
+	// Number of nodes that should run the OneAgent of the node pool
+	DesiredNumberScheduled int32 `json:"desiredNumberScheduled"`
+
+	// Number of nodes that run a ready OneAgent of the node pool
+	NumberReady int32 `json:"numberReady"`
+}
@@ -112,6 +112,7 @@ func (dk *DynaKube) OneAgent() *oneagent.OneAgent {
 		dk.FF().IsOneAgentPrivileged(),
 		dk.FF().SkipOneAgentLivenessProbe(),
 		dk.GetResourceAttributes(),
+		dk.Spec.TopologyZones,
 	)
 }
 
@@ -0,0 +1,167 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package dynakube
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:Enum=IncreaseMemoryLimit;Recommend
+type RemediationMode string
+
+const (
+	// IncreaseMemoryLimitRemediation raises the memory limit of the affected component.
+	IncreaseMemoryLimitRemediation RemediationMode = "IncreaseMemoryLimit"
+	// RecommendRemediation only reports the recommended memory limit in the status.
+	RecommendRemediation RemediationMode = "Recommend"
+
+	DefaultRemediationRestartThreshold  int32 = 3
+	DefaultRemediationMemoryStepPercent int32 = 25
+)
+
+// RemediableComponents are the components that support remediation.
+var RemediableComponents = []ComponentName{
+	ActiveGateComponent,
+	ExtensionExecutionControllerComponent,
+	OTelCollectorComponent,
+}
+
+type RemediationSpec struct {
+	// Maximum memory limit the operator may set or recommend.
+	// +kubebuilder:validation:Required
+	MaxMemoryLimit resource.Quantity `json:"maxMemoryLimit"`
+
+	// Defines whether the memory limit is raised (IncreaseMemoryLimit) or only recommended in the status (Recommend).
+	// Defaults to Recommend.
+	// +kubebuilder:validation:Optional
+	Mode RemediationMode `json:"mode,omitempty"`
+
+	// Components to remediate, supported are activeGate, extensionExecutionController and otelCollector.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	Components []ComponentName `json:"components"`
+
+	// Number of restarts of a container that was OOMKilled or is in CrashLoopBackOff before the remediation is triggered.
+	// Defaults to 3.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	RestartThreshold *int32 `json:"restartThreshold,omitempty"`
+
+	// Percentage by which the memory limit is raised in each step.
+	// Defaults to 25.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	MemoryStepPercent *int32 `json:"memoryStepPercent,omitempty"`
+}
+
+type RemediationStatus struct {
+	// Memory limit that was recommended or, depending on the mode, set by the operator
+	MemoryLimit resource.Quantity `json:"memoryLimit"`
+
+	// Reason of the last remediation, either OOMKilled or CrashLoopBackOff
+	// +kubebuilder:validation:Optional
+	Reason string `json:"reason,omitempty"`
+
+	// Hash of the resources configured for the component when the remediation started, the remediation is reverted once they change
+	// +kubebuilder:validation:Optional
+	ResourcesHash string `json:"resourcesHash,omitempty"`
+
+	// Time of the last remediation step
+	// +kubebuilder:validation:Optional
+	LastRemediationTime metav1.Time `json:"lastRemediationTime,omitzero"`
+
+	// Number of remediation steps since the resources were last changed
+	Steps int32 `json:"steps"`
+
+	// Indicates that the memory limit reached the configured maximum
+	// +kubebuilder:validation:Optional
+	CeilingReached bool `json:"ceilingReached,omitempty"`
+}
+
+// IsRemediationEnabled checks if pod health remediation is configured for the given component.
+func (dk *DynaKube) IsRemediationEnabled(name ComponentName) bool {
+	return dk.Spec.Remediation != nil && slices.Contains(dk.Spec.Remediation.Components, name)
+}
+
+func (dk *DynaKube) GetRemediationMode() RemediationMode {
+	if dk.Spec.Remediation == nil || dk.Spec.Remediation.Mode == "" {
+		return RecommendRemediation
+	}
+
+	return dk.Spec.Remediation.Mode
+}
+
+func (dk *DynaKube) GetRemediationRestartThreshold() int32 {
+	if dk.Spec.Remediation == nil || dk.Spec.Remediation.RestartThreshold == nil {
+		return DefaultRemediationRestartThreshold
+	}
+
+	return *dk.Spec.Remediation.RestartThreshold
+}
+
+func (dk *DynaKube) GetRemediationMemoryStepPercent() int32 {
+	if dk.Spec.Remediation == nil || dk.Spec.Remediation.MemoryStepPercent == nil {
+		return DefaultRemediationMemoryStepPercent
+	}
+
+	return *dk.Spec.Remediation.MemoryStepPercent
+}
+
+// GetConfiguredResources returns the resources the user configured for a remediable component.
+func (dk *DynaKube) GetConfiguredResources(name ComponentName) corev1.ResourceRequirements {
+	switch name {
+	case ActiveGateComponent:
+		return dk.Spec.ActiveGate.Resources
+	case ExtensionExecutionControllerComponent:
+		return dk.Spec.Templates.ExtensionExecutionController.Resources
+	case OTelCollectorComponent:
+		return dk.Spec.Templates.OpenTelemetryCollector.Resources
+	}
+
+	return corev1.ResourceRequirements{}
+}
+
+// GetRemediatedResources returns the resources for the main container of a component.
+// If the memory limit was raised by the remediation it replaces the configured one, otherwise the configured resources are returned as is.
+func (dk *DynaKube) GetRemediatedResources(name ComponentName) corev1.ResourceRequirements {
+	resources := dk.GetConfiguredResources(name)
+
+	remediation, ok := dk.Status.Remediations[name]
+	if !ok || !dk.IsRemediationEnabled(name) || dk.GetRemediationMode() != IncreaseMemoryLimitRemediation {
+		return resources
+	}
+
+	resources = *resources.DeepCopy()
+	if resources.Limits == nil {
+		resources.Limits = corev1.ResourceList{}
+	}
+
+	resources.Limits[corev1.ResourceMemory] = remediation.MemoryLimit
+
+	return resources
+}
+
+// SetRemediationStatus stores the remediation state of a component.
+func (dk *DynaKubeStatus) SetRemediationStatus(name ComponentName, remediationStatus RemediationStatus) {
+	if dk.Remediations == nil {
+		dk.Remediations = map[ComponentName]RemediationStatus{}
+	}
+
+	dk.Remediations[name] = remediationStatus
+}
+
+// RemoveRemediationStatus reverts the remediation of a component.
+func (dk *DynaKubeStatus) RemoveRemediationStatus(name ComponentName) {
+	delete(dk.Remediations, name)
+
+	if len(dk.Remediations) == 0 {
+		dk.Remediations = nil
+	}
+}
@@ -14,6 +14,9 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/logmonitoring"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/otlp"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/telemetryingest"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/communication"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/istio"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/podtemplate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/value"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -66,6 +69,22 @@ func (in *AvailableOptionalScopes) DeepCopy() *AvailableOptionalScopes {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
+func (in *ComponentStatus) DeepCopy() *ComponentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DynaKube) DeepCopyInto(out *DynaKube) {
 	*out = *in
@@ -172,6 +191,16 @@ func (in *DynaKubeSpec) DeepCopyInto(out *DynaKubeSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.OneAgent.DeepCopyInto(&out.OneAgent)
+	if in.TopologyZones != nil {
+		in, out := &in.TopologyZones, &out.TopologyZones
+		*out = make([]communication.TopologyZone, len(*in))
+		copy(*out, *in)
+	}
+	if in.WorkloadIdentityRegistries != nil {
+		in, out := &in.WorkloadIdentityRegistries, &out.WorkloadIdentityRegistries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	in.Templates.DeepCopyInto(&out.Templates)
 	in.ActiveGate.DeepCopyInto(&out.ActiveGate)
 	if in.KubernetesMonitoring != nil {
@@ -189,6 +218,26 @@ func (in *DynaKubeSpec) DeepCopyInto(out *DynaKubeSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Istio != nil {
+		in, out := &in.Istio, &out.Istio
+		*out = new(istio.Spec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PhasePolicy != nil {
+		in, out := &in.PhasePolicy, &out.PhasePolicy
+		*out = new(PhasePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Remediation != nil {
+		in, out := &in.Remediation, &out.Remediation
+		*out = new(RemediationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicies != nil {
+		in, out := &in.NetworkPolicies, &out.NetworkPolicies
+		*out = new(NetworkPoliciesSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynaKubeSpec.
@@ -210,7 +259,22 @@ func (in *DynaKubeStatus) DeepCopyInto(out *DynaKubeStatus) {
 	in.CodeModules.DeepCopyInto(&out.CodeModules)
 	in.MetadataEnrichment.DeepCopyInto(&out.MetadataEnrichment)
 	out.KSPM = in.KSPM
+	in.Extensions.DeepCopyInto(&out.Extensions)
 	in.UpdatedTimestamp.DeepCopyInto(&out.UpdatedTimestamp)
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make(map[ComponentName]ComponentStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Remediations != nil {
+		in, out := &in.Remediations, &out.Remediations
+		*out = make(map[ComponentName]RemediationStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -231,6 +295,26 @@ func (in *DynaKubeStatus) DeepCopy() *DynaKubeStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPoliciesSpec) DeepCopyInto(out *NetworkPoliciesSpec) {
+	*out = *in
+	if in.KubernetesAPICIDRs != nil {
+		in, out := &in.KubernetesAPICIDRs, &out.KubernetesAPICIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPoliciesSpec.
+func (in *NetworkPoliciesSpec) DeepCopy() *NetworkPoliciesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPoliciesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenTelemetryCollectorSpec) DeepCopyInto(out *OpenTelemetryCollectorSpec) {
 	*out = *in
@@ -276,6 +360,11 @@ func (in *OpenTelemetryCollectorSpec) DeepCopyInto(out *OpenTelemetryCollectorSp
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PodTemplatePatch != nil {
+		in, out := &in.PodTemplatePatch, &out.PodTemplatePatch
+		*out = new(podtemplate.Patch)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenTelemetryCollectorSpec.
@@ -288,6 +377,74 @@ func (in *OpenTelemetryCollectorSpec) DeepCopy() *OpenTelemetryCollectorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhasePolicy) DeepCopyInto(out *PhasePolicy) {
+	*out = *in
+	if in.OptionalComponents != nil {
+		in, out := &in.OptionalComponents, &out.OptionalComponents
+		*out = make([]ComponentName, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhasePolicy.
+func (in *PhasePolicy) DeepCopy() *PhasePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PhasePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationSpec) DeepCopyInto(out *RemediationSpec) {
+	*out = *in
+	out.MaxMemoryLimit = in.MaxMemoryLimit.DeepCopy()
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]ComponentName, len(*in))
+		copy(*out, *in)
+	}
+	if in.RestartThreshold != nil {
+		in, out := &in.RestartThreshold, &out.RestartThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MemoryStepPercent != nil {
+		in, out := &in.MemoryStepPercent, &out.MemoryStepPercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationSpec.
+func (in *RemediationSpec) DeepCopy() *RemediationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationStatus) DeepCopyInto(out *RemediationStatus) {
+	*out = *in
+	out.MemoryLimit = in.MemoryLimit.DeepCopy()
+	in.LastRemediationTime.DeepCopyInto(&out.LastRemediationTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationStatus.
+func (in *RemediationStatus) DeepCopy() *RemediationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TemplatesSpec) DeepCopyInto(out *TemplatesSpec) {
 	*out = *in
@@ -0,0 +1,9 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package autoscaling
+
+// IsAutoMode checks if the VerticalPodAutoscaler is configured and allowed to apply its recommendations.
+func (spec *VerticalPodAutoscalerSpec) IsAutoMode() bool {
+	return spec != nil && spec.Mode == AutoMode
+}
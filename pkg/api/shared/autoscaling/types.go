@@ -0,0 +1,33 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package autoscaling
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// +kubebuilder:object:generate=true
+
+type VerticalPodAutoscalerSpec struct {
+	// Defines whether the VerticalPodAutoscaler only provides recommendations (Recommend) or also applies them by evicting pods (Auto).
+	// Defaults to Recommend.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Recommend;Auto
+	Mode VerticalPodAutoscalerMode `json:"mode,omitempty"`
+
+	// Lower bound for the resources the VerticalPodAutoscaler may recommend.
+	// +kubebuilder:validation:Optional
+	MinAllowed corev1.ResourceList `json:"minAllowed,omitempty"`
+
+	// Upper bound for the resources the VerticalPodAutoscaler may recommend.
+	// +kubebuilder:validation:Optional
+	MaxAllowed corev1.ResourceList `json:"maxAllowed,omitempty"`
+}
+
+type VerticalPodAutoscalerMode string
+
+const (
+	RecommendMode VerticalPodAutoscalerMode = "Recommend"
+	AutoMode      VerticalPodAutoscalerMode = "Auto"
+)
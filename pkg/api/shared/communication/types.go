@@ -22,3 +22,19 @@ type ConnectionInfo struct {
 	// Hash of the tenant token
 	TenantTokenHash string `json:"tenantTokenHash,omitempty"`
 }
+
+// +kubebuilder:object:generate=true
+
+// TopologyZone maps a Kubernetes topology zone to a Dynatrace network zone.
+type TopologyZone struct {
+	// Value of the topology.kubernetes.io/zone label of the nodes in the zone.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Zone string `json:"zone"`
+
+	// Network zone used by the OneAgent and ActiveGate pods in the zone.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	NetworkZone string `json:"networkZone"`
+}
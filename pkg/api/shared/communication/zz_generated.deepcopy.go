@@ -24,3 +24,18 @@ func (in *ConnectionInfo) DeepCopy() *ConnectionInfo {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologyZone) DeepCopyInto(out *TopologyZone) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyZone.
+func (in *TopologyZone) DeepCopy() *TopologyZone {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologyZone)
+	in.DeepCopyInto(out)
+	return out
+}
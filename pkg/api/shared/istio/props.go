@@ -0,0 +1,29 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package istio
+
+func (spec *Spec) IsAmbientMode() bool {
+	return spec != nil && spec.Mode == AmbientMode
+}
+
+// GetWaypoint returns the name of the waypoint, it is only relevant in ambient mode.
+func (spec *Spec) GetWaypoint() string {
+	if !spec.IsAmbientMode() {
+		return ""
+	}
+
+	return spec.Waypoint
+}
+
+func (spec *Spec) IsMTLSEnabled() bool {
+	return spec != nil && spec.MTLS != nil
+}
+
+func (spec *Spec) GetMTLSMode() MTLSMode {
+	if !spec.IsMTLSEnabled() || spec.MTLS.Mode == "" {
+		return PermissiveMTLSMode
+	}
+
+	return spec.MTLS.Mode
+}
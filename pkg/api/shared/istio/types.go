@@ -0,0 +1,46 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package istio
+
+// +kubebuilder:object:generate=true
+
+type Spec struct {
+	// Data plane mode of the Istio installation, either sidecar (default) or ambient.
+	// In ambient mode no VirtualServices are created, because ztunnel and waypoint proxies do not use them for egress traffic.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=sidecar;ambient
+	Mode Mode `json:"mode,omitempty"`
+
+	// Name of the waypoint proxy, in the namespace of the custom resource, that handles the egress traffic in ambient mode.
+	// The ServiceEntries are bound to the waypoint and an AuthorizationPolicy is created for each of them,
+	// which only allows traffic from the namespace of the custom resource.
+	// +kubebuilder:validation:Optional
+	Waypoint string `json:"waypoint,omitempty"`
+
+	// Configures mutual TLS for the in-cluster endpoints of the components by creating PeerAuthentication and DestinationRule objects.
+	// +kubebuilder:validation:Optional
+	MTLS *MTLSSpec `json:"mtls,omitempty"`
+}
+
+type MTLSSpec struct {
+	// Mode of the PeerAuthentication, either PERMISSIVE (default) or STRICT.
+	// STRICT rejects plain text traffic, so all clients of the components, e.g. the OneAgents on the hosts, have to be part of the mesh.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=STRICT;PERMISSIVE
+	Mode MTLSMode `json:"mode,omitempty"`
+}
+
+type Mode string
+
+const (
+	SidecarMode Mode = "sidecar"
+	AmbientMode Mode = "ambient"
+)
+
+type MTLSMode string
+
+const (
+	StrictMTLSMode     MTLSMode = "STRICT"
+	PermissiveMTLSMode MTLSMode = "PERMISSIVE"
+)
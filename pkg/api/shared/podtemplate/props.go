@@ -0,0 +1,13 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package podtemplate
+
+// GetType returns the type of the patch, StrategicMerge if none is set.
+func (p *Patch) GetType() PatchType {
+	if p.Type == "" {
+		return StrategicMergePatchType
+	}
+
+	return p.Type
+}
@@ -0,0 +1,31 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package podtemplate
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// +kubebuilder:object:generate=true
+
+// Patch is applied to the pod template of an operand after the operator has built it.
+type Patch struct {
+	// Type of the patch, StrategicMerge (default) or JSON (RFC 6902).
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=StrategicMerge;JSON
+	Type PatchType `json:"type,omitempty"`
+
+	// The patch, a partial pod template (metadata and spec) for StrategicMerge
+	// or a list of operations with paths relative to the pod template (e.g. /spec/hostAliases) for JSON.
+	// Operator-owned fields, like the labels used by selectors, the service account and the images of the operator's containers, can not be patched.
+	// +kubebuilder:validation:Required
+	Patch apiextensionsv1.JSON `json:"patch"`
+}
+
+type PatchType string
+
+const (
+	StrategicMergePatchType PatchType = "StrategicMerge"
+	JSONPatchType           PatchType = "JSON"
+)
@@ -28,12 +28,51 @@ type EdgeConnectStatus struct { //nolint:revive
 	// kube-system namespace uid
 	KubeSystemUID string `json:"kubeSystemUID,omitempty"`
 
+	// Connectivity of the EdgeConnect instances to the tenant and reachability of the host patterns
+	// +kubebuilder:validation:Optional
+	Connectivity ConnectivityStatus `json:"connectivity,omitzero"`
+
 	// Conditions includes status about the current state of the instance
 	// +listType=map
 	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+type ConnectivityStatus struct {
+	// Indicates when the connectivity was probed the last time
+	// +kubebuilder:validation:Optional
+	LastProbeTimestamp metav1.Time `json:"lastProbeTimestamp,omitzero"`
+
+	// EdgeConnect instances that are connected to the tenant, only available when provisioning is enabled
+	// +kubebuilder:validation:Optional
+	Instances []InstanceStatus `json:"instances,omitempty"`
+
+	// Reachability of the probed hosts from inside the cluster
+	// +kubebuilder:validation:Optional
+	Hosts []HostReachabilityStatus `json:"hosts,omitempty"`
+}
+
+type InstanceStatus struct {
+	// ID of the instance reported by the tenant
+	ID string `json:"id"`
+
+	// Version of the instance reported by the tenant
+	// +kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
+}
+
+type HostReachabilityStatus struct {
+	// Probed host
+	Host string `json:"host"`
+
+	// Indicates if a connection to the host could be established
+	Reachable bool `json:"reachable"`
+
+	// Reason why the host is not reachable
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
 // SetPhase sets the status phase on the EdgeConnect object.
 func (dk *EdgeConnectStatus) SetPhase(phase status.DeploymentPhase) bool {
 	upd := phase != dk.DeploymentPhase
@@ -10,9 +10,12 @@ package edgeconnect
 
 import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/image"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/istio"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/proxy"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // Hub tags this version as the 'source' of the conversion for controller runtime.
@@ -83,9 +86,117 @@ type EdgeConnectSpec struct { //nolint:revive
 	// Sets topology spread constraints for the EdgeConnect pods
 	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
 
+	// Sets the affinity for the EdgeConnect pods, if not set the pods are preferably scheduled on different nodes
+	// +kubebuilder:validation:Optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// Enables horizontal autoscaling of the EdgeConnect pods, replaces the static amount of replicas
+	// +kubebuilder:validation:Optional
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
+
+	// Configures the PodDisruptionBudget for the EdgeConnect pods
+	// +kubebuilder:validation:Optional
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+
+	// Configures the health probes that verify the EdgeConnect instances are connected and the host patterns are reachable
+	// +kubebuilder:validation:Optional
+	HealthProbes *HealthProbesSpec `json:"healthProbes,omitempty"`
+
+	// When enabled, and if Istio is installed, ServiceEntries are created for the outbound hosts of EdgeConnect:
+	// the API server, the OAuth endpoint, the host patterns without wildcards and the targets of the host mappings.
+	// +kubebuilder:validation:Optional
+	EnableIstio *bool `json:"enableIstio,omitempty"`
+
+	// Configures how EdgeConnect is integrated into the Istio service mesh, only considered if enableIstio is true.
+	// +kubebuilder:validation:Optional
+	Istio *istio.Spec `json:"istio,omitempty"`
+
 	// Host patterns to be set in the tenant, only considered when provisioning is enabled.
 	// +kubebuilder:validation:Optional
 	HostPatterns []string `json:"hostPatterns,omitempty"`
+
+	// Host mappings to be set in the tenant, only considered when provisioning is enabled.
+	// +kubebuilder:validation:Optional
+	HostMappings []HostMapping `json:"hostMappings,omitempty"`
+
+	// Secrets that EdgeConnect can use in requests to your internal resources, the values are read from Kubernetes Secrets.
+	// +kubebuilder:validation:Optional
+	Secrets []SecretSpec `json:"secrets,omitempty"`
+}
+
+type HostMapping struct {
+	// Host pattern that is mapped, has to match one of the host patterns of the EdgeConnect
+	// +kubebuilder:validation:Required
+	From string `json:"from"`
+	// Host that requests matching the host pattern are forwarded to
+	// +kubebuilder:validation:Required
+	To string `json:"to"`
+}
+
+type SecretSpec struct {
+	// Name of the secret, used to reference it in requests sent through the EdgeConnect
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// Key of the Kubernetes Secret, in the namespace of the EdgeConnect, that holds the value of the secret
+	// +kubebuilder:validation:Required
+	SecretRef corev1.SecretKeySelector `json:"secretRef"`
+	// Restricts the usage of the secret to the specified hosts
+	// +kubebuilder:validation:Optional
+	RestrictHostsTo []string `json:"restrictHostsTo,omitempty"`
+}
+
+type AutoscalingSpec struct {
+	// Lower limit for the amount of replicas (the default value is: 1)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// Upper limit for the amount of replicas
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+	// Metrics used to calculate the desired amount of replicas, supports resource (CPU, memory), pods, object and external metrics (the default is: 80% average CPU utilization)
+	// +kubebuilder:validation:Optional
+	Metrics []autoscalingv2.MetricSpec `json:"metrics,omitempty"`
+	// Configures the scaling behavior in both directions
+	// +kubebuilder:validation:Optional
+	Behavior *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+}
+
+type PodDisruptionBudgetSpec struct {
+	// Enables the PodDisruptionBudget for the EdgeConnect pods (the default value is: true)
+	// +kubebuilder:validation:Optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Amount or percentage of pods that have to be available during a voluntary disruption, mutually exclusive with maxUnavailable
+	// +kubebuilder:validation:Optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+	// Amount or percentage of pods that can be unavailable during a voluntary disruption (the default value is: 1)
+	// +kubebuilder:validation:Optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+type HealthProbesSpec struct {
+	// Interval in seconds between two health probes (the default value is: 300)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=30
+	IntervalSeconds *int32 `json:"intervalSeconds,omitempty"`
+	// Enables reachability checks of the host patterns from inside the cluster
+	// +kubebuilder:validation:Optional
+	Reachability *ReachabilitySpec `json:"reachability,omitempty"`
+}
+
+type ReachabilitySpec struct {
+	// Hosts that are checked for reachability (the default value is: the host patterns without wildcards)
+	// +kubebuilder:validation:Optional
+	Hosts []string `json:"hosts,omitempty"`
+	// Port used to connect to the hosts (the default value is: 443)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port *int32 `json:"port,omitempty"`
+	// Timeout in seconds for connecting to a single host (the default value is: 5)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
 }
 
 type OAuthSpec struct {
@@ -54,4 +54,34 @@ func Test_EdgeConnect_HostMappings(t *testing.T) {
 		}
 		require.Equal(t, expected, got)
 	})
+
+	t.Run("Get HostMappings with user defined mappings", func(t *testing.T) {
+		e := EdgeConnect{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-edgeconnect",
+				Namespace: "test-namespace",
+			},
+			Spec: EdgeConnectSpec{
+				HostMappings: []HostMapping{
+					{From: "internal.example.org", To: "internal.example.svc.cluster.local"},
+					{From: "test-edgeconnect.test-namespace.test-kube-system-uid." + kubernetesHostnameSuffix, To: "other.svc"},
+				},
+			},
+			Status: EdgeConnectStatus{
+				KubeSystemUID: "test-kube-system-uid",
+			},
+		}
+		got := e.HostMappings()
+		expected := []HostMapping{
+			{
+				From: "test-edgeconnect.test-namespace.test-kube-system-uid." + kubernetesHostnameSuffix,
+				To:   KubernetesDefaultDNS,
+			},
+			{
+				From: "internal.example.org",
+				To:   "internal.example.svc.cluster.local",
+			},
+		}
+		require.Equal(t, expected, got)
+	})
 }
@@ -5,6 +5,7 @@ package edgeconnect
 
 import (
 	"strings"
+	"time"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api"
 	corev1 "k8s.io/api/core/v1"
@@ -17,6 +18,10 @@ const (
 	MaxNameLength = 40
 
 	defaultEdgeConnectRepository = "docker.io/dynatrace/edgeconnect"
+
+	defaultHealthProbeInterval = 5 * time.Minute
+	defaultReachabilityPort    = int32(443)
+	defaultReachabilityTimeout = 5 * time.Second
 )
 
 func (ec *EdgeConnect) Image() string {
@@ -48,6 +53,64 @@ func (ec *EdgeConnect) IsK8SAutomationEnabled() bool {
 	return ec.Spec.KubernetesAutomation != nil && ec.Spec.KubernetesAutomation.Enabled
 }
 
+func (ec *EdgeConnect) IsAutoscalingEnabled() bool {
+	return ec.Spec.Autoscaling != nil
+}
+
+func (ec *EdgeConnect) IsPodDisruptionBudgetEnabled() bool {
+	return ec.Spec.PodDisruptionBudget == nil || ec.Spec.PodDisruptionBudget.Enabled == nil || *ec.Spec.PodDisruptionBudget.Enabled
+}
+
+func (ec *EdgeConnect) IsReachabilityCheckEnabled() bool {
+	return ec.Spec.HealthProbes != nil && ec.Spec.HealthProbes.Reachability != nil
+}
+
+// HealthProbeInterval returns the interval between two health probes of the EdgeConnect.
+func (ec *EdgeConnect) HealthProbeInterval() time.Duration {
+	if ec.Spec.HealthProbes == nil || ec.Spec.HealthProbes.IntervalSeconds == nil {
+		return defaultHealthProbeInterval
+	}
+
+	return time.Duration(*ec.Spec.HealthProbes.IntervalSeconds) * time.Second
+}
+
+// ReachabilityHosts returns the hosts that are probed for reachability, wildcard host patterns can't be probed and are skipped.
+func (ec *EdgeConnect) ReachabilityHosts() []string {
+	if !ec.IsReachabilityCheckEnabled() {
+		return nil
+	}
+
+	if len(ec.Spec.HealthProbes.Reachability.Hosts) > 0 {
+		return ec.Spec.HealthProbes.Reachability.Hosts
+	}
+
+	hosts := make([]string, 0, len(ec.Spec.HostPatterns))
+
+	for _, hostPattern := range ec.Spec.HostPatterns {
+		if !strings.Contains(hostPattern, "*") {
+			hosts = append(hosts, hostPattern)
+		}
+	}
+
+	return hosts
+}
+
+func (ec *EdgeConnect) ReachabilityPort() int32 {
+	if !ec.IsReachabilityCheckEnabled() || ec.Spec.HealthProbes.Reachability.Port == nil {
+		return defaultReachabilityPort
+	}
+
+	return *ec.Spec.HealthProbes.Reachability.Port
+}
+
+func (ec *EdgeConnect) ReachabilityTimeout() time.Duration {
+	if !ec.IsReachabilityCheckEnabled() || ec.Spec.HealthProbes.Reachability.TimeoutSeconds == nil {
+		return defaultReachabilityTimeout
+	}
+
+	return time.Duration(*ec.Spec.HealthProbes.Reachability.TimeoutSeconds) * time.Second
+}
+
 func (ec *EdgeConnect) EmptyPullSecret() corev1.Secret {
 	return corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -77,13 +140,16 @@ func (ec *EdgeConnect) HostPatterns() []string {
 	return hostPatterns
 }
 
-type HostMapping struct {
-	From string `json:"from"`
-	To   string `json:"to"`
-}
-
 func (ec *EdgeConnect) HostMappings() []HostMapping {
-	return []HostMapping{{From: ec.K8sAutomationHostPattern(), To: KubernetesDefaultDNS}}
+	hostMappings := []HostMapping{{From: ec.K8sAutomationHostPattern(), To: KubernetesDefaultDNS}}
+
+	for _, hostMapping := range ec.Spec.HostMappings {
+		if !strings.EqualFold(hostMapping.From, ec.K8sAutomationHostPattern()) {
+			hostMappings = append(hostMappings, hostMapping)
+		}
+	}
+
+	return hostMappings
 }
 
 func (ec *EdgeConnect) K8sAutomationHostPattern() string {
@@ -6,10 +6,12 @@ package edgeconnect
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/edgeconnect/consts"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -62,3 +64,56 @@ func (ec *EdgeConnect) GetOAuthClientFromSecret(ctx context.Context, kubeReader
 
 	return oAuth, nil
 }
+
+// SecretValues returns the values of the secrets defined in the spec, keyed by the name of the secret.
+func (ec *EdgeConnect) SecretValues(ctx context.Context, kubeReader client.Reader) (map[string][]byte, error) {
+	values := make(map[string][]byte, len(ec.Spec.Secrets))
+
+	for _, secretSpec := range ec.Spec.Secrets {
+		var secret corev1.Secret
+
+		isOptional := secretSpec.SecretRef.Optional != nil && *secretSpec.SecretRef.Optional
+
+		err := kubeReader.Get(ctx, client.ObjectKey{Name: secretSpec.SecretRef.Name, Namespace: ec.Namespace}, &secret)
+		if k8serrors.IsNotFound(err) && isOptional {
+			continue
+		} else if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("failed to get value of %s from %s secret", secretSpec.Name, secretSpec.SecretRef.Name))
+		}
+
+		value, hasKey := secret.Data[secretSpec.SecretRef.Key]
+		if !hasKey && isOptional {
+			continue
+		} else if !hasKey {
+			return nil, errors.Errorf("missing key %s in secret %s", secretSpec.SecretRef.Key, secretSpec.SecretRef.Name)
+		}
+
+		values[secretSpec.Name] = value
+	}
+
+	return values, nil
+}
+
+// ReferencedSecretNames returns the names of the secrets used in the spec, for the OAuth client, the proxy and as values of secrets.
+func (ec *EdgeConnect) ReferencedSecretNames() []string {
+	var secretNames []string
+
+	if ec.Spec.OAuth.ClientSecret != "" {
+		secretNames = append(secretNames, ec.Spec.OAuth.ClientSecret)
+	}
+
+	if ec.Spec.Proxy != nil && ec.Spec.Proxy.AuthRef != "" {
+		secretNames = append(secretNames, ec.Spec.Proxy.AuthRef)
+	}
+
+	for _, secretSpec := range ec.Spec.Secrets {
+		secretNames = append(secretNames, secretSpec.SecretRef.Name)
+	}
+
+	return secretNames
+}
+
+// IsReferencingSecret checks if the given secret is used in the spec, either for the OAuth client, the proxy or as a value of a secret.
+func (ec *EdgeConnect) IsReferencingSecret(secretName string) bool {
+	return slices.Contains(ec.ReferencedSecretNames(), secretName)
+}
@@ -0,0 +1,70 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	agconsts "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+)
+
+const (
+	errorInvalidActiveGateGroupCapability = `The DynaKube's specification tries to use an invalid capability in ActiveGate group %s, invalid capability=%s.
+Make sure you correctly specify the ActiveGate group capabilities in your custom resource.
+`
+
+	errorActiveGateGroupKubeMon = `The DynaKube's specification enables the kubernetes-monitoring capability in ActiveGate group %s. Kubernetes monitoring is only supported by the main ActiveGate, please configure it in spec.activeGate.capabilities.`
+
+	errorActiveGateGroupNameTooLong = `The name of the StatefulSet of ActiveGate group %s (<DynaKube name>-` + agconsts.MultiActiveGateName + `-<group name>) exceeds the limit of %d characters. Use a shorter name for the DynaKube or the group.`
+)
+
+func invalidActiveGateGroupCapabilities(ctx context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	log := logd.FromContext(ctx)
+
+	for _, group := range dk.Spec.ActiveGate.Groups {
+		for _, capability := range group.Capabilities {
+			if _, ok := activegate.CapabilityDisplayNames[capability]; !ok {
+				log.Info("requested dynakube has invalid active gate group capability", "group", group.Name)
+
+				return fmt.Sprintf(errorInvalidActiveGateGroupCapability, group.Name, capability)
+			}
+		}
+	}
+
+	return ""
+}
+
+func kubeMonInActiveGateGroup(ctx context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	log := logd.FromContext(ctx)
+
+	for _, group := range dk.Spec.ActiveGate.Groups {
+		if slices.Contains(group.Capabilities, activegate.KubeMonCapability.DisplayName) {
+			log.Info("requested dynakube enables kubernetes monitoring in an ActiveGate group", "group", group.Name)
+
+			return fmt.Sprintf(errorActiveGateGroupKubeMon, group.Name)
+		}
+	}
+
+	return ""
+}
+
+// activeGateGroupNameTooLong checks the name of the group StatefulSet, which is also used for the group Service.
+func activeGateGroupNameTooLong(ctx context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	log := logd.FromContext(ctx)
+
+	for _, group := range dk.Spec.ActiveGate.Groups {
+		if len(dk.Name)+len("-"+agconsts.MultiActiveGateName+"-")+len(group.Name) > maxStatefulSetNameLength {
+			log.Info("requested dynakube has an ActiveGate group with a too long name", "group", group.Name)
+
+			return fmt.Sprintf(errorActiveGateGroupNameTooLong, group.Name, maxStatefulSetNameLength)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,84 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestActiveGateGroups(t *testing.T) {
+	newDynaKube := func(capabilities ...activegate.CapabilityDisplayName) *dynakube.DynaKube {
+		return &dynakube.DynaKube{
+			ObjectMeta: defaultDynakubeObjectMeta,
+			Spec: dynakube.DynaKubeSpec{
+				APIURL: testAPIURL,
+				ActiveGate: activegate.Spec{
+					Capabilities: []activegate.CapabilityDisplayName{activegate.KubeMonCapability.DisplayName},
+					CapabilityProperties: activegate.CapabilityProperties{
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceLimitsMemory: *resource.NewMilliQuantity(1, ""),
+							},
+						},
+					},
+					Groups: []activegate.GroupSpec{
+						{Name: "routing", Capabilities: capabilities},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("valid group", func(t *testing.T) {
+		assertAllowedWithoutWarnings(t, newDynaKube(activegate.RoutingCapability.DisplayName, activegate.DynatraceAPICapability.DisplayName))
+	})
+
+	t.Run("invalid capability in group", func(t *testing.T) {
+		assertDenied(t,
+			[]string{fmt.Sprintf(errorInvalidActiveGateGroupCapability, "routing", "invalid-capability")},
+			newDynaKube("invalid-capability"))
+	})
+
+	t.Run("kubernetes monitoring in group", func(t *testing.T) {
+		assertDenied(t,
+			[]string{fmt.Sprintf(errorActiveGateGroupKubeMon, "routing")},
+			newDynaKube(activegate.KubeMonCapability.DisplayName))
+	})
+
+	t.Run("group name length", func(t *testing.T) {
+		// <dk name>-activegate-<group name> must not exceed maxStatefulSetNameLength (52)
+		testCases := []struct {
+			name      string
+			dkName    string
+			groupName string
+			allowed   bool
+		}{
+			{name: "short names", dkName: "dynakube", groupName: "routing", allowed: true},
+			{name: "at the limit", dkName: strings.Repeat("a", 20), groupName: strings.Repeat("b", 20), allowed: true},
+			{name: "over the limit", dkName: strings.Repeat("a", 21), groupName: strings.Repeat("b", 20), allowed: false},
+			{name: "max DynaKube and group name", dkName: strings.Repeat("a", dynakube.MaxNameLength), groupName: strings.Repeat("b", 20), allowed: false},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				dk := newDynaKube(activegate.RoutingCapability.DisplayName)
+				dk.Name = tc.dkName
+				dk.Spec.ActiveGate.Groups[0].Name = tc.groupName
+
+				if tc.allowed {
+					assertAllowed(t, dk)
+				} else {
+					assertDenied(t, []string{fmt.Sprintf(errorActiveGateGroupNameTooLong, tc.groupName, maxStatefulSetNameLength)}, dk)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,63 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"context"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+)
+
+const (
+	errorMultipleSyntheticActiveGates = `The DynaKube's specification enables the synthetic capability more than once. Only one ActiveGate or ActiveGate group can serve the private Synthetic location.`
+
+	warningUnusedSyntheticSpec = `The DynaKube's specification configures spec.activeGate.synthetic, but the synthetic capability is not enabled for the ActiveGate or any ActiveGate group. The configuration will be ignored.`
+
+	warningSyntheticWithoutActivationGroup = `The synthetic capability is enabled without an ActiveGate activation group. The ActiveGates of the private Synthetic location are assigned by their activation group, please set the group of the synthetic-enabled ActiveGates.`
+)
+
+func multipleSyntheticActiveGates(ctx context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	count := 0
+
+	if dk.ActiveGate().IsMode(activegate.SyntheticCapability.DisplayName) {
+		count++
+	}
+
+	for _, group := range dk.Spec.ActiveGate.Groups {
+		if group.IsMode(activegate.SyntheticCapability.DisplayName) {
+			count++
+		}
+	}
+
+	if count > 1 {
+		logd.FromContext(ctx).Info("requested dynakube enables the synthetic capability more than once")
+
+		return errorMultipleSyntheticActiveGates
+	}
+
+	return ""
+}
+
+func unusedSyntheticSpec(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	if dk.Spec.ActiveGate.Synthetic != nil && !dk.ActiveGate().IsSyntheticEnabled() {
+		return warningUnusedSyntheticSpec
+	}
+
+	return ""
+}
+
+func syntheticWithoutActivationGroup(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	groupName, ok := dk.ActiveGate().GetSyntheticGroupName()
+	if !ok || dk.Spec.ActiveGate.Group != "" {
+		return ""
+	}
+
+	if group := dk.ActiveGate().GetGroup(groupName); group != nil && group.Group != "" {
+		return ""
+	}
+
+	return warningSyntheticWithoutActivationGroup
+}
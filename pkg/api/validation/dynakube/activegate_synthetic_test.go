@@ -0,0 +1,73 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestActiveGateSynthetic(t *testing.T) {
+	newDynaKube := func() *dynakube.DynaKube {
+		return &dynakube.DynaKube{
+			ObjectMeta: defaultDynakubeObjectMeta,
+			Spec: dynakube.DynaKubeSpec{
+				APIURL: testAPIURL,
+				ActiveGate: activegate.Spec{
+					Capabilities: []activegate.CapabilityDisplayName{activegate.SyntheticCapability.DisplayName},
+					CapabilityProperties: activegate.CapabilityProperties{
+						Group: "synthetic",
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceLimitsMemory: *resource.NewMilliQuantity(1, ""),
+							},
+						},
+					},
+					Synthetic: &activegate.SyntheticSpec{LoadProfile: activegate.SyntheticLoadProfileM},
+				},
+			},
+		}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		assertAllowedWithoutWarnings(t, newDynaKube())
+	})
+
+	t.Run("synthetic in group", func(t *testing.T) {
+		dk := newDynaKube()
+		dk.Spec.ActiveGate.Capabilities = []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName}
+		dk.Spec.ActiveGate.Groups = []activegate.GroupSpec{
+			{Name: "synthetic", Capabilities: []activegate.CapabilityDisplayName{activegate.SyntheticCapability.DisplayName}},
+		}
+
+		assertAllowedWithoutWarnings(t, dk)
+	})
+
+	t.Run("synthetic more than once", func(t *testing.T) {
+		dk := newDynaKube()
+		dk.Spec.ActiveGate.Groups = []activegate.GroupSpec{
+			{Name: "synthetic", Capabilities: []activegate.CapabilityDisplayName{activegate.SyntheticCapability.DisplayName}},
+		}
+
+		assertDenied(t, []string{errorMultipleSyntheticActiveGates}, dk)
+	})
+
+	t.Run("unused synthetic configuration", func(t *testing.T) {
+		dk := newDynaKube()
+		dk.Spec.ActiveGate.Capabilities = []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName}
+
+		assertAllowedWithWarnings(t, 1, dk)
+	})
+
+	t.Run("no activation group", func(t *testing.T) {
+		dk := newDynaKube()
+		dk.Spec.ActiveGate.Group = ""
+
+		assertAllowedWithWarnings(t, 1, dk)
+	})
+}
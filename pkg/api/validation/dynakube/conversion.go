@@ -0,0 +1,60 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/conversion/roundtrip"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/exp"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+)
+
+const (
+	errorLossyConversion   = `The DynaKube contains fields that would be lost if it was written with an older API version: %s. Remove the fields or the '` + exp.ConversionCheckKey + `' annotation.`
+	warningLossyConversion = `The DynaKube contains fields that would be lost if it was written with an older API version: %s.`
+)
+
+func lossyConversion(ctx context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	if dk.FF().GetConversionCheck() != exp.ConversionCheckReject {
+		return ""
+	}
+
+	if lossyFields := getLossyFields(ctx, dk); lossyFields != "" {
+		return fmt.Sprintf(errorLossyConversion, lossyFields)
+	}
+
+	return ""
+}
+
+func lossyConversionWarning(ctx context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	if dk.FF().GetConversionCheck() != exp.ConversionCheckWarn {
+		return ""
+	}
+
+	if lossyFields := getLossyFields(ctx, dk); lossyFields != "" {
+		return fmt.Sprintf(warningLossyConversion, lossyFields)
+	}
+
+	return ""
+}
+
+func getLossyFields(ctx context.Context, dk *dynakube.DynaKube) string {
+	diffs, err := roundtrip.Check(dk)
+	if err != nil {
+		logd.FromContext(ctx).Info("failed to check conversion round trip", "error", err)
+
+		return ""
+	}
+
+	lossyFields := make([]string, 0, len(diffs))
+	for _, diff := range diffs {
+		lossyFields = append(lossyFields, diff.String())
+	}
+
+	return strings.Join(lossyFields, ", ")
+}
@@ -0,0 +1,54 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/exp"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLossyConversion(t *testing.T) {
+	const lossyField = "spec.oneAgent.hostMonitoring.nodePools is lost in v1beta5"
+
+	newDynakube := func(conversionCheck string, nodePools ...oneagent.NodePoolSpec) *dynakube.DynaKube {
+		return &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        testName,
+				Namespace:   testNamespace,
+				Annotations: map[string]string{exp.ConversionCheckKey: conversionCheck},
+			},
+			Spec: dynakube.DynaKubeSpec{
+				APIURL: testAPIURL,
+				OneAgent: oneagent.Spec{
+					HostMonitoring: &oneagent.HostInjectSpec{NodePools: nodePools},
+				},
+			},
+		}
+	}
+	nodePool := oneagent.NodePoolSpec{Name: "gpu", NodeSelector: map[string]string{"pool": "gpu"}}
+
+	t.Run("no lossy fields", func(t *testing.T) {
+		assertAllowedWithoutWarnings(t, newDynakube(exp.ConversionCheckReject))
+	})
+
+	t.Run("lossy fields are ignored by default", func(t *testing.T) {
+		assertAllowedWithoutWarnings(t, newDynakube("", nodePool))
+	})
+
+	t.Run("lossy fields are rejected", func(t *testing.T) {
+		assertDenied(t, []string{fmt.Sprintf(errorLossyConversion, lossyField)}, newDynakube(exp.ConversionCheckReject, nodePool))
+	})
+
+	t.Run("lossy fields are reported as warning", func(t *testing.T) {
+		warnings, _ := assertAllowed(t, newDynakube(exp.ConversionCheckWarn, nodePool))
+
+		assert.Equal(t, []string{fmt.Sprintf(warningLossyConversion, lossyField)}, []string(warnings))
+	})
+}
@@ -53,8 +53,9 @@ func conflictingOrInvalidDatabasesVolumeMounts(ctx context.Context, _ *Validator
 		return ""
 	}
 
-	defaultVolumeMounts := databases.GetDefaultVolumeMounts(dk)
 	for _, database := range dk.Spec.Extensions.Databases {
+		defaultVolumeMounts := databases.GetDatabaseVolumeMounts(dk, database)
+
 		for _, volumeMount := range database.VolumeMounts {
 			volumeFound := slices.ContainsFunc(database.Volumes, func(volume corev1.Volume) bool {
 				return volume.Name == volumeMount.Name
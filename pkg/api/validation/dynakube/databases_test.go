@@ -183,6 +183,27 @@ func TestConflictingOrInvalidVolumeMounts(t *testing.T) {
 		assertDenied(t, expectedErrors, dk)
 	})
 
+	t.Run("credentials secret mount used => conflicts => fail", func(t *testing.T) {
+		volumeName := "some-volume"
+
+		dbSpec := extensions.DatabaseSpec{
+			ID:                   testName,
+			CredentialsSecretRef: &corev1.LocalObjectReference{Name: "db-credentials"},
+			Volumes:              []corev1.Volume{{Name: volumeName}},
+		}
+		dk := baseDK.DeepCopy()
+
+		credentialsVolumeMount := databases.GetDatabaseVolumeMounts(dk, dbSpec)[len(databases.GetDefaultVolumeMounts(dk))]
+		dbSpec.VolumeMounts = []corev1.VolumeMount{{Name: volumeName, MountPath: credentialsVolumeMount.MountPath}}
+
+		dk.Spec.Extensions.Databases = append(dk.Spec.Extensions.Databases, dbSpec)
+
+		expectedErrors := []string{
+			fmt.Sprintf(errorConflictingDatabasesVolumeMounts, credentialsVolumeMount.MountPath, credentialsVolumeMount.MountPath),
+		}
+		assertDenied(t, expectedErrors, dk)
+	})
+
 	t.Run("invalid volume mount path => error", func(t *testing.T) {
 		volumeName := "vol123"
 		volumeMountPath := "not/absolute"
@@ -16,6 +16,7 @@ import (
 const (
 	errorExtensionExecutionControllerImageNotSpecified       = `DynaKube's specification enables extensions, make sure you correctly specify the ExtensionExecutionController image.`
 	errorExtensionExecutionControllerInvalidPVCConfiguration = `DynaKube specifies a PVC for the extension controller while ephemeral volume is also enabled. These settings are mutually exclusive, please choose only one.`
+	errorExtensionExecutionControllerPDBMinAndMaxConfigured  = `spec.templates.extensionExecutionController.podDisruptionBudget.minAvailable and maxUnavailable are mutually exclusive! Please set only one of them.`
 	warningConflictingAPIURLForExtensions                    = `You are already using a Dynakube ('%s') that enables extensions. Having multiple Dynakubes with same '.spec.apiUrl' and '.spec.extensions' enabled can have severe side-effects on “sum” and “count” metrics and cause double-billing.`
 )
 
@@ -85,3 +86,12 @@ func extensionControllerPVCStorageDevice(ctx context.Context, _ *Validator, dk *
 func extensionControllerMutuallyExclusivePVCSettings(dk *dynakube.DynaKube) bool {
 	return ptr.Deref(dk.Spec.Templates.ExtensionExecutionController.UseEphemeralVolume, false) && dk.Spec.Templates.ExtensionExecutionController.PersistentVolumeClaim != nil
 }
+
+func extensionControllerPodDisruptionBudget(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	pdb := dk.Spec.Templates.ExtensionExecutionController.PodDisruptionBudget
+	if dk.Extensions().IsAnyEnabled() && pdb != nil && pdb.MinAvailable != nil && pdb.MaxUnavailable != nil {
+		return errorExtensionExecutionControllerPDBMinAndMaxConfigured
+	}
+
+	return ""
+}
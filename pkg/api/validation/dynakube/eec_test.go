@@ -17,6 +17,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func TestExtensionExecutionControllerImage(t *testing.T) {
@@ -305,6 +306,40 @@ func TestExtensionExecutionControllerPVCSettings(t *testing.T) {
 	)
 }
 
+func TestExtensionExecutionControllerPodDisruptionBudget(t *testing.T) {
+	newDynakube := func(pdb *extensions.PodDisruptionBudgetSpec) *dynakube.DynaKube {
+		return &dynakube.DynaKube{
+			ObjectMeta: defaultDynakubeObjectMeta,
+			Spec: dynakube.DynaKubeSpec{
+				APIURL:     testAPIURL,
+				Extensions: &extensions.Spec{Prometheus: &extensions.PrometheusSpec{}},
+				Templates: dynakube.TemplatesSpec{
+					ExtensionExecutionController: extensions.ExecutionControllerSpec{
+						Replicas:            new(int32(2)),
+						PodDisruptionBudget: pdb,
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("minAvailable only", func(t *testing.T) {
+		dk := newDynakube(&extensions.PodDisruptionBudgetSpec{MinAvailable: new(intstr.FromInt32(1))})
+		assert.Empty(t, extensionControllerPodDisruptionBudget(t.Context(), nil, dk))
+	})
+
+	t.Run("minAvailable and maxUnavailable", func(t *testing.T) {
+		dk := newDynakube(&extensions.PodDisruptionBudgetSpec{MinAvailable: new(intstr.FromInt32(1)), MaxUnavailable: new(intstr.FromInt32(1))})
+		assert.Equal(t, errorExtensionExecutionControllerPDBMinAndMaxConfigured, extensionControllerPodDisruptionBudget(t.Context(), nil, dk))
+	})
+
+	t.Run("extensions disabled", func(t *testing.T) {
+		dk := newDynakube(&extensions.PodDisruptionBudgetSpec{MinAvailable: new(intstr.FromInt32(1)), MaxUnavailable: new(intstr.FromInt32(1))})
+		dk.Spec.Extensions = nil
+		assert.Empty(t, extensionControllerPodDisruptionBudget(t.Context(), nil, dk))
+	})
+}
+
 func TestWarnIfmultipleDKwithExtensionsEnabled(t *testing.T) {
 	imgRef := image.Ref{
 		Repository: "a",
@@ -40,6 +40,7 @@ var (
 		exp.NoProxyKey,
 		exp.UseEECLegacyMountsKey,
 		exp.UsePublicRegistryKey,
+		exp.ConversionCheckKey,
 		// activegate.go
 		exp.AGAppArmorKey,
 		exp.AGAutomaticK8sAPIMonitoringKey,
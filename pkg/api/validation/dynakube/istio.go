@@ -12,7 +12,8 @@ import (
 )
 
 const (
-	errorNoIstioInstalled = `No resources for istio available`
+	errorNoIstioInstalled            = `No resources for istio available`
+	errorIstioWaypointWithoutAmbient = `spec.istio.waypoint is only supported in ambient mode! Please set spec.istio.mode to 'ambient' or remove spec.istio.waypoint.`
 )
 
 func isIstioNotInstalled(ctx context.Context, dv *Validator, dk *dynakube.DynaKube) string {
@@ -22,3 +23,11 @@ func isIstioNotInstalled(ctx context.Context, dv *Validator, dk *dynakube.DynaKu
 
 	return ""
 }
+
+func istioWaypointWithoutAmbientMode(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	if dk.Spec.Istio != nil && dk.Spec.Istio.Waypoint != "" && !dk.Spec.Istio.IsAmbientMode() {
+		return errorIstioWaypointWithoutAmbient
+	}
+
+	return ""
+}
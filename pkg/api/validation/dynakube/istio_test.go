@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/istio"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
@@ -47,3 +48,25 @@ func TestIsIstioNotInstalled(t *testing.T) {
 		})
 	})
 }
+
+func TestIstioWaypointWithoutAmbientMode(t *testing.T) {
+	t.Run("waypoint in ambient mode", func(t *testing.T) {
+		assertAllowed(t, &dynakube.DynaKube{
+			Spec: dynakube.DynaKubeSpec{
+				APIURL:      testAPIURL,
+				EnableIstio: new(true),
+				Istio:       &istio.Spec{Mode: istio.AmbientMode, Waypoint: "waypoint"},
+			},
+		})
+	})
+
+	t.Run("waypoint in sidecar mode", func(t *testing.T) {
+		assertDenied(t, []string{errorIstioWaypointWithoutAmbient}, &dynakube.DynaKube{
+			Spec: dynakube.DynaKubeSpec{
+				APIURL:      testAPIURL,
+				EnableIstio: new(true),
+				Istio:       &istio.Spec{Waypoint: "waypoint"},
+			},
+		})
+	})
+}
@@ -0,0 +1,43 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+)
+
+const (
+	errorInvalidKubernetesAPICIDR = `The DynaKube's specification lists invalid CIDRs in networkPolicies.kubernetesApiCidrs: %s. Use the CIDR notation, e.g. 10.0.0.1/32.`
+
+	errorMissingKubernetesAPICIDRs = `The DynaKube's specification enables NetworkPolicies without networkPolicies.kubernetesApiCidrs. Add the CIDRs of the Kubernetes API server, so the egress to it can be restricted.`
+)
+
+func missingKubernetesAPICIDRs(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	if dk.IsNetworkPolicyEnabled() && len(dk.GetKubernetesAPICIDRs()) == 0 {
+		return errorMissingKubernetesAPICIDRs
+	}
+
+	return ""
+}
+
+func invalidKubernetesAPICIDRs(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	var invalid []string
+
+	for _, cidr := range dk.GetKubernetesAPICIDRs() {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			invalid = append(invalid, cidr)
+		}
+	}
+
+	if len(invalid) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(errorInvalidKubernetesAPICIDR, strings.Join(invalid, ", "))
+}
@@ -0,0 +1,45 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestInvalidKubernetesAPICIDRs(t *testing.T) {
+	newDynakube := func(spec *dynakube.NetworkPoliciesSpec) *dynakube.DynaKube {
+		return &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testName,
+				Namespace: testNamespace,
+			},
+			Spec: dynakube.DynaKubeSpec{
+				APIURL:          testAPIURL,
+				NetworkPolicies: spec,
+			},
+		}
+	}
+
+	t.Run("network policies disabled", func(t *testing.T) {
+		assertAllowed(t, newDynakube(nil))
+	})
+
+	t.Run("network policies without CIDRs", func(t *testing.T) {
+		assertDenied(t, []string{errorMissingKubernetesAPICIDRs}, newDynakube(&dynakube.NetworkPoliciesSpec{}))
+	})
+
+	t.Run("valid CIDRs", func(t *testing.T) {
+		assertAllowed(t, newDynakube(&dynakube.NetworkPoliciesSpec{KubernetesAPICIDRs: []string{"10.96.0.1/32", "fd00::/64"}}))
+	})
+
+	t.Run("invalid CIDRs", func(t *testing.T) {
+		dk := newDynakube(&dynakube.NetworkPoliciesSpec{KubernetesAPICIDRs: []string{"10.96.0.1", "10.0.0.0/8", "kubernetes.default"}})
+
+		assertDenied(t, []string{fmt.Sprintf(errorInvalidKubernetesAPICIDR, "10.96.0.1, kubernetes.default")}, dk)
+	})
+}
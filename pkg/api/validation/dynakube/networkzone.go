@@ -5,14 +5,19 @@ package validation
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/communication"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/sanitize"
 )
 
 const (
 	errorInvalidNetworkZone = "The DynaKube's specification has an invalid Network Zone value set. Make sure to remove forbidden characters (newline, tab, carriage return, null) from the Network Zone value in your custom resource."
+
+	errorUnknownActiveGateGroupTopologyZone = "The ActiveGate group %s is pinned to the topology zone %s, which is not listed in spec.topologyZones. Add the topology zone with its network zone to spec.topologyZones."
 )
 
 func invalidNetworkZone(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
@@ -20,5 +25,27 @@ func invalidNetworkZone(_ context.Context, _ *Validator, dk *dynakube.DynaKube)
 		return errorInvalidNetworkZone
 	}
 
+	for _, networkZone := range dk.GetAdditionalNetworkZones() {
+		if strings.ContainsAny(networkZone, sanitize.InvalidCommandLineCharset) {
+			return errorInvalidNetworkZone
+		}
+	}
+
+	return ""
+}
+
+func unknownActiveGateGroupTopologyZone(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	for _, group := range dk.Spec.ActiveGate.Groups {
+		if group.TopologyZone == "" {
+			continue
+		}
+
+		if !slices.ContainsFunc(dk.Spec.TopologyZones, func(topologyZone communication.TopologyZone) bool {
+			return topologyZone.Zone == group.TopologyZone
+		}) {
+			return fmt.Sprintf(errorUnknownActiveGateGroupTopologyZone, group.Name, group.TopologyZone)
+		}
+	}
+
 	return ""
 }
@@ -4,9 +4,12 @@
 package validation
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/communication"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -34,3 +37,52 @@ func TestInvalidNetworkZone(t *testing.T) {
 		}, errorInvalidNetworkZone)
 	})
 }
+
+func TestTopologyZones(t *testing.T) {
+	newDynaKube := func() *dynakube.DynaKube {
+		return &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: testNamespace},
+			Spec: dynakube.DynaKubeSpec{
+				APIURL:      testAPIURL,
+				NetworkZone: "default",
+				TopologyZones: []communication.TopologyZone{
+					{Zone: "zone-a", NetworkZone: "network-zone-a"},
+				},
+			},
+		}
+	}
+
+	t.Run("topology zones are allowed", func(t *testing.T) {
+		assertAllowed(t, newDynaKube())
+	})
+
+	t.Run("network zone of topology zone with invalid characters is denied", func(t *testing.T) {
+		assertSanitizeArg(t, newDynaKube(), func(dk *dynakube.DynaKube, value string) {
+			dk.Spec.TopologyZones[0].NetworkZone = value
+		}, errorInvalidNetworkZone)
+	})
+
+	t.Run("ActiveGate group pinned to a listed topology zone is allowed", func(t *testing.T) {
+		dk := newDynaKube()
+		dk.Spec.ActiveGate = activegate.Spec{
+			Capabilities: []activegate.CapabilityDisplayName{activegate.KubeMonCapability.DisplayName},
+			Groups: []activegate.GroupSpec{
+				{Name: "zone-a", Capabilities: []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName}, TopologyZone: "zone-a"},
+			},
+		}
+
+		assertAllowed(t, dk)
+	})
+
+	t.Run("ActiveGate group pinned to an unknown topology zone is denied", func(t *testing.T) {
+		dk := newDynaKube()
+		dk.Spec.ActiveGate = activegate.Spec{
+			Capabilities: []activegate.CapabilityDisplayName{activegate.KubeMonCapability.DisplayName},
+			Groups: []activegate.GroupSpec{
+				{Name: "zone-b", Capabilities: []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName}, TopologyZone: "zone-b"},
+			},
+		}
+
+		assertDenied(t, []string{fmt.Sprintf(errorUnknownActiveGateGroupTopologyZone, "zone-b", "zone-b")}, dk)
+	})
+}
@@ -0,0 +1,78 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/sanitize"
+)
+
+const (
+	// maxNodePoolAffinityTerms limits the node selector terms that are needed to exclude the node pools from the OneAgent DaemonSet.
+	maxNodePoolAffinityTerms = 64
+
+	errorInvalidNodePoolHostGroup = "The DynaKube's specification has an invalid Host Group value set for the OneAgent node pool %s. Make sure to remove forbidden characters (newline, tab, carriage return, null) from the Host Group value in your custom resource."
+
+	errorTooComplexNodePoolSelectors = "The node selectors of the OneAgent node pools use too many labels. To exclude the node pools from each other, a node affinity term is needed per combination of their labels, which must not exceed %d."
+
+	errorDuplicateTopologyZoneNodePoolName = "The topology zones %s and %s result in the same OneAgent node pool name %s. The node pool names are derived from the first characters of the topology zones, make sure they differ."
+
+	errorTopologyZoneNodePoolNameConflict = "The OneAgent node pool %s has the same name as the node pool generated for the topology zone %s. Rename the node pool, as the names starting with '" + oneagent.TopologyZoneNodePoolPrefix + "' are used for the topology zones."
+)
+
+func invalidNodePoolHostGroup(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	for _, nodePool := range dk.OneAgent().GetNodePools() {
+		if strings.ContainsAny(nodePool.HostGroup, sanitize.InvalidCommandLineCharset) {
+			return fmt.Sprintf(errorInvalidNodePoolHostGroup, nodePool.Name)
+		}
+	}
+
+	return ""
+}
+
+func conflictingTopologyZoneNodePoolNames(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	hostInjectSpec := dk.OneAgent().GetHostInjectSpec()
+	if hostInjectSpec == nil {
+		return ""
+	}
+
+	zones := make(map[string]string, len(dk.Spec.TopologyZones))
+
+	for _, topologyZone := range dk.Spec.TopologyZones {
+		name := oneagent.GetTopologyZoneNodePoolName(topologyZone.Zone)
+
+		if zone, ok := zones[name]; ok {
+			return fmt.Sprintf(errorDuplicateTopologyZoneNodePoolName, zone, topologyZone.Zone, name)
+		}
+
+		zones[name] = topologyZone.Zone
+	}
+
+	for _, nodePool := range hostInjectSpec.NodePools {
+		if zone, ok := zones[nodePool.Name]; ok {
+			return fmt.Sprintf(errorTopologyZoneNodePoolNameConflict, nodePool.Name, zone)
+		}
+	}
+
+	return ""
+}
+
+func tooComplexNodePoolSelectors(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	terms := 1
+
+	for _, nodePool := range dk.OneAgent().GetNodePools() {
+		terms *= max(len(nodePool.NodeSelector), 1)
+
+		if terms > maxNodePoolAffinityTerms {
+			return fmt.Sprintf(errorTooComplexNodePoolSelectors, maxNodePoolAffinityTerms)
+		}
+	}
+
+	return ""
+}
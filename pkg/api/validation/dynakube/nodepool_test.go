@@ -0,0 +1,84 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/communication"
+)
+
+func TestNodePools(t *testing.T) {
+	newDynakube := func(nodePools ...oneagent.NodePoolSpec) *dynakube.DynaKube {
+		return &dynakube.DynaKube{
+			Spec: dynakube.DynaKubeSpec{
+				APIURL: testAPIURL,
+				OneAgent: oneagent.Spec{
+					HostMonitoring: &oneagent.HostInjectSpec{NodePools: nodePools},
+				},
+			},
+		}
+	}
+
+	t.Run("valid node pools", func(t *testing.T) {
+		assertAllowed(t, newDynakube(
+			oneagent.NodePoolSpec{Name: "gpu", NodeSelector: map[string]string{"pool": "gpu"}, HostGroup: "gpu"},
+			oneagent.NodePoolSpec{Name: "arm", NodeSelector: map[string]string{"pool": "arm", "zone": "a"}},
+		))
+	})
+
+	t.Run("host group with invalid characters", func(t *testing.T) {
+		dk := newDynakube(oneagent.NodePoolSpec{Name: "gpu", NodeSelector: map[string]string{"pool": "gpu"}, HostGroup: "gpu\n"})
+
+		assertDenied(t, []string{fmt.Sprintf(errorInvalidNodePoolHostGroup, "gpu")}, dk)
+	})
+
+	t.Run("too many node selector combinations", func(t *testing.T) {
+		var nodePools []oneagent.NodePoolSpec
+		for i := range 7 {
+			nodePools = append(nodePools, oneagent.NodePoolSpec{
+				Name:         "pool-" + strconv.Itoa(i),
+				NodeSelector: map[string]string{"pool": strconv.Itoa(i), "zone": "a"},
+			})
+		}
+
+		assertDenied(t, []string{fmt.Sprintf(errorTooComplexNodePoolSelectors, maxNodePoolAffinityTerms)}, newDynakube(nodePools...))
+	})
+
+	t.Run("topology zones with distinct node pool names", func(t *testing.T) {
+		dk := newDynakube(oneagent.NodePoolSpec{Name: "gpu", NodeSelector: map[string]string{"pool": "gpu"}})
+		dk.Spec.TopologyZones = []communication.TopologyZone{{Zone: "zone-a", NetworkZone: "a"}, {Zone: "zone-b", NetworkZone: "b"}}
+
+		assertAllowed(t, dk)
+	})
+
+	t.Run("topology zones resulting in the same node pool name", func(t *testing.T) {
+		dk := newDynakube()
+		dk.Spec.TopologyZones = []communication.TopologyZone{{Zone: "zone_a", NetworkZone: "a"}, {Zone: "ZONE-A", NetworkZone: "b"}}
+
+		assertDenied(t, []string{fmt.Sprintf(errorDuplicateTopologyZoneNodePoolName, "zone_a", "ZONE-A", "zone-zone-a")}, dk)
+	})
+
+	t.Run("truncated topology zones resulting in the same node pool name", func(t *testing.T) {
+		dk := newDynakube()
+		dk.Spec.TopologyZones = []communication.TopologyZone{
+			{Zone: "a-very-long-topology-zone-name-1", NetworkZone: "a"},
+			{Zone: "a-very-long-topology-zone-name-2", NetworkZone: "b"},
+		}
+
+		assertDenied(t, []string{fmt.Sprintf(errorDuplicateTopologyZoneNodePoolName,
+			"a-very-long-topology-zone-name-1", "a-very-long-topology-zone-name-2", "zone-a-very-long-topology-zone-n")}, dk)
+	})
+
+	t.Run("node pool named like a topology zone node pool", func(t *testing.T) {
+		dk := newDynakube(oneagent.NodePoolSpec{Name: "zone-a", NodeSelector: map[string]string{"pool": "a"}})
+		dk.Spec.TopologyZones = []communication.TopologyZone{{Zone: "a", NetworkZone: "a"}}
+
+		assertDenied(t, []string{fmt.Sprintf(errorTopologyZoneNodePoolNameConflict, "zone-a", "a")}, dk)
+	})
+}
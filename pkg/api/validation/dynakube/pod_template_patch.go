@@ -0,0 +1,62 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/podtemplate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8spodtemplate"
+)
+
+const (
+	errorInvalidPodTemplatePatch = `The DynaKube's specification has an invalid podTemplatePatch in %s: %s. Operator-owned labels, annotations, the service account and the operator's containers can not be patched.`
+)
+
+func invalidPodTemplatePatch(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	for _, field := range podTemplatePatches(dk) {
+		if err := k8spodtemplate.Validate(field.patch); err != nil {
+			return fmt.Sprintf(errorInvalidPodTemplatePatch, field.path, err.Error())
+		}
+	}
+
+	return ""
+}
+
+type podTemplatePatchField struct {
+	patch *podtemplate.Patch
+	path  string
+}
+
+func podTemplatePatches(dk *dynakube.DynaKube) []podTemplatePatchField {
+	templates := dk.Spec.Templates
+
+	patches := []podTemplatePatchField{
+		{path: "spec.activeGate", patch: dk.Spec.ActiveGate.PodTemplatePatch},
+		{path: "spec.templates.kspmNodeConfigurationCollector", patch: templates.KSPMNodeConfigurationCollector.PodTemplatePatch},
+		{path: "spec.templates.otelCollector", patch: templates.OpenTelemetryCollector.PodTemplatePatch},
+		{path: "spec.templates.sqlExtensionExecutor", patch: templates.SQLExtensionExecutor.PodTemplatePatch},
+		{path: "spec.templates.extensionExecutionController", patch: templates.ExtensionExecutionController.PodTemplatePatch},
+	}
+
+	if hostInjectSpec := dk.OneAgent().GetHostInjectSpec(); hostInjectSpec != nil {
+		patches = append(patches, podTemplatePatchField{path: "spec.oneAgent", patch: hostInjectSpec.PodTemplatePatch})
+	}
+
+	for _, group := range dk.Spec.ActiveGate.Groups {
+		patches = append(patches, podTemplatePatchField{path: "spec.activeGate.groups[" + group.Name + "]", patch: group.PodTemplatePatch})
+	}
+
+	if dk.Spec.KubernetesMonitoring != nil {
+		patches = append(patches, podTemplatePatchField{path: "spec.kubernetesMonitoring", patch: dk.Spec.KubernetesMonitoring.PodTemplatePatch})
+	}
+
+	if templates.LogMonitoring != nil {
+		patches = append(patches, podTemplatePatchField{path: "spec.templates.logMonitoring", patch: templates.LogMonitoring.PodTemplatePatch})
+	}
+
+	return patches
+}
@@ -0,0 +1,72 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/podtemplate"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestInvalidPodTemplatePatch(t *testing.T) {
+	newPatch := func(patchType podtemplate.PatchType, raw string) *podtemplate.Patch {
+		return &podtemplate.Patch{Type: patchType, Patch: apiextensionsv1.JSON{Raw: []byte(raw)}}
+	}
+
+	newDynakube := func() *dynakube.DynaKube {
+		return &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testName,
+				Namespace: testNamespace,
+			},
+			Spec: dynakube.DynaKubeSpec{
+				APIURL: testAPIURL,
+				OneAgent: oneagent.Spec{
+					HostMonitoring: &oneagent.HostInjectSpec{},
+				},
+			},
+		}
+	}
+
+	t.Run("no patches", func(t *testing.T) {
+		assertAllowed(t, newDynakube())
+	})
+
+	t.Run("valid patches", func(t *testing.T) {
+		dk := newDynakube()
+		dk.Spec.OneAgent.HostMonitoring.PodTemplatePatch = newPatch("", `{"spec": {"hostAliases": [{"ip": "10.0.0.1", "hostnames": ["tenant.local"]}]}}`)
+		dk.Spec.Templates.OpenTelemetryCollector.PodTemplatePatch = newPatch(podtemplate.JSONPatchType, `[{"op": "add", "path": "/spec/containers/-", "value": {"name": "sidecar", "image": "sidecar"}}]`)
+
+		assertAllowed(t, dk)
+	})
+
+	t.Run("patch of an operator-owned label", func(t *testing.T) {
+		dk := newDynakube()
+		dk.Spec.OneAgent.HostMonitoring.PodTemplatePatch = newPatch("", `{"metadata": {"labels": {"app.kubernetes.io/name": "other"}}}`)
+
+		assertDenied(t, []string{fmt.Sprintf(errorInvalidPodTemplatePatch, "spec.oneAgent", "the operator-owned label app.kubernetes.io/name can not be patched")}, dk)
+	})
+
+	t.Run("patch of the service account of an ActiveGate group", func(t *testing.T) {
+		dk := newDynakube()
+		dk.Spec.ActiveGate = activegate.Spec{
+			Capabilities: []activegate.CapabilityDisplayName{activegate.KubeMonCapability.DisplayName},
+			Groups: []activegate.GroupSpec{
+				{
+					Name:             "routing",
+					Capabilities:     []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName},
+					PodTemplatePatch: newPatch(podtemplate.JSONPatchType, `[{"op": "replace", "path": "/spec/serviceAccountName", "value": "default"}]`),
+				},
+			},
+		}
+
+		assertDenied(t, []string{"spec.activeGate.groups[routing]", "spec/serviceAccountName"}, dk)
+	})
+}
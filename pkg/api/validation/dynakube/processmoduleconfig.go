@@ -0,0 +1,46 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
+	oneagentclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/oneagent"
+)
+
+const (
+	errorManagedProcessModuleProperty = `The DynaKube's specification overrides the '%s' process module property of the '%s' section, which is managed by the operator. Remove it from the processModuleConfig properties.`
+)
+
+func managedProcessModuleProperty(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	pmcSpec := dk.OneAgent().GetProcessModuleConfig()
+	if pmcSpec == nil {
+		return ""
+	}
+
+	if property := findManagedProcessModuleProperty(pmcSpec.Properties); property != nil {
+		return fmt.Sprintf(errorManagedProcessModuleProperty, property.Key, property.Section)
+	}
+
+	for _, profile := range pmcSpec.Profiles {
+		if property := findManagedProcessModuleProperty(profile.Properties); property != nil {
+			return fmt.Sprintf(errorManagedProcessModuleProperty, property.Key, property.Section)
+		}
+	}
+
+	return ""
+}
+
+func findManagedProcessModuleProperty(properties []oneagent.ProcessModuleProperty) *oneagent.ProcessModuleProperty {
+	for i, property := range properties {
+		if oneagentclient.IsManagedProperty(property.Section, property.Key) {
+			return &properties[i]
+		}
+	}
+
+	return nil
+}
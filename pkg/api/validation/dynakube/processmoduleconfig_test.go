@@ -0,0 +1,59 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
+)
+
+func TestManagedProcessModuleProperty(t *testing.T) {
+	newDynaKube := func(pmcSpec *oneagent.ProcessModuleConfigSpec) *dynakube.DynaKube {
+		return &dynakube.DynaKube{
+			ObjectMeta: defaultDynakubeObjectMeta,
+			Spec: dynakube.DynaKubeSpec{
+				APIURL: testAPIURL,
+				OneAgent: oneagent.Spec{
+					ApplicationMonitoring: &oneagent.ApplicationMonitoringSpec{
+						AppInjectionSpec: oneagent.AppInjectionSpec{
+							ProcessModuleConfig: pmcSpec,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("overrides of unmanaged properties are allowed", func(t *testing.T) {
+		assertAllowed(t, newDynaKube(&oneagent.ProcessModuleConfigSpec{
+			Properties: []oneagent.ProcessModuleProperty{{Section: "general", Key: "logLevel", Value: "debug"}},
+			Profiles: []oneagent.ProcessModuleProfile{
+				{Name: "java", Properties: []oneagent.ProcessModuleProperty{{Section: "general", Key: "javaEnabled", Value: "true"}}},
+			},
+		}))
+	})
+
+	t.Run("managed keys in other sections are allowed", func(t *testing.T) {
+		assertAllowed(t, newDynaKube(&oneagent.ProcessModuleConfigSpec{
+			Properties: []oneagent.ProcessModuleProperty{{Section: "agentType", Key: "proxy", Value: "off"}},
+		}))
+	})
+
+	t.Run("override of a managed property is denied", func(t *testing.T) {
+		assertDenied(t, []string{fmt.Sprintf(errorManagedProcessModuleProperty, "tenantToken", "general")}, newDynaKube(&oneagent.ProcessModuleConfigSpec{
+			Properties: []oneagent.ProcessModuleProperty{{Section: "general", Key: "tenantToken", Value: "token"}},
+		}))
+	})
+
+	t.Run("override of a managed property in a profile is denied", func(t *testing.T) {
+		assertDenied(t, []string{fmt.Sprintf(errorManagedProcessModuleProperty, "proxy", "general")}, newDynaKube(&oneagent.ProcessModuleConfigSpec{
+			Profiles: []oneagent.ProcessModuleProfile{
+				{Name: "proxied", Properties: []oneagent.ProcessModuleProperty{{Section: "general", Key: "proxy", Value: "http://proxy"}}},
+			},
+		}))
+	})
+}
@@ -0,0 +1,47 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	errorUnsupportedRemediationComponent = `The component '%s' doesn't support remediation, only activeGate, extensionExecutionController and otelCollector are supported.`
+	errorRemediationCeilingBelowLimit    = `The memory limit of '%s' is higher than spec.remediation.maxMemoryLimit. Please increase spec.remediation.maxMemoryLimit.`
+)
+
+func unsupportedRemediationComponent(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	if dk.Spec.Remediation == nil {
+		return ""
+	}
+
+	for _, name := range dk.Spec.Remediation.Components {
+		if !slices.Contains(dynakube.RemediableComponents, name) {
+			return fmt.Sprintf(errorUnsupportedRemediationComponent, name)
+		}
+	}
+
+	return ""
+}
+
+func remediationCeilingBelowMemoryLimit(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	if dk.Spec.Remediation == nil {
+		return ""
+	}
+
+	for _, name := range dk.Spec.Remediation.Components {
+		limit, ok := dk.GetConfiguredResources(name).Limits[corev1.ResourceMemory]
+		if ok && limit.Cmp(dk.Spec.Remediation.MaxMemoryLimit) > 0 {
+			return fmt.Sprintf(errorRemediationCeilingBelowLimit, name)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,49 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestRemediation(t *testing.T) {
+	newDynakube := func(components ...dynakube.ComponentName) *dynakube.DynaKube {
+		dk := &dynakube.DynaKube{
+			Spec: dynakube.DynaKubeSpec{
+				APIURL: testAPIURL,
+				ActiveGate: activegate.Spec{
+					Capabilities: []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName},
+				},
+				Remediation: &dynakube.RemediationSpec{
+					MaxMemoryLimit: resource.MustParse("2Gi"),
+					Components:     components,
+				},
+			},
+		}
+		dk.Spec.ActiveGate.Resources.Limits = corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}
+
+		return dk
+	}
+
+	t.Run("supported component", func(t *testing.T) {
+		assertAllowed(t, newDynakube(dynakube.ActiveGateComponent))
+	})
+
+	t.Run("unsupported component", func(t *testing.T) {
+		assertDenied(t, []string{fmt.Sprintf(errorUnsupportedRemediationComponent, dynakube.OneAgentComponent)}, newDynakube(dynakube.OneAgentComponent))
+	})
+
+	t.Run("memory limit above maximum", func(t *testing.T) {
+		dk := newDynakube(dynakube.ActiveGateComponent)
+		dk.Spec.Remediation.MaxMemoryLimit = resource.MustParse("512Mi")
+
+		assertDenied(t, []string{fmt.Sprintf(errorRemediationCeilingBelowLimit, dynakube.ActiveGateComponent)}, dk)
+	})
+}
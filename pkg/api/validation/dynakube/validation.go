@@ -37,6 +37,10 @@ var (
 		NoAPIURL,
 		isInvalidAPIURL,
 		invalidActiveGateCapabilities,
+		invalidActiveGateGroupCapabilities,
+		kubeMonInActiveGateGroup,
+		activeGateGroupNameTooLong,
+		multipleSyntheticActiveGates,
 		mutuallyExclusiveActiveGatePVsettings,
 		activeGateHasConflictingVolumes,
 		activeGateHasDisallowedVolumeType,
@@ -45,6 +49,9 @@ var (
 		conflictingOneAgentNodeSelector,
 		conflictingNamespaceSelector,
 		isIstioNotInstalled,
+		istioWaypointWithoutAmbientMode,
+		unsupportedRemediationComponent,
+		remediationCeilingBelowMemoryLimit,
 		conflictingOneAgentVolumeStorageSettings,
 		nameInvalid,
 		invalidOneAgentNamespaceSelector,
@@ -53,6 +60,7 @@ var (
 		imageFieldHasTenantImage,
 		extensionControllerImage,
 		extensionControllerPVCStorageDevice,
+		extensionControllerPodDisruptionBudget,
 		tooManyKubernetesMonitoringReplicas,
 		missingKSPMImage,
 		kspmWithoutKubernetesMonitoringRegistration,
@@ -76,12 +84,22 @@ var (
 		invalidOTLPResourceAttributesSanitization,
 		publicRegistryOverrideWithoutPublicRegistry,
 		invalidNetworkZone,
+		unknownActiveGateGroupTopologyZone,
 		invalidOneAgentHostGroup,
+		managedProcessModuleProperty,
+		invalidNodePoolHostGroup,
+		conflictingTopologyZoneNodePoolNames,
+		tooComplexNodePoolSelectors,
 		invalidNoProxy,
 		publicRegistryNotAllowedForClassic,
+		unsupportedWorkloadIdentityRegistry,
+		invalidPodTemplatePatch,
+		missingKubernetesAPICIDRs,
+		invalidKubernetesAPICIDRs,
 		invalidOneAgentArguments,
 		invalidLogmonArguments,
 		missingCodeModulesImage,
+		lossyConversion,
 	}
 	validatorWarningFuncs = []validatorFunc{
 		missingActiveGateMemoryLimit,
@@ -100,6 +118,8 @@ var (
 		hostPathDatabaseVolumeFound,
 		disabledMetadataEnrichmentForInjectionModes,
 		activeGateRollingUpdateWithOldK8sVersion,
+		unusedSyntheticSpec,
+		syntheticWithoutActivationGroup,
 		globalResourceAttributesExceedsLimit,
 		oneAgentResourceAttributesExceedsLimit,
 		otlpResourceAttributesExceedsLimit,
@@ -109,6 +129,7 @@ var (
 		warnGlobalResourceAttributesSanitization,
 		warnOneAgentResourceAttributesSanitization,
 		warnOTLPResourceAttributesSanitization,
+		lossyConversionWarning,
 	}
 	updateValidatorErrorFuncs = []updateValidatorFunc{
 		IsMutatedAPIURL,
@@ -0,0 +1,33 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/oci/workloadidentity"
+)
+
+const (
+	errorUnsupportedWorkloadIdentityRegistry = `The DynaKube's specification lists registries in workloadIdentityRegistries, which are not supported: %s. Only Amazon ECR, Google Container Registry/Artifact Registry and Azure Container Registry hosts without scheme or path are supported.`
+)
+
+func unsupportedWorkloadIdentityRegistry(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	var unsupported []string
+
+	for _, registry := range dk.Spec.WorkloadIdentityRegistries {
+		if !workloadidentity.IsSupportedRegistry(registry) {
+			unsupported = append(unsupported, registry)
+		}
+	}
+
+	if len(unsupported) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(errorUnsupportedWorkloadIdentityRegistry, strings.Join(unsupported, ", "))
+}
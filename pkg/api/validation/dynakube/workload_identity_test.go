@@ -0,0 +1,41 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestUnsupportedWorkloadIdentityRegistry(t *testing.T) {
+	newDynakube := func(registries ...string) *dynakube.DynaKube {
+		return &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testName,
+				Namespace: testNamespace,
+			},
+			Spec: dynakube.DynaKubeSpec{
+				APIURL:                     testAPIURL,
+				WorkloadIdentityRegistries: registries,
+			},
+		}
+	}
+
+	t.Run("no registries", func(t *testing.T) {
+		assertAllowed(t, newDynakube())
+	})
+
+	t.Run("supported registries", func(t *testing.T) {
+		assertAllowed(t, newDynakube("123456789012.dkr.ecr.eu-west-1.amazonaws.com", "europe-docker.pkg.dev", "dynatrace.azurecr.io"))
+	})
+
+	t.Run("unsupported registries", func(t *testing.T) {
+		dk := newDynakube("dynatrace.azurecr.io", "docker.io", "https://eu.gcr.io/project")
+
+		assertDenied(t, []string{fmt.Sprintf(errorUnsupportedWorkloadIdentityRegistry, "docker.io, https://eu.gcr.io/project")}, dk)
+	})
+}
@@ -5,12 +5,17 @@ package validation
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
 )
 
 const (
-	errorHostPattersIsRequired = `hostPatterns is required when using provisioner mode`
+	errorHostPattersIsRequired     = `hostPatterns is required when using provisioner mode`
+	errorHostMappingInvalid        = `hostMappings require both 'from' and 'to' to be set`
+	errorHostMappingWithoutPattern = `The host mapping for '%s' has no matching entry in hostPatterns`
 )
 
 func checkHostPatternsValue(_ context.Context, _ *Validator, ec *edgeconnect.EdgeConnect) string {
@@ -20,3 +25,19 @@ func checkHostPatternsValue(_ context.Context, _ *Validator, ec *edgeconnect.Edg
 
 	return ""
 }
+
+func checkHostMappings(_ context.Context, _ *Validator, ec *edgeconnect.EdgeConnect) string {
+	hostPatterns := ec.HostPatterns()
+
+	for _, hostMapping := range ec.Spec.HostMappings {
+		if hostMapping.From == "" || hostMapping.To == "" {
+			return errorHostMappingInvalid
+		}
+
+		if !slices.ContainsFunc(hostPatterns, func(hostPattern string) bool { return strings.EqualFold(hostPattern, hostMapping.From) }) {
+			return fmt.Sprintf(errorHostMappingWithoutPattern, hostMapping.From)
+		}
+	}
+
+	return ""
+}
@@ -4,6 +4,7 @@
 package validation
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
@@ -44,3 +45,40 @@ func Test_checkHostPatternsValue(t *testing.T) {
 		assertDenied(t, []string{errorHostPattersIsRequired}, ec)
 	})
 }
+
+func Test_checkHostMappings(t *testing.T) {
+	newEdgeConnect := func(hostMappings []edgeconnect.HostMapping) *edgeconnect.EdgeConnect {
+		return &edgeconnect.EdgeConnect{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testName,
+				Namespace: testNamespace,
+			},
+			Spec: edgeconnect.EdgeConnectSpec{
+				APIServer: "tenantid-test.dev.apps.dynatracelabs.com",
+				OAuth: edgeconnect.OAuthSpec{
+					ClientSecret: "secret",
+					Endpoint:     testValidOAuthEndpoint,
+					Resource:     "resource",
+					Provisioner:  true,
+				},
+				HostPatterns: []string{"*.internal.org"},
+				HostMappings: hostMappings,
+			},
+		}
+	}
+
+	t.Run("host mapping matching a host pattern is allowed", func(t *testing.T) {
+		ec := newEdgeConnect([]edgeconnect.HostMapping{{From: "*.internal.org", To: "internal.svc.cluster.local"}})
+		assertAllowed(t, ec, prepareTestServiceAccount(t, testServiceAccountName, testNamespace))
+	})
+
+	t.Run("host mapping without matching host pattern is denied", func(t *testing.T) {
+		ec := newEdgeConnect([]edgeconnect.HostMapping{{From: "*.external.org", To: "internal.svc.cluster.local"}})
+		assertDenied(t, []string{fmt.Sprintf(errorHostMappingWithoutPattern, "*.external.org")}, ec, prepareTestServiceAccount(t, testServiceAccountName, testNamespace))
+	})
+
+	t.Run("incomplete host mapping is denied", func(t *testing.T) {
+		ec := newEdgeConnect([]edgeconnect.HostMapping{{From: "*.internal.org"}})
+		assertDenied(t, []string{errorHostMappingInvalid}, ec, prepareTestServiceAccount(t, testServiceAccountName, testNamespace))
+	})
+}
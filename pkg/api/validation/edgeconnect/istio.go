@@ -0,0 +1,33 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"context"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/istio"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	errorNoIstioInstalled            = `No resources for istio available`
+	errorIstioWaypointWithoutAmbient = `spec.istio.waypoint is only supported in ambient mode! Please set spec.istio.mode to 'ambient' or remove spec.istio.waypoint.`
+)
+
+func isIstioNotInstalled(ctx context.Context, v *Validator, ec *edgeconnect.EdgeConnect) string {
+	if ptr.Deref(ec.Spec.EnableIstio, false) && !istio.IsInstalled(ctx, v.apiReader) {
+		return errorNoIstioInstalled
+	}
+
+	return ""
+}
+
+func istioWaypointWithoutAmbientMode(_ context.Context, _ *Validator, ec *edgeconnect.EdgeConnect) string {
+	if ec.Spec.Istio != nil && ec.Spec.Istio.Waypoint != "" && !ec.Spec.Istio.IsAmbientMode() {
+		return errorIstioWaypointWithoutAmbient
+	}
+
+	return ""
+}
@@ -0,0 +1,52 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	istiospec "github.com/Dynatrace/dynatrace-operator/pkg/api/shared/istio"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func Test_isIstioNotInstalled(t *testing.T) {
+	noIstioClient := fake.NewClientWithInterceptors(interceptor.Funcs{
+		Get: func(_ context.Context, _ client.WithWatch, _ client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
+			return new(meta.NoResourceMatchError)
+		},
+	})
+
+	t.Run("istio is not installed", func(t *testing.T) {
+		ec := &edgeconnect.EdgeConnect{Spec: edgeconnect.EdgeConnectSpec{EnableIstio: new(true)}}
+		require.Equal(t, errorNoIstioInstalled, isIstioNotInstalled(t.Context(), &Validator{apiReader: noIstioClient}, ec))
+	})
+	t.Run("istio is installed", func(t *testing.T) {
+		ec := &edgeconnect.EdgeConnect{Spec: edgeconnect.EdgeConnectSpec{EnableIstio: new(true)}}
+		require.Empty(t, isIstioNotInstalled(t.Context(), &Validator{apiReader: fake.NewClient()}, ec))
+	})
+	t.Run("istio not enabled", func(t *testing.T) {
+		require.Empty(t, isIstioNotInstalled(t.Context(), &Validator{apiReader: noIstioClient}, &edgeconnect.EdgeConnect{}))
+	})
+}
+
+func Test_istioWaypointWithoutAmbientMode(t *testing.T) {
+	t.Run("waypoint in ambient mode", func(t *testing.T) {
+		ec := &edgeconnect.EdgeConnect{Spec: edgeconnect.EdgeConnectSpec{
+			Istio: &istiospec.Spec{Mode: istiospec.AmbientMode, Waypoint: "waypoint"},
+		}}
+		require.Empty(t, istioWaypointWithoutAmbientMode(t.Context(), nil, ec))
+	})
+	t.Run("waypoint in sidecar mode", func(t *testing.T) {
+		ec := &edgeconnect.EdgeConnect{Spec: edgeconnect.EdgeConnectSpec{
+			Istio: &istiospec.Spec{Waypoint: "waypoint"},
+		}}
+		require.Equal(t, errorIstioWaypointWithoutAmbient, istioWaypointWithoutAmbientMode(t.Context(), nil, ec))
+	})
+}
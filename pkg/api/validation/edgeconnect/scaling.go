@@ -0,0 +1,43 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
+)
+
+const (
+	errorReplicasWithAutoscaling                = `The amount of replicas is managed by the autoscaler when spec.autoscaling is set! Please remove spec.replicas.`
+	errorAutoscalingMinReplicasExceedMax        = `spec.autoscaling.minReplicas (%d) must not be greater than spec.autoscaling.maxReplicas (%d).`
+	errorPodDisruptionBudgetMinAndMaxConfigured = `spec.podDisruptionBudget.minAvailable and spec.podDisruptionBudget.maxUnavailable are mutually exclusive! Please set only one of them.`
+)
+
+func checkAutoscaling(_ context.Context, _ *Validator, ec *edgeconnect.EdgeConnect) string {
+	autoscaling := ec.Spec.Autoscaling
+	if autoscaling == nil {
+		return ""
+	}
+
+	if ec.Spec.Replicas != nil {
+		return errorReplicasWithAutoscaling
+	}
+
+	if autoscaling.MinReplicas != nil && *autoscaling.MinReplicas > autoscaling.MaxReplicas {
+		return fmt.Sprintf(errorAutoscalingMinReplicasExceedMax, *autoscaling.MinReplicas, autoscaling.MaxReplicas)
+	}
+
+	return ""
+}
+
+func checkPodDisruptionBudget(_ context.Context, _ *Validator, ec *edgeconnect.EdgeConnect) string {
+	pdb := ec.Spec.PodDisruptionBudget
+	if pdb != nil && pdb.MinAvailable != nil && pdb.MaxUnavailable != nil {
+		return errorPodDisruptionBudgetMinAndMaxConfigured
+	}
+
+	return ""
+}
@@ -0,0 +1,72 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func Test_checkAutoscaling(t *testing.T) {
+	t.Run("accept edgeconnect without autoscaling", func(t *testing.T) {
+		ec := &edgeconnect.EdgeConnect{
+			Spec: edgeconnect.EdgeConnectSpec{Replicas: new(int32(2))},
+		}
+		require.Empty(t, checkAutoscaling(t.Context(), nil, ec))
+	})
+	t.Run("accept autoscaling with valid bounds", func(t *testing.T) {
+		ec := &edgeconnect.EdgeConnect{
+			Spec: edgeconnect.EdgeConnectSpec{
+				Autoscaling: &edgeconnect.AutoscalingSpec{MinReplicas: new(int32(2)), MaxReplicas: 2},
+			},
+		}
+		require.Empty(t, checkAutoscaling(t.Context(), nil, ec))
+	})
+	t.Run("reject autoscaling together with replicas", func(t *testing.T) {
+		ec := &edgeconnect.EdgeConnect{
+			Spec: edgeconnect.EdgeConnectSpec{
+				Replicas:    new(int32(2)),
+				Autoscaling: &edgeconnect.AutoscalingSpec{MaxReplicas: 3},
+			},
+		}
+		require.Equal(t, errorReplicasWithAutoscaling, checkAutoscaling(t.Context(), nil, ec))
+	})
+	t.Run("reject minReplicas greater than maxReplicas", func(t *testing.T) {
+		ec := &edgeconnect.EdgeConnect{
+			Spec: edgeconnect.EdgeConnectSpec{
+				Autoscaling: &edgeconnect.AutoscalingSpec{MinReplicas: new(int32(4)), MaxReplicas: 3},
+			},
+		}
+		require.Equal(t, fmt.Sprintf(errorAutoscalingMinReplicasExceedMax, 4, 3), checkAutoscaling(t.Context(), nil, ec))
+	})
+}
+
+func Test_checkPodDisruptionBudget(t *testing.T) {
+	t.Run("accept edgeconnect without pod disruption budget config", func(t *testing.T) {
+		require.Empty(t, checkPodDisruptionBudget(t.Context(), nil, &edgeconnect.EdgeConnect{}))
+	})
+	t.Run("accept minAvailable", func(t *testing.T) {
+		ec := &edgeconnect.EdgeConnect{
+			Spec: edgeconnect.EdgeConnectSpec{
+				PodDisruptionBudget: &edgeconnect.PodDisruptionBudgetSpec{MinAvailable: new(intstr.FromString("50%"))},
+			},
+		}
+		require.Empty(t, checkPodDisruptionBudget(t.Context(), nil, ec))
+	})
+	t.Run("reject minAvailable together with maxUnavailable", func(t *testing.T) {
+		ec := &edgeconnect.EdgeConnect{
+			Spec: edgeconnect.EdgeConnectSpec{
+				PodDisruptionBudget: &edgeconnect.PodDisruptionBudgetSpec{
+					MinAvailable:   new(intstr.FromInt32(1)),
+					MaxUnavailable: new(intstr.FromInt32(1)),
+				},
+			},
+		}
+		require.Equal(t, errorPodDisruptionBudgetMinAndMaxConfigured, checkPodDisruptionBudget(t.Context(), nil, ec))
+	})
+}
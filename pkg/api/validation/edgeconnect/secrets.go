@@ -0,0 +1,43 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
+	ecsecret "github.com/Dynatrace/dynatrace-operator/pkg/controllers/edgeconnect/secret"
+)
+
+const (
+	errorInvalidSecretName   = `The name of the secret '%s' is invalid, only alphanumeric characters, '-', '_' and '.' are allowed`
+	errorDuplicateSecretName = `The name of the secret '%s' is used more than once`
+	errorReservedSecretName  = `The name of the secret '%s' is reserved for Kubernetes Automation`
+	errorMissingSecretRef    = `The secret '%s' has to reference a key of a Kubernetes Secret`
+)
+
+var secretNameRegex = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+func checkSecrets(_ context.Context, _ *Validator, ec *edgeconnect.EdgeConnect) string {
+	names := make(map[string]bool, len(ec.Spec.Secrets))
+
+	for _, secretSpec := range ec.Spec.Secrets {
+		switch {
+		case !secretNameRegex.MatchString(secretSpec.Name):
+			return fmt.Sprintf(errorInvalidSecretName, secretSpec.Name)
+		case secretSpec.Name == ecsecret.KubernetesAPISecretName:
+			return fmt.Sprintf(errorReservedSecretName, secretSpec.Name)
+		case names[secretSpec.Name]:
+			return fmt.Sprintf(errorDuplicateSecretName, secretSpec.Name)
+		case secretSpec.SecretRef.Name == "" || secretSpec.SecretRef.Key == "":
+			return fmt.Sprintf(errorMissingSecretRef, secretSpec.Name)
+		}
+
+		names[secretSpec.Name] = true
+	}
+
+	return ""
+}
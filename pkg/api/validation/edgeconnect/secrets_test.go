@@ -0,0 +1,66 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_checkSecrets(t *testing.T) {
+	validRef := corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"}, Key: "value"}
+
+	newEdgeConnect := func(secrets ...edgeconnect.SecretSpec) *edgeconnect.EdgeConnect {
+		return &edgeconnect.EdgeConnect{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testName,
+				Namespace: testNamespace,
+			},
+			Spec: edgeconnect.EdgeConnectSpec{
+				APIServer: "tenantid-test.dev.apps.dynatracelabs.com",
+				OAuth: edgeconnect.OAuthSpec{
+					ClientSecret: "secret",
+					Endpoint:     testValidOAuthEndpoint,
+					Resource:     "resource",
+				},
+				Secrets: secrets,
+			},
+		}
+	}
+
+	t.Run("valid secrets are allowed", func(t *testing.T) {
+		ec := newEdgeConnect(
+			edgeconnect.SecretSpec{Name: "MY_API_KEY", SecretRef: validRef},
+			edgeconnect.SecretSpec{Name: "other.key", SecretRef: validRef, RestrictHostsTo: []string{"internal.org"}},
+		)
+		assertAllowed(t, ec, prepareTestServiceAccount(t, testServiceAccountName, testNamespace))
+	})
+
+	t.Run("invalid name is denied", func(t *testing.T) {
+		ec := newEdgeConnect(edgeconnect.SecretSpec{Name: "my/key", SecretRef: validRef})
+		assertDenied(t, []string{fmt.Sprintf(errorInvalidSecretName, "my/key")}, ec, prepareTestServiceAccount(t, testServiceAccountName, testNamespace))
+	})
+
+	t.Run("duplicate name is denied", func(t *testing.T) {
+		ec := newEdgeConnect(
+			edgeconnect.SecretSpec{Name: "MY_API_KEY", SecretRef: validRef},
+			edgeconnect.SecretSpec{Name: "MY_API_KEY", SecretRef: validRef},
+		)
+		assertDenied(t, []string{fmt.Sprintf(errorDuplicateSecretName, "MY_API_KEY")}, ec, prepareTestServiceAccount(t, testServiceAccountName, testNamespace))
+	})
+
+	t.Run("reserved name is denied", func(t *testing.T) {
+		ec := newEdgeConnect(edgeconnect.SecretSpec{Name: "K8S_SERVICE_ACCOUNT_TOKEN", SecretRef: validRef})
+		assertDenied(t, []string{fmt.Sprintf(errorReservedSecretName, "K8S_SERVICE_ACCOUNT_TOKEN")}, ec, prepareTestServiceAccount(t, testServiceAccountName, testNamespace))
+	})
+
+	t.Run("missing secret reference is denied", func(t *testing.T) {
+		ec := newEdgeConnect(edgeconnect.SecretSpec{Name: "MY_API_KEY"})
+		assertDenied(t, []string{fmt.Sprintf(errorMissingSecretRef, "MY_API_KEY")}, ec, prepareTestServiceAccount(t, testServiceAccountName, testNamespace))
+	})
+}
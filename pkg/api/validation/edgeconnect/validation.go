@@ -33,11 +33,17 @@ var validatorErrorFuncs = []validatorFunc{
 	isAllowedSuffixAPIServer,
 	nameTooLong,
 	checkHostPatternsValue,
+	checkHostMappings,
+	checkSecrets,
+	checkAutoscaling,
+	checkPodDisruptionBudget,
 	isInvalidServiceName,
 	automationRequiresProvisionerValidation,
 	isValidSSOServerURL,
 	checkSSOServerProtocol,
 	isAllowedSSOServer,
+	isIstioNotInstalled,
+	istioWaypointWithoutAmbientMode,
 }
 
 func New(apiReader client.Reader, cfg *rest.Config) admission.Validator[runtime.Object] {
@@ -71,6 +71,7 @@ func NewClient(options ...Option) (*Client, error) {
 		return nil, errors.Wrap(err, "could not get client config")
 	}
 
+	addRateLimitMiddleware(httpClient, config)
 	addCacheMiddleware(httpClient, config)
 
 	if len(config.APIToken) == 0 && len(config.PaasToken) == 0 {
@@ -107,6 +108,7 @@ func NewOAuthClient(credentials clientcredentials.Config, options ...Option) (*O
 		return nil, errors.Wrap(err, "could not get oauth config")
 	}
 
+	addRateLimitMiddleware(httpClient, config)
 	addCacheMiddleware(httpClient, config)
 
 	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, httpClient)
@@ -274,6 +276,16 @@ func WithConnectionTimeout(timeout time.Duration) Option {
 	}
 }
 
+// addRateLimitMiddleware has to be added before the cache, so responses served from the cache don't count against the rate limit.
+func addRateLimitMiddleware(httpClient *http.Client, config *Config) {
+	tenant := ""
+	if config.BaseURL != nil {
+		tenant = config.BaseURL.Host
+	}
+
+	httpClient.Transport = middleware.NewRateLimitRoundTripper(httpClient.Transport, tenant)
+}
+
 func addCacheMiddleware(httpClient *http.Client, config *Config) {
 	httpClient.Transport = middleware.NewCacheRoundTripper(httpClient.Transport, config.CacheEntryTTL)
 }
@@ -0,0 +1,395 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+)
+
+const (
+	defaultRequestsPerSecond = 10
+	defaultBurst             = 20
+	defaultMaxRetries        = 3
+
+	initialBackoff = 500 * time.Millisecond
+	maxBackoff     = 30 * time.Second
+
+	// maxRetryAfter caps how long a request waits for a Retry-After of the server, longer waits are returned to the caller instead
+	maxRetryAfter = 2 * time.Minute
+
+	// minReportedWait is the shortest client side wait that is reported as throttling, shorter waits are regular smoothing of bursts
+	minReportedWait = time.Second
+)
+
+// Limit is a token bucket, RequestsPerSecond tokens are added per second up to Burst.
+type Limit struct {
+	RequestsPerSecond float64 `json:"requestsPerSecond"`
+	Burst             int     `json:"burst"`
+}
+
+// RateLimits configures the client side rate limiting and retries of all requests to the Dynatrace API.
+// Each tenant has its own token buckets, they are shared by all clients of the tenant in the process.
+type RateLimits struct {
+	// Endpoints overrides the Default limit for requests whose path contains the key, the longest matching key wins.
+	// Every endpoint has its own bucket, requests to it don't count against the Default limit.
+	Endpoints map[string]Limit `json:"endpoints,omitempty"`
+
+	// MaxRetries is the number of retries of a failed request, 0 disables retries.
+	MaxRetries *int `json:"maxRetries,omitempty"`
+
+	Default Limit `json:"default"`
+}
+
+func DefaultRateLimits() RateLimits {
+	return RateLimits{
+		Default: Limit{
+			RequestsPerSecond: defaultRequestsPerSecond,
+			Burst:             defaultBurst,
+		},
+		MaxRetries: new(defaultMaxRetries),
+	}
+}
+
+// ParseRateLimits reads the JSON encoded RateLimits, unset fields keep their defaults.
+func ParseRateLimits(raw string) (RateLimits, error) {
+	limits := DefaultRateLimits()
+	if raw == "" {
+		return limits, nil
+	}
+
+	if err := json.Unmarshal([]byte(raw), &limits); err != nil {
+		return limits, errors.Wrap(err, "failed to parse rate limits")
+	}
+
+	if err := limits.validate(); err != nil {
+		return limits, err
+	}
+
+	return limits, nil
+}
+
+func (l RateLimits) validate() error {
+	if err := l.Default.validate("default"); err != nil {
+		return err
+	}
+
+	for endpoint, limit := range l.Endpoints {
+		if err := limit.validate(endpoint); err != nil {
+			return err
+		}
+	}
+
+	if l.MaxRetries != nil && *l.MaxRetries < 0 {
+		return errors.Errorf("maxRetries must not be negative, got %d", *l.MaxRetries)
+	}
+
+	return nil
+}
+
+func (l Limit) validate(name string) error {
+	if l.RequestsPerSecond <= 0 || l.Burst <= 0 {
+		return errors.Errorf("rate limit %q must have positive requestsPerSecond and burst", name)
+	}
+
+	return nil
+}
+
+// forPath returns the key of the bucket and the limit that applies to the path.
+func (l RateLimits) forPath(path string) (string, Limit) {
+	key, limit := "", l.Default
+
+	for endpoint, endpointLimit := range l.Endpoints {
+		if strings.Contains(path, endpoint) && len(endpoint) > len(key) {
+			key, limit = endpoint, endpointLimit
+		}
+	}
+
+	return key, limit
+}
+
+func (l RateLimits) maxRetries() int {
+	if l.MaxRetries == nil {
+		return defaultMaxRetries
+	}
+
+	return *l.MaxRetries
+}
+
+var (
+	rateLimitsMu sync.RWMutex
+	rateLimits   = DefaultRateLimits()
+
+	buckets = newBucketRegistry()
+)
+
+// SetRateLimits replaces the limits of all clients, the existing token buckets are discarded.
+func SetRateLimits(limits RateLimits) {
+	rateLimitsMu.Lock()
+	defer rateLimitsMu.Unlock()
+
+	rateLimits = limits
+	buckets.reset()
+}
+
+func getRateLimits() RateLimits {
+	rateLimitsMu.RLock()
+	defer rateLimitsMu.RUnlock()
+
+	return rateLimits
+}
+
+// bucket is the token bucket of a tenant endpoint.
+// A Retry-After of the server blocks the bucket, so the other requests to the endpoint wait as well.
+type bucket struct {
+	blockedUntil time.Time
+	limiter      *rate.Limiter
+	mu           sync.Mutex
+}
+
+func (b *bucket) wait(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+
+	b.mu.Lock()
+	blocked := time.Until(b.blockedUntil)
+	b.mu.Unlock()
+
+	if err := sleep(ctx, blocked); err != nil {
+		return time.Since(start), err
+	}
+
+	if err := b.limiter.Wait(ctx); err != nil {
+		return time.Since(start), errors.WithStack(err)
+	}
+
+	return time.Since(start), nil
+}
+
+func (b *bucket) block(d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if until := time.Now().Add(d); until.After(b.blockedUntil) {
+		b.blockedUntil = until
+	}
+}
+
+type bucketRegistry struct {
+	buckets map[string]*bucket
+	mu      sync.Mutex
+}
+
+func newBucketRegistry() *bucketRegistry {
+	return &bucketRegistry{buckets: map[string]*bucket{}}
+}
+
+func (br *bucketRegistry) get(tenant, endpoint string, limit Limit) *bucket {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+
+	key := tenant + "|" + endpoint
+
+	b, ok := br.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), limit.Burst)}
+		br.buckets[key] = b
+	}
+
+	return b
+}
+
+func (br *bucketRegistry) reset() {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+
+	br.buckets = map[string]*bucket{}
+}
+
+// NewRateLimitRoundTripper limits the requests to the tenant according to the configured RateLimits.
+// Requests that were rejected by the server are retried with a jittered exponential backoff or after the Retry-After of the response:
+//   - 429 Too Many Requests is retried for every method, as the server didn't process the request
+//   - 502, 503, 504 and network errors are only retried for idempotent methods
+//
+// The request body must be replayable via GetBody to be retried, which is the case for all requests of the core client.
+// A retry that can't happen before the deadline of the request, which includes the Timeout of the http.Client, is not made,
+// the response is returned to the caller unchanged instead and the bucket is not blocked.
+func NewRateLimitRoundTripper(next http.RoundTripper, tenant string) http.RoundTripper {
+	return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		ctx := r.Context()
+		limits := getRateLimits()
+		endpoint, limit := limits.forPath(r.URL.Path)
+		b := buckets.get(tenant, endpoint, limit)
+		host := r.URL.Host
+
+		for attempt := 0; ; attempt++ {
+			waited, err := b.wait(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			if waited >= minReportedWait {
+				reportThrottling(ctx, host, ClientSideThrottle, waited)
+			}
+
+			req, err := rewind(r, attempt)
+			if err != nil {
+				return nil, err
+			}
+
+			resp, err := next.RoundTrip(req)
+
+			delay, ok := retryDelay(r, resp, err, attempt, limits.maxRetries())
+			if !ok || exceedsDeadline(ctx, delay) {
+				return resp, err
+			}
+
+			if resp != nil {
+				if resp.StatusCode == http.StatusTooManyRequests {
+					b.block(delay)
+					reportThrottling(ctx, host, ServerSideThrottle, delay)
+				}
+
+				_, _ = io.Copy(io.Discard, resp.Body)
+				_ = resp.Body.Close()
+			}
+
+			retriesTotal.WithLabelValues(host).Inc()
+			rateLimitLog.Debug("retrying request", "url", r.URL.String(), "attempt", attempt+1, "delay", delay)
+
+			if err := sleep(ctx, delay); err != nil {
+				return nil, err
+			}
+		}
+	})
+}
+
+// rewind returns the request for the attempt, retries get a fresh copy of the body.
+func rewind(r *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 0 || r.Body == nil || r.Body == http.NoBody {
+		return r, nil
+	}
+
+	body, err := r.GetBody()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	req := r.Clone(r.Context())
+	req.Body = body
+
+	return req, nil
+}
+
+// retryDelay decides whether the result of an attempt is retried and how long to wait before.
+func retryDelay(r *http.Request, resp *http.Response, err error, attempt, maxRetries int) (time.Duration, bool) {
+	if attempt >= maxRetries || r.Context().Err() != nil {
+		return 0, false
+	}
+
+	if r.Body != nil && r.Body != http.NoBody && r.GetBody == nil {
+		return 0, false
+	}
+
+	idempotent := isIdempotent(r.Method)
+
+	if err != nil {
+		var netErr net.Error
+		if idempotent && errors.As(err, &netErr) {
+			return backoff(attempt), true
+		}
+
+		return 0, false
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return retryAfterOrBackoff(resp, attempt)
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if idempotent {
+			return retryAfterOrBackoff(resp, attempt)
+		}
+	}
+
+	return 0, false
+}
+
+// exceedsDeadline reports whether waiting for the delay would run past the deadline of the context.
+func exceedsDeadline(ctx context.Context, delay time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+
+	return ok && delay >= time.Until(deadline)
+}
+
+func retryAfterOrBackoff(resp *http.Response, attempt int) (time.Duration, bool) {
+	delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"))
+	if !ok {
+		return backoff(attempt), true
+	}
+
+	if delay > maxRetryAfter {
+		return 0, false
+	}
+
+	return delay, true
+}
+
+// parseRetryAfter supports both formats of the header, delay in seconds and HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+// backoff is the full jitter exponential backoff, a random delay between 0 and min(maxBackoff, initialBackoff * 2^attempt).
+func backoff(attempt int) time.Duration {
+	ceiling := min(initialBackoff<<min(attempt, 16), maxBackoff)
+
+	return rand.N(ceiling) + 1
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return errors.WithStack(ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,303 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTenant = "tenant.example.com"
+
+// useRateLimits replaces the package-level limits and buckets, and restores the defaults after the test.
+func useRateLimits(t *testing.T, limits RateLimits) {
+	t.Helper()
+	SetRateLimits(limits)
+	t.Cleanup(func() { SetRateLimits(DefaultRateLimits()) })
+}
+
+func statusResponse(status int, header http.Header) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewBufferString("")),
+	}
+}
+
+type fakeServer struct {
+	bodies    []string
+	responses []*http.Response
+	calls     []time.Time
+}
+
+func (f *fakeServer) roundTripper() http.RoundTripper {
+	return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		f.calls = append(f.calls, time.Now())
+
+		if r.Body != nil {
+			body, _ := io.ReadAll(r.Body)
+			f.bodies = append(f.bodies, string(body))
+		}
+
+		resp := f.responses[min(len(f.calls), len(f.responses))-1]
+		resp.Request = r
+
+		return resp, nil
+	})
+}
+
+func newTestRequest(t *testing.T, method, body string) *http.Request {
+	t.Helper()
+
+	var reader io.Reader
+	if body != "" {
+		reader = bytes.NewReader([]byte(body))
+	}
+
+	r, err := http.NewRequestWithContext(t.Context(), method, "https://"+testTenant+"/api/v1/deployment/installer/agent/unix", reader)
+	require.NoError(t, err)
+
+	return r
+}
+
+func TestNewRateLimitRoundTripper(t *testing.T) {
+	t.Run("requests are delayed by the client side limit", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			useRateLimits(t, RateLimits{Default: Limit{RequestsPerSecond: 1, Burst: 1}})
+
+			server := &fakeServer{responses: []*http.Response{statusResponse(http.StatusOK, nil)}}
+			rt := NewRateLimitRoundTripper(server.roundTripper(), testTenant)
+
+			ctx, observer := ContextWithThrottleObserver(t.Context())
+			start := time.Now()
+
+			for range 3 {
+				_, err := rt.RoundTrip(newTestRequest(t, http.MethodGet, "").WithContext(ctx))
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, 2*time.Second, time.Since(start))
+
+			count, waited := observer.Throttled(ClientSideThrottle)
+			assert.Equal(t, 2, count)
+			assert.Equal(t, 2*time.Second, waited)
+		})
+	})
+
+	t.Run("endpoint limits use their own bucket", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			useRateLimits(t, RateLimits{
+				Default: Limit{RequestsPerSecond: 100, Burst: 100},
+				Endpoints: map[string]Limit{
+					"/v1/deployment":                 {RequestsPerSecond: 100, Burst: 100},
+					"/v1/deployment/installer/agent": {RequestsPerSecond: 0.1, Burst: 1},
+				},
+			})
+
+			server := &fakeServer{responses: []*http.Response{statusResponse(http.StatusOK, nil)}}
+			rt := NewRateLimitRoundTripper(server.roundTripper(), testTenant)
+			start := time.Now()
+
+			for range 2 {
+				_, err := rt.RoundTrip(newTestRequest(t, http.MethodGet, ""))
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, 10*time.Second, time.Since(start))
+
+			other, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "https://"+testTenant+"/api/v1/settings", nil)
+			require.NoError(t, err)
+
+			start = time.Now()
+			_, err = rt.RoundTrip(other)
+			require.NoError(t, err)
+			assert.Zero(t, time.Since(start))
+		})
+	})
+
+	t.Run("429 is retried after Retry-After and blocks the bucket", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			useRateLimits(t, DefaultRateLimits())
+
+			server := &fakeServer{responses: []*http.Response{
+				statusResponse(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"5"}}),
+				statusResponse(http.StatusCreated, nil),
+			}}
+			rt := NewRateLimitRoundTripper(server.roundTripper(), testTenant)
+
+			ctx, observer := ContextWithThrottleObserver(t.Context())
+
+			resp, err := rt.RoundTrip(newTestRequest(t, http.MethodPost, "payload").WithContext(ctx))
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+			require.Len(t, server.calls, 2)
+			assert.Equal(t, 5*time.Second, server.calls[1].Sub(server.calls[0]))
+			assert.Equal(t, []string{"payload", "payload"}, server.bodies)
+
+			count, waited := observer.Throttled(ServerSideThrottle)
+			assert.Equal(t, 1, count)
+			assert.Equal(t, 5*time.Second, waited)
+		})
+	})
+
+	t.Run("Retry-After as HTTP date", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			useRateLimits(t, DefaultRateLimits())
+
+			retryAt := time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)
+			server := &fakeServer{responses: []*http.Response{
+				statusResponse(http.StatusServiceUnavailable, http.Header{"Retry-After": []string{retryAt}}),
+				statusResponse(http.StatusOK, nil),
+			}}
+			rt := NewRateLimitRoundTripper(server.roundTripper(), testTenant)
+
+			resp, err := rt.RoundTrip(newTestRequest(t, http.MethodGet, ""))
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			require.Len(t, server.calls, 2)
+			assert.Equal(t, 10*time.Second, server.calls[1].Sub(server.calls[0]))
+		})
+	})
+
+	t.Run("server errors are not retried for non-idempotent requests", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			useRateLimits(t, DefaultRateLimits())
+
+			server := &fakeServer{responses: []*http.Response{statusResponse(http.StatusServiceUnavailable, nil)}}
+			rt := NewRateLimitRoundTripper(server.roundTripper(), testTenant)
+
+			resp, err := rt.RoundTrip(newTestRequest(t, http.MethodPost, "payload"))
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+			assert.Len(t, server.calls, 1)
+		})
+	})
+
+	t.Run("retries stop after max retries", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			useRateLimits(t, RateLimits{Default: Limit{RequestsPerSecond: 100, Burst: 100}, MaxRetries: new(2)})
+
+			server := &fakeServer{responses: []*http.Response{statusResponse(http.StatusBadGateway, nil)}}
+			rt := NewRateLimitRoundTripper(server.roundTripper(), testTenant)
+
+			resp, err := rt.RoundTrip(newTestRequest(t, http.MethodGet, ""))
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+			assert.Len(t, server.calls, 3)
+		})
+	})
+
+	t.Run("too long Retry-After is returned to the caller", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			useRateLimits(t, DefaultRateLimits())
+
+			retryAfter := strconv.Itoa(int((maxRetryAfter + time.Second).Seconds()))
+			server := &fakeServer{responses: []*http.Response{
+				statusResponse(http.StatusTooManyRequests, http.Header{"Retry-After": []string{retryAfter}}),
+			}}
+			rt := NewRateLimitRoundTripper(server.roundTripper(), testTenant)
+
+			resp, err := rt.RoundTrip(newTestRequest(t, http.MethodGet, ""))
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+			assert.Len(t, server.calls, 1)
+		})
+	})
+
+	t.Run("Retry-After beyond the client timeout is returned to the caller", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			useRateLimits(t, DefaultRateLimits())
+
+			server := &fakeServer{responses: []*http.Response{
+				statusResponse(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"60"}}),
+				statusResponse(http.StatusOK, nil),
+			}}
+			httpClient := &http.Client{
+				Transport: NewRateLimitRoundTripper(server.roundTripper(), testTenant),
+				Timeout:   30 * time.Second,
+			}
+
+			resp, err := httpClient.Do(newTestRequest(t, http.MethodGet, ""))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+			assert.Len(t, server.calls, 1)
+
+			b := buckets.get(testTenant, "", DefaultRateLimits().Default)
+			assert.True(t, b.blockedUntil.IsZero())
+		})
+	})
+
+	t.Run("waiting respects the context", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			useRateLimits(t, DefaultRateLimits())
+
+			server := &fakeServer{responses: []*http.Response{
+				statusResponse(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"60"}}),
+			}}
+			rt := NewRateLimitRoundTripper(server.roundTripper(), testTenant)
+
+			ctx, cancel := context.WithCancel(t.Context())
+			time.AfterFunc(10*time.Second, cancel)
+
+			_, err := rt.RoundTrip(newTestRequest(t, http.MethodGet, "").WithContext(ctx))
+			require.ErrorIs(t, err, context.Canceled)
+			assert.Len(t, server.calls, 1)
+		})
+	})
+}
+
+func TestParseRateLimits(t *testing.T) {
+	t.Run("empty uses defaults", func(t *testing.T) {
+		limits, err := ParseRateLimits("")
+		require.NoError(t, err)
+		assert.Equal(t, DefaultRateLimits(), limits)
+	})
+
+	t.Run("unset fields keep defaults", func(t *testing.T) {
+		limits, err := ParseRateLimits(`{"endpoints":{"/v1/deployment":{"requestsPerSecond":0.5,"burst":2}}}`)
+		require.NoError(t, err)
+		assert.Equal(t, DefaultRateLimits().Default, limits.Default)
+		assert.Equal(t, defaultMaxRetries, limits.maxRetries())
+
+		key, limit := limits.forPath("/api/v1/deployment/installer/agent/unix")
+		assert.Equal(t, "/v1/deployment", key)
+		assert.Equal(t, Limit{RequestsPerSecond: 0.5, Burst: 2}, limit)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParseRateLimits(`{"default":{"requestsPerSecond":0,"burst":1}}`)
+		require.Error(t, err)
+
+		_, err = ParseRateLimits(`{"maxRetries":-1}`)
+		require.Error(t, err)
+
+		_, err = ParseRateLimits(`not json`)
+		require.Error(t, err)
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt := range 10 {
+		delay := backoff(attempt)
+		assert.Positive(t, delay)
+		assert.LessOrEqual(t, delay, min(initialBackoff<<attempt, maxBackoff))
+	}
+}
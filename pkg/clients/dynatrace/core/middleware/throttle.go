@@ -0,0 +1,98 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+type ThrottleReason string
+
+const (
+	// ClientSideThrottle means the request waited for the client side rate limit.
+	ClientSideThrottle ThrottleReason = "client"
+	// ServerSideThrottle means the server responded with 429 Too Many Requests.
+	ServerSideThrottle ThrottleReason = "server"
+)
+
+var (
+	rateLimitLog = logd.Get().WithName("dynatraceapi-ratelimit")
+
+	throttledTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "dynatrace",
+		Subsystem: "api_client",
+		Name:      "throttled_requests_total",
+		Help:      "Number of requests to the Dynatrace API that were delayed by the client side rate limit or by the server",
+	}, []string{"host", "reason"})
+
+	throttledSeconds = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "dynatrace",
+		Subsystem: "api_client",
+		Name:      "throttled_seconds_total",
+		Help:      "Time requests to the Dynatrace API were delayed by the client side rate limit or by the server",
+	}, []string{"host", "reason"})
+
+	retriesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "dynatrace",
+		Subsystem: "api_client",
+		Name:      "retries_total",
+		Help:      "Number of retried requests to the Dynatrace API",
+	}, []string{"host"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(throttledTotal, throttledSeconds, retriesTotal)
+}
+
+type throttleObserverKey struct{}
+
+// ThrottleObserver collects the throttled requests of the calls made with its context.
+type ThrottleObserver struct {
+	waited map[ThrottleReason]time.Duration
+	count  map[ThrottleReason]int
+	mu     sync.Mutex
+}
+
+// ContextWithThrottleObserver returns a context whose requests to the Dynatrace API report throttling to the returned observer.
+func ContextWithThrottleObserver(ctx context.Context) (context.Context, *ThrottleObserver) {
+	observer := &ThrottleObserver{
+		waited: map[ThrottleReason]time.Duration{},
+		count:  map[ThrottleReason]int{},
+	}
+
+	return context.WithValue(ctx, throttleObserverKey{}, observer), observer
+}
+
+// Throttled returns the number of throttled requests and the total time they were delayed for the reason.
+func (o *ThrottleObserver) Throttled(reason ThrottleReason) (int, time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.count[reason], o.waited[reason]
+}
+
+func (o *ThrottleObserver) observe(reason ThrottleReason, waited time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.count[reason]++
+	o.waited[reason] += waited
+}
+
+func reportThrottling(ctx context.Context, host string, reason ThrottleReason, waited time.Duration) {
+	rateLimitLog.Info("request to the Dynatrace API was throttled", "host", host, "reason", reason, "delay", waited)
+
+	throttledTotal.WithLabelValues(host, string(reason)).Inc()
+	throttledSeconds.WithLabelValues(host, string(reason)).Add(waited.Seconds())
+
+	if observer, ok := ctx.Value(throttleObserverKey{}).(*ThrottleObserver); ok {
+		observer.observe(reason, waited)
+	}
+}
@@ -19,13 +19,25 @@ const (
 var errNoEdgeConnectID = errors.New("no EdgeConnect ID given")
 
 type APIResponse struct {
-	ID                         string   `json:"id"`
-	Name                       string   `json:"name"`
-	OauthClientID              string   `json:"oauthClientId"`
-	OauthClientSecret          string   `json:"oauthClientSecret"`
-	OauthClientResource        string   `json:"oauthClientResource"`
-	HostPatterns               []string `json:"hostPatterns"`
-	ManagedByDynatraceOperator bool     `json:"managedByDynatraceOperator"`
+	ID                         string                    `json:"id"`
+	Name                       string                    `json:"name"`
+	OauthClientID              string                    `json:"oauthClientId"`
+	OauthClientSecret          string                    `json:"oauthClientSecret"`
+	OauthClientResource        string                    `json:"oauthClientResource"`
+	HostPatterns               []string                  `json:"hostPatterns"`
+	HostMappings               []edgeconnect.HostMapping `json:"hostMappings"`
+	ManagedByDynatraceOperator bool                      `json:"managedByDynatraceOperator"`
+	Metadata                   Metadata                  `json:"metadata"`
+}
+
+type Metadata struct {
+	// Instances contains the EdgeConnect instances that are currently connected to the tenant
+	Instances []InstanceMetadata `json:"instances"`
+}
+
+type InstanceMetadata struct {
+	InstanceID string `json:"instanceId"`
+	Version    string `json:"version"`
 }
 
 type listResponse struct {
@@ -0,0 +1,260 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package faketenant
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/hostevent"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/oneagent"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/token"
+)
+
+const (
+	agentInstallerPath   = APIPath + "/v1/deployment/installer/agent"
+	gatewayInstallerPath = APIPath + "/v1/deployment/installer/gateway"
+
+	communicationPath = "/communication"
+)
+
+func (s *Server) registerAPIHandlers() {
+	s.mux.HandleFunc("POST "+APIPath+"/v2/apiTokens/lookup", s.withAPIToken("", s.lookupToken))
+	s.mux.HandleFunc("POST "+APIPath+"/v2/activeGateTokens", s.withAPIToken(token.ScopeActiveGateTokenCreate, s.createActiveGateToken))
+	s.mux.HandleFunc("GET "+gatewayInstallerPath+"/connectioninfo", s.withAPIToken("", s.getActiveGateConnectionInfo))
+	s.mux.HandleFunc("GET "+gatewayInstallerPath+"/{os}/latest/metainfo", s.withAPIToken("", s.getLatestGatewayVersion))
+	s.mux.HandleFunc("GET "+agentInstallerPath+"/connectioninfo", s.withAPIToken(token.ScopeInstallerDownload, s.getOneAgentConnectionInfo))
+	s.mux.HandleFunc("GET "+agentInstallerPath+"/processmoduleconfig", s.withAPIToken(token.ScopeInstallerDownload, s.getProcessModuleConfig))
+	s.mux.HandleFunc("GET "+agentInstallerPath+"/processgroupingconfig", s.withAPIToken("", s.getProcessGroupingConfig))
+	s.mux.HandleFunc("GET "+agentInstallerPath+"/{path...}", s.withAPIToken(token.ScopeInstallerDownload, s.getAgentInstaller))
+	s.mux.HandleFunc("GET "+APIPath+"/v2/fleetManagement/components/containerImages", s.withAPIToken("", s.getContainerImages))
+	s.mux.HandleFunc("GET "+APIPath+"/v1/entity/infrastructure/hosts", s.withAPIToken(token.ScopeDataExport, s.getHosts))
+	s.mux.HandleFunc("POST "+APIPath+"/v1/events", s.withAPIToken(token.ScopeDataExport, s.sendEvent))
+}
+
+func (s *Server) lookupToken(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Token string `json:"token"`
+	}
+
+	if !readJSON(w, r, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	scopes, ok := s.state.tokens[request.Token]
+	s.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "Token not found")
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string][]string{"scopes": scopes})
+}
+
+func (s *Server) createActiveGateToken(w http.ResponseWriter, r *http.Request) {
+	var agToken ActiveGateToken
+
+	if !readJSON(w, r, &agToken) {
+		return
+	}
+
+	s.mu.Lock()
+	agToken.ID = s.state.newID("dt0g02.")
+	agToken.Token = agToken.ID + ".AGTOKEN"
+	s.state.activeGateTokens = append(s.state.activeGateTokens, agToken)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, activegate.AuthTokenInfo{TokenID: agToken.ID, Token: agToken.Token})
+}
+
+func (s *Server) getActiveGateConnectionInfo(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]string{
+		"tenantUUID":             s.state.tenantUUID,
+		"tenantToken":            s.state.tenantToken,
+		"communicationEndpoints": strings.Join(s.state.communicationEndpoints(r), ","),
+	})
+}
+
+func (s *Server) getOneAgentConnectionInfo(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	endpoints := s.state.communicationEndpoints(r)
+
+	if zone := r.URL.Query().Get("networkZone"); zone != "" {
+		zoneEndpoints, ok := s.state.networkZones[zone]
+
+		switch {
+		case ok:
+			endpoints = zoneEndpoints
+		case r.URL.Query().Get("defaultZoneFallback") != "true":
+			writeError(w, http.StatusBadRequest, "Unknown network zone: "+zone)
+
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"tenantUUID":             s.state.tenantUUID,
+		"tenantToken":            s.state.tenantToken,
+		"communicationEndpoints": endpoints,
+	})
+}
+
+func (s *Server) getProcessModuleConfig(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	config := oneagent.ProcessModuleConfig{Revision: 1}
+	config.Add(oneagent.ProcessModuleProperty{Section: "general", Key: "tenant", Value: s.state.tenantUUID})
+	config.Add(oneagent.ProcessModuleProperty{Section: "general", Key: "tenantToken", Value: s.state.tenantToken})
+	config.Add(oneagent.ProcessModuleProperty{Section: "general", Key: "serverAddress", Value: "{" + strings.Join(s.state.communicationEndpoints(r), ";") + "}"})
+	config.Add(oneagent.ProcessModuleProperty{Section: "general", Key: "hostGroup", Value: r.URL.Query().Get("hostgroup")})
+	config.SortPropertiesByKey()
+
+	writeJSON(w, http.StatusOK, config)
+}
+
+func (s *Server) getProcessGroupingConfig(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	data := s.state.processGroupingConfig
+	s.mu.Unlock()
+
+	if r.URL.Query().Get("kubernetesClusterId") == "" {
+		writeError(w, http.StatusBadRequest, "Missing kubernetesClusterId")
+
+		return
+	} else if data == nil {
+		writeError(w, http.StatusNotFound, "Process grouping config not available")
+
+		return
+	}
+
+	hash := sha256.Sum256(data)
+	etag := `"` + hex.EncodeToString(hash[:]) + `"`
+
+	w.Header().Set("ETag", etag)
+
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/cbor")
+	_, _ = w.Write(data)
+}
+
+// getAgentInstaller serves the version listing, latest version and the download of OneAgent installers.
+// The paths overlap, e.g. versions/unix/paas and unix/paas/latest, so they are routed here instead of by the mux.
+func (s *Server) getAgentInstaller(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	agentVersion := s.state.agentVersion
+	s.mu.Unlock()
+
+	segments := strings.Split(r.PathValue("path"), "/")
+
+	switch {
+	case len(segments) == 3 && segments[0] == "versions":
+		writeJSON(w, http.StatusOK, map[string][]string{"availableVersions": {agentVersion}})
+	case len(segments) == 4 && segments[2] == "latest" && segments[3] == "metainfo":
+		writeJSON(w, http.StatusOK, map[string]string{"latestAgentVersion": agentVersion})
+	case len(segments) == 3 && segments[2] == "latest",
+		len(segments) == 4 && segments[2] == "version":
+		writeInstaller(w)
+	default:
+		writeError(w, http.StatusNotFound, "Unknown installer path")
+	}
+}
+
+// writeInstaller writes an empty zip archive, enough for the operator to unpack it.
+func writeInstaller(w http.ResponseWriter) {
+	var buf bytes.Buffer
+
+	if err := zip.NewWriter(&buf).Close(); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	_, _ = w.Write(buf.Bytes())
+}
+
+func (s *Server) getLatestGatewayVersion(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]string{"latestGatewayVersion": s.state.gatewayVersion})
+}
+
+func (s *Server) getContainerImages(w http.ResponseWriter, r *http.Request) {
+	type component struct {
+		Type     string `json:"type"`
+		ImageURI string `json:"imageUri"`
+	}
+
+	registry := r.URL.Query().Get("registry")
+	components := []component{}
+
+	s.mu.Lock()
+	for componentType, uri := range s.state.images {
+		if registry == "" || strings.HasPrefix(uri, registry+"/") {
+			components = append(components, component{Type: string(componentType), ImageURI: uri})
+		}
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]any{"components": components})
+}
+
+func (s *Server) getHosts(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hosts := s.state.hosts
+	if hosts == nil {
+		hosts = []hostevent.HostResponse{}
+	}
+
+	writeJSON(w, http.StatusOK, hosts)
+}
+
+func (s *Server) sendEvent(w http.ResponseWriter, r *http.Request) {
+	var event hostevent.Event
+
+	if !readJSON(w, r, &event) {
+		return
+	}
+
+	s.mu.Lock()
+	s.state.events = append(s.state.events, event)
+	eventID := s.state.newID("event-")
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, map[string][]string{
+		"storedEventIds": {eventID},
+		"storedIds":      {eventID},
+	})
+}
+
+// communicationEndpoints returns the configured endpoints, or an endpoint on the address of the fake tenant.
+func (st *state) communicationEndpoints(r *http.Request) []string {
+	if len(st.endpoints) > 0 {
+		return st.endpoints
+	}
+
+	return []string{baseURL(r) + communicationPath}
+}
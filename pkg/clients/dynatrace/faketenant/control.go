@@ -0,0 +1,38 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package faketenant
+
+import (
+	"net/http"
+)
+
+// registerControlHandlers serves the state inspection and failure injection, so a standalone server can be controlled by tests.
+// The control endpoints are neither authenticated nor recorded and failures are never injected into them.
+func (s *Server) registerControlHandlers() {
+	s.mux.HandleFunc("GET "+ControlPath+"/state", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, s.State())
+	})
+
+	s.mux.HandleFunc("POST "+ControlPath+"/failures", func(w http.ResponseWriter, r *http.Request) {
+		var failure Failure
+
+		if !readJSON(w, r, &failure) {
+			return
+		}
+
+		if http.StatusText(failure.StatusCode) == "" {
+			writeError(w, http.StatusBadRequest, "Invalid status code")
+
+			return
+		}
+
+		s.InjectFailure(failure)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	s.mux.HandleFunc("DELETE "+ControlPath+"/failures", func(w http.ResponseWriter, _ *http.Request) {
+		s.ClearFailures()
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,73 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package faketenant
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Failure makes the fake tenant answer matching requests with an error instead of serving them.
+type Failure struct {
+	// Method of the requests to fail, empty matches every method.
+	Method string `json:"method,omitempty"`
+	// PathPrefix of the requests to fail, e.g. /api/v2/settings, empty matches every path.
+	PathPrefix string `json:"pathPrefix,omitempty"`
+	// Message of the error body, defaults to the status text.
+	Message string `json:"message,omitempty"`
+	// StatusCode of the response, e.g. 500 or 429.
+	StatusCode int `json:"statusCode"`
+	// Times the failure is injected, 0 injects it until the failures are cleared.
+	Times int `json:"times,omitempty"`
+}
+
+func (f *Failure) matches(r *http.Request) bool {
+	return (f.Method == "" || strings.EqualFold(f.Method, r.Method)) && strings.HasPrefix(r.URL.Path, f.PathPrefix)
+}
+
+func (f *Failure) message() string {
+	if f.Message != "" {
+		return f.Message
+	}
+
+	return http.StatusText(f.StatusCode)
+}
+
+// InjectFailure adds a failure, failures are matched in the order they were added.
+func (s *Server) InjectFailure(failure Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, &failure)
+}
+
+// ClearFailures removes all injected failures.
+func (s *Server) ClearFailures() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = nil
+}
+
+// nextFailure returns the first failure matching the request and removes it once it was injected often enough.
+// The caller must hold the lock.
+func (s *Server) nextFailure(r *http.Request) *Failure {
+	for i, failure := range s.failures {
+		if !failure.matches(r) {
+			continue
+		}
+
+		if failure.Times > 0 {
+			failure.Times--
+
+			if failure.Times == 0 {
+				s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			}
+		}
+
+		return failure
+	}
+
+	return nil
+}
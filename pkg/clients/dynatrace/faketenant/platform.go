@@ -0,0 +1,255 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package faketenant
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/edgeconnect"
+)
+
+const (
+	edgeConnectsPath        = "/platform/app-engine/edge-connect/v1/edge-connects"
+	environmentSettingsPath = "/platform/classic/environment-api/v2/settings/objects"
+
+	accessTokenLifetimeSeconds = 300
+)
+
+func (s *Server) registerPlatformHandlers() {
+	s.mux.HandleFunc("POST "+OAuthTokenPath, s.issueAccessToken)
+
+	s.mux.HandleFunc("GET "+edgeConnectsPath, s.withAccessToken(s.listEdgeConnects))
+	s.mux.HandleFunc("POST "+edgeConnectsPath, s.withAccessToken(s.createEdgeConnect))
+	s.mux.HandleFunc("GET "+edgeConnectsPath+"/{id}", s.withAccessToken(s.getEdgeConnect))
+	s.mux.HandleFunc("PUT "+edgeConnectsPath+"/{id}", s.withAccessToken(s.updateEdgeConnect))
+	s.mux.HandleFunc("DELETE "+edgeConnectsPath+"/{id}", s.withAccessToken(s.deleteEdgeConnect))
+
+	s.mux.HandleFunc("GET "+environmentSettingsPath, s.withAccessToken(s.listEnvironmentSettings))
+	s.mux.HandleFunc("POST "+environmentSettingsPath, s.withAccessToken(s.createEnvironmentSettings))
+	s.mux.HandleFunc("PUT "+environmentSettingsPath+"/{id}", s.withAccessToken(s.updateEnvironmentSetting))
+	s.mux.HandleFunc("DELETE "+environmentSettingsPath+"/{id}", s.withAccessToken(s.deleteEnvironmentSetting))
+}
+
+// issueAccessToken implements the client credentials grant, the credentials are accepted either as basic auth or in the form.
+func (s *Server) issueAccessToken(w http.ResponseWriter, r *http.Request) {
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = r.PostFormValue("client_id"), r.PostFormValue("client_secret")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if secret, known := s.state.oauthClients[clientID]; !known || secret != clientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+
+		return
+	}
+
+	accessToken := s.state.newID("dt0s16.ACCESSTOKEN")
+	s.state.accessTokens[accessToken] = true
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   accessTokenLifetimeSeconds,
+	})
+}
+
+func (s *Server) listEdgeConnects(w http.ResponseWriter, r *http.Request) {
+	// the operator only filters by name, e.g. name='my-edgeconnect'
+	name := strings.Trim(strings.TrimPrefix(r.URL.Query().Get("filter"), "name="), "'")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	edgeConnects := []edgeconnect.APIResponse{}
+
+	for _, ec := range s.state.edgeConnects {
+		if name == "" || ec.Name == name {
+			edgeConnects = append(edgeConnects, ec)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"edgeConnects": edgeConnects})
+}
+
+func (s *Server) createEdgeConnect(w http.ResponseWriter, r *http.Request) {
+	var request edgeconnect.Request
+
+	if !readJSON(w, r, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if slices.ContainsFunc(s.state.edgeConnects, func(ec edgeconnect.APIResponse) bool { return ec.Name == request.Name }) {
+		writeError(w, http.StatusBadRequest, "EdgeConnect with name "+request.Name+" already exists")
+
+		return
+	}
+
+	ec := edgeconnect.APIResponse{
+		ID:                         s.state.newID("edgeconnect-"),
+		Name:                       request.Name,
+		OauthClientResource:        "urn:dtenvironment:" + s.state.tenantUUID,
+		HostPatterns:               request.HostPatterns,
+		HostMappings:               request.HostMappings,
+		ManagedByDynatraceOperator: request.ManagedByDynatraceOperator,
+	}
+
+	// a new OAuth client is created for the EdgeConnect, it can be used to request access tokens as well
+	ec.OauthClientID = "dt0s10." + ec.ID
+	ec.OauthClientSecret = ec.OauthClientID + ".SECRET"
+	s.state.oauthClients[ec.OauthClientID] = ec.OauthClientSecret
+	s.state.edgeConnects = append(s.state.edgeConnects, ec)
+
+	writeJSON(w, http.StatusOK, ec)
+}
+
+func (s *Server) getEdgeConnect(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index := s.state.edgeConnectIndex(r.PathValue("id"))
+	if index == -1 {
+		writeError(w, http.StatusNotFound, "EdgeConnect not found")
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.state.edgeConnects[index])
+}
+
+func (s *Server) updateEdgeConnect(w http.ResponseWriter, r *http.Request) {
+	var request edgeconnect.Request
+
+	if !readJSON(w, r, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index := s.state.edgeConnectIndex(r.PathValue("id"))
+	if index == -1 {
+		writeError(w, http.StatusNotFound, "EdgeConnect not found")
+
+		return
+	}
+
+	ec := &s.state.edgeConnects[index]
+	ec.Name = request.Name
+	ec.HostPatterns = request.HostPatterns
+	ec.HostMappings = request.HostMappings
+	ec.ManagedByDynatraceOperator = request.ManagedByDynatraceOperator
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) deleteEdgeConnect(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index := s.state.edgeConnectIndex(r.PathValue("id"))
+	if index == -1 {
+		writeError(w, http.StatusNotFound, "EdgeConnect not found")
+
+		return
+	}
+
+	delete(s.state.oauthClients, s.state.edgeConnects[index].OauthClientID)
+	s.state.edgeConnects = slices.Delete(s.state.edgeConnects, index, index+1)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listEnvironmentSettings(w http.ResponseWriter, r *http.Request) {
+	schemaIDs := splitList(r.URL.Query().Get("schemaIds"))
+	scopes := splitList(r.URL.Query().Get("scopes"))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := []edgeconnect.EnvironmentSetting{}
+
+	for _, setting := range s.state.environmentSettings {
+		if (len(schemaIDs) == 0 || slices.Contains(schemaIDs, setting.SchemaID)) && (len(scopes) == 0 || slices.Contains(scopes, setting.Scope)) {
+			items = append(items, setting)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+func (s *Server) createEnvironmentSettings(w http.ResponseWriter, r *http.Request) {
+	var settings []edgeconnect.EnvironmentSetting
+
+	if !readJSON(w, r, &settings) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	response := make([]map[string]string, 0, len(settings))
+
+	for _, setting := range settings {
+		setting.ObjectID = s.state.newID("settings-")
+		s.state.environmentSettings = append(s.state.environmentSettings, setting)
+		response = append(response, map[string]string{"objectId": setting.ObjectID})
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) updateEnvironmentSetting(w http.ResponseWriter, r *http.Request) {
+	var setting edgeconnect.EnvironmentSetting
+
+	if !readJSON(w, r, &setting) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index := s.state.environmentSettingIndex(r.PathValue("id"))
+	if index == -1 {
+		writeError(w, http.StatusNotFound, "Settings object not found")
+
+		return
+	}
+
+	setting.ObjectID = s.state.environmentSettings[index].ObjectID
+	s.state.environmentSettings[index] = setting
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) deleteEnvironmentSetting(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index := s.state.environmentSettingIndex(r.PathValue("id"))
+	if index == -1 {
+		writeError(w, http.StatusNotFound, "Settings object not found")
+
+		return
+	}
+
+	s.state.environmentSettings = slices.Delete(s.state.environmentSettings, index, index+1)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (st *state) edgeConnectIndex(id string) int {
+	return slices.IndexFunc(st.edgeConnects, func(ec edgeconnect.APIResponse) bool { return ec.ID == id })
+}
+
+func (st *state) environmentSettingIndex(id string) int {
+	return slices.IndexFunc(st.environmentSettings, func(setting edgeconnect.EnvironmentSetting) bool { return setting.ObjectID == id })
+}
@@ -0,0 +1,224 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+// Package faketenant implements an in-memory Dynatrace tenant, serving the part of the Dynatrace API used by the operator.
+// It is meant for local development and tests, where no real tenant is available.
+//
+// The classic API is served below [APIPath], so the API URL of a DynaKube is the URL of the server with that suffix.
+// The platform API (EdgeConnect) is served at the root and authenticated via the OAuth endpoint at [OAuthTokenPath].
+// The state of the tenant can be inspected and failures can be injected either via the methods of [Server],
+// or via the control endpoints below [ControlPath] when running as a standalone server.
+package faketenant
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/core"
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+)
+
+const (
+	APIPath        = "/api"
+	OAuthTokenPath = "/sso/oauth2/token"
+	ControlPath    = "/faketenant"
+
+	DefaultTenantUUID        = "faketenant"
+	DefaultTenantToken       = "faketenant-tenant-token"
+	DefaultAPIToken          = "dt0c01.FAKETENANT.APITOKEN"
+	DefaultNoSettingsToken   = "dt0c01.FAKETENANT.NOSETTINGS"
+	DefaultDataIngestToken   = "dt0c01.FAKETENANT.DATAINGEST"
+	DefaultOAuthClientID     = "dt0s02.FAKETENANT"
+	DefaultOAuthClientSecret = "dt0s02.FAKETENANT.SECRET"
+	DefaultAgentVersion      = "1.300.0.20240101-000000"
+	DefaultGatewayVersion    = "1.300.0.20240101-000000"
+
+	apiTokenPrefix = "Api-Token "
+	bearerPrefix   = "Bearer "
+
+	readHeaderTimeout = 10 * time.Second
+	shutdownTimeout   = 5 * time.Second
+)
+
+var log = logd.Get().WithName("faketenant")
+
+// Server is an in-memory Dynatrace tenant, it is safe for concurrent use.
+type Server struct {
+	mux *http.ServeMux
+
+	failures []*Failure
+	requests []Request
+
+	state state
+
+	mu sync.Mutex
+}
+
+// Option configures the initial state of a [Server].
+type Option func(*state)
+
+// New creates a fake tenant with the given options applied on top of the defaults.
+// By default, [DefaultAPIToken] has every scope the operator uses, [DefaultNoSettingsToken] lacks the settings scopes
+// and [DefaultDataIngestToken] only has the ingest scopes.
+func New(options ...Option) *Server {
+	s := &Server{
+		mux:   http.NewServeMux(),
+		state: newState(),
+	}
+
+	for _, opt := range options {
+		opt(&s.state)
+	}
+
+	s.registerAPIHandlers()
+	s.registerSettingsHandlers()
+	s.registerPlatformHandlers()
+	s.registerControlHandlers()
+
+	return s
+}
+
+// ListenAndServe serves the fake tenant on the address until the context is done.
+// TLS is used if a certificate and key are given, which is required for the EdgeConnect API.
+func (s *Server) ListenAndServe(ctx context.Context, address, certFile, keyFile string) error {
+	server := &http.Server{
+		Addr:              address,
+		Handler:           s,
+		ReadHeaderTimeout: readHeaderTimeout,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+		defer cancel()
+
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	var err error
+	if certFile != "" && keyFile != "" {
+		err = server.ListenAndServeTLS(certFile, keyFile)
+	} else {
+		err = server.ListenAndServe()
+	}
+
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+
+	return err
+}
+
+// ServeHTTP records the request, applies injected failures and serves the matching endpoint.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, ControlPath+"/") {
+		s.mux.ServeHTTP(w, r)
+
+		return
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery})
+	failure := s.nextFailure(r)
+	s.mu.Unlock()
+
+	if failure != nil {
+		log.Info("injecting failure", "method", r.Method, "path", r.URL.Path, "status", failure.StatusCode)
+		writeError(w, failure.StatusCode, failure.message())
+
+		return
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+// withAPIToken only serves requests authenticated with a known token that has the required scope, an empty scope allows every known token.
+func (s *Server) withAPIToken(scope string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+
+		token, ok := strings.CutPrefix(header, apiTokenPrefix)
+		if !ok {
+			token, ok = strings.CutPrefix(header, bearerPrefix)
+		}
+
+		s.mu.Lock()
+		scopes, known := s.state.tokens[token]
+		s.mu.Unlock()
+
+		switch {
+		case !ok || !known:
+			writeError(w, http.StatusUnauthorized, "Missing or invalid authorization token")
+		case scope != "" && !slices.Contains(scopes, scope):
+			writeError(w, http.StatusForbidden, "Token is missing required scope: "+scope)
+		default:
+			handler(w, r)
+		}
+	}
+}
+
+// withAccessToken only serves requests authenticated with an access token issued by the OAuth endpoint.
+func (s *Server) withAccessToken(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), bearerPrefix)
+
+		s.mu.Lock()
+		issued := s.state.accessTokens[token]
+		s.mu.Unlock()
+
+		if !ok || !issued {
+			writeError(w, http.StatusUnauthorized, "Missing or invalid access token")
+
+			return
+		}
+
+		handler(w, r)
+	}
+}
+
+// writeError writes the error body in the format the Dynatrace API uses, so the clients can parse it into a [core.ServerError].
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, struct {
+		Error core.ServerError `json:"error"`
+	}{
+		Error: core.ServerError{Code: statusCode, Message: message},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Info("failed to write response", "err", err.Error())
+	}
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, target any) bool {
+	if err := json.NewDecoder(r.Body).Decode(target); err != nil {
+		writeError(w, http.StatusBadRequest, "Could not parse request body: "+err.Error())
+
+		return false
+	}
+
+	return true
+}
+
+// baseURL returns the URL the request was sent to without path, used for the communication endpoints.
+func baseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	return scheme + "://" + r.Host
+}
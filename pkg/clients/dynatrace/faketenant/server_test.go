@@ -0,0 +1,333 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package faketenant
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/metadataenrichment"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/core"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/edgeconnect"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/hostevent"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/image"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/installer"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/oneagent"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+func setupServer(t *testing.T, options ...Option) (*Server, string) {
+	t.Helper()
+
+	server := New(options...)
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	return server, httpServer.URL
+}
+
+func newClient(t *testing.T, url string, options ...dynatrace.Option) *dynatrace.Client {
+	t.Helper()
+
+	client, err := dynatrace.NewClient(append([]dynatrace.Option{dynatrace.WithBaseURL(url + APIPath), dynatrace.WithAPIToken(DefaultAPIToken)}, options...)...)
+	require.NoError(t, err)
+
+	return client
+}
+
+func TestToken(t *testing.T) {
+	_, url := setupServer(t, WithToken("dt0c01.CUSTOM", token.ScopeDataExport))
+	client := newClient(t, url)
+
+	t.Run("scopes of known tokens", func(t *testing.T) {
+		scopes, err := client.Token.GetScopes(t.Context(), "dt0c01.CUSTOM")
+		require.NoError(t, err)
+		assert.Equal(t, []string{token.ScopeDataExport}, scopes)
+
+		scopes, err = client.Token.GetScopes(t.Context(), DefaultNoSettingsToken)
+		require.NoError(t, err)
+		assert.NotContains(t, scopes, token.ScopeSettingsRead)
+		assert.Contains(t, scopes, token.ScopeInstallerDownload)
+	})
+
+	t.Run("unknown token is rejected", func(t *testing.T) {
+		_, err := newClient(t, url, dynatrace.WithAPIToken("dt0c01.UNKNOWN")).Token.GetScopes(t.Context(), "dt0c01.UNKNOWN")
+		require.Error(t, err)
+		assert.True(t, core.HasStatusCode(err, http.StatusUnauthorized))
+	})
+
+	t.Run("missing scope is forbidden", func(t *testing.T) {
+		_, err := newClient(t, url, dynatrace.WithAPIToken(DefaultNoSettingsToken)).Settings.GetK8sClusterME(t.Context(), "kube-system-uuid")
+		require.Error(t, err)
+		assert.True(t, core.IsForbidden(err))
+	})
+}
+
+func TestActiveGate(t *testing.T) {
+	server, url := setupServer(t)
+	client := newClient(t, url)
+
+	authToken, err := client.ActiveGate.GetAuthToken(t.Context(), "dynakube")
+	require.NoError(t, err)
+	assert.NotEmpty(t, authToken.Token)
+
+	agTokens := server.State().ActiveGateTokens
+	require.Len(t, agTokens, 1)
+	assert.Equal(t, "dynakube", agTokens[0].Name)
+	assert.Equal(t, authToken.TokenID, agTokens[0].ID)
+
+	connectionInfo, err := client.ActiveGate.GetConnectionInfo(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, DefaultTenantUUID, connectionInfo.TenantUUID)
+	assert.Equal(t, url+communicationPath, connectionInfo.Endpoints)
+
+	version, err := client.Version.GetLatestActiveGateVersion(t.Context(), installer.OSUnix)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultGatewayVersion, version)
+}
+
+func TestOneAgent(t *testing.T) {
+	_, url := setupServer(t, WithTenantUUID("abc12345"), WithNetworkZone("zone", "https://zone-ag:443/communication"))
+
+	t.Run("connection info", func(t *testing.T) {
+		connectionInfo, err := newClient(t, url).OneAgent.GetConnectionInfo(t.Context(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "abc12345", connectionInfo.TenantUUID)
+		assert.Equal(t, DefaultTenantToken, connectionInfo.TenantToken)
+		assert.Equal(t, url+communicationPath, connectionInfo.Endpoints)
+	})
+
+	t.Run("connection info of network zone", func(t *testing.T) {
+		connectionInfo, err := newClient(t, url, dynatrace.WithNetworkZone("zone")).OneAgent.GetConnectionInfo(t.Context(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "https://zone-ag:443/communication", connectionInfo.Endpoints)
+
+		// unknown zones fall back to the default zone
+		connectionInfo, err = newClient(t, url, dynatrace.WithNetworkZone("unknown")).OneAgent.GetConnectionInfo(t.Context(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, url+communicationPath, connectionInfo.Endpoints)
+	})
+
+	t.Run("process module config", func(t *testing.T) {
+		config, err := newClient(t, url, dynatrace.WithHostGroup("group")).OneAgent.GetProcessModuleConfig(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"tenant":        "abc12345",
+			"tenantToken":   DefaultTenantToken,
+			"serverAddress": "{" + url + communicationPath + "}",
+			"hostGroup":     "group",
+		}, config.ToMap()["general"])
+	})
+
+	t.Run("versions and installer", func(t *testing.T) {
+		client := newClient(t, url)
+
+		version, err := client.Version.GetLatestAgentVersion(t.Context(), installer.OSUnix, installer.TypePaaS)
+		require.NoError(t, err)
+		assert.Equal(t, DefaultAgentVersion, version)
+
+		versions, err := client.OneAgent.GetVersions(t.Context(), oneagent.GetParams{OS: installer.OSUnix, InstallerType: installer.TypePaaS})
+		require.NoError(t, err)
+		assert.Equal(t, []string{DefaultAgentVersion}, versions)
+
+		var buf bytes.Buffer
+
+		require.NoError(t, client.OneAgent.GetLatest(t.Context(), oneagent.GetParams{OS: installer.OSUnix, InstallerType: installer.TypePaaS}, &buf))
+		assert.NotEmpty(t, buf.Bytes())
+	})
+
+	t.Run("process grouping config", func(t *testing.T) {
+		config, err := newClient(t, url).OneAgent.GetProcessGroupingConfig(t.Context(), "kube-system-uuid", "")
+		require.NoError(t, err)
+		assert.Empty(t, config.Data)
+
+		_, pgcURL := setupServer(t, WithProcessGroupingConfig([]byte{0xa0}))
+		client := newClient(t, pgcURL)
+
+		config, err = client.OneAgent.GetProcessGroupingConfig(t.Context(), "kube-system-uuid", "")
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0xa0}, config.Data)
+		require.NotEmpty(t, config.ETag)
+
+		notModified, err := client.OneAgent.GetProcessGroupingConfig(t.Context(), "kube-system-uuid", config.ETag)
+		require.NoError(t, err)
+		assert.Nil(t, notModified.Data)
+	})
+}
+
+func TestSettings(t *testing.T) {
+	server, url := setupServer(t)
+	client := newClient(t, url)
+
+	me, err := client.Settings.GetK8sClusterME(t.Context(), "kube-system-uuid")
+	require.NoError(t, err)
+	assert.Empty(t, me.ID)
+
+	objectID, err := client.Settings.CreateOrUpdateKubernetesSetting(t.Context(), "cluster", "kube-system-uuid", "")
+	require.NoError(t, err)
+
+	me, err = client.Settings.GetK8sClusterME(t.Context(), "kube-system-uuid")
+	require.NoError(t, err)
+	assert.Contains(t, me.ID, "KUBERNETES_CLUSTER-")
+	assert.Equal(t, "cluster", me.Name)
+
+	ruleIDs, err := client.Settings.CreateLegacyEnrichmentRuleObject(t.Context(), me.ID, metadataenrichment.Rule{Type: "LABEL", Source: "team", Target: "dt.cost.costcenter"})
+	require.NoError(t, err)
+	require.Len(t, ruleIDs, 1)
+
+	rules, err := client.Settings.GetRules(t.Context(), "kube-system-uuid", me.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []metadataenrichment.Rule{{Type: "LABEL", Source: "team", Target: "dt.cost.costcenter"}}, rules)
+
+	require.NoError(t, client.Settings.DeleteSettings(t.Context(), ruleIDs[0]))
+	assert.Len(t, server.State().Settings, 1)
+	assert.Equal(t, objectID, server.SettingsObjects("builtin:cloud.kubernetes")[0].ObjectID)
+}
+
+func TestUnavailableSchemas(t *testing.T) {
+	server, url := setupServer(t, WithUnavailableSchemas("builtin:kubernetes.generic.metadata.enrichment", "builtin:ingest.enrichment.config"))
+
+	rules, err := newClient(t, url).Settings.GetRules(t.Context(), "kube-system-uuid", "")
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+	assert.Len(t, server.Requests(effectiveValuesPath), 2)
+}
+
+func TestImages(t *testing.T) {
+	_, url := setupServer(t, WithImage(image.ActiveGate, "registry.example.com/activegate:1.2.3"))
+	client := newClient(t, url)
+
+	info, err := client.Images.GetComponentLatestInfo(t.Context(), image.ActiveGate, "")
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3", info.Tag)
+
+	info, err = client.Images.GetComponentLatestInfo(t.Context(), image.OneAgent, "public.ecr.aws")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultAgentVersion, info.Tag)
+}
+
+func TestHostEvents(t *testing.T) {
+	server, url := setupServer(t, WithHosts(hostevent.HostResponse{EntityID: "HOST-1", IPAddresses: []string{"10.0.0.1"}}))
+	client := newClient(t, url)
+
+	entityID, err := client.HostEvent.GetEntityIDForIP(t.Context(), "10.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, "HOST-1", entityID)
+
+	event := hostevent.Event{EventType: hostevent.MarkedForTerminationEvent, AttachRules: hostevent.EventAttachRules{EntityIDs: []string{entityID}}}
+	require.NoError(t, client.HostEvent.SendEvent(t.Context(), event))
+	assert.Equal(t, []hostevent.Event{event}, server.State().Events)
+}
+
+func TestEdgeConnect(t *testing.T) {
+	server := New()
+	httpServer := httptest.NewTLSServer(server)
+	t.Cleanup(httpServer.Close)
+
+	certs := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: httpServer.Certificate().Raw})
+
+	oauthClient, err := dynatrace.NewOAuthClient(clientcredentials.Config{
+		ClientID:     DefaultOAuthClientID,
+		ClientSecret: DefaultOAuthClientSecret,
+		TokenURL:     httpServer.URL + OAuthTokenPath,
+	}, dynatrace.WithBaseURL(httpServer.URL), dynatrace.WithCerts(certs))
+	require.NoError(t, err)
+
+	client := oauthClient.EdgeConnect
+
+	created, err := client.CreateEdgeConnect(t.Context(), edgeconnect.NewCreateRequest("ec", []string{"*.internal"}, nil))
+	require.NoError(t, err)
+	assert.NotEmpty(t, created.OauthClientSecret)
+
+	require.NoError(t, client.UpdateEdgeConnect(t.Context(), created.ID, edgeconnect.NewUpdateRequest("ec", []string{"*.example.com"}, nil, created.OauthClientID)))
+
+	listed, err := client.ListEdgeConnects(t.Context(), "ec")
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, []string{"*.example.com"}, listed[0].HostPatterns)
+
+	setting := edgeconnect.EnvironmentSetting{SchemaID: edgeconnect.KubernetesConnectionSchemaID, Scope: edgeconnect.KubernetesConnectionScope}
+	require.NoError(t, client.CreateEnvironmentSetting(t.Context(), setting))
+
+	settings, err := client.ListEnvironmentSettings(t.Context())
+	require.NoError(t, err)
+	require.Len(t, settings, 1)
+	require.NoError(t, client.DeleteEnvironmentSetting(t.Context(), settings[0].ObjectID))
+
+	require.NoError(t, client.DeleteEdgeConnect(t.Context(), created.ID))
+	assert.Empty(t, server.State().EdgeConnects)
+
+	_, err = client.GetEdgeConnect(t.Context(), created.ID)
+	require.Error(t, err)
+	assert.True(t, core.IsNotFound(err))
+}
+
+func TestFailures(t *testing.T) {
+	t.Run("injected failure is returned until cleared", func(t *testing.T) {
+		server, url := setupServer(t)
+		server.InjectFailure(Failure{Method: http.MethodPost, PathPrefix: APIPath + "/v2/activeGateTokens", StatusCode: http.StatusForbidden, Message: "no quota"})
+
+		client := newClient(t, url)
+
+		for range 2 {
+			_, err := client.ActiveGate.GetAuthToken(t.Context(), "dynakube")
+			require.Error(t, err)
+			assert.True(t, core.IsForbidden(err))
+			assert.Contains(t, err.Error(), "no quota")
+		}
+
+		server.ClearFailures()
+
+		_, err := client.ActiveGate.GetAuthToken(t.Context(), "dynakube")
+		require.NoError(t, err)
+	})
+
+	t.Run("failure is injected the given number of times", func(t *testing.T) {
+		server, url := setupServer(t)
+		server.InjectFailure(Failure{PathPrefix: gatewayInstallerPath, StatusCode: http.StatusServiceUnavailable, Times: 1})
+
+		// the client retries reads from an unavailable tenant
+		_, err := newClient(t, url).Version.GetLatestActiveGateVersion(t.Context(), installer.OSUnix)
+		require.NoError(t, err)
+		assert.Len(t, server.Requests(gatewayInstallerPath), 2)
+	})
+
+	t.Run("control endpoints", func(t *testing.T) {
+		_, url := setupServer(t)
+
+		body, err := json.Marshal(Failure{PathPrefix: APIPath, StatusCode: http.StatusInternalServerError})
+		require.NoError(t, err)
+
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, url+ControlPath+"/failures", bytes.NewReader(body))
+		require.NoError(t, err)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		_, err = newClient(t, url).Version.GetLatestActiveGateVersion(t.Context(), installer.OSUnix)
+		require.Error(t, err)
+
+		req, err = http.NewRequestWithContext(t.Context(), http.MethodGet, url+ControlPath+"/state", nil)
+		require.NoError(t, err)
+
+		resp, err = http.DefaultClient.Do(req)
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		var state State
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&state))
+		assert.NotEmpty(t, state.Requests)
+	})
+}
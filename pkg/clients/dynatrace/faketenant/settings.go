@@ -0,0 +1,202 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package faketenant
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/settings"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/token"
+)
+
+const (
+	settingsObjectsPath  = APIPath + settings.ObjectsPath
+	effectiveValuesPath  = APIPath + "/v2/settings/effectiveValues"
+	kubernetesScopeIDLen = 16
+)
+
+func (s *Server) registerSettingsHandlers() {
+	s.mux.HandleFunc("GET "+settingsObjectsPath, s.withAPIToken(token.ScopeSettingsRead, s.listSettingsObjects))
+	s.mux.HandleFunc("POST "+settingsObjectsPath, s.withAPIToken(token.ScopeSettingsWrite, s.createSettingsObjects))
+	s.mux.HandleFunc("DELETE "+settingsObjectsPath+"/{objectID}", s.withAPIToken(token.ScopeSettingsWrite, s.deleteSettingsObject))
+	s.mux.HandleFunc("GET "+effectiveValuesPath, s.withAPIToken(token.ScopeSettingsRead, s.getEffectiveValues))
+}
+
+func (s *Server) listSettingsObjects(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	schemaIDs := splitList(query.Get("schemaIds"))
+	scopes := splitList(query.Get("scopes"))
+
+	filterKey, filterValue, err := parseFilter(query.Get("filter"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if schemaID := s.state.firstUnavailable(schemaIDs); schemaID != "" {
+		writeError(w, http.StatusNotFound, "Schema not found: "+schemaID)
+
+		return
+	}
+
+	items := []SettingsObject{}
+
+	for _, obj := range s.state.settings {
+		if len(schemaIDs) > 0 && !slices.Contains(schemaIDs, obj.SchemaID) ||
+			len(scopes) > 0 && !slices.Contains(scopes, obj.Scope) ||
+			filterKey != "" && !valueEquals(obj.Value, filterKey, filterValue) {
+			continue
+		}
+
+		items = append(items, obj)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"items": items, "totalCount": len(items)})
+}
+
+func (s *Server) createSettingsObjects(w http.ResponseWriter, r *http.Request) {
+	var objects []SettingsObject
+
+	if !readJSON(w, r, &objects) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, obj := range objects {
+		if s.state.unavailableSchemas[obj.SchemaID] {
+			writeError(w, http.StatusNotFound, "Schema not found: "+obj.SchemaID)
+
+			return
+		}
+	}
+
+	validateOnly := r.URL.Query().Get("validateOnly") == "true"
+	response := make([]map[string]string, 0, len(objects))
+
+	for _, obj := range objects {
+		obj.ObjectID = s.state.newID("settings-")
+
+		// the tenant creates the monitored entity of a new kubernetes cluster, which becomes the scope of its settings
+		if obj.SchemaID == settings.KubernetesSettingsSchemaID && obj.Scope == "" {
+			obj.Scope = kubernetesClusterScope(obj.Value)
+		}
+
+		if !validateOnly {
+			s.state.settings = append(s.state.settings, obj)
+		}
+
+		response = append(response, map[string]string{"objectId": obj.ObjectID})
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) deleteSettingsObject(w http.ResponseWriter, r *http.Request) {
+	objectID := r.PathValue("objectID")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index := slices.IndexFunc(s.state.settings, func(obj SettingsObject) bool { return obj.ObjectID == objectID })
+	if index == -1 {
+		writeError(w, http.StatusNotFound, "Settings object not found: "+objectID)
+
+		return
+	}
+
+	s.state.settings = slices.Delete(s.state.settings, index, index+1)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getEffectiveValues returns the values of the objects of the schema in the scope, the environment scope includes every object.
+func (s *Server) getEffectiveValues(w http.ResponseWriter, r *http.Request) {
+	schemaIDs := splitList(r.URL.Query().Get("schemaIds"))
+	scope := r.URL.Query().Get("scope")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if schemaID := s.state.firstUnavailable(schemaIDs); schemaID != "" {
+		writeError(w, http.StatusNotFound, "Schema not found: "+schemaID)
+
+		return
+	}
+
+	items := []map[string]json.RawMessage{}
+
+	for _, obj := range s.state.settings {
+		if slices.Contains(schemaIDs, obj.SchemaID) && (scope == "" || scope == "environment" || obj.Scope == scope) {
+			items = append(items, map[string]json.RawMessage{"value": obj.Value})
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"items": items, "totalCount": len(items)})
+}
+
+func (st *state) firstUnavailable(schemaIDs []string) string {
+	for _, schemaID := range schemaIDs {
+		if st.unavailableSchemas[schemaID] {
+			return schemaID
+		}
+	}
+
+	return ""
+}
+
+// kubernetesClusterScope returns a stable monitored entity ID for the cluster of a kubernetes settings object.
+func kubernetesClusterScope(value json.RawMessage) string {
+	var cluster struct {
+		ClusterID string `json:"clusterId"`
+	}
+
+	_ = json.Unmarshal(value, &cluster)
+
+	hash := sha256.Sum256([]byte(cluster.ClusterID))
+
+	return "KUBERNETES_CLUSTER-" + strings.ToUpper(fmt.Sprintf("%x", hash[:kubernetesScopeIDLen/2]))
+}
+
+// parseFilter parses the only filter used by the operator, value.<key>='<value>'.
+func parseFilter(filter string) (string, string, error) {
+	if filter == "" {
+		return "", "", nil
+	}
+
+	key, value, ok := strings.Cut(filter, "=")
+	if !ok || !strings.HasPrefix(key, "value.") || len(value) < 2 || !strings.HasPrefix(value, "'") || !strings.HasSuffix(value, "'") {
+		return "", "", fmt.Errorf("unsupported filter: %s", filter)
+	}
+
+	return strings.TrimPrefix(key, "value."), strings.Trim(value, "'"), nil
+}
+
+func valueEquals(value json.RawMessage, key, expected string) bool {
+	var fields map[string]any
+
+	if err := json.Unmarshal(value, &fields); err != nil {
+		return false
+	}
+
+	return fmt.Sprint(fields[key]) == expected
+}
+
+func splitList(list string) []string {
+	if list == "" {
+		return nil
+	}
+
+	return strings.Split(list, ",")
+}
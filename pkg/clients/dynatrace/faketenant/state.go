@@ -0,0 +1,283 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package faketenant
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/edgeconnect"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/hostevent"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/image"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/token"
+)
+
+const defaultImageRegistry = "public.ecr.aws/dynatrace/dynatrace-"
+
+// AllScopes are the scopes of [DefaultAPIToken], every scope the operator uses.
+var AllScopes = []string{
+	token.ScopeActiveGateTokenCreate,
+	token.ScopeDataExport,
+	token.ScopeInstallerDownload,
+	token.ScopeLogsIngest,
+	token.ScopeMetricsIngest,
+	token.ScopeOpenTelemetryTraceIngest,
+	token.ScopeSettingsRead,
+	token.ScopeSettingsWrite,
+}
+
+// SettingsObject is a settings object stored in the fake tenant.
+type SettingsObject struct {
+	ObjectID      string          `json:"objectId"`
+	SchemaID      string          `json:"schemaId"`
+	SchemaVersion string          `json:"schemaVersion,omitempty"`
+	Scope         string          `json:"scope"`
+	Value         json.RawMessage `json:"value"`
+}
+
+// ActiveGateToken is an ActiveGate auth token created by the fake tenant.
+type ActiveGateToken struct {
+	ID             string `json:"id"`
+	Token          string `json:"token"`
+	Name           string `json:"name"`
+	ActiveGateType string `json:"activeGateType"`
+	ExpirationDate string `json:"expirationDate"`
+	SeedToken      bool   `json:"seedToken"`
+}
+
+// Request is a request received by the fake tenant.
+type Request struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+}
+
+// State is a snapshot of everything the operator created in or sent to the fake tenant.
+type State struct {
+	Settings            []SettingsObject                 `json:"settings"`
+	EnvironmentSettings []edgeconnect.EnvironmentSetting `json:"environmentSettings"`
+	EdgeConnects        []edgeconnect.APIResponse        `json:"edgeConnects"`
+	ActiveGateTokens    []ActiveGateToken                `json:"activeGateTokens"`
+	Events              []hostevent.Event                `json:"events"`
+	Hosts               []hostevent.HostResponse         `json:"hosts"`
+	Requests            []Request                        `json:"requests"`
+}
+
+type state struct {
+	tokens       map[string][]string
+	oauthClients map[string]string
+	accessTokens map[string]bool
+
+	networkZones       map[string][]string
+	images             map[image.ComponentType]string
+	unavailableSchemas map[string]bool
+
+	tenantUUID            string
+	tenantToken           string
+	agentVersion          string
+	gatewayVersion        string
+	endpoints             []string
+	processGroupingConfig []byte
+
+	settings            []SettingsObject
+	environmentSettings []edgeconnect.EnvironmentSetting
+	edgeConnects        []edgeconnect.APIResponse
+	activeGateTokens    []ActiveGateToken
+	events              []hostevent.Event
+	hosts               []hostevent.HostResponse
+
+	lastID int
+}
+
+func newState() state {
+	images := map[image.ComponentType]string{}
+	for _, component := range []image.ComponentType{image.OneAgent, image.CodeModules, image.ActiveGate} {
+		images[component] = defaultImageRegistry + string(component) + ":" + DefaultAgentVersion
+	}
+
+	for _, component := range []image.ComponentType{image.EEC, image.LogModule, image.DBExecutor} {
+		images[component] = defaultImageRegistry + string(component) + ":1.0.0"
+	}
+
+	return state{
+		tokens: map[string][]string{
+			DefaultAPIToken: slices.Clone(AllScopes),
+			DefaultNoSettingsToken: slices.DeleteFunc(slices.Clone(AllScopes), func(scope string) bool {
+				return slices.Contains(token.OptionalScopes, scope)
+			}),
+			DefaultDataIngestToken: {token.ScopeMetricsIngest, token.ScopeLogsIngest, token.ScopeOpenTelemetryTraceIngest},
+		},
+		oauthClients:       map[string]string{DefaultOAuthClientID: DefaultOAuthClientSecret},
+		accessTokens:       map[string]bool{},
+		networkZones:       map[string][]string{},
+		images:             images,
+		unavailableSchemas: map[string]bool{},
+		tenantUUID:         DefaultTenantUUID,
+		tenantToken:        DefaultTenantToken,
+		agentVersion:       DefaultAgentVersion,
+		gatewayVersion:     DefaultGatewayVersion,
+	}
+}
+
+// newID returns a unique ID with the given prefix, IDs are predictable to ease debugging.
+func (st *state) newID(prefix string) string {
+	st.lastID++
+
+	return fmt.Sprintf("%s%06d", prefix, st.lastID)
+}
+
+// WithTenantUUID sets the tenant UUID returned in the connection info.
+func WithTenantUUID(tenantUUID string) Option {
+	return func(st *state) {
+		st.tenantUUID = tenantUUID
+	}
+}
+
+// WithTenantToken sets the tenant token returned in the connection info.
+func WithTenantToken(tenantToken string) Option {
+	return func(st *state) {
+		st.tenantToken = tenantToken
+	}
+}
+
+// WithToken adds a token with the given scopes, or replaces the scopes of an existing one.
+func WithToken(tok string, scopes ...string) Option {
+	return func(st *state) {
+		st.tokens[tok] = scopes
+	}
+}
+
+// WithOAuthClient adds an OAuth client that can request access tokens for the platform API.
+func WithOAuthClient(clientID, clientSecret string) Option {
+	return func(st *state) {
+		st.oauthClients[clientID] = clientSecret
+	}
+}
+
+// WithCommunicationEndpoints sets the communication endpoints of the default network zone.
+// By default, a single endpoint on the address of the fake tenant is returned.
+func WithCommunicationEndpoints(endpoints ...string) Option {
+	return func(st *state) {
+		st.endpoints = endpoints
+	}
+}
+
+// WithNetworkZone adds a network zone with its communication endpoints.
+// Requests for unknown zones fail, unless they ask for the fallback to the default zone like the operator does.
+func WithNetworkZone(zone string, endpoints ...string) Option {
+	return func(st *state) {
+		st.networkZones[zone] = endpoints
+	}
+}
+
+// WithImage sets the image URI returned for a component.
+func WithImage(component image.ComponentType, uri string) Option {
+	return func(st *state) {
+		st.images[component] = uri
+	}
+}
+
+// WithVersions sets the latest OneAgent and ActiveGate versions.
+func WithVersions(agentVersion, gatewayVersion string) Option {
+	return func(st *state) {
+		st.agentVersion = agentVersion
+		st.gatewayVersion = gatewayVersion
+	}
+}
+
+// WithProcessGroupingConfig makes the process grouping config available, it isn't by default.
+func WithProcessGroupingConfig(data []byte) Option {
+	return func(st *state) {
+		st.processGroupingConfig = data
+	}
+}
+
+// WithUnavailableSchemas makes the settings schemas unknown to the tenant, like on older tenants.
+func WithUnavailableSchemas(schemaIDs ...string) Option {
+	return func(st *state) {
+		for _, schemaID := range schemaIDs {
+			st.unavailableSchemas[schemaID] = true
+		}
+	}
+}
+
+// WithHosts adds host entities used to resolve the entity ID of nodes.
+func WithHosts(hosts ...hostevent.HostResponse) Option {
+	return func(st *state) {
+		st.hosts = append(st.hosts, hosts...)
+	}
+}
+
+// WithSettingsObjects adds settings objects, objects without ID get one assigned.
+func WithSettingsObjects(objects ...SettingsObject) Option {
+	return func(st *state) {
+		for _, obj := range objects {
+			if obj.ObjectID == "" {
+				obj.ObjectID = st.newID("settings-")
+			}
+
+			st.settings = append(st.settings, obj)
+		}
+	}
+}
+
+// State returns a snapshot of the current state of the tenant.
+func (s *Server) State() State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return State{
+		Settings:            slices.Clone(s.state.settings),
+		EnvironmentSettings: slices.Clone(s.state.environmentSettings),
+		EdgeConnects:        slices.Clone(s.state.edgeConnects),
+		ActiveGateTokens:    slices.Clone(s.state.activeGateTokens),
+		Events:              slices.Clone(s.state.events),
+		Hosts:               slices.Clone(s.state.hosts),
+		Requests:            slices.Clone(s.requests),
+	}
+}
+
+// SettingsObjects returns the settings objects of the given schema.
+func (s *Server) SettingsObjects(schemaID string) []SettingsObject {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var objects []SettingsObject
+
+	for _, obj := range s.state.settings {
+		if obj.SchemaID == schemaID {
+			objects = append(objects, obj)
+		}
+	}
+
+	return objects
+}
+
+// Requests returns the requests received for the given path prefix, an empty prefix returns all.
+func (s *Server) Requests(pathPrefix string) []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var requests []Request
+
+	for _, req := range s.requests {
+		if strings.HasPrefix(req.Path, pathPrefix) {
+			requests = append(requests, req)
+		}
+	}
+
+	return requests
+}
+
+// Update changes the configuration of the running tenant with the given options, e.g. to roll out a new version.
+func (s *Server) Update(options ...Option) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, opt := range options {
+		opt(&s.state)
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 type Client interface {
 	GetConnectionInfo(ctx context.Context, requiredIPs []string) (ConnectionInfo, error)
+	GetNetworkZoneConnectionInfo(ctx context.Context, networkZone string, requiredIPs []string) (ConnectionInfo, error)
 
 	Get(ctx context.Context, args GetParams, writer io.Writer) error
 	GetLatest(ctx context.Context, args GetParams, writer io.Writer) error
@@ -105,6 +105,11 @@ type ConnectionInfo struct {
 }
 
 func (c *ClientImpl) GetConnectionInfo(ctx context.Context, requiredHosts []string) (ConnectionInfo, error) {
+	return c.GetNetworkZoneConnectionInfo(ctx, c.networkZone, requiredHosts)
+}
+
+// GetNetworkZoneConnectionInfo returns the connection info of the given network zone instead of the one configured for the client.
+func (c *ClientImpl) GetNetworkZoneConnectionInfo(ctx context.Context, networkZone string, requiredHosts []string) (ConnectionInfo, error) {
 	ctx, log := logd.NewFromContext(ctx, loggerName)
 
 	resp := connectionInfoResponse{
@@ -112,8 +117,8 @@ func (c *ClientImpl) GetConnectionInfo(ctx context.Context, requiredHosts []stri
 	}
 
 	params := map[string]string{}
-	if c.networkZone != "" {
-		params["networkZone"] = c.networkZone
+	if networkZone != "" {
+		params["networkZone"] = networkZone
 		params["defaultZoneFallback"] = "true"
 	}
 
@@ -4,6 +4,7 @@
 package oneagent
 
 import (
+	"cmp"
 	"context"
 	"slices"
 	"strings"
@@ -16,8 +17,25 @@ const (
 	generalSectionName      = "general"
 	hostGroupParamName      = "hostgroup"
 	processModuleConfigPath = "/v1/deployment/installer/agent/processmoduleconfig"
+
+	tenantPropertyKey        = "tenant"
+	tenantTokenPropertyKey   = "tenantToken"
+	serverAddressPropertyKey = "serverAddress"
+	hostGroupPropertyKey     = "hostGroup"
+	proxyPropertyKey         = "proxy"
+	noProxyPropertyKey       = "noProxy"
 )
 
+// managedPropertyKeys are the properties that are set by the operator and must not be overridden.
+var managedPropertyKeys = []string{
+	tenantPropertyKey,
+	tenantTokenPropertyKey,
+	serverAddressPropertyKey,
+	hostGroupPropertyKey,
+	proxyPropertyKey,
+	noProxyPropertyKey,
+}
+
 type ProcessModuleConfig struct {
 	Properties []ProcessModuleProperty `json:"properties"`
 	Revision   uint                    `json:"revision"`
@@ -33,11 +51,12 @@ type ProcessModuleProperty struct {
 // each section consists of key value pairs.
 type ConfMap map[string]map[string]string
 
+// Add adds, updates or, if the value is empty, removes the property with the same section and key.
 func (pmc *ProcessModuleConfig) Add(newProperty ProcessModuleProperty) *ProcessModuleConfig {
 	pmc.fixBrokenCache()
 
 	for index, cachedProperty := range pmc.Properties {
-		if cachedProperty.Key == newProperty.Key {
+		if cachedProperty.Section == newProperty.Section && cachedProperty.Key == newProperty.Key {
 			if newProperty.Value == "" {
 				pmc.removeProperty(index)
 			} else {
@@ -59,14 +78,19 @@ func (pmc *ProcessModuleConfig) Add(newProperty ProcessModuleProperty) *ProcessM
 // Older operator versions handled the cache wrong and multiplied properties on an update
 // instead of updating it.
 // The fixed algorithm in Add cannot handle this broken cache without this function
-// It adds every property first to a map using the property's key, to make them distinct
+// It adds every property first to a map using the property's section and key, to make them distinct
 // Then collects the now distinct properties and updates the cache
 func (pmc *ProcessModuleConfig) fixBrokenCache() {
+	type sectionKey struct {
+		section string
+		key     string
+	}
+
 	properties := make([]ProcessModuleProperty, 0, len(pmc.Properties))
-	propertyMap := make(map[string]ProcessModuleProperty)
+	propertyMap := make(map[sectionKey]ProcessModuleProperty)
 
 	for _, property := range pmc.Properties {
-		propertyMap[property.Key] = property
+		propertyMap[sectionKey{section: property.Section, key: property.Key}] = property
 	}
 
 	for _, value := range propertyMap {
@@ -81,7 +105,6 @@ func (pmc *ProcessModuleConfig) addProperty(newProperty ProcessModuleProperty) {
 }
 
 func (pmc *ProcessModuleConfig) updateProperty(index int, newProperty ProcessModuleProperty) {
-	pmc.Properties[index].Section = newProperty.Section
 	pmc.Properties[index].Value = newProperty.Value
 }
 
@@ -92,21 +115,21 @@ func (pmc *ProcessModuleConfig) removeProperty(index int) {
 func (pmc *ProcessModuleConfig) AddConnectionInfo(oneAgentConnectionInfo communication.ConnectionInfo, tenantToken string) *ProcessModuleConfig {
 	tenant := ProcessModuleProperty{
 		Section: generalSectionName,
-		Key:     "tenant",
+		Key:     tenantPropertyKey,
 		Value:   oneAgentConnectionInfo.TenantUUID,
 	}
 	pmc.Add(tenant)
 
 	token := ProcessModuleProperty{
 		Section: generalSectionName,
-		Key:     "tenantToken",
+		Key:     tenantTokenPropertyKey,
 		Value:   tenantToken,
 	}
 	pmc.Add(token)
 
 	endpoints := ProcessModuleProperty{
 		Section: generalSectionName,
-		Key:     "serverAddress",
+		Key:     serverAddressPropertyKey,
 		Value:   "{" + oneAgentConnectionInfo.Endpoints + "}",
 	}
 	pmc.Add(endpoints)
@@ -115,23 +138,29 @@ func (pmc *ProcessModuleConfig) AddConnectionInfo(oneAgentConnectionInfo communi
 }
 
 func (pmc *ProcessModuleConfig) AddHostGroup(hostGroup string) *ProcessModuleConfig {
-	property := ProcessModuleProperty{Section: generalSectionName, Key: "hostGroup", Value: hostGroup}
+	property := ProcessModuleProperty{Section: generalSectionName, Key: hostGroupPropertyKey, Value: hostGroup}
 
 	return pmc.Add(property)
 }
 
 func (pmc *ProcessModuleConfig) AddProxy(proxy string) *ProcessModuleConfig {
-	property := ProcessModuleProperty{Section: generalSectionName, Key: "proxy", Value: proxy}
+	property := ProcessModuleProperty{Section: generalSectionName, Key: proxyPropertyKey, Value: proxy}
 
 	return pmc.Add(property)
 }
 
 func (pmc *ProcessModuleConfig) AddNoProxy(noProxy string) *ProcessModuleConfig {
-	property := ProcessModuleProperty{Section: generalSectionName, Key: "noProxy", Value: noProxy}
+	property := ProcessModuleProperty{Section: generalSectionName, Key: noProxyPropertyKey, Value: noProxy}
 
 	return pmc.Add(property)
 }
 
+// IsManagedProperty reports whether the property is set by the operator, like the connection info, host group or proxy.
+// The operator only manages properties of the general section.
+func IsManagedProperty(section, key string) bool {
+	return section == generalSectionName && slices.Contains(managedPropertyKeys, key)
+}
+
 func (pmc ProcessModuleConfig) ToMap() ConfMap {
 	sections := map[string]map[string]string{}
 	for _, prop := range pmc.Properties {
@@ -149,7 +178,7 @@ func (pmc ProcessModuleConfig) ToMap() ConfMap {
 
 func (pmc *ProcessModuleConfig) SortPropertiesByKey() {
 	slices.SortFunc(pmc.Properties, func(a, b ProcessModuleProperty) int {
-		return strings.Compare(a.Key, b.Key)
+		return cmp.Or(strings.Compare(a.Key, b.Key), strings.Compare(a.Section, b.Section))
 	})
 }
 
@@ -266,6 +266,21 @@ func TestAdd(t *testing.T) {
 			Value:   "new-value",
 		})
 	})
+	t.Run("distinguishes properties by section", func(t *testing.T) {
+		processModuleConfig := &ProcessModuleConfig{}
+
+		processModuleConfig.Add(ProcessModuleProperty{Section: "general", Key: testKey, Value: testValue})
+		processModuleConfig.Add(ProcessModuleProperty{Section: "agentType", Key: testKey, Value: "other-value"})
+
+		assert.ElementsMatch(t, []ProcessModuleProperty{
+			{Section: "general", Key: testKey, Value: testValue},
+			{Section: "agentType", Key: testKey, Value: "other-value"},
+		}, processModuleConfig.Properties)
+
+		processModuleConfig.Add(ProcessModuleProperty{Section: "agentType", Key: testKey, Value: ""})
+
+		assert.Equal(t, []ProcessModuleProperty{{Section: "general", Key: testKey, Value: testValue}}, processModuleConfig.Properties)
+	})
 	t.Run("fixes broken cache", func(t *testing.T) {
 		processModuleConfig := &ProcessModuleConfig{}
 
@@ -485,3 +500,12 @@ func TestProcessModuleConfig_SortPropertiesByKey(t *testing.T) {
 
 	assert.Equal(t, expectedByteds, actualBytes)
 }
+
+func TestIsManagedProperty(t *testing.T) {
+	for _, key := range []string{"tenant", "tenantToken", "serverAddress", "hostGroup", "proxy", "noProxy"} {
+		assert.True(t, IsManagedProperty("general", key), key)
+		assert.False(t, IsManagedProperty("agentType", key), key)
+	}
+
+	assert.False(t, IsManagedProperty("general", "logLevel"))
+}
@@ -74,6 +74,10 @@ type Client interface {
 	CreateEnrichmentRuleObject(ctx context.Context, scope string, rules ...metadataenrichment.Rule) ([]string, error)
 	// CreateLegacyEnrichmentRuleObject creates a settings object for the builtin:kubernetes.generic.metadata.enrichment schema.
 	CreateLegacyEnrichmentRuleObject(ctx context.Context, scope string, rules ...metadataenrichment.Rule) ([]string, error)
+	// GetSyntheticLocations returns the private Synthetic locations with the given name.
+	GetSyntheticLocations(ctx context.Context, name string) (SyntheticLocationsResponse, error)
+	// CreateSyntheticLocation returns the object ID of the created private Synthetic location.
+	CreateSyntheticLocation(ctx context.Context, location SyntheticLocationValue) (string, error)
 	// DeleteSettings deletes the settings for a monitored entity.
 	DeleteSettings(ctx context.Context, settingsID string) error
 }
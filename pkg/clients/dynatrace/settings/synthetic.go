@@ -0,0 +1,83 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package settings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+const (
+	syntheticLocationSchemaID      = "builtin:synthetic.private-location"
+	syntheticLocationSchemaVersion = "1"
+
+	SyntheticLocationDeploymentTypeKubernetes = "KUBERNETES"
+)
+
+type SyntheticLocationsResponse struct {
+	TotalCount int                     `json:"totalCount"`
+	Items      []SyntheticLocationItem `json:"items"`
+}
+
+type SyntheticLocationItem struct {
+	ObjectID string                 `json:"objectId"`
+	Value    SyntheticLocationValue `json:"value"`
+}
+
+type SyntheticLocationValue struct {
+	Name            string `json:"name"`
+	DeploymentType  string `json:"deploymentType"`
+	ActiveGateGroup string `json:"activeGateGroup,omitempty"`
+}
+
+// GetSyntheticLocations returns the private Synthetic locations with the given name.
+func (c *ClientImpl) GetSyntheticLocations(ctx context.Context, name string) (SyntheticLocationsResponse, error) {
+	if name == "" {
+		return SyntheticLocationsResponse{}, nil
+	}
+
+	var resp SyntheticLocationsResponse
+
+	err := c.apiClient.GET(ctx, ObjectsPath).
+		WithQueryParams(map[string]string{
+			schemaIDsQueryParam: syntheticLocationSchemaID,
+			scopesQueryParam:    globalScope,
+			filterQueryParam:    fmt.Sprintf("value.name='%s'", name),
+		}).
+		Execute(&resp)
+	if err != nil {
+		return SyntheticLocationsResponse{}, fmt.Errorf("get synthetic locations: %w", err)
+	}
+
+	return resp, nil
+}
+
+// CreateSyntheticLocation returns the object ID of the created private Synthetic location.
+func (c *ClientImpl) CreateSyntheticLocation(ctx context.Context, location SyntheticLocationValue) (string, error) {
+	if location.Name == "" {
+		return "", errors.New("no name was provided for creating the private Synthetic location")
+	}
+
+	body := newPostObjectsBody(
+		syntheticLocationSchemaID,
+		syntheticLocationSchemaVersion,
+		globalScope,
+		location,
+	)
+
+	var response []postObjectsResponse
+
+	err := c.apiClient.POST(ctx, ObjectsPath).
+		WithQueryParams(map[string]string{
+			validateOnlyQueryParam: "false",
+		}).
+		WithJSONBody(body).
+		Execute(&response)
+	if err != nil {
+		return "", fmt.Errorf("create synthetic location: %w", err)
+	}
+
+	return getObjectID(response)
+}
@@ -0,0 +1,103 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package settings
+
+import (
+	"errors"
+	"testing"
+
+	coremock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/clients/dynatrace/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSyntheticLocations(t *testing.T) {
+	ctx := t.Context()
+
+	params := map[string]string{
+		schemaIDsQueryParam: syntheticLocationSchemaID,
+		scopesQueryParam:    globalScope,
+		filterQueryParam:    "value.name='in-cluster'",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		expected := SyntheticLocationsResponse{
+			TotalCount: 1,
+			Items: []SyntheticLocationItem{
+				{ObjectID: "obj-1", Value: SyntheticLocationValue{Name: "in-cluster", DeploymentType: SyntheticLocationDeploymentTypeKubernetes}},
+			},
+		}
+
+		apiClient := coremock.NewClient(t)
+		request := coremock.NewRequest(t)
+		request.EXPECT().WithQueryParams(params).Return(request).Once()
+		request.EXPECT().Execute(new(SyntheticLocationsResponse)).Run(injectResponse(expected)).Return(nil).Once()
+		apiClient.EXPECT().GET(ctx, ObjectsPath).Return(request).Once()
+
+		client := NewClient(apiClient)
+		resp, err := client.GetSyntheticLocations(ctx, "in-cluster")
+		require.NoError(t, err)
+		assert.Equal(t, expected, resp)
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		client := NewClient(coremock.NewClient(t))
+		resp, err := client.GetSyntheticLocations(ctx, "")
+		require.NoError(t, err)
+		assert.Empty(t, resp.Items)
+	})
+
+	t.Run("error from API", func(t *testing.T) {
+		apiClient := coremock.NewClient(t)
+		request := coremock.NewRequest(t)
+		request.EXPECT().WithQueryParams(params).Return(request).Once()
+		request.EXPECT().Execute(new(SyntheticLocationsResponse)).Return(errors.New("api error")).Once()
+		apiClient.EXPECT().GET(ctx, ObjectsPath).Return(request).Once()
+
+		client := NewClient(apiClient)
+		_, err := client.GetSyntheticLocations(ctx, "in-cluster")
+		require.Error(t, err)
+	})
+}
+
+func TestCreateSyntheticLocation(t *testing.T) {
+	ctx := t.Context()
+
+	location := SyntheticLocationValue{Name: "in-cluster", DeploymentType: SyntheticLocationDeploymentTypeKubernetes, ActiveGateGroup: "synthetic"}
+
+	t.Run("no name", func(t *testing.T) {
+		client := NewClient(coremock.NewClient(t))
+		objectID, err := client.CreateSyntheticLocation(ctx, SyntheticLocationValue{})
+		require.Error(t, err)
+		assert.Empty(t, objectID)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		apiClient := coremock.NewClient(t)
+		request := coremock.NewRequest(t)
+		request.EXPECT().WithQueryParams(map[string]string{"validateOnly": "false"}).Return(request).Once()
+		request.EXPECT().WithJSONBody(matchJSONBody[SyntheticLocationValue](syntheticLocationSchemaID, syntheticLocationSchemaVersion)).Return(request).Once()
+		request.EXPECT().Execute(new([]postObjectsResponse)).Run(injectResponse([]postObjectsResponse{{ObjectID: "obj-123"}})).Return(nil).Once()
+		apiClient.EXPECT().POST(ctx, ObjectsPath).Return(request).Once()
+
+		client := NewClient(apiClient)
+		objectID, err := client.CreateSyntheticLocation(ctx, location)
+		require.NoError(t, err)
+		assert.Equal(t, "obj-123", objectID)
+	})
+
+	t.Run("error from API", func(t *testing.T) {
+		apiClient := coremock.NewClient(t)
+		request := coremock.NewRequest(t)
+		request.EXPECT().WithQueryParams(map[string]string{"validateOnly": "false"}).Return(request).Once()
+		request.EXPECT().WithJSONBody(matchJSONBody[SyntheticLocationValue](syntheticLocationSchemaID, syntheticLocationSchemaVersion)).Return(request).Once()
+		request.EXPECT().Execute(new([]postObjectsResponse)).Return(errors.New("api error")).Once()
+		apiClient.EXPECT().POST(ctx, ObjectsPath).Return(request).Once()
+
+		client := NewClient(apiClient)
+		objectID, err := client.CreateSyntheticLocation(ctx, location)
+		require.Error(t, err)
+		assert.Empty(t, objectID)
+	})
+}
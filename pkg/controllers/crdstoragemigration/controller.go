@@ -14,23 +14,23 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/utils/ptr"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const retryInterval = 10 * time.Second
 
 // for unit tests
-var run = Run
+var run = (*Migrator).Run
 
-func InitReconcile(ctx context.Context, clt client.Client, namespace string) error {
+// InitReconcile waits for the webhook deployment to become ready before running the migration, as the webhook serves the conversion of the CRDs.
+func InitReconcile(ctx context.Context, migrator *Migrator) error {
 	ctx, log := logd.NewFromContext(ctx, "crd-storage-migration")
-	request := types.NamespacedName{Name: webhook.DeploymentName, Namespace: namespace}
+	request := types.NamespacedName{Name: webhook.DeploymentName, Namespace: migrator.namespace}
 
 	return wait.PollUntilContextCancel(ctx, retryInterval, true, func(ctx context.Context) (bool, error) {
 		log.Info("reconciling CRD storage version migration", "namespace", request.Namespace, "name", request.Name)
 
 		deploy := &appsv1.Deployment{}
-		if err := clt.Get(ctx, request, deploy); err != nil {
+		if err := migrator.client.Get(ctx, request, deploy); err != nil {
 			if k8serrors.IsNotFound(err) {
 				log.Info("no webhook deployment found, skipping CRD storage version migration")
 
@@ -48,7 +48,7 @@ func InitReconcile(ctx context.Context, clt client.Client, namespace string) err
 			return false, nil
 		}
 
-		if err := run(ctx, clt, request.Namespace); err != nil {
+		if err := run(migrator, ctx); err != nil {
 			log.Error(err, "CRD storage migration failed")
 
 			return false, nil
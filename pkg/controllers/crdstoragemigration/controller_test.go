@@ -36,11 +36,11 @@ func TestInitReconcile(t *testing.T) {
 		return ctx, fakeClient
 	}
 
-	t.Cleanup(func() { run = Run })
+	t.Cleanup(func() { run = (*Migrator).Run })
 
 	t.Run("deployment not found", func(t *testing.T) {
 		run = nil
-		err := InitReconcile(t.Context(), fake.NewClient(), testNamespace)
+		err := InitReconcile(t.Context(), NewMigrator(fake.NewClient(), nil, testNamespace))
 		assert.NoError(t, err)
 	})
 
@@ -50,7 +50,7 @@ func TestInitReconcile(t *testing.T) {
 		})
 
 		run = nil
-		err := InitReconcile(ctx, clt, testNamespace)
+		err := InitReconcile(ctx, NewMigrator(clt, nil, testNamespace))
 		assert.ErrorIs(t, err, context.Canceled)
 	})
 
@@ -61,13 +61,13 @@ func TestInitReconcile(t *testing.T) {
 		})
 
 		called := false
-		run = func(context.Context, client.Client, string) error {
+		run = func(*Migrator, context.Context) error {
 			called = true
 
 			return errors.New("retry")
 		}
 
-		err := InitReconcile(ctx, clt, testNamespace)
+		err := InitReconcile(ctx, NewMigrator(clt, nil, testNamespace))
 		assert.ErrorIs(t, err, context.Canceled) //nolint:testifylint
 		assert.True(t, called)
 	})
@@ -78,11 +78,11 @@ func TestInitReconcile(t *testing.T) {
 			Status: appsv1.DeploymentStatus{ReadyReplicas: 3},
 		})
 
-		run = func(context.Context, client.Client, string) error {
+		run = func(*Migrator, context.Context) error {
 			return nil
 		}
 
-		err := InitReconcile(ctx, clt, testNamespace)
+		err := InitReconcile(ctx, NewMigrator(clt, nil, testNamespace))
 		assert.NoError(t, err)
 	})
 }
@@ -0,0 +1,115 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package crdstoragemigration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	k8sobject "github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// StatusConfigMapName is the ConfigMap in the operator namespace that holds the progress of the storage migration, one entry per CRD.
+	StatusConfigMapName = "dynatrace-crd-storage-migration"
+
+	storageMigrationAction = "StorageMigration"
+
+	storageMigrationStartedEvent   = "StorageMigrationStarted"
+	storageMigrationCompletedEvent = "StorageMigrationCompleted"
+	storageMigrationFailedEvent    = "StorageMigrationFailed"
+)
+
+type Phase string
+
+const (
+	MigratingPhase Phase = "Migrating"
+	CompletedPhase Phase = "Completed"
+	FailedPhase    Phase = "Failed"
+)
+
+// Progress is the state of the storage migration of a single CRD.
+type Progress struct {
+	Phase          Phase       `json:"phase"`
+	TargetVersion  string      `json:"targetVersion"`
+	StoredVersions []string    `json:"storedVersions"`
+	Migrated       int         `json:"migrated"`
+	Failed         []string    `json:"failed,omitempty"`
+	Message        string      `json:"message,omitempty"`
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+
+	// Pending holds the objects that would be migrated, only used for dry runs
+	Pending []string `json:"-"`
+}
+
+// recordProgress stores the progress in the status ConfigMap.
+// Failing to do so doesn't stop the migration, the progress is informational only.
+func (m *Migrator) recordProgress(ctx context.Context, crdName string, progress *Progress) {
+	if m.dryRun {
+		return
+	}
+
+	log := logd.FromContext(ctx)
+
+	progress.LastUpdateTime = *m.timeProvider.Now()
+
+	data, err := json.Marshal(progress)
+	if err != nil {
+		log.Error(err, "failed to marshal storage migration progress")
+
+		return
+	}
+
+	configMap := m.statusConfigMap()
+
+	_, err = k8sobject.RetryCreateOrUpdate(ctx, m.client, configMap, func() error {
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+
+		configMap.Data[crdName] = string(data)
+
+		return nil
+	})
+	if err != nil {
+		log.Error(err, "failed to record storage migration progress", "configMap", StatusConfigMapName)
+	}
+}
+
+func (m *Migrator) statusConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      StatusConfigMapName,
+			Namespace: m.namespace,
+		},
+	}
+}
+
+func (m *Migrator) sendStartedEvent(crdName string, progress *Progress) {
+	msg := fmt.Sprintf("Migrating %s from stored versions %s to %s", crdName, strings.Join(progress.StoredVersions, ", "), progress.TargetVersion)
+	m.sendEvent(corev1.EventTypeNormal, storageMigrationStartedEvent, msg)
+}
+
+func (m *Migrator) sendCompletedEvent(crdName string, progress *Progress) {
+	msg := fmt.Sprintf("Migrated %d objects of %s to %s", progress.Migrated, crdName, progress.TargetVersion)
+	m.sendEvent(corev1.EventTypeNormal, storageMigrationCompletedEvent, msg)
+}
+
+func (m *Migrator) sendFailedEvent(crdName string, progress *Progress) {
+	msg := fmt.Sprintf("Storage migration of %s failed after %d migrated objects: %s", crdName, progress.Migrated, progress.Message)
+	m.sendEvent(corev1.EventTypeWarning, storageMigrationFailedEvent, msg)
+}
+
+func (m *Migrator) sendEvent(eventType, reason, msg string) {
+	if m.dryRun || m.eventRecorder == nil {
+		return
+	}
+
+	m.eventRecorder.Eventf(m.statusConfigMap(), nil, eventType, reason, storageMigrationAction, msg)
+}
@@ -5,40 +5,116 @@ package crdstoragemigration
 
 import (
 	"context"
+	goerrors "errors"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8scrd"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/timeprovider"
 	"github.com/pkg/errors"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func Run(ctx context.Context, clt client.Client, namespace string) error {
-	log := logd.FromContext(ctx)
+const defaultPageSize int64 = 100
 
-	log.Info("starting CRD storage version storage migration")
+// DefaultCRDs are the CRDs of the operator whose objects are migrated to the current storage version.
+var DefaultCRDs = []string{
+	k8scrd.DynaKubeName,
+	k8scrd.EdgeConnectName,
+	k8scrd.DTPrometheusName,
+}
 
-	var crd apiextensionsv1.CustomResourceDefinition
+type Option func(*Migrator)
 
-	err := clt.Get(ctx, types.NamespacedName{Name: k8scrd.DynaKubeName}, &crd)
-	if err != nil {
-		if k8serrors.IsNotFound(err) {
-			log.Info("DynaKube CRD not found, skipping storage migration")
+// WithDryRun only reports the objects that would be migrated, nothing is written.
+func WithDryRun(dryRun bool) Option {
+	return func(m *Migrator) {
+		m.dryRun = dryRun
+	}
+}
+
+// WithPageSize sets the number of objects that are listed per request.
+func WithPageSize(pageSize int64) Option {
+	return func(m *Migrator) {
+		m.pageSize = pageSize
+	}
+}
+
+// WithCRDs overrides the CRDs that are migrated, defaults to DefaultCRDs.
+func WithCRDs(crdNames ...string) Option {
+	return func(m *Migrator) {
+		m.crdNames = crdNames
+	}
+}
+
+// Migrator rewrites all objects of a CRD, so they are persisted in the current storage version.
+// Once every object was rewritten, the outdated versions are removed from status.storedVersions of the CRD.
+// The progress is recorded in the status ConfigMap and as events.
+type Migrator struct {
+	client        client.Client
+	eventRecorder events.EventRecorder
+	timeProvider  *timeprovider.Provider
+	namespace     string
+	crdNames      []string
+	pageSize      int64
+	dryRun        bool
+}
+
+func NewMigrator(clt client.Client, eventRecorder events.EventRecorder, namespace string, opts ...Option) *Migrator {
+	m := &Migrator{
+		client:        clt,
+		eventRecorder: eventRecorder,
+		timeProvider:  timeprovider.New(),
+		namespace:     namespace,
+		crdNames:      DefaultCRDs,
+		pageSize:      defaultPageSize,
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
 
-			return nil
+	return m
+}
+
+func (m *Migrator) Run(ctx context.Context) error {
+	log := logd.FromContext(ctx)
+
+	log.Info("starting CRD storage version migration", "dryRun", m.dryRun)
+
+	var errs []error
+
+	for _, crdName := range m.crdNames {
+		if err := m.migrate(ctx, crdName); err != nil {
+			errs = append(errs, errors.WithMessagef(err, "failed to migrate %s", crdName))
 		}
+	}
+
+	return goerrors.Join(errs...)
+}
 
-		log.Info("failed to get DynaKube CRD, skipping storage migration", "error", err)
+func (m *Migrator) migrate(ctx context.Context, crdName string) error {
+	log := logd.FromContext(ctx).WithValues("crd", crdName)
+
+	var crd apiextensionsv1.CustomResourceDefinition
+
+	err := m.client.Get(ctx, types.NamespacedName{Name: crdName}, &crd)
+	if k8serrors.IsNotFound(err) {
+		log.Info("CRD not found, skipping storage migration")
 
 		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "failed to get CRD")
 	}
 
 	if len(crd.Status.StoredVersions) == 0 {
-		log.Info("DynaKube CRD has no storage versions, skipping storage migration")
+		log.Info("CRD has no storage versions, skipping storage migration")
 
 		return nil
 	}
@@ -49,55 +125,135 @@ func Run(ctx context.Context, clt client.Client, namespace string) error {
 	}
 
 	if len(crd.Status.StoredVersions) == 1 && crd.Status.StoredVersions[0] == targetVersion {
-		log.Info("DynaKube CRD has single, up-to-date storage version, no storage migration needed",
-			"storedVersions", crd.Status.StoredVersions)
+		log.Info("CRD has single, up-to-date storage version, no storage migration needed", "storedVersions", crd.Status.StoredVersions)
 
 		return nil
 	}
 
-	log.Info("DynaKube CRD has multiple storage versions, performing migration",
-		"storedVersions", crd.Status.StoredVersions,
-		"targetVersion", targetVersion)
+	log.Info("CRD has multiple storage versions, performing migration", "storedVersions", crd.Status.StoredVersions, "targetVersion", targetVersion)
 
-	// List all DynaKube instances using unstructured to avoid version conflicts
-	dynakubeList := &unstructured.UnstructuredList{}
-	dynakubeList.SetGroupVersionKind(schema.GroupVersionKind{
-		Group:   "dynatrace.com",
-		Version: targetVersion,
-		Kind:    "DynaKubeList",
-	})
+	progress := &Progress{
+		Phase:          MigratingPhase,
+		TargetVersion:  targetVersion,
+		StoredVersions: crd.Status.StoredVersions,
+	}
 
-	err = clt.List(ctx, dynakubeList, &client.ListOptions{
-		Namespace: namespace,
-	})
-	if err != nil {
-		return errors.Wrap(err, "failed to list DynaKube instances")
+	m.recordProgress(ctx, crdName, progress)
+	m.sendStartedEvent(crdName, progress)
+
+	gvk := schema.GroupVersionKind{
+		Group:   crd.Spec.Group,
+		Version: targetVersion,
+		Kind:    crd.Spec.Names.Kind + "List",
 	}
 
-	log.Info("migrating DynaKube instances to current storage version",
-		"count", len(dynakubeList.Items),
-		"targetVersion", targetVersion)
+	continueToken := ""
 
-	for i := range dynakubeList.Items {
-		dk := &dynakubeList.Items[i]
-		log.Info("migrating DynaKube instance",
-			"name", dk.GetName(),
-			"namespace", dk.GetNamespace())
+	for {
+		// unstructured avoids version conflicts with the typed API
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk)
 
-		err = clt.Update(ctx, dk)
+		// the objects are listed cluster-wide, as they can live in any of the watched namespaces,
+		// the stored versions may only be pruned once all of them were rewritten
+		err = m.client.List(ctx, list, client.Limit(m.pageSize), client.Continue(continueToken))
 		if err != nil {
-			return errors.Wrapf(err, "failed to update DynaKube %s/%s", dk.GetNamespace(), dk.GetName())
+			return m.fail(ctx, crdName, progress, errors.Wrapf(err, "failed to list %s instances", crd.Spec.Names.Kind))
 		}
+
+		for i := range list.Items {
+			m.migrateObject(ctx, &list.Items[i], progress)
+		}
+
+		m.recordProgress(ctx, crdName, progress)
+
+		continueToken = list.GetContinue()
+		if continueToken == "" {
+			break
+		}
+	}
+
+	if m.dryRun {
+		log.Info("dry run, storage migration would touch the listed objects", "count", len(progress.Pending), "objects", progress.Pending)
+
+		return nil
 	}
 
-	crd.Status.StoredVersions = []string{targetVersion}
+	if len(progress.Failed) > 0 {
+		return m.fail(ctx, crdName, progress, errors.Errorf("%d objects couldn't be migrated, keeping stored versions", len(progress.Failed)))
+	}
 
-	err = clt.Status().Update(ctx, &crd)
+	err = m.pruneStoredVersions(ctx, crdName, targetVersion)
 	if err != nil {
-		return errors.Wrap(err, "failed to update DynaKube CRD status")
+		return m.fail(ctx, crdName, progress, err)
 	}
 
-	log.Info("successfully migrated all DynaKube instances to current storage version")
+	progress.Phase = CompletedPhase
+	progress.StoredVersions = []string{targetVersion}
+	m.recordProgress(ctx, crdName, progress)
+	m.sendCompletedEvent(crdName, progress)
+
+	log.Info("successfully migrated all instances to current storage version", "count", progress.Migrated)
 
 	return nil
 }
+
+// migrateObject rewrites the object without changes, so the API server persists it in the current storage version.
+// Deleted objects are ignored, conflicts are retried with the latest revision of the object.
+func (m *Migrator) migrateObject(ctx context.Context, obj *unstructured.Unstructured, progress *Progress) {
+	log := logd.FromContext(ctx)
+	key := client.ObjectKeyFromObject(obj)
+
+	if m.dryRun {
+		log.Info("dry run, object would be migrated", "kind", obj.GetKind(), "name", key.Name, "namespace", key.Namespace)
+		progress.Pending = append(progress.Pending, key.String())
+
+		return
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := m.client.Update(ctx, obj)
+		if k8serrors.IsConflict(err) {
+			if getErr := m.client.Get(ctx, key, obj); getErr != nil {
+				return getErr
+			}
+		}
+
+		return err
+	})
+
+	switch {
+	case k8serrors.IsNotFound(err):
+		log.Info("object was deleted during storage migration", "name", key.Name, "namespace", key.Namespace)
+	case err != nil:
+		log.Error(err, "failed to migrate object", "name", key.Name, "namespace", key.Namespace)
+		progress.Failed = append(progress.Failed, key.String())
+	default:
+		log.Debug("migrated object", "name", key.Name, "namespace", key.Namespace)
+		progress.Migrated++
+	}
+}
+
+func (m *Migrator) pruneStoredVersions(ctx context.Context, crdName, targetVersion string) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var crd apiextensionsv1.CustomResourceDefinition
+		if err := m.client.Get(ctx, types.NamespacedName{Name: crdName}, &crd); err != nil {
+			return err
+		}
+
+		crd.Status.StoredVersions = []string{targetVersion}
+
+		return m.client.Status().Update(ctx, &crd)
+	})
+
+	return errors.Wrap(err, "failed to update CRD status")
+}
+
+func (m *Migrator) fail(ctx context.Context, crdName string, progress *Progress, err error) error {
+	progress.Phase = FailedPhase
+	progress.Message = err.Error()
+	m.recordProgress(ctx, crdName, progress)
+	m.sendFailedEvent(crdName, progress)
+
+	return err
+}
@@ -5,6 +5,7 @@ package crdstoragemigration
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
@@ -12,225 +13,344 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 )
 
 const testNamespace = "test-namespace"
 
+var failOnUpdate = interceptor.Funcs{
+	Update: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+		return errors.New("unexpected write operation")
+	},
+}
+
+func newTestCRD(name, kind string, storedVersions []string, versions ...apiextensionsv1.CustomResourceDefinitionVersion) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "dynatrace.com",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Kind: kind,
+			},
+			Versions: versions,
+		},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{
+			StoredVersions: storedVersions,
+		},
+	}
+}
+
+func newOutdatedDynaKubeCRD() *apiextensionsv1.CustomResourceDefinition {
+	return newTestCRD(k8scrd.DynaKubeName, "DynaKube", []string{"v1beta1", "v1beta2"},
+		apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1beta1", Storage: false},
+		apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1beta2", Storage: true},
+	)
+}
+
+func newTestObject(apiVersion, kind, name string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": apiVersion,
+			"kind":       kind,
+			"metadata": map[string]any{
+				"name":      name,
+				"namespace": testNamespace,
+			},
+			"spec": map[string]any{},
+		},
+	}
+}
+
+func newTestObjectInNamespace(apiVersion, kind, name, namespace string) *unstructured.Unstructured {
+	obj := newTestObject(apiVersion, kind, name)
+	obj.SetNamespace(namespace)
+
+	return obj
+}
+
+func newTestMigrator(clt client.Client, recorder events.EventRecorder, opts ...Option) *Migrator {
+	return NewMigrator(clt, recorder, testNamespace, append([]Option{WithCRDs(k8scrd.DynaKubeName)}, opts...)...)
+}
+
+func getStoredVersions(t *testing.T, clt client.Client, crdName string) []string {
+	var crd apiextensionsv1.CustomResourceDefinition
+
+	require.NoError(t, clt.Get(t.Context(), client.ObjectKey{Name: crdName}, &crd))
+
+	return crd.Status.StoredVersions
+}
+
+func getProgress(t *testing.T, clt client.Client, crdName string) Progress {
+	var configMap corev1.ConfigMap
+
+	require.NoError(t, clt.Get(t.Context(), client.ObjectKey{Name: StatusConfigMapName, Namespace: testNamespace}, &configMap))
+
+	var progress Progress
+
+	require.NoError(t, json.Unmarshal([]byte(configMap.Data[crdName]), &progress))
+
+	return progress
+}
+
 func TestRun(t *testing.T) {
 	t.Run("returns no error when CRD not found", func(t *testing.T) {
+		fakeClient := fake.NewClientWithInterceptors(failOnUpdate)
+		err := newTestMigrator(fakeClient, events.NewFakeRecorder(10)).Run(t.Context())
+
+		require.NoError(t, err)
+	})
+
+	t.Run("returns error when CRD can't be read", func(t *testing.T) {
 		fakeClient := fake.NewClientWithInterceptors(interceptor.Funcs{
-			Update: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
-				return errors.New("unexpected write operation")
+			Get: func(ctx context.Context, client client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				return errors.New("forbidden")
 			},
 		})
-		err := Run(t.Context(), fakeClient, testNamespace)
+		err := newTestMigrator(fakeClient, events.NewFakeRecorder(10)).Run(t.Context())
 
-		require.NoError(t, err)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get CRD")
 	})
 
 	t.Run("returns no error when CRD has no storage versions", func(t *testing.T) {
-		crd := &apiextensionsv1.CustomResourceDefinition{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: k8scrd.DynaKubeName,
-			},
-			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
-				Group: "dynatrace.com",
-				Names: apiextensionsv1.CustomResourceDefinitionNames{
-					Plural: "dynakubes",
-					Kind:   "DynaKube",
-				},
-				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
-					{
-						Name:    "v1beta1",
-						Storage: true,
-					},
-				},
-			},
-			Status: apiextensionsv1.CustomResourceDefinitionStatus{
-				StoredVersions: []string{},
-			},
-		}
-		fakeClient := fake.NewClientWithInterceptors(interceptor.Funcs{
-			Update: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
-				return errors.New("unexpected write operation")
-			},
-		}, crd)
+		crd := newTestCRD(k8scrd.DynaKubeName, "DynaKube", []string{},
+			apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1beta1", Storage: true},
+		)
+		fakeClient := fake.NewClientWithInterceptors(failOnUpdate, crd)
 
-		err := Run(t.Context(), fakeClient, testNamespace)
+		err := newTestMigrator(fakeClient, events.NewFakeRecorder(10)).Run(t.Context())
 
 		require.NoError(t, err)
 	})
 
 	t.Run("returns no error when CRD has single up-to-date storage version", func(t *testing.T) {
-		crd := &apiextensionsv1.CustomResourceDefinition{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: k8scrd.DynaKubeName,
-			},
-			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
-				Group: "dynatrace.com",
-				Names: apiextensionsv1.CustomResourceDefinitionNames{
-					Plural: "dynakubes",
-					Kind:   "DynaKube",
-				},
-				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
-					{
-						Name:    "v1beta1",
-						Storage: true,
-					},
-				},
-			},
-			Status: apiextensionsv1.CustomResourceDefinitionStatus{
-				StoredVersions: []string{"v1beta1"},
-			},
-		}
-		fakeClient := fake.NewClientWithInterceptors(interceptor.Funcs{
-			Update: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
-				return errors.New("unexpected write operation")
-			},
-		}, crd)
-		err := Run(t.Context(), fakeClient, testNamespace)
+		crd := newTestCRD(k8scrd.DynaKubeName, "DynaKube", []string{"v1beta1"},
+			apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1beta1", Storage: true},
+		)
+		fakeClient := fake.NewClientWithInterceptors(failOnUpdate, crd)
+		recorder := events.NewFakeRecorder(10)
+
+		err := newTestMigrator(fakeClient, recorder).Run(t.Context())
 
 		require.NoError(t, err)
+		assert.Empty(t, recorder.Events)
 	})
 
 	t.Run("returns error when no storage version is found", func(t *testing.T) {
-		crd := &apiextensionsv1.CustomResourceDefinition{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: k8scrd.DynaKubeName,
-			},
-			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
-				Group: "dynatrace.com",
-				Names: apiextensionsv1.CustomResourceDefinitionNames{
-					Plural: "dynakubes",
-					Kind:   "DynaKube",
-				},
-				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
-					{
-						Name:    "v1beta1",
-						Storage: false,
-					},
-				},
-			},
-			Status: apiextensionsv1.CustomResourceDefinitionStatus{
-				StoredVersions: []string{"v1beta1", "v1beta2"},
-			},
-		}
-		fakeClient := fake.NewClientWithInterceptors(interceptor.Funcs{
-			Update: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
-				return errors.New("unexpected write operation")
-			},
-		}, crd)
-		err := Run(t.Context(), fakeClient, testNamespace)
+		crd := newTestCRD(k8scrd.DynaKubeName, "DynaKube", []string{"v1beta1", "v1beta2"},
+			apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1beta1", Storage: false},
+		)
+		fakeClient := fake.NewClientWithInterceptors(failOnUpdate, crd)
+		err := newTestMigrator(fakeClient, events.NewFakeRecorder(10)).Run(t.Context())
 
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to determine target storage version")
 	})
 
 	t.Run("migrates DynaKube instances when multiple storage versions exist", func(t *testing.T) {
-		crd := &apiextensionsv1.CustomResourceDefinition{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: k8scrd.DynaKubeName,
-			},
-			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
-				Group: "dynatrace.com",
-				Names: apiextensionsv1.CustomResourceDefinitionNames{
-					Plural: "dynakubes",
-					Kind:   "DynaKube",
-				},
-				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
-					{
-						Name:    "v1beta1",
-						Storage: false,
-					},
-					{
-						Name:    "v1beta2",
-						Storage: true,
-					},
-				},
-			},
-			Status: apiextensionsv1.CustomResourceDefinitionStatus{
-				StoredVersions: []string{"v1beta1", "v1beta2"},
+		dk1 := newTestObject("dynatrace.com/v1beta2", "DynaKube", "dynakube-1")
+		dk2 := newTestObject("dynatrace.com/v1beta2", "DynaKube", "dynakube-2")
+		fakeClient := fake.NewClient(newOutdatedDynaKubeCRD(), dk1, dk2)
+		recorder := events.NewFakeRecorder(10)
+
+		err := newTestMigrator(fakeClient, recorder).Run(t.Context())
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"v1beta2"}, getStoredVersions(t, fakeClient, k8scrd.DynaKubeName))
+
+		progress := getProgress(t, fakeClient, k8scrd.DynaKubeName)
+		assert.Equal(t, CompletedPhase, progress.Phase)
+		assert.Equal(t, 2, progress.Migrated)
+		assert.Equal(t, "v1beta2", progress.TargetVersion)
+		assert.Empty(t, progress.Failed)
+
+		assert.Contains(t, <-recorder.Events, storageMigrationStartedEvent)
+		assert.Contains(t, <-recorder.Events, storageMigrationCompletedEvent)
+	})
+
+	t.Run("migrates DynaKube instances in all namespaces", func(t *testing.T) {
+		dk1 := newTestObject("dynatrace.com/v1beta2", "DynaKube", "dynakube")
+		dk2 := newTestObjectInNamespace("dynatrace.com/v1beta2", "DynaKube", "dynakube", "other-namespace")
+		migrated := map[string]bool{}
+		fakeClient := fake.NewClientWithInterceptors(interceptor.Funcs{
+			Update: func(ctx context.Context, clt client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				migrated[obj.GetNamespace()] = true
+
+				return clt.Update(ctx, obj, opts...)
 			},
-		}
+		}, newOutdatedDynaKubeCRD(), dk1, dk2)
+
+		err := newTestMigrator(fakeClient, events.NewFakeRecorder(10)).Run(t.Context())
 
-		// Create DynaKube instances using unstructured
-		dk1 := &unstructured.Unstructured{
-			Object: map[string]any{
-				"apiVersion": "dynatrace.com/v1beta2",
-				"kind":       "DynaKube",
-				"metadata": map[string]any{
-					"name":      "dynakube-1",
-					"namespace": testNamespace,
-				},
-				"spec": map[string]any{},
+		require.NoError(t, err)
+		assert.Equal(t, map[string]bool{testNamespace: true, "other-namespace": true}, migrated)
+		assert.Equal(t, []string{"v1beta2"}, getStoredVersions(t, fakeClient, k8scrd.DynaKubeName))
+		assert.Equal(t, 2, getProgress(t, fakeClient, k8scrd.DynaKubeName).Migrated)
+	})
+
+	t.Run("keeps stored versions if an object in another namespace can't be migrated", func(t *testing.T) {
+		dk1 := newTestObject("dynatrace.com/v1beta2", "DynaKube", "dynakube")
+		dk2 := newTestObjectInNamespace("dynatrace.com/v1beta2", "DynaKube", "dynakube", "other-namespace")
+		fakeClient := fake.NewClientWithInterceptors(interceptor.Funcs{
+			Update: func(ctx context.Context, clt client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if obj.GetNamespace() == "other-namespace" {
+					return errors.New("forbidden")
+				}
+
+				return clt.Update(ctx, obj, opts...)
 			},
+		}, newOutdatedDynaKubeCRD(), dk1, dk2)
+
+		err := newTestMigrator(fakeClient, events.NewFakeRecorder(10)).Run(t.Context())
+
+		require.Error(t, err)
+		assert.Equal(t, []string{"v1beta1", "v1beta2"}, getStoredVersions(t, fakeClient, k8scrd.DynaKubeName))
+	})
+
+	t.Run("migrates EdgeConnect and DTPrometheus instances", func(t *testing.T) {
+		edgeConnectCRD := newTestCRD(k8scrd.EdgeConnectName, "EdgeConnect", []string{"v1alpha1", "v1alpha2"},
+			apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1alpha1", Storage: false},
+			apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1alpha2", Storage: true},
+		)
+		dtPrometheusCRD := newTestCRD(k8scrd.DTPrometheusName, "DTPrometheus", []string{"v1alpha1"},
+			apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1alpha1", Storage: true},
+		)
+		ec := newTestObject("dynatrace.com/v1alpha2", "EdgeConnect", "edgeconnect")
+		fakeClient := fake.NewClient(edgeConnectCRD, dtPrometheusCRD, ec)
+
+		err := NewMigrator(fakeClient, events.NewFakeRecorder(10), testNamespace).Run(t.Context())
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"v1alpha2"}, getStoredVersions(t, fakeClient, k8scrd.EdgeConnectName))
+		assert.Equal(t, []string{"v1alpha1"}, getStoredVersions(t, fakeClient, k8scrd.DTPrometheusName))
+		assert.Equal(t, 1, getProgress(t, fakeClient, k8scrd.EdgeConnectName).Migrated)
+	})
+
+	t.Run("handles empty DynaKube list", func(t *testing.T) {
+		fakeClient := fake.NewClient(newOutdatedDynaKubeCRD())
+		err := newTestMigrator(fakeClient, events.NewFakeRecorder(10)).Run(t.Context())
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"v1beta2"}, getStoredVersions(t, fakeClient, k8scrd.DynaKubeName))
+	})
+
+	t.Run("pages through the list", func(t *testing.T) {
+		dk1 := newTestObject("dynatrace.com/v1beta2", "DynaKube", "dynakube-1")
+		dk2 := newTestObject("dynatrace.com/v1beta2", "DynaKube", "dynakube-2")
+		pages := map[string]struct {
+			item          *unstructured.Unstructured
+			continueToken string
+		}{
+			"":     {item: dk1, continueToken: "next"},
+			"next": {item: dk2},
 		}
 
-		dk2 := &unstructured.Unstructured{
-			Object: map[string]any{
-				"apiVersion": "dynatrace.com/v1beta2",
-				"kind":       "DynaKube",
-				"metadata": map[string]any{
-					"name":      "dynakube-2",
-					"namespace": testNamespace,
-				},
-				"spec": map[string]any{},
+		var listOptions []*client.ListOptions
+
+		fakeClient := fake.NewClientWithInterceptors(interceptor.Funcs{
+			List: func(ctx context.Context, clt client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				listOpts := &client.ListOptions{}
+				listOpts.ApplyOptions(opts)
+				listOptions = append(listOptions, listOpts)
+
+				page := pages[listOpts.Continue]
+				unstructuredList := list.(*unstructured.UnstructuredList)
+				unstructuredList.Items = []unstructured.Unstructured{*page.item.DeepCopy()}
+				unstructuredList.SetContinue(page.continueToken)
+
+				return nil
 			},
-		}
+		}, newOutdatedDynaKubeCRD(), dk1, dk2)
 
-		fakeClient := fake.NewClient(crd, dk1, dk2)
-		err := Run(t.Context(), fakeClient, testNamespace)
+		err := newTestMigrator(fakeClient, events.NewFakeRecorder(10), WithPageSize(1)).Run(t.Context())
 
 		require.NoError(t, err)
+		require.Len(t, listOptions, 2)
+		assert.Equal(t, int64(1), listOptions[0].Limit)
+		assert.Empty(t, listOptions[0].Namespace)
+		assert.Equal(t, "next", listOptions[1].Continue)
+		assert.Equal(t, 2, getProgress(t, fakeClient, k8scrd.DynaKubeName).Migrated)
+	})
+
+	t.Run("retries on conflict", func(t *testing.T) {
+		dk := newTestObject("dynatrace.com/v1beta2", "DynaKube", "dynakube")
+		conflicts := 0
+
+		fakeClient := fake.NewClientWithInterceptors(interceptor.Funcs{
+			Update: func(ctx context.Context, clt client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if conflicts == 0 {
+					conflicts++
+
+					return k8serrors.NewConflict(schema.GroupResource{Resource: "dynakubes"}, obj.GetName(), errors.New("modified"))
+				}
+
+				return clt.Update(ctx, obj, opts...)
+			},
+		}, newOutdatedDynaKubeCRD(), dk)
+
+		err := newTestMigrator(fakeClient, events.NewFakeRecorder(10)).Run(t.Context())
 
-		// Verify CRD status was updated
-		var updatedCRD apiextensionsv1.CustomResourceDefinition
-		err = fakeClient.Get(t.Context(), client.ObjectKey{Name: k8scrd.DynaKubeName}, &updatedCRD)
 		require.NoError(t, err)
-		assert.Equal(t, []string{"v1beta2"}, updatedCRD.Status.StoredVersions)
+		assert.Equal(t, 1, conflicts)
+		assert.Equal(t, []string{"v1beta2"}, getStoredVersions(t, fakeClient, k8scrd.DynaKubeName))
 	})
 
-	t.Run("handles empty DynaKube list", func(t *testing.T) {
-		crd := &apiextensionsv1.CustomResourceDefinition{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: k8scrd.DynaKubeName,
+	t.Run("keeps stored versions if an object can't be migrated", func(t *testing.T) {
+		dk1 := newTestObject("dynatrace.com/v1beta2", "DynaKube", "dynakube-1")
+		dk2 := newTestObject("dynatrace.com/v1beta2", "DynaKube", "dynakube-2")
+
+		fakeClient := fake.NewClientWithInterceptors(interceptor.Funcs{
+			Update: func(ctx context.Context, clt client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if obj.GetName() == "dynakube-1" {
+					return errors.New("denied by webhook")
+				}
+
+				return clt.Update(ctx, obj, opts...)
 			},
-			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
-				Group: "dynatrace.com",
-				Names: apiextensionsv1.CustomResourceDefinitionNames{
-					Plural: "dynakubes",
-					Kind:   "DynaKube",
-				},
-				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
-					{
-						Name:    "v1beta1",
-						Storage: false,
-					},
-					{
-						Name:    "v1beta2",
-						Storage: true,
-					},
-				},
+		}, newOutdatedDynaKubeCRD(), dk1, dk2)
+		recorder := events.NewFakeRecorder(10)
+
+		err := newTestMigrator(fakeClient, recorder).Run(t.Context())
+
+		require.Error(t, err)
+		assert.Equal(t, []string{"v1beta1", "v1beta2"}, getStoredVersions(t, fakeClient, k8scrd.DynaKubeName))
+
+		progress := getProgress(t, fakeClient, k8scrd.DynaKubeName)
+		assert.Equal(t, FailedPhase, progress.Phase)
+		assert.Equal(t, 1, progress.Migrated)
+		assert.Equal(t, []string{testNamespace + "/dynakube-1"}, progress.Failed)
+
+		assert.Contains(t, <-recorder.Events, storageMigrationStartedEvent)
+		assert.Contains(t, <-recorder.Events, storageMigrationFailedEvent)
+	})
+
+	t.Run("dry run doesn't write anything", func(t *testing.T) {
+		dk := newTestObject("dynatrace.com/v1beta2", "DynaKube", "dynakube")
+		fakeClient := fake.NewClientWithInterceptors(interceptor.Funcs{
+			Update: failOnUpdate.Update,
+			Create: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				return errors.New("unexpected write operation")
 			},
-			Status: apiextensionsv1.CustomResourceDefinitionStatus{
-				StoredVersions: []string{"v1beta1", "v1beta2"},
+			SubResourceUpdate: func(ctx context.Context, client client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				return errors.New("unexpected write operation")
 			},
-		}
-
-		fakeClient := fake.NewClient(crd)
-		err := Run(t.Context(), fakeClient, testNamespace)
+		}, newOutdatedDynaKubeCRD(), dk)
+		recorder := events.NewFakeRecorder(10)
 
-		require.NoError(t, err)
+		err := newTestMigrator(fakeClient, recorder, WithDryRun(true)).Run(t.Context())
 
-		// Verify CRD status was updated even without DynaKubes
-		var updatedCRD apiextensionsv1.CustomResourceDefinition
-		err = fakeClient.Get(t.Context(), client.ObjectKey{Name: k8scrd.DynaKubeName}, &updatedCRD)
 		require.NoError(t, err)
-		assert.Equal(t, []string{"v1beta2"}, updatedCRD.Status.StoredVersions)
+		assert.Equal(t, []string{"v1beta1", "v1beta2"}, getStoredVersions(t, fakeClient, k8scrd.DynaKubeName))
+		assert.Empty(t, recorder.Events)
 	})
 }
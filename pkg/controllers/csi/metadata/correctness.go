@@ -14,6 +14,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/injection/codemodule/installer/symlink"
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8senv"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/watchnamespaces"
 	"k8s.io/mount-utils"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -155,7 +156,7 @@ func (checker *CorrectnessChecker) migrateHostMounts(ctx context.Context) {
 func GetRelevantDynaKubes(ctx context.Context, apiReader client.Reader) ([]dynakube.DynaKube, error) {
 	var dkList dynakube.DynaKubeList
 
-	err := apiReader.List(ctx, &dkList, client.InNamespace(k8senv.DefaultNamespace()))
+	err := watchnamespaces.List(ctx, apiReader, &dkList, k8senv.DefaultNamespace())
 	if err != nil {
 		return nil, err
 	}
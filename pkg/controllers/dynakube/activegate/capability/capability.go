@@ -10,6 +10,9 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
+	maputils "github.com/Dynatrace/dynatrace-operator/pkg/util/map"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/net"
 )
 
@@ -20,6 +23,7 @@ var (
 		activegate.MetricsIngestCapability.DisplayName: activegate.MetricsIngestCapability.ArgumentName,
 		activegate.DynatraceAPICapability.DisplayName:  activegate.DynatraceAPICapability.ArgumentName,
 		activegate.DebuggingCapability.DisplayName:     activegate.DebuggingCapability.ArgumentName,
+		activegate.SyntheticCapability.DisplayName:     activegate.SyntheticCapability.ArgumentName,
 	}
 )
 
@@ -27,11 +31,14 @@ type Capability interface {
 	Enabled() bool
 	ArgName() string
 	Properties() *activegate.CapabilityProperties
+	// GroupName returns the name of the ActiveGate group, it is empty for the ActiveGate configured in spec.activeGate.
+	GroupName() string
 }
 
 type capabilityBase struct {
 	properties *activegate.CapabilityProperties
 	argName    string
+	groupName  string
 }
 
 func (capability *capabilityBase) Enabled() bool {
@@ -46,10 +53,24 @@ func (capability *capabilityBase) ArgName() string {
 	return capability.argName
 }
 
+func (capability *capabilityBase) GroupName() string {
+	return capability.groupName
+}
+
 func CalculateStatefulSetName(dynakubeName string) string {
 	return dynakubeName + "-" + consts.MultiActiveGateName
 }
 
+// CalculateGroupStatefulSetName returns the name of the StatefulSet of an ActiveGate group,
+// an empty group name refers to the ActiveGate configured in spec.activeGate.
+func CalculateGroupStatefulSetName(dynakubeName, groupName string) string {
+	if groupName == "" {
+		return CalculateStatefulSetName(dynakubeName)
+	}
+
+	return CalculateStatefulSetName(dynakubeName) + "-" + groupName
+}
+
 type MultiCapability struct {
 	capabilityBase
 }
@@ -88,22 +109,151 @@ func NewMultiCapability(dk *dynakube.DynaKube) Capability {
 	return &mc
 }
 
+// NewGroupCapabilities returns a capability for every ActiveGate group of the DynaKube.
+// The properties of a group are the properties of spec.activeGate with the settings of the group applied.
+func NewGroupCapabilities(dk *dynakube.DynaKube) []Capability {
+	if dk == nil || !dk.ActiveGate().IsEnabled() {
+		return nil
+	}
+
+	groupCapabilities := make([]Capability, 0, len(dk.Spec.ActiveGate.Groups))
+
+	for _, group := range dk.Spec.ActiveGate.Groups {
+		groupCapabilities = append(groupCapabilities, newGroupCapability(dk, group))
+	}
+
+	return groupCapabilities
+}
+
+func newGroupCapability(dk *dynakube.DynaKube, group activegate.GroupSpec) Capability {
+	properties := dk.Spec.ActiveGate.CapabilityProperties.DeepCopy()
+
+	if group.Replicas != nil {
+		properties.Replicas = group.Replicas
+	}
+
+	if group.Resources != nil {
+		properties.Resources = *group.Resources
+	}
+
+	if group.NodeSelector != nil {
+		properties.NodeSelector = group.NodeSelector
+	}
+
+	if group.TopologyZone != "" {
+		properties.NodeSelector = maputils.MergeMap(properties.NodeSelector, map[string]string{corev1.LabelTopologyZone: group.TopologyZone})
+	}
+
+	if group.Tolerations != nil {
+		properties.Tolerations = group.Tolerations
+	}
+
+	if group.TopologySpreadConstraints != nil {
+		properties.TopologySpreadConstraints = group.TopologySpreadConstraints
+	}
+
+	if group.Group != "" {
+		properties.Group = group.Group
+	}
+
+	if group.CustomProperties != nil {
+		properties.CustomProperties = group.CustomProperties
+	}
+
+	if group.PodTemplatePatch != nil {
+		properties.PodTemplatePatch = group.PodTemplatePatch
+	}
+
+	capabilityArgs := []string{}
+
+	for _, capName := range group.Capabilities {
+		argName, ok := activeGateCapabilities[capName]
+		if !ok {
+			continue
+		}
+
+		capabilityArgs = append(capabilityArgs, argName)
+	}
+
+	return &MultiCapability{
+		capabilityBase{
+			properties: properties,
+			argName:    strings.Join(capabilityArgs, ","),
+			groupName:  group.Name,
+		},
+	}
+}
+
 func BuildServiceName(dynakubeName string) string {
 	return dynakubeName + "-" + consts.MultiActiveGateName
 }
 
+// BuildGroupServiceName returns the name of the Service of an ActiveGate group,
+// an empty group name refers to the ActiveGate configured in spec.activeGate.
+func BuildGroupServiceName(dynakubeName, groupName string) string {
+	if groupName == "" {
+		return BuildServiceName(dynakubeName)
+	}
+
+	return BuildServiceName(dynakubeName) + "-" + groupName
+}
+
 // BuildServiceHostname returns the in-cluster DNS hostname of the ActiveGate Service:
 // "<dk-name>-activegate.<namespace>".
 func BuildServiceHostname(dk dynakube.DynaKube) string {
-	return fmt.Sprintf("%s.%s", BuildServiceName(dk.Name), dk.Namespace)
+	return BuildGroupServiceHostname(dk, "")
+}
+
+// BuildGroupServiceHostname returns the in-cluster DNS hostname of the Service of an ActiveGate group:
+// "<dk-name>-activegate-<group>.<namespace>".
+func BuildGroupServiceHostname(dk dynakube.DynaKube, groupName string) string {
+	return fmt.Sprintf("%s.%s", BuildGroupServiceName(dk.Name, groupName), dk.Namespace)
+}
+
+// BuildCustomPropertiesOwnerName returns the name used for the custom properties secret of the ActiveGate group.
+func BuildCustomPropertiesOwnerName(dk dynakube.DynaKube, groupName string) string {
+	if groupName == "" {
+		return dk.ActiveGate().GetServiceAccountOwner()
+	}
+
+	return consts.MultiActiveGateName + "-" + groupName
+}
+
+// BuildSelectorLabels returns the labels selecting the pods of an ActiveGate group.
+// The group label is only set once groups are configured, so the selector of existing ActiveGates doesn't change.
+func BuildSelectorLabels(dk dynakube.DynaKube, groupName string) map[string]string {
+	appLabels := k8slabel.NewAppLabels(k8slabel.ActiveGateComponentLabel, dk.Name, "", "")
+	selectorLabels := appLabels.BuildMatchLabels()
+
+	if groupName != "" || len(dk.Spec.ActiveGate.Groups) > 0 {
+		selectorLabels[k8slabel.ActiveGateGroupLabel] = groupName
+	}
+
+	return selectorLabels
 }
 
 // BuildDNSEntryPoint will create a string listing of the full DNS entry points for the Service of the ActiveGate in the provided DynaKube.
 // Example: https://34.118.233.238:443,https://dynakube-activegate.dynatrace:443
 func BuildDNSEntryPoint(dk dynakube.DynaKube) string {
+	return BuildGroupDNSEntryPoint(dk, "")
+}
+
+// BuildGroupDNSEntryPoint will create a string listing of the full DNS entry points for the Service of an ActiveGate group.
+// Example: https://34.118.233.239:443,https://dynakube-activegate-routing.dynatrace:443
+func BuildGroupDNSEntryPoint(dk dynakube.DynaKube, groupName string) string {
+	serviceIPs := dk.Status.ActiveGate.ServiceIPs
+	routingEnabled := dk.ActiveGate().IsRoutingEnabled()
+	serviceDomain := buildServiceHostnameWithPort(dk)
+
+	if group := dk.ActiveGate().GetGroup(groupName); group != nil {
+		serviceIPs = dk.Status.ActiveGate.Groups[groupName].ServiceIPs
+		routingEnabled = group.IsRoutingEnabled()
+		serviceDomain = withHTTPSPort(BuildGroupServiceHostname(dk, groupName))
+	}
+
 	entries := []string{}
 
-	for _, ip := range dk.Status.ActiveGate.ServiceIPs {
+	for _, ip := range serviceIPs {
 		if net.IsIPv6String(ip) {
 			ip = "[" + ip + "]"
 		}
@@ -112,8 +262,7 @@ func BuildDNSEntryPoint(dk dynakube.DynaKube) string {
 		entries = append(entries, serviceHostEntry)
 	}
 
-	if dk.ActiveGate().IsRoutingEnabled() {
-		serviceDomain := buildServiceHostnameWithPort(dk)
+	if routingEnabled {
 		serviceDomainEntry := buildDNSEntry(serviceDomain)
 		entries = append(entries, serviceDomainEntry)
 	}
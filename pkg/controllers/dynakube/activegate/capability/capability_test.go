@@ -11,11 +11,16 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/extensions"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/otlp"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/telemetryingest"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/podtemplate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/proxy"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 const (
@@ -358,3 +363,121 @@ func TestBuildDNSEntryPoint(t *testing.T) {
 		})
 	}
 }
+
+func TestGroupCapabilities(t *testing.T) {
+	newDynaKube := func() *dynakube.DynaKube {
+		return &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{Name: "dynakube", Namespace: "dynatrace"},
+			Spec: dynakube.DynaKubeSpec{
+				ActiveGate: activegate.Spec{
+					Capabilities: []activegate.CapabilityDisplayName{activegate.KubeMonCapability.DisplayName},
+					CapabilityProperties: activegate.CapabilityProperties{
+						Replicas:     ptr.To(int32(1)),
+						Group:        "main",
+						NodeSelector: map[string]string{"node": "main"},
+					},
+					Groups: []activegate.GroupSpec{
+						{
+							Name:         "routing",
+							Capabilities: []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName},
+							Replicas:     ptr.To(int32(3)),
+							NodeSelector: map[string]string{"node": "routing"},
+						},
+						{
+							Name:         "api",
+							Capabilities: []activegate.CapabilityDisplayName{activegate.DynatraceAPICapability.DisplayName},
+						},
+					},
+				},
+			},
+			Status: dynakube.DynaKubeStatus{
+				ActiveGate: activegate.Status{
+					ServiceIPs: []string{"1.2.3.4"},
+					Groups: map[string]activegate.GroupStatus{
+						"routing": {ServiceIPs: []string{"5.6.7.8"}},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("group settings override the main ActiveGate", func(t *testing.T) {
+		dk := newDynaKube()
+
+		groupCapabilities := NewGroupCapabilities(dk)
+		require.Len(t, groupCapabilities, 2)
+
+		routing := groupCapabilities[0]
+		assert.True(t, routing.Enabled())
+		assert.Equal(t, "routing", routing.GroupName())
+		assert.Equal(t, "MSGrouter", routing.ArgName())
+		assert.Equal(t, int32(3), *routing.Properties().Replicas)
+		assert.Equal(t, map[string]string{"node": "routing"}, routing.Properties().NodeSelector)
+		assert.Equal(t, "main", routing.Properties().Group)
+
+		api := groupCapabilities[1]
+		assert.Equal(t, "restInterface", api.ArgName())
+		assert.Equal(t, int32(1), *api.Properties().Replicas)
+		assert.Equal(t, map[string]string{"node": "main"}, api.Properties().NodeSelector)
+
+		// the main ActiveGate is left untouched
+		assert.Equal(t, map[string]string{"node": "main"}, dk.Spec.ActiveGate.NodeSelector)
+		assert.Equal(t, int32(1), *dk.Spec.ActiveGate.Replicas)
+	})
+
+	t.Run("group pinned to a topology zone", func(t *testing.T) {
+		dk := newDynaKube()
+		dk.Spec.ActiveGate.Groups[0].TopologyZone = "zone-a"
+
+		routing := NewGroupCapabilities(dk)[0]
+		assert.Equal(t, map[string]string{"node": "routing", corev1.LabelTopologyZone: "zone-a"}, routing.Properties().NodeSelector)
+		assert.Equal(t, map[string]string{"node": "routing"}, dk.Spec.ActiveGate.Groups[0].NodeSelector)
+	})
+
+	t.Run("group pod template patch replaces the main one", func(t *testing.T) {
+		dk := newDynaKube()
+		mainPatch := &podtemplate.Patch{Patch: apiextensionsv1.JSON{Raw: []byte(`{"spec":{"runtimeClassName":"main"}}`)}}
+		groupPatch := &podtemplate.Patch{Patch: apiextensionsv1.JSON{Raw: []byte(`{"spec":{"runtimeClassName":"routing"}}`)}}
+		dk.Spec.ActiveGate.PodTemplatePatch = mainPatch
+		dk.Spec.ActiveGate.Groups[0].PodTemplatePatch = groupPatch
+
+		groupCapabilities := NewGroupCapabilities(dk)
+		assert.Equal(t, groupPatch, groupCapabilities[0].Properties().PodTemplatePatch)
+		assert.Equal(t, mainPatch, groupCapabilities[1].Properties().PodTemplatePatch)
+	})
+
+	t.Run("no groups", func(t *testing.T) {
+		assert.Empty(t, NewGroupCapabilities(buildDynakube(capabilities, false, false)))
+		assert.Empty(t, NewGroupCapabilities(nil))
+	})
+
+	t.Run("names", func(t *testing.T) {
+		assert.Equal(t, "dynakube-activegate", CalculateGroupStatefulSetName("dynakube", ""))
+		assert.Equal(t, "dynakube-activegate-routing", CalculateGroupStatefulSetName("dynakube", "routing"))
+		assert.Equal(t, "dynakube-activegate", BuildGroupServiceName("dynakube", ""))
+		assert.Equal(t, "dynakube-activegate-routing", BuildGroupServiceName("dynakube", "routing"))
+		assert.Equal(t, "activegate-routing", BuildCustomPropertiesOwnerName(*newDynaKube(), "routing"))
+		assert.Equal(t, newDynaKube().ActiveGate().GetServiceAccountOwner(), BuildCustomPropertiesOwnerName(*newDynaKube(), ""))
+	})
+
+	t.Run("selector labels", func(t *testing.T) {
+		dk := newDynaKube()
+
+		assert.Equal(t, "routing", BuildSelectorLabels(*dk, "routing")[k8slabel.ActiveGateGroupLabel])
+
+		mainSelector := BuildSelectorLabels(*dk, "")
+		require.Contains(t, mainSelector, k8slabel.ActiveGateGroupLabel)
+		assert.Empty(t, mainSelector[k8slabel.ActiveGateGroupLabel])
+
+		dk.Spec.ActiveGate.Groups = nil
+		assert.NotContains(t, BuildSelectorLabels(*dk, ""), k8slabel.ActiveGateGroupLabel)
+	})
+
+	t.Run("DNS entry point", func(t *testing.T) {
+		dk := newDynaKube()
+
+		assert.Equal(t, "https://5.6.7.8:443/communication,https://dynakube-activegate-routing.dynatrace:443/communication", BuildGroupDNSEntryPoint(*dk, "routing"))
+		assert.Empty(t, BuildGroupDNSEntryPoint(*dk, "api"))
+		assert.Equal(t, "https://1.2.3.4:443/communication", BuildDNSEntryPoint(*dk))
+	})
+}
@@ -25,6 +25,9 @@ const (
 	AnnotationActiveGateTenantTokenHash   = api.InternalFlagPrefix + "activegate-tenant-token-hash"
 	AnnotationActiveGateContainerAppArmor = corev1.DeprecatedAppArmorBetaContainerAnnotationKeyPrefix + ActiveGateContainerName
 
+	// AnnotationSyntheticRecommendedReplicas is a hint for autoscalers about the replicas needed for the configured monitor load.
+	AnnotationSyntheticRecommendedReplicas = "activegate.dynatrace.com/synthetic-recommended-replicas"
+
 	EnvDTCapabilities    = "DT_CAPABILITIES"
 	EnvDTIDSeedNamespace = "DT_ID_SEED_NAMESPACE"
 	EnvDTIDSeedClusterID = "DT_ID_SEED_K8S_CLUSTER_ID"
@@ -89,6 +92,15 @@ const (
 	TrustStoreVolumeName       = "truststore-volume"
 	TrustStoreCacertsMountPath = "/opt/dynatrace/gateway/jre/lib/security/cacerts"
 
+	// Volumes: Synthetic browser engine
+	SyntheticEngineContainerName = "synthetic-engine"
+	SyntheticDataVolumeName      = "synthetic-data"
+	SyntheticDataMountPath       = "/var/lib/dynatrace/synthetic"
+	SyntheticShmVolumeName       = "synthetic-shm"
+	SyntheticShmMountPath        = "/dev/shm"
+	SyntheticTmpVolumeName       = "synthetic-tmp"
+	SyntheticTmpMountPath        = "/tmp"
+
 	// Volumes: certificate-loader init container working directory
 	InitCertLoaderWorkDirVolumeName = "cert-tmp"
 	InitCertLoaderWorkDirMountPath  = "/var/lib/dynatrace/gateway"
@@ -111,6 +123,9 @@ var (
 		EECVolumeName,
 		KSPMTokenVolumeName,
 		TrustStoreVolumeName,
+		SyntheticDataVolumeName,
+		SyntheticShmVolumeName,
+		SyntheticTmpVolumeName,
 		InitCertLoaderWorkDirVolumeName,
 		GatewayConfigVolumeName,
 		GatewayLibTempVolumeName,
@@ -133,6 +148,7 @@ var (
 		EECMountPath,
 		KSPMTokenMountPath,
 		TrustStoreCacertsMountPath,
+		SyntheticDataMountPath,
 		GatewayConfigMountPath,
 		GatewayLibTempMountPath,
 		GatewayDataMountPath,
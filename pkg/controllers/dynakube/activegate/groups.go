@@ -0,0 +1,160 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package activegate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/customproperties"
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcileGroups deploys a StatefulSet and Service for every ActiveGate group and removes the ones of removed groups.
+func (r *Reconciler) reconcileGroups(ctx context.Context, dk *dynakube.DynaKube) error {
+	for _, agCapability := range capability.NewGroupCapabilities(dk) {
+		if err := r.reconcileGroup(ctx, dk, agCapability); err != nil {
+			return errors.WithMessagef(err, "could not reconcile ActiveGate group %s", agCapability.GroupName())
+		}
+	}
+
+	return r.removeOutdatedGroups(ctx, dk)
+}
+
+func (r *Reconciler) reconcileGroup(ctx context.Context, dk *dynakube.DynaKube, agCapability capability.Capability) error {
+	groupName := agCapability.GroupName()
+
+	// the custom properties secret is only mounted if there is something to mount, see modifiers.CustomPropertiesModifier
+	if customProperties := agCapability.Properties().CustomProperties; customProperties != nil || dk.NeedsCustomNoProxy() {
+		err := r.customPropertiesReconciler.Reconcile(ctx, dk, capability.BuildCustomPropertiesOwnerName(*dk, groupName), customProperties)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	service := CreateGroupService(dk, groupName)
+
+	err := r.createOrUpdateService(ctx, dk, service)
+	if err != nil {
+		return err
+	}
+
+	serviceIPs, err := r.getServiceIPs(ctx, service)
+	if err != nil {
+		return err
+	}
+
+	if dk.Status.ActiveGate.Groups == nil {
+		dk.Status.ActiveGate.Groups = map[string]activegate.GroupStatus{}
+	}
+
+	// the service IPs are needed to build the StatefulSet, so they are set before it is reconciled
+	groupStatus := dk.Status.ActiveGate.Groups[groupName]
+	groupStatus.StatefulSetName = capability.CalculateGroupStatefulSetName(dk.Name, groupName)
+	groupStatus.ServiceIPs = serviceIPs
+	dk.Status.ActiveGate.Groups[groupName] = groupStatus
+
+	err = r.statefulsetReconciler.Reconcile(ctx, dk, agCapability)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	var sts appsv1.StatefulSet
+
+	err = r.apiReader.Get(ctx, client.ObjectKey{Name: groupStatus.StatefulSetName, Namespace: dk.Namespace}, &sts)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.WithStack(err)
+	}
+
+	groupStatus.Replicas = sts.Status.Replicas
+	groupStatus.ReadyReplicas = sts.Status.ReadyReplicas
+	dk.Status.ActiveGate.Groups[groupName] = groupStatus
+
+	return nil
+}
+
+// removeOutdatedGroups deletes the StatefulSets, Services and custom properties of ActiveGate groups that are no longer part of the DynaKube.
+func (r *Reconciler) removeOutdatedGroups(ctx context.Context, dk *dynakube.DynaKube) error {
+	log := logd.FromContext(ctx)
+
+	appLabels := k8slabel.NewAppLabels(k8slabel.ActiveGateComponentLabel, dk.Name, "", "")
+
+	var statefulSets appsv1.StatefulSetList
+
+	err := r.client.List(ctx, &statefulSets,
+		client.InNamespace(dk.Namespace),
+		client.MatchingLabels(appLabels.BuildMatchLabels()),
+		client.HasLabels{k8slabel.ActiveGateGroupLabel},
+	)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	coreLabels := k8slabel.NewCoreLabels(dk.Name, k8slabel.ActiveGateComponentLabel)
+
+	var services corev1.ServiceList
+
+	err = r.client.List(ctx, &services,
+		client.InNamespace(dk.Namespace),
+		client.MatchingLabels(coreLabels.BuildMatchLabels()),
+		client.HasLabels{k8slabel.ActiveGateGroupLabel},
+	)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	outdatedGroups := map[string]bool{}
+
+	for _, sts := range statefulSets.Items {
+		outdatedGroups[sts.Labels[k8slabel.ActiveGateGroupLabel]] = true
+	}
+
+	for _, svc := range services.Items {
+		outdatedGroups[svc.Labels[k8slabel.ActiveGateGroupLabel]] = true
+	}
+
+	for groupName := range outdatedGroups {
+		// the main ActiveGate is never labeled with a group name, but better safe than sorry
+		if groupName == "" || dk.ActiveGate().GetGroup(groupName) != nil {
+			continue
+		}
+
+		log.Info("removing ActiveGate group", "group", groupName)
+
+		customPropertiesName := fmt.Sprintf("%s-%s-%s", dk.Name, capability.BuildCustomPropertiesOwnerName(*dk, groupName), customproperties.Suffix)
+		groupObjects := []client.Object{
+			&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: capability.CalculateGroupStatefulSetName(dk.Name, groupName), Namespace: dk.Namespace}},
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: capability.BuildGroupServiceName(dk.Name, groupName), Namespace: dk.Namespace}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: customPropertiesName, Namespace: dk.Namespace}},
+		}
+
+		for _, obj := range groupObjects {
+			if err := r.client.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+				return errors.WithStack(err)
+			}
+		}
+	}
+
+	for groupName := range dk.Status.ActiveGate.Groups {
+		if dk.ActiveGate().GetGroup(groupName) == nil {
+			delete(dk.Status.ActiveGate.Groups, groupName)
+		}
+	}
+
+	if len(dk.Status.ActiveGate.Groups) == 0 {
+		dk.Status.ActiveGate.Groups = nil
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package activegate
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestReconcileGroups(t *testing.T) {
+	newDynaKube := func(groups ...activegate.GroupSpec) *dynakube.DynaKube {
+		return &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testName},
+			Spec: dynakube.DynaKubeSpec{
+				ActiveGate: activegate.Spec{
+					Capabilities: []activegate.CapabilityDisplayName{activegate.KubeMonCapability.DisplayName},
+					Groups:       groups,
+				},
+			},
+		}
+	}
+	routingGroup := activegate.GroupSpec{
+		Name:         "routing",
+		Capabilities: []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName},
+	}
+	groupStatefulSet := func(groupName string) *appsv1.StatefulSet {
+		appLabels := k8slabel.NewAppLabels(k8slabel.ActiveGateComponentLabel, testName, "", "")
+		labels := appLabels.BuildLabels()
+		labels[k8slabel.ActiveGateGroupLabel] = groupName
+
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      capability.CalculateGroupStatefulSetName(testName, groupName),
+				Namespace: testNamespace,
+				Labels:    labels,
+			},
+			Status: appsv1.StatefulSetStatus{Replicas: 2, ReadyReplicas: 1},
+		}
+	}
+
+	t.Run("creates service and statefulset per group", func(t *testing.T) {
+		dk := newDynaKube(routingGroup)
+		clt := fake.NewClient(groupStatefulSet(routingGroup.Name))
+
+		statefulsetReconciler := newMockStatefulsetReconciler(t)
+		statefulsetReconciler.EXPECT().Reconcile(anyCtx, anyDynakube, mock.MatchedBy(func(agCapability capability.Capability) bool {
+			return agCapability.GroupName() == routingGroup.Name
+		})).Return(nil).Once()
+
+		r := &Reconciler{
+			client:                clt,
+			apiReader:             clt,
+			statefulsetReconciler: statefulsetReconciler,
+		}
+
+		err := r.reconcileGroups(t.Context(), dk)
+		require.NoError(t, err)
+
+		var service corev1.Service
+
+		err = clt.Get(t.Context(), client.ObjectKey{Name: testServiceName + "-routing", Namespace: testNamespace}, &service)
+		require.NoError(t, err)
+		assert.Equal(t, "routing", service.Labels[k8slabel.ActiveGateGroupLabel])
+		assert.Equal(t, "routing", service.Spec.Selector[k8slabel.ActiveGateGroupLabel])
+
+		require.Contains(t, dk.Status.ActiveGate.Groups, "routing")
+		groupStatus := dk.Status.ActiveGate.Groups["routing"]
+		assert.Equal(t, testName+"-activegate-routing", groupStatus.StatefulSetName)
+		assert.Equal(t, int32(2), groupStatus.Replicas)
+		assert.Equal(t, int32(1), groupStatus.ReadyReplicas)
+	})
+
+	t.Run("removes outdated groups", func(t *testing.T) {
+		dk := newDynaKube()
+		dk.Status.ActiveGate.Groups = map[string]activegate.GroupStatus{"routing": {}}
+
+		service := CreateGroupService(newDynaKube(routingGroup), routingGroup.Name)
+		clt := fake.NewClient(groupStatefulSet(routingGroup.Name), service)
+
+		r := &Reconciler{
+			client:    clt,
+			apiReader: clt,
+		}
+
+		err := r.reconcileGroups(t.Context(), dk)
+		require.NoError(t, err)
+
+		err = clt.Get(t.Context(), client.ObjectKeyFromObject(service), &corev1.Service{})
+		assert.True(t, k8serrors.IsNotFound(err))
+
+		err = clt.Get(t.Context(), client.ObjectKey{Name: testName + "-activegate-routing", Namespace: testNamespace}, &appsv1.StatefulSet{})
+		assert.True(t, k8serrors.IsNotFound(err))
+
+		assert.Nil(t, dk.Status.ActiveGate.Groups)
+	})
+}
@@ -5,6 +5,7 @@ package modifiers
 
 import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/statefulset/builder"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8scontainer"
@@ -16,18 +17,20 @@ var _ volumeModifier = CertificatesModifier{}
 var _ volumeMountModifier = CertificatesModifier{}
 var _ builder.Modifier = CertificatesModifier{}
 
-func NewCertificatesModifier(dk dynakube.DynaKube) CertificatesModifier {
+func NewCertificatesModifier(dk dynakube.DynaKube, capability capability.Capability) CertificatesModifier {
 	return CertificatesModifier{
-		dk: dk,
+		dk:         dk,
+		capability: capability,
 	}
 }
 
 type CertificatesModifier struct {
-	dk dynakube.DynaKube
+	capability capability.Capability
+	dk         dynakube.DynaKube
 }
 
 func (mod CertificatesModifier) Enabled() bool {
-	return mod.dk.ActiveGate().HasCaCert()
+	return mod.dk.ActiveGate().IsEnabled() && mod.tlsSecretName() != ""
 }
 
 func (mod CertificatesModifier) Modify(sts *appsv1.StatefulSet) error {
@@ -44,7 +47,7 @@ func (mod CertificatesModifier) getVolumes() []corev1.Volume {
 			Name: consts.CertsVolumeName,
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
-					SecretName:  mod.dk.ActiveGate().GetTLSSecretName(),
+					SecretName:  mod.tlsSecretName(),
 					DefaultMode: new(int32(0o640)),
 				},
 			},
@@ -61,3 +64,7 @@ func (mod CertificatesModifier) getVolumeMounts() []corev1.VolumeMount {
 		},
 	}
 }
+
+func (mod CertificatesModifier) tlsSecretName() string {
+	return mod.dk.ActiveGate().GetGroupTLSSecretName(mod.capability.GroupName())
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/exp"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -33,7 +35,7 @@ func TestCertEnabled(t *testing.T) {
 		enableKubeMonCapability(&dk)
 		setCertUsage(&dk, true)
 
-		mod := NewCertificatesModifier(dk)
+		mod := NewCertificatesModifier(dk, capability.NewMultiCapability(&dk))
 
 		assert.True(t, mod.Enabled())
 	})
@@ -44,7 +46,7 @@ func TestCertEnabled(t *testing.T) {
 		enableKubeMonCapability(&dk)
 		setCertUsage(&dk, false)
 
-		mod := NewCertificatesModifier(dk)
+		mod := NewCertificatesModifier(dk, capability.NewMultiCapability(&dk))
 
 		assert.False(t, mod.Enabled())
 	})
@@ -54,7 +56,7 @@ func TestCertEnabled(t *testing.T) {
 		enableKubeMonCapability(&dk)
 		setCertUsage(&dk, true)
 
-		mod := NewCertificatesModifier(dk)
+		mod := NewCertificatesModifier(dk, capability.NewMultiCapability(&dk))
 
 		assert.True(t, mod.Enabled())
 	})
@@ -64,7 +66,7 @@ func TestCertEnabled(t *testing.T) {
 		enableKubeMonCapability(&dk)
 		setCertUsage(&dk, false)
 
-		mod := NewCertificatesModifier(dk)
+		mod := NewCertificatesModifier(dk, capability.NewMultiCapability(&dk))
 
 		assert.True(t, mod.Enabled())
 	})
@@ -75,7 +77,7 @@ func TestCertModify(t *testing.T) {
 		dk := getBaseDynakube()
 		enableKubeMonCapability(&dk)
 		setCertUsage(&dk, true)
-		mod := NewCertificatesModifier(dk)
+		mod := NewCertificatesModifier(dk, capability.NewMultiCapability(&dk))
 		builder := createBuilderForTesting()
 
 		sts, _ := builder.AddModifier(mod).Build()
@@ -85,3 +87,35 @@ func TestCertModify(t *testing.T) {
 		isSubset(t, mod.getVolumeMounts(), sts.Spec.Template.Spec.Containers[0].VolumeMounts)
 	})
 }
+
+func TestGroupCert(t *testing.T) {
+	dk := getBaseDynakube()
+	disableAutomaticAGCertificate(&dk)
+	dk.Spec.ActiveGate.Groups = []activegate.GroupSpec{
+		{Name: "routing", Capabilities: []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName}, TLSSecretName: "routing-tls"},
+	}
+
+	t.Run("group uses its own TLS secret", func(t *testing.T) {
+		mod := NewCertificatesModifier(dk, capability.NewGroupCapabilities(&dk)[0])
+
+		require.True(t, mod.Enabled())
+		assert.Equal(t, "routing-tls", mod.getVolumes()[0].Secret.SecretName)
+	})
+
+	t.Run("default ActiveGate doesn't use the TLS secret of a group", func(t *testing.T) {
+		mod := NewCertificatesModifier(dk, capability.NewMultiCapability(&dk))
+
+		assert.False(t, mod.Enabled())
+	})
+
+	t.Run("group falls back to the ActiveGate TLS secret", func(t *testing.T) {
+		dk := *dk.DeepCopy()
+		dk.Spec.ActiveGate.Groups[0].TLSSecretName = ""
+		setCertUsage(&dk, true)
+
+		mod := NewCertificatesModifier(dk, capability.NewGroupCapabilities(&dk)[0])
+
+		require.True(t, mod.Enabled())
+		assert.Equal(t, testTLSSecretName, mod.getVolumes()[0].Secret.SecretName)
+	})
+}
@@ -30,10 +30,10 @@ type initContainerModifier interface {
 }
 
 func GenerateAllModifiers(dk dynakube.DynaKube, capability capability.Capability, agBaseContainerEnvMap *prioritymap.Map) []builder.Modifier {
-	return []builder.Modifier{
+	mods := []builder.Modifier{
 		NewAuthTokenModifier(dk),
-		NewSSLVolumeModifier(dk),
-		NewCertificatesModifier(dk),
+		NewSSLVolumeModifier(dk, capability),
+		NewCertificatesModifier(dk, capability),
 		NewTrustedCAsVolumeModifier(dk),
 		NewDeploymentPropertiesModifier(dk),
 		NewCustomPropertiesModifier(dk, capability),
@@ -41,8 +41,17 @@ func GenerateAllModifiers(dk dynakube.DynaKube, capability capability.Capability
 		NewRawImageModifier(dk, agBaseContainerEnvMap),
 		NewReadOnlyModifier(dk),
 		NewServicePortModifier(dk, capability, agBaseContainerEnvMap),
-		NewKubernetesMonitoringModifier(dk, capability),
-		NewEECVolumeModifier(dk),
-		NewKSPMModifier(dk),
+		NewSyntheticModifier(dk, capability),
 	}
+
+	// Kubernetes monitoring, extensions and KSPM are only served by the ActiveGate configured in spec.activeGate
+	if capability.GroupName() == "" {
+		mods = append(mods,
+			NewKubernetesMonitoringModifier(dk, capability),
+			NewEECVolumeModifier(dk),
+			NewKSPMModifier(dk),
+		)
+	}
+
+	return mods
 }
@@ -128,3 +128,22 @@ func TestNoConflict(t *testing.T) {
 		}
 	})
 }
+
+func TestGroupModifiers(t *testing.T) {
+	dk := getBaseDynakube()
+	enableKubeMonCapability(&dk)
+	dk.Spec.ActiveGate.Groups = []activegate.GroupSpec{
+		{Name: "routing", Capabilities: []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName}},
+	}
+
+	groupCapability := capability.NewGroupCapabilities(&dk)[0]
+	mods := GenerateAllModifiers(dk, groupCapability, prioritymap.New())
+
+	for _, mod := range mods {
+		assert.IsNotType(t, KubernetesMonitoringModifier{}, mod)
+		assert.IsNotType(t, EECModifier{}, mod)
+		assert.IsNotType(t, KSPMModifier{}, mod)
+	}
+
+	assert.Len(t, GenerateAllModifiers(dk, capability.NewMultiCapability(&dk), prioritymap.New()), len(mods)+3)
+}
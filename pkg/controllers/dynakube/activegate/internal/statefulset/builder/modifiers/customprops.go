@@ -81,5 +81,5 @@ func (mod CustomPropertiesModifier) hasCustomProperties() bool {
 }
 
 func (mod CustomPropertiesModifier) determineCustomPropertiesSource() string {
-	return fmt.Sprintf("%s-%s-%s", mod.dk.Name, mod.dk.ActiveGate().GetServiceAccountOwner(), customproperties.Suffix)
+	return fmt.Sprintf("%s-%s-%s", mod.dk.Name, capability.BuildCustomPropertiesOwnerName(mod.dk, mod.capability.GroupName()), customproperties.Suffix)
 }
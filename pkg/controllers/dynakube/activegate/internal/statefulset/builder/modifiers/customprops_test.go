@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/exp"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/value"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
 	"github.com/stretchr/testify/assert"
@@ -83,3 +84,23 @@ func TestCustomPropertyModify(t *testing.T) {
 		isSubset(t, mod.getVolumeMounts(), sts.Spec.Template.Spec.Containers[0].VolumeMounts)
 	})
 }
+
+func TestGroupCustomPropertySource(t *testing.T) {
+	dk := getBaseDynakube()
+	enableKubeMonCapability(&dk)
+	dk.Spec.ActiveGate.Groups = []activegate.GroupSpec{
+		{
+			Name:             "routing",
+			Capabilities:     []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName},
+			CustomProperties: &value.Source{Value: "test"},
+		},
+	}
+
+	defaultMod := NewCustomPropertiesModifier(dk, capability.NewMultiCapability(&dk))
+	groupMod := NewCustomPropertiesModifier(dk, capability.NewGroupCapabilities(&dk)[0])
+
+	assert.False(t, defaultMod.Enabled())
+	assert.True(t, groupMod.Enabled())
+	assert.Equal(t, testDynakubeName+"-kubernetes-monitoring-custom-properties", defaultMod.determineCustomPropertiesSource())
+	assert.Equal(t, testDynakubeName+"-activegate-routing-custom-properties", groupMod.determineCustomPropertiesSource())
+}
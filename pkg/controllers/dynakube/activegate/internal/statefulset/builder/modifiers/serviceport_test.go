@@ -6,11 +6,13 @@ package modifiers
 import (
 	"testing"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/prioritymap"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func TestServicePortModify(t *testing.T) {
@@ -31,3 +33,20 @@ func TestServicePortModify(t *testing.T) {
 		assert.Equal(t, consts.HTTPSServicePortName, container.ReadinessProbe.HTTPGet.Port.StrVal)
 	})
 }
+
+func TestGroupServicePortDNSEntryPoint(t *testing.T) {
+	dk := getBaseDynakube()
+	dk.Spec.ActiveGate.Groups = []activegate.GroupSpec{
+		{Name: "routing", Capabilities: []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName}},
+	}
+	dk.Status.ActiveGate.Groups = map[string]activegate.GroupStatus{
+		"routing": {ServiceIPs: []string{"1.2.3.4"}},
+	}
+
+	mod := NewServicePortModifier(dk, capability.NewGroupCapabilities(&dk)[0], prioritymap.New())
+
+	assert.Contains(t, mod.getEnvs(), corev1.EnvVar{
+		Name:  consts.EnvDTDNSEntryPoint,
+		Value: "https://1.2.3.4:443/communication,https://testDk-activegate-routing.testNs:443/communication",
+	})
+}
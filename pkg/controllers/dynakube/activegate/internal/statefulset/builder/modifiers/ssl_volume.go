@@ -5,6 +5,7 @@ package modifiers
 
 import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/statefulset/builder"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8scontainer"
@@ -16,18 +17,20 @@ var _ volumeModifier = SSLVolumeModifier{}
 var _ volumeMountModifier = SSLVolumeModifier{}
 var _ builder.Modifier = SSLVolumeModifier{}
 
-func NewSSLVolumeModifier(dk dynakube.DynaKube) SSLVolumeModifier {
+func NewSSLVolumeModifier(dk dynakube.DynaKube, capability capability.Capability) SSLVolumeModifier {
 	return SSLVolumeModifier{
-		dk: dk,
+		dk:         dk,
+		capability: capability,
 	}
 }
 
 type SSLVolumeModifier struct {
-	dk dynakube.DynaKube
+	capability capability.Capability
+	dk         dynakube.DynaKube
 }
 
 func (mod SSLVolumeModifier) Enabled() bool {
-	return mod.dk.ActiveGate().HasCaCert() || mod.dk.Spec.TrustedCAs != ""
+	return NewCertificatesModifier(mod.dk, mod.capability).Enabled() || mod.dk.Spec.TrustedCAs != ""
 }
 
 func (mod SSLVolumeModifier) Modify(sts *appsv1.StatefulSet) error {
@@ -6,6 +6,7 @@ package modifiers
 import (
 	"testing"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -17,7 +18,7 @@ func TestSSLVolumeEnabled(t *testing.T) {
 		enableKubeMonCapability(&dk)
 		dk.Spec.ActiveGate.TLSSecretName = testTLSSecretName
 
-		mod := NewSSLVolumeModifier(dk)
+		mod := NewSSLVolumeModifier(dk, capability.NewMultiCapability(&dk))
 
 		assert.True(t, mod.Enabled())
 	})
@@ -28,7 +29,7 @@ func TestSSLVolumeEnabled(t *testing.T) {
 		enableKubeMonCapability(&dk)
 		dk.Spec.TrustedCAs = testTLSSecretName
 
-		mod := NewSSLVolumeModifier(dk)
+		mod := NewSSLVolumeModifier(dk, capability.NewMultiCapability(&dk))
 
 		assert.True(t, mod.Enabled())
 	})
@@ -38,7 +39,7 @@ func TestSSLVolumeEnabled(t *testing.T) {
 		disableAutomaticAGCertificate(&dk)
 		enableKubeMonCapability(&dk)
 
-		mod := NewSSLVolumeModifier(dk)
+		mod := NewSSLVolumeModifier(dk, capability.NewMultiCapability(&dk))
 
 		assert.False(t, mod.Enabled())
 	})
@@ -48,7 +49,7 @@ func TestSSLVolumeEnabled(t *testing.T) {
 		enableKubeMonCapability(&dk)
 		dk.Spec.ActiveGate.TLSSecretName = testTLSSecretName
 
-		mod := NewSSLVolumeModifier(dk)
+		mod := NewSSLVolumeModifier(dk, capability.NewMultiCapability(&dk))
 
 		assert.True(t, mod.Enabled())
 	})
@@ -58,7 +59,7 @@ func TestSSLVolumeEnabled(t *testing.T) {
 		enableKubeMonCapability(&dk)
 		dk.Spec.TrustedCAs = testTLSSecretName
 
-		mod := NewSSLVolumeModifier(dk)
+		mod := NewSSLVolumeModifier(dk, capability.NewMultiCapability(&dk))
 
 		assert.True(t, mod.Enabled())
 	})
@@ -67,7 +68,7 @@ func TestSSLVolumeEnabled(t *testing.T) {
 		dk := getBaseDynakube()
 		enableKubeMonCapability(&dk)
 
-		mod := NewSSLVolumeModifier(dk)
+		mod := NewSSLVolumeModifier(dk, capability.NewMultiCapability(&dk))
 
 		assert.True(t, mod.Enabled())
 	})
@@ -79,7 +80,7 @@ func TestSSLVolumeModify(t *testing.T) {
 		enableKubeMonCapability(&dl)
 		dl.Spec.ActiveGate.TLSSecretName = testTLSSecretName
 
-		mod := NewSSLVolumeModifier(dl)
+		mod := NewSSLVolumeModifier(dl, capability.NewMultiCapability(&dl))
 		builder := createBuilderForTesting()
 
 		sts, _ := builder.AddModifier(mod).Build()
@@ -0,0 +1,121 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package modifiers
+
+import (
+	"strconv"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/statefulset/builder"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/synthetic"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8scontainer"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ volumeModifier = SyntheticModifier{}
+var _ volumeMountModifier = SyntheticModifier{}
+var _ builder.Modifier = SyntheticModifier{}
+
+func NewSyntheticModifier(dk dynakube.DynaKube, capability capability.Capability) SyntheticModifier {
+	return SyntheticModifier{
+		dk:         dk,
+		capability: capability,
+	}
+}
+
+// SyntheticModifier adds the Synthetic browser engine next to the ActiveGate with the synthetic capability.
+type SyntheticModifier struct {
+	capability capability.Capability
+	dk         dynakube.DynaKube
+}
+
+func (mod SyntheticModifier) Enabled() bool {
+	return mod.dk.ActiveGate().IsGroupMode(mod.capability.GroupName(), activegate.SyntheticCapability.DisplayName)
+}
+
+func (mod SyntheticModifier) Modify(sts *appsv1.StatefulSet) error {
+	if sts.Annotations == nil {
+		sts.Annotations = map[string]string{}
+	}
+
+	sts.Annotations[consts.AnnotationSyntheticRecommendedReplicas] = strconv.Itoa(int(synthetic.RecommendedReplicas(mod.dk)))
+
+	sts.Spec.Template.Spec.Volumes = append(sts.Spec.Template.Spec.Volumes, mod.getVolumes()...)
+	baseContainer := k8scontainer.FindInPodSpec(&sts.Spec.Template.Spec, consts.ActiveGateContainerName)
+	baseContainer.VolumeMounts = append(baseContainer.VolumeMounts, mod.getVolumeMounts()...)
+
+	// the browser engine needs a bigger ActiveGate than the defaults, unless resources are set by the user
+	if len(baseContainer.Resources.Requests) == 0 && len(baseContainer.Resources.Limits) == 0 {
+		baseContainer.Resources = synthetic.ActiveGateResources(mod.dk)
+	}
+
+	sts.Spec.Template.Spec.Containers = append(sts.Spec.Template.Spec.Containers, mod.getEngineContainer())
+
+	return nil
+}
+
+func (mod SyntheticModifier) getEngineContainer() corev1.Container {
+	return corev1.Container{
+		Name:            consts.SyntheticEngineContainerName,
+		Image:           mod.dk.ActiveGate().GetSyntheticEngineImage(),
+		ImagePullPolicy: mod.dk.ActiveGate().ImagePullPolicy,
+		Resources:       synthetic.EngineResources(mod.dk),
+		SecurityContext: GetSecurityContext(true),
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      consts.SyntheticDataVolumeName,
+				MountPath: consts.SyntheticDataMountPath,
+			},
+			{
+				Name:      consts.SyntheticShmVolumeName,
+				MountPath: consts.SyntheticShmMountPath,
+			},
+			{
+				Name:      consts.SyntheticTmpVolumeName,
+				MountPath: consts.SyntheticTmpMountPath,
+			},
+		},
+	}
+}
+
+func (mod SyntheticModifier) getVolumes() []corev1.Volume {
+	shmSizeLimit := synthetic.ShmSizeLimit(mod.dk)
+
+	return []corev1.Volume{
+		{
+			Name: consts.SyntheticDataVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+		{
+			Name: consts.SyntheticShmVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{
+					Medium:    corev1.StorageMediumMemory,
+					SizeLimit: &shmSizeLimit,
+				},
+			},
+		},
+		{
+			Name: consts.SyntheticTmpVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+}
+
+func (mod SyntheticModifier) getVolumeMounts() []corev1.VolumeMount {
+	return []corev1.VolumeMount{
+		{
+			Name:      consts.SyntheticDataVolumeName,
+			MountPath: consts.SyntheticDataMountPath,
+		},
+	}
+}
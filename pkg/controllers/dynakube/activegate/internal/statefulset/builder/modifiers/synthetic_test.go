@@ -0,0 +1,105 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package modifiers
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/synthetic"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8scontainer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestSyntheticEnabled(t *testing.T) {
+	t.Run("true", func(t *testing.T) {
+		dk := getBaseDynakube()
+		dk.Spec.ActiveGate.Capabilities = []activegate.CapabilityDisplayName{activegate.SyntheticCapability.DisplayName}
+
+		mod := NewSyntheticModifier(dk, capability.NewMultiCapability(&dk))
+
+		assert.True(t, mod.Enabled())
+	})
+
+	t.Run("false", func(t *testing.T) {
+		dk := getBaseDynakube()
+		enableKubeMonCapability(&dk)
+
+		mod := NewSyntheticModifier(dk, capability.NewMultiCapability(&dk))
+
+		assert.False(t, mod.Enabled())
+	})
+
+	t.Run("only for the group with the capability", func(t *testing.T) {
+		dk := getBaseDynakube()
+		enableKubeMonCapability(&dk)
+		dk.Spec.ActiveGate.Groups = []activegate.GroupSpec{
+			{Name: "synthetic", Capabilities: []activegate.CapabilityDisplayName{activegate.SyntheticCapability.DisplayName}},
+		}
+
+		assert.False(t, NewSyntheticModifier(dk, capability.NewMultiCapability(&dk)).Enabled())
+		assert.True(t, NewSyntheticModifier(dk, capability.NewGroupCapabilities(&dk)[0]).Enabled())
+	})
+}
+
+func TestSyntheticModify(t *testing.T) {
+	t.Run("successfully modified", func(t *testing.T) {
+		dk := getBaseDynakube()
+		dk.Spec.ActiveGate.Capabilities = []activegate.CapabilityDisplayName{activegate.SyntheticCapability.DisplayName}
+		dk.Spec.ActiveGate.Synthetic = &activegate.SyntheticSpec{
+			LoadProfile:                activegate.SyntheticLoadProfileXS,
+			MonitorExecutionsPerMinute: new(int32(35)),
+		}
+		mod := NewSyntheticModifier(dk, capability.NewMultiCapability(&dk))
+		builder := createBuilderForTesting()
+
+		sts, err := builder.AddModifier(mod).Build()
+		require.NoError(t, err)
+
+		isSubset(t, mod.getVolumes(), sts.Spec.Template.Spec.Volumes)
+
+		agContainer := k8scontainer.FindInPodSpec(&sts.Spec.Template.Spec, consts.ActiveGateContainerName)
+		require.NotNil(t, agContainer)
+		isSubset(t, mod.getVolumeMounts(), agContainer.VolumeMounts)
+		assert.Equal(t, synthetic.ActiveGateResources(dk), agContainer.Resources)
+
+		engineContainer := k8scontainer.FindInPodSpec(&sts.Spec.Template.Spec, consts.SyntheticEngineContainerName)
+		require.NotNil(t, engineContainer)
+		assert.Equal(t, dk.ActiveGate().GetSyntheticEngineImage(), engineContainer.Image)
+		assert.Equal(t, synthetic.EngineResources(dk), engineContainer.Resources)
+		assert.True(t, *engineContainer.SecurityContext.ReadOnlyRootFilesystem)
+
+		assert.Equal(t, "4", sts.Annotations[consts.AnnotationSyntheticRecommendedReplicas])
+	})
+
+	t.Run("user resources are kept", func(t *testing.T) {
+		dk := getBaseDynakube()
+		dk.Spec.ActiveGate.Capabilities = []activegate.CapabilityDisplayName{activegate.SyntheticCapability.DisplayName}
+		mod := NewSyntheticModifier(dk, capability.NewMultiCapability(&dk))
+		resources := corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+		}
+		sts := appsv1.StatefulSet{
+			Spec: appsv1.StatefulSetSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: consts.ActiveGateContainerName, Resources: resources}},
+					},
+				},
+			},
+		}
+
+		err := mod.Modify(&sts)
+		require.NoError(t, err)
+
+		assert.Equal(t, resources, sts.Spec.Template.Spec.Containers[0].Resources)
+		assert.Len(t, sts.Spec.Template.Spec.Containers, 2)
+	})
+}
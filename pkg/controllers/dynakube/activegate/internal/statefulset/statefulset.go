@@ -16,6 +16,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/deploymentmetadata"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8saffinity"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8spodtemplate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8ssecuritycontext"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8sstatefulset"
 	maputils "github.com/Dynatrace/dynatrace-operator/pkg/util/map"
@@ -56,8 +57,15 @@ func (statefulSetBuilder Builder) CreateStatefulSet() (*appsv1.StatefulSet, erro
 	activeGateBuilder := builder.NewBuilder(statefulSetBuilder.getBase())
 	mods := modifiers.GenerateAllModifiers(statefulSetBuilder.dynakube, statefulSetBuilder.capability, statefulSetBuilder.envMap)
 	sts, err := activeGateBuilder.AddModifier(mods...).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := k8spodtemplate.Apply(&sts.Spec.Template, statefulSetBuilder.capability.Properties().PodTemplatePatch); err != nil {
+		return nil, err
+	}
 
-	return &sts, err
+	return &sts, nil
 }
 
 func (statefulSetBuilder Builder) getBase() appsv1.StatefulSet {
@@ -80,7 +88,7 @@ func (statefulSetBuilder Builder) getBase() appsv1.StatefulSet {
 
 func (statefulSetBuilder Builder) getBaseObjectMeta() metav1.ObjectMeta {
 	return metav1.ObjectMeta{
-		Name:        statefulSetBuilder.dynakube.Name + "-" + consts.MultiActiveGateName,
+		Name:        capability.CalculateGroupStatefulSetName(statefulSetBuilder.dynakube.Name, statefulSetBuilder.capability.GroupName()),
 		Namespace:   statefulSetBuilder.dynakube.Namespace,
 		Annotations: map[string]string{},
 	}
@@ -107,9 +115,20 @@ func (statefulSetBuilder Builder) getBaseSpec() appsv1.StatefulSetSpec {
 
 func (statefulSetBuilder Builder) addLabels(sts *appsv1.StatefulSet) {
 	appLabels := statefulSetBuilder.buildAppLabels()
+	selectorLabels := statefulSetBuilder.buildSelectorLabels()
 	sts.Labels = appLabels.BuildLabels()
 	sts.Spec.Selector = &metav1.LabelSelector{MatchLabels: appLabels.BuildMatchLabels()}
-	sts.Spec.Template.Labels = maputils.MergeMap(statefulSetBuilder.capability.Properties().Labels, appLabels.BuildLabels())
+	sts.Spec.Template.Labels = maputils.MergeMap(statefulSetBuilder.capability.Properties().Labels, appLabels.BuildLabels(), selectorLabels)
+
+	// the selector of a StatefulSet is immutable, so only the StatefulSets of groups select by the group label
+	if groupName := statefulSetBuilder.capability.GroupName(); groupName != "" {
+		sts.Labels[k8slabel.ActiveGateGroupLabel] = groupName
+		sts.Spec.Selector.MatchLabels = selectorLabels
+	}
+}
+
+func (statefulSetBuilder Builder) buildSelectorLabels() map[string]string {
+	return capability.BuildSelectorLabels(statefulSetBuilder.dynakube, statefulSetBuilder.capability.GroupName())
 }
 
 func (statefulSetBuilder Builder) buildAppLabels() *k8slabel.AppLabels {
@@ -194,7 +213,7 @@ func (statefulSetBuilder Builder) buildPodSecurityContext() *corev1.PodSecurityC
 }
 
 func (statefulSetBuilder Builder) defaultTopologyConstraints() []corev1.TopologySpreadConstraint {
-	appLabels := statefulSetBuilder.buildAppLabels()
+	selectorLabels := statefulSetBuilder.buildSelectorLabels()
 	nodeInclusionPolicyHonor := corev1.NodeInclusionPolicyHonor
 
 	return []corev1.TopologySpreadConstraint{
@@ -202,14 +221,14 @@ func (statefulSetBuilder Builder) defaultTopologyConstraints() []corev1.Topology
 			MaxSkew:           1,
 			TopologyKey:       corev1.LabelTopologyZone,
 			WhenUnsatisfiable: corev1.ScheduleAnyway,
-			LabelSelector:     &metav1.LabelSelector{MatchLabels: appLabels.BuildMatchLabels()},
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: selectorLabels},
 		},
 		{
 			MaxSkew:           1,
 			TopologyKey:       corev1.LabelHostname,
 			WhenUnsatisfiable: corev1.ScheduleAnyway,
 			NodeTaintsPolicy:  &nodeInclusionPolicyHonor,
-			LabelSelector:     &metav1.LabelSelector{MatchLabels: appLabels.BuildMatchLabels()},
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: selectorLabels},
 		},
 	}
 }
@@ -234,7 +253,11 @@ func (statefulSetBuilder Builder) buildBaseContainer(sts *appsv1.StatefulSet) []
 }
 
 func (statefulSetBuilder Builder) buildResources() corev1.ResourceRequirements {
-	return statefulSetBuilder.capability.Properties().Resources
+	if statefulSetBuilder.capability.GroupName() != "" {
+		return statefulSetBuilder.capability.Properties().Resources
+	}
+
+	return statefulSetBuilder.dynakube.GetRemediatedResources(dynakube.ActiveGateComponent)
 }
 
 func (statefulSetBuilder Builder) buildCommonEnvs() []corev1.EnvVar {
@@ -258,8 +281,8 @@ func (statefulSetBuilder Builder) buildCommonEnvs() []corev1.EnvVar {
 		prioritymap.Append(statefulSetBuilder.envMap, corev1.EnvVar{Name: consts.EnvDTGroup, Value: statefulSetBuilder.capability.Properties().Group})
 	}
 
-	if statefulSetBuilder.dynakube.Spec.NetworkZone != "" {
-		prioritymap.Append(statefulSetBuilder.envMap, corev1.EnvVar{Name: consts.EnvDTNetworkZone, Value: statefulSetBuilder.dynakube.Spec.NetworkZone})
+	if networkZone := statefulSetBuilder.networkZone(); networkZone != "" {
+		prioritymap.Append(statefulSetBuilder.envMap, corev1.EnvVar{Name: consts.EnvDTNetworkZone, Value: networkZone})
 	}
 
 	prioritymap.Append(statefulSetBuilder.envMap, statefulSetBuilder.capability.Properties().Env, prioritymap.WithPriority(customEnvPriority))
@@ -267,6 +290,16 @@ func (statefulSetBuilder Builder) buildCommonEnvs() []corev1.EnvVar {
 	return statefulSetBuilder.envMap.AsEnvVars()
 }
 
+// networkZone returns the network zone of the topology zone the ActiveGate group is pinned to, or spec.networkZone.
+func (statefulSetBuilder Builder) networkZone() string {
+	var topologyZone string
+	if group := statefulSetBuilder.dynakube.ActiveGate().GetGroup(statefulSetBuilder.capability.GroupName()); group != nil {
+		topologyZone = group.TopologyZone
+	}
+
+	return statefulSetBuilder.dynakube.GetTopologyNetworkZone(topologyZone)
+}
+
 func (statefulSetBuilder Builder) nodeAffinity() *corev1.Affinity {
 	var affinity corev1.Affinity
 	if statefulSetBuilder.dynakube.Status.ActiveGate.Source == status.TenantRegistryVersionSource || statefulSetBuilder.dynakube.Status.ActiveGate.Source == status.CustomVersionVersionSource {
@@ -28,6 +28,7 @@ import (
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -225,6 +226,69 @@ func TestAddLabels(t *testing.T) {
 	})
 }
 
+func TestGroupStatefulSet(t *testing.T) {
+	t.Cleanup(version.DisableCacheForTest(123))
+
+	dk := getTestDynakube()
+	dk.Spec.ActiveGate.Groups = []activegate.GroupSpec{
+		{
+			Name:         "api",
+			Capabilities: []activegate.CapabilityDisplayName{activegate.DynatraceAPICapability.DisplayName},
+			Resources: &corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			},
+		},
+	}
+
+	t.Run("group has its own name, labels and resources", func(t *testing.T) {
+		groupCapability := capability.NewGroupCapabilities(&dk)[0]
+		builder := NewStatefulSetBuilder(testKubeUID, testConfigHash, dk, groupCapability)
+
+		sts, err := builder.CreateStatefulSet()
+		require.NoError(t, err)
+
+		assert.Equal(t, testDynakubeName+"-activegate-api", sts.Name)
+		assert.Equal(t, "api", sts.Labels[k8slabel.ActiveGateGroupLabel])
+		assert.Equal(t, "api", sts.Spec.Selector.MatchLabels[k8slabel.ActiveGateGroupLabel])
+		assert.Equal(t, "api", sts.Spec.Template.Labels[k8slabel.ActiveGateGroupLabel])
+		assert.Equal(t, *dk.Spec.ActiveGate.Groups[0].Resources, sts.Spec.Template.Spec.Containers[0].Resources)
+	})
+
+	t.Run("main ActiveGate keeps its selector", func(t *testing.T) {
+		builder := NewStatefulSetBuilder(testKubeUID, testConfigHash, dk, capability.NewMultiCapability(&dk))
+
+		sts, err := builder.CreateStatefulSet()
+		require.NoError(t, err)
+
+		assert.Equal(t, testDynakubeName+"-activegate", sts.Name)
+		assert.NotContains(t, sts.Spec.Selector.MatchLabels, k8slabel.ActiveGateGroupLabel)
+		require.Contains(t, sts.Spec.Template.Labels, k8slabel.ActiveGateGroupLabel)
+		assert.Empty(t, sts.Spec.Template.Labels[k8slabel.ActiveGateGroupLabel])
+	})
+
+	t.Run("group pinned to a topology zone uses its network zone", func(t *testing.T) {
+		dk := dk.DeepCopy()
+		dk.Spec.NetworkZone = "default"
+		dk.Spec.TopologyZones = []communication.TopologyZone{{Zone: "zone-a", NetworkZone: "network-zone-a"}}
+		dk.Spec.ActiveGate.Groups[0].TopologyZone = "zone-a"
+
+		groupSts, err := NewStatefulSetBuilder(testKubeUID, testConfigHash, *dk, capability.NewGroupCapabilities(dk)[0]).CreateStatefulSet()
+		require.NoError(t, err)
+
+		assert.Equal(t, "zone-a", groupSts.Spec.Template.Spec.NodeSelector[corev1.LabelTopologyZone])
+		networkZoneEnv := k8senv.Find(groupSts.Spec.Template.Spec.Containers[0].Env, consts.EnvDTNetworkZone)
+		require.NotNil(t, networkZoneEnv)
+		assert.Equal(t, "network-zone-a", networkZoneEnv.Value)
+
+		mainSts, err := NewStatefulSetBuilder(testKubeUID, testConfigHash, *dk, capability.NewMultiCapability(dk)).CreateStatefulSet()
+		require.NoError(t, err)
+
+		networkZoneEnv = k8senv.Find(mainSts.Spec.Template.Spec.Containers[0].Env, consts.EnvDTNetworkZone)
+		require.NotNil(t, networkZoneEnv)
+		assert.Equal(t, "default", networkZoneEnv.Value)
+	})
+}
+
 func TestAddTemplateSpec(t *testing.T) {
 	t.Cleanup(version.DisableCacheForTest(123))
 
@@ -0,0 +1,36 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package synthetic
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	registeredReason = "Registered"
+	errorReason      = "Error"
+
+	conditionType = "SyntheticLocation"
+)
+
+func setRegisteredCondition(conditions *[]metav1.Condition, locationName string) {
+	condition := metav1.Condition{
+		Type:    conditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  registeredReason,
+		Message: "Private Synthetic location " + locationName + " is registered.",
+	}
+	_ = meta.SetStatusCondition(conditions, condition)
+}
+
+func setErrorCondition(conditions *[]metav1.Condition) {
+	condition := metav1.Condition{
+		Type:    conditionType,
+		Status:  metav1.ConditionFalse,
+		Reason:  errorReason,
+		Message: "Private Synthetic location registration encountered an error",
+	}
+	_ = meta.SetStatusCondition(conditions, condition)
+}
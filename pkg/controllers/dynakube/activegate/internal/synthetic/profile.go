@@ -0,0 +1,91 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package synthetic
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// loadProfile describes the sizing of a synthetic-enabled ActiveGate replica.
+type loadProfile struct {
+	engineResources     corev1.ResourceRequirements
+	activeGateResources corev1.ResourceRequirements
+	shmSize             resource.Quantity
+
+	// executionsPerReplica is the number of monitor executions per minute a single replica can handle.
+	executionsPerReplica int32
+}
+
+var loadProfiles = map[activegate.SyntheticLoadProfile]loadProfile{
+	activegate.SyntheticLoadProfileXS: {
+		engineResources:      newResources("1", "2Gi", "2", "3Gi"),
+		activeGateResources:  newResources("250m", "512Mi", "500m", "1Gi"),
+		shmSize:              resource.MustParse("512Mi"),
+		executionsPerReplica: 10,
+	},
+	activegate.SyntheticLoadProfileS: {
+		engineResources:      newResources("2", "3Gi", "3", "5Gi"),
+		activeGateResources:  newResources("500m", "1Gi", "1", "2Gi"),
+		shmSize:              resource.MustParse("1Gi"),
+		executionsPerReplica: 25,
+	},
+	activegate.SyntheticLoadProfileM: {
+		engineResources:      newResources("4", "6Gi", "6", "10Gi"),
+		activeGateResources:  newResources("1", "2Gi", "2", "3Gi"),
+		shmSize:              resource.MustParse("2Gi"),
+		executionsPerReplica: 60,
+	},
+}
+
+func newResources(cpuRequest, memoryRequest, cpuLimit, memoryLimit string) corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpuRequest),
+			corev1.ResourceMemory: resource.MustParse(memoryRequest),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpuLimit),
+			corev1.ResourceMemory: resource.MustParse(memoryLimit),
+		},
+	}
+}
+
+func getLoadProfile(dk dynakube.DynaKube) loadProfile {
+	profile, ok := loadProfiles[dk.ActiveGate().GetSyntheticLoadProfile()]
+	if !ok {
+		return loadProfiles[activegate.SyntheticLoadProfileS]
+	}
+
+	return profile
+}
+
+// EngineResources returns the resources of the Synthetic browser engine container.
+func EngineResources(dk dynakube.DynaKube) corev1.ResourceRequirements {
+	if dk.Spec.ActiveGate.Synthetic != nil && dk.Spec.ActiveGate.Synthetic.Resources != nil {
+		return *dk.Spec.ActiveGate.Synthetic.Resources
+	}
+
+	return getLoadProfile(dk).engineResources
+}
+
+// ActiveGateResources returns the default resources of the ActiveGate container of synthetic-enabled ActiveGates.
+func ActiveGateResources(dk dynakube.DynaKube) corev1.ResourceRequirements {
+	return getLoadProfile(dk).activeGateResources
+}
+
+// ShmSizeLimit returns the size of the in-memory /dev/shm volume needed by the browsers of the engine.
+func ShmSizeLimit(dk dynakube.DynaKube) resource.Quantity {
+	return getLoadProfile(dk).shmSize
+}
+
+// RecommendedReplicas returns the number of replicas needed to handle the configured monitor load, it is at least 1.
+func RecommendedReplicas(dk dynakube.DynaKube) int32 {
+	executions := dk.ActiveGate().GetSyntheticMonitorExecutionsPerMinute()
+	perReplica := getLoadProfile(dk).executionsPerReplica
+
+	return max(1, (executions+perReplica-1)/perReplica)
+}
@@ -0,0 +1,131 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package synthetic
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/core"
+	dtsettings "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/settings"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/token"
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8sconditions"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/tenant/optionalscope"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+type Reconciler struct{}
+
+func NewReconciler() *Reconciler {
+	return &Reconciler{}
+}
+
+// Reconcile registers the private Synthetic location of the synthetic-enabled ActiveGates and records the recommended number of replicas.
+// The location is kept on the tenant when the synthetic capability is removed, as monitors may still be assigned to it.
+func (r *Reconciler) Reconcile(ctx context.Context, dtClient dtsettings.Client, dk *dynakube.DynaKube) error {
+	ctx, log := logd.NewFromContext(ctx, "synthetic")
+
+	if !dk.ActiveGate().IsSyntheticEnabled() {
+		if dk.Status.ActiveGate.Synthetic != nil {
+			log.Info("synthetic capability removed, the private Synthetic location is kept on the tenant", "location", dk.Status.ActiveGate.Synthetic.LocationName)
+		}
+
+		dk.Status.ActiveGate.Synthetic = nil
+		_ = meta.RemoveStatusCondition(dk.Conditions(), conditionType)
+
+		return nil
+	}
+
+	if dk.Status.ActiveGate.Synthetic == nil {
+		dk.Status.ActiveGate.Synthetic = &activegate.SyntheticStatus{}
+	}
+
+	syntheticStatus := dk.Status.ActiveGate.Synthetic
+	syntheticStatus.RecommendedReplicas = RecommendedReplicas(*dk)
+
+	locationName := dk.ActiveGate().GetSyntheticLocationName()
+	if syntheticStatus.LocationID != "" && syntheticStatus.LocationName == locationName {
+		return nil
+	}
+
+	var missingScopes []string
+	if !optionalscope.IsAvailable(dk, token.ScopeSettingsRead) {
+		missingScopes = append(missingScopes, token.ScopeSettingsRead)
+	}
+
+	if !optionalscope.IsAvailable(dk, token.ScopeSettingsWrite) {
+		missingScopes = append(missingScopes, token.ScopeSettingsWrite)
+	}
+
+	if len(missingScopes) > 0 {
+		message := strings.Join(missingScopes, ", ") + " scope(s) missing: cannot register the private Synthetic location."
+		k8sconditions.SetOptionalScopeMissing(dk.Conditions(), conditionType, message)
+		log.Info(message)
+
+		return nil
+	}
+
+	locationID, err := r.registerLocation(ctx, dtClient, dk, locationName)
+	if err != nil {
+		if core.IsForbidden(err) {
+			message := "cannot register the private Synthetic location due to missing token scopes or tenant configuration"
+			k8sconditions.SetOptionalScopeMissing(dk.Conditions(), conditionType, message)
+			log.Info(message)
+
+			return nil
+		}
+
+		setErrorCondition(dk.Conditions())
+
+		return err
+	}
+
+	syntheticStatus.LocationName = locationName
+	syntheticStatus.LocationID = locationID
+	setRegisteredCondition(dk.Conditions(), locationName)
+
+	return nil
+}
+
+func (r *Reconciler) registerLocation(ctx context.Context, dtClient dtsettings.Client, dk *dynakube.DynaKube, locationName string) (string, error) {
+	log := logd.FromContext(ctx)
+
+	locations, err := dtClient.GetSyntheticLocations(ctx, locationName)
+	if err != nil {
+		return "", errors.WithMessage(err, "error trying to check if the private Synthetic location exists")
+	}
+
+	if len(locations.Items) > 0 {
+		log.Info("private Synthetic location already exists", "location", locationName, "objectId", locations.Items[0].ObjectID)
+
+		return locations.Items[0].ObjectID, nil
+	}
+
+	objectID, err := dtClient.CreateSyntheticLocation(ctx, dtsettings.SyntheticLocationValue{
+		Name:            locationName,
+		DeploymentType:  dtsettings.SyntheticLocationDeploymentTypeKubernetes,
+		ActiveGateGroup: getActiveGateGroup(dk),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	log.Info("private Synthetic location created", "location", locationName, "objectId", objectID)
+
+	return objectID, nil
+}
+
+// getActiveGateGroup returns the activation group of the synthetic-enabled ActiveGates, which is used to assign them to the location.
+func getActiveGateGroup(dk *dynakube.DynaKube) string {
+	groupName, _ := dk.ActiveGate().GetSyntheticGroupName()
+	if group := dk.ActiveGate().GetGroup(groupName); group != nil && group.Group != "" {
+		return group.Group
+	}
+
+	return dk.Spec.ActiveGate.Group
+}
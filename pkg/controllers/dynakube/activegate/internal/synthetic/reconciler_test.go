@@ -0,0 +1,203 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package synthetic
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/core"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/settings"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/token"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8sconditions"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/tenant/optionalscope"
+	settingsmock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/clients/dynatrace/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var anyCtx = mock.MatchedBy(func(context.Context) bool { return true })
+
+func TestReconcile(t *testing.T) {
+	getDK := func() *dynakube.DynaKube {
+		dk := &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{Name: "dynakube"},
+			Spec: dynakube.DynaKubeSpec{
+				ActiveGate: activegate.Spec{
+					Groups: []activegate.GroupSpec{
+						{Name: "synthetic", Capabilities: []activegate.CapabilityDisplayName{activegate.SyntheticCapability.DisplayName}, Group: "synthetic-ag"},
+					},
+					Synthetic: &activegate.SyntheticSpec{
+						LocationName:               "in-cluster",
+						MonitorExecutionsPerMinute: new(int32(60)),
+					},
+				},
+			},
+		}
+		optionalscope.SetAvailable(dk, token.ScopeSettingsRead)
+		optionalscope.SetAvailable(dk, token.ScopeSettingsWrite)
+
+		return dk
+	}
+	expectedLocation := settings.SyntheticLocationValue{
+		Name:            "in-cluster",
+		DeploymentType:  settings.SyntheticLocationDeploymentTypeKubernetes,
+		ActiveGateGroup: "synthetic-ag",
+	}
+
+	t.Run("creates location", func(t *testing.T) {
+		dtClient := settingsmock.NewClient(t)
+		dtClient.EXPECT().GetSyntheticLocations(anyCtx, "in-cluster").Return(settings.SyntheticLocationsResponse{}, nil).Once()
+		dtClient.EXPECT().CreateSyntheticLocation(anyCtx, expectedLocation).Return("obj-1", nil).Once()
+
+		dk := getDK()
+
+		err := NewReconciler().Reconcile(t.Context(), dtClient, dk)
+		require.NoError(t, err)
+
+		require.NotNil(t, dk.Status.ActiveGate.Synthetic)
+		assert.Equal(t, "obj-1", dk.Status.ActiveGate.Synthetic.LocationID)
+		assert.Equal(t, "in-cluster", dk.Status.ActiveGate.Synthetic.LocationName)
+		assert.Equal(t, int32(3), dk.Status.ActiveGate.Synthetic.RecommendedReplicas)
+		verifyCondition(t, dk, registeredReason)
+	})
+
+	t.Run("uses existing location", func(t *testing.T) {
+		dtClient := settingsmock.NewClient(t)
+		dtClient.EXPECT().GetSyntheticLocations(anyCtx, "in-cluster").Return(settings.SyntheticLocationsResponse{
+			TotalCount: 1,
+			Items:      []settings.SyntheticLocationItem{{ObjectID: "obj-2", Value: expectedLocation}},
+		}, nil).Once()
+
+		dk := getDK()
+
+		err := NewReconciler().Reconcile(t.Context(), dtClient, dk)
+		require.NoError(t, err)
+
+		assert.Equal(t, "obj-2", dk.Status.ActiveGate.Synthetic.LocationID)
+		verifyCondition(t, dk, registeredReason)
+	})
+
+	t.Run("registered location is not queried again", func(t *testing.T) {
+		dk := getDK()
+		dk.Status.ActiveGate.Synthetic = &activegate.SyntheticStatus{LocationName: "in-cluster", LocationID: "obj-1"}
+
+		err := NewReconciler().Reconcile(t.Context(), settingsmock.NewClient(t), dk)
+		require.NoError(t, err)
+
+		assert.Equal(t, "obj-1", dk.Status.ActiveGate.Synthetic.LocationID)
+		assert.Equal(t, int32(3), dk.Status.ActiveGate.Synthetic.RecommendedReplicas)
+	})
+
+	t.Run("renamed location is registered", func(t *testing.T) {
+		dtClient := settingsmock.NewClient(t)
+		dtClient.EXPECT().GetSyntheticLocations(anyCtx, "in-cluster").Return(settings.SyntheticLocationsResponse{}, nil).Once()
+		dtClient.EXPECT().CreateSyntheticLocation(anyCtx, expectedLocation).Return("obj-3", nil).Once()
+
+		dk := getDK()
+		dk.Status.ActiveGate.Synthetic = &activegate.SyntheticStatus{LocationName: "old-name", LocationID: "obj-1"}
+
+		err := NewReconciler().Reconcile(t.Context(), dtClient, dk)
+		require.NoError(t, err)
+
+		assert.Equal(t, "obj-3", dk.Status.ActiveGate.Synthetic.LocationID)
+	})
+
+	t.Run("missing scopes", func(t *testing.T) {
+		dk := getDK()
+		optionalscope.SetMissing(dk, token.ScopeSettingsWrite)
+
+		err := NewReconciler().Reconcile(t.Context(), settingsmock.NewClient(t), dk)
+		require.NoError(t, err)
+
+		assert.Empty(t, dk.Status.ActiveGate.Synthetic.LocationID)
+		verifyCondition(t, dk, k8sconditions.OptionalScopeMissingReason)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		dtClient := settingsmock.NewClient(t)
+		dtClient.EXPECT().GetSyntheticLocations(anyCtx, "in-cluster").Return(settings.SyntheticLocationsResponse{}, &core.HTTPError{StatusCode: http.StatusForbidden}).Once()
+
+		dk := getDK()
+
+		err := NewReconciler().Reconcile(t.Context(), dtClient, dk)
+		require.NoError(t, err)
+
+		verifyCondition(t, dk, k8sconditions.OptionalScopeMissingReason)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dtClient := settingsmock.NewClient(t)
+		dtClient.EXPECT().GetSyntheticLocations(anyCtx, "in-cluster").Return(settings.SyntheticLocationsResponse{}, nil).Once()
+		dtClient.EXPECT().CreateSyntheticLocation(anyCtx, expectedLocation).Return("", errors.New("boom")).Once()
+
+		dk := getDK()
+
+		err := NewReconciler().Reconcile(t.Context(), dtClient, dk)
+		require.Error(t, err)
+
+		verifyCondition(t, dk, errorReason)
+	})
+
+	t.Run("disabled cleans up status", func(t *testing.T) {
+		dk := getDK()
+		dk.Spec.ActiveGate.Groups = nil
+		dk.Status.ActiveGate.Synthetic = &activegate.SyntheticStatus{LocationName: "in-cluster", LocationID: "obj-1"}
+		setRegisteredCondition(dk.Conditions(), "in-cluster")
+
+		err := NewReconciler().Reconcile(t.Context(), settingsmock.NewClient(t), dk)
+		require.NoError(t, err)
+
+		assert.Nil(t, dk.Status.ActiveGate.Synthetic)
+		assert.Nil(t, meta.FindStatusCondition(*dk.Conditions(), conditionType))
+	})
+}
+
+func TestRecommendedReplicas(t *testing.T) {
+	newDynaKube := func(profile activegate.SyntheticLoadProfile, executions *int32) dynakube.DynaKube {
+		return dynakube.DynaKube{
+			Spec: dynakube.DynaKubeSpec{
+				ActiveGate: activegate.Spec{
+					Capabilities: []activegate.CapabilityDisplayName{activegate.SyntheticCapability.DisplayName},
+					Synthetic:    &activegate.SyntheticSpec{LoadProfile: profile, MonitorExecutionsPerMinute: executions},
+				},
+			},
+		}
+	}
+
+	assert.Equal(t, int32(1), RecommendedReplicas(newDynaKube("", nil)))
+	assert.Equal(t, int32(1), RecommendedReplicas(newDynaKube(activegate.SyntheticLoadProfileS, new(int32(25)))))
+	assert.Equal(t, int32(2), RecommendedReplicas(newDynaKube(activegate.SyntheticLoadProfileS, new(int32(26)))))
+	assert.Equal(t, int32(5), RecommendedReplicas(newDynaKube(activegate.SyntheticLoadProfileXS, new(int32(50)))))
+	assert.Equal(t, int32(2), RecommendedReplicas(newDynaKube(activegate.SyntheticLoadProfileM, new(int32(100)))))
+}
+
+func TestEngineResources(t *testing.T) {
+	dk := dynakube.DynaKube{}
+	assert.Equal(t, loadProfiles[activegate.SyntheticLoadProfileS].engineResources, EngineResources(dk))
+
+	dk.Spec.ActiveGate.Synthetic = &activegate.SyntheticSpec{LoadProfile: activegate.SyntheticLoadProfileM}
+	assert.Equal(t, loadProfiles[activegate.SyntheticLoadProfileM].engineResources, EngineResources(dk))
+	assert.Equal(t, loadProfiles[activegate.SyntheticLoadProfileM].shmSize, ShmSizeLimit(dk))
+
+	custom := newResources("1", "1Gi", "1", "1Gi")
+	dk.Spec.ActiveGate.Synthetic.Resources = &custom
+	assert.Equal(t, custom, EngineResources(dk))
+}
+
+func verifyCondition(t *testing.T, dk *dynakube.DynaKube, expectedReason string) {
+	t.Helper()
+
+	c := meta.FindStatusCondition(*dk.Conditions(), conditionType)
+
+	require.NotNil(t, c)
+	assert.Equal(t, expectedReason, c.Reason)
+}
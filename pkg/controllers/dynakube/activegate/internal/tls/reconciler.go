@@ -6,7 +6,9 @@ package tls
 import (
 	"context"
 	"crypto/x509"
+	"encoding/pem"
 	"net"
+	"slices"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
@@ -61,7 +63,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, dk *dynakube.DynaKube) error
 }
 
 func (r *Reconciler) reconcileSelfSignedTLSSecret(ctx context.Context, dk *dynakube.DynaKube) error {
-	_, err := r.secrets.Get(ctx, types.NamespacedName{
+	secret, err := r.secrets.Get(ctx, types.NamespacedName{
 		Name:      dk.ActiveGate().GetTLSSecretName(),
 		Namespace: dk.Namespace,
 	})
@@ -75,6 +77,26 @@ func (r *Reconciler) reconcileSelfSignedTLSSecret(ctx context.Context, dk *dynak
 		return err
 	}
 
+	ipAddresses, err := getCertificateAltIPs(dk)
+	if err != nil {
+		k8sconditions.SetSecretGenFailed(dk.Conditions(), conditionType, err)
+
+		return err
+	}
+
+	// the certificate has to be recreated when ActiveGate groups without their own TLS secret or new Service IPs were added
+	if !coversAltNames(secret.Data[consts.TLSCrtDataName], getCertificateAltNames(dk), ipAddresses) {
+		logd.FromContext(ctx).Info("recreating self-signed ActiveGate certificate for new ActiveGate groups or Service IPs")
+
+		if err := r.deleteSelfSignedTLSSecret(ctx, dk); err != nil {
+			k8sconditions.SetKubeAPIError(dk.Conditions(), conditionType, err)
+
+			return err
+		}
+
+		return r.createSelfSignedTLSSecret(ctx, dk)
+	}
+
 	return nil
 }
 
@@ -101,12 +123,12 @@ func (r *Reconciler) createSelfSignedTLSSecret(ctx context.Context, dk *dynakube
 		return err
 	}
 
-	cert.Cert.DNSNames = certificates.AltNames(dk.Name, dk.Namespace, activeGateSelfSignedTLSCommonNameSuffix)
+	cert.Cert.DNSNames = getCertificateAltNames(dk)
 	cert.Cert.KeyUsage = x509.KeyUsageKeyEncipherment | x509.KeyUsageDataEncipherment
 	cert.Cert.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
 	cert.Cert.Subject.CommonName = certificates.CommonName(dk.Name, dk.Namespace, activeGateSelfSignedTLSCommonNameSuffix)
 
-	ipAddresses, err := getCertificateAltIPs(dk.Status.ActiveGate.ServiceIPs)
+	ipAddresses, err := getCertificateAltIPs(dk)
 	if err != nil {
 		k8sconditions.SetSecretGenFailed(dk.Conditions(), conditionType, err)
 
@@ -157,7 +179,57 @@ func (r *Reconciler) createSelfSignedTLSSecret(ctx context.Context, dk *dynakube
 	return nil
 }
 
-func getCertificateAltIPs(ips []string) ([]net.IP, error) {
+// getCertificateAltNames returns the DNS names of the ActiveGate Service and the Services of the ActiveGate groups that use the self-signed certificate.
+func getCertificateAltNames(dk *dynakube.DynaKube) []string {
+	altNames := certificates.AltNames(dk.Name, dk.Namespace, activeGateSelfSignedTLSCommonNameSuffix)
+
+	for _, group := range dk.Spec.ActiveGate.Groups {
+		if group.TLSSecretName == "" {
+			altNames = append(altNames, certificates.AltNames(dk.Name, dk.Namespace, activeGateSelfSignedTLSCommonNameSuffix+"-"+group.Name)...)
+		}
+	}
+
+	return altNames
+}
+
+// coversAltNames reports whether the PEM encoded certificate is valid for all the given DNS names and IP addresses.
+// A certificate that can't be parsed is treated as valid, it was created by the operator and is left untouched.
+func coversAltNames(pemCert []byte, dnsNames []string, ipAddresses []net.IP) bool {
+	block, _ := pem.Decode(pemCert)
+	if block == nil {
+		return true
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return true
+	}
+
+	for _, dnsName := range dnsNames {
+		if !slices.Contains(cert.DNSNames, dnsName) {
+			return false
+		}
+	}
+
+	for _, ipAddress := range ipAddresses {
+		if !slices.ContainsFunc(cert.IPAddresses, ipAddress.Equal) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// getCertificateAltIPs returns the IPs of the ActiveGate Service and the Services of the ActiveGate groups that use the self-signed certificate.
+func getCertificateAltIPs(dk *dynakube.DynaKube) ([]net.IP, error) {
+	ips := slices.Clone(dk.Status.ActiveGate.ServiceIPs)
+
+	for _, group := range dk.Spec.ActiveGate.Groups {
+		if group.TLSSecretName == "" {
+			ips = append(ips, dk.Status.ActiveGate.Groups[group.Name].ServiceIPs...)
+		}
+	}
+
 	altIPs := []net.IP{}
 
 	for _, ip := range ips {
@@ -4,13 +4,17 @@
 package tls
 
 import (
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"net"
 	"testing"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/exp"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8sconditions"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -178,4 +182,83 @@ func TestReconciler_Reconcile(t *testing.T) {
 
 		assert.True(t, k8serrors.IsNotFound(err))
 	})
+	t.Run("secret recreated for new ActiveGate groups", func(t *testing.T) {
+		dk := &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      testDynakubeName,
+			},
+			Spec: dynakube.DynaKubeSpec{
+				ActiveGate: activegate.Spec{
+					Capabilities: []activegate.CapabilityDisplayName{
+						activegate.DynatraceAPICapability.DisplayName,
+					},
+				},
+			},
+		}
+		fakeClient := fake.NewClient()
+		r := NewReconciler(fakeClient, fakeClient)
+		require.NoError(t, r.Reconcile(t.Context(), dk))
+
+		dk.Spec.ActiveGate.Groups = []activegate.GroupSpec{
+			{Name: "routing", Capabilities: []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName}},
+			{Name: "custom", Capabilities: []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName}, TLSSecretName: "custom-tls"},
+		}
+		require.NoError(t, r.Reconcile(t.Context(), dk))
+
+		agTLSSecret, err := r.secrets.Get(t.Context(), types.NamespacedName{
+			Namespace: dk.Namespace,
+			Name:      dk.ActiveGate().GetTLSSecretName(),
+		})
+		require.NoError(t, err)
+
+		block, _ := pem.Decode(agTLSSecret.Data[consts.TLSCrtDataName])
+		require.NotNil(t, block)
+		cert, err := x509.ParseCertificate(block.Bytes)
+		require.NoError(t, err)
+
+		assert.Contains(t, cert.DNSNames, testDynakubeName+"-activegate."+testNamespace)
+		assert.Contains(t, cert.DNSNames, testDynakubeName+"-activegate-routing."+testNamespace)
+		assert.NotContains(t, cert.DNSNames, testDynakubeName+"-activegate-custom."+testNamespace)
+	})
+
+	t.Run("secret recreated for new ActiveGate group Service IPs", func(t *testing.T) {
+		dk := &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      testDynakubeName,
+			},
+			Spec: dynakube.DynaKubeSpec{
+				ActiveGate: activegate.Spec{
+					Groups: []activegate.GroupSpec{
+						{Name: "routing", Capabilities: []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName}},
+						{Name: "custom", Capabilities: []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName}, TLSSecretName: "custom-tls"},
+					},
+				},
+			},
+		}
+		fakeClient := fake.NewClient()
+		r := NewReconciler(fakeClient, fakeClient)
+		require.NoError(t, r.Reconcile(t.Context(), dk))
+
+		dk.Status.ActiveGate.Groups = map[string]activegate.GroupStatus{
+			"routing": {ServiceIPs: []string{"10.0.0.1"}},
+			"custom":  {ServiceIPs: []string{"10.0.0.2"}},
+		}
+		require.NoError(t, r.Reconcile(t.Context(), dk))
+
+		agTLSSecret, err := r.secrets.Get(t.Context(), types.NamespacedName{
+			Namespace: dk.Namespace,
+			Name:      dk.ActiveGate().GetTLSSecretName(),
+		})
+		require.NoError(t, err)
+
+		block, _ := pem.Decode(agTLSSecret.Data[consts.TLSCrtDataName])
+		require.NotNil(t, block)
+		cert, err := x509.ParseCertificate(block.Bytes)
+		require.NoError(t, err)
+
+		require.Len(t, cert.IPAddresses, 1)
+		assert.True(t, net.ParseIP("10.0.0.1").Equal(cert.IPAddresses[0]))
+	})
 }
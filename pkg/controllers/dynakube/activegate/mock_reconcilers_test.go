@@ -14,6 +14,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/value"
 	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/activegate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/image"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/settings"
 	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/version"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/token"
@@ -739,3 +740,93 @@ func (_c *mockTlsReconciler_Reconcile_Call) RunAndReturn(run func(ctx context.Co
 	_c.Call.Return(run)
 	return _c
 }
+
+// newMockSyntheticReconciler creates a new instance of mockSyntheticReconciler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockSyntheticReconciler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockSyntheticReconciler {
+	mock := &mockSyntheticReconciler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockSyntheticReconciler is an autogenerated mock type for the syntheticReconciler type
+type mockSyntheticReconciler struct {
+	mock.Mock
+}
+
+type mockSyntheticReconciler_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockSyntheticReconciler) EXPECT() *mockSyntheticReconciler_Expecter {
+	return &mockSyntheticReconciler_Expecter{mock: &_m.Mock}
+}
+
+// Reconcile provides a mock function for the type mockSyntheticReconciler
+func (_mock *mockSyntheticReconciler) Reconcile(ctx context.Context, settingsClient settings.Client, dk *dynakube.DynaKube) error {
+	ret := _mock.Called(ctx, settingsClient, dk)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reconcile")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, settings.Client, *dynakube.DynaKube) error); ok {
+		r0 = returnFunc(ctx, settingsClient, dk)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// mockSyntheticReconciler_Reconcile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reconcile'
+type mockSyntheticReconciler_Reconcile_Call struct {
+	*mock.Call
+}
+
+// Reconcile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - settingsClient settings.Client
+//   - dk *dynakube.DynaKube
+func (_e *mockSyntheticReconciler_Expecter) Reconcile(ctx any, settingsClient any, dk any) *mockSyntheticReconciler_Reconcile_Call {
+	return &mockSyntheticReconciler_Reconcile_Call{Call: _e.mock.On("Reconcile", ctx, settingsClient, dk)}
+}
+
+func (_c *mockSyntheticReconciler_Reconcile_Call) Run(run func(ctx context.Context, settingsClient settings.Client, dk *dynakube.DynaKube)) *mockSyntheticReconciler_Reconcile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 settings.Client
+		if args[1] != nil {
+			arg1 = args[1].(settings.Client)
+		}
+		var arg2 *dynakube.DynaKube
+		if args[2] != nil {
+			arg2 = args[2].(*dynakube.DynaKube)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *mockSyntheticReconciler_Reconcile_Call) Return(err error) *mockSyntheticReconciler_Reconcile_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *mockSyntheticReconciler_Reconcile_Call) RunAndReturn(run func(ctx context.Context, settingsClient settings.Client, dk *dynakube.DynaKube) error) *mockSyntheticReconciler_Reconcile_Call {
+	_c.Call.Return(run)
+	return _c
+}
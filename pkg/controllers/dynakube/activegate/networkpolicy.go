@@ -0,0 +1,68 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package activegate
+
+import (
+	"context"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/connectioninfo"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/networkpolicy"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8snetworkpolicy"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+const networkPolicyConditionType = "ActiveGateNetworkPolicy"
+
+// reconcileNetworkPolicy keeps one NetworkPolicy for the pods of the ActiveGate and of all its groups, it has the same name as the service.
+func (r *Reconciler) reconcileNetworkPolicy(ctx context.Context, dk *dynakube.DynaKube) error {
+	name := capability.BuildServiceName(dk.Name)
+
+	if !dk.IsNetworkPolicyEnabled() || !dk.ActiveGate().IsEnabled() {
+		return networkpolicy.Remove(ctx, r.client, r.apiReader, dk, networkPolicyConditionType, name)
+	}
+
+	return networkpolicy.Reconcile(ctx, r.client, r.apiReader, dk, networkPolicyConditionType, func() (*networkingv1.NetworkPolicy, error) {
+		return r.buildNetworkPolicy(ctx, dk, name)
+	})
+}
+
+// buildNetworkPolicy allows the ingress from any source, as OneAgents and injected pods in all namespaces connect to the ActiveGate.
+// The egress is restricted to the communication hosts of the ActiveGate, unless synthetic monitors have to reach arbitrary destinations.
+func (r *Reconciler) buildNetworkPolicy(ctx context.Context, dk *dynakube.DynaKube, name string) (*networkingv1.NetworkPolicy, error) {
+	coreLabels := k8slabel.NewCoreLabels(dk.Name, k8slabel.ActiveGateComponentLabel)
+
+	egressRules := []networkingv1.NetworkPolicyEgressRule{
+		networkpolicy.DNSEgress(),
+		networkpolicy.KubernetesAPIEgress(dk),
+	}
+
+	if dk.ActiveGate().IsSyntheticEnabled() {
+		egressRules = append(egressRules, networkpolicy.EgressToAll())
+	} else {
+		hosts, err := networkpolicy.TenantHosts(ctx, r.apiReader, dk)
+		if err != nil {
+			return nil, err
+		}
+
+		agHosts, err := connectioninfo.ParseAGCommunicationHosts(dk.Status.ActiveGate.ConnectionInfo.Endpoints)
+		if err != nil {
+			return nil, err
+		}
+
+		egressRules = append(egressRules, networkpolicy.CommunicationHostsEgress(append(hosts, agHosts...))...)
+	}
+
+	return k8snetworkpolicy.Build(dk, name, networkpolicy.ActiveGatePods(dk),
+		k8snetworkpolicy.SetLabels(coreLabels.BuildLabels()),
+		k8snetworkpolicy.AddIngressRules(networkpolicy.IngressFromAny(
+			networkpolicy.TCPPort(consts.HTTPSContainerPort),
+			networkpolicy.TCPPort(consts.HTTPContainerPort),
+		)),
+		k8snetworkpolicy.AddEgressRules(egressRules...),
+	)
+}
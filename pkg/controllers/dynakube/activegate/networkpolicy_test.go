@@ -0,0 +1,89 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package activegate
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/networkpolicy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestNetworkPolicy(t *testing.T) {
+	newDynakube := func(capabilities ...activegate.CapabilityDisplayName) *dynakube.DynaKube {
+		dk := &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      testName,
+			},
+			Spec: dynakube.DynaKubeSpec{
+				APIURL:          "https://tenant.live.dynatrace.com/api",
+				NetworkPolicies: &dynakube.NetworkPoliciesSpec{},
+				ActiveGate: activegate.Spec{
+					Capabilities: capabilities,
+				},
+			},
+		}
+		dk.Status.ActiveGate.ConnectionInfo.Endpoints = "https://sg.live.dynatrace.com:9999/communication,https://10.0.0.5:9999/communication"
+
+		return dk
+	}
+
+	key := client.ObjectKey{Name: testServiceName, Namespace: testNamespace}
+
+	t.Run("allows OneAgents and the communication hosts", func(t *testing.T) {
+		dk := newDynakube(activegate.RoutingCapability.DisplayName)
+		clt := fake.NewClient()
+		r := NewReconciler(clt, clt)
+
+		require.NoError(t, r.reconcileNetworkPolicy(t.Context(), dk))
+
+		var policy networkingv1.NetworkPolicy
+		require.NoError(t, clt.Get(t.Context(), key, &policy))
+		assert.Equal(t, networkpolicy.ActiveGatePods(dk), policy.Spec.PodSelector.MatchLabels)
+
+		require.Len(t, policy.Spec.Ingress, 1)
+		assert.Empty(t, policy.Spec.Ingress[0].From)
+		assert.Equal(t, []networkingv1.NetworkPolicyPort{networkpolicy.TCPPort(9999), networkpolicy.TCPPort(9998)}, policy.Spec.Ingress[0].Ports)
+
+		require.Len(t, policy.Spec.Egress, 4)
+		assert.Equal(t, "10.0.0.5/32", policy.Spec.Egress[2].To[0].IPBlock.CIDR)
+		assert.Equal(t, []networkingv1.NetworkPolicyPort{networkpolicy.TCPPort(443), networkpolicy.TCPPort(9999)}, policy.Spec.Egress[3].Ports)
+		assert.NotNil(t, meta.FindStatusCondition(*dk.Conditions(), networkPolicyConditionType))
+	})
+
+	t.Run("synthetic monitors can reach any destination", func(t *testing.T) {
+		dk := newDynakube(activegate.SyntheticCapability.DisplayName)
+		clt := fake.NewClient()
+
+		require.NoError(t, NewReconciler(clt, clt).reconcileNetworkPolicy(t.Context(), dk))
+
+		var policy networkingv1.NetworkPolicy
+		require.NoError(t, clt.Get(t.Context(), key, &policy))
+		assert.Contains(t, policy.Spec.Egress, networkpolicy.EgressToAll())
+	})
+
+	t.Run("removed when the ActiveGate is disabled", func(t *testing.T) {
+		dk := newDynakube(activegate.RoutingCapability.DisplayName)
+		clt := fake.NewClient()
+		r := NewReconciler(clt, clt)
+
+		require.NoError(t, r.reconcileNetworkPolicy(t.Context(), dk))
+
+		dk.Spec.ActiveGate.Capabilities = nil
+
+		require.NoError(t, r.reconcileNetworkPolicy(t.Context(), dk))
+		assert.True(t, k8serrors.IsNotFound(clt.Get(t.Context(), key, &networkingv1.NetworkPolicy{})))
+		assert.Nil(t, meta.FindStatusCondition(*dk.Conditions(), networkPolicyConditionType))
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	agclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/activegate"
 	dtimage "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/image"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/settings"
 	dtversion "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/version"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
@@ -18,6 +19,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/authtoken"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/customproperties"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/statefulset"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/synthetic"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/tls"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/connectioninfo"
 	agconnectioninfo "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/connectioninfo/activegate"
@@ -66,6 +68,10 @@ type tlsReconciler interface {
 	Reconcile(ctx context.Context, dk *dynakube.DynaKube) error
 }
 
+type syntheticReconciler interface {
+	Reconcile(ctx context.Context, settingsClient settings.Client, dk *dynakube.DynaKube) error
+}
+
 type Reconciler struct {
 	apiReader                  client.Reader
 	client                     client.Client
@@ -77,6 +83,7 @@ type Reconciler struct {
 	statefulsetReconciler      statefulsetReconciler
 	customPropertiesReconciler customPropertiesReconciler
 	tlsSecretReconciler        tlsReconciler
+	syntheticReconciler        syntheticReconciler
 	configMaps                 k8sconfigmap.QueryObject
 }
 
@@ -92,6 +99,7 @@ func NewReconciler(clt client.Client, apiReader client.Reader) *Reconciler {
 		customPropertiesReconciler: customproperties.NewReconciler(clt, apiReader),
 		statefulsetReconciler:      statefulset.NewReconciler(clt, apiReader),
 		tlsSecretReconciler:        tls.NewReconciler(clt, apiReader),
+		syntheticReconciler:        synthetic.NewReconciler(),
 		configMaps:                 k8sconfigmap.Query(clt, apiReader),
 	}
 }
@@ -149,16 +157,28 @@ func (r *Reconciler) Reconcile(ctx context.Context, dk *dynakube.DynaKube, dtCli
 
 	agCapability := capability.NewMultiCapability(dk)
 	if agCapability.Enabled() {
-		return r.createCapability(ctx, dk, agCapability)
+		if err := r.createCapability(ctx, dk, agCapability); err != nil {
+			return err
+		}
 	} else {
 		if err := r.deleteCapability(ctx, dk); err != nil {
 			return err
 		}
+		// TODO: move cleanup to ActiveGate reconciler
+		meta.RemoveStatusCondition(dk.Conditions(), statefulset.ActiveGateStatefulSetConditionType)
 	}
-	// TODO: move cleanup to ActiveGate reconciler
-	meta.RemoveStatusCondition(dk.Conditions(), statefulset.ActiveGateStatefulSetConditionType)
 
-	return nil
+	err = r.reconcileGroups(ctx, dk)
+	if err != nil {
+		return err
+	}
+
+	err = r.reconcileNetworkPolicy(ctx, dk)
+	if err != nil {
+		return err
+	}
+
+	return r.syntheticReconciler.Reconcile(ctx, dtClient.Settings, dk)
 }
 
 func (r *Reconciler) createActiveGateTenantConnectionInfoConfigMap(ctx context.Context, dk *dynakube.DynaKube) error {
@@ -235,7 +255,7 @@ func (r *Reconciler) createCapability(ctx context.Context, dk *dynakube.DynaKube
 		return errors.WithStack(err)
 	}
 
-	err = r.createOrUpdateService(ctx, dk)
+	err = r.createOrUpdateService(ctx, dk, CreateService(dk))
 	if err != nil {
 		return err
 	}
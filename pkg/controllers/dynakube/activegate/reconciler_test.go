@@ -188,6 +188,7 @@ func TestReconciler_Reconcile(t *testing.T) {
 			statefulsetReconciler:      mockStatefulsetReconcileOnce(t),
 			customPropertiesReconciler: mockCustomPropertiesReconcileOnce(t),
 			tlsSecretReconciler:        mockTLSReconcileOnce(t),
+			syntheticReconciler:        mockSyntheticReconcileOnce(t),
 			configMaps:                 k8sconfigmap.Query(clt, clt),
 		}
 
@@ -224,6 +225,7 @@ func TestReconciler_Reconcile(t *testing.T) {
 			// statefulsetReconciler: panic if called
 			// customPropertiesReconciler: panic if called
 			tlsSecretReconciler: mockTLSReconcileOnce(t),
+			syntheticReconciler: mockSyntheticReconcileOnce(t),
 			configMaps:          k8sconfigmap.Query(clt, clt),
 		}
 
@@ -310,6 +312,7 @@ func TestReconciler_Reconcile(t *testing.T) {
 			statefulsetReconciler:      mockStatefulsetReconcileOnce(t),
 			customPropertiesReconciler: mockCustomPropertiesReconcileOnce(t),
 			tlsSecretReconciler:        mockTLSReconcileOnce(t),
+			syntheticReconciler:        mockSyntheticReconcileOnce(t),
 			configMaps:                 k8sconfigmap.Query(fakeClient, fakeClient),
 		}
 		err := proxyReconciler.Reconcile(t.Context(), dkWithProxy, createMockDTClient(t, false), nil)
@@ -326,6 +329,7 @@ func TestReconciler_Reconcile(t *testing.T) {
 			statefulsetReconciler:      mockStatefulsetReconcileOnce(t),
 			customPropertiesReconciler: mockCustomPropertiesReconcileOnce(t),
 			tlsSecretReconciler:        mockTLSReconcileOnce(t),
+			syntheticReconciler:        mockSyntheticReconcileOnce(t),
 			configMaps:                 k8sconfigmap.Query(fakeClient, fakeClient),
 		}
 
@@ -683,6 +687,7 @@ func TestReconcile_ActivegateConfigMap(t *testing.T) {
 			statefulsetReconciler:      mockStatefulsetReconcileOnce(t),
 			customPropertiesReconciler: mockCustomPropertiesReconcileOnce(t),
 			tlsSecretReconciler:        mockTLSReconcileOnce(t),
+			syntheticReconciler:        mockSyntheticReconcileOnce(t),
 			configMaps:                 k8sconfigmap.Query(fakeClient, fakeClient),
 		}
 
@@ -808,6 +813,15 @@ func mockCustomPropertiesReconcileOnce(t *testing.T) customPropertiesReconciler
 	return reconciler
 }
 
+func mockSyntheticReconcileOnce(t *testing.T) syntheticReconciler {
+	t.Helper()
+
+	reconciler := newMockSyntheticReconciler(t)
+	reconciler.EXPECT().Reconcile(anyCtx, mock.Anything, anyDynakube).Return(nil).Once()
+
+	return reconciler
+}
+
 func mockTLSReconcileOnce(t *testing.T) tlsReconciler {
 	t.Helper()
 
@@ -19,6 +19,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
@@ -35,31 +36,38 @@ func (r *Reconciler) deleteService(ctx context.Context, dk *dynakube.DynaKube) e
 }
 
 func (r *Reconciler) setAGServiceIPs(ctx context.Context, dk *dynakube.DynaKube) error {
-	template := CreateService(dk)
-	present := &corev1.Service{}
+	serviceIPs, err := r.getServiceIPs(ctx, CreateService(dk))
+	if err != nil {
+		return err
+	}
 
-	// retry because a Service created by the preceding createOrUpdateService call may not be immediately visible in the API.
-	return retry.OnError(retry.DefaultBackoff, k8serrors.IsNotFound, func() error {
-		err := r.client.Get(ctx, client.ObjectKeyFromObject(template), present)
-		if err != nil {
-			return errors.WithStack(err)
-		}
+	dk.Status.ActiveGate.ServiceIPs = serviceIPs
+
+	return nil
+}
 
-		dk.Status.ActiveGate.ServiceIPs = present.Spec.ClusterIPs
+func (r *Reconciler) getServiceIPs(ctx context.Context, template *corev1.Service) ([]string, error) {
+	present := &corev1.Service{}
 
-		return nil
+	// retry because a Service created by the preceding createOrUpdateService call may not be immediately visible in the API.
+	err := retry.OnError(retry.DefaultBackoff, k8serrors.IsNotFound, func() error {
+		return errors.WithStack(r.client.Get(ctx, client.ObjectKeyFromObject(template), present))
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	return present.Spec.ClusterIPs, nil
 }
 
-func (r *Reconciler) createOrUpdateService(ctx context.Context, dk *dynakube.DynaKube) error {
+func (r *Reconciler) createOrUpdateService(ctx context.Context, dk *dynakube.DynaKube, desired *corev1.Service) error {
 	log := logd.FromContext(ctx)
 
-	desired := CreateService(dk)
 	installed := &corev1.Service{}
 
 	err := r.client.Get(ctx, client.ObjectKeyFromObject(desired), installed)
 	if k8serrors.IsNotFound(err) {
-		log.Info("creating AG service")
+		log.Info("creating AG service", "name", desired.Name)
 
 		err = controllerutil.SetControllerReference(dk, desired, r.client.Scheme())
 		if err != nil {
@@ -75,7 +83,7 @@ func (r *Reconciler) createOrUpdateService(ctx context.Context, dk *dynakube.Dyn
 		return errors.WithStack(err)
 	}
 
-	if r.portsAreOutdated(installed, desired) || r.labelsAreOutdated(installed, desired) {
+	if r.portsAreOutdated(installed, desired) || r.labelsAreOutdated(installed, desired) || r.trafficDistributionIsOutdated(installed, desired) {
 		desired.Spec.ClusterIP = installed.Spec.ClusterIP
 		desired.ResourceVersion = installed.ResourceVersion
 
@@ -92,12 +100,21 @@ func (r *Reconciler) portsAreOutdated(installedService, desiredService *corev1.S
 	return !reflect.DeepEqual(installedService.Spec.Ports, desiredService.Spec.Ports)
 }
 
+func (r *Reconciler) trafficDistributionIsOutdated(installedService, desiredService *corev1.Service) bool {
+	return !ptr.Equal(installedService.Spec.TrafficDistribution, desiredService.Spec.TrafficDistribution)
+}
+
 func (r *Reconciler) labelsAreOutdated(installedService, desiredService *corev1.Service) bool {
 	return !maps.Equal(installedService.Labels, desiredService.Labels) ||
 		!maps.Equal(installedService.Spec.Selector, desiredService.Spec.Selector)
 }
 
 func CreateService(dk *dynakube.DynaKube) *corev1.Service {
+	return CreateGroupService(dk, "")
+}
+
+// CreateGroupService builds the Service of an ActiveGate group, an empty group name refers to the ActiveGate configured in spec.activeGate.
+func CreateGroupService(dk *dynakube.DynaKube, groupName string) *corev1.Service {
 	ports := []corev1.ServicePort{
 		{
 			Name:       consts.HTTPSServicePortName,
@@ -114,23 +131,29 @@ func CreateService(dk *dynakube.DynaKube) *corev1.Service {
 	}
 
 	coreLabels := k8slabel.NewCoreLabels(dk.Name, k8slabel.ActiveGateComponentLabel)
+	labels := coreLabels.BuildLabels()
+
+	if groupName != "" {
+		labels[k8slabel.ActiveGateGroupLabel] = groupName
+	}
 
-	return &corev1.Service{
+	service := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      capability.BuildServiceName(dk.Name),
+			Name:      capability.BuildGroupServiceName(dk.Name, groupName),
 			Namespace: dk.Namespace,
-			Labels:    coreLabels.BuildLabels(),
+			Labels:    labels,
 		},
 		Spec: corev1.ServiceSpec{
 			Type:     corev1.ServiceTypeClusterIP,
-			Selector: buildSelectorLabels(dk.Name),
+			Selector: capability.BuildSelectorLabels(*dk, groupName),
 			Ports:    ports,
 		},
 	}
-}
 
-func buildSelectorLabels(dynakubeName string) map[string]string {
-	appLabels := k8slabel.NewAppLabels(k8slabel.ActiveGateComponentLabel, dynakubeName, "", "")
+	// keeps the traffic of the OneAgents in the zone of their node, as long as there is a ready ActiveGate in it
+	if len(dk.Spec.TopologyZones) > 0 {
+		service.Spec.TrafficDistribution = new(corev1.ServiceTrafficDistributionPreferClose) //nolint:staticcheck // PreferSameZone requires Kubernetes 1.34
+	}
 
-	return appLabels.BuildMatchLabels()
+	return service
 }
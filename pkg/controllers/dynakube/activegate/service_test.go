@@ -10,6 +10,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/communication"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
@@ -86,6 +87,17 @@ func TestCreateService(t *testing.T) {
 		ports := service.Spec.Ports
 		assert.Contains(t, ports, agHTTPSPort)
 		assert.Contains(t, ports, agHTTPPort)
+		assert.Nil(t, serviceSpec.TrafficDistribution)
+	})
+
+	t.Run("prefer ActiveGates in the same zone if topology zones are configured", func(t *testing.T) {
+		dk := createTestDynaKube()
+		dk.Spec.TopologyZones = []communication.TopologyZone{{Zone: "zone-a", NetworkZone: "network-zone-a"}}
+
+		service := CreateGroupService(dk, "routing")
+
+		require.NotNil(t, service.Spec.TrafficDistribution)
+		assert.Equal(t, corev1.ServiceTrafficDistributionPreferClose, *service.Spec.TrafficDistribution) //nolint:staticcheck
 	})
 }
 
@@ -129,13 +141,19 @@ func TestCreateOrUpdateService(t *testing.T) {
 				svc.Spec.Selector = map[string]string{}
 			},
 		},
+		{
+			"traffic distribution gets updated",
+			func(svc *corev1.Service) {
+				svc.Spec.TrafficDistribution = new(corev1.ServiceTrafficDistributionPreferSameNode)
+			},
+		},
 	}
 
 	for _, test := range tests {
 		clt := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
 		r := &Reconciler{client: clt}
 
-		err := r.createOrUpdateService(t.Context(), dk)
+		err := r.createOrUpdateService(t.Context(), dk, CreateService(dk))
 		require.NoError(t, err)
 
 		service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: capability.BuildServiceName(dk.Name), Namespace: dk.Namespace}}
@@ -147,7 +165,7 @@ func TestCreateOrUpdateService(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, controllerutil.OperationResultUpdated, result)
 
-		err = r.createOrUpdateService(t.Context(), dk)
+		err = r.createOrUpdateService(t.Context(), dk, CreateService(dk))
 		require.NoError(t, err)
 
 		actualService := getService(t, clt)
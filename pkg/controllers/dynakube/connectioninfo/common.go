@@ -5,6 +5,7 @@ package connectioninfo
 
 import (
 	"context"
+	"strings"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
@@ -30,6 +31,18 @@ const (
 	EnvDTTenant = "DT_TENANT"
 )
 
+// GetNetworkZoneEndpointsKey returns the key of the communication endpoints of a network zone in the connection info ConfigMap.
+// Characters that are not allowed in ConfigMap keys are replaced by an underscore.
+func GetNetworkZoneEndpointsKey(networkZone string) string {
+	return CommunicationEndpointsKey + "." + strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '.' || r == '_' {
+			return r
+		}
+
+		return '_'
+	}, networkZone)
+}
+
 func IsTenantSecretPresent(ctx context.Context, secrets k8ssecret.QueryObject, secretNamespacedName types.NamespacedName) (bool, error) {
 	log := logd.FromContext(ctx)
 
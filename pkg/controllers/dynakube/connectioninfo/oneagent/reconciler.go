@@ -49,6 +49,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, oaClient oneagent.Client, dk
 
 		meta.RemoveStatusCondition(dk.Conditions(), oaConnectionInfoConditionType)
 		dk.Status.OneAgent.ConnectionInfo = communication.ConnectionInfo{}
+		dk.Status.OneAgent.NetworkZoneEndpoints = nil
 
 		return nil // clean-up shouldn't cause a failure
 	}
@@ -100,6 +101,11 @@ func (r *Reconciler) reconcileConnectionInfo(ctx context.Context, oaClient oneag
 		return oneagent.StaleNetworkZoneEndpointsError
 	}
 
+	err = r.reconcileNetworkZoneEndpoints(ctx, oaClient, dk)
+	if err != nil {
+		return err
+	}
+
 	err = r.createTenantTokenSecret(ctx, dk, dk.OneAgent().GetTenantSecret(), connectionInfo)
 	if err != nil {
 		return err
@@ -115,6 +121,50 @@ func (r *Reconciler) reconcileConnectionInfo(ctx context.Context, oaClient oneag
 	return nil
 }
 
+// reconcileNetworkZoneEndpoints fetches the communication endpoints of the network zones used by the topology zones and node pools,
+// so the OneAgents of a zone start with the endpoints of their network zone.
+func (r *Reconciler) reconcileNetworkZoneEndpoints(ctx context.Context, oaClient oneagent.Client, dk *dynakube.DynaKube) error {
+	log := logd.FromContext(ctx)
+
+	networkZones := dk.GetAdditionalNetworkZones()
+	if len(networkZones) == 0 {
+		dk.Status.OneAgent.NetworkZoneEndpoints = nil
+
+		return nil
+	}
+
+	networkZoneEndpoints := make(map[string]string, len(networkZones))
+
+	for _, networkZone := range networkZones {
+		connectionInfo, err := oaClient.GetNetworkZoneConnectionInfo(ctx, networkZone, getRequiredNetworkZoneServiceIPs(dk, networkZone))
+
+		switch {
+		case errors.Is(err, oneagent.NoCommunicationEndpointsError):
+			log.Info("no received OneAgent connection info for network zone", "network zone", networkZone)
+			setEmptyCommunicationHostsCondition(dk.Conditions())
+
+			return err
+		case errors.Is(err, oneagent.StaleNetworkZoneEndpointsError):
+			log.Info("OneAgent endpoints of network zone do not contain the ActiveGate Service IPs of the zone yet, postponing OneAgent deployment",
+				"network zone", networkZone,
+				"endpoints", connectionInfo.Endpoints)
+			setStaleNetworkZoneEndpointsCondition(dk.Conditions())
+
+			return err
+		case err != nil:
+			k8sconditions.SetDynatraceAPIError(dk.Conditions(), oaConnectionInfoConditionType, err)
+
+			return errors.WithMessagef(err, "failed to get OneAgent connection info of network zone %s", networkZone)
+		}
+
+		networkZoneEndpoints[networkZone] = connectionInfo.Endpoints
+	}
+
+	dk.Status.OneAgent.NetworkZoneEndpoints = networkZoneEndpoints
+
+	return nil
+}
+
 // IsPostponedError reports whether the error indicates a transient
 // OneAgent connection-info state that resolves itself once the local ActiveGate is
 // ready or has re-registered. Callers treat these as "not yet ready" and trigger a
@@ -156,3 +206,18 @@ func getRequiredActiveGateServiceIPs(dk *dynakube.DynaKube) []string {
 
 	return dk.Status.ActiveGate.ServiceIPs
 }
+
+// getRequiredNetworkZoneServiceIPs returns the Service IPs of the routing ActiveGate groups pinned to a topology zone of the network zone.
+func getRequiredNetworkZoneServiceIPs(dk *dynakube.DynaKube, networkZone string) []string {
+	var serviceIPs []string
+
+	for _, group := range dk.Spec.ActiveGate.Groups {
+		if group.TopologyZone == "" || !group.IsRoutingEnabled() || dk.GetTopologyNetworkZone(group.TopologyZone) != networkZone {
+			continue
+		}
+
+		serviceIPs = append(serviceIPs, dk.Status.ActiveGate.Groups[group.Name].ServiceIPs...)
+	}
+
+	return serviceIPs
+}
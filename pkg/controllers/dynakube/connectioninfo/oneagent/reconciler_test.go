@@ -271,6 +271,51 @@ func TestReconcile_StaleNetworkZoneEndpoints(t *testing.T) {
 	})
 }
 
+func TestReconcile_TopologyZones(t *testing.T) {
+	ctx := t.Context()
+
+	dk := getTestDynakube()
+	dk.Spec.NetworkZone = "default-zone"
+	dk.Spec.TopologyZones = []communication.TopologyZone{
+		{Zone: "eu-west-1a", NetworkZone: "zone-a"},
+		{Zone: "eu-west-1b", NetworkZone: "zone-b"},
+		{Zone: "eu-west-1c", NetworkZone: "default-zone"},
+	}
+
+	t.Run("store endpoints of each additional network zone", func(t *testing.T) {
+		fakeClient := fake.NewClient(dk)
+		dtClient := oneagentclientmock.NewClient(t)
+		dtClient.EXPECT().GetConnectionInfo(anyCtx, mock.Anything).Return(getTestOneAgentConnectionInfo(), nil).Once()
+		dtClient.EXPECT().GetNetworkZoneConnectionInfo(anyCtx, "zone-a", mock.Anything).Return(oneagentclient.ConnectionInfo{Endpoints: "zone-a-endpoints"}, nil).Once()
+		dtClient.EXPECT().GetNetworkZoneConnectionInfo(anyCtx, "zone-b", mock.Anything).Return(oneagentclient.ConnectionInfo{Endpoints: "zone-b-endpoints"}, nil).Once()
+
+		err := NewReconciler(fakeClient, fakeClient).Reconcile(ctx, dtClient, dk)
+		require.NoError(t, err)
+
+		assert.Equal(t, testTenantEndpoints, dk.Status.OneAgent.ConnectionInfo.Endpoints)
+		assert.Equal(t, map[string]string{
+			"zone-a": "zone-a-endpoints",
+			"zone-b": "zone-b-endpoints",
+		}, dk.Status.OneAgent.NetworkZoneEndpoints)
+	})
+	t.Run("block deployment when a network zone has no endpoints", func(t *testing.T) {
+		dk := dk.DeepCopy()
+		dk.Status = dynakube.DynaKubeStatus{}
+		fakeClient := fake.NewClient(dk)
+		dtClient := oneagentclientmock.NewClient(t)
+		dtClient.EXPECT().GetConnectionInfo(anyCtx, mock.Anything).Return(getTestOneAgentConnectionInfo(), nil).Once()
+		dtClient.EXPECT().GetNetworkZoneConnectionInfo(anyCtx, "zone-a", mock.Anything).Return(oneagentclient.ConnectionInfo{}, oneagentclient.NoCommunicationEndpointsError).Once()
+
+		err := NewReconciler(fakeClient, fakeClient).Reconcile(ctx, dtClient, dk)
+		require.ErrorIs(t, err, oneagentclient.NoCommunicationEndpointsError)
+		assert.Empty(t, dk.Status.OneAgent.NetworkZoneEndpoints)
+
+		condition := meta.FindStatusCondition(*dk.Conditions(), oaConnectionInfoConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, EmptyCommunicationHostsReason, condition.Reason)
+	})
+}
+
 func TestReconcile_NoOneAgentCommunicationHosts(t *testing.T) {
 	ctx := t.Context()
 	dk := &dynakube.DynaKube{
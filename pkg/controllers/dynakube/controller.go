@@ -14,12 +14,14 @@ import (
 	dynatracestatus "github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/core"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/core/middleware"
 	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/image"
 	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/settings"
 	tokenclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/token"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate"
 	oaconnectioninfo "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/connectioninfo/oneagent"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/deploymentmetadata"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/dtpullsecret"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/extension"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/injection"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/istio"
@@ -31,7 +33,9 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/oneagent"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/otelc"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/proxy"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/remediation"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/token"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/vpa"
 	"github.com/Dynatrace/dynatrace-operator/pkg/injection/namespace/mapper"
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
@@ -44,6 +48,7 @@ import (
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -105,12 +110,16 @@ func NewDynaKubeController(kubeClient client.Client, apiReader client.Reader, ev
 		k8sEntityReconciler:          k8sentity.NewReconciler(),
 		otelColReconciler:            otelc.NewReconciler(kubeClient, apiReader),
 		proxyReconciler:              proxy.NewReconciler(kubeClient, apiReader),
+		remediationReconciler:        remediation.NewReconciler(kubeClient, apiReader, eventRecorder),
+		vpaReconciler:                vpa.NewReconciler(kubeClient, apiReader),
 		deploymentMetadataReconciler: deploymentmetadata.NewReconciler(kubeClient, apiReader, clusterID),
 		istioReconciler:              istio.NewReconciler(kubeClient, apiReader),
 		logMonitoringReconciler:      logmonitoring.NewReconciler(kubeClient, apiReader),
 		oneAgentReconciler:           oneagent.NewReconciler(kubeClient, apiReader, clusterID),
 		activeGateReconciler:         activegate.NewReconciler(kubeClient, apiReader),
 		injectionReconciler:          injection.NewReconciler(kubeClient, apiReader),
+
+		workloadIdentityPullSecretReconciler: dtpullsecret.NewWorkloadIdentityReconciler(kubeClient, apiReader),
 	}
 }
 
@@ -124,6 +133,7 @@ func (controller *Controller) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&corev1.ConfigMap{}).
 		Owns(&corev1.Secret{}).
 		Owns(&corev1.Service{}).
+		Owns(&networkingv1.NetworkPolicy{}).
 		// Istio related resources are not registered as owned,
 		// because we can't be sure that they are present at Operator startup,
 		// istio could be installed later, or uninstalled while the Operator is present.
@@ -136,6 +146,7 @@ func (controller *Controller) SetupWithManager(mgr ctrl.Manager) error {
 
 type istioReconciler interface {
 	ReconcileAPIURL(ctx context.Context, dk *dynakube.DynaKube) error
+	ReconcileInClusterEndpoints(ctx context.Context, dk *dynakube.DynaKube) error
 }
 
 type dynakubeReconciler interface {
@@ -171,6 +182,11 @@ type injectionReconciler interface {
 	Reconcile(ctx context.Context, dtClient *dynatrace.Client, dk *dynakube.DynaKube) error
 }
 
+// workloadIdentityPullSecretReconciler returns the duration until the pull secret has to be refreshed.
+type workloadIdentityPullSecretReconciler interface {
+	Reconcile(ctx context.Context, dk *dynakube.DynaKube) (time.Duration, error)
+}
+
 type kubemonReconciler interface {
 	Reconcile(ctx context.Context, dk *dynakube.DynaKube, dtClient *dynatrace.Client, tokens token.Tokens) error
 }
@@ -189,6 +205,8 @@ type Controller struct {
 	kubemonReconciler            kubemonReconciler
 	otelColReconciler            dynakubeReconciler
 	proxyReconciler              dynakubeReconciler
+	remediationReconciler        dynakubeReconciler
+	vpaReconciler                dynakubeReconciler
 	deploymentMetadataReconciler dynakubeReconciler
 	istioReconciler              istioReconciler
 	logMonitoringReconciler      logMonitoringReconciler
@@ -196,6 +214,8 @@ type Controller struct {
 	activeGateReconciler         activeGateReconciler
 	injectionReconciler          injectionReconciler
 
+	workloadIdentityPullSecretReconciler workloadIdentityPullSecretReconciler
+
 	config *rest.Config
 
 	tokens            token.Tokens
@@ -205,6 +225,9 @@ type Controller struct {
 	defaultRequeueAfter time.Duration
 	requeueAfter        time.Duration
 
+	// componentErrors are the errors of the component reconcilers in the current reconcile, they are shown as the last error of the component
+	componentErrors map[dynakube.ComponentName]error
+
 	dtClientFactory dynatrace.ClientFactory
 }
 
@@ -238,13 +261,29 @@ func (controller *Controller) Reconcile(ctx context.Context, request reconcile.R
 	}
 
 	controller.requeueAfter = controller.defaultRequeueAfter
+	controller.componentErrors = nil
 	oldStatus := *dk.Status.DeepCopy()
+
+	ctx, throttleObserver := middleware.ContextWithThrottleObserver(ctx)
 	err = controller.reconcileDynaKube(ctx, dk)
+	controller.sendThrottlingEvent(dk, throttleObserver)
+
 	result, err := controller.handleError(ctx, dk, err, oldStatus)
 
 	return result, err
 }
 
+func (controller *Controller) sendThrottlingEvent(dk *dynakube.DynaKube, observer *middleware.ThrottleObserver) {
+	clientThrottled, clientDelay := observer.Throttled(middleware.ClientSideThrottle)
+	serverThrottled, serverDelay := observer.Throttled(middleware.ServerSideThrottle)
+
+	if clientThrottled+serverThrottled == 0 {
+		return
+	}
+
+	k8sevent.SendDynatraceAPIThrottled(controller.eventRecorder, dk, clientThrottled+serverThrottled, serverThrottled, clientDelay+serverDelay)
+}
+
 func (controller *Controller) getDynakubeOrCleanup(ctx context.Context, dkName, dkNamespace string) (*dynakube.DynaKube, error) {
 	dk := &dynakube.DynaKube{
 		ObjectMeta: metav1.ObjectMeta{
@@ -255,7 +294,7 @@ func (controller *Controller) getDynakubeOrCleanup(ctx context.Context, dkName,
 
 	err := controller.apiReader.Get(ctx, client.ObjectKey{Name: dk.Name, Namespace: dk.Namespace}, dk)
 	if k8serrors.IsNotFound(err) {
-		namespaces, err := mapper.GetNamespacesForDynakube(ctx, controller.apiReader, dkName)
+		namespaces, err := mapper.GetNamespacesForDynakube(ctx, controller.apiReader, dk)
 		if err != nil {
 			return nil, errors.WithMessagef(err, "failed to list namespaces for dynakube %s", dkName)
 		}
@@ -284,6 +323,8 @@ func (controller *Controller) handleError(
 		return reconcile.Result{RequeueAfter: fastRequeueInterval}, nil
 
 	case reconcileErr != nil:
+		// the state of the components is still recorded, so the errors of the component reconcilers are visible
+		controller.determineDynaKubePhase(ctx, dk)
 		dk.Status.SetPhase(dynatracestatus.Error)
 
 	default:
@@ -317,6 +358,14 @@ func (controller *Controller) handleError(
 	return reconcile.Result{RequeueAfter: controller.requeueAfter}, nil
 }
 
+func (controller *Controller) setComponentError(name dynakube.ComponentName, err error) {
+	if controller.componentErrors == nil {
+		controller.componentErrors = map[dynakube.ComponentName]error{}
+	}
+
+	controller.componentErrors[name] = err
+}
+
 func (controller *Controller) setRequeueAfterIfNewIsShorter(requeueAfter time.Duration) {
 	if controller.requeueAfter > requeueAfter {
 		controller.requeueAfter = requeueAfter
@@ -331,6 +380,11 @@ func (controller *Controller) reconcileDynaKube(ctx context.Context, dk *dynakub
 		return errors.WithMessage(err, "failed to reconcile istio objects for API url")
 	}
 
+	err = controller.istioReconciler.ReconcileInClusterEndpoints(ctx, dk)
+	if err != nil {
+		return errors.WithMessage(err, "failed to reconcile istio objects for in-cluster endpoints")
+	}
+
 	dtClient, err := controller.setupTokensAndClient(ctx, dk)
 	if err != nil {
 		return err
@@ -351,6 +405,27 @@ func (controller *Controller) reconcileDynaKube(ctx context.Context, dk *dynakub
 		return err
 	}
 
+	// the remediated memory limits have to be known before the components are reconciled,
+	// a failure only means that the limits stay as they are, so it doesn't block the components
+	if err := controller.remediationReconciler.Reconcile(ctx, dk); err != nil {
+		log.Info("could not remediate unhealthy components", "error", err.Error())
+	}
+
+	// the generated pull secret is only refreshed here, failing to do so doesn't block the components,
+	// as the current credentials are still valid for a while
+	if refreshIn, err := controller.workloadIdentityPullSecretReconciler.Reconcile(ctx, dk); err != nil {
+		log.Info("could not reconcile workload identity pull secret", "error", err.Error())
+		controller.setRequeueAfterIfNewIsShorter(fastRequeueInterval)
+	} else if refreshIn > 0 {
+		controller.setRequeueAfterIfNewIsShorter(max(refreshIn, fastRequeueInterval))
+	}
+
+	if err := controller.vpaReconciler.Reconcile(ctx, dk); err != nil {
+		log.Info("could not reconcile VerticalPodAutoscalers")
+
+		return err
+	}
+
 	return controller.reconcileComponents(ctx, dtClient, dk)
 }
 
@@ -404,6 +479,7 @@ func (controller *Controller) reconcileComponents(ctx context.Context, dtClient
 		log.Info("could not reconcile ActiveGate")
 
 		componentErrors = append(componentErrors, err)
+		controller.setComponentError(dynakube.ActiveGateComponent, err)
 	}
 
 	log.Debug("start reconciling KubernetesMonitoring")
@@ -416,6 +492,7 @@ func (controller *Controller) reconcileComponents(ctx context.Context, dtClient
 			log.Info("could not reconcile KubernetesMonitoring")
 
 			componentErrors = append(componentErrors, err)
+			controller.setComponentError(dynakube.KubernetesMonitoringComponent, err)
 		}
 	}
 
@@ -423,6 +500,9 @@ func (controller *Controller) reconcileComponents(ctx context.Context, dtClient
 		log.Info("could not reconcile Extensions")
 
 		componentErrors = append(componentErrors, err)
+		// the extension reconciler covers the execution controller and the database datasources
+		controller.setComponentError(dynakube.ExtensionExecutionControllerComponent, err)
+		controller.setComponentError(dynakube.ExtensionDatabasesComponent, err)
 	}
 
 	log.Info("start reconciling otel-collector")
@@ -431,6 +511,7 @@ func (controller *Controller) reconcileComponents(ctx context.Context, dtClient
 		log.Info("could not reconcile otelc")
 
 		componentErrors = append(componentErrors, err)
+		controller.setComponentError(dynakube.OTelCollectorComponent, err)
 	}
 
 	log.Info("start reconciling KSPM")
@@ -439,6 +520,7 @@ func (controller *Controller) reconcileComponents(ctx context.Context, dtClient
 		log.Info("could not reconcile kspm")
 
 		componentErrors = append(componentErrors, err)
+		controller.setComponentError(dynakube.KSPMComponent, err)
 	}
 
 	log.Info("start reconciling LogMonitoring")
@@ -454,6 +536,7 @@ func (controller *Controller) reconcileComponents(ctx context.Context, dtClient
 		log.Info("could not reconcile LogMonitoring")
 
 		componentErrors = append(componentErrors, err)
+		controller.setComponentError(dynakube.LogMonitoringComponent, err)
 	}
 
 	log.Info("start reconciling app injection")
@@ -488,6 +571,7 @@ func (controller *Controller) reconcileComponents(ctx context.Context, dtClient
 		log.Info("could not reconcile OneAgent")
 
 		componentErrors = append(componentErrors, err)
+		controller.setComponentError(dynakube.OneAgentComponent, err)
 	}
 
 	return goerrors.Join(componentErrors...)
@@ -215,6 +215,27 @@ func TestHandleError(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, status.Error, dk.Status.Phase)
 	})
+	t.Run("component error => error, set last error of component", func(t *testing.T) {
+		oldDynakube := dynakubeBase.DeepCopy()
+		oldDynakube.Spec.ActiveGate.Capabilities = []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName}
+		fakeClient := fake.NewClientWithIndex(oldDynakube, createCRD(t))
+		controller := &Controller{
+			client:    fakeClient,
+			apiReader: fakeClient,
+		}
+		componentError := errors.New("BOOM")
+		controller.setComponentError(dynakube.ActiveGateComponent, componentError)
+
+		result, err := controller.handleError(ctx, oldDynakube, componentError, oldDynakube.Status)
+		assert.Empty(t, result)
+		require.Error(t, err)
+
+		dk := &dynakube.DynaKube{}
+		err = fakeClient.Get(ctx, types.NamespacedName{Name: oldDynakube.Name, Namespace: oldDynakube.Namespace}, dk)
+		require.NoError(t, err)
+		assert.Equal(t, status.Error, dk.Status.Phase)
+		assert.Equal(t, "BOOM", dk.Status.Components[dynakube.ActiveGateComponent].LastError)
+	})
 }
 
 func TestSetupTokensAndClient(t *testing.T) {
@@ -567,6 +588,15 @@ func TestReconcileDynaKube(t *testing.T) {
 	mockProxyReconciler := newMockDynakubeReconciler(t)
 	mockProxyReconciler.EXPECT().Reconcile(anyCtx, anyDynaKube).Return(nil)
 
+	mockRemediationReconciler := newMockDynakubeReconciler(t)
+	mockRemediationReconciler.EXPECT().Reconcile(anyCtx, anyDynaKube).Return(nil)
+
+	mockVPAReconciler := newMockDynakubeReconciler(t)
+	mockVPAReconciler.EXPECT().Reconcile(anyCtx, anyDynaKube).Return(nil)
+
+	mockWorkloadIdentityPullSecretReconciler := newMockWorkloadIdentityPullSecretReconciler(t)
+	mockWorkloadIdentityPullSecretReconciler.EXPECT().Reconcile(anyCtx, anyDynaKube).Return(0, nil)
+
 	mockOneAgentReconciler := newMockOneAgentReconciler(t)
 	mockOneAgentReconciler.EXPECT().Reconcile(anyCtx, anyDynaKube, dtClient, mock.Anything).Return(nil)
 
@@ -587,6 +617,7 @@ func TestReconcileDynaKube(t *testing.T) {
 
 	mockIstioReconciler := newMockIstioReconciler(t)
 	mockIstioReconciler.EXPECT().ReconcileAPIURL(anyCtx, anyDynaKube).Return(nil)
+	mockIstioReconciler.EXPECT().ReconcileInClusterEndpoints(anyCtx, anyDynaKube).Return(nil)
 
 	mockKSPMReconciler := newMockDtSettingReconciler(t)
 	mockKSPMReconciler.EXPECT().Reconcile(anyCtx, dtClient.Settings, anyDynaKube).Return(nil)
@@ -608,11 +639,15 @@ func TestReconcileDynaKube(t *testing.T) {
 		logMonitoringReconciler:      mockLogMonitoringReconciler,
 		otelColReconciler:            mockOTelColReconciler,
 		proxyReconciler:              mockProxyReconciler,
+		remediationReconciler:        mockRemediationReconciler,
+		vpaReconciler:                mockVPAReconciler,
 		kspmReconciler:               mockKSPMReconciler,
 		kubemonReconciler:            mockKubemonReconciler,
 		k8sEntityReconciler:          mockK8sEntityReconciler,
 		oneAgentReconciler:           mockOneAgentReconciler,
 		activeGateReconciler:         mockActiveGateReconciler,
+
+		workloadIdentityPullSecretReconciler: mockWorkloadIdentityPullSecretReconciler,
 	}
 
 	request := reconcile.Request{
@@ -699,6 +734,8 @@ func TestGetDynakube(t *testing.T) {
 		assert.NotNil(t, dynakube.Spec.OneAgent.CloudNativeFullStack)
 	})
 	t.Run("unmap if not not found", func(t *testing.T) {
+		t.Setenv(k8senv.PodNamespace, testNamespace)
+
 		namespace := &corev1.Namespace{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:   testNamespace,
@@ -0,0 +1,172 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package dtpullsecret
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8sconditions"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8ssecret"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/oci/workloadidentity"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/timeprovider"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	WorkloadIdentityPullSecretConditionType = "WorkloadIdentityPullSecret"
+
+	annotationRefreshAfter = api.InternalFlagPrefix + "refresh-after"
+	annotationRegistries   = api.InternalFlagPrefix + "workload-identity-registries"
+)
+
+type credentialsResolver interface {
+	GetCredentials(ctx context.Context, registry string) (workloadidentity.Credentials, error)
+}
+
+// WorkloadIdentityReconciler maintains the pull secret for the registries in spec.workloadIdentityRegistries,
+// the short-lived credentials are obtained with the workload identity of the operator and replaced after half of their lifetime.
+type WorkloadIdentityReconciler struct {
+	resolver     credentialsResolver
+	timeProvider *timeprovider.Provider
+	secrets      k8ssecret.QueryObject
+}
+
+func NewWorkloadIdentityReconciler(clt client.Client, apiReader client.Reader) *WorkloadIdentityReconciler {
+	return &WorkloadIdentityReconciler{
+		secrets:      k8ssecret.Query(clt, apiReader),
+		resolver:     workloadidentity.NewResolver(),
+		timeProvider: timeprovider.New(),
+	}
+}
+
+// Reconcile returns the duration until the pull secret has to be refreshed, it is zero if no pull secret is needed.
+func (r *WorkloadIdentityReconciler) Reconcile(ctx context.Context, dk *dynakube.DynaKube) (time.Duration, error) {
+	ctx, log := logd.NewFromContext(ctx, "pullsecret-workload-identity")
+
+	if len(dk.Spec.WorkloadIdentityRegistries) == 0 {
+		if meta.FindStatusCondition(*dk.Conditions(), WorkloadIdentityPullSecretConditionType) == nil {
+			return 0, nil // no condition == nothing is there to clean up
+		}
+
+		secret, _ := k8ssecret.Build(dk, dk.WorkloadIdentityPullSecretName(), nil)
+
+		log.Info("deleting pull secret", "secretName", secret.Name)
+
+		if err := r.secrets.Delete(ctx, secret); err != nil && !k8serrors.IsNotFound(err) {
+			k8sconditions.SetKubeAPIError(dk.Conditions(), WorkloadIdentityPullSecretConditionType, err)
+
+			return 0, errors.WithMessagef(err, "failed to delete secret %s", secret.Name)
+		}
+
+		meta.RemoveStatusCondition(dk.Conditions(), WorkloadIdentityPullSecretConditionType)
+
+		return 0, nil
+	}
+
+	now := r.timeProvider.Now().Time
+
+	current, err := r.secrets.Get(ctx, client.ObjectKey{Name: dk.WorkloadIdentityPullSecretName(), Namespace: dk.Namespace})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		k8sconditions.SetKubeAPIError(dk.Conditions(), WorkloadIdentityPullSecretConditionType, err)
+
+		return 0, errors.WithStack(err)
+	}
+
+	if err == nil {
+		if refreshAfter, ok := getRefreshAfter(current, dk.Spec.WorkloadIdentityRegistries); ok && now.Before(refreshAfter) {
+			return refreshAfter.Sub(now), nil
+		}
+	}
+
+	return r.refreshPullSecret(ctx, dk, now)
+}
+
+func (r *WorkloadIdentityReconciler) refreshPullSecret(ctx context.Context, dk *dynakube.DynaKube, now time.Time) (time.Duration, error) {
+	log := logd.FromContext(ctx)
+
+	dockerCfg := &dockerConfig{Auths: map[string]dockerAuthentication{}}
+
+	var refreshAfter time.Time
+
+	for _, registry := range dk.Spec.WorkloadIdentityRegistries {
+		credentials, err := r.resolver.GetCredentials(ctx, registry)
+		if err != nil {
+			k8sconditions.SetSecretGenFailed(dk.Conditions(), WorkloadIdentityPullSecretConditionType, err)
+
+			return 0, err
+		}
+
+		dockerCfg.Auths[registry] = dockerAuthentication{
+			Username: credentials.Username,
+			Password: credentials.Password,
+			Auth:     basicAuth(credentials.Username, credentials.Password),
+		}
+
+		if registryRefreshAfter := credentials.RefreshAt(now); refreshAfter.IsZero() || registryRefreshAfter.Before(refreshAfter) {
+			refreshAfter = registryRefreshAfter
+		}
+	}
+
+	pullSecretData, err := pullSecretDataFromDockerConfig(dockerCfg)
+	if err != nil {
+		k8sconditions.SetSecretGenFailed(dk.Conditions(), WorkloadIdentityPullSecretConditionType, err)
+
+		return 0, err
+	}
+
+	coreLabels := k8slabel.NewCoreLabels(dk.Name, k8slabel.OperatorComponentLabel)
+
+	secret, err := k8ssecret.Build(dk,
+		dk.WorkloadIdentityPullSecretName(), pullSecretData,
+		k8ssecret.SetType(corev1.SecretTypeDockerConfigJson),
+		k8ssecret.SetLabels(coreLabels.BuildLabels()),
+	)
+	if err != nil {
+		k8sconditions.SetKubeAPIError(dk.Conditions(), WorkloadIdentityPullSecretConditionType, err)
+
+		return 0, errors.WithStack(err)
+	}
+
+	secret.Annotations = map[string]string{
+		annotationRefreshAfter: refreshAfter.UTC().Format(time.RFC3339),
+		annotationRegistries:   strings.Join(dk.Spec.WorkloadIdentityRegistries, ","),
+	}
+
+	if _, err := r.secrets.CreateOrUpdate(ctx, secret); err != nil {
+		log.Info("could not create or update secret", "secretName", secret.Name)
+		k8sconditions.SetKubeAPIError(dk.Conditions(), WorkloadIdentityPullSecretConditionType, errors.WithMessage(err, "failed to create or update secret"))
+
+		return 0, errors.WithMessage(err, "failed to create or update secret")
+	}
+
+	log.Info("refreshed workload identity pull secret", "secretName", secret.Name, "refreshAfter", refreshAfter)
+	k8sconditions.SetSecretCreatedOrUpdated(dk.Conditions(), WorkloadIdentityPullSecretConditionType, secret.Name)
+
+	return refreshAfter.Sub(now), nil
+}
+
+// getRefreshAfter reads the time after which the pull secret has to be refreshed,
+// it reports false if the pull secret was generated for other registries.
+func getRefreshAfter(secret *corev1.Secret, registries []string) (time.Time, bool) {
+	if secret.Annotations[annotationRegistries] != strings.Join(registries, ",") {
+		return time.Time{}, false
+	}
+
+	refreshAfter, err := time.Parse(time.RFC3339, secret.Annotations[annotationRefreshAfter])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return refreshAfter, true
+}
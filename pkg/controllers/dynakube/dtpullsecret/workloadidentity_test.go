@@ -0,0 +1,199 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package dtpullsecret
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8ssecret"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/oci/workloadidentity"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/timeprovider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	testECRRegistry = "123456789012.dkr.ecr.eu-west-1.amazonaws.com"
+	testACRRegistry = "dynatrace.azurecr.io"
+)
+
+type fakeResolver struct {
+	err         error
+	credentials map[string]workloadidentity.Credentials
+	calls       int
+}
+
+func (r *fakeResolver) GetCredentials(_ context.Context, registry string) (workloadidentity.Credentials, error) {
+	r.calls++
+
+	return r.credentials[registry], r.err
+}
+
+func TestWorkloadIdentityReconciler_Reconcile(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	newDynakube := func(registries ...string) *dynakube.DynaKube {
+		return &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: testNamespace},
+			Spec:       dynakube.DynaKubeSpec{WorkloadIdentityRegistries: registries},
+		}
+	}
+
+	newReconciler := func(clt client.Client, resolver credentialsResolver) *WorkloadIdentityReconciler {
+		timeProvider := timeprovider.New().Freeze()
+		timeProvider.Set(now)
+
+		return &WorkloadIdentityReconciler{
+			secrets:      k8ssecret.Query(clt, clt),
+			resolver:     resolver,
+			timeProvider: timeProvider,
+		}
+	}
+
+	newResolver := func() *fakeResolver {
+		return &fakeResolver{credentials: map[string]workloadidentity.Credentials{
+			testECRRegistry: {Username: "AWS", Password: "ecr-password", ExpiresAt: now.Add(12 * time.Hour)},
+			testACRRegistry: {Username: "00000000-0000-0000-0000-000000000000", Password: "acr-token", ExpiresAt: now.Add(3 * time.Hour)},
+		}}
+	}
+
+	getSecret := func(t *testing.T, clt client.Client, dk *dynakube.DynaKube) (*corev1.Secret, error) {
+		t.Helper()
+
+		var secret corev1.Secret
+		err := clt.Get(t.Context(), client.ObjectKey{Name: dk.WorkloadIdentityPullSecretName(), Namespace: testNamespace}, &secret)
+
+		return &secret, err
+	}
+
+	t.Run("creates pull secret for all registries", func(t *testing.T) {
+		dk := newDynakube(testECRRegistry, testACRRegistry)
+		clt := fake.NewClient()
+
+		refreshIn, err := newReconciler(clt, newResolver()).Reconcile(t.Context(), dk)
+		require.NoError(t, err)
+		assert.Equal(t, 90*time.Minute, refreshIn)
+
+		secret, err := getSecret(t, clt, dk)
+		require.NoError(t, err)
+		assert.Equal(t, corev1.SecretTypeDockerConfigJson, secret.Type)
+		assert.Equal(t, now.Add(90*time.Minute).Format(time.RFC3339), secret.Annotations[annotationRefreshAfter])
+
+		var cfg dockerConfig
+		require.NoError(t, json.Unmarshal(secret.Data[DockerConfigJSON], &cfg))
+		assert.Equal(t, "ecr-password", cfg.Auths[testECRRegistry].Password)
+		assert.Equal(t, basicAuth("AWS", "ecr-password"), cfg.Auths[testECRRegistry].Auth)
+		assert.Equal(t, "acr-token", cfg.Auths[testACRRegistry].Password)
+
+		condition := meta.FindStatusCondition(*dk.Conditions(), WorkloadIdentityPullSecretConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	})
+
+	t.Run("keeps pull secret until it has to be refreshed", func(t *testing.T) {
+		dk := newDynakube(testECRRegistry)
+		clt := fake.NewClient()
+		resolver := newResolver()
+		r := newReconciler(clt, resolver)
+
+		_, err := r.Reconcile(t.Context(), dk)
+		require.NoError(t, err)
+
+		r.timeProvider.Set(now.Add(5 * time.Hour))
+
+		refreshIn, err := r.Reconcile(t.Context(), dk)
+		require.NoError(t, err)
+		assert.Equal(t, time.Hour, refreshIn)
+		assert.Equal(t, 1, resolver.calls)
+
+		r.timeProvider.Set(now.Add(6 * time.Hour))
+
+		_, err = r.Reconcile(t.Context(), dk)
+		require.NoError(t, err)
+		assert.Equal(t, 2, resolver.calls)
+	})
+
+	t.Run("refreshes pull secret if registries change", func(t *testing.T) {
+		dk := newDynakube(testECRRegistry)
+		clt := fake.NewClient()
+		resolver := newResolver()
+		r := newReconciler(clt, resolver)
+
+		_, err := r.Reconcile(t.Context(), dk)
+		require.NoError(t, err)
+
+		dk.Spec.WorkloadIdentityRegistries = append(dk.Spec.WorkloadIdentityRegistries, testACRRegistry)
+
+		_, err = r.Reconcile(t.Context(), dk)
+		require.NoError(t, err)
+		assert.Equal(t, 3, resolver.calls)
+
+		secret, err := getSecret(t, clt, dk)
+		require.NoError(t, err)
+		assert.Contains(t, string(secret.Data[DockerConfigJSON]), testACRRegistry)
+	})
+
+	t.Run("credential error keeps existing pull secret", func(t *testing.T) {
+		dk := newDynakube(testECRRegistry)
+		clt := fake.NewClient()
+		resolver := newResolver()
+		r := newReconciler(clt, resolver)
+
+		_, err := r.Reconcile(t.Context(), dk)
+		require.NoError(t, err)
+
+		resolver.err = errors.New("boom")
+
+		r.timeProvider.Set(now.Add(7 * time.Hour))
+
+		_, err = r.Reconcile(t.Context(), dk)
+		require.Error(t, err)
+
+		_, err = getSecret(t, clt, dk)
+		require.NoError(t, err)
+
+		condition := meta.FindStatusCondition(*dk.Conditions(), WorkloadIdentityPullSecretConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	})
+
+	t.Run("deletes pull secret if registries are removed", func(t *testing.T) {
+		dk := newDynakube(testECRRegistry)
+		clt := fake.NewClient()
+		r := newReconciler(clt, newResolver())
+
+		_, err := r.Reconcile(t.Context(), dk)
+		require.NoError(t, err)
+
+		dk.Spec.WorkloadIdentityRegistries = nil
+
+		refreshIn, err := r.Reconcile(t.Context(), dk)
+		require.NoError(t, err)
+		assert.Zero(t, refreshIn)
+
+		_, err = getSecret(t, clt, dk)
+		require.True(t, k8serrors.IsNotFound(err))
+		assert.Nil(t, meta.FindStatusCondition(*dk.Conditions(), WorkloadIdentityPullSecretConditionType))
+	})
+
+	t.Run("nothing to do without registries", func(t *testing.T) {
+		resolver := newResolver()
+
+		refreshIn, err := newReconciler(fake.NewClient(), resolver).Reconcile(t.Context(), newDynakube())
+		require.NoError(t, err)
+		assert.Zero(t, refreshIn)
+		assert.Zero(t, resolver.calls)
+	})
+}
@@ -9,12 +9,16 @@ import (
 	"maps"
 	"slices"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/extensions"
 	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/extension/eec"
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8ssecuritycontext"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8ssecret"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -44,6 +48,14 @@ const (
 	customCertsVolumeName = "custom-certs"
 	customCertsMountPath  = "/var/ssl-certs/user"
 	customCertsFileName   = "custom.crt"
+
+	credentialsVolumeName   = "credentials"
+	credentialsMountPath    = "/var/run/dynatrace/executor/credentials"
+	databaseCertsVolumeName = "database-certs"
+	databaseCertsMountPath  = "/var/ssl-certs/database"
+
+	credentialsSecretHashAnnotation = api.InternalFlagPrefix + "sql-executor-credentials-hash"
+	tlsSecretHashAnnotation         = api.InternalFlagPrefix + "sql-executor-tls-hash"
 )
 
 // ListDeployments returns a list of database datasource deployments that are managed by the DynaKube.
@@ -95,7 +107,7 @@ func buildContainer(dk *dynakube.DynaKube, dbSpec extensions.DatabaseSpec, image
 		Name:            containerName,
 		Image:           imageURI,
 		ImagePullPolicy: dk.Spec.Templates.SQLExtensionExecutor.ImageRef.PullPolicy,
-		Args:            buildContainerArgs(dk),
+		Args:            buildContainerArgs(dk, dbSpec),
 		Env:             buildContainerEnvs(),
 		LivenessProbe: &corev1.Probe{
 			ProbeHandler: corev1.ProbeHandler{
@@ -131,10 +143,10 @@ func buildContainer(dk *dynakube.DynaKube, dbSpec extensions.DatabaseSpec, image
 	return container
 }
 
-func buildContainerArgs(dk *dynakube.DynaKube) []string {
+func buildContainerArgs(dk *dynakube.DynaKube, dbSpec extensions.DatabaseSpec) []string {
 	return []string{
 		"--podid=$(POD_UID)",
-		fmt.Sprintf("--url=https://%s:%d", dk.Extensions().GetServiceNameFQDN(), consts.ExtensionsDatasourceTargetPort),
+		fmt.Sprintf("--url=https://%s:%d", eec.GetDatasourceServiceFQDN(dk, eec.DatabaseDatasource(dbSpec.ID)), consts.ExtensionsDatasourceTargetPort),
 		"--idtoken=" + tokenMountPath + "/" + tokenVolumeName,
 	}
 }
@@ -181,9 +193,32 @@ func GetDefaultVolumeMounts(dk *dynakube.DynaKube) []corev1.VolumeMount {
 	return volumeMounts
 }
 
-func buildVolumeMounts(dk *dynakube.DynaKube, dbSpec extensions.DatabaseSpec) []corev1.VolumeMount {
+// GetDatabaseVolumeMounts returns the default volume mounts and the mounts of the credentials and TLS secrets of the database.
+func GetDatabaseVolumeMounts(dk *dynakube.DynaKube, dbSpec extensions.DatabaseSpec) []corev1.VolumeMount {
 	volumeMounts := GetDefaultVolumeMounts(dk)
 
+	if dbSpec.CredentialsSecretRef != nil {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      credentialsVolumeName,
+			MountPath: credentialsMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	if dbSpec.TLSSecretRef != nil {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      databaseCertsVolumeName,
+			MountPath: databaseCertsMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	return volumeMounts
+}
+
+func buildVolumeMounts(dk *dynakube.DynaKube, dbSpec extensions.DatabaseSpec) []corev1.VolumeMount {
+	volumeMounts := GetDatabaseVolumeMounts(dk, dbSpec)
+
 	return append(volumeMounts, dbSpec.VolumeMounts...)
 }
 
@@ -248,9 +283,63 @@ func buildVolumes(dk *dynakube.DynaKube, dbSpec extensions.DatabaseSpec) []corev
 		})
 	}
 
+	if dbSpec.CredentialsSecretRef != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: credentialsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  dbSpec.CredentialsSecretRef.Name,
+					DefaultMode: mode,
+				},
+			},
+		})
+	}
+
+	if dbSpec.TLSSecretRef != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: databaseCertsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  dbSpec.TLSSecretRef.Name,
+					DefaultMode: mode,
+				},
+			},
+		})
+	}
+
 	return append(volumes, dbSpec.Volumes...)
 }
 
+// buildSecretHashAnnotations hashes the content of the credentials and TLS secrets of the database, so that a rotation restarts the executor pods.
+func buildSecretHashAnnotations(ctx context.Context, apiReader client.Reader, dk *dynakube.DynaKube, dbSpec extensions.DatabaseSpec) (map[string]string, error) {
+	annotations := map[string]string{}
+
+	secretRefs := map[string]*corev1.LocalObjectReference{
+		credentialsSecretHashAnnotation: dbSpec.CredentialsSecretRef,
+		tlsSecretHashAnnotation:         dbSpec.TLSSecretRef,
+	}
+
+	for annotation, secretRef := range secretRefs {
+		if secretRef == nil {
+			continue
+		}
+
+		secret, err := k8ssecret.Query(nil, apiReader).Get(ctx, client.ObjectKey{Name: secretRef.Name, Namespace: dk.Namespace})
+		if err != nil {
+			return nil, fmt.Errorf("get secret %s of database %s: %w", secretRef.Name, dbSpec.ID, err)
+		}
+
+		hash, err := hasher.GenerateHash(secret.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		annotations[annotation] = hash
+	}
+
+	return annotations, nil
+}
+
 func buildContainerResources(custom *corev1.ResourceRequirements) corev1.ResourceRequirements {
 	if custom != nil {
 		return *custom
@@ -0,0 +1,38 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package databases
+
+import (
+	"context"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/networkpolicy"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8snetworkpolicy"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+const networkPolicyConditionType = "DatabaseDatasourcesNetworkPolicy"
+
+// buildNetworkPolicy creates one NetworkPolicy for the SQL executors of all databases.
+// Only the operator may connect to them to check their readiness, the egress isn't restricted as the databases can be anywhere.
+func buildNetworkPolicy(dk *dynakube.DynaKube) (*networkingv1.NetworkPolicy, error) {
+	appLabels := k8slabel.NewAppLabels(k8slabel.DatabaseSQLExecutorLabel, dk.Name, k8slabel.DatabaseSQLExecutorLabel, dk.Spec.Templates.SQLExtensionExecutor.ImageRef.Tag)
+
+	return k8snetworkpolicy.Build(dk, dk.Extensions().GetDatabasesNetworkPolicyName(), appLabels.BuildMatchLabels(),
+		k8snetworkpolicy.SetLabels(appLabels.BuildLabels()),
+		k8snetworkpolicy.AddIngressRules(networkpolicy.IngressFromOperator(networkpolicy.TCPPort(probePort))),
+		k8snetworkpolicy.AddEgressRules(networkpolicy.EgressToAll()),
+	)
+}
+
+func (r *Reconciler) reconcileNetworkPolicy(ctx context.Context, dk *dynakube.DynaKube) error {
+	if !dk.IsNetworkPolicyEnabled() || !dk.Extensions().IsDatabasesEnabled() {
+		return networkpolicy.Remove(ctx, r.client, r.apiReader, dk, networkPolicyConditionType, dk.Extensions().GetDatabasesNetworkPolicyName())
+	}
+
+	return networkpolicy.Reconcile(ctx, r.client, r.apiReader, dk, networkPolicyConditionType, func() (*networkingv1.NetworkPolicy, error) {
+		return buildNetworkPolicy(dk)
+	})
+}
@@ -0,0 +1,55 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package databases
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/networkpolicy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestNetworkPolicy(t *testing.T) {
+	t.Run("selects the executors of all databases and allows the operator", func(t *testing.T) {
+		dk := getTestDynakube()
+		dk.Spec.NetworkPolicies = &dynakube.NetworkPoliciesSpec{}
+		clt := fakeClient()
+
+		deployment := getReconciledDeployment(t, clt, dk)
+		require.NotNil(t, deployment)
+
+		var policy networkingv1.NetworkPolicy
+		require.NoError(t, clt.Get(t.Context(), client.ObjectKey{Name: dk.Extensions().GetDatabasesNetworkPolicyName(), Namespace: dk.Namespace}, &policy))
+
+		assert.True(t, labels.SelectorFromSet(policy.Spec.PodSelector.MatchLabels).Matches(labels.Set(deployment.Spec.Template.Labels)))
+		require.Len(t, policy.Spec.Ingress, 1)
+		assert.Equal(t, networkpolicy.IngressFromOperator(networkpolicy.TCPPort(probePort)), policy.Spec.Ingress[0])
+		assert.Equal(t, []networkingv1.NetworkPolicyEgressRule{networkpolicy.EgressToAll()}, policy.Spec.Egress)
+		assert.NotNil(t, meta.FindStatusCondition(*dk.Conditions(), networkPolicyConditionType))
+	})
+
+	t.Run("removed when the databases are removed", func(t *testing.T) {
+		dk := getTestDynakube()
+		dk.Spec.NetworkPolicies = &dynakube.NetworkPoliciesSpec{}
+		clt := fakeClient()
+		r := NewReconciler(clt, clt)
+		key := client.ObjectKey{Name: dk.Extensions().GetDatabasesNetworkPolicyName(), Namespace: dk.Namespace}
+
+		require.NoError(t, r.reconcileNetworkPolicy(t.Context(), dk))
+		require.NoError(t, clt.Get(t.Context(), key, &networkingv1.NetworkPolicy{}))
+
+		dk.Spec.Extensions.Databases = nil
+
+		require.NoError(t, r.reconcileNetworkPolicy(t.Context(), dk))
+		assert.True(t, k8serrors.IsNotFound(clt.Get(t.Context(), key, &networkingv1.NetworkPolicy{})))
+		assert.Nil(t, meta.FindStatusCondition(*dk.Conditions(), networkPolicyConditionType))
+	})
+}
@@ -6,6 +6,7 @@ package databases
 import (
 	"context"
 	"errors"
+	"maps"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/core"
@@ -13,8 +14,10 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/registry"
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8sconditions"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8spodtemplate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8ssecuritycontext"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8sdeployment"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/timeprovider"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -23,20 +26,28 @@ import (
 const conditionType = "DatabaseDatasourcesAvailable"
 
 type Reconciler struct {
-	client    client.Client
-	apiReader client.Reader
+	client         client.Client
+	apiReader      client.Reader
+	timeProvider   *timeprovider.Provider
+	probeReadiness ReadinessProbe
 }
 
 func NewReconciler(clt client.Client, apiReader client.Reader) *Reconciler {
 	return &Reconciler{
-		client:    clt,
-		apiReader: apiReader,
+		client:         clt,
+		apiReader:      apiReader,
+		timeProvider:   timeprovider.New(),
+		probeReadiness: probeReadinessEndpoint,
 	}
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, imageClient dtimage.Client, dk *dynakube.DynaKube) error {
 	ctx, log := logd.NewFromContext(ctx, "databases")
 
+	if err := r.reconcileNetworkPolicy(ctx, dk); err != nil {
+		return err
+	}
+
 	log.Debug("reconciling deployments")
 
 	ext := dk.Extensions()
@@ -54,6 +65,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, imageClient dtimage.Client,
 
 	if !ext.IsDatabasesEnabled() {
 		_ = meta.RemoveStatusCondition(dk.Conditions(), conditionType)
+		dk.Status.Extensions.Databases = nil
 
 		return nil
 	}
@@ -83,11 +95,20 @@ func (r *Reconciler) Reconcile(ctx context.Context, imageClient dtimage.Client,
 			return err
 		}
 
+		templateAnnotations, err := buildSecretHashAnnotations(ctx, r.apiReader, dk, dbSpec)
+		if err != nil {
+			k8sconditions.SetKubeAPIError(dk.Conditions(), conditionType, err)
+
+			return err
+		}
+
+		maps.Copy(templateAnnotations, k8ssecuritycontext.RemoveAppArmorAnnotation(dbSpec.Annotations, containerName))
+
 		deploy, err := k8sdeployment.Build(
 			dk, ext.GetDatabaseDatasourceName(dbSpec.ID),
 			k8sdeployment.SetReplicas(replicas),
 			k8sdeployment.SetAllLabels(buildAllLabels(dk, dbSpec)),
-			k8sdeployment.SetAllAnnotations(nil, k8ssecuritycontext.RemoveAppArmorAnnotation(dbSpec.Annotations, containerName)),
+			k8sdeployment.SetAllAnnotations(nil, templateAnnotations),
 			k8sdeployment.SetAffinity(dbSpec.Affinity),
 			k8sdeployment.SetTolerations(dk.Spec.Templates.SQLExtensionExecutor.Tolerations),
 			k8sdeployment.SetTopologySpreadConstraints(dbSpec.TopologySpreadConstraints),
@@ -105,6 +126,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, imageClient dtimage.Client,
 			return err
 		}
 
+		if err := k8spodtemplate.Apply(&deploy.Spec.Template, dk.Spec.Templates.SQLExtensionExecutor.PodTemplatePatch); err != nil {
+			k8sconditions.SetKubeAPIError(dk.Conditions(), conditionType, err)
+
+			return err
+		}
+
 		changed, err := query.WithOwner(dk).CreateOrUpdate(ctx, deploy)
 		if err != nil {
 			k8sconditions.SetKubeAPIError(dk.Conditions(), conditionType, err)
@@ -119,6 +146,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, imageClient dtimage.Client,
 
 	k8sconditions.SetDeploymentsApplied(dk, conditionType, expectedDeploymentNames)
 
+	r.updateStatus(ctx, dk)
+
 	return nil
 }
 
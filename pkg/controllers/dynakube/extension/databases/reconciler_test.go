@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/exp"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
@@ -17,6 +18,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/core"
 	dtimage "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/image"
 	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/extension/eec"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8senv"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/version"
@@ -438,6 +440,230 @@ func TestPublicRegistryImage(t *testing.T) {
 	})
 }
 
+func TestExecutionControllerURL(t *testing.T) {
+	t.Run("common service without sharding", func(t *testing.T) {
+		dk := getTestDynakube()
+		dk.Spec.Templates.ExtensionExecutionController.Replicas = new(int32(1))
+
+		deploy := getReconciledDeployment(t, fakeClient(), dk)
+		require.NotNil(t, deploy)
+
+		assert.Contains(t, deploy.Spec.Template.Spec.Containers[0].Args, fmt.Sprintf("--url=https://%s:%d", dk.Extensions().GetServiceNameFQDN(), consts.ExtensionsDatasourceTargetPort))
+	})
+
+	t.Run("datasource service with sharding", func(t *testing.T) {
+		dk := getTestDynakube()
+		dk.Spec.Templates.ExtensionExecutionController.Replicas = new(int32(3))
+		dk.Status.Extensions.ExecutionController.Assignments = []extensions.DatasourceAssignment{
+			{Datasource: eec.DatabaseDatasource("test"), Replica: 2},
+		}
+
+		deploy := getReconciledDeployment(t, fakeClient(), dk)
+		require.NotNil(t, deploy)
+
+		assert.Contains(t, deploy.Spec.Template.Spec.Containers[0].Args, fmt.Sprintf("--url=https://%s:%d", dk.Extensions().GetDatasourceServiceNameFQDN(eec.DatabaseDatasource("test")), consts.ExtensionsDatasourceTargetPort))
+	})
+}
+
+func TestSecretReferences(t *testing.T) {
+	const (
+		credentialsSecretName = "db-credentials"
+		tlsSecretName         = "db-tls"
+	)
+
+	newSecret := func(name, value string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespaceName},
+			Data:       map[string][]byte{"key": []byte(value)},
+		}
+	}
+
+	newDynakube := func() *dynakube.DynaKube {
+		dk := getTestDynakube()
+		dk.Spec.Extensions.Databases[0].CredentialsSecretRef = &corev1.LocalObjectReference{Name: credentialsSecretName}
+		dk.Spec.Extensions.Databases[0].TLSSecretRef = &corev1.LocalObjectReference{Name: tlsSecretName}
+
+		return dk
+	}
+
+	t.Run("secrets are mounted", func(t *testing.T) {
+		dk := newDynakube()
+		clt := fake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(newSecret(credentialsSecretName, "user"), newSecret(tlsSecretName, "cert")).
+			Build()
+
+		deploy := getReconciledDeployment(t, clt, dk)
+		require.NotNil(t, deploy)
+
+		podSpec := deploy.Spec.Template.Spec
+		assert.Contains(t, podSpec.Volumes, corev1.Volume{
+			Name: credentialsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: credentialsSecretName, DefaultMode: new(int32(0o640))},
+			},
+		})
+		assert.Contains(t, podSpec.Volumes, corev1.Volume{
+			Name: databaseCertsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: tlsSecretName, DefaultMode: new(int32(0o640))},
+			},
+		})
+		assert.Contains(t, podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{Name: credentialsVolumeName, MountPath: credentialsMountPath, ReadOnly: true})
+		assert.Contains(t, podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{Name: databaseCertsVolumeName, MountPath: databaseCertsMountPath, ReadOnly: true})
+		assert.NotEmpty(t, deploy.Spec.Template.Annotations[credentialsSecretHashAnnotation])
+		assert.NotEmpty(t, deploy.Spec.Template.Annotations[tlsSecretHashAnnotation])
+	})
+
+	t.Run("secret rotation changes hash annotation", func(t *testing.T) {
+		dk := newDynakube()
+		clt := fake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(newSecret(credentialsSecretName, "user"), newSecret(tlsSecretName, "cert")).
+			Build()
+
+		before := getReconciledDeployment(t, clt, dk)
+		require.NotNil(t, before)
+
+		require.NoError(t, clt.Update(t.Context(), newSecret(credentialsSecretName, "rotated")))
+
+		after := getReconciledDeployment(t, clt, dk)
+		require.NotNil(t, after)
+
+		assert.NotEqual(t, before.Spec.Template.Annotations[credentialsSecretHashAnnotation], after.Spec.Template.Annotations[credentialsSecretHashAnnotation])
+		assert.Equal(t, before.Spec.Template.Annotations[tlsSecretHashAnnotation], after.Spec.Template.Annotations[tlsSecretHashAnnotation])
+	})
+
+	t.Run("user annotations are kept", func(t *testing.T) {
+		dk := newDynakube()
+		dk.Spec.Extensions.Databases[0].Annotations = map[string]string{"foo": "bar"}
+		clt := fake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(newSecret(credentialsSecretName, "user"), newSecret(tlsSecretName, "cert")).
+			Build()
+
+		deploy := getReconciledDeployment(t, clt, dk)
+		require.NotNil(t, deploy)
+
+		assert.Equal(t, "bar", deploy.Spec.Template.Annotations["foo"])
+		assert.Contains(t, deploy.Spec.Template.Annotations, credentialsSecretHashAnnotation)
+	})
+
+	t.Run("missing secret => error", func(t *testing.T) {
+		dk := newDynakube()
+		clt := fake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(newSecret(tlsSecretName, "cert")).
+			Build()
+
+		err := NewReconciler(clt, clt).Reconcile(t.Context(), nil, dk)
+		require.Error(t, err)
+
+		condition := meta.FindStatusCondition(*dk.Conditions(), conditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	})
+}
+
+func TestConnectionStatus(t *testing.T) {
+	newPod := func(dk *dynakube.DynaKube, name string, phase corev1.PodPhase) *corev1.Pod {
+		_, _, podLabels := buildAllLabels(dk, dk.Spec.Extensions.Databases[0])
+
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespaceName, Labels: podLabels},
+			Status:     corev1.PodStatus{Phase: phase, PodIP: "10.0.0.1"},
+		}
+	}
+
+	reconcile := func(t *testing.T, dk *dynakube.DynaKube, probe ReadinessProbe, objs ...client.Object) {
+		t.Helper()
+		t.Cleanup(version.DisableCacheForTest(123))
+
+		clt := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objs...).Build()
+		r := NewReconciler(clt, clt)
+		r.probeReadiness = probe
+
+		require.NoError(t, r.Reconcile(t.Context(), nil, dk))
+	}
+
+	connected := func(context.Context, *corev1.Pod) error { return nil }
+	disconnected := func(context.Context, *corev1.Pod) error { return errors.New("connection refused") }
+
+	t.Run("connected", func(t *testing.T) {
+		dk := getTestDynakube()
+		reconcile(t, dk, connected, newPod(dk, "pod-1", corev1.PodRunning), newPod(dk, "pod-2", corev1.PodPending))
+
+		require.Len(t, dk.Status.Extensions.Databases, 1)
+		status := dk.Status.Extensions.Databases[0]
+		assert.Equal(t, "test", status.ID)
+		assert.Equal(t, extensions.DatabaseConnected, status.ConnectionState)
+		assert.Equal(t, int32(1), status.Replicas)
+		assert.Equal(t, int32(1), status.ConnectedReplicas)
+		assert.False(t, status.LastProbeTimestamp.IsZero())
+	})
+
+	t.Run("disconnected", func(t *testing.T) {
+		dk := getTestDynakube()
+		reconcile(t, dk, disconnected, newPod(dk, "pod-1", corev1.PodRunning))
+
+		require.Len(t, dk.Status.Extensions.Databases, 1)
+		status := dk.Status.Extensions.Databases[0]
+		assert.Equal(t, extensions.DatabaseDisconnected, status.ConnectionState)
+		assert.Equal(t, int32(0), status.ConnectedReplicas)
+		assert.Contains(t, status.Message, "connection refused")
+	})
+
+	t.Run("no running pods", func(t *testing.T) {
+		dk := getTestDynakube()
+		reconcile(t, dk, connected)
+
+		require.Len(t, dk.Status.Extensions.Databases, 1)
+		assert.Equal(t, extensions.DatabaseUnknown, dk.Status.Extensions.Databases[0].ConnectionState)
+	})
+
+	t.Run("pods of other databases are ignored", func(t *testing.T) {
+		dk := getTestDynakube()
+		otherPod := newPod(dk, "pod-other", corev1.PodRunning)
+		otherPod.Labels[executorIDLabelKey] = "other"
+		reconcile(t, dk, connected, otherPod)
+
+		require.Len(t, dk.Status.Extensions.Databases, 1)
+		assert.Equal(t, extensions.DatabaseUnknown, dk.Status.Extensions.Databases[0].ConnectionState)
+	})
+
+	t.Run("probe is skipped until the previous one is outdated", func(t *testing.T) {
+		dk := getTestDynakube()
+		clt := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(newPod(dk, "pod-1", corev1.PodRunning)).Build()
+		t.Cleanup(version.DisableCacheForTest(123))
+
+		probes := 0
+		r := NewReconciler(clt, clt)
+		r.probeReadiness = func(context.Context, *corev1.Pod) error {
+			probes++
+
+			return nil
+		}
+		r.timeProvider.Freeze()
+
+		require.NoError(t, r.Reconcile(t.Context(), nil, dk))
+		require.NoError(t, r.Reconcile(t.Context(), nil, dk))
+		assert.Equal(t, 1, probes)
+
+		r.timeProvider.Set(r.timeProvider.Now().Add(probeInterval + time.Second))
+		require.NoError(t, r.Reconcile(t.Context(), nil, dk))
+		assert.Equal(t, 2, probes)
+	})
+
+	t.Run("status is cleared when databases are removed", func(t *testing.T) {
+		dk := getTestDynakube()
+		dk.Status.Extensions.Databases = []extensions.DatabaseStatus{{ID: "test"}}
+		dk.Spec.Extensions.Databases = nil
+		reconcile(t, dk, connected)
+
+		assert.Empty(t, dk.Status.Extensions.Databases)
+	})
+}
+
 func fakeClient() client.Client {
 	return fake.NewClientBuilder().
 		WithScheme(scheme.Scheme).
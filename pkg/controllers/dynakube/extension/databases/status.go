@@ -0,0 +1,135 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package databases
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/extensions"
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	probeTimeout = 2 * time.Second
+
+	// probeInterval limits how often the executors are probed, as every status change triggers another reconcile.
+	probeInterval = 5 * time.Minute
+)
+
+// ReadinessProbe checks whether the sql-executor in the given pod is connected to its database.
+type ReadinessProbe func(ctx context.Context, pod *corev1.Pod) error
+
+var httpReadinessProbeClient = &http.Client{Timeout: probeTimeout}
+
+// probeReadinessEndpoint queries the readiness endpoint of the executor, which only reports ready once the database connection is established.
+func probeReadinessEndpoint(ctx context.Context, pod *corev1.Pod) error {
+	url := "http://" + net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(probePort))) + readinessProbePath
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := httpReadinessProbeClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("readiness endpoint returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) updateStatus(ctx context.Context, dk *dynakube.DynaKube) {
+	log := logd.FromContext(ctx)
+
+	ext := dk.Extensions()
+	statuses := make([]extensions.DatabaseStatus, 0, len(ext.Databases))
+
+	previousStatuses := make(map[string]extensions.DatabaseStatus, len(dk.Status.Extensions.Databases))
+	for _, status := range dk.Status.Extensions.Databases {
+		previousStatuses[status.ID] = status
+	}
+
+	for _, dbSpec := range ext.Databases {
+		if previous, ok := previousStatuses[dbSpec.ID]; ok && !r.timeProvider.IsOutdated(&previous.LastProbeTimestamp, probeInterval) {
+			statuses = append(statuses, previous)
+
+			continue
+		}
+
+		status, err := r.probeDatabase(ctx, dk, dbSpec)
+		if err != nil {
+			log.Info("failed to determine connection state of database executor", "id", dbSpec.ID, "error", err.Error())
+
+			status = extensions.DatabaseStatus{
+				ID:              dbSpec.ID,
+				ConnectionState: extensions.DatabaseUnknown,
+				Message:         err.Error(),
+			}
+		}
+
+		status.LastProbeTimestamp = *r.timeProvider.Now()
+		statuses = append(statuses, status)
+	}
+
+	dk.Status.Extensions.Databases = statuses
+}
+
+func (r *Reconciler) probeDatabase(ctx context.Context, dk *dynakube.DynaKube, dbSpec extensions.DatabaseSpec) (extensions.DatabaseStatus, error) {
+	_, matchLabels, _ := buildAllLabels(dk, dbSpec)
+	matchLabels[executorIDLabelKey] = dbSpec.ID
+
+	var pods corev1.PodList
+	if err := r.apiReader.List(ctx, &pods, client.InNamespace(dk.Namespace), client.MatchingLabels(matchLabels)); err != nil {
+		return extensions.DatabaseStatus{}, err
+	}
+
+	status := extensions.DatabaseStatus{ID: dbSpec.ID}
+
+	var failures []string
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" || pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		status.Replicas++
+
+		if err := r.probeReadiness(ctx, pod); err != nil {
+			failures = append(failures, pod.Name+": "+err.Error())
+
+			continue
+		}
+
+		status.ConnectedReplicas++
+	}
+
+	switch {
+	case status.Replicas == 0:
+		status.ConnectionState = extensions.DatabaseUnknown
+		status.Message = "no running executor pods"
+	case status.ConnectedReplicas == 0:
+		status.ConnectionState = extensions.DatabaseDisconnected
+		status.Message = strings.Join(failures, "; ")
+	default:
+		status.ConnectionState = extensions.DatabaseConnected
+		status.Message = strings.Join(failures, "; ")
+	}
+
+	return status, nil
+}
@@ -0,0 +1,47 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package eec
+
+import (
+	"context"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
+	agconsts "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/networkpolicy"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8snetworkpolicy"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+const networkPolicyConditionType = "ExtensionControllerNetworkPolicy"
+
+// buildNetworkPolicy creates the NetworkPolicy for the ExtensionExecutionController pods, it has the same name as the statefulset.
+// The datasources (OpenTelemetry collector and SQL executors) connect to the EEC, which itself only talks to the ActiveGate.
+func buildNetworkPolicy(dk *dynakube.DynaKube) (*networkingv1.NetworkPolicy, error) {
+	appLabels := buildAppLabels(dk)
+	datasourcePort := networkpolicy.TCPPort(consts.ExtensionsDatasourceTargetPort)
+
+	return k8snetworkpolicy.Build(dk, dk.Extensions().GetExecutionControllerStatefulsetName(), appLabels.BuildMatchLabels(),
+		k8snetworkpolicy.SetLabels(appLabels.BuildLabels()),
+		k8snetworkpolicy.AddIngressRules(
+			networkpolicy.IngressFromPods(networkpolicy.OTelCollectorPods(dk), datasourcePort),
+			networkpolicy.IngressFromPods(networkpolicy.SQLExecutorPods(dk), datasourcePort),
+		),
+		k8snetworkpolicy.AddEgressRules(
+			networkpolicy.DNSEgress(),
+			networkpolicy.KubernetesAPIEgress(dk),
+			networkpolicy.EgressToPods(networkpolicy.ActiveGatePods(dk), networkpolicy.TCPPort(agconsts.HTTPSContainerPort)),
+		),
+	)
+}
+
+func (r *Reconciler) reconcileNetworkPolicy(ctx context.Context, dk *dynakube.DynaKube) error {
+	if !dk.IsNetworkPolicyEnabled() || !dk.Extensions().IsAnyEnabled() {
+		return networkpolicy.Remove(ctx, r.client, r.apiReader, dk, networkPolicyConditionType, dk.Extensions().GetExecutionControllerStatefulsetName())
+	}
+
+	return networkpolicy.Reconcile(ctx, r.client, r.apiReader, dk, networkPolicyConditionType, func() (*networkingv1.NetworkPolicy, error) {
+		return buildNetworkPolicy(dk)
+	})
+}
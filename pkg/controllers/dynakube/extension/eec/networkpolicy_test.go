@@ -0,0 +1,61 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package eec
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/networkpolicy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestNetworkPolicy(t *testing.T) {
+	t.Run("allows the datasources and the ActiveGate", func(t *testing.T) {
+		dk := getTestDynakube()
+
+		policy, err := buildNetworkPolicy(dk)
+		require.NoError(t, err)
+
+		assert.Equal(t, "dynakube-extension-controller", policy.Name)
+		assert.Equal(t, buildAppLabels(dk).BuildMatchLabels(), policy.Spec.PodSelector.MatchLabels)
+		require.Len(t, policy.Spec.Ingress, 2)
+		assert.Equal(t, networkpolicy.OTelCollectorPods(dk), policy.Spec.Ingress[0].From[0].PodSelector.MatchLabels)
+		assert.Equal(t, networkpolicy.SQLExecutorPods(dk), policy.Spec.Ingress[1].From[0].PodSelector.MatchLabels)
+		require.Len(t, policy.Spec.Egress, 3)
+		assert.Equal(t, networkpolicy.ActiveGatePods(dk), policy.Spec.Egress[2].To[0].PodSelector.MatchLabels)
+	})
+
+	t.Run("created if enabled and removed when disabled", func(t *testing.T) {
+		dk := getTestDynakube()
+		dk.Spec.NetworkPolicies = &dynakube.NetworkPoliciesSpec{}
+		clt := fake.NewClient(dk)
+		r := NewReconciler(clt, clt)
+		key := client.ObjectKey{Name: dk.Extensions().GetExecutionControllerStatefulsetName(), Namespace: dk.Namespace}
+
+		require.NoError(t, r.reconcileNetworkPolicy(t.Context(), dk))
+		require.NoError(t, clt.Get(t.Context(), key, &networkingv1.NetworkPolicy{}))
+		assert.NotNil(t, meta.FindStatusCondition(*dk.Conditions(), networkPolicyConditionType))
+
+		dk.Spec.NetworkPolicies = nil
+
+		require.NoError(t, r.reconcileNetworkPolicy(t.Context(), dk))
+		assert.True(t, k8serrors.IsNotFound(clt.Get(t.Context(), key, &networkingv1.NetworkPolicy{})))
+		assert.Nil(t, meta.FindStatusCondition(*dk.Conditions(), networkPolicyConditionType))
+	})
+
+	t.Run("not created by default", func(t *testing.T) {
+		dk := getTestDynakube()
+		clt := fake.NewClient(dk)
+
+		require.NoError(t, NewReconciler(clt, clt).reconcileNetworkPolicy(t.Context(), dk))
+		assert.Nil(t, meta.FindStatusCondition(*dk.Conditions(), networkPolicyConditionType))
+	})
+}
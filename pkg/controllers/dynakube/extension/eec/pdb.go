@@ -0,0 +1,97 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package eec
+
+import (
+	"context"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8sconditions"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8spdb"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	podDisruptionBudgetConditionType = "ExtensionControllerPodDisruptionBudget"
+	podDisruptionBudgetCreatedReason = "PodDisruptionBudgetCreated"
+)
+
+// buildPodDisruptionBudget creates the PodDisruptionBudget for the ExtensionExecutionController pods, it has the same name as the statefulset.
+// By default only one pod may be unavailable at a time, so the datasources can always fail over to the remaining replicas.
+func buildPodDisruptionBudget(dk *dynakube.DynaKube) *policyv1.PodDisruptionBudget {
+	appLabels := buildAppLabels(dk)
+
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dk.Extensions().GetExecutionControllerStatefulsetName(),
+			Namespace: dk.Namespace,
+			Labels:    appLabels.BuildLabels(),
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: appLabels.BuildMatchLabels(),
+			},
+		},
+	}
+
+	spec := dk.Spec.Templates.ExtensionExecutionController.PodDisruptionBudget
+
+	switch {
+	case spec != nil && spec.MinAvailable != nil:
+		pdb.Spec.MinAvailable = spec.MinAvailable
+	case spec != nil && spec.MaxUnavailable != nil:
+		pdb.Spec.MaxUnavailable = spec.MaxUnavailable
+	default:
+		pdb.Spec.MaxUnavailable = new(intstr.FromInt32(1))
+	}
+
+	return pdb
+}
+
+// reconcilePodDisruptionBudget only keeps a PodDisruptionBudget if the datasources are sharded between multiple replicas,
+// for a single replica it would only block node drains.
+func (r *Reconciler) reconcilePodDisruptionBudget(ctx context.Context, dk *dynakube.DynaKube) error {
+	log := logd.FromContext(ctx)
+	query := k8spdb.Query(r.client, r.apiReader).WithOwner(dk)
+
+	if ext := dk.Extensions(); !ext.IsAnyEnabled() || !ext.IsExecutionControllerSharded() {
+		if meta.FindStatusCondition(*dk.Conditions(), podDisruptionBudgetConditionType) == nil {
+			return nil
+		}
+
+		err := query.Delete(ctx, &policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: ext.GetExecutionControllerStatefulsetName(), Namespace: dk.Namespace}})
+		if err != nil {
+			k8sconditions.SetKubeAPIError(dk.Conditions(), podDisruptionBudgetConditionType, err)
+
+			return err
+		}
+
+		_ = meta.RemoveStatusCondition(dk.Conditions(), podDisruptionBudgetConditionType)
+
+		return nil
+	}
+
+	desiredPDB := buildPodDisruptionBudget(dk)
+
+	if _, err := query.CreateOrUpdate(ctx, desiredPDB); err != nil {
+		log.Info("could not create or update PodDisruptionBudget for " + desiredPDB.Name)
+		k8sconditions.SetKubeAPIError(dk.Conditions(), podDisruptionBudgetConditionType, err)
+
+		return err
+	}
+
+	meta.SetStatusCondition(dk.Conditions(), metav1.Condition{
+		Type:               podDisruptionBudgetConditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             podDisruptionBudgetCreatedReason,
+		Message:            desiredPDB.Name + " created",
+		ObservedGeneration: dk.GetGeneration(),
+	})
+
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package eec
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/extensions"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestPodDisruptionBudget(t *testing.T) {
+	t.Run("defaults to maxUnavailable 1", func(t *testing.T) {
+		pdb := buildPodDisruptionBudget(getTestDynakube())
+
+		assert.Equal(t, "dynakube-extension-controller", pdb.Name)
+		assert.Equal(t, new(intstr.FromInt32(1)), pdb.Spec.MaxUnavailable)
+		assert.Nil(t, pdb.Spec.MinAvailable)
+		assert.Equal(t, buildAppLabels(getTestDynakube()).BuildMatchLabels(), pdb.Spec.Selector.MatchLabels)
+	})
+
+	t.Run("custom minAvailable", func(t *testing.T) {
+		dk := getTestDynakube()
+		dk.Spec.Templates.ExtensionExecutionController.PodDisruptionBudget = &extensions.PodDisruptionBudgetSpec{MinAvailable: new(intstr.FromString("50%"))}
+		pdb := buildPodDisruptionBudget(dk)
+
+		assert.Equal(t, new(intstr.FromString("50%")), pdb.Spec.MinAvailable)
+		assert.Nil(t, pdb.Spec.MaxUnavailable)
+	})
+
+	t.Run("created for multiple replicas and removed when scaled down", func(t *testing.T) {
+		dk := getTestDynakube()
+		dk.Spec.Templates.ExtensionExecutionController.Replicas = new(int32(2))
+		clt := fake.NewClient(dk)
+		r := NewReconciler(clt, clt)
+		key := client.ObjectKey{Name: dk.Extensions().GetExecutionControllerStatefulsetName(), Namespace: dk.Namespace}
+
+		require.NoError(t, r.reconcilePodDisruptionBudget(t.Context(), dk))
+		require.NoError(t, clt.Get(t.Context(), key, &policyv1.PodDisruptionBudget{}))
+		assert.NotNil(t, meta.FindStatusCondition(*dk.Conditions(), podDisruptionBudgetConditionType))
+
+		dk.Spec.Templates.ExtensionExecutionController.Replicas = nil
+
+		require.NoError(t, r.reconcilePodDisruptionBudget(t.Context(), dk))
+		assert.True(t, k8serrors.IsNotFound(clt.Get(t.Context(), key, &policyv1.PodDisruptionBudget{})))
+		assert.Nil(t, meta.FindStatusCondition(*dk.Conditions(), podDisruptionBudgetConditionType))
+	})
+
+	t.Run("not created for a single replica", func(t *testing.T) {
+		dk := getTestDynakube()
+		clt := fake.NewClient(dk)
+
+		require.NoError(t, NewReconciler(clt, clt).reconcilePodDisruptionBudget(t.Context(), dk))
+		assert.Nil(t, meta.FindStatusCondition(*dk.Conditions(), podDisruptionBudgetConditionType))
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/extensions"
 	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/image"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/registry"
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
@@ -38,7 +39,18 @@ func (r *Reconciler) Reconcile(ctx context.Context, imageClient image.Client, dk
 	// TODO: Remove as part of ICP-1086
 	meta.RemoveStatusCondition(dk.Conditions(), "ExtensionsControllerStatefulSet")
 
+	if err := r.reconcilePodDisruptionBudget(ctx, dk); err != nil {
+		return err
+	}
+
+	if err := r.reconcileNetworkPolicy(ctx, dk); err != nil {
+		return err
+	}
+
 	if ext := dk.Extensions(); !ext.IsAnyEnabled() {
+		dk.Status.Extensions.ExecutionController = extensions.ExecutionControllerStatus{}
+		_ = meta.RemoveStatusCondition(dk.Conditions(), availabilityConditionType)
+
 		if meta.FindStatusCondition(*dk.Conditions(), extensionControllerStatefulSetConditionType) == nil {
 			return nil
 		}
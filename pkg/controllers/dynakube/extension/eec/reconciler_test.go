@@ -192,6 +192,14 @@ func TestStatefulsetBase(t *testing.T) {
 		assert.Equal(t, int32(1), *statefulSet.Spec.Replicas)
 	})
 
+	t.Run("custom replicas", func(t *testing.T) {
+		dk := getTestDynakube()
+		dk.Spec.Templates.ExtensionExecutionController.Replicas = new(int32(3))
+		statefulSet := getStatefulset(t, dk)
+
+		assert.Equal(t, int32(3), *statefulSet.Spec.Replicas)
+	})
+
 	t.Run("pod management policy", func(t *testing.T) {
 		statefulSet := getStatefulset(t, getTestDynakube())
 
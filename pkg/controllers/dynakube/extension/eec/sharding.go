@@ -0,0 +1,229 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package eec
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/extensions"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	availabilityConditionType = "ExtensionControllerAvailability"
+
+	availableReason          = "Available"
+	partiallyAvailableReason = "PartiallyAvailable"
+	unavailableReason        = "Unavailable"
+)
+
+// PrometheusDatasource is the name of the datasource that is served by the OpenTelemetry collector.
+const PrometheusDatasource = "prometheus"
+
+// DatabaseDatasource returns the name of the datasource that is served by the SQL extension executor of the given database.
+func DatabaseDatasource(id string) string {
+	return "database-" + id
+}
+
+// GetDatasourceServiceFQDN returns the address a datasource has to connect to.
+// With a single replica it is the common Service, otherwise the Service of the datasource,
+// which targets the replica the datasource is assigned to. The address doesn't depend on the assignment,
+// so pod templates using it are not rolled out again if a replica fails over.
+func GetDatasourceServiceFQDN(dk *dynakube.DynaKube, datasource string) string {
+	ext := dk.Extensions()
+
+	if ext.IsExecutionControllerSharded() {
+		return ext.GetDatasourceServiceNameFQDN(datasource)
+	}
+
+	return ext.GetServiceNameFQDN()
+}
+
+// GetAllServiceFQDNs returns the address of the common Service and, if sharded, of all datasource Services.
+func GetAllServiceFQDNs(dk *dynakube.DynaKube) []string {
+	ext := dk.Extensions()
+	fqdns := []string{ext.GetServiceNameFQDN()}
+
+	if ext.IsExecutionControllerSharded() {
+		for _, datasource := range ListDatasources(dk) {
+			fqdns = append(fqdns, ext.GetDatasourceServiceNameFQDN(datasource))
+		}
+	}
+
+	return fqdns
+}
+
+// GetAssignedReplica returns the ordinal of the replica the datasource is assigned to.
+// If the status doesn't contain an assignment yet, all replicas are considered.
+func GetAssignedReplica(dk *dynakube.DynaKube, datasource string) int32 {
+	for _, assignment := range dk.Status.Extensions.ExecutionController.Assignments {
+		if assignment.Datasource == datasource {
+			return assignment.Replica
+		}
+	}
+
+	return assignReplica(datasource, dk.Extensions().GetExecutionControllerReplicas(), nil)
+}
+
+// ListDatasources returns the names of all enabled datasources.
+func ListDatasources(dk *dynakube.DynaKube) []string {
+	ext := dk.Extensions()
+
+	var datasources []string
+
+	if ext.IsPrometheusEnabled() {
+		datasources = append(datasources, PrometheusDatasource)
+	}
+
+	for _, database := range ext.Databases {
+		datasources = append(datasources, DatabaseDatasource(database.ID))
+	}
+
+	return datasources
+}
+
+// assignReplica picks the replica for a datasource with rendezvous hashing.
+// Only ready replicas are considered, so datasources of an unavailable replica fail over to the remaining ones,
+// while the assignment of all other datasources stays stable. If no replica is ready, all replicas are considered.
+func assignReplica(datasource string, replicas int32, ready []int32) int32 {
+	candidates := ready
+	if len(candidates) == 0 {
+		for replica := range replicas {
+			candidates = append(candidates, replica)
+		}
+	}
+
+	var (
+		best      int32
+		bestScore uint64
+	)
+
+	for i, replica := range candidates {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(datasource + "/" + strconv.Itoa(int(replica))))
+
+		if score := h.Sum64(); i == 0 || score > bestScore {
+			best, bestScore = replica, score
+		}
+	}
+
+	return best
+}
+
+func buildAssignments(dk *dynakube.DynaKube, ready []int32) []extensions.DatasourceAssignment {
+	ext := dk.Extensions()
+	if !ext.IsExecutionControllerSharded() {
+		return nil
+	}
+
+	datasources := ListDatasources(dk)
+	assignments := make([]extensions.DatasourceAssignment, 0, len(datasources))
+
+	for _, datasource := range datasources {
+		assignments = append(assignments, extensions.DatasourceAssignment{
+			Datasource: datasource,
+			Replica:    assignReplica(datasource, ext.GetExecutionControllerReplicas(), ready),
+		})
+	}
+
+	return assignments
+}
+
+// listReadyReplicas returns the ordinals of the ExtensionExecutionController pods that are ready, sorted.
+func (r *Reconciler) listReadyReplicas(ctx context.Context, dk *dynakube.DynaKube) ([]int32, error) {
+	ext := dk.Extensions()
+
+	var pods corev1.PodList
+
+	err := r.apiReader.List(ctx, &pods, client.InNamespace(dk.Namespace), client.MatchingLabels(buildAppLabels(dk).BuildMatchLabels()))
+	if err != nil {
+		return nil, err
+	}
+
+	var ready []int32
+
+	for _, pod := range pods.Items {
+		ordinal, ok := strings.CutPrefix(pod.Name, ext.GetExecutionControllerStatefulsetName()+"-")
+		if !ok {
+			continue
+		}
+
+		replica, err := strconv.ParseInt(ordinal, 10, 32)
+		if err != nil || int32(replica) >= ext.GetExecutionControllerReplicas() || !isPodReady(&pod) {
+			continue
+		}
+
+		ready = append(ready, int32(replica))
+	}
+
+	slices.Sort(ready)
+
+	return ready, nil
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil {
+		return false
+	}
+
+	return slices.ContainsFunc(pod.Status.Conditions, func(condition corev1.PodCondition) bool {
+		return condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue
+	})
+}
+
+func (r *Reconciler) updateStatus(ctx context.Context, dk *dynakube.DynaKube) error {
+	ready, err := r.listReadyReplicas(ctx, dk)
+	if err != nil {
+		return err
+	}
+
+	dk.Status.Extensions.ExecutionController = extensions.ExecutionControllerStatus{
+		Replicas:      dk.Extensions().GetExecutionControllerReplicas(),
+		ReadyReplicas: int32(len(ready)),
+		Assignments:   buildAssignments(dk, ready),
+	}
+
+	setAvailabilityCondition(dk)
+
+	return nil
+}
+
+// setAvailabilityCondition reports how many replicas serve the datasources if they are sharded.
+// The phase stays running as long as one replica is ready, so a partial outage is only visible in this condition.
+func setAvailabilityCondition(dk *dynakube.DynaKube) {
+	if !dk.Extensions().IsExecutionControllerSharded() {
+		_ = meta.RemoveStatusCondition(dk.Conditions(), availabilityConditionType)
+
+		return
+	}
+
+	ecStatus := dk.Status.Extensions.ExecutionController
+	condition := metav1.Condition{
+		Type:               availabilityConditionType,
+		Status:             metav1.ConditionFalse,
+		Message:            fmt.Sprintf("%d of %d replicas ready", ecStatus.ReadyReplicas, ecStatus.Replicas),
+		ObservedGeneration: dk.GetGeneration(),
+	}
+
+	switch {
+	case ecStatus.ReadyReplicas >= ecStatus.Replicas:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = availableReason
+	case ecStatus.ReadyReplicas > 0:
+		condition.Reason = partiallyAvailableReason
+	default:
+		condition.Reason = unavailableReason
+	}
+
+	meta.SetStatusCondition(dk.Conditions(), condition)
+}
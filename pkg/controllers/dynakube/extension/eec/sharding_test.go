@@ -0,0 +1,221 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package eec
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/extensions"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestAssignReplica(t *testing.T) {
+	t.Run("assignment is deterministic", func(t *testing.T) {
+		assert.Equal(t, assignReplica("database-a", 3, []int32{0, 1, 2}), assignReplica("database-a", 3, []int32{0, 1, 2}))
+	})
+
+	t.Run("datasources are spread between replicas", func(t *testing.T) {
+		used := map[int32]bool{}
+
+		for i := range 50 {
+			used[assignReplica(DatabaseDatasource(strconv.Itoa(i)), 3, []int32{0, 1, 2})] = true
+		}
+
+		assert.Len(t, used, 3)
+	})
+
+	t.Run("only ready replicas are assigned", func(t *testing.T) {
+		for i := range 50 {
+			assert.Equal(t, int32(1), assignReplica(DatabaseDatasource(strconv.Itoa(i)), 3, []int32{1}))
+		}
+	})
+
+	t.Run("unavailable replica only moves its own datasources", func(t *testing.T) {
+		for i := range 50 {
+			datasource := DatabaseDatasource(strconv.Itoa(i))
+
+			before := assignReplica(datasource, 3, []int32{0, 1, 2})
+			after := assignReplica(datasource, 3, []int32{0, 2})
+
+			if before != 1 {
+				assert.Equal(t, before, after)
+			} else {
+				assert.NotEqual(t, int32(1), after)
+			}
+		}
+	})
+
+	t.Run("all replicas are considered if none is ready", func(t *testing.T) {
+		assert.Less(t, assignReplica("prometheus", 3, nil), int32(3))
+	})
+}
+
+func TestShardingStatus(t *testing.T) {
+	newPod := func(name string, ready bool) *corev1.Pod {
+		condition := corev1.ConditionFalse
+		if ready {
+			condition = corev1.ConditionTrue
+		}
+
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: testNamespaceName,
+				Labels:    buildAppLabels(getTestDynakube()).BuildMatchLabels(),
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: condition}},
+			},
+		}
+	}
+
+	reconcile := func(t *testing.T, replicas int32, objs ...client.Object) *extensions.ExecutionControllerStatus {
+		t.Helper()
+		t.Cleanup(version.DisableCacheForTest(123))
+
+		dk := getTestDynakube()
+		dk.Spec.Templates.ExtensionExecutionController.Replicas = new(replicas)
+		dk.Spec.Extensions.Databases = []extensions.DatabaseSpec{{ID: "a"}, {ID: "b"}}
+
+		clt := fake.NewClient(objs...)
+		clt = mockTLSSecret(t, clt, dk)
+
+		require.NoError(t, NewReconciler(clt, clt).Reconcile(t.Context(), nil, dk))
+
+		return &dk.Status.Extensions.ExecutionController
+	}
+
+	t.Run("single replica has no assignments", func(t *testing.T) {
+		status := reconcile(t, 1, newPod("dynakube-extension-controller-0", true))
+
+		assert.Equal(t, int32(1), status.Replicas)
+		assert.Equal(t, int32(1), status.ReadyReplicas)
+		assert.Empty(t, status.Assignments)
+	})
+
+	t.Run("datasources are assigned to ready replicas", func(t *testing.T) {
+		status := reconcile(t, 3,
+			newPod("dynakube-extension-controller-0", false),
+			newPod("dynakube-extension-controller-1", true),
+			newPod("dynakube-extension-controller-2", false),
+		)
+
+		assert.Equal(t, int32(3), status.Replicas)
+		assert.Equal(t, int32(1), status.ReadyReplicas)
+		assert.ElementsMatch(t, []extensions.DatasourceAssignment{
+			{Datasource: PrometheusDatasource, Replica: 1},
+			{Datasource: DatabaseDatasource("a"), Replica: 1},
+			{Datasource: DatabaseDatasource("b"), Replica: 1},
+		}, status.Assignments)
+	})
+
+	t.Run("pods beyond the desired replicas are ignored", func(t *testing.T) {
+		status := reconcile(t, 2,
+			newPod("dynakube-extension-controller-0", true),
+			newPod("dynakube-extension-controller-5", true),
+		)
+
+		assert.Equal(t, int32(1), status.ReadyReplicas)
+	})
+}
+
+func TestSetAvailabilityCondition(t *testing.T) {
+	newDynakube := func(replicas, ready int32) *dynakube.DynaKube {
+		dk := getTestDynakube()
+		dk.Spec.Templates.ExtensionExecutionController.Replicas = new(replicas)
+		dk.Status.Extensions.ExecutionController = extensions.ExecutionControllerStatus{Replicas: replicas, ReadyReplicas: ready}
+
+		return dk
+	}
+
+	t.Run("no condition without sharding", func(t *testing.T) {
+		dk := newDynakube(1, 0)
+		meta.SetStatusCondition(dk.Conditions(), metav1.Condition{Type: availabilityConditionType, Status: metav1.ConditionTrue, Reason: availableReason})
+
+		setAvailabilityCondition(dk)
+
+		assert.Nil(t, meta.FindStatusCondition(*dk.Conditions(), availabilityConditionType))
+	})
+
+	t.Run("all replicas ready", func(t *testing.T) {
+		dk := newDynakube(3, 3)
+
+		setAvailabilityCondition(dk)
+
+		condition := meta.FindStatusCondition(*dk.Conditions(), availabilityConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, availableReason, condition.Reason)
+	})
+
+	t.Run("partially available", func(t *testing.T) {
+		dk := newDynakube(3, 1)
+
+		setAvailabilityCondition(dk)
+
+		condition := meta.FindStatusCondition(*dk.Conditions(), availabilityConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, partiallyAvailableReason, condition.Reason)
+		assert.Equal(t, "1 of 3 replicas ready", condition.Message)
+	})
+
+	t.Run("no replica ready", func(t *testing.T) {
+		dk := newDynakube(3, 0)
+
+		setAvailabilityCondition(dk)
+
+		condition := meta.FindStatusCondition(*dk.Conditions(), availabilityConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, unavailableReason, condition.Reason)
+	})
+}
+
+func TestGetDatasourceServiceFQDN(t *testing.T) {
+	t.Run("common service without sharding", func(t *testing.T) {
+		dk := getTestDynakube()
+
+		assert.Equal(t, dk.Extensions().GetServiceNameFQDN(), GetDatasourceServiceFQDN(dk, PrometheusDatasource))
+		assert.Equal(t, []string{dk.Extensions().GetServiceNameFQDN()}, GetAllServiceFQDNs(dk))
+	})
+
+	t.Run("datasource service independent of the assignment", func(t *testing.T) {
+		dk := getTestDynakube()
+		dk.Spec.Templates.ExtensionExecutionController.Replicas = new(int32(2))
+
+		assert.Equal(t, "dynakube-eec-prometheus.dynatrace", GetDatasourceServiceFQDN(dk, PrometheusDatasource))
+
+		dk.Status.Extensions.ExecutionController.Assignments = []extensions.DatasourceAssignment{{Datasource: PrometheusDatasource, Replica: 1}}
+
+		assert.Equal(t, "dynakube-eec-prometheus.dynatrace", GetDatasourceServiceFQDN(dk, PrometheusDatasource))
+		assert.Len(t, GetAllServiceFQDNs(dk), 1+len(ListDatasources(dk)))
+	})
+}
+
+func TestGetAssignedReplica(t *testing.T) {
+	t.Run("replica from the status", func(t *testing.T) {
+		dk := getTestDynakube()
+		dk.Spec.Templates.ExtensionExecutionController.Replicas = new(int32(3))
+		dk.Status.Extensions.ExecutionController.Assignments = []extensions.DatasourceAssignment{{Datasource: PrometheusDatasource, Replica: 2}}
+
+		assert.Equal(t, int32(2), GetAssignedReplica(dk, PrometheusDatasource))
+	})
+
+	t.Run("all replicas are considered without assignment", func(t *testing.T) {
+		dk := getTestDynakube()
+		dk.Spec.Templates.ExtensionExecutionController.Replicas = new(int32(3))
+
+		assert.Equal(t, assignReplica(PrometheusDatasource, 3, nil), GetAssignedReplica(dk, PrometheusDatasource))
+	})
+}
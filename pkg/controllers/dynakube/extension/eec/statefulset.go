@@ -19,6 +19,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8saffinity"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8sconditions"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8spodtemplate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8ssecuritycontext"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8stopology"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8ssecret"
@@ -103,7 +104,7 @@ func (r *Reconciler) createOrUpdateStatefulset(ctx context.Context, dk *dynakube
 	}
 
 	desiredSts, err := k8sstatefulset.Build(dk, dk.Extensions().GetExecutionControllerStatefulsetName(), buildContainer(dk, imageURI),
-		k8sstatefulset.SetReplicas(1),
+		k8sstatefulset.SetReplicas(dk.Extensions().GetExecutionControllerReplicas()),
 		k8sstatefulset.SetPodManagementPolicy(appsv1.ParallelPodManagement),
 		k8sstatefulset.SetAllLabels(appLabels.BuildLabels(), appLabels.BuildMatchLabels(), appLabels.BuildLabels(), dk.Spec.Templates.ExtensionExecutionController.Labels),
 		k8sstatefulset.SetAllAnnotations(nil, templateAnnotations),
@@ -123,6 +124,12 @@ func (r *Reconciler) createOrUpdateStatefulset(ctx context.Context, dk *dynakube
 		return err
 	}
 
+	if err := k8spodtemplate.Apply(&desiredSts.Spec.Template, dk.Spec.Templates.ExtensionExecutionController.PodTemplatePatch); err != nil {
+		k8sconditions.SetKubeAPIError(dk.Conditions(), extensionControllerStatefulSetConditionType, err)
+
+		return err
+	}
+
 	_, err = k8sstatefulset.Query(r.client, r.apiReader).WithOwner(dk).CreateOrUpdate(ctx, desiredSts)
 	if err != nil {
 		log.Info("failed to create/update " + dk.Extensions().GetExecutionControllerStatefulsetName() + " statefulset")
@@ -133,6 +140,13 @@ func (r *Reconciler) createOrUpdateStatefulset(ctx context.Context, dk *dynakube
 
 	k8sconditions.SetStatefulSetCreated(dk.Conditions(), extensionControllerStatefulSetConditionType, desiredSts.Name)
 
+	if err := r.updateStatus(ctx, dk); err != nil {
+		log.Info("failed to determine the ready replicas of " + desiredSts.Name)
+		k8sconditions.SetKubeAPIError(dk.Conditions(), extensionControllerStatefulSetConditionType, err)
+
+		return err
+	}
+
 	return nil
 }
 
@@ -195,7 +209,7 @@ func buildContainer(dk *dynakube.DynaKube, imageURI string) corev1.Container {
 			},
 		},
 		Env:          buildContainerEnvs(dk),
-		Resources:    dk.Spec.Templates.ExtensionExecutionController.Resources,
+		Resources:    dk.GetRemediatedResources(dynakube.ExtensionExecutionControllerComponent),
 		VolumeMounts: buildContainerVolumeMounts(dk),
 	}
 }
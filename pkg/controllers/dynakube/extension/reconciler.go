@@ -76,6 +76,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, imageClient dtimage.Client,
 		return err
 	}
 
+	// the datasource Services select the replicas assigned by the eec reconciler
+	err = r.reconcileDatasourceServices(ctx, dk)
+	if err != nil {
+		return err
+	}
+
 	if err := r.databasesReconciler.Reconcile(ctx, imageClient, dk); err != nil {
 		return err
 	}
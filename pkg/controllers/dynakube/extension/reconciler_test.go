@@ -16,11 +16,13 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/image"
 	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
 	eecConsts "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/extension/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/extension/eec"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/dttoken"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8sconditions"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8ssecret"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -204,6 +206,42 @@ func TestReconciler_ReconcileService(t *testing.T) {
 		assert.Equal(t, dk.Name+eecConsts.ExtensionControllerSuffix+" created", condition.Message)
 	})
 
+	t.Run("Create datasource services when extension controller is sharded and remove them when scaled down", func(t *testing.T) {
+		dk := createDynakube()
+		dk.Spec.Extensions = &extensions.Spec{Prometheus: &extensions.PrometheusSpec{}}
+		dk.Spec.Templates.ExtensionExecutionController.Replicas = new(int32(2))
+		dk.Status.Extensions.ExecutionController.Assignments = []extensions.DatasourceAssignment{{Datasource: eec.PrometheusDatasource, Replica: 1}}
+
+		mockK8sClient := fake.NewClient(dk)
+		r := NewReconciler(mockK8sClient, mockK8sClient)
+
+		require.NoError(t, r.reconcileService(t.Context(), dk))
+		require.NoError(t, r.reconcileDatasourceServices(t.Context(), dk))
+
+		var svc corev1.Service
+		key := client.ObjectKey{Name: dk.Extensions().GetDatasourceServiceName(eec.PrometheusDatasource), Namespace: testNamespace}
+		require.NoError(t, mockK8sClient.Get(t.Context(), key, &svc))
+		assert.Equal(t, dk.Extensions().GetExecutionControllerStatefulsetName()+"-1", svc.Spec.Selector[appsv1.StatefulSetPodNameLabel])
+
+		// failover only changes the selector, the service is kept
+		uid := svc.UID
+		dk.Status.Extensions.ExecutionController.Assignments = []extensions.DatasourceAssignment{{Datasource: eec.PrometheusDatasource, Replica: 0}}
+		require.NoError(t, r.reconcileDatasourceServices(t.Context(), dk))
+
+		require.NoError(t, mockK8sClient.Get(t.Context(), key, &svc))
+		assert.Equal(t, dk.Extensions().GetExecutionControllerStatefulsetName()+"-0", svc.Spec.Selector[appsv1.StatefulSetPodNameLabel])
+		assert.Equal(t, uid, svc.UID)
+
+		dk.Spec.Templates.ExtensionExecutionController.Replicas = nil
+		require.NoError(t, r.reconcileService(t.Context(), dk))
+		require.NoError(t, r.reconcileDatasourceServices(t.Context(), dk))
+
+		var services corev1.ServiceList
+		require.NoError(t, mockK8sClient.List(t.Context(), &services, client.InNamespace(testNamespace)))
+		require.Len(t, services.Items, 1)
+		assert.Equal(t, dk.Extensions().GetServiceName(), services.Items[0].Name)
+	})
+
 	t.Run("Don't create service when extensions are disabled with minimal setup", func(t *testing.T) {
 		dk := createDynakube()
 		dk.Spec.Extensions = nil
@@ -5,18 +5,26 @@ package extension
 
 import (
 	"context"
+	"slices"
+	"strconv"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/extension/eec"
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8sconditions"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8sservice"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// datasourceLabelKey marks the Services that only target the ExtensionExecutionController replica a single datasource is assigned to.
+const datasourceLabelKey = "extensions.dynatrace.com/datasource"
+
 func (r *Reconciler) reconcileService(ctx context.Context, dk *dynakube.DynaKube) error {
 	log := logd.FromContext(ctx)
 
@@ -38,12 +46,86 @@ func (r *Reconciler) reconcileService(ctx context.Context, dk *dynakube.DynaKube
 			log.Error(err, "failed to clean up extension service")
 		}
 
+		if err := r.deleteDatasourceServices(ctx, dk, nil); err != nil {
+			log.Error(err, "failed to clean up extension datasource services")
+		}
+
 		return nil
 	}
 
 	return r.createOrUpdateService(ctx, dk)
 }
 
+// reconcileDatasourceServices creates a Service per datasource if the datasources are sharded.
+// The Service targets the replica the datasource is assigned to, so the address of a datasource stays the same on failover.
+func (r *Reconciler) reconcileDatasourceServices(ctx context.Context, dk *dynakube.DynaKube) error {
+	if !dk.Extensions().IsAnyEnabled() {
+		return nil
+	}
+
+	var datasources []string
+	if dk.Extensions().IsExecutionControllerSharded() {
+		datasources = eec.ListDatasources(dk)
+	}
+
+	// The selector of a datasource Service follows the assigned replica, it is updated in place so the ClusterIP is kept.
+	query := k8sservice.Query(r.client, r.apiReader)
+	query.MustRecreate = func(_, _ *corev1.Service) bool { return false }
+
+	for _, datasource := range datasources {
+		svc, err := r.buildDatasourceService(dk, datasource)
+		if err != nil {
+			k8sconditions.SetServiceGenFailed(dk.Conditions(), serviceConditionType, err)
+
+			return err
+		}
+
+		if _, err := query.CreateOrUpdate(ctx, svc); err != nil {
+			k8sconditions.SetKubeAPIError(dk.Conditions(), serviceConditionType, err)
+
+			return err
+		}
+	}
+
+	if err := r.deleteDatasourceServices(ctx, dk, datasources); err != nil {
+		k8sconditions.SetKubeAPIError(dk.Conditions(), serviceConditionType, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// deleteDatasourceServices deletes the Services of all datasources that are not in the given list.
+func (r *Reconciler) deleteDatasourceServices(ctx context.Context, dk *dynakube.DynaKube, datasources []string) error {
+	coreLabels := k8slabel.NewCoreLabels(dk.Name, k8slabel.ExtensionComponentLabel)
+
+	var services corev1.ServiceList
+
+	err := r.apiReader.List(ctx, &services,
+		client.InNamespace(dk.Namespace),
+		client.MatchingLabels(coreLabels.BuildLabels()),
+		client.HasLabels{datasourceLabelKey},
+	)
+	if err != nil {
+		return err
+	}
+
+	for i := range services.Items {
+		svc := &services.Items[i]
+
+		if slices.Contains(datasources, svc.Labels[datasourceLabelKey]) {
+			continue
+		}
+
+		if err := k8sservice.Query(r.client, r.apiReader).Delete(ctx, svc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (r *Reconciler) createOrUpdateService(ctx context.Context, dk *dynakube.DynaKube) error {
 	log := logd.FromContext(ctx)
 
@@ -88,3 +170,32 @@ func (r *Reconciler) buildService(dk *dynakube.DynaKube) (*corev1.Service, error
 		k8sservice.SetType(corev1.ServiceTypeClusterIP),
 	)
 }
+
+func (r *Reconciler) buildDatasourceService(dk *dynakube.DynaKube, datasource string) (*corev1.Service, error) {
+	ext := dk.Extensions()
+	coreLabels := k8slabel.NewCoreLabels(dk.Name, k8slabel.ExtensionComponentLabel)
+	appLabels := k8slabel.NewAppLabels(k8slabel.ExtensionComponentLabel, dk.Name, k8slabel.ExtensionComponentLabel, "")
+
+	labels := coreLabels.BuildLabels()
+	labels[datasourceLabelKey] = datasource
+
+	selectorLabels := appLabels.BuildMatchLabels()
+	selectorLabels[appsv1.StatefulSetPodNameLabel] = ext.GetExecutionControllerStatefulsetName() + "-" + strconv.Itoa(int(eec.GetAssignedReplica(dk, datasource)))
+
+	svcPorts := []corev1.ServicePort{
+		{
+			Name:       ext.GetPortName(),
+			Port:       consts.ExtensionsDatasourceTargetPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.IntOrString{Type: intstr.String, StrVal: consts.ExtensionsDatasourceTargetPortName},
+		},
+	}
+
+	return k8sservice.Build(dk,
+		ext.GetDatasourceServiceName(datasource),
+		selectorLabels,
+		svcPorts,
+		k8sservice.SetLabels(labels),
+		k8sservice.SetType(corev1.ServiceTypeClusterIP),
+	)
+}
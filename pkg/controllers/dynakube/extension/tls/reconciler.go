@@ -6,9 +6,13 @@ package tls
 import (
 	"context"
 	"crypto/x509"
+	"encoding/pem"
+	"slices"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/extensions"
 	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/extension/eec"
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/certificates"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8sconditions"
@@ -60,7 +64,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, dk *dynakube.DynaKube) error
 }
 
 func (r *Reconciler) reconcileSelfSignedTLSSecret(ctx context.Context, dk *dynakube.DynaKube) error {
-	_, err := r.secrets.Get(ctx, types.NamespacedName{
+	log := logd.FromContext(ctx)
+
+	secret, err := r.secrets.Get(ctx, types.NamespacedName{
 		Name:      dk.Extensions().GetSelfSignedTLSSecretName(),
 		Namespace: dk.Namespace,
 	})
@@ -74,9 +80,55 @@ func (r *Reconciler) reconcileSelfSignedTLSSecret(ctx context.Context, dk *dynak
 		return err
 	}
 
+	if !coversDNSNames(secret.Data[consts.TLSCrtDataName], buildDNSNames(dk)) {
+		log.Info("self-signed tls certificate doesn't cover all extension controller services, recreating it")
+
+		if err := r.deleteSelfSignedTLSSecret(ctx, dk); err != nil {
+			k8sconditions.SetKubeAPIError(dk.Conditions(), conditionType, err)
+
+			return err
+		}
+
+		return r.createSelfSignedTLSSecret(ctx, dk)
+	}
+
 	return nil
 }
 
+// buildDNSNames returns the names of the common Service and, if the datasources are sharded, of the Services of all datasources.
+func buildDNSNames(dk *dynakube.DynaKube) []string {
+	dnsNames := certificates.AltNames(dk.Name, dk.Namespace, extensionsSelfSignedTLSCommonNameSuffix)
+
+	if ext := dk.Extensions(); ext.IsExecutionControllerSharded() {
+		for _, datasource := range eec.ListDatasources(dk) {
+			dnsNames = append(dnsNames, certificates.AltNames(dk.Name, dk.Namespace, extensions.DatasourceServicePrefix+datasource)...)
+		}
+	}
+
+	return dnsNames
+}
+
+// coversDNSNames checks if the certificate contains all the given DNS names, certificates that can't be parsed are left untouched.
+func coversDNSNames(certData []byte, dnsNames []string) bool {
+	block, _ := pem.Decode(certData)
+	if block == nil {
+		return true
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return true
+	}
+
+	for _, dnsName := range dnsNames {
+		if !slices.Contains(cert.DNSNames, dnsName) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (r *Reconciler) deleteSelfSignedTLSSecret(ctx context.Context, dk *dynakube.DynaKube) error {
 	return r.secrets.Delete(ctx, &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -94,7 +146,7 @@ func (r *Reconciler) createSelfSignedTLSSecret(ctx context.Context, dk *dynakube
 		return err
 	}
 
-	cert.Cert.DNSNames = certificates.AltNames(dk.Name, dk.Namespace, extensionsSelfSignedTLSCommonNameSuffix)
+	cert.Cert.DNSNames = buildDNSNames(dk)
 	cert.Cert.KeyUsage = x509.KeyUsageKeyEncipherment | x509.KeyUsageDataEncipherment
 	cert.Cert.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
 	cert.Cert.Subject.CommonName = certificates.CommonName(dk.Name, dk.Namespace, extensionsSelfSignedTLSCommonNameSuffix)
@@ -4,6 +4,8 @@
 package tls
 
 import (
+	"crypto/x509"
+	"encoding/pem"
 	"testing"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
@@ -97,6 +99,31 @@ func TestReconcile(t *testing.T) {
 		require.NotEmpty(t, secret)
 		assert.NotEmpty(t, dk.Conditions())
 	})
+	t.Run("self-signed tls secret is recreated if datasource services are missing", func(t *testing.T) {
+		dk := getTestDynakube()
+		dk.Spec.Templates.ExtensionExecutionController.TLSRefName = ""
+		fakeClient := fake.NewClient()
+
+		reconciler := NewReconciler(fakeClient, fakeClient)
+		require.NoError(t, reconciler.Reconcile(t.Context(), dk))
+
+		dk.Spec.Templates.ExtensionExecutionController.Replicas = new(int32(2))
+		require.NoError(t, reconciler.Reconcile(t.Context(), dk))
+
+		var secret corev1.Secret
+
+		key := client.ObjectKey{Name: dk.Extensions().GetSelfSignedTLSSecretName(), Namespace: testNamespaceName}
+		require.NoError(t, fakeClient.Get(t.Context(), key, &secret))
+
+		block, _ := pem.Decode(secret.Data[consts.TLSCrtDataName])
+		require.NotNil(t, block)
+		cert, err := x509.ParseCertificate(block.Bytes)
+		require.NoError(t, err)
+
+		assert.Contains(t, cert.DNSNames, "dynakube-extension-controller."+testNamespaceName)
+		assert.Contains(t, cert.DNSNames, "dynakube-eec-prometheus."+testNamespaceName)
+		assert.Contains(t, cert.DNSNames, "dynakube-eec-prometheus."+testNamespaceName+".svc")
+	})
 	t.Run("self-signed tls secret is deleted", func(t *testing.T) {
 		dk := getTestDynakube()
 		dk.Spec.Templates.ExtensionExecutionController.TLSRefName = "dummy-value"
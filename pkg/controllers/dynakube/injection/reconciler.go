@@ -98,7 +98,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, dtClient *dynatrace.Client,
 	if !dk.OneAgent().IsAppInjectionNeeded() && !dk.MetadataEnrichment().IsEnabled() && !dk.OTLPExporterConfiguration().IsEnabled() {
 		defer r.unmap(ctx, dk)
 
-		namespaces, err := mapper.GetNamespacesForDynakube(ctx, r.apiReader, dk.Name)
+		namespaces, err := mapper.GetNamespacesForDynakube(ctx, r.apiReader, dk)
 		if err != nil {
 			return err
 		}
@@ -114,7 +114,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, dtClient *dynatrace.Client,
 
 			// Fall back to the last successfully-persisted label-based list rather than trusting a
 			// possibly-partially-populated in-memory view from an aborted matching run.
-			namespaces, err := mapper.GetNamespacesForDynakube(ctx, r.apiReader, dk.Name)
+			namespaces, err := mapper.GetNamespacesForDynakube(ctx, r.apiReader, dk)
 			if err != nil {
 				return err
 			}
@@ -183,7 +183,7 @@ func (r *Reconciler) setupInitSecret(ctx context.Context, dtClient *dynatrace.Cl
 func (r *Reconciler) unmap(ctx context.Context, dk *dynakube.DynaKube) {
 	log := logd.FromContext(ctx)
 
-	namespaces, err := mapper.GetNamespacesForDynakube(ctx, r.apiReader, dk.Name)
+	namespaces, err := mapper.GetNamespacesForDynakube(ctx, r.apiReader, dk)
 	if err != nil {
 		log.Error(err, "failed to list namespaces for dynakube")
 	}
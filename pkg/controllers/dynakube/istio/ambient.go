@@ -0,0 +1,84 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package istio
+
+import (
+	"context"
+	"maps"
+
+	istiospec "github.com/Dynatrace/dynatrace-operator/pkg/api/shared/istio"
+	istiosecurity "istio.io/api/security/v1beta1"
+	istiotype "istio.io/api/type/v1beta1"
+	istiosecurityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// UseWaypointLabel binds a ServiceEntry to a waypoint proxy in ambient mode.
+	UseWaypointLabel = "istio.io/use-waypoint"
+
+	serviceEntryGroup = "networking.istio.io"
+	serviceEntryKind  = "ServiceEntry"
+)
+
+// meshSettings describes how the egress related istio objects of an owner have to be built.
+type meshSettings struct {
+	waypoint string
+	ambient  bool
+}
+
+func newMeshSettings(spec *istiospec.Spec) meshSettings {
+	return meshSettings{
+		ambient:  spec.IsAmbientMode(),
+		waypoint: spec.GetWaypoint(),
+	}
+}
+
+func (mesh meshSettings) serviceEntryLabels(labels map[string]string) map[string]string {
+	if mesh.waypoint == "" {
+		return labels
+	}
+
+	out := maps.Clone(labels)
+	out[UseWaypointLabel] = mesh.waypoint
+
+	return out
+}
+
+// reconcileAuthorizationPolicy makes sure that only the namespace of the owner may use the ServiceEntry through the waypoint.
+// AuthorizationPolicies that target a ServiceEntry are only enforced by waypoint proxies, so without a waypoint it is removed.
+func (r *Reconciler) reconcileAuthorizationPolicy(ctx context.Context, serviceEntryMeta metav1.ObjectMeta, owner client.Object, mesh meshSettings) error {
+	if mesh.waypoint == "" {
+		return r.authorizationPolicy.DeleteForNamespace(ctx, serviceEntryMeta.Name, serviceEntryMeta.Namespace)
+	}
+
+	_, err := r.authorizationPolicy.WithOwner(owner).CreateOrUpdate(ctx, buildAuthorizationPolicy(serviceEntryMeta))
+
+	return err
+}
+
+func buildAuthorizationPolicy(serviceEntryMeta metav1.ObjectMeta) *istiosecurityv1beta1.AuthorizationPolicy {
+	labels := maps.Clone(serviceEntryMeta.Labels)
+	delete(labels, UseWaypointLabel)
+
+	return &istiosecurityv1beta1.AuthorizationPolicy{
+		ObjectMeta: buildObjectMeta(serviceEntryMeta.Name, serviceEntryMeta.Namespace, labels),
+		Spec: istiosecurity.AuthorizationPolicy{
+			TargetRefs: []*istiotype.PolicyTargetReference{{
+				Group: serviceEntryGroup,
+				Kind:  serviceEntryKind,
+				Name:  serviceEntryMeta.Name,
+			}},
+			Action: istiosecurity.AuthorizationPolicy_ALLOW,
+			Rules: []*istiosecurity.Rule{{
+				From: []*istiosecurity.Rule_From{{
+					Source: &istiosecurity.Source{
+						Namespaces: []string{serviceEntryMeta.Namespace},
+					},
+				}},
+			}},
+		},
+	}
+}
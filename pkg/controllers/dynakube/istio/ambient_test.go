@@ -0,0 +1,91 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package istio
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	istiospec "github.com/Dynatrace/dynatrace-operator/pkg/api/shared/istio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	istiosecurity "istio.io/api/security/v1beta1"
+	istiov1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	istiosecurityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestNewMeshSettings(t *testing.T) {
+	assert.Equal(t, meshSettings{}, newMeshSettings(nil))
+	assert.Equal(t, meshSettings{}, newMeshSettings(&istiospec.Spec{Waypoint: "waypoint"}))
+	assert.Equal(t, meshSettings{ambient: true}, newMeshSettings(&istiospec.Spec{Mode: istiospec.AmbientMode}))
+	assert.Equal(t, meshSettings{ambient: true, waypoint: "waypoint"}, newMeshSettings(&istiospec.Spec{Mode: istiospec.AmbientMode, Waypoint: "waypoint"}))
+}
+
+func TestAmbientMode(t *testing.T) {
+	t.Run("ambient without waypoint => no VirtualService and no AuthorizationPolicy", func(t *testing.T) {
+		ctx := t.Context()
+		dk := createTestDynaKube()
+		dk.Spec.Istio = &istiospec.Spec{Mode: istiospec.AmbientMode}
+		name := BuildNameForFQDNServiceEntry(dk.Name, OperatorComponent)
+		fakeClient := fake.NewClientWithIndex(
+			&istiov1beta1.VirtualService{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: dk.Namespace}},
+		)
+		reconciler := NewReconciler(fakeClient, fakeClient)
+
+		require.NoError(t, reconciler.ReconcileAPIURL(ctx, dk))
+
+		var serviceEntry istiov1beta1.ServiceEntry
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: name, Namespace: dk.Namespace}, &serviceEntry))
+		assert.NotContains(t, serviceEntry.Labels, UseWaypointLabel)
+
+		err := fakeClient.Get(ctx, client.ObjectKey{Name: name, Namespace: dk.Namespace}, &istiov1beta1.VirtualService{})
+		assert.True(t, k8serrors.IsNotFound(err))
+
+		err = fakeClient.Get(ctx, client.ObjectKey{Name: name, Namespace: dk.Namespace}, &istiosecurityv1beta1.AuthorizationPolicy{})
+		assert.True(t, k8serrors.IsNotFound(err))
+	})
+
+	t.Run("ambient with waypoint => ServiceEntry bound to waypoint and AuthorizationPolicy", func(t *testing.T) {
+		ctx := t.Context()
+		dk := createTestDynaKube()
+		dk.Spec.Istio = &istiospec.Spec{Mode: istiospec.AmbientMode, Waypoint: "egress-waypoint"}
+		fakeClient := fake.NewClientWithIndex()
+		reconciler := NewReconciler(fakeClient, fakeClient)
+
+		require.NoError(t, reconciler.ReconcileAPIURL(ctx, dk))
+
+		name := BuildNameForFQDNServiceEntry(dk.Name, OperatorComponent)
+
+		var serviceEntry istiov1beta1.ServiceEntry
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: name, Namespace: dk.Namespace}, &serviceEntry))
+		assert.Equal(t, "egress-waypoint", serviceEntry.Labels[UseWaypointLabel])
+
+		var policy istiosecurityv1beta1.AuthorizationPolicy
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: name, Namespace: dk.Namespace}, &policy))
+		assert.NotContains(t, policy.Labels, UseWaypointLabel)
+		assert.Equal(t, istiosecurity.AuthorizationPolicy_ALLOW, policy.Spec.GetAction())
+		require.Len(t, policy.Spec.GetTargetRefs(), 1)
+		assert.Equal(t, serviceEntryKind, policy.Spec.GetTargetRefs()[0].GetKind())
+		assert.Equal(t, name, policy.Spec.GetTargetRefs()[0].GetName())
+		assert.Equal(t, []string{dk.Namespace}, policy.Spec.GetRules()[0].GetFrom()[0].GetSource().GetNamespaces())
+	})
+
+	t.Run("cleanup removes AuthorizationPolicy", func(t *testing.T) {
+		ctx := t.Context()
+		dk := createTestDynaKube()
+		name := BuildNameForFQDNServiceEntry(dk.Name, CodeModuleComponent)
+		fakeClient := fake.NewClientWithIndex(
+			&istiosecurityv1beta1.AuthorizationPolicy{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: dk.Namespace}},
+		)
+		reconciler := NewReconciler(fakeClient, fakeClient)
+
+		require.NoError(t, reconciler.cleanupIstio(ctx, dk, CodeModuleComponent))
+
+		err := fakeClient.Get(ctx, client.ObjectKey{Name: name, Namespace: dk.Namespace}, &istiosecurityv1beta1.AuthorizationPolicy{})
+		assert.True(t, k8serrors.IsNotFound(err))
+	})
+}
@@ -31,3 +31,23 @@ func setServiceEntryFailedConditionForComponent(conditions *[]metav1.Condition,
 	}
 	_ = meta.SetStatusCondition(conditions, condition)
 }
+
+func setMTLSUpdatedCondition(conditions *[]metav1.Condition) {
+	condition := metav1.Condition{
+		Type:    getConditionTypeName(mtlsConditionName),
+		Status:  metav1.ConditionTrue,
+		Reason:  "IstioFor" + mtlsConditionName + "Changed",
+		Message: "PeerAuthentications and DestinationRules for the in-cluster endpoints have been configured.",
+	}
+	_ = meta.SetStatusCondition(conditions, condition)
+}
+
+func setMTLSFailedCondition(conditions *[]metav1.Condition) {
+	condition := metav1.Condition{
+		Type:    getConditionTypeName(mtlsConditionName),
+		Status:  metav1.ConditionFalse,
+		Reason:  "IstioFor" + mtlsConditionName + "Failed",
+		Message: "Failed to configure Istio PeerAuthentications and DestinationRules for the in-cluster endpoints",
+	}
+	_ = meta.SetStatusCondition(conditions, condition)
+}
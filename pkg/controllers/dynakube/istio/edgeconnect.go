@@ -0,0 +1,99 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package istio
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/connectioninfo"
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	EdgeConnectComponent     = "edgeconnect"
+	edgeConnectConditionName = "EdgeConnect"
+)
+
+// ReconcileEdgeConnect manages the istio objects for the outbound hosts of an EdgeConnect.
+func (r *Reconciler) ReconcileEdgeConnect(ctx context.Context, ec *edgeconnect.EdgeConnect) error {
+	log := logd.FromContext(ctx)
+
+	log.Debug("reconciling istio components for edgeconnect outbound hosts")
+
+	if ec == nil {
+		return errors.New("can't reconcile edgeconnect outbound hosts of nil edgeconnect")
+	}
+
+	if !ptr.Deref(ec.Spec.EnableIstio, false) {
+		if isIstioConfigured(ec.Conditions(), edgeConnectConditionName) {
+			log.Info("istio disabled for edgeconnect, cleaning up")
+
+			err := r.cleanupIstio(ctx, ec, EdgeConnectComponent)
+			if err != nil {
+				// We don't error out here to avoid stuck reconciliations in case cleanup fails
+				log.Error(err, "failed to cleanup the istio configuration", "component", EdgeConnectComponent)
+			}
+
+			meta.RemoveStatusCondition(ec.Conditions(), getConditionTypeName(edgeConnectConditionName))
+		}
+
+		return nil
+	}
+
+	ecCommunicationHosts, err := edgeConnectCommunicationHosts(ec)
+	if err != nil {
+		setServiceEntryFailedConditionForComponent(ec.Conditions(), edgeConnectConditionName)
+
+		return err
+	}
+
+	err = r.reconcileCommunicationHostsForComponent(ctx, ecCommunicationHosts, ec, EdgeConnectComponent, newMeshSettings(ec.Spec.Istio))
+	if err != nil {
+		setServiceEntryFailedConditionForComponent(ec.Conditions(), edgeConnectConditionName)
+
+		return err
+	}
+
+	setServiceEntryUpdatedConditionForComponent(ec.Conditions(), edgeConnectConditionName)
+
+	return nil
+}
+
+// edgeConnectCommunicationHosts collects the API server, the OAuth endpoint, the host patterns and the targets of the host mappings.
+// Host patterns with wildcards are skipped, as they can't be resolved via DNS.
+// The host pattern of the Kubernetes automation is skipped as well, because it is mapped to the in-cluster API server.
+func edgeConnectCommunicationHosts(ec *edgeconnect.EdgeConnect) ([]connectioninfo.CommunicationHost, error) {
+	endpoints := []string{"https://" + ec.Spec.APIServer, ec.Spec.OAuth.Endpoint}
+
+	for _, hostPattern := range ec.Spec.HostPatterns {
+		if !strings.Contains(hostPattern, "*") {
+			endpoints = append(endpoints, "https://"+hostPattern)
+		}
+	}
+
+	for _, hostMapping := range ec.Spec.HostMappings {
+		endpoints = append(endpoints, "https://"+hostMapping.To)
+	}
+
+	commHosts := make([]connectioninfo.CommunicationHost, 0, len(endpoints))
+
+	for _, endpoint := range endpoints {
+		commHost, err := connectioninfo.NewCommunicationHost(endpoint)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "invalid edgeconnect outbound host %s", endpoint)
+		}
+
+		if !slices.Contains(commHosts, commHost) {
+			commHosts = append(commHosts, commHost)
+		}
+	}
+
+	return commHosts, nil
+}
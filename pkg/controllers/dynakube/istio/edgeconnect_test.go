@@ -0,0 +1,107 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package istio
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	istiov1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func createTestEdgeConnect() *edgeconnect.EdgeConnect {
+	return &edgeconnect.EdgeConnect{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "edgeconnect",
+			Namespace: "test",
+		},
+		Spec: edgeconnect.EdgeConnectSpec{
+			APIServer:    "abc12345.apps.dynatrace.com",
+			OAuth:        edgeconnect.OAuthSpec{Endpoint: "https://sso.dynatrace.com/sso/oauth2/token"},
+			HostPatterns: []string{"*.internal.org", "service.internal.org"},
+			HostMappings: []edgeconnect.HostMapping{{From: "*.internal.org", To: "proxy.internal.org:8443"}},
+			EnableIstio:  new(true),
+		},
+	}
+}
+
+func TestEdgeConnectCommunicationHosts(t *testing.T) {
+	commHosts, err := edgeConnectCommunicationHosts(createTestEdgeConnect())
+	require.NoError(t, err)
+
+	hosts := make([]string, 0, len(commHosts))
+	for _, commHost := range commHosts {
+		hosts = append(hosts, commHost.String())
+	}
+
+	assert.Equal(t, []string{
+		"https://abc12345.apps.dynatrace.com:443",
+		"https://sso.dynatrace.com:443",
+		"https://service.internal.org:443",
+		"https://proxy.internal.org:8443",
+	}, hosts)
+}
+
+func TestReconcileEdgeConnect(t *testing.T) {
+	t.Run("nil => error", func(t *testing.T) {
+		fakeClient := fake.NewClientWithIndex()
+		reconciler := NewReconciler(fakeClient, fakeClient)
+
+		require.Error(t, reconciler.ReconcileEdgeConnect(t.Context(), nil))
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctx := t.Context()
+		ec := createTestEdgeConnect()
+		fakeClient := fake.NewClientWithIndex()
+		reconciler := NewReconciler(fakeClient, fakeClient)
+
+		require.NoError(t, reconciler.ReconcileEdgeConnect(ctx, ec))
+
+		var serviceEntry istiov1beta1.ServiceEntry
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: BuildNameForFQDNServiceEntry(ec.Name, EdgeConnectComponent), Namespace: ec.Namespace}, &serviceEntry))
+		assert.Contains(t, serviceEntry.Spec.GetHosts(), "abc12345.apps.dynatrace.com")
+		assert.Contains(t, serviceEntry.Spec.GetHosts(), "proxy.internal.org")
+
+		condition := meta.FindStatusCondition(*ec.Conditions(), "IstioForEdgeConnect")
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	})
+
+	t.Run("disabled => cleanup", func(t *testing.T) {
+		ctx := t.Context()
+		ec := createTestEdgeConnect()
+		fakeClient := fake.NewClientWithIndex()
+		reconciler := NewReconciler(fakeClient, fakeClient)
+
+		require.NoError(t, reconciler.ReconcileEdgeConnect(ctx, ec))
+
+		ec.Spec.EnableIstio = new(false)
+		require.NoError(t, reconciler.ReconcileEdgeConnect(ctx, ec))
+
+		err := fakeClient.Get(ctx, client.ObjectKey{Name: BuildNameForFQDNServiceEntry(ec.Name, EdgeConnectComponent), Namespace: ec.Namespace}, &istiov1beta1.ServiceEntry{})
+		assert.True(t, k8serrors.IsNotFound(err))
+		assert.Nil(t, meta.FindStatusCondition(*ec.Conditions(), "IstioForEdgeConnect"))
+	})
+
+	t.Run("invalid host => error", func(t *testing.T) {
+		ec := createTestEdgeConnect()
+		ec.Spec.HostMappings = []edgeconnect.HostMapping{{From: "*.internal.org", To: "proxy.internal.org:invalid"}}
+		fakeClient := fake.NewClientWithIndex()
+		reconciler := NewReconciler(fakeClient, fakeClient)
+
+		require.Error(t, reconciler.ReconcileEdgeConnect(t.Context(), ec))
+
+		condition := meta.FindStatusCondition(*ec.Conditions(), "IstioForEdgeConnect")
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	})
+}
@@ -0,0 +1,286 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package istio
+
+import (
+	"context"
+	goerrors "errors"
+	"strconv"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
+	agconsts "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/kubemon/gateway"
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8senv"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
+	"github.com/pkg/errors"
+	istionetworking "istio.io/api/networking/v1beta1"
+	istiosecurity "istio.io/api/security/v1beta1"
+	istiotype "istio.io/api/type/v1beta1"
+	istiov1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	istiosecurityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	mtlsConditionName = "MTLS"
+	mtlsInfix         = "-mtls-"
+	egressInfix       = "-egress-"
+)
+
+// inClusterEndpoint is a component that serves traffic for other components within the cluster.
+type inClusterEndpoint struct {
+	component   string
+	appName     string
+	serviceName string
+	enabled     bool
+}
+
+func inClusterEndpoints(dk *dynakube.DynaKube) []inClusterEndpoint {
+	return []inClusterEndpoint{
+		{
+			component:   ActiveGateComponent,
+			appName:     k8slabel.ActiveGateComponentLabel,
+			serviceName: capability.BuildServiceName(dk.Name),
+			enabled:     dk.ActiveGate().IsEnabled(),
+		},
+		{
+			component:   k8slabel.OTelColComponentLabel,
+			appName:     k8slabel.OTelColComponentLabel,
+			serviceName: dk.TelemetryIngest().GetServiceName(),
+			enabled:     dk.TelemetryIngest().IsEnabled(),
+		},
+		{
+			component:   k8slabel.ExtensionComponentLabel,
+			appName:     k8slabel.ExtensionComponentLabel,
+			serviceName: dk.Extensions().GetServiceName(),
+			enabled:     dk.Extensions().IsAnyEnabled(),
+		},
+	}
+}
+
+// inClusterClient is a component that sends its data to an in-cluster endpoint of the ActiveGate.
+type inClusterClient struct {
+	component   string
+	serviceName string
+	enabled     bool
+}
+
+func inClusterClients(dk *dynakube.DynaKube) []inClusterClient {
+	kspmServiceName := capability.BuildServiceName(dk.Name)
+	if dk.IsKubemonEnabled() {
+		kspmServiceName = gateway.ServiceName(dk.Name)
+	}
+
+	return []inClusterClient{
+		{
+			component:   k8slabel.OTelColComponentLabel,
+			serviceName: capability.BuildServiceName(dk.Name),
+			// without an ActiveGate the data is sent to the API url, which is covered by the ServiceEntries of the operator
+			enabled: dk.TelemetryIngest().IsEnabled() && dk.ActiveGate().IsEnabled(),
+		},
+		{
+			component:   k8slabel.ExtensionComponentLabel,
+			serviceName: capability.BuildServiceName(dk.Name),
+			enabled:     dk.Extensions().IsAnyEnabled(),
+		},
+		{
+			component:   k8slabel.KSPMComponentLabel,
+			serviceName: kspmServiceName,
+			enabled:     dk.KSPM().IsEnabled(),
+		},
+	}
+}
+
+func BuildNameForMTLS(ownerName, component string) string {
+	return ownerName + mtlsInfix + component
+}
+
+func BuildNameForEgress(ownerName, component string) string {
+	return ownerName + egressInfix + component
+}
+
+func buildServiceFQDN(serviceName, namespace string) string {
+	return serviceName + "." + namespace + ".svc." + k8senv.GetClusterDomain()
+}
+
+// ReconcileInClusterEndpoints manages the PeerAuthentication and DestinationRule objects,
+// that configure mutual TLS for the services of the ActiveGate, the OpenTelemetry collector and the extension execution controller,
+// and the egress ServiceEntries of the OpenTelemetry collector, the extension execution controller and KSPM towards the ActiveGate.
+// In ambient mode no DestinationRules are created, as ztunnel always uses mutual TLS between the workloads.
+func (r *Reconciler) ReconcileInClusterEndpoints(ctx context.Context, dk *dynakube.DynaKube) error {
+	log := logd.FromContext(ctx)
+
+	if dk == nil {
+		return errors.New("can't reconcile in-cluster endpoints of nil dynakube")
+	}
+
+	if !ptr.Deref(dk.Spec.EnableIstio, false) || !dk.Spec.Istio.IsMTLSEnabled() {
+		if isIstioConfigured(dk.Conditions(), mtlsConditionName) {
+			log.Info("istio mtls disabled, cleaning up")
+
+			if err := goerrors.Join(r.cleanupMTLS(ctx, dk, inClusterEndpoints(dk)), r.cleanupEgress(ctx, dk, inClusterClients(dk))); err != nil {
+				// We don't error out here to avoid stuck reconciliations in case cleanup fails
+				log.Error(err, "failed to cleanup the istio mtls configuration")
+			}
+
+			meta.RemoveStatusCondition(dk.Conditions(), getConditionTypeName(mtlsConditionName))
+		}
+
+		return nil
+	}
+
+	var errs []error
+
+	for _, endpoint := range inClusterEndpoints(dk) {
+		var err error
+		if endpoint.enabled {
+			err = r.reconcileMTLS(ctx, dk, endpoint)
+		} else {
+			err = r.cleanupMTLS(ctx, dk, []inClusterEndpoint{endpoint})
+		}
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, clt := range inClusterClients(dk) {
+		var err error
+		if clt.enabled {
+			err = r.reconcileEgress(ctx, dk, clt)
+		} else {
+			err = r.cleanupEgress(ctx, dk, []inClusterClient{clt})
+		}
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := goerrors.Join(errs...); err != nil {
+		setMTLSFailedCondition(dk.Conditions())
+
+		return errors.WithMessage(err, "error reconciling istio mtls config for in-cluster endpoints")
+	}
+
+	setMTLSUpdatedCondition(dk.Conditions())
+
+	return nil
+}
+
+func (r *Reconciler) reconcileMTLS(ctx context.Context, dk *dynakube.DynaKube, endpoint inClusterEndpoint) error {
+	objectMeta := buildObjectMeta(
+		BuildNameForMTLS(dk.Name, endpoint.component),
+		dk.Namespace,
+		k8slabel.NewCoreLabels(dk.Name, endpoint.component).BuildLabels(),
+	)
+
+	peerAuthentication := buildPeerAuthentication(objectMeta, dk, endpoint)
+
+	_, err := r.peerAuthentication.WithOwner(dk).CreateOrUpdate(ctx, peerAuthentication)
+	if err != nil {
+		return err
+	}
+
+	if dk.Spec.Istio.IsAmbientMode() {
+		return r.destinationRule.DeleteForNamespace(ctx, objectMeta.Name, objectMeta.Namespace)
+	}
+
+	_, err = r.destinationRule.WithOwner(dk).CreateOrUpdate(ctx, buildDestinationRule(objectMeta, endpoint))
+
+	return err
+}
+
+func (r *Reconciler) cleanupMTLS(ctx context.Context, dk *dynakube.DynaKube, endpoints []inClusterEndpoint) error {
+	var errs []error
+
+	for _, endpoint := range endpoints {
+		name := BuildNameForMTLS(dk.Name, endpoint.component)
+
+		errs = append(errs,
+			r.peerAuthentication.DeleteForNamespace(ctx, name, dk.Namespace),
+			r.destinationRule.DeleteForNamespace(ctx, name, dk.Namespace),
+		)
+	}
+
+	return goerrors.Join(errs...)
+}
+
+func (r *Reconciler) reconcileEgress(ctx context.Context, dk *dynakube.DynaKube, clt inClusterClient) error {
+	objectMeta := buildObjectMeta(
+		BuildNameForEgress(dk.Name, clt.component),
+		dk.Namespace,
+		k8slabel.NewCoreLabels(dk.Name, clt.component).BuildLabels(),
+	)
+
+	_, err := r.serviceEntry.WithOwner(dk).CreateOrUpdate(ctx, buildEgressServiceEntry(objectMeta, clt))
+
+	return err
+}
+
+func (r *Reconciler) cleanupEgress(ctx context.Context, dk *dynakube.DynaKube, clients []inClusterClient) error {
+	var errs []error
+
+	for _, clt := range clients {
+		errs = append(errs, r.serviceEntry.DeleteForNamespace(ctx, BuildNameForEgress(dk.Name, clt.component), dk.Namespace))
+	}
+
+	return goerrors.Join(errs...)
+}
+
+func buildPeerAuthentication(objectMeta metav1.ObjectMeta, dk *dynakube.DynaKube, endpoint inClusterEndpoint) *istiosecurityv1beta1.PeerAuthentication {
+	return &istiosecurityv1beta1.PeerAuthentication{
+		ObjectMeta: objectMeta,
+		Spec: istiosecurity.PeerAuthentication{
+			Selector: &istiotype.WorkloadSelector{
+				MatchLabels: map[string]string{
+					k8slabel.AppNameLabel:      endpoint.appName,
+					k8slabel.AppCreatedByLabel: dk.Name,
+				},
+			},
+			Mtls: &istiosecurity.PeerAuthentication_MutualTLS{
+				Mode: istiosecurity.PeerAuthentication_MutualTLS_Mode(
+					istiosecurity.PeerAuthentication_MutualTLS_Mode_value[string(dk.Spec.Istio.GetMTLSMode())],
+				),
+			},
+		},
+	}
+}
+
+func buildDestinationRule(objectMeta metav1.ObjectMeta, endpoint inClusterEndpoint) *istiov1beta1.DestinationRule {
+	return &istiov1beta1.DestinationRule{
+		ObjectMeta: objectMeta,
+		Spec: istionetworking.DestinationRule{
+			Host: buildServiceFQDN(endpoint.serviceName, objectMeta.Namespace),
+			TrafficPolicy: &istionetworking.TrafficPolicy{
+				Tls: &istionetworking.ClientTLSSettings{
+					Mode: istionetworking.ClientTLSSettings_ISTIO_MUTUAL,
+				},
+			},
+		},
+	}
+}
+
+// buildEgressServiceEntry registers the ActiveGate endpoint of a component, so its traffic is allowed if the mesh only permits registered destinations.
+// It is only exported to the namespace of the DynaKube, where the component runs.
+func buildEgressServiceEntry(objectMeta metav1.ObjectMeta, clt inClusterClient) *istiov1beta1.ServiceEntry {
+	return &istiov1beta1.ServiceEntry{
+		ObjectMeta: objectMeta,
+		Spec: istionetworking.ServiceEntry{
+			Hosts:    []string{buildServiceFQDN(clt.serviceName, objectMeta.Namespace)},
+			ExportTo: []string{"."},
+			Ports: []*istionetworking.ServicePort{{
+				Name:     "https-" + strconv.Itoa(agconsts.HTTPSServicePort),
+				Number:   agconsts.HTTPSServicePort,
+				Protocol: "HTTPS",
+			}},
+			Location:   istionetworking.ServiceEntry_MESH_INTERNAL,
+			Resolution: istionetworking.ServiceEntry_DNS,
+		},
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package istio
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/kspm"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/telemetryingest"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	istiospec "github.com/Dynatrace/dynatrace-operator/pkg/api/shared/istio"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8senv"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	istionetworking "istio.io/api/networking/v1beta1"
+	istiosecurity "istio.io/api/security/v1beta1"
+	istiov1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	istiosecurityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestReconcileInClusterEndpoints(t *testing.T) {
+	t.Run("nil => error", func(t *testing.T) {
+		fakeClient := fake.NewClientWithIndex()
+		reconciler := NewReconciler(fakeClient, fakeClient)
+
+		require.Error(t, reconciler.ReconcileInClusterEndpoints(t.Context(), nil))
+	})
+
+	t.Run("mtls not configured => nothing created", func(t *testing.T) {
+		ctx := t.Context()
+		dk := createTestDynaKube()
+		fakeClient := fake.NewClientWithIndex()
+		reconciler := NewReconciler(fakeClient, fakeClient)
+
+		require.NoError(t, reconciler.ReconcileInClusterEndpoints(ctx, dk))
+
+		var peerAuthentications istiosecurityv1beta1.PeerAuthenticationList
+		require.NoError(t, fakeClient.List(ctx, &peerAuthentications))
+		assert.Empty(t, peerAuthentications.Items)
+		assert.Nil(t, meta.FindStatusCondition(*dk.Conditions(), getConditionTypeName(mtlsConditionName)))
+	})
+
+	t.Run("sidecar mode => PeerAuthentication and DestinationRule for enabled components", func(t *testing.T) {
+		ctx := t.Context()
+		dk := createTestDynaKube()
+		dk.Spec.Istio = &istiospec.Spec{MTLS: &istiospec.MTLSSpec{}}
+		fakeClient := fake.NewClientWithIndex()
+		reconciler := NewReconciler(fakeClient, fakeClient)
+
+		require.NoError(t, reconciler.ReconcileInClusterEndpoints(ctx, dk))
+
+		key := client.ObjectKey{Name: BuildNameForMTLS(dk.Name, ActiveGateComponent), Namespace: dk.Namespace}
+
+		var peerAuthentication istiosecurityv1beta1.PeerAuthentication
+		require.NoError(t, fakeClient.Get(ctx, key, &peerAuthentication))
+		assert.Equal(t, istiosecurity.PeerAuthentication_MutualTLS_PERMISSIVE, peerAuthentication.Spec.GetMtls().GetMode())
+		assert.Equal(t, map[string]string{
+			k8slabel.AppNameLabel:      k8slabel.ActiveGateComponentLabel,
+			k8slabel.AppCreatedByLabel: dk.Name,
+		}, peerAuthentication.Spec.GetSelector().GetMatchLabels())
+
+		var destinationRule istiov1beta1.DestinationRule
+		require.NoError(t, fakeClient.Get(ctx, key, &destinationRule))
+		assert.Equal(t, capability.BuildServiceName(dk.Name)+"."+dk.Namespace+".svc.cluster.local", destinationRule.Spec.GetHost())
+		assert.Equal(t, istionetworking.ClientTLSSettings_ISTIO_MUTUAL, destinationRule.Spec.GetTrafficPolicy().GetTls().GetMode())
+
+		// telemetry ingest is disabled
+		err := fakeClient.Get(ctx, client.ObjectKey{Name: BuildNameForMTLS(dk.Name, k8slabel.OTelColComponentLabel), Namespace: dk.Namespace}, &istiosecurityv1beta1.PeerAuthentication{})
+		assert.True(t, k8serrors.IsNotFound(err))
+
+		condition := meta.FindStatusCondition(*dk.Conditions(), getConditionTypeName(mtlsConditionName))
+		require.NotNil(t, condition)
+		assert.Equal(t, "IstioForMTLSChanged", condition.Reason)
+	})
+
+	t.Run("strict mode is opt-in", func(t *testing.T) {
+		ctx := t.Context()
+		dk := createTestDynaKube()
+		dk.Spec.Istio = &istiospec.Spec{MTLS: &istiospec.MTLSSpec{Mode: istiospec.StrictMTLSMode}}
+		fakeClient := fake.NewClientWithIndex()
+		reconciler := NewReconciler(fakeClient, fakeClient)
+
+		require.NoError(t, reconciler.ReconcileInClusterEndpoints(ctx, dk))
+
+		var peerAuthentication istiosecurityv1beta1.PeerAuthentication
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: BuildNameForMTLS(dk.Name, ActiveGateComponent), Namespace: dk.Namespace}, &peerAuthentication))
+		assert.Equal(t, istiosecurity.PeerAuthentication_MutualTLS_STRICT, peerAuthentication.Spec.GetMtls().GetMode())
+	})
+
+	t.Run("DestinationRule uses the configured cluster domain", func(t *testing.T) {
+		t.Setenv(k8senv.ClusterDomainEnvVar, "cluster.example")
+
+		ctx := t.Context()
+		dk := createTestDynaKube()
+		dk.Spec.Istio = &istiospec.Spec{MTLS: &istiospec.MTLSSpec{}}
+		fakeClient := fake.NewClientWithIndex()
+		reconciler := NewReconciler(fakeClient, fakeClient)
+
+		require.NoError(t, reconciler.ReconcileInClusterEndpoints(ctx, dk))
+
+		var destinationRule istiov1beta1.DestinationRule
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: BuildNameForMTLS(dk.Name, ActiveGateComponent), Namespace: dk.Namespace}, &destinationRule))
+		assert.Equal(t, capability.BuildServiceName(dk.Name)+"."+dk.Namespace+".svc.cluster.example", destinationRule.Spec.GetHost())
+	})
+
+	t.Run("egress ServiceEntries for the clients of the ActiveGate", func(t *testing.T) {
+		ctx := t.Context()
+		dk := createTestDynaKube()
+		dk.Spec.Istio = &istiospec.Spec{MTLS: &istiospec.MTLSSpec{}}
+		dk.Spec.TelemetryIngest = &telemetryingest.Spec{}
+		dk.Spec.KSPM = &kspm.Spec{}
+		fakeClient := fake.NewClientWithIndex()
+		reconciler := NewReconciler(fakeClient, fakeClient)
+
+		require.NoError(t, reconciler.ReconcileInClusterEndpoints(ctx, dk))
+
+		var serviceEntry istiov1beta1.ServiceEntry
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: BuildNameForEgress(dk.Name, k8slabel.OTelColComponentLabel), Namespace: dk.Namespace}, &serviceEntry))
+		assert.Equal(t, []string{capability.BuildServiceName(dk.Name) + "." + dk.Namespace + ".svc.cluster.local"}, serviceEntry.Spec.GetHosts())
+		assert.Equal(t, []string{"."}, serviceEntry.Spec.GetExportTo())
+		assert.Equal(t, istionetworking.ServiceEntry_MESH_INTERNAL, serviceEntry.Spec.GetLocation())
+
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: BuildNameForEgress(dk.Name, k8slabel.KSPMComponentLabel), Namespace: dk.Namespace}, &serviceEntry))
+		assert.Equal(t, []string{capability.BuildServiceName(dk.Name) + "." + dk.Namespace + ".svc.cluster.local"}, serviceEntry.Spec.GetHosts())
+
+		// extensions are disabled
+		err := fakeClient.Get(ctx, client.ObjectKey{Name: BuildNameForEgress(dk.Name, k8slabel.ExtensionComponentLabel), Namespace: dk.Namespace}, &istiov1beta1.ServiceEntry{})
+		assert.True(t, k8serrors.IsNotFound(err))
+
+		dk.Spec.Istio = nil
+		require.NoError(t, reconciler.ReconcileInClusterEndpoints(ctx, dk))
+
+		err = fakeClient.Get(ctx, client.ObjectKey{Name: BuildNameForEgress(dk.Name, k8slabel.OTelColComponentLabel), Namespace: dk.Namespace}, &istiov1beta1.ServiceEntry{})
+		assert.True(t, k8serrors.IsNotFound(err))
+	})
+
+	t.Run("ambient mode => only PeerAuthentication with configured mode", func(t *testing.T) {
+		ctx := t.Context()
+		dk := createTestDynaKube()
+		dk.Spec.TelemetryIngest = &telemetryingest.Spec{}
+		dk.Spec.Istio = &istiospec.Spec{Mode: istiospec.AmbientMode, MTLS: &istiospec.MTLSSpec{Mode: istiospec.PermissiveMTLSMode}}
+		fakeClient := fake.NewClientWithIndex()
+		reconciler := NewReconciler(fakeClient, fakeClient)
+
+		require.NoError(t, reconciler.ReconcileInClusterEndpoints(ctx, dk))
+
+		key := client.ObjectKey{Name: BuildNameForMTLS(dk.Name, k8slabel.OTelColComponentLabel), Namespace: dk.Namespace}
+
+		var peerAuthentication istiosecurityv1beta1.PeerAuthentication
+		require.NoError(t, fakeClient.Get(ctx, key, &peerAuthentication))
+		assert.Equal(t, istiosecurity.PeerAuthentication_MutualTLS_PERMISSIVE, peerAuthentication.Spec.GetMtls().GetMode())
+
+		err := fakeClient.Get(ctx, key, &istiov1beta1.DestinationRule{})
+		assert.True(t, k8serrors.IsNotFound(err))
+	})
+
+	t.Run("mtls disabled => cleanup", func(t *testing.T) {
+		ctx := t.Context()
+		dk := createTestDynaKube()
+		dk.Spec.Istio = &istiospec.Spec{MTLS: &istiospec.MTLSSpec{}}
+		fakeClient := fake.NewClientWithIndex()
+		reconciler := NewReconciler(fakeClient, fakeClient)
+
+		require.NoError(t, reconciler.ReconcileInClusterEndpoints(ctx, dk))
+
+		dk.Spec.Istio = nil
+		require.NoError(t, reconciler.ReconcileInClusterEndpoints(ctx, dk))
+
+		key := client.ObjectKey{Name: BuildNameForMTLS(dk.Name, ActiveGateComponent), Namespace: dk.Namespace}
+		err := fakeClient.Get(ctx, key, &istiosecurityv1beta1.PeerAuthentication{})
+		assert.True(t, k8serrors.IsNotFound(err))
+		err = fakeClient.Get(ctx, key, &istiov1beta1.DestinationRule{})
+		assert.True(t, k8serrors.IsNotFound(err))
+		assert.Nil(t, meta.FindStatusCondition(*dk.Conditions(), getConditionTypeName(mtlsConditionName)))
+	})
+
+	t.Run("unknown k8s client error => error", func(t *testing.T) {
+		dk := createTestDynaKube()
+		dk.Spec.Istio = &istiospec.Spec{MTLS: &istiospec.MTLSSpec{}}
+		fakeClient := createFailK8sClient()
+		reconciler := NewReconciler(fakeClient, fakeClient)
+
+		require.Error(t, reconciler.ReconcileInClusterEndpoints(t.Context(), dk))
+
+		condition := meta.FindStatusCondition(*dk.Conditions(), getConditionTypeName(mtlsConditionName))
+		require.NotNil(t, condition)
+		assert.Equal(t, "IstioForMTLSFailed", condition.Reason)
+	})
+}
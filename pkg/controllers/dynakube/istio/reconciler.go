@@ -6,12 +6,18 @@ package istio
 import (
 	"context"
 	goerrors "errors"
+	"maps"
 	"net"
+	"slices"
+	"strings"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/connectioninfo"
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8sauthorizationpolicy"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8sdestinationrule"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8speerauthentication"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8sserviceentry"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8svirtualservice"
 	"github.com/pkg/errors"
@@ -33,17 +39,23 @@ const (
 	activeGateConditionName = "ActiveGate"
 )
 
-// Reconciler holds the shared logic for managing istio ServiceEntry
-// and VirtualService objects for a given set of communication hosts.
+// Reconciler holds the shared logic for managing istio ServiceEntry, VirtualService and AuthorizationPolicy
+// objects for a given set of communication hosts, and the PeerAuthentication and DestinationRule objects for in-cluster endpoints.
 type Reconciler struct {
-	serviceEntry   k8sserviceentry.QueryObject
-	virtualService k8svirtualservice.QueryObject
+	serviceEntry        k8sserviceentry.QueryObject
+	virtualService      k8svirtualservice.QueryObject
+	authorizationPolicy k8sauthorizationpolicy.QueryObject
+	peerAuthentication  k8speerauthentication.QueryObject
+	destinationRule     k8sdestinationrule.QueryObject
 }
 
 func NewReconciler(kubeClient client.Client, apiReader client.Reader) *Reconciler {
 	return &Reconciler{
-		serviceEntry:   k8sserviceentry.Query(kubeClient, apiReader),
-		virtualService: k8svirtualservice.Query(kubeClient, apiReader),
+		serviceEntry:        k8sserviceentry.Query(kubeClient, apiReader),
+		virtualService:      k8svirtualservice.Query(kubeClient, apiReader),
+		authorizationPolicy: k8sauthorizationpolicy.Query(kubeClient, apiReader),
+		peerAuthentication:  k8speerauthentication.Query(kubeClient, apiReader),
+		destinationRule:     k8sdestinationrule.Query(kubeClient, apiReader),
 	}
 }
 func (r *Reconciler) ReconcileAPIURL(ctx context.Context, dk *dynakube.DynaKube) error {
@@ -56,7 +68,7 @@ func (r *Reconciler) ReconcileAPIURL(ctx context.Context, dk *dynakube.DynaKube)
 	}
 
 	if !ptr.Deref(dk.Spec.EnableIstio, false) {
-		if isIstioConfigured(dk, OperatorComponent) {
+		if isIstioConfigured(dk.Conditions(), operatorConditionName) {
 			err := r.cleanupIstio(ctx, dk, OperatorComponent)
 			if err != nil {
 				// We don't error out here to avoid stuck reconciliations in case cleanup fails
@@ -74,7 +86,7 @@ func (r *Reconciler) ReconcileAPIURL(ctx context.Context, dk *dynakube.DynaKube)
 		return err
 	}
 
-	err = r.reconcileCommunicationHosts(ctx, []connectioninfo.CommunicationHost{apiCommunicationHost}, dk, OperatorComponent)
+	err = r.reconcileCommunicationHosts(ctx, []connectioninfo.CommunicationHost{apiCommunicationHost}, dk, OperatorComponent, newMeshSettings(dk.Spec.Istio))
 	if err != nil {
 		return errors.WithMessage(err, "error reconciling config for Dynatrace API URL")
 	}
@@ -86,10 +98,12 @@ func (r *Reconciler) ReconcileAPIURL(ctx context.Context, dk *dynakube.DynaKube)
 	return nil
 }
 
+// ReconcileCodeModules manages the istio objects for the OneAgent communication hosts,
+// which are used by the code modules and the log module.
 func (r *Reconciler) ReconcileCodeModules(ctx context.Context, dk *dynakube.DynaKube) error {
 	log := logd.FromContext(ctx)
 
-	log.Info("reconciling istio components for oneagent-code-modules communication hosts")
+	log.Info("reconciling istio components for oneagent communication hosts")
 
 	if dk == nil {
 		return errors.New("can't reconcile oneagent communication hosts of nil dynakube")
@@ -97,9 +111,9 @@ func (r *Reconciler) ReconcileCodeModules(ctx context.Context, dk *dynakube.Dyna
 
 	migrateDeprecatedCondition(dk.Conditions())
 
-	if !ptr.Deref(dk.Spec.EnableIstio, false) || !dk.OneAgent().IsAppInjectionNeeded() {
-		if isIstioConfigured(dk, codeModuleConditionName) {
-			log.Info("appinjection disabled, cleaning up")
+	if !ptr.Deref(dk.Spec.EnableIstio, false) || (!dk.OneAgent().IsAppInjectionNeeded() && !dk.LogMonitoring().IsEnabled()) {
+		if isIstioConfigured(dk.Conditions(), codeModuleConditionName) {
+			log.Info("appinjection and logmonitoring disabled, cleaning up")
 
 			err := r.cleanupIstio(ctx, dk, CodeModuleComponent)
 			if err != nil {
@@ -113,14 +127,14 @@ func (r *Reconciler) ReconcileCodeModules(ctx context.Context, dk *dynakube.Dyna
 		return nil
 	}
 
-	oaCommunicationHosts, err := connectioninfo.ParseOACommunicationHosts(dk.Status.OneAgent.ConnectionInfo.Endpoints)
+	oaCommunicationHosts, err := connectioninfo.ParseOACommunicationHosts(getOneAgentEndpoints(dk))
 	if err != nil {
 		setServiceEntryFailedConditionForComponent(dk.Conditions(), codeModuleConditionName)
 
 		return err
 	}
 
-	err = r.reconcileCommunicationHostsForComponent(ctx, oaCommunicationHosts, dk, CodeModuleComponent)
+	err = r.reconcileCommunicationHostsForComponent(ctx, oaCommunicationHosts, dk, CodeModuleComponent, newMeshSettings(dk.Spec.Istio))
 	if err != nil {
 		setServiceEntryFailedConditionForComponent(dk.Conditions(), codeModuleConditionName)
 
@@ -148,7 +162,7 @@ func (r *Reconciler) ReconcileActiveGate(ctx context.Context, dk *dynakube.DynaK
 	}
 
 	if !ptr.Deref(dk.Spec.EnableIstio, false) || (!dk.ActiveGate().IsEnabled() && !dk.IsKubemonEnabled()) {
-		if isIstioConfigured(dk, activeGateConditionName) {
+		if isIstioConfigured(dk.Conditions(), activeGateConditionName) {
 			log.Info("activegate disabled, cleaning up")
 
 			err := r.cleanupIstio(ctx, dk, ActiveGateComponent)
@@ -181,7 +195,7 @@ func (r *Reconciler) ReconcileActiveGate(ctx context.Context, dk *dynakube.DynaK
 		return err
 	}
 
-	err = r.reconcileCommunicationHostsForComponent(ctx, agCommunicationHosts, dk, ActiveGateComponent)
+	err = r.reconcileCommunicationHostsForComponent(ctx, agCommunicationHosts, dk, ActiveGateComponent, newMeshSettings(dk.Spec.Istio))
 	if err != nil {
 		setServiceEntryFailedConditionForComponent(dk.Conditions(), activeGateConditionName)
 
@@ -207,16 +221,16 @@ func (r *Reconciler) cleanupIstio(ctx context.Context, owner client.Object, comp
 	return goerrors.Join(err1, err2)
 }
 
-func isIstioConfigured(dk *dynakube.DynaKube, conditionComponent string) bool {
-	istioCondition := meta.FindStatusCondition(*dk.Conditions(), getConditionTypeName(conditionComponent))
+func isIstioConfigured(conditions *[]metav1.Condition, conditionComponent string) bool {
+	istioCondition := meta.FindStatusCondition(*conditions, getConditionTypeName(conditionComponent))
 
 	return istioCondition != nil
 }
 
-func (r *Reconciler) reconcileCommunicationHostsForComponent(ctx context.Context, comHosts []connectioninfo.CommunicationHost, owner client.Object, componentName string) error {
+func (r *Reconciler) reconcileCommunicationHostsForComponent(ctx context.Context, comHosts []connectioninfo.CommunicationHost, owner client.Object, componentName string, mesh meshSettings) error {
 	log := logd.FromContext(ctx)
 
-	err := r.reconcileCommunicationHosts(ctx, comHosts, owner, componentName)
+	err := r.reconcileCommunicationHosts(ctx, comHosts, owner, componentName, mesh)
 	if err != nil {
 		return errors.WithMessage(err, "error reconciling config for Dynatrace communication hosts")
 	}
@@ -226,11 +240,11 @@ func (r *Reconciler) reconcileCommunicationHostsForComponent(ctx context.Context
 	return nil
 }
 
-func (r *Reconciler) reconcileCommunicationHosts(ctx context.Context, comHosts []connectioninfo.CommunicationHost, owner client.Object, component string) error {
+func (r *Reconciler) reconcileCommunicationHosts(ctx context.Context, comHosts []connectioninfo.CommunicationHost, owner client.Object, component string, mesh meshSettings) error {
 	ipHosts, fqdnHosts := splitCommunicationHost(comHosts)
 
-	errIPServiceEntry := r.reconcileIPServiceEntry(ctx, ipHosts, owner, component)
-	errFQDNServiceEntry := r.reconcileFQDNServiceEntry(ctx, fqdnHosts, owner, component)
+	errIPServiceEntry := r.reconcileIPServiceEntry(ctx, ipHosts, owner, component, mesh)
+	errFQDNServiceEntry := r.reconcileFQDNServiceEntry(ctx, fqdnHosts, owner, component, mesh)
 
 	return goerrors.Join(errIPServiceEntry, errFQDNServiceEntry)
 }
@@ -247,14 +261,14 @@ func splitCommunicationHost(comHosts []connectioninfo.CommunicationHost) (ipHost
 	return
 }
 
-func (r *Reconciler) reconcileIPServiceEntry(ctx context.Context, ipHosts []connectioninfo.CommunicationHost, owner client.Object, component string) error {
+func (r *Reconciler) reconcileIPServiceEntry(ctx context.Context, ipHosts []connectioninfo.CommunicationHost, owner client.Object, component string, mesh meshSettings) error {
 	entryName := BuildNameForIPServiceEntry(owner.GetName(), component)
 
 	if len(ipHosts) != 0 {
 		objectMeta := buildObjectMeta(
 			entryName,
 			owner.GetNamespace(),
-			k8slabel.NewCoreLabels(owner.GetName(), component).BuildLabels(),
+			mesh.serviceEntryLabels(k8slabel.NewCoreLabels(owner.GetName(), component).BuildLabels()),
 		)
 
 		serviceEntry := buildServiceEntryIPs(objectMeta, ipHosts)
@@ -263,6 +277,8 @@ func (r *Reconciler) reconcileIPServiceEntry(ctx context.Context, ipHosts []conn
 		if err != nil {
 			return err
 		}
+
+		return r.reconcileAuthorizationPolicy(ctx, objectMeta, owner, mesh)
 	} else {
 		err := r.cleanupIPServiceEntry(ctx, owner, component)
 		if err != nil {
@@ -276,17 +292,20 @@ func (r *Reconciler) reconcileIPServiceEntry(ctx context.Context, ipHosts []conn
 func (r *Reconciler) cleanupIPServiceEntry(ctx context.Context, owner client.Object, component string) error {
 	entryName := BuildNameForIPServiceEntry(owner.GetName(), component)
 
-	return r.serviceEntry.DeleteForNamespace(ctx, entryName, owner.GetNamespace())
+	errServiceEntry := r.serviceEntry.DeleteForNamespace(ctx, entryName, owner.GetNamespace())
+	errAuthorizationPolicy := r.authorizationPolicy.DeleteForNamespace(ctx, entryName, owner.GetNamespace())
+
+	return goerrors.Join(errServiceEntry, errAuthorizationPolicy)
 }
 
-func (r *Reconciler) reconcileFQDNServiceEntry(ctx context.Context, fqdnHosts []connectioninfo.CommunicationHost, owner client.Object, component string) error {
+func (r *Reconciler) reconcileFQDNServiceEntry(ctx context.Context, fqdnHosts []connectioninfo.CommunicationHost, owner client.Object, component string, mesh meshSettings) error {
 	entryName := BuildNameForFQDNServiceEntry(owner.GetName(), component)
 
 	if len(fqdnHosts) != 0 {
 		objectMeta := buildObjectMeta(
 			entryName,
 			owner.GetNamespace(),
-			k8slabel.NewCoreLabels(owner.GetName(), component).BuildLabels(),
+			mesh.serviceEntryLabels(k8slabel.NewCoreLabels(owner.GetName(), component).BuildLabels()),
 		)
 
 		serviceEntry := buildServiceEntryFQDNs(objectMeta, fqdnHosts)
@@ -296,12 +315,18 @@ func (r *Reconciler) reconcileFQDNServiceEntry(ctx context.Context, fqdnHosts []
 			return err
 		}
 
-		virtualService := buildVirtualService(objectMeta, fqdnHosts)
+		// ztunnel and waypoint proxies don't use VirtualServices for egress traffic
+		if mesh.ambient {
+			err = r.virtualService.DeleteForNamespace(ctx, entryName, owner.GetNamespace())
+		} else {
+			_, err = r.virtualService.WithOwner(owner).CreateOrUpdate(ctx, buildVirtualService(objectMeta, fqdnHosts))
+		}
 
-		_, err = r.virtualService.WithOwner(owner).CreateOrUpdate(ctx, virtualService)
 		if err != nil {
 			return err
 		}
+
+		return r.reconcileAuthorizationPolicy(ctx, objectMeta, owner, mesh)
 	} else {
 		err := r.cleanupFQDNServiceEntry(ctx, owner, component)
 		if err != nil {
@@ -317,8 +342,9 @@ func (r *Reconciler) cleanupFQDNServiceEntry(ctx context.Context, owner client.O
 
 	errServiceEntry := r.serviceEntry.DeleteForNamespace(ctx, entryName, owner.GetNamespace())
 	errVirtualService := r.virtualService.DeleteForNamespace(ctx, entryName, owner.GetNamespace())
+	errAuthorizationPolicy := r.authorizationPolicy.DeleteForNamespace(ctx, entryName, owner.GetNamespace())
 
-	return goerrors.Join(errServiceEntry, errVirtualService)
+	return goerrors.Join(errServiceEntry, errVirtualService, errAuthorizationPolicy)
 }
 
 func buildObjectMeta(name, namespace string, labels map[string]string) metav1.ObjectMeta {
@@ -337,3 +363,20 @@ func IsInstalled(ctx context.Context, apiReader client.Reader) bool {
 
 	return true
 }
+
+// getOneAgentEndpoints returns the OneAgent endpoints including the ones of the additional network zones, separated by `;`.
+func getOneAgentEndpoints(dk *dynakube.DynaKube) string {
+	endpoints := []string{}
+
+	if dk.Status.OneAgent.ConnectionInfo.Endpoints != "" {
+		endpoints = append(endpoints, dk.Status.OneAgent.ConnectionInfo.Endpoints)
+	}
+
+	for _, networkZone := range slices.Sorted(maps.Keys(dk.Status.OneAgent.NetworkZoneEndpoints)) {
+		if networkZoneEndpoints := dk.Status.OneAgent.NetworkZoneEndpoints[networkZone]; networkZoneEndpoints != "" {
+			endpoints = append(endpoints, networkZoneEndpoints)
+		}
+	}
+
+	return strings.Join(endpoints, ";")
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
 	kubemonapi "github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/kubemon"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/logmonitoring"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/communication"
@@ -69,7 +70,7 @@ func TestReconcileIPServiceEntry(t *testing.T) {
 		fakeClient := fake.NewClientWithIndex(serviceEntry)
 		reconciler := NewReconciler(fakeClient, fakeClient)
 
-		err := reconciler.reconcileIPServiceEntry(ctx, nil, dk, component)
+		err := reconciler.reconcileIPServiceEntry(ctx, nil, dk, component, meshSettings{})
 		require.NoError(t, err)
 
 		err = fakeClient.Get(ctx, client.ObjectKeyFromObject(serviceEntry), serviceEntry)
@@ -84,7 +85,7 @@ func TestReconcileIPServiceEntry(t *testing.T) {
 			createTestIPCommunicationHost(),
 		}
 
-		err := reconciler.reconcileIPServiceEntry(ctx, commHosts, dk, component)
+		err := reconciler.reconcileIPServiceEntry(ctx, commHosts, dk, component, meshSettings{})
 		require.NoError(t, err)
 
 		expectedServiceEntry := &istiov1beta1.ServiceEntry{
@@ -108,7 +109,7 @@ func TestReconcileIPServiceEntry(t *testing.T) {
 			createTestIPCommunicationHost(),
 		}
 
-		err := reconciler.reconcileIPServiceEntry(ctx, commHosts, dk, component)
+		err := reconciler.reconcileIPServiceEntry(ctx, commHosts, dk, component, meshSettings{})
 		require.Error(t, err)
 	})
 }
@@ -134,7 +135,7 @@ func TestReconcileFQDNServiceEntry(t *testing.T) {
 		fakeClient := fake.NewClientWithIndex(serviceEntry, virtualService)
 		reconciler := NewReconciler(fakeClient, fakeClient)
 
-		err := reconciler.reconcileFQDNServiceEntry(ctx, nil, owner, component)
+		err := reconciler.reconcileFQDNServiceEntry(ctx, nil, owner, component, meshSettings{})
 		require.NoError(t, err)
 		err = fakeClient.Get(ctx, client.ObjectKeyFromObject(serviceEntry), serviceEntry)
 		require.True(t, k8serrors.IsNotFound(err))
@@ -150,7 +151,7 @@ func TestReconcileFQDNServiceEntry(t *testing.T) {
 			createTestFQDNCommunicationHost(),
 		}
 
-		err := reconciler.reconcileFQDNServiceEntry(ctx, commHosts, owner, component)
+		err := reconciler.reconcileFQDNServiceEntry(ctx, commHosts, owner, component, meshSettings{})
 		require.NoError(t, err)
 
 		expectedServiceEntry := &istiov1beta1.ServiceEntry{
@@ -184,7 +185,7 @@ func TestReconcileFQDNServiceEntry(t *testing.T) {
 			createTestFQDNCommunicationHost(),
 		}
 
-		err := reconciler.reconcileFQDNServiceEntry(ctx, commHosts, owner, component)
+		err := reconciler.reconcileFQDNServiceEntry(ctx, commHosts, owner, component, meshSettings{})
 		require.Error(t, err)
 	})
 }
@@ -303,6 +304,22 @@ func TestReconcileOneAgentCommunicationHosts(t *testing.T) {
 		require.NotNil(t, statusCondition)
 		require.Equal(t, "IstioForOneAgentChanged", statusCondition.Reason)
 	})
+	t.Run("logmonitoring without AppInjection => success", func(t *testing.T) {
+		ctx := t.Context()
+		dk := createTestDynaKube()
+		dk.Spec.OneAgent = oneagent.Spec{}
+		dk.Spec.LogMonitoring = &logmonitoring.Spec{}
+		fakeClient := fake.NewClientWithIndex()
+		reconciler := NewReconciler(fakeClient, fakeClient)
+
+		err := reconciler.ReconcileCodeModules(ctx, dk)
+		require.NoError(t, err)
+
+		expectedFQDNServiceEntry := &istiov1beta1.ServiceEntry{}
+		err = fakeClient.Get(ctx, client.ObjectKey{Name: BuildNameForFQDNServiceEntry(dk.GetName(), CodeModuleComponent), Namespace: dk.GetNamespace()}, expectedFQDNServiceEntry)
+		require.NoError(t, err)
+		assert.Contains(t, expectedFQDNServiceEntry.Spec.GetHosts(), "something.test.io")
+	})
 	t.Run("unknown k8s client error => error", func(t *testing.T) {
 		ctx := t.Context()
 		dk := createTestDynaKube()
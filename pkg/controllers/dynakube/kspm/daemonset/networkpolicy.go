@@ -0,0 +1,49 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package daemonset
+
+import (
+	"context"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	agconsts "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/networkpolicy"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8snetworkpolicy"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+const networkPolicyConditionType = "NodeConfigCollectorNetworkPolicy"
+
+// reconcileNetworkPolicy keeps the NetworkPolicy of the node config collector pods, it has the same name as the DaemonSet.
+func (r *Reconciler) reconcileNetworkPolicy(ctx context.Context, dk *dynakube.DynaKube) error {
+	name := dk.KSPM().GetDaemonSetName()
+
+	if !dk.IsNetworkPolicyEnabled() || !dk.KSPM().IsEnabled() {
+		return networkpolicy.Remove(ctx, r.client, r.apiReader, dk, networkPolicyConditionType, name)
+	}
+
+	return networkpolicy.Reconcile(ctx, r.client, r.apiReader, dk, networkPolicyConditionType, func() (*networkingv1.NetworkPolicy, error) {
+		return buildNetworkPolicy(dk, name)
+	})
+}
+
+// buildNetworkPolicy denies all ingress, the node config collectors only send their findings to the ActiveGate,
+// or to the kubemon gateway if kubemon is enabled. They have no access to the Kubernetes API.
+func buildNetworkPolicy(dk *dynakube.DynaKube, name string) (*networkingv1.NetworkPolicy, error) {
+	labels := k8slabel.NewAppLabels(k8slabel.KSPMComponentLabel, dk.Name, k8slabel.KSPMComponentLabel, "")
+
+	activeGatePods := networkpolicy.ActiveGatePods(dk)
+	if dk.IsKubemonEnabled() {
+		activeGatePods = networkpolicy.KubeMonPods(dk)
+	}
+
+	return k8snetworkpolicy.Build(dk, name, networkpolicy.KSPMPods(dk),
+		k8snetworkpolicy.SetLabels(labels.BuildLabels()),
+		k8snetworkpolicy.AddEgressRules(
+			networkpolicy.DNSEgress(),
+			networkpolicy.EgressToPods(activeGatePods, networkpolicy.TCPPort(agconsts.HTTPSContainerPort)),
+		),
+	)
+}
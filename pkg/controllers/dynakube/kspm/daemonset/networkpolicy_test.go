@@ -0,0 +1,83 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package daemonset
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	kubemonapi "github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/kubemon"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/networkpolicy"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8senv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestReconcileNetworkPolicy(t *testing.T) {
+	t.Run("no network policy by default", func(t *testing.T) {
+		dk := createDynakube(true)
+		clt := fake.NewClient()
+
+		require.NoError(t, NewReconciler(clt, clt).Reconcile(t.Context(), dk))
+
+		err := clt.Get(t.Context(), types.NamespacedName{Name: dk.KSPM().GetDaemonSetName(), Namespace: dk.Namespace}, &networkingv1.NetworkPolicy{})
+		assert.True(t, k8serrors.IsNotFound(err))
+	})
+
+	t.Run("egress to the ActiveGate", func(t *testing.T) {
+		dk := createDynakube(true)
+		dk.Spec.NetworkPolicies = &dynakube.NetworkPoliciesSpec{}
+		clt := fake.NewClient()
+
+		require.NoError(t, NewReconciler(clt, clt).Reconcile(t.Context(), dk))
+
+		var networkPolicy networkingv1.NetworkPolicy
+		require.NoError(t, clt.Get(t.Context(), types.NamespacedName{Name: dk.KSPM().GetDaemonSetName(), Namespace: dk.Namespace}, &networkPolicy))
+
+		assert.Equal(t, networkpolicy.KSPMPods(dk), networkPolicy.Spec.PodSelector.MatchLabels)
+		assert.Empty(t, networkPolicy.Spec.Ingress)
+		require.Len(t, networkPolicy.Spec.Egress, 2)
+		assert.Equal(t, networkpolicy.DNSEgress(), networkPolicy.Spec.Egress[0])
+		assert.Equal(t, networkpolicy.ActiveGatePods(dk), networkPolicy.Spec.Egress[1].To[0].PodSelector.MatchLabels)
+		assert.NotNil(t, meta.FindStatusCondition(*dk.Conditions(), networkPolicyConditionType))
+	})
+
+	t.Run("egress to the kubemon gateway", func(t *testing.T) {
+		t.Setenv(k8senv.ExperimentalEnableKubemonOperand, "true")
+
+		dk := createDynakube(true)
+		dk.Spec.NetworkPolicies = &dynakube.NetworkPoliciesSpec{}
+		dk.Spec.KubernetesMonitoring = &kubemonapi.Spec{}
+		clt := fake.NewClient()
+
+		require.NoError(t, NewReconciler(clt, clt).Reconcile(t.Context(), dk))
+
+		var networkPolicy networkingv1.NetworkPolicy
+		require.NoError(t, clt.Get(t.Context(), types.NamespacedName{Name: dk.KSPM().GetDaemonSetName(), Namespace: dk.Namespace}, &networkPolicy))
+
+		require.Len(t, networkPolicy.Spec.Egress, 2)
+		assert.Equal(t, networkpolicy.KubeMonPods(dk), networkPolicy.Spec.Egress[1].To[0].PodSelector.MatchLabels)
+	})
+
+	t.Run("network policy is removed with KSPM", func(t *testing.T) {
+		dk := createDynakube(true)
+		dk.Spec.NetworkPolicies = &dynakube.NetworkPoliciesSpec{}
+		clt := fake.NewClient()
+		reconciler := NewReconciler(clt, clt)
+
+		require.NoError(t, reconciler.Reconcile(t.Context(), dk))
+
+		dk.Spec.KSPM = nil
+		require.NoError(t, reconciler.Reconcile(t.Context(), dk))
+
+		err := clt.Get(t.Context(), types.NamespacedName{Name: dk.KSPM().GetDaemonSetName(), Namespace: dk.Namespace}, &networkingv1.NetworkPolicy{})
+		assert.True(t, k8serrors.IsNotFound(err))
+		assert.Nil(t, meta.FindStatusCondition(*dk.Conditions(), networkPolicyConditionType))
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8saffinity"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8sconditions"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8spodtemplate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8ssecuritycontext"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8sdaemonset"
 	appsv1 "k8s.io/api/apps/v1"
@@ -29,11 +30,15 @@ const (
 )
 
 type Reconciler struct {
+	client    client.Client
+	apiReader client.Reader
 	daemonset k8sdaemonset.QueryObject
 }
 
 func NewReconciler(clt client.Client, apiReader client.Reader) *Reconciler {
 	return &Reconciler{
+		client:    clt,
+		apiReader: apiReader,
 		daemonset: k8sdaemonset.Query(clt, apiReader),
 	}
 }
@@ -41,6 +46,10 @@ func NewReconciler(clt client.Client, apiReader client.Reader) *Reconciler {
 func (r *Reconciler) Reconcile(ctx context.Context, dk *dynakube.DynaKube) error {
 	ctx, log := logd.NewFromContext(ctx, "daemonset")
 
+	if err := r.reconcileNetworkPolicy(ctx, dk); err != nil {
+		return err
+	}
+
 	if !dk.KSPM().IsEnabled() {
 		if meta.FindStatusCondition(*dk.Conditions(), conditionType) == nil {
 			return nil // no condition == nothing is there to clean up
@@ -61,6 +70,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, dk *dynakube.DynaKube) error
 		return err
 	}
 
+	if dk.KSPM().VerticalPodAutoscaler.IsAutoMode() {
+		if err := k8sdaemonset.AddHashAnnotationIgnoringResources(ds); err != nil {
+			return err
+		}
+	}
+
 	updated, err := r.daemonset.WithOwner(dk).CreateOrUpdate(ctx, ds)
 	if err != nil {
 		k8sconditions.SetKubeAPIError(dk.Conditions(), conditionType, err)
@@ -110,6 +125,10 @@ func (r *Reconciler) generateDaemonSet(dk *dynakube.DynaKube) (*appsv1.DaemonSet
 		return nil, err
 	}
 
+	if err := k8spodtemplate.Apply(&ds.Spec.Template, dk.KSPM().PodTemplatePatch); err != nil {
+		return nil, err
+	}
+
 	return ds, nil
 }
 
@@ -0,0 +1,64 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package statefulset
+
+import (
+	"context"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	agconsts "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/connectioninfo"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/networkpolicy"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8snetworkpolicy"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+const networkPolicyConditionType = "KubeMonNetworkPolicy"
+
+// reconcileNetworkPolicy keeps the NetworkPolicy of the kubemon pods, it has the same name as the StatefulSet.
+func (r *Reconciler) reconcileNetworkPolicy(ctx context.Context, dk *dynakube.DynaKube) error {
+	if !dk.IsNetworkPolicyEnabled() {
+		return r.deleteNetworkPolicy(ctx, dk)
+	}
+
+	return networkpolicy.Reconcile(ctx, r.kubeClient, r.kubeClient, dk, networkPolicyConditionType, func() (*networkingv1.NetworkPolicy, error) {
+		return r.buildNetworkPolicy(ctx, dk)
+	})
+}
+
+func (r *Reconciler) deleteNetworkPolicy(ctx context.Context, dk *dynakube.DynaKube) error {
+	return networkpolicy.Remove(ctx, r.kubeClient, r.kubeClient, dk, networkPolicyConditionType, dk.KubernetesMonitoring().GetStatefulSetName())
+}
+
+// buildNetworkPolicy only allows the ingress from the KSPM node collectors, which send their data through the kubemon gateway service.
+// The egress is restricted to the Kubernetes API and the communication hosts of kubemon.
+func (r *Reconciler) buildNetworkPolicy(ctx context.Context, dk *dynakube.DynaKube) (*networkingv1.NetworkPolicy, error) {
+	labels := k8slabel.New(k8slabel.KubeMonComponentLabel, dk.GetName(), "")
+
+	hosts, err := networkpolicy.TenantHosts(ctx, r.kubeClient, dk)
+	if err != nil {
+		return nil, err
+	}
+
+	kubemonHosts, err := connectioninfo.ParseAGCommunicationHosts(dk.Status.KubernetesMonitoring.ConnectionInfo.Endpoints)
+	if err != nil {
+		return nil, err
+	}
+
+	options := []k8snetworkpolicy.Option{
+		k8snetworkpolicy.SetLabels(labels.AsMap()),
+		k8snetworkpolicy.AddEgressRules(networkpolicy.DNSEgress(), networkpolicy.KubernetesAPIEgress(dk)),
+		k8snetworkpolicy.AddEgressRules(networkpolicy.CommunicationHostsEgress(append(hosts, kubemonHosts...))...),
+	}
+
+	if dk.KSPM().IsEnabled() {
+		options = append(options, k8snetworkpolicy.AddIngressRules(networkpolicy.IngressFromPods(networkpolicy.KSPMPods(dk),
+			networkpolicy.TCPPort(agconsts.HTTPSContainerPort),
+			networkpolicy.TCPPort(agconsts.HTTPContainerPort),
+		)))
+	}
+
+	return k8snetworkpolicy.Build(dk, dk.KubernetesMonitoring().GetStatefulSetName(), labels.AsSelector(), options...)
+}
@@ -0,0 +1,131 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package statefulset_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	kspmapi "github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/kspm"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/kubemon/statefulset"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/networkpolicy"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8sstatefulset"
+	imageclientmock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/clients/dynatrace/image"
+	versionclientmock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/clients/dynatrace/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestReconcileNetworkPolicy(t *testing.T) {
+	t.Run("no network policy by default", func(t *testing.T) {
+		dk := newTestDynaKube()
+		deletes := 0
+		fakeClient := fake.NewClientWithInterceptors(interceptor.Funcs{
+			Delete: func(ctx context.Context, clt client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				if _, ok := obj.(*networkingv1.NetworkPolicy); ok {
+					deletes++
+				}
+
+				return clt.Delete(ctx, obj, opts...)
+			},
+		}, dk, newTestTenantSecret(dk), newTestAuthTokenSecret(dk))
+
+		require.ErrorIs(t, statefulset.NewReconciler(fakeClient).Reconcile(t.Context(), dk, imageclientmock.NewClient(t), versionclientmock.NewClient(t)), k8sstatefulset.ErrRolloutInProgress)
+
+		err := fakeClient.Get(t.Context(), networkPolicyKey(dk), &networkingv1.NetworkPolicy{})
+		assert.True(t, k8serrors.IsNotFound(err))
+		assert.Zero(t, deletes, "no delete request without a created network policy")
+		assert.Nil(t, meta.FindStatusCondition(*dk.Conditions(), "KubeMonNetworkPolicy"))
+	})
+
+	t.Run("egress only without KSPM", func(t *testing.T) {
+		dk := newTestDynaKube()
+		dk.Spec.NetworkPolicies = &dynakube.NetworkPoliciesSpec{}
+		fakeClient := fake.NewClient(dk, newTestTenantSecret(dk), newTestAuthTokenSecret(dk))
+
+		require.ErrorIs(t, statefulset.NewReconciler(fakeClient).Reconcile(t.Context(), dk, imageclientmock.NewClient(t), versionclientmock.NewClient(t)), k8sstatefulset.ErrRolloutInProgress)
+
+		networkPolicy := requireNetworkPolicy(t, fakeClient, dk)
+		sts := requireTestStatefulSet(t, t.Context(), fakeClient, dk)
+
+		assert.Equal(t, sts.Spec.Selector.MatchLabels, networkPolicy.Spec.PodSelector.MatchLabels)
+		assert.Empty(t, networkPolicy.Spec.Ingress)
+		require.NotEmpty(t, networkPolicy.Spec.Egress)
+		assert.Equal(t, networkpolicy.DNSEgress(), networkPolicy.Spec.Egress[0])
+
+		condition := meta.FindStatusCondition(*dk.Conditions(), "KubeMonNetworkPolicy")
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	})
+
+	t.Run("ingress from KSPM node collectors", func(t *testing.T) {
+		dk := newTestDynaKube()
+		dk.Spec.NetworkPolicies = &dynakube.NetworkPoliciesSpec{}
+		dk.Spec.KSPM = &kspmapi.Spec{}
+		fakeClient := fake.NewClient(dk, newTestTenantSecret(dk), newTestAuthTokenSecret(dk))
+
+		require.ErrorIs(t, statefulset.NewReconciler(fakeClient).Reconcile(t.Context(), dk, imageclientmock.NewClient(t), versionclientmock.NewClient(t)), k8sstatefulset.ErrRolloutInProgress)
+
+		networkPolicy := requireNetworkPolicy(t, fakeClient, dk)
+		require.Len(t, networkPolicy.Spec.Ingress, 1)
+		require.Len(t, networkPolicy.Spec.Ingress[0].From, 1)
+		assert.Equal(t, networkpolicy.KSPMPods(dk), networkPolicy.Spec.Ingress[0].From[0].PodSelector.MatchLabels)
+		assert.Len(t, networkPolicy.Spec.Ingress[0].Ports, 2)
+	})
+
+	t.Run("network policy is removed when disabled", func(t *testing.T) {
+		dk := newTestDynaKube()
+		dk.Spec.NetworkPolicies = &dynakube.NetworkPoliciesSpec{}
+		fakeClient := fake.NewClient(dk, newTestTenantSecret(dk), newTestAuthTokenSecret(dk))
+		reconciler := statefulset.NewReconciler(fakeClient)
+
+		require.ErrorIs(t, reconciler.Reconcile(t.Context(), dk, imageclientmock.NewClient(t), versionclientmock.NewClient(t)), k8sstatefulset.ErrRolloutInProgress)
+		requireNetworkPolicy(t, fakeClient, dk)
+
+		dk.Spec.NetworkPolicies = nil
+		require.ErrorIs(t, reconciler.Reconcile(t.Context(), dk, imageclientmock.NewClient(t), versionclientmock.NewClient(t)), k8sstatefulset.ErrRolloutInProgress)
+
+		err := fakeClient.Get(t.Context(), networkPolicyKey(dk), &networkingv1.NetworkPolicy{})
+		assert.True(t, k8serrors.IsNotFound(err))
+		assert.Nil(t, meta.FindStatusCondition(*dk.Conditions(), "KubeMonNetworkPolicy"))
+	})
+
+	t.Run("network policy is removed with kubemon", func(t *testing.T) {
+		dk := newTestDynaKube()
+		dk.Spec.NetworkPolicies = &dynakube.NetworkPoliciesSpec{}
+		fakeClient := fake.NewClient(dk, newTestTenantSecret(dk), newTestAuthTokenSecret(dk))
+		reconciler := statefulset.NewReconciler(fakeClient)
+
+		require.ErrorIs(t, reconciler.Reconcile(t.Context(), dk, imageclientmock.NewClient(t), versionclientmock.NewClient(t)), k8sstatefulset.ErrRolloutInProgress)
+		requireNetworkPolicy(t, fakeClient, dk)
+
+		dk.Spec.KubernetesMonitoring = nil
+		require.NoError(t, reconciler.Reconcile(t.Context(), dk, imageclientmock.NewClient(t), versionclientmock.NewClient(t)))
+
+		err := fakeClient.Get(t.Context(), networkPolicyKey(dk), &networkingv1.NetworkPolicy{})
+		assert.True(t, k8serrors.IsNotFound(err))
+	})
+}
+
+func networkPolicyKey(dk *dynakube.DynaKube) types.NamespacedName {
+	return types.NamespacedName{Name: dk.KubernetesMonitoring().GetStatefulSetName(), Namespace: dk.Namespace}
+}
+
+func requireNetworkPolicy(t *testing.T, clt client.Client, dk *dynakube.DynaKube) *networkingv1.NetworkPolicy {
+	t.Helper()
+
+	var networkPolicy networkingv1.NetworkPolicy
+	require.NoError(t, clt.Get(t.Context(), networkPolicyKey(dk), &networkPolicy))
+
+	return &networkPolicy
+}
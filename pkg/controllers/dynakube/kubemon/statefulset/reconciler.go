@@ -23,6 +23,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8spodtemplate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8sstatefulset"
 	maputil "github.com/Dynatrace/dynatrace-operator/pkg/util/map"
 	"github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/mutator"
@@ -75,6 +76,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, dk *dynakube.DynaKube, image
 		return err
 	}
 
+	if err := r.reconcileNetworkPolicy(ctx, dk); err != nil {
+		return err
+	}
+
 	desiredStatefulSet, err := r.buildDesiredStatefulSet(ctx, dk, imageClient, versionClient)
 	if err != nil {
 		return err
@@ -277,6 +282,10 @@ func buildVolumeMounts(dk *dynakube.DynaKube) []corev1.VolumeMount {
 }
 
 func (r *Reconciler) delete(ctx context.Context, dk *dynakube.DynaKube) error {
+	if err := r.deleteNetworkPolicy(ctx, dk); err != nil {
+		return err
+	}
+
 	statefulSet := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: dk.KubernetesMonitoring().GetStatefulSetName(), Namespace: dk.Namespace}}
 
 	return r.sts.Delete(ctx, statefulSet)
@@ -384,7 +393,16 @@ func (r *Reconciler) buildDesiredStatefulSet(ctx context.Context, dk *dynakube.D
 		k8sstatefulset.SetAutomountServiceAccountToken(true),
 	}
 
-	return k8sstatefulset.Build(dk, km.GetStatefulSetName(), container, opts...)
+	sts, err := k8sstatefulset.Build(dk, km.GetStatefulSetName(), container, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := k8spodtemplate.Apply(&sts.Spec.Template, km.PodTemplatePatch); err != nil {
+		return nil, err
+	}
+
+	return sts, nil
 }
 
 func (r *Reconciler) getTenantTokenHash(ctx context.Context, dk *dynakube.DynaKube) (string, error) {
@@ -0,0 +1,57 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package daemonset
+
+import (
+	"context"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	agconsts "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/connectioninfo"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/networkpolicy"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8snetworkpolicy"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+const NetworkPolicyConditionType = "LogMonitoringNetworkPolicy"
+
+// reconcileNetworkPolicy keeps the NetworkPolicy of the log module pods, it has the same name as the DaemonSet.
+func (r *Reconciler) reconcileNetworkPolicy(ctx context.Context, dk *dynakube.DynaKube) error {
+	name := dk.LogMonitoring().GetDaemonSetName()
+
+	if !dk.IsNetworkPolicyEnabled() || !dk.LogMonitoring().IsStandalone() {
+		return networkpolicy.Remove(ctx, r.client, r.apiReader, dk, NetworkPolicyConditionType, name)
+	}
+
+	return networkpolicy.Reconcile(ctx, r.client, r.apiReader, dk, NetworkPolicyConditionType, func() (*networkingv1.NetworkPolicy, error) {
+		return r.buildNetworkPolicy(ctx, dk, name)
+	})
+}
+
+// buildNetworkPolicy denies all ingress, the log module only ships logs to the OneAgent communication hosts,
+// which can also be an ActiveGate inside the cluster.
+func (r *Reconciler) buildNetworkPolicy(ctx context.Context, dk *dynakube.DynaKube, name string) (*networkingv1.NetworkPolicy, error) {
+	hosts, err := networkpolicy.TenantHosts(ctx, r.apiReader, dk)
+	if err != nil {
+		return nil, err
+	}
+
+	oaHosts, err := connectioninfo.ParseOACommunicationHosts(dk.Status.OneAgent.ConnectionInfo.Endpoints)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := k8slabel.NewAppLabels(k8slabel.LogMonitoringComponentLabel, dk.Name, k8slabel.LogMonitoringComponentLabel, "")
+
+	return k8snetworkpolicy.Build(dk, name, networkpolicy.LogMonitoringPods(dk),
+		k8snetworkpolicy.SetLabels(labels.BuildLabels()),
+		k8snetworkpolicy.AddEgressRules(
+			networkpolicy.DNSEgress(),
+			networkpolicy.KubernetesAPIEgress(dk),
+			networkpolicy.EgressToPods(networkpolicy.ActiveGatePods(dk), networkpolicy.TCPPort(agconsts.HTTPSContainerPort)),
+		),
+		k8snetworkpolicy.AddEgressRules(networkpolicy.CommunicationHostsEgress(append(hosts, oaHosts...))...),
+	)
+}
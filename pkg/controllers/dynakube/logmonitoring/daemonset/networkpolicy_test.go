@@ -0,0 +1,84 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package daemonset
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/networkpolicy"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8sconditions"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestReconcileNetworkPolicy(t *testing.T) {
+	t.Cleanup(version.DisableCacheForTest(123))
+
+	createNetworkPolicyDynakube := func() *dynakube.DynaKube {
+		dk := createDynakube(true)
+		dk.Spec.APIURL = "https://tenant.live.dynatrace.com/api"
+		dk.Spec.NetworkPolicies = &dynakube.NetworkPoliciesSpec{}
+		dk.Status.OneAgent.ConnectionInfo.Endpoints = "https://10.0.0.1:443/communication;https://tenant.live.dynatrace.com:8443/communication"
+
+		return dk
+	}
+
+	t.Run("no network policy by default", func(t *testing.T) {
+		dk := createDynakube(true)
+		clt := fake.NewClient()
+
+		require.NoError(t, NewReconciler(clt, clt).Reconcile(t.Context(), nil, dk))
+
+		err := clt.Get(t.Context(), types.NamespacedName{Name: dk.LogMonitoring().GetDaemonSetName(), Namespace: dk.Namespace}, &networkingv1.NetworkPolicy{})
+		assert.True(t, k8serrors.IsNotFound(err))
+		assert.Nil(t, meta.FindStatusCondition(*dk.Conditions(), NetworkPolicyConditionType))
+	})
+
+	t.Run("egress to the OneAgent communication hosts", func(t *testing.T) {
+		dk := createNetworkPolicyDynakube()
+		clt := fake.NewClient()
+
+		require.NoError(t, NewReconciler(clt, clt).Reconcile(t.Context(), nil, dk))
+
+		var networkPolicy networkingv1.NetworkPolicy
+		require.NoError(t, clt.Get(t.Context(), types.NamespacedName{Name: dk.LogMonitoring().GetDaemonSetName(), Namespace: dk.Namespace}, &networkPolicy))
+
+		assert.Equal(t, networkpolicy.LogMonitoringPods(dk), networkPolicy.Spec.PodSelector.MatchLabels)
+		assert.Empty(t, networkPolicy.Spec.Ingress)
+		assert.Contains(t, networkPolicy.Spec.PolicyTypes, networkingv1.PolicyTypeIngress)
+
+		require.Len(t, networkPolicy.Spec.Egress, 5)
+		assert.Equal(t, networkpolicy.DNSEgress(), networkPolicy.Spec.Egress[0])
+		assert.Equal(t, networkpolicy.ActiveGatePods(dk), networkPolicy.Spec.Egress[2].To[0].PodSelector.MatchLabels)
+		assert.Equal(t, "10.0.0.1/32", networkPolicy.Spec.Egress[3].To[0].IPBlock.CIDR)
+		assert.Len(t, networkPolicy.Spec.Egress[4].Ports, 2)
+
+		condition := meta.FindStatusCondition(*dk.Conditions(), NetworkPolicyConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, k8sconditions.NetworkPolicyCreatedOrUpdatedReason, condition.Reason)
+	})
+
+	t.Run("network policy is removed when not standalone", func(t *testing.T) {
+		dk := createNetworkPolicyDynakube()
+		clt := fake.NewClient()
+		reconciler := NewReconciler(clt, clt)
+
+		require.NoError(t, reconciler.Reconcile(t.Context(), nil, dk))
+
+		dk.Spec.OneAgent.CloudNativeFullStack = &oneagent.CloudNativeFullStackSpec{}
+		require.NoError(t, reconciler.Reconcile(t.Context(), nil, dk))
+
+		err := clt.Get(t.Context(), types.NamespacedName{Name: dk.LogMonitoring().GetDaemonSetName(), Namespace: dk.Namespace}, &networkingv1.NetworkPolicy{})
+		assert.True(t, k8serrors.IsNotFound(err))
+		assert.Nil(t, meta.FindStatusCondition(*dk.Conditions(), NetworkPolicyConditionType))
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8saffinity"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8sconditions"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8spodtemplate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8sdaemonset"
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
@@ -30,12 +31,16 @@ const (
 )
 
 type Reconciler struct {
+	client    client.Client
+	apiReader client.Reader
 	daemonset k8sdaemonset.QueryObject
 }
 
 func NewReconciler(clt client.Client,
 	apiReader client.Reader) *Reconciler {
 	return &Reconciler{
+		client:    clt,
+		apiReader: apiReader,
 		daemonset: k8sdaemonset.Query(clt, apiReader),
 	}
 }
@@ -45,6 +50,10 @@ var KubernetesSettingsNotAvailableError = errors.New("the status of the DynaKube
 func (r *Reconciler) Reconcile(ctx context.Context, imageClient dtimage.Client, dk *dynakube.DynaKube) error {
 	ctx, log := logd.NewFromContext(ctx, "daemonset")
 
+	if err := r.reconcileNetworkPolicy(ctx, dk); err != nil {
+		return err
+	}
+
 	if !dk.LogMonitoring().IsStandalone() {
 		if meta.FindStatusCondition(*dk.Conditions(), ConditionType) == nil {
 			return nil // no condition == nothing is there to clean up
@@ -80,6 +89,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, imageClient dtimage.Client,
 		return err
 	}
 
+	if dk.LogMonitoring().Template().VerticalPodAutoscaler.IsAutoMode() {
+		if err := k8sdaemonset.AddHashAnnotationIgnoringResources(ds); err != nil {
+			return err
+		}
+	}
+
 	updated, err := r.daemonset.WithOwner(dk).CreateOrUpdate(ctx, ds)
 	if err != nil {
 		k8sconditions.SetKubeAPIError(dk.Conditions(), ConditionType, err)
@@ -127,6 +142,10 @@ func (r *Reconciler) generateDaemonSet(dk *dynakube.DynaKube, imageURI string) (
 		return nil, err
 	}
 
+	if err := k8spodtemplate.Apply(&ds.Spec.Template, dk.LogMonitoring().Template().PodTemplatePatch); err != nil {
+		return nil, err
+	}
+
 	return ds, nil
 }
 
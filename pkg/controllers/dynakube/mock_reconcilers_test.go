@@ -9,6 +9,7 @@ package dynakube
 
 import (
 	"context"
+	"time"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
@@ -102,6 +103,63 @@ func (_c *mockIstioReconciler_ReconcileAPIURL_Call) RunAndReturn(run func(ctx co
 	return _c
 }
 
+// ReconcileInClusterEndpoints provides a mock function for the type mockIstioReconciler
+func (_mock *mockIstioReconciler) ReconcileInClusterEndpoints(ctx context.Context, dk *dynakube.DynaKube) error {
+	ret := _mock.Called(ctx, dk)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReconcileInClusterEndpoints")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynakube.DynaKube) error); ok {
+		r0 = returnFunc(ctx, dk)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// mockIstioReconciler_ReconcileInClusterEndpoints_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReconcileInClusterEndpoints'
+type mockIstioReconciler_ReconcileInClusterEndpoints_Call struct {
+	*mock.Call
+}
+
+// ReconcileInClusterEndpoints is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dk *dynakube.DynaKube
+func (_e *mockIstioReconciler_Expecter) ReconcileInClusterEndpoints(ctx any, dk any) *mockIstioReconciler_ReconcileInClusterEndpoints_Call {
+	return &mockIstioReconciler_ReconcileInClusterEndpoints_Call{Call: _e.mock.On("ReconcileInClusterEndpoints", ctx, dk)}
+}
+
+func (_c *mockIstioReconciler_ReconcileInClusterEndpoints_Call) Run(run func(ctx context.Context, dk *dynakube.DynaKube)) *mockIstioReconciler_ReconcileInClusterEndpoints_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *dynakube.DynaKube
+		if args[1] != nil {
+			arg1 = args[1].(*dynakube.DynaKube)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockIstioReconciler_ReconcileInClusterEndpoints_Call) Return(err error) *mockIstioReconciler_ReconcileInClusterEndpoints_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *mockIstioReconciler_ReconcileInClusterEndpoints_Call) RunAndReturn(run func(ctx context.Context, dk *dynakube.DynaKube) error) *mockIstioReconciler_ReconcileInClusterEndpoints_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // newMockDynakubeReconciler creates a new instance of mockDynakubeReconciler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func newMockDynakubeReconciler(t interface {
@@ -923,3 +981,96 @@ func (_c *mockKubemonReconciler_Reconcile_Call) RunAndReturn(run func(ctx contex
 	_c.Call.Return(run)
 	return _c
 }
+
+// newMockWorkloadIdentityPullSecretReconciler creates a new instance of mockWorkloadIdentityPullSecretReconciler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockWorkloadIdentityPullSecretReconciler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockWorkloadIdentityPullSecretReconciler {
+	mock := &mockWorkloadIdentityPullSecretReconciler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockWorkloadIdentityPullSecretReconciler is an autogenerated mock type for the workloadIdentityPullSecretReconciler type
+type mockWorkloadIdentityPullSecretReconciler struct {
+	mock.Mock
+}
+
+type mockWorkloadIdentityPullSecretReconciler_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockWorkloadIdentityPullSecretReconciler) EXPECT() *mockWorkloadIdentityPullSecretReconciler_Expecter {
+	return &mockWorkloadIdentityPullSecretReconciler_Expecter{mock: &_m.Mock}
+}
+
+// Reconcile provides a mock function for the type mockWorkloadIdentityPullSecretReconciler
+func (_mock *mockWorkloadIdentityPullSecretReconciler) Reconcile(ctx context.Context, dk *dynakube.DynaKube) (time.Duration, error) {
+	ret := _mock.Called(ctx, dk)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reconcile")
+	}
+
+	var r0 time.Duration
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynakube.DynaKube) (time.Duration, error)); ok {
+		return returnFunc(ctx, dk)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynakube.DynaKube) time.Duration); ok {
+		r0 = returnFunc(ctx, dk)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *dynakube.DynaKube) error); ok {
+		r1 = returnFunc(ctx, dk)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockWorkloadIdentityPullSecretReconciler_Reconcile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reconcile'
+type mockWorkloadIdentityPullSecretReconciler_Reconcile_Call struct {
+	*mock.Call
+}
+
+// Reconcile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dk *dynakube.DynaKube
+func (_e *mockWorkloadIdentityPullSecretReconciler_Expecter) Reconcile(ctx any, dk any) *mockWorkloadIdentityPullSecretReconciler_Reconcile_Call {
+	return &mockWorkloadIdentityPullSecretReconciler_Reconcile_Call{Call: _e.mock.On("Reconcile", ctx, dk)}
+}
+
+func (_c *mockWorkloadIdentityPullSecretReconciler_Reconcile_Call) Run(run func(ctx context.Context, dk *dynakube.DynaKube)) *mockWorkloadIdentityPullSecretReconciler_Reconcile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *dynakube.DynaKube
+		if args[1] != nil {
+			arg1 = args[1].(*dynakube.DynaKube)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockWorkloadIdentityPullSecretReconciler_Reconcile_Call) Return(duration time.Duration, err error) *mockWorkloadIdentityPullSecretReconciler_Reconcile_Call {
+	_c.Call.Return(duration, err)
+	return _c
+}
+
+func (_c *mockWorkloadIdentityPullSecretReconciler_Reconcile_Call) RunAndReturn(run func(ctx context.Context, dk *dynakube.DynaKube) (time.Duration, error)) *mockWorkloadIdentityPullSecretReconciler_Reconcile_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,62 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package networkpolicy
+
+import (
+	"context"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8sconditions"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8snetworkpolicy"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Builder builds the desired NetworkPolicy of an operand.
+type Builder func() (*networkingv1.NetworkPolicy, error)
+
+// Reconcile creates or updates the NetworkPolicy of an operand and keeps track of it with the given condition.
+func Reconcile(ctx context.Context, clt client.Client, apiReader client.Reader, dk *dynakube.DynaKube, conditionType string, build Builder) error {
+	log := logd.FromContext(ctx)
+
+	desired, err := build()
+	if err != nil {
+		log.Info("could not build NetworkPolicy", "conditionType", conditionType)
+		k8sconditions.SetKubeAPIError(dk.Conditions(), conditionType, err)
+
+		return err
+	}
+
+	if _, err := k8snetworkpolicy.Query(clt, apiReader).WithOwner(dk).CreateOrUpdate(ctx, desired); err != nil {
+		log.Info("could not create or update NetworkPolicy for " + desired.Name)
+		k8sconditions.SetKubeAPIError(dk.Conditions(), conditionType, err)
+
+		return err
+	}
+
+	k8sconditions.SetNetworkPolicyCreatedOrUpdated(dk.Conditions(), conditionType, desired.Name)
+
+	return nil
+}
+
+// Remove deletes the NetworkPolicy of an operand, if it was created before, i.e. the condition is present.
+func Remove(ctx context.Context, clt client.Client, apiReader client.Reader, dk *dynakube.DynaKube, conditionType, name string) error {
+	if meta.FindStatusCondition(*dk.Conditions(), conditionType) == nil {
+		return nil
+	}
+
+	err := k8snetworkpolicy.Query(clt, apiReader).WithOwner(dk).Delete(ctx, &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: dk.Namespace}})
+	if err != nil {
+		k8sconditions.SetKubeAPIError(dk.Conditions(), conditionType, err)
+
+		return err
+	}
+
+	_ = meta.RemoveStatusCondition(dk.Conditions(), conditionType)
+
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package networkpolicy
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8sconditions"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8snetworkpolicy"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const testConditionType = "TestNetworkPolicy"
+
+func TestReconcile(t *testing.T) {
+	dk := &dynakube.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: testNamespace},
+		Spec:       dynakube.DynaKubeSpec{APIURL: testAPIURL, NetworkPolicies: &dynakube.NetworkPoliciesSpec{}},
+	}
+	key := client.ObjectKey{Name: "test-policy", Namespace: testNamespace}
+
+	t.Run("created, updated and removed", func(t *testing.T) {
+		clt := fake.NewClient(dk)
+
+		desired, err := k8snetworkpolicy.Build(dk, key.Name, map[string]string{"app": "test"}, k8snetworkpolicy.AddEgressRules(DNSEgress()))
+		require.NoError(t, err)
+		require.NoError(t, Reconcile(t.Context(), clt, clt, dk, testConditionType, func() (*networkingv1.NetworkPolicy, error) { return desired, nil }))
+
+		var policy networkingv1.NetworkPolicy
+		require.NoError(t, clt.Get(t.Context(), key, &policy))
+		assert.Len(t, policy.Spec.Egress, 1)
+
+		condition := meta.FindStatusCondition(*dk.Conditions(), testConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+
+		desired, err = k8snetworkpolicy.Build(dk, key.Name, map[string]string{"app": "test"}, k8snetworkpolicy.AddEgressRules(DNSEgress(), EgressToAll()))
+		require.NoError(t, err)
+		require.NoError(t, Reconcile(t.Context(), clt, clt, dk, testConditionType, func() (*networkingv1.NetworkPolicy, error) { return desired, nil }))
+		require.NoError(t, clt.Get(t.Context(), key, &policy))
+		assert.Len(t, policy.Spec.Egress, 2)
+
+		require.NoError(t, Remove(t.Context(), clt, clt, dk, testConditionType, key.Name))
+		assert.True(t, k8serrors.IsNotFound(clt.Get(t.Context(), key, &networkingv1.NetworkPolicy{})))
+		assert.Nil(t, meta.FindStatusCondition(*dk.Conditions(), testConditionType))
+	})
+
+	t.Run("nothing removed without condition", func(t *testing.T) {
+		existing := &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}}
+		clt := fake.NewClient(existing)
+
+		require.NoError(t, Remove(t.Context(), clt, clt, dk, testConditionType, key.Name))
+		require.NoError(t, clt.Get(t.Context(), key, &networkingv1.NetworkPolicy{}))
+	})
+
+	t.Run("build error is reported", func(t *testing.T) {
+		clt := fake.NewClient(dk)
+
+		err := Reconcile(t.Context(), clt, clt, dk, testConditionType, func() (*networkingv1.NetworkPolicy, error) {
+			return nil, errors.New("build failed")
+		})
+		require.Error(t, err)
+
+		condition := meta.FindStatusCondition(*dk.Conditions(), testConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, k8sconditions.KubeAPIErrorReason, condition.Reason)
+	})
+}
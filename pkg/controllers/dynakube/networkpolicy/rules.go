@@ -0,0 +1,191 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package networkpolicy
+
+import (
+	"cmp"
+	"context"
+	"net"
+	"slices"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/connectioninfo"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8senv"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	dnsPort = 53
+	// openShiftDNSPort is the port the DNS pods listen on in OpenShift, the DNS service maps port 53 to it.
+	openShiftDNSPort = 5353
+
+	kubernetesAPIPort = 443
+	// kubernetesAPIServerPort is the port of the API server itself, which is the destination after the kubernetes service was resolved.
+	kubernetesAPIServerPort = 6443
+)
+
+// TCPPort returns a NetworkPolicyPort for the given TCP port.
+func TCPPort(port int32) networkingv1.NetworkPolicyPort {
+	return Port(corev1.ProtocolTCP, port)
+}
+
+// Port returns a NetworkPolicyPort for the given protocol and port.
+func Port(protocol corev1.Protocol, port int32) networkingv1.NetworkPolicyPort {
+	return networkingv1.NetworkPolicyPort{
+		Protocol: new(protocol),
+		Port:     new(intstr.FromInt32(port)),
+	}
+}
+
+// IngressFromAny allows the ingress from any source to the given ports, e.g. for Services that are used by pods in other namespaces.
+func IngressFromAny(ports ...networkingv1.NetworkPolicyPort) networkingv1.NetworkPolicyIngressRule {
+	return networkingv1.NetworkPolicyIngressRule{Ports: ports}
+}
+
+// IngressFromPods allows the ingress from the pods with the given labels in the same namespace.
+func IngressFromPods(podLabels map[string]string, ports ...networkingv1.NetworkPolicyPort) networkingv1.NetworkPolicyIngressRule {
+	return networkingv1.NetworkPolicyIngressRule{
+		From:  []networkingv1.NetworkPolicyPeer{podPeer(podLabels)},
+		Ports: ports,
+	}
+}
+
+// IngressFromOperator allows the ingress from the operator pods, which may run in another namespace than the DynaKube.
+func IngressFromOperator(ports ...networkingv1.NetworkPolicyPort) networkingv1.NetworkPolicyIngressRule {
+	peer := podPeer(OperatorPods())
+	peer.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{corev1.LabelMetadataName: k8senv.DefaultNamespace()}}
+
+	return networkingv1.NetworkPolicyIngressRule{
+		From:  []networkingv1.NetworkPolicyPeer{peer},
+		Ports: ports,
+	}
+}
+
+// EgressToPods allows the egress to the pods with the given labels in the same namespace.
+func EgressToPods(podLabels map[string]string, ports ...networkingv1.NetworkPolicyPort) networkingv1.NetworkPolicyEgressRule {
+	return networkingv1.NetworkPolicyEgressRule{
+		To:    []networkingv1.NetworkPolicyPeer{podPeer(podLabels)},
+		Ports: ports,
+	}
+}
+
+// EgressToAll allows all egress traffic, e.g. for operands that connect to user-defined destinations.
+func EgressToAll() networkingv1.NetworkPolicyEgressRule {
+	return networkingv1.NetworkPolicyEgressRule{}
+}
+
+// DNSEgress allows the egress to the cluster DNS, the DNS pods can not be selected reliably across distributions.
+func DNSEgress() networkingv1.NetworkPolicyEgressRule {
+	return networkingv1.NetworkPolicyEgressRule{
+		Ports: []networkingv1.NetworkPolicyPort{
+			Port(corev1.ProtocolUDP, dnsPort),
+			Port(corev1.ProtocolTCP, dnsPort),
+			Port(corev1.ProtocolUDP, openShiftDNSPort),
+			Port(corev1.ProtocolTCP, openShiftDNSPort),
+		},
+	}
+}
+
+// KubernetesAPIEgress allows the egress to the configured CIDRs of the Kubernetes API, the validation webhook ensures they are set.
+func KubernetesAPIEgress(dk *dynakube.DynaKube) networkingv1.NetworkPolicyEgressRule {
+	rule := networkingv1.NetworkPolicyEgressRule{
+		Ports: []networkingv1.NetworkPolicyPort{
+			TCPPort(kubernetesAPIPort),
+			TCPPort(kubernetesAPIServerPort),
+		},
+	}
+
+	for _, cidr := range dk.GetKubernetesAPICIDRs() {
+		rule.To = append(rule.To, networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: cidr}})
+	}
+
+	return rule
+}
+
+// CommunicationHostsEgress allows the egress to the given communication hosts.
+// NetworkPolicies can't select hostnames, so for them only the port is restricted, hosts given as IP are restricted to the IP and port.
+func CommunicationHostsEgress(hosts []connectioninfo.CommunicationHost) []networkingv1.NetworkPolicyEgressRule {
+	var (
+		rules         []networkingv1.NetworkPolicyEgressRule
+		hostnamePorts []int32
+	)
+
+	for _, host := range hosts {
+		port := int32(host.Port) //nolint:gosec
+
+		ip := net.ParseIP(host.Host)
+		if ip == nil {
+			if !slices.Contains(hostnamePorts, port) {
+				hostnamePorts = append(hostnamePorts, port)
+			}
+
+			continue
+		}
+
+		rules = append(rules, networkingv1.NetworkPolicyEgressRule{
+			To:    []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: toCIDR(ip)}}},
+			Ports: []networkingv1.NetworkPolicyPort{TCPPort(port)},
+		})
+	}
+
+	if len(hostnamePorts) > 0 {
+		slices.SortFunc(hostnamePorts, cmp.Compare)
+
+		rule := networkingv1.NetworkPolicyEgressRule{}
+		for _, port := range hostnamePorts {
+			rule.Ports = append(rule.Ports, TCPPort(port))
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+// TenantHosts returns the communication hosts of the Dynatrace API and, if configured, of the proxy, which every operand talking to the tenant needs.
+func TenantHosts(ctx context.Context, kubeReader client.Reader, dk *dynakube.DynaKube) ([]connectioninfo.CommunicationHost, error) {
+	apiHost, err := connectioninfo.NewCommunicationHost(dk.APIURL())
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to parse the API URL")
+	}
+
+	hosts := []connectioninfo.CommunicationHost{apiHost}
+
+	if !dk.HasProxy() {
+		return hosts, nil
+	}
+
+	proxy, err := dk.Proxy(ctx, kubeReader)
+	if err != nil {
+		return nil, err
+	}
+
+	if proxy == "" {
+		return hosts, nil
+	}
+
+	proxyHost, err := connectioninfo.NewCommunicationHost(proxy)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to parse the proxy URL")
+	}
+
+	return append(hosts, proxyHost), nil
+}
+
+func podPeer(podLabels map[string]string) networkingv1.NetworkPolicyPeer {
+	return networkingv1.NetworkPolicyPeer{PodSelector: &metav1.LabelSelector{MatchLabels: podLabels}}
+}
+
+func toCIDR(ip net.IP) string {
+	if ip.To4() != nil {
+		return ip.String() + "/32"
+	}
+
+	return ip.String() + "/128"
+}
@@ -0,0 +1,49 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package networkpolicy
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
+	"github.com/Dynatrace/dynatrace-operator/pkg/version"
+)
+
+// The pod selectors of the operands are based on their match labels, which don't change between versions.
+
+// ActiveGatePods selects the pods of the main ActiveGate and of all ActiveGate groups.
+func ActiveGatePods(dk *dynakube.DynaKube) map[string]string {
+	return k8slabel.NewAppLabels(k8slabel.ActiveGateComponentLabel, dk.Name, "", "").BuildMatchLabels()
+}
+
+func KubeMonPods(dk *dynakube.DynaKube) map[string]string {
+	return k8slabel.New(k8slabel.KubeMonComponentLabel, dk.Name, "").AsSelector()
+}
+
+func KSPMPods(dk *dynakube.DynaKube) map[string]string {
+	return k8slabel.NewAppLabels(k8slabel.KSPMComponentLabel, dk.Name, "", "").BuildMatchLabels()
+}
+
+func LogMonitoringPods(dk *dynakube.DynaKube) map[string]string {
+	return k8slabel.NewAppLabels(k8slabel.LogMonitoringComponentLabel, dk.Name, "", "").BuildMatchLabels()
+}
+
+func ExtensionControllerPods(dk *dynakube.DynaKube) map[string]string {
+	return k8slabel.NewAppLabels(k8slabel.ExtensionComponentLabel, dk.Name, "", "").BuildMatchLabels()
+}
+
+func OTelCollectorPods(dk *dynakube.DynaKube) map[string]string {
+	return k8slabel.NewAppLabels(k8slabel.OTelColComponentLabel, dk.Name, "", "").BuildMatchLabels()
+}
+
+func SQLExecutorPods(dk *dynakube.DynaKube) map[string]string {
+	return k8slabel.NewAppLabels(k8slabel.DatabaseSQLExecutorLabel, dk.Name, "", "").BuildMatchLabels()
+}
+
+// OperatorPods selects the operator pods deployed by the Helm chart or the manifests.
+func OperatorPods() map[string]string {
+	return map[string]string{
+		k8slabel.AppNameLabel:      version.AppName,
+		k8slabel.AppComponentLabel: k8slabel.OperatorComponentLabel,
+	}
+}
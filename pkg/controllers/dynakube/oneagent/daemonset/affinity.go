@@ -17,5 +17,23 @@ func (b *builder) affinity() *corev1.Affinity {
 		affinity = k8saffinity.NewMultiArchNodeAffinity()
 	}
 
+	k8saffinity.ExcludeNodeSelectors(&affinity, b.excludedNodeSelectors()...)
+
 	return &affinity
 }
+
+// excludedNodeSelectors returns the node selectors of the node pools that take precedence, so the DaemonSets don't overlap.
+// The main DaemonSet excludes all node pools, a node pool only the ones listed before it.
+func (b *builder) excludedNodeSelectors() []map[string]string {
+	var excluded []map[string]string
+
+	for _, nodePool := range b.dk.OneAgent().GetNodePools() {
+		if b.nodePool != nil && nodePool.Name == b.nodePool.Name {
+			break
+		}
+
+		excluded = append(excluded, nodePool.NodeSelector)
+	}
+
+	return excluded
+}
@@ -14,6 +14,7 @@ import (
 )
 
 const argumentPrefix = "--"
+const nodePoolArgumentPriority = 3
 const customArgumentPriority = 2
 const defaultArgumentPriority = 1
 
@@ -66,6 +67,10 @@ func (b *builder) appendHostInjectArgs(argMap *prioritymap.Map) {
 	if b.hostInjectSpec != nil {
 		prioritymap.Append(argMap, b.hostInjectSpec.Args, prioritymap.WithPriority(customArgumentPriority))
 	}
+
+	if b.nodePool != nil {
+		prioritymap.Append(argMap, b.nodePool.Args, prioritymap.WithPriority(nodePoolArgumentPriority))
+	}
 }
 
 func appendOperatorVersionArg(argMap *prioritymap.Map) {
@@ -73,15 +78,28 @@ func appendOperatorVersionArg(argMap *prioritymap.Map) {
 }
 
 func (b *builder) appendNetworkZoneArg(argMap *prioritymap.Map) {
-	if b.dk != nil && b.dk.Spec.NetworkZone != "" {
-		argMap.Append(argumentPrefix+"set-network-zone", b.dk.Spec.NetworkZone)
+	if b.dk != nil && b.networkZone() != "" {
+		argMap.Append(argumentPrefix+"set-network-zone", b.networkZone())
+	}
+}
+
+// networkZone returns the network zone of the node pool, which defaults to spec.networkZone.
+func (b *builder) networkZone() string {
+	if b.nodePool != nil && b.nodePool.NetworkZone != "" {
+		return b.nodePool.NetworkZone
 	}
+
+	return b.dk.Spec.NetworkZone
 }
 
 func (b *builder) appendHostGroupArg(argMap *prioritymap.Map) {
 	if b.dk != nil && b.dk.Spec.OneAgent.HostGroup != "" {
 		argMap.Append(argumentPrefix+"set-host-group", b.dk.Spec.OneAgent.HostGroup, prioritymap.WithPriority(prioritymap.HighPriority))
 	}
+
+	if b.nodePool != nil && b.nodePool.HostGroup != "" {
+		argMap.Append(argumentPrefix+"set-host-group", b.nodePool.HostGroup, prioritymap.WithPriority(prioritymap.HighPriority+1))
+	}
 }
 
 func (b *builder) appendNoProxyArg(argMap *prioritymap.Map) {
@@ -6,6 +6,7 @@ package daemonset
 import (
 	"context"
 	"path/filepath"
+	"slices"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
@@ -16,6 +17,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/dtversion"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8spodtemplate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8ssecuritycontext"
 	maputils "github.com/Dynatrace/dynatrace-operator/pkg/util/map"
 	webhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/mutator"
@@ -85,6 +87,7 @@ type classicFullStack struct {
 type builder struct {
 	dk                     *dynakube.DynaKube
 	hostInjectSpec         *oneagent.HostInjectSpec
+	nodePool               *oneagent.NodePoolSpec
 	clusterID              string
 	deploymentType         string
 	processGroupConfigHash string
@@ -92,6 +95,7 @@ type builder struct {
 
 type Builder interface {
 	BuildDaemonSet(ctx context.Context) (*appsv1.DaemonSet, error)
+	BuildNodePoolDaemonSet(ctx context.Context, nodePool oneagent.NodePoolSpec) (*appsv1.DaemonSet, error)
 }
 
 func NewHostMonitoring(dk *dynakube.DynaKube, clusterID, processGroupConfigHash string) Builder {
@@ -135,7 +139,7 @@ func (hm *hostMonitoring) BuildDaemonSet(ctx context.Context) (*appsv1.DaemonSet
 		return nil, err
 	}
 
-	daemonSet.Name = hm.dk.OneAgent().GetDaemonsetName()
+	daemonSet.Name = hm.daemonSetName()
 
 	if len(daemonSet.Spec.Template.Spec.Containers) > 0 {
 		hm.appendInfraMonEnvVars(daemonSet)
@@ -144,17 +148,45 @@ func (hm *hostMonitoring) BuildDaemonSet(ctx context.Context) (*appsv1.DaemonSet
 	return daemonSet, nil
 }
 
+func (hm *hostMonitoring) BuildNodePoolDaemonSet(ctx context.Context, nodePool oneagent.NodePoolSpec) (*appsv1.DaemonSet, error) {
+	nodePoolBuilder := &hostMonitoring{hm.withNodePool(nodePool)}
+
+	return nodePoolBuilder.BuildDaemonSet(ctx)
+}
+
 func (classic *classicFullStack) BuildDaemonSet(ctx context.Context) (*appsv1.DaemonSet, error) {
 	result, err := classic.builder.BuildDaemonSet(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	result.Name = classic.dk.OneAgent().GetDaemonsetName()
+	result.Name = classic.daemonSetName()
 
 	return result, nil
 }
 
+func (classic *classicFullStack) BuildNodePoolDaemonSet(ctx context.Context, nodePool oneagent.NodePoolSpec) (*appsv1.DaemonSet, error) {
+	nodePoolBuilder := &classicFullStack{classic.withNodePool(nodePool)}
+
+	return nodePoolBuilder.BuildDaemonSet(ctx)
+}
+
+// withNodePool returns a copy of the builder that renders the DaemonSet of the given node pool.
+func (b *builder) withNodePool(nodePool oneagent.NodePoolSpec) builder {
+	nodePoolBuilder := *b
+	nodePoolBuilder.nodePool = &nodePool
+
+	return nodePoolBuilder
+}
+
+func (b *builder) daemonSetName() string {
+	if b.nodePool != nil {
+		return b.dk.OneAgent().GetNodePoolDaemonSetName(b.nodePool.Name)
+	}
+
+	return b.dk.OneAgent().GetDaemonsetName()
+}
+
 func (b *builder) BuildDaemonSet(ctx context.Context) (*appsv1.DaemonSet, error) {
 	ctx, _ = logd.NewFromContext(ctx, "daemonset")
 	dk := b.dk
@@ -173,6 +205,12 @@ func (b *builder) BuildDaemonSet(ctx context.Context) (*appsv1.DaemonSet, error)
 		b.hostInjectSpec.Labels,
 	)
 
+	matchLabels := appLabels.BuildMatchLabels()
+	if b.nodePool != nil {
+		labels[k8slabel.NodePoolLabel] = b.nodePool.Name
+		matchLabels[k8slabel.NodePoolLabel] = b.nodePool.Name
+	}
+
 	templateAnnotations := map[string]string{
 		appArmorAnnotation:                appArmorUnconfined,
 		webhook.AnnotationDynatraceInject: "false",
@@ -197,7 +235,7 @@ func (b *builder) BuildDaemonSet(ctx context.Context) (*appsv1.DaemonSet, error)
 		},
 		Spec: appsv1.DaemonSetSpec{
 			Selector: &metav1.LabelSelector{
-				MatchLabels: appLabels.BuildMatchLabels(),
+				MatchLabels: matchLabels,
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
@@ -210,6 +248,10 @@ func (b *builder) BuildDaemonSet(ctx context.Context) (*appsv1.DaemonSet, error)
 		},
 	}
 
+	if err := k8spodtemplate.Apply(&result.Spec.Template, b.hostInjectSpec.PodTemplatePatch); err != nil {
+		return nil, err
+	}
+
 	return result, nil
 }
 
@@ -279,14 +321,23 @@ func (b *builder) immutableOneAgentImage() string {
 }
 
 func (b *builder) tolerations() []corev1.Toleration {
+	var tolerations []corev1.Toleration
 	if b.hostInjectSpec != nil {
-		return b.hostInjectSpec.Tolerations
+		tolerations = b.hostInjectSpec.Tolerations
+	}
+
+	if b.nodePool != nil && len(b.nodePool.Tolerations) > 0 {
+		tolerations = append(slices.Clone(tolerations), b.nodePool.Tolerations...)
 	}
 
-	return nil
+	return tolerations
 }
 
 func (b *builder) priorityClassName() string {
+	if b.nodePool != nil && b.nodePool.PriorityClassName != "" {
+		return b.nodePool.PriorityClassName
+	}
+
 	if b.hostInjectSpec != nil {
 		return b.hostInjectSpec.PriorityClassName
 	}
@@ -299,6 +350,10 @@ func (b *builder) nodeSelector() map[string]string {
 		return make(map[string]string, 0)
 	}
 
+	if b.nodePool != nil {
+		return maputils.MergeMap(b.hostInjectSpec.NodeSelector, b.nodePool.NodeSelector)
+	}
+
 	return b.hostInjectSpec.NodeSelector
 }
 
@@ -333,6 +388,10 @@ func (b *builder) updateStrategy() appsv1.DaemonSetUpdateStrategy {
 }
 
 func (b *builder) oneAgentResource() corev1.ResourceRequirements {
+	if b.nodePool != nil && b.nodePool.OneAgentResources != nil {
+		return *b.nodePool.OneAgentResources.DeepCopy()
+	}
+
 	if b.hostInjectSpec == nil {
 		return corev1.ResourceRequirements{}
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/exp"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/podtemplate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/deploymentmetadata"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
@@ -22,6 +23,7 @@ import (
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -167,6 +169,47 @@ func TestCustomPullSecret(t *testing.T) {
 	assert.Contains(t, podSpecs.ImagePullSecrets, corev1.LocalObjectReference{Name: testName})
 }
 
+func TestPodTemplatePatch(t *testing.T) {
+	t.Cleanup(k8sversion.DisableCacheForTest(123))
+
+	newDynakube := func(patch string) *dynakube.DynaKube {
+		return &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: testDynakubeName,
+			},
+			Spec: dynakube.DynaKubeSpec{
+				APIURL: testURL,
+				OneAgent: oneagent.Spec{
+					ClassicFullStack: &oneagent.HostInjectSpec{
+						PodTemplatePatch: &podtemplate.Patch{Patch: apiextensionsv1.JSON{Raw: []byte(patch)}},
+						NodePools:        []oneagent.NodePoolSpec{{Name: "gpu"}},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("patch is applied to all DaemonSets", func(t *testing.T) {
+		dsBuilder := NewClassicFullStack(newDynakube(`{"spec": {"hostAliases": [{"ip": "10.0.0.1", "hostnames": ["tenant.local"]}]}}`), testClusterID)
+
+		ds, err := dsBuilder.BuildDaemonSet(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, []corev1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"tenant.local"}}}, ds.Spec.Template.Spec.HostAliases)
+		assert.Equal(t, serviceAccountName, ds.Spec.Template.Spec.ServiceAccountName)
+
+		nodePoolDS, err := dsBuilder.BuildNodePoolDaemonSet(t.Context(), oneagent.NodePoolSpec{Name: "gpu"})
+		require.NoError(t, err)
+		assert.Len(t, nodePoolDS.Spec.Template.Spec.HostAliases, 1)
+	})
+
+	t.Run("patch of an operator-owned field fails", func(t *testing.T) {
+		dsBuilder := NewClassicFullStack(newDynakube(`{"spec": {"serviceAccountName": "default"}}`), testClusterID)
+
+		_, err := dsBuilder.BuildDaemonSet(t.Context())
+		require.Error(t, err)
+	})
+}
+
 func TestResources(t *testing.T) {
 	t.Cleanup(k8sversion.DisableCacheForTest(123))
 
@@ -1113,3 +1156,109 @@ func TestDefaultArguments(t *testing.T) {
 		assert.Equal(t, expectedDefaultArguments, ds.Spec.Template.Spec.Containers[0].Args)
 	})
 }
+
+func TestNodePools(t *testing.T) {
+	t.Cleanup(k8sversion.DisableCacheForTest(123))
+
+	gpuResources := corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+	}
+	gpuToleration := corev1.Toleration{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists}
+	newDynakube := func() *dynakube.DynaKube {
+		return &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{Name: "dynakube", Namespace: "dynatrace"},
+			Spec: dynakube.DynaKubeSpec{
+				OneAgent: oneagent.Spec{
+					HostGroup: "default-group",
+					CloudNativeFullStack: &oneagent.CloudNativeFullStackSpec{
+						HostInjectSpec: oneagent.HostInjectSpec{
+							NodeSelector:      map[string]string{"kubernetes.io/os": "linux"},
+							PriorityClassName: "default-priority",
+							Tolerations:       []corev1.Toleration{{Key: "default", Operator: corev1.TolerationOpExists}},
+							Env:               []corev1.EnvVar{{Name: "CUSTOM", Value: "default"}},
+							NodePools: []oneagent.NodePoolSpec{
+								{
+									Name:              "gpu",
+									NodeSelector:      map[string]string{"pool": "gpu"},
+									OneAgentResources: &gpuResources,
+									Args:              []string{"--set-host-property=pool=gpu"},
+									Env:               []corev1.EnvVar{{Name: "CUSTOM", Value: "gpu"}},
+									HostGroup:         "gpu-group",
+									Tolerations:       []corev1.Toleration{gpuToleration},
+									PriorityClassName: "gpu-priority",
+								},
+								{
+									Name:         "arm",
+									NodeSelector: map[string]string{"pool": "arm"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	notIn := func(value string) corev1.NodeSelectorRequirement {
+		return corev1.NodeSelectorRequirement{Key: "pool", Operator: corev1.NodeSelectorOpNotIn, Values: []string{value}}
+	}
+
+	t.Run("node pool overrides", func(t *testing.T) {
+		dk := newDynakube()
+
+		ds, err := NewCloudNativeFullStack(dk, testClusterID, testProcessGroupConfigHash).BuildNodePoolDaemonSet(t.Context(), dk.OneAgent().GetNodePools()[0])
+		require.NoError(t, err)
+
+		assert.Equal(t, "dynakube-oneagent-gpu", ds.Name)
+		assert.Equal(t, "gpu", ds.Labels[k8slabel.NodePoolLabel])
+		assert.Equal(t, "gpu", ds.Spec.Selector.MatchLabels[k8slabel.NodePoolLabel])
+		assert.Equal(t, "gpu", ds.Spec.Template.Labels[k8slabel.NodePoolLabel])
+
+		podSpec := ds.Spec.Template.Spec
+		assert.Equal(t, map[string]string{"kubernetes.io/os": "linux", "pool": "gpu"}, podSpec.NodeSelector)
+		assert.Equal(t, "gpu-priority", podSpec.PriorityClassName)
+		assert.Len(t, podSpec.Tolerations, 2)
+		assert.Contains(t, podSpec.Tolerations, gpuToleration)
+		assert.Equal(t, gpuResources.Limits, podSpec.Containers[0].Resources.Limits)
+		assert.Contains(t, podSpec.Containers[0].Args, "--set-host-group=gpu-group")
+		assert.Contains(t, podSpec.Containers[0].Args, "--set-host-property=pool=gpu")
+		assert.Contains(t, podSpec.Containers[0].Env, corev1.EnvVar{Name: "CUSTOM", Value: "gpu"})
+
+		// the first node pool takes precedence, so nothing is excluded
+		for _, term := range podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+			assert.NotContains(t, term.MatchExpressions, notIn("arm"))
+		}
+	})
+
+	t.Run("later node pools exclude earlier ones", func(t *testing.T) {
+		dk := newDynakube()
+
+		ds, err := NewCloudNativeFullStack(dk, testClusterID, testProcessGroupConfigHash).BuildNodePoolDaemonSet(t.Context(), dk.OneAgent().GetNodePools()[1])
+		require.NoError(t, err)
+
+		podSpec := ds.Spec.Template.Spec
+		assert.Equal(t, "dynakube-oneagent-arm", ds.Name)
+		assert.Equal(t, "default-priority", podSpec.PriorityClassName)
+		assert.Contains(t, podSpec.Containers[0].Args, "--set-host-group=default-group")
+		assert.Contains(t, podSpec.Containers[0].Env, corev1.EnvVar{Name: "CUSTOM", Value: "default"})
+
+		terms := podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+		require.Len(t, terms, 1)
+		assert.Contains(t, terms[0].MatchExpressions, notIn("gpu"))
+	})
+
+	t.Run("main daemonset excludes all node pools", func(t *testing.T) {
+		dk := newDynakube()
+
+		ds, err := NewCloudNativeFullStack(dk, testClusterID, testProcessGroupConfigHash).BuildDaemonSet(t.Context())
+		require.NoError(t, err)
+
+		assert.Equal(t, dk.OneAgent().GetDaemonsetName(), ds.Name)
+		assert.NotContains(t, ds.Spec.Selector.MatchLabels, k8slabel.NodePoolLabel)
+		assert.Equal(t, "default-priority", ds.Spec.Template.Spec.PriorityClassName)
+
+		terms := ds.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+		require.Len(t, terms, 1)
+		assert.Contains(t, terms[0].MatchExpressions, notIn("gpu"))
+		assert.Contains(t, terms[0].MatchExpressions, notIn("arm"))
+	})
+}
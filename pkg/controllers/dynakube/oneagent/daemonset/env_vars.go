@@ -29,6 +29,7 @@ const (
 	ProxyAsEnvVarDeprecatedVersion = "1.273.0.0-0"
 )
 
+const nodePoolEnvPriority = prioritymap.HighPriority + 1
 const customEnvPriority = prioritymap.HighPriority
 const defaultEnvPriority = prioritymap.DefaultPriority
 
@@ -39,6 +40,10 @@ func (b *builder) environmentVariables() ([]corev1.EnvVar, error) {
 		prioritymap.Append(envMap, b.hostInjectSpec.Env, prioritymap.WithPriority(customEnvPriority))
 	}
 
+	if b.nodePool != nil {
+		prioritymap.Append(envMap, b.nodePool.Env, prioritymap.WithPriority(nodePoolEnvPriority))
+	}
+
 	addNodeNameEnv(envMap)
 	b.addClusterIDEnv(envMap)
 	b.addDeploymentMetadataEnv(envMap)
@@ -100,11 +105,20 @@ func (b *builder) addConnectionInfoEnvs(envVarMap *prioritymap.Map) {
 		LocalObjectReference: corev1.LocalObjectReference{
 			Name: b.dk.OneAgent().GetConnectionInfoConfigMapName(),
 		},
-		Key:      connectioninfo.CommunicationEndpointsKey,
+		Key:      b.communicationEndpointsKey(),
 		Optional: new(false),
 	}})
 }
 
+// communicationEndpointsKey returns the key of the endpoints of the node pool's network zone, if it differs from spec.networkZone.
+func (b *builder) communicationEndpointsKey() string {
+	if networkZone := b.networkZone(); networkZone != b.dk.Spec.NetworkZone {
+		return connectioninfo.GetNetworkZoneEndpointsKey(networkZone)
+	}
+
+	return connectioninfo.CommunicationEndpointsKey
+}
+
 // deprecated
 func (b *builder) addProxyEnv(envVarMap *prioritymap.Map) {
 	if !b.hasProxy() {
@@ -174,6 +174,27 @@ func TestAddConnectionInfoEnvs(t *testing.T) {
 
 		assertConnectionInfoEnv(t, envVars.AsEnvVars(), dk)
 	})
+
+	t.Run("uses the endpoints of the network zone of the node pool", func(t *testing.T) {
+		dk := &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Spec: dynakube.DynaKubeSpec{
+				NetworkZone: "default",
+			},
+		}
+		dsBuilder := builder{
+			dk:       dk,
+			nodePool: &oneagent.NodePoolSpec{Name: "zone-a", NetworkZone: "network-zone-a"},
+		}
+		envVars := prioritymap.New()
+		dsBuilder.addConnectionInfoEnvs(envVars)
+
+		env := k8senv.Find(envVars.AsEnvVars(), connectioninfo.EnvDTServer)
+		require.NotNil(t, env)
+		assert.Equal(t, connectioninfo.GetNetworkZoneEndpointsKey("network-zone-a"), env.ValueFrom.ConfigMapKeyRef.Key)
+	})
 }
 
 func assertConnectionInfoEnv(t *testing.T, envs []corev1.EnvVar, dk *dynakube.DynaKube) {
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"slices"
 	"strconv"
 	"time"
 
@@ -139,6 +140,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, dk *dynakube.DynaKube, dtCli
 		return err
 	}
 
+	err = r.updateNodePoolStatuses(ctx, dk)
+	if err != nil {
+		return err
+	}
+
 	err = r.updateInstancesStatus(ctx, dk)
 	if err != nil {
 		return err
@@ -168,6 +174,12 @@ func (r *Reconciler) cleanUp(ctx context.Context, dk *dynakube.DynaKube) error {
 	// only cleanup things that are directly set in THIS reconciler
 	dk.Status.OneAgent.Instances = nil
 	dk.Status.OneAgent.LastInstanceStatusUpdate = nil
+	dk.Status.OneAgent.NodePools = nil
+
+	err = r.removeOutdatedNodePoolDaemonSets(ctx, dk)
+	if err != nil {
+		log.Error(err, "failed to cleanup oneagent node pool daemonsets") // error shouldn't block another cleanup
+	}
 
 	return r.removeOneAgentDaemonSet(ctx, dk)
 }
@@ -246,13 +258,17 @@ func extractPublicData(dk *dynakube.DynaKube) map[string]string {
 		data[connectioninfo.CommunicationEndpointsKey] = dk.Status.OneAgent.ConnectionInfo.Endpoints
 	}
 
+	for networkZone, endpoints := range dk.Status.OneAgent.NetworkZoneEndpoints {
+		data[connectioninfo.GetNetworkZoneEndpointsKey(networkZone)] = endpoints
+	}
+
 	return data
 }
 
 func (r *Reconciler) reconcileRollout(ctx context.Context, dk *dynakube.DynaKube) error {
 	log := logd.FromContext(ctx)
-	// Define a new DaemonSet object
-	dsDesired, err := r.buildDesiredDaemonSet(ctx, dk)
+	// Define the new DaemonSet objects, one for the OneAgent and one for each node pool
+	daemonSets, err := r.buildDesiredDaemonSets(ctx, dk)
 	if err != nil {
 		log.Info("failed to get desired daemonset")
 		setDaemonSetGenerationFailedCondition(dk.Conditions())
@@ -260,21 +276,28 @@ func (r *Reconciler) reconcileRollout(ctx context.Context, dk *dynakube.DynaKube
 		return err
 	}
 
-	// Set OneAgent instance as the owner and controller
-	if err := controllerutil.SetControllerReference(dk, dsDesired, scheme.Scheme); err != nil {
-		return err
+	updated := false
+
+	for i, dsDesired := range daemonSets {
+		// only the OneAgent DaemonSet is managed by the VerticalPodAutoscaler, node pools configure their resources explicitly
+		isAutoscaled := i == 0 && dk.OneAgent().GetVerticalPodAutoscaler().IsAutoMode()
+
+		dsUpdated, err := r.rolloutDaemonSet(ctx, dk, dsDesired, isAutoscaled)
+		if err != nil {
+			return err
+		}
+
+		updated = updated || dsUpdated
 	}
 
-	updated, err := r.daemonset.WithOwner(dk).CreateOrUpdate(ctx, dsDesired)
+	err = r.removeOutdatedNodePoolDaemonSets(ctx, dk)
 	if err != nil {
-		log.Info("failed to roll out new OneAgent DaemonSet")
 		k8sconditions.SetKubeAPIError(dk.Conditions(), oaConditionType, err)
 
 		return err
 	}
 
 	if updated {
-		log.Info("rolled out new OneAgent DaemonSet")
 		setDaemonSetCreatedCondition(dk.Conditions())
 
 		// remove old daemonset with feature in name
@@ -299,6 +322,102 @@ func (r *Reconciler) reconcileRollout(ctx context.Context, dk *dynakube.DynaKube
 	return nil
 }
 
+func (r *Reconciler) rolloutDaemonSet(ctx context.Context, dk *dynakube.DynaKube, dsDesired *appsv1.DaemonSet, isAutoscaled bool) (bool, error) {
+	log := logd.FromContext(ctx)
+
+	// Set OneAgent instance as the owner and controller
+	if err := controllerutil.SetControllerReference(dk, dsDesired, scheme.Scheme); err != nil {
+		return false, err
+	}
+
+	if isAutoscaled {
+		if err := k8sdaemonset.AddHashAnnotationIgnoringResources(dsDesired); err != nil {
+			return false, err
+		}
+	}
+
+	updated, err := r.daemonset.WithOwner(dk).CreateOrUpdate(ctx, dsDesired)
+	if err != nil {
+		log.Info("failed to roll out new OneAgent DaemonSet", "name", dsDesired.Name)
+		k8sconditions.SetKubeAPIError(dk.Conditions(), oaConditionType, err)
+
+		return false, err
+	}
+
+	if updated {
+		log.Info("rolled out new OneAgent DaemonSet", "name", dsDesired.Name)
+	}
+
+	return updated, nil
+}
+
+// removeOutdatedNodePoolDaemonSets deletes the DaemonSets of node pools that are no longer part of the DynaKube.
+func (r *Reconciler) removeOutdatedNodePoolDaemonSets(ctx context.Context, dk *dynakube.DynaKube) error {
+	log := logd.FromContext(ctx)
+	appLabels := k8slabel.NewAppLabels(k8slabel.OneAgentComponentLabel, dk.Name, "", "")
+
+	var daemonSets appsv1.DaemonSetList
+
+	err := r.client.List(ctx, &daemonSets,
+		client.InNamespace(dk.Namespace),
+		client.MatchingLabels(appLabels.BuildMatchLabels()),
+		client.HasLabels{k8slabel.NodePoolLabel},
+	)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	nodePools := dk.OneAgent().GetNodePools()
+
+	for _, ds := range daemonSets.Items {
+		if slices.ContainsFunc(nodePools, func(nodePool oneagent.NodePoolSpec) bool {
+			return nodePool.Name == ds.Labels[k8slabel.NodePoolLabel]
+		}) {
+			continue
+		}
+
+		log.Info("removing OneAgent DaemonSet of removed node pool", "name", ds.Name)
+
+		if err := r.client.Delete(ctx, &ds); client.IgnoreNotFound(err) != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) updateNodePoolStatuses(ctx context.Context, dk *dynakube.DynaKube) error {
+	nodePools := dk.OneAgent().GetNodePools()
+	if len(nodePools) == 0 {
+		dk.Status.OneAgent.NodePools = nil
+
+		return nil
+	}
+
+	statuses := make(map[string]oneagent.NodePoolStatus, len(nodePools))
+
+	for _, nodePool := range nodePools {
+		name := dk.OneAgent().GetNodePoolDaemonSetName(nodePool.Name)
+
+		ds, err := r.daemonset.Get(ctx, client.ObjectKey{Name: name, Namespace: dk.Namespace})
+		if k8serrors.IsNotFound(err) {
+			ds = &appsv1.DaemonSet{}
+		} else if err != nil {
+			return err
+		}
+
+		statuses[nodePool.Name] = oneagent.NodePoolStatus{
+			DaemonSetName:          name,
+			DesiredNumberScheduled: ds.Status.DesiredNumberScheduled,
+			NumberReady:            ds.Status.NumberReady,
+		}
+	}
+
+	dk.Status.OneAgent.NodePools = statuses
+
+	return nil
+}
+
 func (r *Reconciler) getOneagentPods(ctx context.Context, dk *dynakube.DynaKube, feature string) ([]corev1.Pod, []client.ListOption, error) {
 	agentVersion := dk.OneAgent().GetVersion()
 	appLabels := k8slabel.NewAppLabels(k8slabel.OneAgentComponentLabel, dk.Name,
@@ -313,8 +432,9 @@ func (r *Reconciler) getOneagentPods(ctx context.Context, dk *dynakube.DynaKube,
 	return podList.Items, listOps, err
 }
 
-func (r *Reconciler) buildDesiredDaemonSet(ctx context.Context, dk *dynakube.DynaKube) (*appsv1.DaemonSet, error) {
-	var ds *appsv1.DaemonSet
+// buildDesiredDaemonSets builds the OneAgent DaemonSet, followed by the DaemonSets of the node pools.
+func (r *Reconciler) buildDesiredDaemonSets(ctx context.Context, dk *dynakube.DynaKube) ([]*appsv1.DaemonSet, error) {
+	var builder daemonset.Builder
 
 	processGroupConfigHash, err := r.getProcessGroupConfigHash(ctx, dk)
 	if err != nil {
@@ -323,25 +443,39 @@ func (r *Reconciler) buildDesiredDaemonSet(ctx context.Context, dk *dynakube.Dyn
 
 	switch {
 	case dk.OneAgent().IsClassicFullStackMode():
-		ds, err = daemonset.NewClassicFullStack(dk, r.clusterID).BuildDaemonSet(ctx)
+		builder = daemonset.NewClassicFullStack(dk, r.clusterID)
 	case dk.OneAgent().IsHostMonitoringMode():
-		ds, err = daemonset.NewHostMonitoring(dk, r.clusterID, processGroupConfigHash).BuildDaemonSet(ctx)
+		builder = daemonset.NewHostMonitoring(dk, r.clusterID, processGroupConfigHash)
 	case dk.OneAgent().IsCloudNativeFullstackMode():
-		ds, err = daemonset.NewCloudNativeFullStack(dk, r.clusterID, processGroupConfigHash).BuildDaemonSet(ctx)
+		builder = daemonset.NewCloudNativeFullStack(dk, r.clusterID, processGroupConfigHash)
 	}
 
+	ds, err := builder.BuildDaemonSet(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	dsHash, err := hasher.GenerateHash(ds)
-	if err != nil {
-		return nil, err
+	daemonSets := []*appsv1.DaemonSet{ds}
+
+	for _, nodePool := range dk.OneAgent().GetNodePools() {
+		ds, err := builder.BuildNodePoolDaemonSet(ctx, nodePool)
+		if err != nil {
+			return nil, err
+		}
+
+		daemonSets = append(daemonSets, ds)
 	}
 
-	ds.Annotations[hasher.AnnotationHash] = dsHash
+	for _, ds := range daemonSets {
+		dsHash, err := hasher.GenerateHash(ds)
+		if err != nil {
+			return nil, err
+		}
+
+		ds.Annotations[hasher.AnnotationHash] = dsHash
+	}
 
-	return ds, nil
+	return daemonSets, nil
 }
 
 func (r *Reconciler) reconcileInstanceStatuses(ctx context.Context, dk *dynakube.DynaKube) error {
@@ -81,6 +81,61 @@ func TestReconcile(t *testing.T) {
 		assert.NotNil(t, dsActual.Spec.Template.Spec.Affinity)
 	})
 
+	t.Run("create DaemonSet per node pool and remove outdated ones", func(t *testing.T) {
+		dk := &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{Name: dkName, Namespace: namespace},
+			Spec: dynakube.DynaKubeSpec{
+				OneAgent: oneagent.Spec{
+					CloudNativeFullStack: &oneagent.CloudNativeFullStackSpec{
+						HostInjectSpec: oneagent.HostInjectSpec{
+							NodePools: []oneagent.NodePoolSpec{
+								{Name: "gpu", NodeSelector: map[string]string{"pool": "gpu"}},
+							},
+						},
+					},
+				},
+			},
+		}
+		dk.Status.OneAgent.ConnectionInfo.TenantUUID = "test-tenant"
+
+		outdatedLabels := k8slabel.NewAppLabels(k8slabel.OneAgentComponentLabel, dkName, "", "").BuildMatchLabels()
+		outdatedLabels[k8slabel.NodePoolLabel] = "arm"
+		outdated := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{
+			Name:      dk.OneAgent().GetNodePoolDaemonSetName("arm"),
+			Namespace: namespace,
+			Labels:    outdatedLabels,
+		}}
+		fakeClient := fake.NewClient(dk, outdated)
+
+		reconciler := &Reconciler{
+			client:                   fakeClient,
+			apiReader:                fakeClient,
+			configmap:                k8sconfigmap.Query(fakeClient, fakeClient),
+			daemonset:                k8sdaemonset.Query(fakeClient, fakeClient),
+			versionReconciler:        createVersionReconcilerMock(t),
+			connectionInfoReconciler: createConnectionInfoReconcilerMock(t),
+		}
+
+		err := reconciler.Reconcile(ctx, dk, &dynatrace.Client{}, createTokens())
+		require.NoError(t, err)
+
+		dsActual := &appsv1.DaemonSet{}
+		err = fakeClient.Get(ctx, types.NamespacedName{Name: dk.OneAgent().GetNodePoolDaemonSetName("gpu"), Namespace: namespace}, dsActual)
+		require.NoError(t, err)
+		assert.Equal(t, "gpu", dsActual.Spec.Selector.MatchLabels[k8slabel.NodePoolLabel])
+		assert.Equal(t, "gpu", dsActual.Spec.Template.Spec.NodeSelector["pool"])
+
+		err = fakeClient.Get(ctx, types.NamespacedName{Name: dk.OneAgent().GetDaemonsetName(), Namespace: namespace}, dsActual)
+		require.NoError(t, err)
+		assert.NotContains(t, dsActual.Spec.Selector.MatchLabels, k8slabel.NodePoolLabel)
+
+		err = fakeClient.Get(ctx, client.ObjectKeyFromObject(outdated), dsActual)
+		assert.True(t, k8serrors.IsNotFound(err))
+
+		require.Contains(t, dk.Status.OneAgent.NodePools, "gpu")
+		assert.Equal(t, dk.OneAgent().GetNodePoolDaemonSetName("gpu"), dk.Status.OneAgent.NodePools["gpu"].DaemonSetName)
+	})
+
 	t.Run("remove DaemonSet in case OneAgent is not needed + remove condition", func(t *testing.T) {
 		dk := &dynakube.DynaKube{ObjectMeta: metav1.ObjectMeta{Name: dkName, Namespace: namespace}}
 		setDaemonSetCreatedCondition(dk.Conditions())
@@ -351,8 +406,7 @@ func TestMigrationForDaemonSetWithoutAnnotation(t *testing.T) {
 		},
 	}
 
-	ds2, err := r.buildDesiredDaemonSet(t.Context(), dk)
-	require.NoError(t, err)
+	ds2 := buildDesiredDaemonSet(t, &r, dk)
 	assert.NotEmpty(t, ds2.Annotations[hasher.AnnotationHash])
 
 	assert.True(t, hasher.IsAnnotationDifferent(ds1, ds2))
@@ -509,11 +563,8 @@ func TestHasSpecChanged(t *testing.T) {
 				},
 			}
 			test.mod(&oldInstance, &newInstance)
-			ds1, err := r.buildDesiredDaemonSet(t.Context(), &oldInstance)
-			require.NoError(t, err)
-
-			ds2, err := r.buildDesiredDaemonSet(t.Context(), &newInstance)
-			require.NoError(t, err)
+			ds1 := buildDesiredDaemonSet(t, &r, &oldInstance)
+			ds2 := buildDesiredDaemonSet(t, &r, &newInstance)
 
 			assert.NotEmpty(t, ds1.Annotations[hasher.AnnotationHash])
 			assert.NotEmpty(t, ds2.Annotations[hasher.AnnotationHash])
@@ -527,9 +578,8 @@ func TestNewDaemonset_Affinity(t *testing.T) {
 	t.Run("adds correct affinities", func(t *testing.T) {
 		r := Reconciler{apiReader: fake.NewClient()}
 		dk := newDynaKube()
-		ds, err := r.buildDesiredDaemonSet(t.Context(), dk)
+		ds := buildDesiredDaemonSet(t, &r, dk)
 
-		require.NoError(t, err)
 		assert.NotNil(t, ds)
 
 		affinity := ds.Spec.Template.Spec.Affinity
@@ -821,3 +871,13 @@ func createTokens() token.Tokens {
 		token.APIKey: &token.Token{Value: "sdfsdf"},
 	}
 }
+
+func buildDesiredDaemonSet(t *testing.T, r *Reconciler, dk *dynakube.DynaKube) *appsv1.DaemonSet {
+	t.Helper()
+
+	daemonSets, err := r.buildDesiredDaemonSets(t.Context(), dk)
+	require.NoError(t, err)
+	require.NotEmpty(t, daemonSets)
+
+	return daemonSets[0]
+}
@@ -0,0 +1,87 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package networkpolicy
+
+import (
+	"context"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	agconsts "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/networkpolicy"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/otelc/service"
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8snetworkpolicy"
+	networkingv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const conditionType = "OTELCNetworkPolicy"
+
+type Reconciler struct {
+	client    client.Client
+	apiReader client.Reader
+}
+
+func NewReconciler(clt client.Client, apiReader client.Reader) *Reconciler {
+	return &Reconciler{
+		client:    clt,
+		apiReader: apiReader,
+	}
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, dk *dynakube.DynaKube) error {
+	ctx, _ = logd.NewFromContext(ctx, "networkpolicy")
+
+	if !dk.IsNetworkPolicyEnabled() || !(dk.Extensions().IsPrometheusEnabled() || dk.TelemetryIngest().IsEnabled()) {
+		return networkpolicy.Remove(ctx, r.client, r.apiReader, dk, conditionType, dk.OTelCollectorStatefulsetName())
+	}
+
+	return networkpolicy.Reconcile(ctx, r.client, r.apiReader, dk, conditionType, func() (*networkingv1.NetworkPolicy, error) {
+		return r.buildNetworkPolicy(ctx, dk)
+	})
+}
+
+// buildNetworkPolicy allows the ingress on the ports of the telemetry service from any source, as the telemetry is sent from all namespaces.
+// The prometheus scraper has to reach the targets in the whole cluster, so its egress is not restricted,
+// for telemetry ingest only the Dynatrace environment and the ActiveGate are allowed.
+func (r *Reconciler) buildNetworkPolicy(ctx context.Context, dk *dynakube.DynaKube) (*networkingv1.NetworkPolicy, error) {
+	appLabels := k8slabel.NewAppLabels(k8slabel.OTelColComponentLabel, dk.Name, k8slabel.OTelColComponentLabel, "")
+
+	var ingressPorts []networkingv1.NetworkPolicyPort
+
+	if dk.TelemetryIngest().IsEnabled() {
+		for _, port := range service.BuildServicePortList(ctx, dk.TelemetryIngest().GetProtocols()) {
+			ingressPorts = append(ingressPorts, networkpolicy.Port(port.Protocol, port.TargetPort.IntVal))
+		}
+	}
+
+	egressRules := []networkingv1.NetworkPolicyEgressRule{
+		networkpolicy.DNSEgress(),
+		networkpolicy.KubernetesAPIEgress(dk),
+	}
+
+	if dk.Extensions().IsPrometheusEnabled() {
+		egressRules = append(egressRules, networkpolicy.EgressToAll())
+	} else {
+		tenantHosts, err := networkpolicy.TenantHosts(ctx, r.apiReader, dk)
+		if err != nil {
+			return nil, err
+		}
+
+		egressRules = append(egressRules, networkpolicy.CommunicationHostsEgress(tenantHosts)...)
+		egressRules = append(egressRules, networkpolicy.EgressToPods(networkpolicy.ActiveGatePods(dk), networkpolicy.TCPPort(agconsts.HTTPSContainerPort)))
+	}
+
+	options := []k8snetworkpolicy.Option{
+		k8snetworkpolicy.SetLabels(appLabels.BuildLabels()),
+		k8snetworkpolicy.AddEgressRules(egressRules...),
+	}
+
+	if len(ingressPorts) > 0 {
+		options = append(options, k8snetworkpolicy.AddIngressRules(networkpolicy.IngressFromAny(ingressPorts...)))
+	}
+
+	return k8snetworkpolicy.Build(dk, dk.OTelCollectorStatefulsetName(), appLabels.BuildMatchLabels(), options...)
+}
@@ -0,0 +1,123 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package networkpolicy
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/extensions"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/telemetryingest"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/networkpolicy"
+	"github.com/Dynatrace/dynatrace-operator/pkg/otelcgen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	testDynakubeName  = "dynakube"
+	testNamespaceName = "dynatrace"
+	testAPIURL        = "https://tenant.live.dynatrace.com/api"
+)
+
+func getTestDynakube() *dynakube.DynaKube {
+	return &dynakube.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testDynakubeName,
+			Namespace: testNamespaceName,
+		},
+		Spec: dynakube.DynaKubeSpec{
+			APIURL:          testAPIURL,
+			NetworkPolicies: &dynakube.NetworkPoliciesSpec{},
+			TelemetryIngest: &telemetryingest.Spec{Protocols: []otelcgen.Protocol{otelcgen.OTLPProtocol, otelcgen.StatsdProtocol}},
+		},
+	}
+}
+
+func getNetworkPolicy(t *testing.T, clt client.Client, dk *dynakube.DynaKube) (*networkingv1.NetworkPolicy, error) {
+	t.Helper()
+
+	var policy networkingv1.NetworkPolicy
+	err := clt.Get(t.Context(), client.ObjectKey{Name: dk.OTelCollectorStatefulsetName(), Namespace: dk.Namespace}, &policy)
+
+	return &policy, err
+}
+
+func TestReconcile(t *testing.T) {
+	t.Run("telemetry ingest allows the protocol ports and the Dynatrace environment", func(t *testing.T) {
+		dk := getTestDynakube()
+		clt := fake.NewClient()
+
+		require.NoError(t, NewReconciler(clt, clt).Reconcile(t.Context(), dk))
+
+		policy, err := getNetworkPolicy(t, clt, dk)
+		require.NoError(t, err)
+		assert.Equal(t, networkpolicy.OTelCollectorPods(dk), policy.Spec.PodSelector.MatchLabels)
+
+		require.Len(t, policy.Spec.Ingress, 1)
+		assert.Empty(t, policy.Spec.Ingress[0].From)
+		assert.Equal(t, []networkingv1.NetworkPolicyPort{
+			networkpolicy.TCPPort(4317),
+			networkpolicy.TCPPort(4318),
+			networkpolicy.Port(corev1.ProtocolUDP, 8125),
+		}, policy.Spec.Ingress[0].Ports)
+
+		require.Len(t, policy.Spec.Egress, 4)
+		assert.Equal(t, []networkingv1.NetworkPolicyPort{networkpolicy.TCPPort(443)}, policy.Spec.Egress[2].Ports)
+		assert.Equal(t, networkpolicy.ActiveGatePods(dk), policy.Spec.Egress[3].To[0].PodSelector.MatchLabels)
+
+		condition := meta.FindStatusCondition(*dk.Conditions(), conditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	})
+
+	t.Run("prometheus scraping is not restricted", func(t *testing.T) {
+		dk := getTestDynakube()
+		dk.Spec.TelemetryIngest = nil
+		dk.Spec.Extensions = &extensions.Spec{Prometheus: &extensions.PrometheusSpec{}}
+		clt := fake.NewClient()
+
+		require.NoError(t, NewReconciler(clt, clt).Reconcile(t.Context(), dk))
+
+		policy, err := getNetworkPolicy(t, clt, dk)
+		require.NoError(t, err)
+		assert.Empty(t, policy.Spec.Ingress)
+		assert.Contains(t, policy.Spec.Egress, networkpolicy.EgressToAll())
+	})
+
+	t.Run("removed when disabled", func(t *testing.T) {
+		dk := getTestDynakube()
+		clt := fake.NewClient()
+		r := NewReconciler(clt, clt)
+
+		require.NoError(t, r.Reconcile(t.Context(), dk))
+
+		dk.Spec.NetworkPolicies = nil
+
+		require.NoError(t, r.Reconcile(t.Context(), dk))
+
+		_, err := getNetworkPolicy(t, clt, dk)
+		assert.True(t, k8serrors.IsNotFound(err))
+		assert.Nil(t, meta.FindStatusCondition(*dk.Conditions(), conditionType))
+	})
+
+	t.Run("invalid API URL", func(t *testing.T) {
+		dk := getTestDynakube()
+		dk.Spec.APIURL = "tenant"
+		clt := fake.NewClient()
+
+		require.Error(t, NewReconciler(clt, clt).Reconcile(t.Context(), dk))
+
+		condition := meta.FindStatusCondition(*dk.Conditions(), conditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	})
+}
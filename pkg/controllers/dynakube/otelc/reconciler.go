@@ -9,6 +9,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/otelc/configuration"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/otelc/endpoint"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/otelc/networkpolicy"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/otelc/service"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/otelc/statefulset"
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
@@ -22,6 +23,7 @@ type Reconciler struct {
 	serviceReconciler       *service.Reconciler
 	endpointReconciler      *endpoint.Reconciler
 	configurationReconciler *configuration.Reconciler
+	networkPolicyReconciler *networkpolicy.Reconciler
 }
 
 func NewReconciler(client client.Client, apiReader client.Reader) *Reconciler { //nolint
@@ -32,6 +34,7 @@ func NewReconciler(client client.Client, apiReader client.Reader) *Reconciler {
 		serviceReconciler:       service.NewReconciler(client, apiReader),
 		endpointReconciler:      endpoint.NewReconciler(client, apiReader),
 		configurationReconciler: configuration.NewReconciler(client, apiReader),
+		networkPolicyReconciler: networkpolicy.NewReconciler(client, apiReader),
 	}
 }
 
@@ -53,6 +56,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, dk *dynakube.DynaKube) error
 		return err
 	}
 
+	err = r.networkPolicyReconciler.Reconcile(ctx, dk)
+	if err != nil {
+		return err
+	}
+
 	err = r.statefulsetReconciler.Reconcile(ctx, dk)
 	if err != nil {
 		log.Info("failed to reconcile Dynatrace OTELc statefulset")
@@ -134,13 +134,14 @@ func (r *Reconciler) buildService(ctx context.Context, dk *dynakube.DynaKube) (*
 	return k8sservice.Build(dk,
 		dk.TelemetryIngest().GetServiceName(),
 		appLabels.BuildMatchLabels(),
-		buildServicePortList(ctx, dk.TelemetryIngest().GetProtocols()),
+		BuildServicePortList(ctx, dk.TelemetryIngest().GetProtocols()),
 		k8sservice.SetLabels(coreLabels.BuildLabels()),
 		k8sservice.SetType(corev1.ServiceTypeClusterIP),
 	)
 }
 
-func buildServicePortList(ctx context.Context, protocols []otelcgen.Protocol) []corev1.ServicePort {
+// BuildServicePortList returns the ports of the telemetry service for the given protocols.
+func BuildServicePortList(ctx context.Context, protocols []otelcgen.Protocol) []corev1.ServicePort {
 	if len(protocols) == 0 {
 		return nil
 	}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/extension/eec"
 	"github.com/Dynatrace/dynatrace-operator/pkg/otelcgen"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -26,7 +27,7 @@ func getContainer(dk *dynakube.DynaKube, replicas int32) corev1.Container {
 		ImagePullPolicy: dk.Spec.Templates.OpenTelemetryCollector.ImageRef.PullPolicy,
 		SecurityContext: buildSecurityContext(dk),
 		Env:             getEnvs(dk, replicas),
-		Resources:       dk.Spec.Templates.OpenTelemetryCollector.Resources,
+		Resources:       dk.GetRemediatedResources(dynakube.OTelCollectorComponent),
 		Args:            buildArgs(dk),
 		VolumeMounts:    buildContainerVolumeMounts(dk),
 	}
@@ -77,8 +78,8 @@ func buildReadinessProbe() *corev1.Probe {
 func buildArgs(dk *dynakube.DynaKube) []string {
 	args := []string{}
 
-	if ext := dk.Extensions(); ext.IsPrometheusEnabled() {
-		args = append(args, fmt.Sprintf("--config=eec://%s:%d/otcconfig/prometheusMetrics#refresh-interval=5s&auth-file=%s", ext.GetServiceNameFQDN(), consts.ExtensionsDatasourceTargetPort, otelcSecretTokenFilePath))
+	if dk.Extensions().IsPrometheusEnabled() {
+		args = append(args, fmt.Sprintf("--config=eec://%s:%d/otcconfig/prometheusMetrics#refresh-interval=5s&auth-file=%s", eec.GetDatasourceServiceFQDN(dk, eec.PrometheusDatasource), consts.ExtensionsDatasourceTargetPort, otelcSecretTokenFilePath))
 	}
 
 	if dk.TelemetryIngest().IsEnabled() {
@@ -10,6 +10,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/value"
 	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/extension/eec"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/otelc/activegate"
 	otelcConsts "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/otelc/consts"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/token"
@@ -145,8 +146,8 @@ func getDynakubeNoProxyEnvValue(dk *dynakube.DynaKube) string {
 		"kubernetes.default",
 	}
 
-	if ext := dk.Extensions(); ext.IsPrometheusEnabled() {
-		noProxyValues = append(noProxyValues, ext.GetServiceNameFQDN())
+	if dk.Extensions().IsPrometheusEnabled() {
+		noProxyValues = append(noProxyValues, eec.GetAllServiceFQDNs(dk)...)
 	}
 
 	if dk.ActiveGate().IsEnabled() {
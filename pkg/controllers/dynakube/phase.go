@@ -11,30 +11,205 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/extension/databases"
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8sstatefulset"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+type component struct {
+	isEnabled      func(dk *dynakube.DynaKube) bool
+	determinePhase func(ctx context.Context, dk *dynakube.DynaKube) status.DeploymentPhase
+	observe        func(ctx context.Context, dk *dynakube.DynaKube) (dynakube.ComponentStatus, error)
+	name           dynakube.ComponentName
+}
+
+func (controller *Controller) components() []component {
+	return []component{
+		{
+			name:           dynakube.ActiveGateComponent,
+			isEnabled:      func(dk *dynakube.DynaKube) bool { return dk.ActiveGate().IsEnabled() },
+			determinePhase: controller.determineActiveGatePhase,
+			observe: func(ctx context.Context, dk *dynakube.DynaKube) (dynakube.ComponentStatus, error) {
+				return controller.observeStatefulSet(ctx, dk, capability.CalculateStatefulSetName(dk.Name))
+			},
+		},
+		{
+			name:           dynakube.KubernetesMonitoringComponent,
+			isEnabled:      func(dk *dynakube.DynaKube) bool { return dk.KubernetesMonitoring().IsEnabled() },
+			determinePhase: controller.determineKubernetesMonitoringPhase,
+			observe: func(ctx context.Context, dk *dynakube.DynaKube) (dynakube.ComponentStatus, error) {
+				return controller.observeStatefulSet(ctx, dk, dk.KubernetesMonitoring().GetStatefulSetName())
+			},
+		},
+		{
+			name:           dynakube.ExtensionExecutionControllerComponent,
+			isEnabled:      func(dk *dynakube.DynaKube) bool { return dk.Extensions().IsAnyEnabled() },
+			determinePhase: controller.determineExtensionsExecutionControllerPhase,
+			observe: func(ctx context.Context, dk *dynakube.DynaKube) (dynakube.ComponentStatus, error) {
+				return controller.observeStatefulSet(ctx, dk, dk.Extensions().GetExecutionControllerStatefulsetName())
+			},
+		},
+		{
+			name:           dynakube.ExtensionDatabasesComponent,
+			isEnabled:      func(dk *dynakube.DynaKube) bool { return dk.Extensions().IsDatabasesEnabled() },
+			determinePhase: controller.determineExtensionsDatabasesPhase,
+			observe:        controller.observeDatabaseDeployments,
+		},
+		{
+			name: dynakube.OneAgentComponent,
+			isEnabled: func(dk *dynakube.DynaKube) bool {
+				return dk.OneAgent().IsCloudNativeFullstackMode() || dk.OneAgent().IsClassicFullStackMode() || dk.OneAgent().IsHostMonitoringMode()
+			},
+			determinePhase: controller.determineOneAgentPhase,
+			observe:        controller.observeOneAgentDaemonSets,
+		},
+		{
+			name:           dynakube.LogMonitoringComponent,
+			isEnabled:      func(dk *dynakube.DynaKube) bool { return dk.LogMonitoring().IsStandalone() },
+			determinePhase: controller.determineLogAgentPhase,
+			observe: func(ctx context.Context, dk *dynakube.DynaKube) (dynakube.ComponentStatus, error) {
+				return controller.observeDaemonSet(ctx, dk, dk.LogMonitoring().GetDaemonSetName())
+			},
+		},
+		{
+			name:           dynakube.KSPMComponent,
+			isEnabled:      func(dk *dynakube.DynaKube) bool { return dk.KSPM().IsEnabled() },
+			determinePhase: controller.determineKSPMPhase,
+			observe: func(ctx context.Context, dk *dynakube.DynaKube) (dynakube.ComponentStatus, error) {
+				return controller.observeDaemonSet(ctx, dk, dk.KSPM().GetDaemonSetName())
+			},
+		},
+		{
+			name: dynakube.OTelCollectorComponent,
+			isEnabled: func(dk *dynakube.DynaKube) bool {
+				return dk.Extensions().IsPrometheusEnabled() || dk.TelemetryIngest().IsEnabled()
+			},
+			determinePhase: controller.determineOTelCollectorPhase,
+			observe: func(ctx context.Context, dk *dynakube.DynaKube) (dynakube.ComponentStatus, error) {
+				return controller.observeStatefulSet(ctx, dk, dk.OTelCollectorStatefulsetName())
+			},
+		},
+	}
+}
+
+// determineDynaKubePhase records the state of every enabled component in status.components and derives the phase of the DynaKube from it,
+// see dynakube.DynaKube.AggregatePhase for the policy.
 func (controller *Controller) determineDynaKubePhase(ctx context.Context, dk *dynakube.DynaKube) status.DeploymentPhase {
-	components := []func(ctx context.Context, dk *dynakube.DynaKube) status.DeploymentPhase{
-		controller.determineActiveGatePhase,
-		controller.determineKubernetesMonitoringPhase,
-		controller.determineExtensionsExecutionControllerPhase,
-		controller.determineExtensionsDatabasesPhase,
-		controller.determineOneAgentPhase,
-		controller.determineLogAgentPhase,
-		controller.determineKSPMPhase,
-		controller.determineOTelCollectorPhase,
+	log := logd.FromContext(ctx)
+	now := metav1.Now()
+
+	for _, comp := range controller.components() {
+		if !comp.isEnabled(dk) {
+			dk.Status.RemoveComponentStatus(comp.name)
+
+			continue
+		}
+
+		componentStatus, err := comp.observe(ctx, dk)
+		if err != nil {
+			log.Info("could not observe component", "component", comp.name, "error", err.Error())
+
+			componentStatus.LastError = err.Error()
+		}
+
+		// an error of the component reconciler takes precedence, it is cleared by the next successful reconcile
+		if reconcileErr := controller.componentErrors[comp.name]; reconcileErr != nil {
+			componentStatus.LastError = reconcileErr.Error()
+		}
+
+		componentStatus.Phase = comp.determinePhase(ctx, dk)
+		dk.Status.SetComponentStatus(comp.name, componentStatus, now)
+	}
+
+	return dk.AggregatePhase()
+}
+
+func (controller *Controller) observeStatefulSet(ctx context.Context, dk *dynakube.DynaKube, name string) (dynakube.ComponentStatus, error) {
+	statefulSet := &appsv1.StatefulSet{}
+
+	err := controller.client.Get(ctx, types.NamespacedName{Name: name, Namespace: dk.Namespace}, statefulSet)
+	if err != nil {
+		return dynakube.ComponentStatus{}, client.IgnoreNotFound(err)
 	}
-	for _, component := range components {
-		if phase := component(ctx, dk); phase != status.Running {
-			return phase
+
+	componentStatus := newComponentStatus(statefulSet.Labels, statefulSet.Spec.Template.Spec)
+	componentStatus.DesiredReplicas = ptr.Deref(statefulSet.Spec.Replicas, 1)
+	componentStatus.ReadyReplicas = statefulSet.Status.ReadyReplicas
+
+	return componentStatus, nil
+}
+
+func (controller *Controller) observeDaemonSet(ctx context.Context, dk *dynakube.DynaKube, name string) (dynakube.ComponentStatus, error) {
+	daemonSet := &appsv1.DaemonSet{}
+
+	err := controller.client.Get(ctx, types.NamespacedName{Name: name, Namespace: dk.Namespace}, daemonSet)
+	if err != nil {
+		return dynakube.ComponentStatus{}, client.IgnoreNotFound(err)
+	}
+
+	componentStatus := newComponentStatus(daemonSet.Labels, daemonSet.Spec.Template.Spec)
+	componentStatus.DesiredReplicas = daemonSet.Status.DesiredNumberScheduled
+	componentStatus.ReadyReplicas = daemonSet.Status.NumberReady
+
+	return componentStatus, nil
+}
+
+// observeOneAgentDaemonSets sums up the OneAgent DaemonSet and the DaemonSets of the node pools.
+func (controller *Controller) observeOneAgentDaemonSets(ctx context.Context, dk *dynakube.DynaKube) (dynakube.ComponentStatus, error) {
+	componentStatus, err := controller.observeDaemonSet(ctx, dk, dk.OneAgent().GetDaemonsetName())
+	if err != nil {
+		return dynakube.ComponentStatus{}, err
+	}
+
+	for _, nodePool := range dk.OneAgent().GetNodePools() {
+		nodePoolStatus, err := controller.observeDaemonSet(ctx, dk, dk.OneAgent().GetNodePoolDaemonSetName(nodePool.Name))
+		if err != nil {
+			return dynakube.ComponentStatus{}, err
 		}
+
+		componentStatus.DesiredReplicas += nodePoolStatus.DesiredReplicas
+		componentStatus.ReadyReplicas += nodePoolStatus.ReadyReplicas
 	}
 
-	return status.Running
+	return componentStatus, nil
+}
+
+func (controller *Controller) observeDatabaseDeployments(ctx context.Context, dk *dynakube.DynaKube) (dynakube.ComponentStatus, error) {
+	deployments, err := databases.ListDeployments(ctx, controller.client, dk)
+	if err != nil {
+		return dynakube.ComponentStatus{}, err
+	}
+
+	var componentStatus dynakube.ComponentStatus
+
+	for _, deployment := range deployments {
+		if componentStatus.Image == "" {
+			componentStatus = newComponentStatus(deployment.Labels, deployment.Spec.Template.Spec)
+		}
+
+		componentStatus.DesiredReplicas += ptr.Deref(deployment.Spec.Replicas, 1)
+		componentStatus.ReadyReplicas += deployment.Status.ReadyReplicas
+	}
+
+	return componentStatus, nil
+}
+
+func newComponentStatus(labels map[string]string, podSpec corev1.PodSpec) dynakube.ComponentStatus {
+	componentStatus := dynakube.ComponentStatus{
+		Version: labels[k8slabel.AppVersionLabel],
+	}
+
+	if len(podSpec.Containers) > 0 {
+		componentStatus.Image = podSpec.Containers[0].Image
+	}
+
+	return componentStatus
 }
 
 func (controller *Controller) determineKubernetesMonitoringPhase(ctx context.Context, dk *dynakube.DynaKube) status.DeploymentPhase {
@@ -73,8 +248,42 @@ func (controller *Controller) determineActiveGatePhase(ctx context.Context, dk *
 }
 
 func (controller *Controller) determineExtensionsExecutionControllerPhase(ctx context.Context, dk *dynakube.DynaKube) status.DeploymentPhase {
-	if dk.Extensions().IsAnyEnabled() {
-		return controller.determineStatefulSetPhase(ctx, dk, dk.Extensions().GetExecutionControllerStatefulsetName())
+	log := logd.FromContext(ctx)
+
+	ext := dk.Extensions()
+	if !ext.IsAnyEnabled() {
+		return status.Running
+	}
+
+	if !ext.IsExecutionControllerSharded() {
+		return controller.determineStatefulSetPhase(ctx, dk, ext.GetExecutionControllerStatefulsetName())
+	}
+
+	// With multiple replicas the datasources fail over to the ready replicas, so data collection continues as long as one replica is ready.
+	// A partial outage is reported by the ExtensionControllerAvailability condition instead.
+	statefulSet := &appsv1.StatefulSet{}
+
+	err := controller.client.Get(ctx, types.NamespacedName{Name: ext.GetExecutionControllerStatefulsetName(), Namespace: dk.Namespace}, statefulSet)
+	if k8serrors.IsNotFound(err) {
+		log.Info("statefulset to be deployed", "statefulset", ext.GetExecutionControllerStatefulsetName())
+
+		return status.Deploying
+	}
+
+	if err != nil {
+		log.Error(err, "statefulset could not be accessed", "statefulset", ext.GetExecutionControllerStatefulsetName())
+
+		return status.Error
+	}
+
+	if statefulSet.Status.ReadyReplicas == 0 {
+		log.Info("no extension controller replica is ready yet", "statefulset", statefulSet.Name)
+
+		return status.Deploying
+	}
+
+	if !k8sstatefulset.IsRolloutComplete(statefulSet) {
+		log.Info("extension controller is partially available", "statefulset", statefulSet.Name, "readyReplicas", statefulSet.Status.ReadyReplicas, "replicas", ext.GetExecutionControllerReplicas())
 	}
 
 	return status.Running
@@ -152,23 +361,30 @@ func (controller *Controller) determineOneAgentPhase(ctx context.Context, dk *dy
 	log := logd.FromContext(ctx)
 
 	if dk.OneAgent().IsCloudNativeFullstackMode() || dk.OneAgent().IsClassicFullStackMode() || dk.OneAgent().IsHostMonitoringMode() {
-		oneAgentPods, err := controller.numberOfMissingDaemonSetPods(ctx, dk, dk.OneAgent().GetDaemonsetName())
-		if k8serrors.IsNotFound(err) {
-			log.Info("oneagent daemonset not yet available")
-
-			return status.Deploying
+		daemonSetNames := []string{dk.OneAgent().GetDaemonsetName()}
+		for _, nodePool := range dk.OneAgent().GetNodePools() {
+			daemonSetNames = append(daemonSetNames, dk.OneAgent().GetNodePoolDaemonSetName(nodePool.Name))
 		}
 
-		if err != nil {
-			log.Error(err, "oneagent daemonset could not be accessed")
+		for _, daemonSetName := range daemonSetNames {
+			oneAgentPods, err := controller.numberOfMissingDaemonSetPods(ctx, dk, daemonSetName)
+			if k8serrors.IsNotFound(err) {
+				log.Info("oneagent daemonset not yet available", "name", daemonSetName)
 
-			return status.Error
-		}
+				return status.Deploying
+			}
 
-		if oneAgentPods > 0 {
-			log.Info("oneagent daemonset is still deploying")
+			if err != nil {
+				log.Error(err, "oneagent daemonset could not be accessed", "name", daemonSetName)
 
-			return status.Deploying
+				return status.Error
+			}
+
+			if oneAgentPods > 0 {
+				log.Info("oneagent daemonset is still deploying", "name", daemonSetName)
+
+				return status.Deploying
+			}
 		}
 	}
 
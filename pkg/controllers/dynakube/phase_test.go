@@ -17,6 +17,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/image"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
@@ -85,6 +86,23 @@ func TestActiveGatePhaseChanges(t *testing.T) {
 		phase := controller.determineDynaKubePhase(t.Context(), dk)
 		assert.Equal(t, status.Running, phase)
 	})
+	t.Run("reconcile error is recorded and cleared", func(t *testing.T) {
+		dk := dk.DeepCopy()
+		fakeClient := fake.NewClient()
+		controller := &Controller{
+			client:    fakeClient,
+			apiReader: fakeClient,
+		}
+		controller.setComponentError(dynakube.ActiveGateComponent, errors.New("reconcile failed"))
+
+		controller.determineDynaKubePhase(t.Context(), dk)
+		assert.Equal(t, "reconcile failed", dk.Status.Components[dynakube.ActiveGateComponent].LastError)
+
+		controller.componentErrors = nil
+
+		controller.determineDynaKubePhase(t.Context(), dk)
+		assert.Empty(t, dk.Status.Components[dynakube.ActiveGateComponent].LastError)
+	})
 }
 
 func createStatefulset(namespace, name string, replicas, readyReplicas int32) *appsv1.StatefulSet {
@@ -225,6 +243,30 @@ func TestExtensionsExecutionControllerPhaseChanges(t *testing.T) {
 		phase := controller.determineExtensionsExecutionControllerPhase(t.Context(), dk)
 		assert.Equal(t, status.Running, phase)
 	})
+
+	shardedDK := dk.DeepCopy()
+	shardedDK.Spec.Templates.ExtensionExecutionController.Replicas = new(int32(3))
+
+	t.Run("sharded eec without ready pods -> deploying", func(t *testing.T) {
+		fakeClient := fake.NewClient(createStatefulset(testNamespace, shardedDK.Extensions().GetExecutionControllerStatefulsetName(), 3, 0))
+
+		controller := &Controller{
+			client:    fakeClient,
+			apiReader: fakeClient,
+		}
+		phase := controller.determineExtensionsExecutionControllerPhase(t.Context(), shardedDK)
+		assert.Equal(t, status.Deploying, phase)
+	})
+	t.Run("sharded eec partially available -> running", func(t *testing.T) {
+		fakeClient := fake.NewClient(createStatefulset(testNamespace, shardedDK.Extensions().GetExecutionControllerStatefulsetName(), 3, 2))
+
+		controller := &Controller{
+			client:    fakeClient,
+			apiReader: fakeClient,
+		}
+		phase := controller.determineExtensionsExecutionControllerPhase(t.Context(), shardedDK)
+		assert.Equal(t, status.Running, phase)
+	})
 }
 
 func TestOTelCollectorPhaseChanges(t *testing.T) {
@@ -668,6 +710,80 @@ func TestDynakubePhaseChanges(t *testing.T) {
 	}
 }
 
+func TestComponentStatus(t *testing.T) {
+	newDynakube := func() *dynakube.DynaKube {
+		return &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testName,
+				Namespace: testNamespace,
+			},
+			Spec: dynakube.DynaKubeSpec{
+				OneAgent: oneagent.Spec{
+					ClassicFullStack: &oneagent.HostInjectSpec{},
+				},
+				KSPM: &kspm.Spec{},
+			},
+		}
+	}
+
+	t.Run("enabled components are reported", func(t *testing.T) {
+		dk := newDynakube()
+		oaNotReady := createDaemonSet(testNamespace, dk.OneAgent().GetDaemonsetName(), 3, 2)
+		oaNotReady.Status.DesiredNumberScheduled = 3
+		oaNotReady.Labels = map[string]string{k8slabel.AppVersionLabel: "1.2.3"}
+
+		controller := &Controller{
+			client:    fake.NewClient(oaNotReady),
+			apiReader: fake.NewClient(oaNotReady),
+		}
+
+		phase := controller.determineDynaKubePhase(t.Context(), dk)
+		assert.Equal(t, status.Deploying, phase)
+
+		require.Len(t, dk.Status.Components, 2)
+		oneAgentStatus := dk.Status.Components[dynakube.OneAgentComponent]
+		assert.Equal(t, status.Deploying, oneAgentStatus.Phase)
+		assert.Equal(t, "1.2.3", oneAgentStatus.Version)
+		assert.Equal(t, int32(3), oneAgentStatus.DesiredReplicas)
+		assert.Equal(t, int32(2), oneAgentStatus.ReadyReplicas)
+		assert.False(t, oneAgentStatus.LastTransitionTime.IsZero())
+		assert.Equal(t, "0/2", dk.Status.ReadyComponents)
+	})
+
+	t.Run("disabled components are removed", func(t *testing.T) {
+		dk := newDynakube()
+		dk.Status.Components = map[dynakube.ComponentName]dynakube.ComponentStatus{
+			dynakube.ActiveGateComponent: {Phase: status.Error},
+		}
+
+		controller := &Controller{
+			client:    fake.NewClient(),
+			apiReader: fake.NewClient(),
+		}
+
+		controller.determineDynaKubePhase(t.Context(), dk)
+
+		assert.NotContains(t, dk.Status.Components, dynakube.ActiveGateComponent)
+	})
+
+	t.Run("optional component doesn't block phase", func(t *testing.T) {
+		dk := newDynakube()
+		dk.Spec.PhasePolicy = &dynakube.PhasePolicy{OptionalComponents: []dynakube.ComponentName{dynakube.KSPMComponent}}
+		oaReady := createDaemonSet(testNamespace, dk.OneAgent().GetDaemonsetName(), 3, 3)
+		kspmNotReady := createDaemonSet(testNamespace, dk.KSPM().GetDaemonSetName(), 3, 2)
+
+		controller := &Controller{
+			client:    fake.NewClient(oaReady, kspmNotReady),
+			apiReader: fake.NewClient(oaReady, kspmNotReady),
+		}
+
+		phase := controller.determineDynaKubePhase(t.Context(), dk)
+		assert.Equal(t, status.Running, phase)
+		assert.Equal(t, status.Deploying, dk.Status.Components[dynakube.KSPMComponent].Phase)
+		assert.Equal(t, "1/2", dk.Status.ReadyComponents)
+	})
+}
+
 func createDeployment(dk *dynakube.DynaKube, replicas, readyReplicas int32) *appsv1.Deployment {
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
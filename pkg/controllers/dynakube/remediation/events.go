@@ -0,0 +1,42 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package remediation
+
+import (
+	"fmt"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/tools/events"
+)
+
+const (
+	remediationAction = "Remediation"
+
+	memoryLimitIncreasedEvent   = "MemoryLimitIncreased"
+	memoryLimitRecommendedEvent = "MemoryLimitRecommended"
+	memoryLimitCeilingEvent     = "MemoryLimitCeilingReached"
+	remediationRevertedEvent    = "RemediationReverted"
+)
+
+func sendIncreasedEvent(recorder events.EventRecorder, dk *dynakube.DynaKube, name dynakube.ComponentName, reason string, from, to resource.Quantity) {
+	msg := fmt.Sprintf("Memory limit of %s increased from %s to %s, the container was repeatedly %s", name, from.String(), to.String(), reason)
+	recorder.Eventf(dk, nil, corev1.EventTypeWarning, memoryLimitIncreasedEvent, remediationAction, msg)
+}
+
+func sendRecommendedEvent(recorder events.EventRecorder, dk *dynakube.DynaKube, name dynakube.ComponentName, reason string, recommended resource.Quantity) {
+	msg := fmt.Sprintf("The container of %s was repeatedly %s, a memory limit of %s is recommended", name, reason, recommended.String())
+	recorder.Eventf(dk, nil, corev1.EventTypeWarning, memoryLimitRecommendedEvent, remediationAction, msg)
+}
+
+func sendCeilingReachedEvent(recorder events.EventRecorder, dk *dynakube.DynaKube, name dynakube.ComponentName, reason string, ceiling resource.Quantity) {
+	msg := fmt.Sprintf("The container of %s was repeatedly %s, but the memory limit already reached the maximum of %s", name, reason, ceiling.String())
+	recorder.Eventf(dk, nil, corev1.EventTypeWarning, memoryLimitCeilingEvent, remediationAction, msg)
+}
+
+func sendRevertedEvent(recorder events.EventRecorder, dk *dynakube.DynaKube, name dynakube.ComponentName) {
+	msg := fmt.Sprintf("The resources of %s were changed, the remediated memory limit is no longer applied", name)
+	recorder.Eventf(dk, nil, corev1.EventTypeNormal, remediationRevertedEvent, remediationAction, msg)
+}
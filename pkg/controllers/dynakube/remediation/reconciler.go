@@ -0,0 +1,247 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package remediation
+
+import (
+	"context"
+	goerrors "errors"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/timeprovider"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	OOMKilledReason        = "OOMKilled"
+	CrashLoopBackOffReason = "CrashLoopBackOff"
+
+	memoryLimitRoundingStep = 1024 * 1024
+)
+
+// Reconciler watches the pods of the ActiveGate, ExtensionExecutionController and OpenTelemetry collector StatefulSets
+// and raises (or recommends) their memory limit if they are repeatedly OOMKilled or in CrashLoopBackOff.
+// The memory limit is stored in the status of the DynaKube, the StatefulSet builders pick it up via dynakube.DynaKube.GetRemediatedResources.
+type Reconciler struct {
+	client        client.Client
+	apiReader     client.Reader
+	eventRecorder events.EventRecorder
+	timeProvider  *timeprovider.Provider
+}
+
+func NewReconciler(client client.Client, apiReader client.Reader, eventRecorder events.EventRecorder) *Reconciler {
+	return &Reconciler{
+		client:        client,
+		apiReader:     apiReader,
+		eventRecorder: eventRecorder,
+		timeProvider:  timeprovider.New(),
+	}
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, dk *dynakube.DynaKube) error {
+	ctx, log := logd.NewFromContext(ctx, "remediation")
+
+	var errs []error
+
+	for _, name := range dynakube.RemediableComponents {
+		if !dk.IsRemediationEnabled(name) || !isComponentEnabled(dk, name) {
+			if _, ok := dk.Status.Remediations[name]; ok {
+				log.Info("remediation is disabled, reverting it", "component", name)
+				dk.Status.RemoveRemediationStatus(name)
+			}
+
+			continue
+		}
+
+		if err := r.remediate(ctx, dk, name); err != nil {
+			errs = append(errs, errors.WithMessagef(err, "failed to remediate %s", name))
+		}
+	}
+
+	return goerrors.Join(errs...)
+}
+
+func (r *Reconciler) remediate(ctx context.Context, dk *dynakube.DynaKube, name dynakube.ComponentName) error {
+	log := logd.FromContext(ctx)
+
+	resourcesHash, err := hasher.GenerateHash(dk.GetConfiguredResources(name))
+	if err != nil {
+		return err
+	}
+
+	remediationStatus, found := dk.Status.Remediations[name]
+	if found && remediationStatus.ResourcesHash != resourcesHash {
+		log.Info("resources were changed, reverting the remediation", "component", name)
+		sendRevertedEvent(r.eventRecorder, dk, name)
+		dk.Status.RemoveRemediationStatus(name)
+
+		remediationStatus, found = dynakube.RemediationStatus{}, false
+	}
+
+	statefulSet := &appsv1.StatefulSet{}
+
+	err = r.apiReader.Get(ctx, types.NamespacedName{Name: getStatefulSetName(dk, name), Namespace: dk.Namespace}, statefulSet)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if len(statefulSet.Spec.Template.Spec.Containers) == 0 || statefulSet.Spec.Selector == nil {
+		return nil
+	}
+
+	container := statefulSet.Spec.Template.Spec.Containers[0]
+
+	deployedLimit, ok := container.Resources.Limits[corev1.ResourceMemory]
+	if !ok {
+		log.Debug("no memory limit set, nothing to remediate", "component", name)
+
+		return nil
+	}
+
+	var pods corev1.PodList
+
+	err = r.apiReader.List(ctx, &pods, client.InNamespace(dk.Namespace), client.MatchingLabels(statefulSet.Spec.Selector.MatchLabels))
+	if err != nil {
+		return err
+	}
+
+	reason := findUnhealthyReason(pods.Items, container.Name, deployedLimit, dk.GetRemediationRestartThreshold())
+	if reason == "" {
+		return nil
+	}
+
+	ceiling := dk.Spec.Remediation.MaxMemoryLimit
+	nextLimit := nextMemoryLimit(deployedLimit, dk.GetRemediationMemoryStepPercent(), ceiling)
+
+	if nextLimit.Cmp(deployedLimit) <= 0 {
+		if !remediationStatus.CeilingReached {
+			log.Info("memory limit already reached the configured maximum", "component", name, "limit", deployedLimit.String())
+			sendCeilingReachedEvent(r.eventRecorder, dk, name, reason, ceiling)
+		}
+
+		if !found {
+			remediationStatus = dynakube.RemediationStatus{MemoryLimit: deployedLimit, ResourcesHash: resourcesHash}
+		}
+
+		remediationStatus.Reason = reason
+		remediationStatus.CeilingReached = true
+		dk.Status.SetRemediationStatus(name, remediationStatus)
+
+		return nil
+	}
+
+	if found && remediationStatus.MemoryLimit.Cmp(nextLimit) == 0 {
+		// already recommended, or the StatefulSet wasn't updated with the raised limit yet
+		return nil
+	}
+
+	log.Info("remediating component", "component", name, "reason", reason, "mode", dk.GetRemediationMode(), "from", deployedLimit.String(), "to", nextLimit.String())
+
+	if dk.GetRemediationMode() == dynakube.IncreaseMemoryLimitRemediation {
+		sendIncreasedEvent(r.eventRecorder, dk, name, reason, deployedLimit, nextLimit)
+	} else {
+		sendRecommendedEvent(r.eventRecorder, dk, name, reason, nextLimit)
+	}
+
+	dk.Status.SetRemediationStatus(name, dynakube.RemediationStatus{
+		MemoryLimit:         nextLimit,
+		Reason:              reason,
+		ResourcesHash:       resourcesHash,
+		LastRemediationTime: *r.timeProvider.Now(),
+		Steps:               remediationStatus.Steps + 1,
+		CeilingReached:      nextLimit.Cmp(ceiling) >= 0,
+	})
+
+	return nil
+}
+
+// findUnhealthyReason checks whether a container was restarted at least threshold times because it was OOMKilled or crashed.
+// Only pods that already run with the deployed memory limit are considered, so a rollout in progress doesn't trigger another step.
+func findUnhealthyReason(pods []corev1.Pod, containerName string, deployedLimit resource.Quantity, threshold int32) string {
+	reason := ""
+
+	for _, pod := range pods {
+		if !hasMemoryLimit(pod, containerName, deployedLimit) {
+			continue
+		}
+
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if containerStatus.Name != containerName || containerStatus.RestartCount < threshold {
+				continue
+			}
+
+			switch {
+			case isOOMKilled(containerStatus.LastTerminationState) || isOOMKilled(containerStatus.State):
+				return OOMKilledReason
+			case containerStatus.State.Waiting != nil && containerStatus.State.Waiting.Reason == CrashLoopBackOffReason:
+				reason = CrashLoopBackOffReason
+			}
+		}
+	}
+
+	return reason
+}
+
+func hasMemoryLimit(pod corev1.Pod, containerName string, limit resource.Quantity) bool {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == containerName {
+			podLimit, ok := container.Resources.Limits[corev1.ResourceMemory]
+
+			return ok && podLimit.Cmp(limit) == 0
+		}
+	}
+
+	return false
+}
+
+func isOOMKilled(state corev1.ContainerState) bool {
+	return state.Terminated != nil && state.Terminated.Reason == OOMKilledReason
+}
+
+// nextMemoryLimit raises the limit by the given percentage, rounded up to full MiB and capped at the ceiling.
+func nextMemoryLimit(current resource.Quantity, stepPercent int32, ceiling resource.Quantity) resource.Quantity {
+	value := current.Value()
+	next := value + value*int64(stepPercent)/100
+	next = (next + memoryLimitRoundingStep - 1) / memoryLimitRoundingStep * memoryLimitRoundingStep
+
+	if next > ceiling.Value() {
+		return ceiling.DeepCopy()
+	}
+
+	return *resource.NewQuantity(next, resource.BinarySI)
+}
+
+func isComponentEnabled(dk *dynakube.DynaKube, name dynakube.ComponentName) bool {
+	switch name {
+	case dynakube.ActiveGateComponent:
+		return dk.ActiveGate().IsEnabled()
+	case dynakube.ExtensionExecutionControllerComponent:
+		return dk.Extensions().IsAnyEnabled()
+	case dynakube.OTelCollectorComponent:
+		return dk.Extensions().IsPrometheusEnabled() || dk.TelemetryIngest().IsEnabled()
+	}
+
+	return false
+}
+
+func getStatefulSetName(dk *dynakube.DynaKube, name dynakube.ComponentName) string {
+	switch name {
+	case dynakube.ActiveGateComponent:
+		return capability.CalculateStatefulSetName(dk.Name)
+	case dynakube.ExtensionExecutionControllerComponent:
+		return dk.Extensions().GetExecutionControllerStatefulsetName()
+	case dynakube.OTelCollectorComponent:
+		return dk.OTelCollectorStatefulsetName()
+	}
+
+	return ""
+}
@@ -0,0 +1,241 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package remediation
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/timeprovider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	testName          = "test-dk"
+	testNamespace     = "dynatrace"
+	testContainerName = "activegate"
+)
+
+var testMatchLabels = map[string]string{"app": "activegate"}
+
+func newTestDynakube(mode dynakube.RemediationMode) *dynakube.DynaKube {
+	dk := &dynakube.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: testNamespace},
+		Spec: dynakube.DynaKubeSpec{
+			ActiveGate: activegate.Spec{
+				Capabilities: []activegate.CapabilityDisplayName{activegate.RoutingCapability.DisplayName},
+			},
+			Remediation: &dynakube.RemediationSpec{
+				MaxMemoryLimit: resource.MustParse("2Gi"),
+				Mode:           mode,
+				Components:     []dynakube.ComponentName{dynakube.ActiveGateComponent},
+			},
+		},
+	}
+	dk.Spec.ActiveGate.Resources.Limits = corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}
+
+	return dk
+}
+
+func newStatefulSet(dk *dynakube.DynaKube, limit string) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: getStatefulSetName(dk, dynakube.ActiveGateComponent), Namespace: testNamespace},
+		Spec: appsv1.StatefulSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: testMatchLabels},
+			Template: corev1.PodTemplateSpec{
+				Spec: newPodSpec(limit),
+			},
+		},
+	}
+}
+
+func newPod(name, limit string, containerStatus corev1.ContainerStatus) *corev1.Pod {
+	containerStatus.Name = testContainerName
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace, Labels: testMatchLabels},
+		Spec:       newPodSpec(limit),
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{containerStatus},
+		},
+	}
+}
+
+func newPodSpec(limit string) corev1.PodSpec {
+	return corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name: testContainerName,
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(limit)},
+				},
+			},
+		},
+	}
+}
+
+func oomKilled(restarts int32) corev1.ContainerStatus {
+	return corev1.ContainerStatus{
+		RestartCount: restarts,
+		LastTerminationState: corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{Reason: OOMKilledReason},
+		},
+	}
+}
+
+func crashLoopBackOff(restarts int32) corev1.ContainerStatus {
+	return corev1.ContainerStatus{
+		RestartCount: restarts,
+		State: corev1.ContainerState{
+			Waiting: &corev1.ContainerStateWaiting{Reason: CrashLoopBackOffReason},
+		},
+	}
+}
+
+func newTestReconciler(recorder events.EventRecorder, objects ...client.Object) *Reconciler {
+	clt := fake.NewClient(objects...)
+	reconciler := NewReconciler(clt, clt, recorder)
+	reconciler.timeProvider = timeprovider.New().Freeze()
+
+	return reconciler
+}
+
+func TestReconcile(t *testing.T) {
+	t.Run("memory limit is raised after repeated OOMKills", func(t *testing.T) {
+		dk := newTestDynakube(dynakube.IncreaseMemoryLimitRemediation)
+		recorder := events.NewFakeRecorder(10)
+		reconciler := newTestReconciler(recorder, newStatefulSet(dk, "1Gi"), newPod("ag-0", "1Gi", oomKilled(3)))
+
+		require.NoError(t, reconciler.Reconcile(t.Context(), dk))
+
+		remediation := dk.Status.Remediations[dynakube.ActiveGateComponent]
+		assert.Equal(t, "1280Mi", remediation.MemoryLimit.String())
+		assert.Equal(t, OOMKilledReason, remediation.Reason)
+		assert.Equal(t, int32(1), remediation.Steps)
+		assert.False(t, remediation.CeilingReached)
+		assert.Contains(t, <-recorder.Events, memoryLimitIncreasedEvent)
+
+		resources := dk.GetRemediatedResources(dynakube.ActiveGateComponent)
+		assert.Equal(t, "1280Mi", resources.Limits.Memory().String())
+		assert.Equal(t, "1Gi", dk.Spec.ActiveGate.Resources.Limits.Memory().String())
+	})
+
+	t.Run("memory limit is capped at the maximum", func(t *testing.T) {
+		dk := newTestDynakube(dynakube.IncreaseMemoryLimitRemediation)
+		dk.Spec.Remediation.MaxMemoryLimit = resource.MustParse("1100Mi")
+		reconciler := newTestReconciler(events.NewFakeRecorder(10), newStatefulSet(dk, "1Gi"), newPod("ag-0", "1Gi", crashLoopBackOff(5)))
+
+		require.NoError(t, reconciler.Reconcile(t.Context(), dk))
+
+		remediation := dk.Status.Remediations[dynakube.ActiveGateComponent]
+		assert.Equal(t, "1100Mi", remediation.MemoryLimit.String())
+		assert.Equal(t, CrashLoopBackOffReason, remediation.Reason)
+		assert.True(t, remediation.CeilingReached)
+	})
+
+	t.Run("no step above the maximum", func(t *testing.T) {
+		dk := newTestDynakube(dynakube.IncreaseMemoryLimitRemediation)
+		dk.Spec.Remediation.MaxMemoryLimit = resource.MustParse("1Gi")
+		recorder := events.NewFakeRecorder(10)
+		reconciler := newTestReconciler(recorder, newStatefulSet(dk, "1Gi"), newPod("ag-0", "1Gi", oomKilled(3)))
+
+		require.NoError(t, reconciler.Reconcile(t.Context(), dk))
+
+		remediation := dk.Status.Remediations[dynakube.ActiveGateComponent]
+		assert.Equal(t, "1Gi", remediation.MemoryLimit.String())
+		assert.True(t, remediation.CeilingReached)
+		assert.Contains(t, <-recorder.Events, memoryLimitCeilingEvent)
+	})
+
+	t.Run("below restart threshold", func(t *testing.T) {
+		dk := newTestDynakube(dynakube.IncreaseMemoryLimitRemediation)
+		reconciler := newTestReconciler(events.NewFakeRecorder(10), newStatefulSet(dk, "1Gi"), newPod("ag-0", "1Gi", oomKilled(2)))
+
+		require.NoError(t, reconciler.Reconcile(t.Context(), dk))
+
+		assert.Empty(t, dk.Status.Remediations)
+	})
+
+	t.Run("pods with an outdated limit are ignored", func(t *testing.T) {
+		dk := newTestDynakube(dynakube.IncreaseMemoryLimitRemediation)
+		reconciler := newTestReconciler(events.NewFakeRecorder(10), newStatefulSet(dk, "1280Mi"), newPod("ag-0", "1Gi", oomKilled(3)))
+
+		require.NoError(t, reconciler.Reconcile(t.Context(), dk))
+
+		assert.Empty(t, dk.Status.Remediations)
+	})
+
+	t.Run("recommend mode doesn't change the resources", func(t *testing.T) {
+		dk := newTestDynakube(dynakube.RecommendRemediation)
+		recorder := events.NewFakeRecorder(10)
+		reconciler := newTestReconciler(recorder, newStatefulSet(dk, "1Gi"), newPod("ag-0", "1Gi", oomKilled(3)))
+
+		require.NoError(t, reconciler.Reconcile(t.Context(), dk))
+		require.NoError(t, reconciler.Reconcile(t.Context(), dk))
+
+		remediation := dk.Status.Remediations[dynakube.ActiveGateComponent]
+		assert.Equal(t, "1280Mi", remediation.MemoryLimit.String())
+		assert.Equal(t, int32(1), remediation.Steps)
+		assert.Len(t, recorder.Events, 1)
+		assert.Contains(t, <-recorder.Events, memoryLimitRecommendedEvent)
+
+		resources := dk.GetRemediatedResources(dynakube.ActiveGateComponent)
+		assert.Equal(t, "1Gi", resources.Limits.Memory().String())
+	})
+
+	t.Run("remediation is reverted if the resources are changed", func(t *testing.T) {
+		dk := newTestDynakube(dynakube.IncreaseMemoryLimitRemediation)
+		recorder := events.NewFakeRecorder(10)
+		reconciler := newTestReconciler(recorder, newStatefulSet(dk, "1280Mi"))
+		dk.Status.SetRemediationStatus(dynakube.ActiveGateComponent, dynakube.RemediationStatus{
+			MemoryLimit:   resource.MustParse("1280Mi"),
+			ResourcesHash: "outdated",
+			Steps:         1,
+		})
+
+		require.NoError(t, reconciler.Reconcile(t.Context(), dk))
+
+		assert.Empty(t, dk.Status.Remediations)
+		assert.Contains(t, <-recorder.Events, remediationRevertedEvent)
+	})
+
+	t.Run("remediation is reverted if disabled", func(t *testing.T) {
+		dk := newTestDynakube(dynakube.IncreaseMemoryLimitRemediation)
+		dk.Status.SetRemediationStatus(dynakube.ActiveGateComponent, dynakube.RemediationStatus{MemoryLimit: resource.MustParse("1280Mi")})
+		dk.Spec.Remediation = nil
+		reconciler := newTestReconciler(events.NewFakeRecorder(10))
+
+		require.NoError(t, reconciler.Reconcile(t.Context(), dk))
+
+		assert.Empty(t, dk.Status.Remediations)
+	})
+
+	t.Run("no statefulset", func(t *testing.T) {
+		dk := newTestDynakube(dynakube.IncreaseMemoryLimitRemediation)
+		reconciler := newTestReconciler(events.NewFakeRecorder(10))
+
+		require.NoError(t, reconciler.Reconcile(t.Context(), dk))
+
+		assert.Empty(t, dk.Status.Remediations)
+	})
+}
+
+func TestNextMemoryLimit(t *testing.T) {
+	ceiling := resource.MustParse("4Gi")
+
+	assert.Equal(t, "1280Mi", new(nextMemoryLimit(resource.MustParse("1Gi"), 25, ceiling)).String())
+	assert.Equal(t, "2Gi", new(nextMemoryLimit(resource.MustParse("1Gi"), 100, ceiling)).String())
+	assert.Equal(t, "4Gi", new(nextMemoryLimit(resource.MustParse("4000Mi"), 25, ceiling)).String())
+	// rounded up to full MiB
+	assert.Equal(t, "2Mi", new(nextMemoryLimit(resource.MustParse("1000Ki"), 10, ceiling)).String())
+}
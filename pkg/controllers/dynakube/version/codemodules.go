@@ -7,7 +7,6 @@ import (
 	"context"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
-	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/image"
 	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/installer"
@@ -97,10 +96,8 @@ func (updater *codeModulesUpdater) UseTenantRegistry(ctx context.Context) error
 
 	customVersion := updater.CustomVersion()
 	if customVersion != "" {
-		updater.dk.Status.CodeModules = oneagent.CodeModulesStatus{
-			VersionStatus: status.VersionStatus{
-				Version: customVersion,
-			},
+		updater.dk.Status.CodeModules.VersionStatus = status.VersionStatus{
+			Version: customVersion,
 		}
 		setVerificationSkippedReasonCondition(updater.dk.Conditions(), cmConditionType)
 
@@ -116,10 +113,8 @@ func (updater *codeModulesUpdater) UseTenantRegistry(ctx context.Context) error
 		return err
 	}
 
-	updater.dk.Status.CodeModules = oneagent.CodeModulesStatus{
-		VersionStatus: status.VersionStatus{
-			Version: latestAgentVersionUnixPaas,
-		},
+	updater.dk.Status.CodeModules.VersionStatus = status.VersionStatus{
+		Version: latestAgentVersionUnixPaas,
 	}
 	setVerifiedCondition(updater.dk.Conditions(), cmConditionType)
 
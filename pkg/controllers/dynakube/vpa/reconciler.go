@@ -0,0 +1,143 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package vpa
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/autoscaling"
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8sconditions"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8svpa"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	conditionType = "VerticalPodAutoscaler"
+
+	createdReason      = "VerticalPodAutoscalerCreated"
+	notInstalledReason = "VerticalPodAutoscalerNotInstalled"
+)
+
+// Reconciler manages the VerticalPodAutoscalers of the OneAgent, LogMonitoring and KSPM DaemonSets.
+type Reconciler struct {
+	client    client.Client
+	apiReader client.Reader
+}
+
+func NewReconciler(client client.Client, apiReader client.Reader) *Reconciler {
+	return &Reconciler{
+		client:    client,
+		apiReader: apiReader,
+	}
+}
+
+type target struct {
+	spec          *autoscaling.VerticalPodAutoscalerSpec
+	daemonSetName string
+	component     string
+}
+
+// targets lists all DaemonSets that may be autoscaled, spec is nil if the DaemonSet or its VerticalPodAutoscaler is disabled.
+func targets(dk *dynakube.DynaKube) []target {
+	oneAgent := target{daemonSetName: dk.OneAgent().GetDaemonsetName(), component: k8slabel.OneAgentComponentLabel}
+	if dk.OneAgent().IsDaemonsetRequired() {
+		oneAgent.spec = dk.OneAgent().GetVerticalPodAutoscaler()
+	}
+
+	logMonitoring := target{daemonSetName: dk.LogMonitoring().GetDaemonSetName(), component: k8slabel.LogMonitoringComponentLabel}
+	if dk.LogMonitoring().IsStandalone() {
+		logMonitoring.spec = dk.LogMonitoring().Template().VerticalPodAutoscaler
+	}
+
+	kspm := target{daemonSetName: dk.KSPM().GetDaemonSetName(), component: k8slabel.KSPMComponentLabel}
+	if dk.KSPM().IsEnabled() {
+		kspm.spec = dk.KSPM().VerticalPodAutoscaler
+	}
+
+	return []target{oneAgent, logMonitoring, kspm}
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, dk *dynakube.DynaKube) error {
+	ctx, log := logd.NewFromContext(ctx, "vpa")
+
+	desired := targets(dk)
+
+	isAnyEnabled := false
+
+	for _, t := range desired {
+		isAnyEnabled = isAnyEnabled || t.spec != nil
+	}
+
+	if !isAnyEnabled && meta.FindStatusCondition(*dk.Conditions(), conditionType) == nil {
+		return nil // no condition == nothing is there to clean up
+	}
+
+	query := k8svpa.Query(r.client, r.apiReader).WithOwner(dk)
+
+	var created []string
+
+	for _, t := range desired {
+		if t.spec == nil {
+			vpa := k8svpa.New()
+			vpa.SetName(t.daemonSetName)
+			vpa.SetNamespace(dk.Namespace)
+
+			if err := query.Delete(ctx, vpa); err != nil && !meta.IsNoMatchError(err) {
+				k8sconditions.SetKubeAPIError(dk.Conditions(), conditionType, err)
+
+				return err
+			}
+
+			continue
+		}
+
+		labels := k8slabel.NewAppLabels(t.component, dk.Name, t.component, "")
+		vpa := k8svpa.Build(dk, t.daemonSetName, labels.BuildLabels(), t.daemonSetName, *t.spec)
+
+		if _, err := query.CreateOrUpdate(ctx, vpa); meta.IsNoMatchError(err) {
+			log.Info("the VerticalPodAutoscaler is not installed in the cluster, skipping the autoscaling of the DaemonSets")
+			setNotInstalledCondition(dk)
+
+			return nil
+		} else if err != nil {
+			k8sconditions.SetKubeAPIError(dk.Conditions(), conditionType, err)
+
+			return err
+		}
+
+		created = append(created, t.daemonSetName)
+	}
+
+	if len(created) == 0 {
+		_ = meta.RemoveStatusCondition(dk.Conditions(), conditionType)
+
+		return nil
+	}
+
+	meta.SetStatusCondition(dk.Conditions(), metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             createdReason,
+		Message:            "VerticalPodAutoscalers created for " + strings.Join(created, ", "),
+		ObservedGeneration: dk.GetGeneration(),
+	})
+
+	return nil
+}
+
+func setNotInstalledCondition(dk *dynakube.DynaKube) {
+	meta.SetStatusCondition(dk.Conditions(), metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             notInstalledReason,
+		Message:            "The VerticalPodAutoscaler CRD is not available in the cluster",
+		ObservedGeneration: dk.GetGeneration(),
+	})
+}
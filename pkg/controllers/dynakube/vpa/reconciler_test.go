@@ -0,0 +1,117 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package vpa
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/kspm"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/autoscaling"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8svpa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+const (
+	testName      = "test-dk"
+	testNamespace = "dynatrace"
+)
+
+func newDynakube(oneAgentVPA *autoscaling.VerticalPodAutoscalerSpec) *dynakube.DynaKube {
+	return &dynakube.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: testNamespace},
+		Spec: dynakube.DynaKubeSpec{
+			OneAgent: oneagent.Spec{
+				CloudNativeFullStack: &oneagent.CloudNativeFullStackSpec{
+					HostInjectSpec: oneagent.HostInjectSpec{VerticalPodAutoscaler: oneAgentVPA},
+				},
+			},
+			KSPM: &kspm.Spec{},
+		},
+	}
+}
+
+func getVPA(t *testing.T, clt client.Client, name string) (*unstructured.Unstructured, error) {
+	t.Helper()
+
+	vpa := k8svpa.New()
+	err := clt.Get(t.Context(), types.NamespacedName{Name: name, Namespace: testNamespace}, vpa)
+
+	return vpa, err
+}
+
+func TestReconcile(t *testing.T) {
+	t.Run("nothing configured", func(t *testing.T) {
+		dk := newDynakube(nil)
+		clt := fake.NewClient()
+
+		require.NoError(t, NewReconciler(clt, clt).Reconcile(t.Context(), dk))
+
+		assert.Empty(t, dk.Status.Conditions)
+	})
+
+	t.Run("create VerticalPodAutoscaler for configured DaemonSets", func(t *testing.T) {
+		dk := newDynakube(&autoscaling.VerticalPodAutoscalerSpec{Mode: autoscaling.AutoMode})
+		dk.Spec.Templates.KSPMNodeConfigurationCollector.VerticalPodAutoscaler = &autoscaling.VerticalPodAutoscalerSpec{}
+		clt := fake.NewClient()
+
+		require.NoError(t, NewReconciler(clt, clt).Reconcile(t.Context(), dk))
+
+		vpa, err := getVPA(t, clt, dk.OneAgent().GetDaemonsetName())
+		require.NoError(t, err)
+
+		updateMode, _, _ := unstructured.NestedString(vpa.Object, "spec", "updatePolicy", "updateMode")
+		assert.Equal(t, k8svpa.UpdateModeRecreate, updateMode)
+
+		vpa, err = getVPA(t, clt, dk.KSPM().GetDaemonSetName())
+		require.NoError(t, err)
+
+		updateMode, _, _ = unstructured.NestedString(vpa.Object, "spec", "updatePolicy", "updateMode")
+		assert.Equal(t, k8svpa.UpdateModeOff, updateMode)
+
+		condition := meta.FindStatusCondition(dk.Status.Conditions, conditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, createdReason, condition.Reason)
+	})
+
+	t.Run("remove VerticalPodAutoscaler once disabled", func(t *testing.T) {
+		dk := newDynakube(&autoscaling.VerticalPodAutoscalerSpec{})
+		clt := fake.NewClient()
+		reconciler := NewReconciler(clt, clt)
+
+		require.NoError(t, reconciler.Reconcile(t.Context(), dk))
+
+		dk.Spec.OneAgent.CloudNativeFullStack.VerticalPodAutoscaler = nil
+		require.NoError(t, reconciler.Reconcile(t.Context(), dk))
+
+		_, err := getVPA(t, clt, dk.OneAgent().GetDaemonsetName())
+		require.Error(t, err)
+		assert.Nil(t, meta.FindStatusCondition(dk.Status.Conditions, conditionType))
+	})
+
+	t.Run("VerticalPodAutoscaler not installed", func(t *testing.T) {
+		dk := newDynakube(&autoscaling.VerticalPodAutoscalerSpec{})
+		clt := fake.NewClientWithInterceptors(interceptor.Funcs{
+			Get: func(_ context.Context, _ client.WithWatch, _ client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
+				return &meta.NoKindMatchError{GroupKind: k8svpa.GroupVersionKind.GroupKind()}
+			},
+		})
+
+		require.NoError(t, NewReconciler(clt, clt).Reconcile(t.Context(), dk))
+
+		condition := meta.FindStatusCondition(dk.Status.Conditions, conditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, notInstalledReason, condition.Reason)
+	})
+}
@@ -18,6 +18,9 @@ const (
 	EdgeConnectSecretSuffix          = "ec-yaml"
 	EdgeConnectCAConfigMapKey        = "certs"
 	EdgeConnectServiceAccountCAPath  = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+	EdgeConnectSecretsMountPath      = "/etc/edge_connect_secrets"
+	EdgeConnectSecretsVolumeName     = "ec-secrets"
+	EdgeConnectSecretValueKeyPrefix  = "secret-"
 
 	KeyEdgeConnectOauthClientID     = "oauth-client-id"
 	KeyEdgeConnectOauthClientSecret = "oauth-client-secret"
@@ -26,4 +29,16 @@ const (
 
 	// SecretConfigConditionType identifies the secret config condition.
 	SecretConfigConditionType = "SecretConfigConditionType"
+
+	// AutoscalingConditionType identifies the condition reflecting the state of the HorizontalPodAutoscaler.
+	AutoscalingConditionType = "Autoscaling"
+
+	// PodDisruptionBudgetConditionType identifies the PodDisruptionBudget condition.
+	PodDisruptionBudgetConditionType = "PodDisruptionBudget"
+
+	// ConnectedConditionType identifies the condition reflecting whether the instances are connected to the tenant.
+	ConnectedConditionType = "Connected"
+
+	// HostsReachableConditionType identifies the condition reflecting whether the probed hosts are reachable from inside the cluster.
+	HostsReachableConditionType = "HostsReachable"
 )
@@ -5,6 +5,7 @@ package edgeconnect
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"slices"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
 	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	edgeconnectClient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/edgeconnect"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/istio"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/edgeconnect/config"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/edgeconnect/consts"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/edgeconnect/deployment"
@@ -34,6 +36,9 @@ import (
 	"golang.org/x/oauth2/clientcredentials"
 	"gopkg.in/yaml.v3"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -42,6 +47,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -52,13 +58,12 @@ const (
 
 	controllerName = "edgeconnect-controller"
 	finalizerName  = "server"
-)
 
-var (
-	ErrTokenNotFound                = errors.New("token not found")
-	ErrUnsupportedConfigFileVersion = errors.New("unsupported config file version")
+	secretNamesIndex = "spec.secretNames"
 )
 
+var ErrUnsupportedConfigFileVersion = errors.New("unsupported config file version")
+
 type oauthCredentialsType struct {
 	clientID     string
 	clientSecret string
@@ -77,7 +82,9 @@ type Controller struct {
 	config                   *rest.Config
 	timeProvider             *timeprovider.Provider
 	edgeConnectClientBuilder edgeConnectClientBuilderType
+	dialContext              dialContextFunc
 	secrets                  k8ssecret.QueryObject
+	istioReconciler          *istio.Reconciler
 }
 
 func Add(mgr manager.Manager, _ string) error {
@@ -93,18 +100,64 @@ func NewController(mgr manager.Manager) *Controller {
 		config:                   mgr.GetConfig(),
 		timeProvider:             timeprovider.New(),
 		edgeConnectClientBuilder: newEdgeConnectClient(),
+		dialContext:              (&net.Dialer{}).DialContext,
 		secrets:                  k8ssecret.Query(mgr.GetClient(), mgr.GetAPIReader()),
+		istioReconciler:          istio.NewReconciler(mgr.GetClient(), mgr.GetAPIReader()),
 	}
 }
 
 func (controller *Controller) SetupWithManager(mgr ctrl.Manager) error {
+	// Add an index for the referenced Secrets to allow using MatchingFields
+	if err := mgr.GetFieldIndexer().IndexField(context.TODO(), &edgeconnect.EdgeConnect{}, secretNamesIndex, indexSecretNames); err != nil {
+		return errors.WithMessage(err, "add secret names index")
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&edgeconnect.EdgeConnect{}).
 		Named(controllerName).
 		Owns(&appsv1.Deployment{}).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Watches(
+			&corev1.Secret{},
+			// Map requests from referenced Secrets to EdgeConnect, so changed values are rotated
+			handler.EnqueueRequestsFromMapFunc(newEdgeConnectFromSecretMapper(mgr.GetClient())),
+		).
 		Complete(controller)
 }
 
+func indexSecretNames(obj client.Object) []string {
+	ec, ok := obj.(*edgeconnect.EdgeConnect)
+	if !ok {
+		return nil
+	}
+
+	return ec.ReferencedSecretNames()
+}
+
+// Create a [handler.MapFunc] for Secrets that returns requests for EdgeConnect objects which reference the Secret.
+// Only the EdgeConnects found by the secret names index are listed, unrelated Secrets don't cause any requests.
+func newEdgeConnectFromSecretMapper(c client.Client) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		_, log := logd.NewFromContext(ctx, "edgeconnect-mapper")
+
+		ecList := &edgeconnect.EdgeConnectList{}
+		if err := c.List(ctx, ecList, client.InNamespace(obj.GetNamespace()), client.MatchingFields{secretNamesIndex: obj.GetName()}); err != nil {
+			log.Error(err, "failed listing EdgeConnect objects", "secretName", obj.GetName())
+
+			return nil
+		}
+
+		reqs := make([]reconcile.Request, 0, len(ecList.Items))
+
+		for i := range ecList.Items {
+			reqs = append(reqs, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&ecList.Items[i])})
+		}
+
+		return reqs
+	}
+}
+
 func (controller *Controller) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	ctx, log := logd.NewFromContext(ctx, "edgeconnect")
 	log.Info("reconciling EdgeConnect")
@@ -230,6 +283,8 @@ func (controller *Controller) reconcileEdgeConnect(ctx context.Context, ec *edge
 	} else {
 		log.Debug("moving EdgeConnect to correct phase")
 		ec.Status.SetPhase(controller.determineEdgeConnectPhase(ctx, ec))
+
+		controller.reconcileConnectivity(ctx, ec)
 	}
 
 	if isDifferentStatus, err := hasher.IsDifferent(oldStatus, ec.Status); err != nil {
@@ -254,6 +309,10 @@ func (controller *Controller) reconcileEdgeConnect(ctx context.Context, ec *edge
 		return reconcile.Result{}, err
 	}
 
+	if isHealthProbingEnabled(ec) {
+		return reconcile.Result{RequeueAfter: min(defaultRequeueInterval, ec.HealthProbeInterval())}, nil
+	}
+
 	return reconcile.Result{RequeueAfter: defaultRequeueInterval}, nil
 }
 
@@ -283,6 +342,12 @@ func (controller *Controller) reconcileEdgeConnectCR(ctx context.Context, ec *ed
 		ec.Status.KubeSystemUID = string(kubeSystemUID)
 	}
 
+	if err := controller.istioReconciler.ReconcileEdgeConnect(ctx, ec); err != nil {
+		log.Debug("reconcile istio objects failed")
+
+		return errors.WithMessage(err, "failed to reconcile istio objects for EdgeConnect")
+	}
+
 	if ec.IsProvisionerModeEnabled() {
 		log.Debug("reconcile EdgeConnect provisioner")
 
@@ -407,7 +472,7 @@ func (controller *Controller) reconcileEdgeConnectRegular(ctx context.Context, e
 
 	desiredDeployment.Spec.Template.Annotations[consts.EdgeConnectAnnotationSecretHash] = secretHash
 
-	if err = k8sdeployment.ResolveAndSetReplicas(ctx, controller.apiReader, desiredDeployment, ec.Spec.Replicas); err != nil {
+	if err = k8sdeployment.ResolveAndSetReplicas(ctx, controller.apiReader, desiredDeployment, desiredReplicas(ec)); err != nil {
 		return errors.Wrap(err, "failed to resolve and set replica count")
 	}
 
@@ -418,7 +483,7 @@ func (controller *Controller) reconcileEdgeConnectRegular(ctx context.Context, e
 		return err
 	}
 
-	return nil
+	return controller.reconcileScaling(ctx, ec)
 }
 
 func (controller *Controller) reconcileEdgeConnectProvisioner(ctx context.Context, ec *edgeconnect.EdgeConnect) error { //nolint:revive
@@ -669,8 +734,8 @@ func (controller *Controller) updateEdgeConnect(ctx context.Context, edgeConnect
 		return errors.WithStack(err)
 	}
 
-	if slices.Equal(ec.HostPatterns(), edgeConnectResponse.HostPatterns) {
-		log.Debug("EdgeConnect host patterns in response match", "patterns", ec.Spec.HostPatterns)
+	if slices.Equal(ec.HostPatterns(), edgeConnectResponse.HostPatterns) && slices.Equal(ec.HostMappings(), edgeConnectResponse.HostMappings) {
+		log.Debug("EdgeConnect host patterns and host mappings in response match", "patterns", ec.Spec.HostPatterns, "mappings", ec.Spec.HostMappings)
 
 		return nil
 	}
@@ -715,7 +780,7 @@ func (controller *Controller) createOrUpdateEdgeConnectDeploymentAndSettings(ctx
 		return errors.WithStack(err)
 	}
 
-	if err = k8sdeployment.ResolveAndSetReplicas(ctx, controller.apiReader, desiredDeployment, ec.Spec.Replicas); err != nil {
+	if err = k8sdeployment.ResolveAndSetReplicas(ctx, controller.apiReader, desiredDeployment, desiredReplicas(ec)); err != nil {
 		return errors.Wrap(err, "failed to resolve and set replica count")
 	}
 
@@ -726,6 +791,10 @@ func (controller *Controller) createOrUpdateEdgeConnectDeploymentAndSettings(ctx
 		return err
 	}
 
+	if err = controller.reconcileScaling(ctx, ec); err != nil {
+		return err
+	}
+
 	if ec.IsK8SAutomationEnabled() {
 		edgeConnectClient, err := controller.buildEdgeConnectClient(ctx, ec)
 		if err != nil {
@@ -796,30 +865,41 @@ func (controller *Controller) createOrUpdateConnectionSetting(ctx context.Contex
 func (controller *Controller) createOrUpdateEdgeConnectConfigSecret(ctx context.Context, ec *edgeconnect.EdgeConnect) (token string, hash string, err error) {
 	log := logd.FromContext(ctx)
 
-	// Get a Token from edgeconnectClient.yaml secret data
-	token, err = controller.getToken(ctx, ec)
+	// Get the tokens from edgeconnectClient.yaml secret data
+	tokens, err := controller.getTokens(ctx, ec)
 
 	// check token not found and not all errors
-	if err != nil {
-		if k8serrors.IsNotFound(err) || errors.Is(err, ErrTokenNotFound) || errors.Is(err, ErrUnsupportedConfigFileVersion) {
-			log.Debug("creating new token", "error", err.Error())
+	if err != nil && !k8serrors.IsNotFound(err) && !errors.Is(err, ErrUnsupportedConfigFileVersion) {
+		k8sconditions.SetSecretGenFailed(ec.Conditions(), consts.SecretConfigConditionType, err)
 
-			newToken, err := dttoken.New("dt0e01")
-			if err != nil {
-				k8sconditions.SetSecretGenFailed(ec.Conditions(), consts.SecretConfigConditionType, err)
+		return "", "", err
+	}
 
-				return "", "", err
-			}
+	token, err = getOrCreateToken(ctx, tokens, ecsecret.KubernetesAPISecretName)
+	if err != nil {
+		k8sconditions.SetSecretGenFailed(ec.Conditions(), consts.SecretConfigConditionType, err)
 
-			token = newToken.String()
-		} else {
+		return "", "", err
+	}
+
+	secretTokens := make(map[string]string, len(ec.Spec.Secrets))
+	for _, secretSpec := range ec.Spec.Secrets {
+		secretTokens[secretSpec.Name], err = getOrCreateToken(ctx, tokens, secretSpec.Name)
+		if err != nil {
 			k8sconditions.SetSecretGenFailed(ec.Conditions(), consts.SecretConfigConditionType, err)
 
 			return "", "", err
 		}
 	}
 
-	configFile, err := ecsecret.PrepareConfigFile(ctx, ec, controller.apiReader, token)
+	secretValues, err := ec.SecretValues(ctx, controller.apiReader)
+	if err != nil {
+		k8sconditions.SetSecretGenFailed(ec.Conditions(), consts.SecretConfigConditionType, err)
+
+		return "", "", err
+	}
+
+	configFile, err := ecsecret.PrepareConfigFile(ctx, ec, controller.apiReader, token, secretTokens)
 	if err != nil {
 		k8sconditions.SetSecretGenFailed(ec.Conditions(), consts.SecretConfigConditionType, err)
 
@@ -829,6 +909,10 @@ func (controller *Controller) createOrUpdateEdgeConnectConfigSecret(ctx context.
 	secretData := make(map[string][]byte)
 	secretData[consts.EdgeConnectConfigFileName] = configFile
 
+	for name, value := range secretValues {
+		secretData[ecsecret.ValueKey(name)] = value
+	}
+
 	secretConfig, err := k8ssecret.Build(ec,
 		ec.Name+"-"+consts.EdgeConnectSecretSuffix,
 		secretData,
@@ -857,12 +941,29 @@ func (controller *Controller) createOrUpdateEdgeConnectConfigSecret(ctx context.
 	return token, hash, err
 }
 
-func (controller *Controller) getToken(ctx context.Context, ec *edgeconnect.EdgeConnect) (string, error) {
-	secretV, err := controller.secrets.Get(ctx, types.NamespacedName{Name: ec.Name + "-" + consts.EdgeConnectSecretSuffix, Namespace: ec.Namespace})
+// getOrCreateToken returns the token that is already in use for the given secret, or creates a new one.
+func getOrCreateToken(ctx context.Context, tokens map[string]string, secretName string) (string, error) {
+	if token, ok := tokens[secretName]; ok && token != "" {
+		return token, nil
+	}
+
+	logd.FromContext(ctx).Debug("creating new token", "secret", secretName)
+
+	newToken, err := dttoken.New("dt0e01")
 	if err != nil {
 		return "", err
 	}
 
+	return newToken.String(), nil
+}
+
+// getTokens returns the tokens of the secrets in the current config file, keyed by the name of the secret.
+func (controller *Controller) getTokens(ctx context.Context, ec *edgeconnect.EdgeConnect) (map[string]string, error) {
+	secretV, err := controller.secrets.Get(ctx, types.NamespacedName{Name: ec.Name + "-" + consts.EdgeConnectSecretSuffix, Namespace: ec.Namespace})
+	if err != nil {
+		return nil, err
+	}
+
 	cfg := secretV.Data[consts.EdgeConnectConfigFileName]
 
 	ecCfg := config.EdgeConnect{}
@@ -871,17 +972,18 @@ func (controller *Controller) getToken(ctx context.Context, ec *edgeconnect.Edge
 	if err != nil {
 		var typeError *yaml.TypeError
 		if errors.As(err, &typeError) {
-			return "", ErrUnsupportedConfigFileVersion
+			return nil, ErrUnsupportedConfigFileVersion
 		}
 
-		return "", errors.WithStack(err)
+		return nil, errors.WithStack(err)
 	}
 
-	if len(ecCfg.Secrets) > 0 {
-		return ecCfg.Secrets[0].Token, nil
+	tokens := make(map[string]string, len(ecCfg.Secrets))
+	for _, secret := range ecCfg.Secrets {
+		tokens[secret.Name] = secret.Token
 	}
 
-	return "", ErrTokenNotFound
+	return tokens, nil
 }
 
 func GetConnectionSetting(ctx context.Context, edgeConnectClient edgeconnectClient.Client, name, namespace, uid string) (edgeconnectClient.EnvironmentSetting, error) {
@@ -10,10 +10,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
 	edgeconnectClient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/edgeconnect"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/istio"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/edgeconnect/consts"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/edgeconnect/deployment"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8sconditions"
@@ -37,6 +39,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -247,6 +250,125 @@ func TestReconcile(t *testing.T) {
 	})
 }
 
+func TestReconcileUserSecrets(t *testing.T) {
+	const (
+		testUserSecretName = "MY_API_KEY"
+		testK8sSecretName  = "api-key"
+		testK8sSecretKey   = "value"
+	)
+
+	newTestEdgeConnect := func() *edgeconnect.EdgeConnect {
+		ec := createEdgeConnectRegularCR()
+		ec.Spec.Secrets = []edgeconnect.SecretSpec{
+			{
+				Name:            testUserSecretName,
+				SecretRef:       corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: testK8sSecretName}, Key: testK8sSecretKey},
+				RestrictHostsTo: []string{"internal.example.org"},
+			},
+		}
+
+		return ec
+	}
+
+	getConfigSecret := func(t *testing.T, controller *Controller, ec *edgeconnect.EdgeConnect) *corev1.Secret {
+		t.Helper()
+
+		var secret corev1.Secret
+		err := controller.apiReader.Get(t.Context(), client.ObjectKey{Name: ec.Name + "-" + consts.EdgeConnectSecretSuffix, Namespace: ec.Namespace}, &secret)
+		require.NoError(t, err)
+
+		return &secret
+	}
+
+	t.Run("secret values are synced into config secret", func(t *testing.T) {
+		ec := newTestEdgeConnect()
+
+		controller := createFakeClientAndReconciler(t, registrymock.NewImageGetter(t), ec,
+			createClientSecret(testOauthClientSecret, ec.Namespace),
+			newSecret(testK8sSecretName, ec.Namespace, map[string]string{testK8sSecretKey: "super-secret"}),
+		)
+
+		err := controller.reconcileEdgeConnectRegular(t.Context(), ec)
+		require.NoError(t, err)
+
+		configSecret := getConfigSecret(t, controller, ec)
+		assert.Equal(t, []byte("super-secret"), configSecret.Data[consts.EdgeConnectSecretValueKeyPrefix+testUserSecretName])
+
+		tokens, err := controller.getTokens(t.Context(), ec)
+		require.NoError(t, err)
+		assert.NotEmpty(t, tokens[testUserSecretName])
+	})
+
+	t.Run("rotated secret value changes hash but keeps token", func(t *testing.T) {
+		ec := newTestEdgeConnect()
+		userSecret := newSecret(testK8sSecretName, ec.Namespace, map[string]string{testK8sSecretKey: "super-secret"})
+
+		controller := createFakeClientAndReconciler(t, registrymock.NewImageGetter(t), ec,
+			createClientSecret(testOauthClientSecret, ec.Namespace),
+			userSecret,
+		)
+
+		_, oldHash, err := controller.createOrUpdateEdgeConnectConfigSecret(t.Context(), ec)
+		require.NoError(t, err)
+
+		oldTokens, err := controller.getTokens(t.Context(), ec)
+		require.NoError(t, err)
+
+		userSecret.Data[testK8sSecretKey] = []byte("rotated-secret")
+		require.NoError(t, controller.client.Update(t.Context(), userSecret))
+
+		_, newHash, err := controller.createOrUpdateEdgeConnectConfigSecret(t.Context(), ec)
+		require.NoError(t, err)
+
+		newTokens, err := controller.getTokens(t.Context(), ec)
+		require.NoError(t, err)
+
+		assert.NotEqual(t, oldHash, newHash)
+		assert.Equal(t, oldTokens[testUserSecretName], newTokens[testUserSecretName])
+		assert.Equal(t, []byte("rotated-secret"), getConfigSecret(t, controller, ec).Data[consts.EdgeConnectSecretValueKeyPrefix+testUserSecretName])
+	})
+
+	t.Run("missing secret sets SecretGenFailed", func(t *testing.T) {
+		ec := newTestEdgeConnect()
+
+		controller := createFakeClientAndReconciler(t, registrymock.NewImageGetter(t), ec,
+			createClientSecret(testOauthClientSecret, ec.Namespace),
+		)
+
+		err := controller.reconcileEdgeConnectRegular(t.Context(), ec)
+		require.Error(t, err)
+
+		condition := meta.FindStatusCondition(*ec.Conditions(), consts.SecretConfigConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, k8sconditions.SecretGenerationFailed, condition.Reason)
+	})
+
+	t.Run("mapper only enqueues EdgeConnects referencing the secret", func(t *testing.T) {
+		ec := newTestEdgeConnect()
+		other := createEdgeConnectRegularCR()
+		other.Name = "other"
+
+		fakeClient := fakeclient.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(ec, other).
+			WithIndex(&edgeconnect.EdgeConnect{}, secretNamesIndex, indexSecretNames).
+			Build()
+
+		reqs := newEdgeConnectFromSecretMapper(fakeClient)(t.Context(), newSecret(testK8sSecretName, ec.Namespace, nil))
+		require.Len(t, reqs, 1)
+		assert.Equal(t, client.ObjectKeyFromObject(ec), reqs[0].NamespacedName)
+
+		reqs = newEdgeConnectFromSecretMapper(fakeClient)(t.Context(), newSecret(testOauthClientSecret, ec.Namespace, nil))
+		assert.Len(t, reqs, 2)
+
+		reqs = newEdgeConnectFromSecretMapper(fakeClient)(t.Context(), newSecret("unrelated", ec.Namespace, nil))
+		assert.Empty(t, reqs)
+
+		reqs = newEdgeConnectFromSecretMapper(fakeClient)(t.Context(), newSecret(testK8sSecretName, "other-namespace", nil))
+		assert.Empty(t, reqs)
+	})
+}
+
 func TestReconcileProvisionerCreate(t *testing.T) {
 	t.Run("create EdgeConnect", func(t *testing.T) {
 		ctx := t.Context()
@@ -871,6 +993,7 @@ func createFakeClientAndReconciler(t *testing.T, mockImageGetter *registrymock.I
 		registryClientBuilder:    mockRegistryClientBuilder,
 		edgeConnectClientBuilder: mockEdgeConnectClientBuilder,
 		secrets:                  k8ssecret.Query(fakeClient, fakeClient),
+		istioReconciler:          istio.NewReconciler(fakeClient, fakeClient),
 	}
 
 	return controller
@@ -897,6 +1020,7 @@ func createFakeClientAndReconcilerForProvisioner(t *testing.T, mockImageGetter *
 		registryClientBuilder:    mockRegistryClientBuilder,
 		edgeConnectClientBuilder: builder,
 		secrets:                  k8ssecret.Query(fakeClient, fakeClient),
+		istioReconciler:          istio.NewReconciler(fakeClient, fakeClient),
 	}
 
 	return controller
@@ -922,6 +1046,9 @@ func mockNewEdgeConnectClientCreate(edgeConnectClient *edgeconnectmock.Client, h
 			nil,
 		)
 
+		// GetEdgeConnect is used for probing the connected instances
+		edgeConnectClient.EXPECT().GetEdgeConnect(anyCtx, testCreatedID).Return(edgeconnectClient.APIResponse{ID: testCreatedID}, nil).Maybe()
+
 		return edgeConnectClient, nil
 	}
 }
@@ -955,6 +1082,9 @@ func mockNewEdgeConnectClientRecreate(edgeConnectClient *edgeconnectmock.Client,
 			nil,
 		)
 
+		// GetEdgeConnect is used for probing the connected instances
+		edgeConnectClient.EXPECT().GetEdgeConnect(anyCtx, testCreatedID).Return(edgeconnectClient.APIResponse{ID: testCreatedID}, nil).Maybe()
+
 		return edgeConnectClient, nil
 	}
 }
@@ -1167,6 +1297,7 @@ func mockController(t *testing.T) *Controller {
 		config:                   &rest.Config{},
 		timeProvider:             timeprovider.New(),
 		edgeConnectClientBuilder: newEdgeConnectClient(),
+		istioReconciler:          istio.NewReconciler(fake.NewClient(), fake.NewClient()),
 	}
 }
 
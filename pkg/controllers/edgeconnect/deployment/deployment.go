@@ -8,6 +8,7 @@ import (
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/edgeconnect/consts"
+	ecsecret "github.com/Dynatrace/dynatrace-operator/pkg/controllers/edgeconnect/secret"
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8slabel"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8sresource"
@@ -73,6 +74,7 @@ func create(ctx context.Context, ec *edgeconnect.EdgeConnect) *appsv1.Deployment
 					NodeSelector:                  ec.Spec.NodeSelector,
 					Tolerations:                   ec.Spec.Tolerations,
 					TopologySpreadConstraints:     ec.Spec.TopologySpreadConstraints,
+					Affinity:                      prepareAffinity(ec, appLabels),
 				},
 			},
 			Strategy: appsv1.DeploymentStrategy{
@@ -87,6 +89,29 @@ func create(ctx context.Context, ec *edgeconnect.EdgeConnect) *appsv1.Deployment
 	}
 }
 
+// prepareAffinity uses the affinity of the EdgeConnect if set, otherwise the pods are preferably spread across nodes, so a single node failure doesn't take down all replicas.
+func prepareAffinity(ec *edgeconnect.EdgeConnect, appLabels *k8slabel.AppLabels) *corev1.Affinity {
+	if ec.Spec.Affinity != nil {
+		return ec.Spec.Affinity
+	}
+
+	return &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{
+					Weight: 100,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: appLabels.BuildMatchLabels(),
+						},
+						TopologyKey: corev1.LabelHostname,
+					},
+				},
+			},
+		},
+	}
+}
+
 func prepareImagePullSecrets(ec *edgeconnect.EdgeConnect) []corev1.LocalObjectReference {
 	if ec.Spec.CustomPullSecret != "" {
 		return []corev1.LocalObjectReference{
@@ -159,6 +184,10 @@ func prepareVolumes(ec *edgeconnect.EdgeConnect) []corev1.Volume {
 		})
 	}
 
+	if len(ec.Spec.Secrets) > 0 {
+		volumes = append(volumes, prepareSecretsVolume(ec))
+	}
+
 	return volumes
 }
 
@@ -171,6 +200,10 @@ func prepareVolumeMounts(ec *edgeconnect.EdgeConnect) []corev1.VolumeMount {
 		volumeMounts = append(volumeMounts, corev1.VolumeMount{MountPath: consts.EdgeConnectMountPath, Name: consts.EdgeConnectCustomCAVolumeName})
 	}
 
+	if len(ec.Spec.Secrets) > 0 {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{MountPath: consts.EdgeConnectSecretsMountPath, Name: consts.EdgeConnectSecretsVolumeName, ReadOnly: true})
+	}
+
 	return volumeMounts
 }
 
@@ -189,6 +222,26 @@ func prepareConfigVolume(ec *edgeconnect.EdgeConnect) corev1.Volume {
 	}
 }
 
+// prepareSecretsVolume mounts the values of the user defined secrets, which are synced into the config secret, as one file per secret.
+func prepareSecretsVolume(ec *edgeconnect.EdgeConnect) corev1.Volume {
+	items := make([]corev1.KeyToPath, 0, len(ec.Spec.Secrets))
+	for _, secretSpec := range ec.Spec.Secrets {
+		items = append(items, corev1.KeyToPath{Key: ecsecret.ValueKey(secretSpec.Name), Path: secretSpec.Name})
+	}
+
+	return corev1.Volume{
+		Name: consts.EdgeConnectSecretsVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName:  ec.Name + "-" + consts.EdgeConnectSecretSuffix,
+				Items:       items,
+				DefaultMode: new(int32(0o440)),
+				Optional:    new(true),
+			},
+		},
+	}
+}
+
 func prepareResourceRequirements(ec *edgeconnect.EdgeConnect) corev1.ResourceRequirements {
 	limits := k8sresource.NewResourceList("100m", "128Mi")
 	requests := k8sresource.NewResourceList("100m", "128Mi")
@@ -302,3 +302,75 @@ func Test_prepareResourceRequirements(t *testing.T) {
 		assert.Equal(t, k8sresource.NewResourceList("100m", "128Mi"), resourceRequirements.Limits)
 	})
 }
+
+func TestSecretsVolume(t *testing.T) {
+	ec := &edgeconnect.EdgeConnect{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName,
+			Namespace: testNamespace,
+		},
+		Spec: edgeconnect.EdgeConnectSpec{
+			APIServer: "abc12345.dynatrace.com",
+		},
+	}
+
+	t.Run("no secrets volume without secrets", func(t *testing.T) {
+		volumes := prepareVolumes(ec)
+		volumeMounts := prepareVolumeMounts(ec)
+
+		assert.Len(t, volumes, 1)
+		assert.Len(t, volumeMounts, 1)
+	})
+
+	t.Run("secrets are mounted from config secret", func(t *testing.T) {
+		ec := ec.DeepCopy()
+		ec.Spec.Secrets = []edgeconnect.SecretSpec{
+			{Name: "MY_API_KEY", SecretRef: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "api-key"}, Key: "value"}},
+		}
+
+		volumes := prepareVolumes(ec)
+		volumeMounts := prepareVolumeMounts(ec)
+
+		require.Len(t, volumes, 2)
+		require.Len(t, volumeMounts, 2)
+
+		assert.Equal(t, consts.EdgeConnectSecretsVolumeName, volumes[1].Name)
+		require.NotNil(t, volumes[1].Secret)
+		assert.Equal(t, testName+"-"+consts.EdgeConnectSecretSuffix, volumes[1].Secret.SecretName)
+		assert.Equal(t, []corev1.KeyToPath{{Key: consts.EdgeConnectSecretValueKeyPrefix + "MY_API_KEY", Path: "MY_API_KEY"}}, volumes[1].Secret.Items)
+
+		assert.Equal(t, consts.EdgeConnectSecretsVolumeName, volumeMounts[1].Name)
+		assert.Equal(t, consts.EdgeConnectSecretsMountPath, volumeMounts[1].MountPath)
+		assert.True(t, volumeMounts[1].ReadOnly)
+	})
+}
+
+func TestAffinity(t *testing.T) {
+	ec := &edgeconnect.EdgeConnect{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName,
+			Namespace: testNamespace,
+		},
+	}
+
+	t.Run("pods are spread across nodes by default", func(t *testing.T) {
+		affinity := New(t.Context(), ec).Spec.Template.Spec.Affinity
+
+		require.NotNil(t, affinity)
+		require.NotNil(t, affinity.PodAntiAffinity)
+		require.Len(t, affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution, 1)
+
+		term := affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].PodAffinityTerm
+		assert.Equal(t, corev1.LabelHostname, term.TopologyKey)
+		assert.Equal(t, buildAppLabels(ec).BuildMatchLabels(), term.LabelSelector.MatchLabels)
+	})
+
+	t.Run("custom affinity replaces default", func(t *testing.T) {
+		ec := ec.DeepCopy()
+		ec.Spec.Affinity = &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{}}
+
+		affinity := New(t.Context(), ec).Spec.Template.Spec.Affinity
+
+		assert.Equal(t, ec.Spec.Affinity, affinity)
+	})
+}
@@ -0,0 +1,62 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package deployment
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const defaultTargetCPUUtilization = int32(80)
+
+// NewHorizontalPodAutoscaler creates the HorizontalPodAutoscaler for the EdgeConnect deployment, it has the same name as the deployment.
+func NewHorizontalPodAutoscaler(ec *edgeconnect.EdgeConnect) *autoscalingv2.HorizontalPodAutoscaler {
+	autoscaling := ec.Spec.Autoscaling
+
+	minReplicas := autoscaling.MinReplicas
+	if minReplicas == nil {
+		minReplicas = new(int32(1))
+	}
+
+	metrics := autoscaling.Metrics
+	if len(metrics) == 0 {
+		metrics = defaultMetrics()
+	}
+
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ec.Name,
+			Namespace: ec.Namespace,
+			Labels:    buildAppLabels(ec).BuildLabels(),
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       ec.Name,
+			},
+			MinReplicas: minReplicas,
+			MaxReplicas: autoscaling.MaxReplicas,
+			Metrics:     metrics,
+			Behavior:    autoscaling.Behavior,
+		},
+	}
+}
+
+func defaultMetrics() []autoscalingv2.MetricSpec {
+	return []autoscalingv2.MetricSpec{
+		{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: corev1.ResourceCPU,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: new(defaultTargetCPUUtilization),
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package deployment
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewHorizontalPodAutoscaler(t *testing.T) {
+	ec := &edgeconnect.EdgeConnect{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName,
+			Namespace: testNamespace,
+		},
+		Spec: edgeconnect.EdgeConnectSpec{
+			Autoscaling: &edgeconnect.AutoscalingSpec{MaxReplicas: 5},
+		},
+	}
+
+	t.Run("defaults to cpu utilization", func(t *testing.T) {
+		hpa := NewHorizontalPodAutoscaler(ec)
+
+		assert.Equal(t, testName, hpa.Name)
+		assert.Equal(t, testNamespace, hpa.Namespace)
+		assert.Equal(t, "Deployment", hpa.Spec.ScaleTargetRef.Kind)
+		assert.Equal(t, testName, hpa.Spec.ScaleTargetRef.Name)
+		assert.Equal(t, int32(1), *hpa.Spec.MinReplicas)
+		assert.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+		assert.Equal(t, defaultMetrics(), hpa.Spec.Metrics)
+	})
+
+	t.Run("custom metrics are used", func(t *testing.T) {
+		ec := ec.DeepCopy()
+		ec.Spec.Autoscaling.MinReplicas = new(int32(2))
+		ec.Spec.Autoscaling.Metrics = []autoscalingv2.MetricSpec{
+			{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name: corev1.ResourceMemory,
+					Target: autoscalingv2.MetricTarget{
+						Type:         autoscalingv2.AverageValueMetricType,
+						AverageValue: new(resource.MustParse("100Mi")),
+					},
+				},
+			},
+		}
+
+		hpa := NewHorizontalPodAutoscaler(ec)
+
+		assert.Equal(t, int32(2), *hpa.Spec.MinReplicas)
+		assert.Equal(t, ec.Spec.Autoscaling.Metrics, hpa.Spec.Metrics)
+	})
+}
@@ -0,0 +1,43 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package deployment
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// NewPodDisruptionBudget creates the PodDisruptionBudget for the EdgeConnect pods, it has the same name as the deployment.
+// By default only one pod may be unavailable at a time, which never blocks a node drain for a single replica.
+func NewPodDisruptionBudget(ec *edgeconnect.EdgeConnect) *policyv1.PodDisruptionBudget {
+	appLabels := buildAppLabels(ec)
+
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ec.Name,
+			Namespace: ec.Namespace,
+			Labels:    appLabels.BuildLabels(),
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: appLabels.BuildMatchLabels(),
+			},
+		},
+	}
+
+	spec := ec.Spec.PodDisruptionBudget
+
+	switch {
+	case spec != nil && spec.MinAvailable != nil:
+		pdb.Spec.MinAvailable = spec.MinAvailable
+	case spec != nil && spec.MaxUnavailable != nil:
+		pdb.Spec.MaxUnavailable = spec.MaxUnavailable
+	default:
+		pdb.Spec.MaxUnavailable = new(intstr.FromInt32(1))
+	}
+
+	return pdb
+}
@@ -0,0 +1,50 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package deployment
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestNewPodDisruptionBudget(t *testing.T) {
+	ec := &edgeconnect.EdgeConnect{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName,
+			Namespace: testNamespace,
+		},
+	}
+
+	t.Run("allows one unavailable pod by default", func(t *testing.T) {
+		pdb := NewPodDisruptionBudget(ec)
+
+		assert.Equal(t, testName, pdb.Name)
+		assert.Equal(t, buildAppLabels(ec).BuildMatchLabels(), pdb.Spec.Selector.MatchLabels)
+		assert.Equal(t, new(intstr.FromInt32(1)), pdb.Spec.MaxUnavailable)
+		assert.Nil(t, pdb.Spec.MinAvailable)
+	})
+
+	t.Run("uses minAvailable of the EdgeConnect", func(t *testing.T) {
+		ec := ec.DeepCopy()
+		ec.Spec.PodDisruptionBudget = &edgeconnect.PodDisruptionBudgetSpec{MinAvailable: new(intstr.FromString("50%"))}
+
+		pdb := NewPodDisruptionBudget(ec)
+
+		assert.Equal(t, ec.Spec.PodDisruptionBudget.MinAvailable, pdb.Spec.MinAvailable)
+		assert.Nil(t, pdb.Spec.MaxUnavailable)
+	})
+
+	t.Run("uses maxUnavailable of the EdgeConnect", func(t *testing.T) {
+		ec := ec.DeepCopy()
+		ec.Spec.PodDisruptionBudget = &edgeconnect.PodDisruptionBudgetSpec{MaxUnavailable: new(intstr.FromInt32(2))}
+
+		pdb := NewPodDisruptionBudget(ec)
+
+		assert.Equal(t, ec.Spec.PodDisruptionBudget.MaxUnavailable, pdb.Spec.MaxUnavailable)
+	})
+}
@@ -0,0 +1,187 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package edgeconnect
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/edgeconnect/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8sconditions"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	instancesConnectedReason    = "InstancesConnected"
+	noInstanceConnectedReason   = "NoInstanceConnected"
+	hostsReachableReason        = "HostsReachable"
+	hostsUnreachableReason      = "HostsUnreachable"
+	noHostsToProbeReason        = "NoHostsToProbe"
+	instanceStateUnknownMessage = "the state of the instances can't be determined, the EdgeConnect is not known to the tenant"
+)
+
+type dialContextFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// isHealthProbingEnabled returns true if there is anything to probe for the EdgeConnect, the instance state can only be queried with the permissions of the provisioner.
+func isHealthProbingEnabled(ec *edgeconnect.EdgeConnect) bool {
+	return ec.IsProvisionerModeEnabled() || ec.IsReachabilityCheckEnabled()
+}
+
+// reconcileConnectivity probes the connection of the instances to the tenant and the reachability of the hosts, the results are reflected in the status.
+// Failing probes don't fail the reconciliation, they only show up in the conditions.
+func (controller *Controller) reconcileConnectivity(ctx context.Context, ec *edgeconnect.EdgeConnect) {
+	log := logd.FromContext(ctx)
+
+	if !isHealthProbingEnabled(ec) {
+		ec.Status.Connectivity = edgeconnect.ConnectivityStatus{}
+		_ = meta.RemoveStatusCondition(ec.Conditions(), consts.ConnectedConditionType)
+		_ = meta.RemoveStatusCondition(ec.Conditions(), consts.HostsReachableConditionType)
+
+		return
+	}
+
+	if !controller.timeProvider.IsOutdated(&ec.Status.Connectivity.LastProbeTimestamp, ec.HealthProbeInterval()) {
+		log.Debug("connectivity of EdgeConnect was probed recently, skipping")
+
+		return
+	}
+
+	ec.Status.Connectivity.LastProbeTimestamp = *controller.timeProvider.Now()
+
+	if ec.IsProvisionerModeEnabled() {
+		controller.probeInstances(ctx, ec)
+	} else {
+		ec.Status.Connectivity.Instances = nil
+		_ = meta.RemoveStatusCondition(ec.Conditions(), consts.ConnectedConditionType)
+	}
+
+	if ec.IsReachabilityCheckEnabled() {
+		controller.probeHosts(ctx, ec)
+	} else {
+		ec.Status.Connectivity.Hosts = nil
+		_ = meta.RemoveStatusCondition(ec.Conditions(), consts.HostsReachableConditionType)
+	}
+}
+
+func (controller *Controller) probeInstances(ctx context.Context, ec *edgeconnect.EdgeConnect) {
+	log := logd.FromContext(ctx)
+
+	edgeConnectClient, err := controller.buildEdgeConnectClient(ctx, ec)
+	if err != nil {
+		log.Info("unable to build EdgeConnect client for probing the instances", "error", err.Error())
+		k8sconditions.SetDynatraceAPIError(ec.Conditions(), consts.ConnectedConditionType, err)
+
+		return
+	}
+
+	id, err := controller.getEdgeConnectIDFromClientSecret(ctx, ec)
+	if err != nil {
+		k8sconditions.SetKubeAPIError(ec.Conditions(), consts.ConnectedConditionType, err)
+
+		return
+	}
+
+	if id == "" {
+		ec.Status.Connectivity.Instances = nil
+		setConnectedCondition(ec, metav1.ConditionUnknown, noInstanceConnectedReason, instanceStateUnknownMessage)
+
+		return
+	}
+
+	response, err := edgeConnectClient.GetEdgeConnect(ctx, id)
+	if err != nil {
+		log.Info("unable to query the state of the EdgeConnect instances", "error", err.Error())
+		k8sconditions.SetDynatraceAPIError(ec.Conditions(), consts.ConnectedConditionType, err)
+
+		return
+	}
+
+	instances := make([]edgeconnect.InstanceStatus, 0, len(response.Metadata.Instances))
+	for _, instance := range response.Metadata.Instances {
+		instances = append(instances, edgeconnect.InstanceStatus{ID: instance.InstanceID, Version: instance.Version})
+	}
+
+	ec.Status.Connectivity.Instances = instances
+
+	if len(instances) == 0 {
+		setConnectedCondition(ec, metav1.ConditionFalse, noInstanceConnectedReason, "no instance is connected to the tenant")
+
+		return
+	}
+
+	setConnectedCondition(ec, metav1.ConditionTrue, instancesConnectedReason, fmt.Sprintf("%d instance(s) connected to the tenant", len(instances)))
+}
+
+func (controller *Controller) probeHosts(ctx context.Context, ec *edgeconnect.EdgeConnect) {
+	hosts := ec.ReachabilityHosts()
+	if len(hosts) == 0 {
+		ec.Status.Connectivity.Hosts = nil
+		setHostsReachableCondition(ec, metav1.ConditionUnknown, noHostsToProbeReason, "no host without wildcard is configured")
+
+		return
+	}
+
+	port := strconv.Itoa(int(ec.ReachabilityPort()))
+	results := make([]edgeconnect.HostReachabilityStatus, 0, len(hosts))
+
+	var unreachable []string
+
+	for _, host := range hosts {
+		result := edgeconnect.HostReachabilityStatus{Host: host, Reachable: true}
+
+		if err := controller.dial(ctx, net.JoinHostPort(host, port), ec); err != nil {
+			result.Reachable = false
+			result.Message = err.Error()
+			unreachable = append(unreachable, host)
+		}
+
+		results = append(results, result)
+	}
+
+	ec.Status.Connectivity.Hosts = results
+
+	if len(unreachable) > 0 {
+		setHostsReachableCondition(ec, metav1.ConditionFalse, hostsUnreachableReason, "unreachable hosts: "+strings.Join(unreachable, ", "))
+
+		return
+	}
+
+	setHostsReachableCondition(ec, metav1.ConditionTrue, hostsReachableReason, fmt.Sprintf("%d host(s) reachable", len(results)))
+}
+
+func (controller *Controller) dial(ctx context.Context, address string, ec *edgeconnect.EdgeConnect) error {
+	ctx, cancel := context.WithTimeout(ctx, ec.ReachabilityTimeout())
+	defer cancel()
+
+	conn, err := controller.dialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+func setConnectedCondition(ec *edgeconnect.EdgeConnect, status metav1.ConditionStatus, reason, message string) {
+	_ = meta.SetStatusCondition(ec.Conditions(), metav1.Condition{
+		Type:    consts.ConnectedConditionType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+func setHostsReachableCondition(ec *edgeconnect.EdgeConnect, status metav1.ConditionStatus, reason, message string) {
+	_ = meta.SetStatusCondition(ec.Conditions(), metav1.Condition{
+		Type:    consts.HostsReachableConditionType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+}
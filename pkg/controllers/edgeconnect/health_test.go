@@ -0,0 +1,218 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package edgeconnect
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
+	edgeconnectClient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/edgeconnect"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/edgeconnect/consts"
+	edgeconnectmock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/clients/dynatrace/edgeconnect"
+	registrymock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/util/oci/registry"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReconcileConnectivity(t *testing.T) {
+	newReachabilityEdgeConnect := func(hostPatterns ...string) *edgeconnect.EdgeConnect {
+		ec := createEdgeConnectRegularCR()
+		ec.Spec.HostPatterns = hostPatterns
+		ec.Spec.HealthProbes = &edgeconnect.HealthProbesSpec{Reachability: &edgeconnect.ReachabilitySpec{}}
+
+		return ec
+	}
+
+	newDialer := func(unreachable ...string) (dialContextFunc, *[]string) {
+		var dialed []string
+
+		return func(_ context.Context, _, address string) (net.Conn, error) {
+			dialed = append(dialed, address)
+
+			host, _, _ := net.SplitHostPort(address)
+			for _, u := range unreachable {
+				if u == host {
+					return nil, errors.New("connection refused")
+				}
+			}
+
+			client, server := net.Pipe()
+			_ = server.Close()
+
+			return client, nil
+		}, &dialed
+	}
+
+	t.Run("nothing is probed without provisioner and reachability checks", func(t *testing.T) {
+		ec := createEdgeConnectRegularCR()
+		controller := createFakeClientAndReconciler(t, registrymock.NewImageGetter(t), ec)
+
+		controller.reconcileConnectivity(t.Context(), ec)
+
+		assert.Empty(t, ec.Status.Connectivity)
+		assert.Nil(t, meta.FindStatusCondition(ec.Status.Conditions, consts.ConnectedConditionType))
+		assert.Nil(t, meta.FindStatusCondition(ec.Status.Conditions, consts.HostsReachableConditionType))
+	})
+
+	t.Run("reachable hosts", func(t *testing.T) {
+		ec := newReachabilityEdgeConnect("*.internal.org", "api.internal.org", "db.internal.org")
+		controller := createFakeClientAndReconciler(t, registrymock.NewImageGetter(t), ec)
+
+		dialer, dialed := newDialer()
+		controller.dialContext = dialer
+
+		controller.reconcileConnectivity(t.Context(), ec)
+
+		assert.Equal(t, []string{"api.internal.org:443", "db.internal.org:443"}, *dialed)
+		assert.Equal(t, []edgeconnect.HostReachabilityStatus{
+			{Host: "api.internal.org", Reachable: true},
+			{Host: "db.internal.org", Reachable: true},
+		}, ec.Status.Connectivity.Hosts)
+
+		condition := meta.FindStatusCondition(ec.Status.Conditions, consts.HostsReachableConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, hostsReachableReason, condition.Reason)
+	})
+
+	t.Run("unreachable host with custom hosts and port", func(t *testing.T) {
+		ec := newReachabilityEdgeConnect("*.internal.org")
+		ec.Spec.HealthProbes.Reachability.Hosts = []string{"api.internal.org", "db.internal.org"}
+		ec.Spec.HealthProbes.Reachability.Port = new(int32(8443))
+		controller := createFakeClientAndReconciler(t, registrymock.NewImageGetter(t), ec)
+
+		dialer, dialed := newDialer("db.internal.org")
+		controller.dialContext = dialer
+
+		controller.reconcileConnectivity(t.Context(), ec)
+
+		assert.Equal(t, []string{"api.internal.org:8443", "db.internal.org:8443"}, *dialed)
+		require.Len(t, ec.Status.Connectivity.Hosts, 2)
+		assert.True(t, ec.Status.Connectivity.Hosts[0].Reachable)
+		assert.False(t, ec.Status.Connectivity.Hosts[1].Reachable)
+		assert.Equal(t, "connection refused", ec.Status.Connectivity.Hosts[1].Message)
+
+		condition := meta.FindStatusCondition(ec.Status.Conditions, consts.HostsReachableConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, "unreachable hosts: db.internal.org", condition.Message)
+	})
+
+	t.Run("only wildcard host patterns can't be probed", func(t *testing.T) {
+		ec := newReachabilityEdgeConnect("*.internal.org")
+		controller := createFakeClientAndReconciler(t, registrymock.NewImageGetter(t), ec)
+
+		dialer, dialed := newDialer()
+		controller.dialContext = dialer
+
+		controller.reconcileConnectivity(t.Context(), ec)
+
+		assert.Empty(t, *dialed)
+
+		condition := meta.FindStatusCondition(ec.Status.Conditions, consts.HostsReachableConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionUnknown, condition.Status)
+	})
+
+	t.Run("probes are throttled by the interval", func(t *testing.T) {
+		ec := newReachabilityEdgeConnect("api.internal.org")
+		ec.Spec.HealthProbes.IntervalSeconds = new(int32(60))
+		controller := createFakeClientAndReconciler(t, registrymock.NewImageGetter(t), ec)
+		controller.timeProvider.Freeze()
+
+		dialer, dialed := newDialer()
+		controller.dialContext = dialer
+
+		controller.reconcileConnectivity(t.Context(), ec)
+		controller.reconcileConnectivity(t.Context(), ec)
+		assert.Len(t, *dialed, 1)
+
+		controller.timeProvider.Set(controller.timeProvider.Now().Add(time.Minute))
+		controller.reconcileConnectivity(t.Context(), ec)
+		assert.Len(t, *dialed, 2)
+	})
+
+	t.Run("connected instances are queried from the tenant", func(t *testing.T) {
+		ec := createEdgeConnectProvisionerCR(nil, nil, testHostPatterns)
+		edgeClient := edgeconnectmock.NewClient(t)
+		edgeClient.EXPECT().GetEdgeConnect(anyCtx, testCreatedID).Return(edgeconnectClient.APIResponse{
+			ID: testCreatedID,
+			Metadata: edgeconnectClient.Metadata{
+				Instances: []edgeconnectClient.InstanceMetadata{
+					{InstanceID: "instance-1", Version: "1.2.3"},
+					{InstanceID: "instance-2", Version: "1.2.3"},
+				},
+			},
+		}, nil).Once()
+
+		controller := createFakeClientAndReconcilerForProvisioner(t, registrymock.NewImageGetter(t), ec,
+			func(context.Context, *edgeconnect.EdgeConnect, oauthCredentialsType, []byte) (edgeconnectClient.Client, error) {
+				return edgeClient, nil
+			},
+			createOauthSecret(ec.Spec.OAuth.ClientSecret, ec.Namespace),
+			createClientSecret(ec.ClientSecretName(), ec.Namespace),
+		)
+
+		controller.reconcileConnectivity(t.Context(), ec)
+
+		assert.Equal(t, []edgeconnect.InstanceStatus{
+			{ID: "instance-1", Version: "1.2.3"},
+			{ID: "instance-2", Version: "1.2.3"},
+		}, ec.Status.Connectivity.Instances)
+
+		condition := meta.FindStatusCondition(ec.Status.Conditions, consts.ConnectedConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, "2 instance(s) connected to the tenant", condition.Message)
+	})
+
+	t.Run("no connected instance", func(t *testing.T) {
+		ec := createEdgeConnectProvisionerCR(nil, nil, testHostPatterns)
+		edgeClient := edgeconnectmock.NewClient(t)
+		edgeClient.EXPECT().GetEdgeConnect(anyCtx, testCreatedID).Return(edgeconnectClient.APIResponse{ID: testCreatedID}, nil).Once()
+
+		controller := createFakeClientAndReconcilerForProvisioner(t, registrymock.NewImageGetter(t), ec,
+			func(context.Context, *edgeconnect.EdgeConnect, oauthCredentialsType, []byte) (edgeconnectClient.Client, error) {
+				return edgeClient, nil
+			},
+			createOauthSecret(ec.Spec.OAuth.ClientSecret, ec.Namespace),
+			createClientSecret(ec.ClientSecretName(), ec.Namespace),
+		)
+
+		controller.reconcileConnectivity(t.Context(), ec)
+
+		assert.Empty(t, ec.Status.Connectivity.Instances)
+
+		condition := meta.FindStatusCondition(ec.Status.Conditions, consts.ConnectedConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, noInstanceConnectedReason, condition.Reason)
+	})
+
+	t.Run("tenant errors are reflected in the condition", func(t *testing.T) {
+		ec := createEdgeConnectProvisionerCR(nil, nil, testHostPatterns)
+		edgeClient := edgeconnectmock.NewClient(t)
+		edgeClient.EXPECT().GetEdgeConnect(anyCtx, testCreatedID).Return(edgeconnectClient.APIResponse{}, errors.New("boom")).Once()
+
+		controller := createFakeClientAndReconcilerForProvisioner(t, registrymock.NewImageGetter(t), ec,
+			func(context.Context, *edgeconnect.EdgeConnect, oauthCredentialsType, []byte) (edgeconnectClient.Client, error) {
+				return edgeClient, nil
+			},
+			createOauthSecret(ec.Spec.OAuth.ClientSecret, ec.Namespace),
+			createClientSecret(ec.ClientSecretName(), ec.Namespace),
+		)
+
+		controller.reconcileConnectivity(t.Context(), ec)
+
+		condition := meta.FindStatusCondition(ec.Status.Conditions, consts.ConnectedConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	})
+}
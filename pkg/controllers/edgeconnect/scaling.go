@@ -0,0 +1,155 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package edgeconnect
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/edgeconnect/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/edgeconnect/deployment"
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8sconditions"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8shpa"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8spdb"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	scalingActiveReason   = "ScalingActive"
+	scalingLimitedReason  = "ScalingLimited"
+	scalingInactiveReason = "ScalingInactive"
+
+	podDisruptionBudgetCreatedReason = "PodDisruptionBudgetCreated"
+)
+
+// desiredReplicas returns the replicas that are enforced on the deployment, with autoscaling the replicas are owned by the HorizontalPodAutoscaler.
+func desiredReplicas(ec *edgeconnect.EdgeConnect) *int32 {
+	if ec.IsAutoscalingEnabled() {
+		return nil
+	}
+
+	return ec.Spec.Replicas
+}
+
+func (controller *Controller) reconcileScaling(ctx context.Context, ec *edgeconnect.EdgeConnect) error {
+	if err := controller.reconcileHorizontalPodAutoscaler(ctx, ec); err != nil {
+		return err
+	}
+
+	return controller.reconcilePodDisruptionBudget(ctx, ec)
+}
+
+func (controller *Controller) reconcileHorizontalPodAutoscaler(ctx context.Context, ec *edgeconnect.EdgeConnect) error {
+	log := logd.FromContext(ctx)
+	query := k8shpa.Query(controller.client, controller.apiReader).WithOwner(ec)
+
+	if !ec.IsAutoscalingEnabled() {
+		if meta.FindStatusCondition(ec.Status.Conditions, consts.AutoscalingConditionType) == nil {
+			return nil
+		}
+
+		err := query.Delete(ctx, &autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Name: ec.Name, Namespace: ec.Namespace}})
+		if err != nil {
+			k8sconditions.SetKubeAPIError(ec.Conditions(), consts.AutoscalingConditionType, err)
+
+			return err
+		}
+
+		_ = meta.RemoveStatusCondition(ec.Conditions(), consts.AutoscalingConditionType)
+
+		return nil
+	}
+
+	desiredHPA := deployment.NewHorizontalPodAutoscaler(ec)
+
+	if _, err := query.CreateOrUpdate(ctx, desiredHPA); err != nil {
+		log.Info("could not create or update HorizontalPodAutoscaler for EdgeConnect")
+		k8sconditions.SetKubeAPIError(ec.Conditions(), consts.AutoscalingConditionType, err)
+
+		return err
+	}
+
+	currentHPA, err := query.Get(ctx, client.ObjectKeyFromObject(desiredHPA))
+	if err != nil {
+		k8sconditions.SetKubeAPIError(ec.Conditions(), consts.AutoscalingConditionType, err)
+
+		return err
+	}
+
+	setAutoscalingCondition(ec, currentHPA)
+
+	return nil
+}
+
+// setAutoscalingCondition makes the scaling activity of the HorizontalPodAutoscaler visible on the EdgeConnect.
+func setAutoscalingCondition(ec *edgeconnect.EdgeConnect, hpa *autoscalingv2.HorizontalPodAutoscaler) {
+	condition := metav1.Condition{
+		Type:   consts.AutoscalingConditionType,
+		Status: metav1.ConditionTrue,
+		Reason: scalingActiveReason,
+		Message: fmt.Sprintf("current replicas: %d, desired replicas: %d (min: %d, max: %d)",
+			hpa.Status.CurrentReplicas, hpa.Status.DesiredReplicas, *hpa.Spec.MinReplicas, hpa.Spec.MaxReplicas),
+	}
+
+	for _, hpaCondition := range hpa.Status.Conditions {
+		switch {
+		case hpaCondition.Type == autoscalingv2.ScalingActive && hpaCondition.Status == corev1.ConditionFalse:
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = scalingInactiveReason
+			condition.Message += ": " + hpaCondition.Message
+		case hpaCondition.Type == autoscalingv2.ScalingLimited && hpaCondition.Status == corev1.ConditionTrue && condition.Status == metav1.ConditionTrue:
+			condition.Reason = scalingLimitedReason
+			condition.Message += ": " + hpaCondition.Message
+		}
+	}
+
+	_ = meta.SetStatusCondition(ec.Conditions(), condition)
+}
+
+func (controller *Controller) reconcilePodDisruptionBudget(ctx context.Context, ec *edgeconnect.EdgeConnect) error {
+	log := logd.FromContext(ctx)
+	query := k8spdb.Query(controller.client, controller.apiReader).WithOwner(ec)
+
+	if !ec.IsPodDisruptionBudgetEnabled() {
+		if meta.FindStatusCondition(ec.Status.Conditions, consts.PodDisruptionBudgetConditionType) == nil {
+			return nil
+		}
+
+		err := query.Delete(ctx, &policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: ec.Name, Namespace: ec.Namespace}})
+		if err != nil {
+			k8sconditions.SetKubeAPIError(ec.Conditions(), consts.PodDisruptionBudgetConditionType, err)
+
+			return err
+		}
+
+		_ = meta.RemoveStatusCondition(ec.Conditions(), consts.PodDisruptionBudgetConditionType)
+
+		return nil
+	}
+
+	desiredPDB := deployment.NewPodDisruptionBudget(ec)
+
+	if _, err := query.CreateOrUpdate(ctx, desiredPDB); err != nil {
+		log.Info("could not create or update PodDisruptionBudget for EdgeConnect")
+		k8sconditions.SetKubeAPIError(ec.Conditions(), consts.PodDisruptionBudgetConditionType, err)
+
+		return err
+	}
+
+	_ = meta.SetStatusCondition(ec.Conditions(), metav1.Condition{
+		Type:    consts.PodDisruptionBudgetConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  podDisruptionBudgetCreatedReason,
+		Message: desiredPDB.Name + " created",
+	})
+
+	return nil
+}
@@ -0,0 +1,144 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package edgeconnect
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/edgeconnect/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/edgeconnect/deployment"
+	registrymock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/util/oci/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestReconcileScaling(t *testing.T) {
+	newTestEdgeConnect := func() *edgeconnect.EdgeConnect {
+		ec := createEdgeConnectRegularCR()
+		ec.Spec.Autoscaling = &edgeconnect.AutoscalingSpec{MinReplicas: new(int32(2)), MaxReplicas: 4}
+
+		return ec
+	}
+
+	t.Run("PodDisruptionBudget is created by default", func(t *testing.T) {
+		ec := createEdgeConnectRegularCR()
+		controller := createFakeClientAndReconciler(t, registrymock.NewImageGetter(t), ec, createClientSecret(testOauthClientSecret, ec.Namespace))
+
+		require.NoError(t, controller.reconcileEdgeConnectRegular(t.Context(), ec))
+
+		var pdb policyv1.PodDisruptionBudget
+		require.NoError(t, controller.apiReader.Get(t.Context(), client.ObjectKeyFromObject(ec), &pdb))
+		assert.Equal(t, ec.Name, pdb.OwnerReferences[0].Name)
+
+		condition := meta.FindStatusCondition(ec.Status.Conditions, consts.PodDisruptionBudgetConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+
+		var hpa autoscalingv2.HorizontalPodAutoscaler
+		assert.True(t, k8serrors.IsNotFound(controller.apiReader.Get(t.Context(), client.ObjectKeyFromObject(ec), &hpa)))
+		assert.Nil(t, meta.FindStatusCondition(ec.Status.Conditions, consts.AutoscalingConditionType))
+	})
+
+	t.Run("PodDisruptionBudget is removed when disabled", func(t *testing.T) {
+		ec := createEdgeConnectRegularCR()
+		controller := createFakeClientAndReconciler(t, registrymock.NewImageGetter(t), ec, createClientSecret(testOauthClientSecret, ec.Namespace))
+
+		require.NoError(t, controller.reconcileEdgeConnectRegular(t.Context(), ec))
+
+		ec.Spec.PodDisruptionBudget = &edgeconnect.PodDisruptionBudgetSpec{Enabled: new(false)}
+		require.NoError(t, controller.reconcileEdgeConnectRegular(t.Context(), ec))
+
+		var pdb policyv1.PodDisruptionBudget
+		assert.True(t, k8serrors.IsNotFound(controller.apiReader.Get(t.Context(), client.ObjectKeyFromObject(ec), &pdb)))
+		assert.Nil(t, meta.FindStatusCondition(ec.Status.Conditions, consts.PodDisruptionBudgetConditionType))
+	})
+
+	t.Run("HorizontalPodAutoscaler is created and owns the replicas", func(t *testing.T) {
+		ec := newTestEdgeConnect()
+		existing := deployment.New(t.Context(), ec)
+		existing.Spec.Replicas = new(int32(3))
+
+		controller := createFakeClientAndReconciler(t, registrymock.NewImageGetter(t), ec, createClientSecret(testOauthClientSecret, ec.Namespace), existing)
+
+		require.NoError(t, controller.reconcileEdgeConnectRegular(t.Context(), ec))
+
+		var hpa autoscalingv2.HorizontalPodAutoscaler
+		require.NoError(t, controller.apiReader.Get(t.Context(), client.ObjectKeyFromObject(ec), &hpa))
+		assert.Equal(t, int32(2), *hpa.Spec.MinReplicas)
+		assert.Equal(t, int32(4), hpa.Spec.MaxReplicas)
+
+		var d appsv1.Deployment
+		require.NoError(t, controller.apiReader.Get(t.Context(), client.ObjectKeyFromObject(ec), &d))
+		assert.Equal(t, int32(3), *d.Spec.Replicas)
+
+		condition := meta.FindStatusCondition(ec.Status.Conditions, consts.AutoscalingConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, scalingActiveReason, condition.Reason)
+	})
+
+	t.Run("HorizontalPodAutoscaler is removed when autoscaling is disabled", func(t *testing.T) {
+		ec := newTestEdgeConnect()
+		controller := createFakeClientAndReconciler(t, registrymock.NewImageGetter(t), ec, createClientSecret(testOauthClientSecret, ec.Namespace))
+
+		require.NoError(t, controller.reconcileEdgeConnectRegular(t.Context(), ec))
+
+		ec.Spec.Autoscaling = nil
+		require.NoError(t, controller.reconcileEdgeConnectRegular(t.Context(), ec))
+
+		var hpa autoscalingv2.HorizontalPodAutoscaler
+		assert.True(t, k8serrors.IsNotFound(controller.apiReader.Get(t.Context(), client.ObjectKeyFromObject(ec), &hpa)))
+		assert.Nil(t, meta.FindStatusCondition(ec.Status.Conditions, consts.AutoscalingConditionType))
+	})
+}
+
+func Test_setAutoscalingCondition(t *testing.T) {
+	newHPA := func(conditions ...autoscalingv2.HorizontalPodAutoscalerCondition) *autoscalingv2.HorizontalPodAutoscaler {
+		return &autoscalingv2.HorizontalPodAutoscaler{
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{MinReplicas: new(int32(1)), MaxReplicas: 3},
+			Status: autoscalingv2.HorizontalPodAutoscalerStatus{
+				CurrentReplicas: 3,
+				DesiredReplicas: 3,
+				Conditions:      conditions,
+			},
+		}
+	}
+
+	t.Run("scaling limited", func(t *testing.T) {
+		ec := createEdgeConnectRegularCR()
+
+		setAutoscalingCondition(ec, newHPA(
+			autoscalingv2.HorizontalPodAutoscalerCondition{Type: autoscalingv2.ScalingActive, Status: corev1.ConditionTrue},
+			autoscalingv2.HorizontalPodAutoscalerCondition{Type: autoscalingv2.ScalingLimited, Status: corev1.ConditionTrue, Message: "the desired replica count is more than the maximum replica count"},
+		))
+
+		condition := meta.FindStatusCondition(ec.Status.Conditions, consts.AutoscalingConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, scalingLimitedReason, condition.Reason)
+		assert.Equal(t, "current replicas: 3, desired replicas: 3 (min: 1, max: 3): the desired replica count is more than the maximum replica count", condition.Message)
+	})
+
+	t.Run("scaling inactive", func(t *testing.T) {
+		ec := createEdgeConnectRegularCR()
+
+		setAutoscalingCondition(ec, newHPA(
+			autoscalingv2.HorizontalPodAutoscalerCondition{Type: autoscalingv2.ScalingActive, Status: corev1.ConditionFalse, Message: "missing request for cpu"},
+		))
+
+		condition := meta.FindStatusCondition(ec.Status.Conditions, consts.AutoscalingConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, scalingInactiveReason, condition.Reason)
+	})
+}
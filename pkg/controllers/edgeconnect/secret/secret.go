@@ -14,7 +14,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func PrepareConfigFile(ctx context.Context, ec *edgeconnect.EdgeConnect, apiReader client.Reader, token string) ([]byte, error) {
+const KubernetesAPISecretName = "K8S_SERVICE_ACCOUNT_TOKEN"
+
+// ValueKey returns the key under which the value of the given secret is stored in the config secret.
+func ValueKey(secretName string) string {
+	return consts.EdgeConnectSecretValueKeyPrefix + secretName
+}
+
+func PrepareConfigFile(ctx context.Context, ec *edgeconnect.EdgeConnect, apiReader client.Reader, token string, secretTokens map[string]string) ([]byte, error) {
 	_, log := logd.NewFromContext(ctx, "secret")
 
 	cfg := config.EdgeConnect{
@@ -59,6 +66,10 @@ func PrepareConfigFile(ctx context.Context, ec *edgeconnect.EdgeConnect, apiRead
 		cfg.Secrets = append(cfg.Secrets, createKubernetesAPISecret(token))
 	}
 
+	for _, secretSpec := range ec.Spec.Secrets {
+		cfg.Secrets = append(cfg.Secrets, createUserSecret(secretSpec, secretTokens[secretSpec.Name]))
+	}
+
 	if ec.Spec.Proxy != nil {
 		cfg.Proxy = config.Proxy{
 			Server:     ec.Spec.Proxy.Host,
@@ -142,9 +153,18 @@ func safeEdgeConnectCfg(cfg config.EdgeConnect) string {
 
 func createKubernetesAPISecret(token string) config.Secret {
 	return config.Secret{
-		Name:            "K8S_SERVICE_ACCOUNT_TOKEN",
+		Name:            KubernetesAPISecretName,
 		Token:           token,
 		FromFile:        "/var/run/secrets/kubernetes.io/serviceaccount/token",
 		RestrictHostsTo: []string{edgeconnect.KubernetesDefaultDNS},
 	}
 }
+
+func createUserSecret(secretSpec edgeconnect.SecretSpec, token string) config.Secret {
+	return config.Secret{
+		Name:            secretSpec.Name,
+		Token:           token,
+		FromFile:        consts.EdgeConnectSecretsMountPath + "/" + secretSpec.Name,
+		RestrictHostsTo: secretSpec.RestrictHostsTo,
+	}
+}
@@ -50,7 +50,7 @@ func TestPrepareConfigFile(t *testing.T) {
 
 		testSecretName := "test-secret"
 		kubeReader := fake.NewClient(createClientSecret(testSecretName, testNamespace))
-		cfg, err := PrepareConfigFile(t.Context(), ec, kubeReader, testToken)
+		cfg, err := PrepareConfigFile(t.Context(), ec, kubeReader, testToken, nil)
 
 		require.NoError(t, err)
 
@@ -95,7 +95,7 @@ root_certificate_paths:
 			edgeconnect.ProxyAuthPasswordKey: "pass",
 		})
 		kubeReader := fake.NewClient(createClientSecret(testSecretName, testNamespace), authRef)
-		cfg, err := PrepareConfigFile(t.Context(), ec, kubeReader, testToken)
+		cfg, err := PrepareConfigFile(t.Context(), ec, kubeReader, testToken, nil)
 
 		require.NoError(t, err)
 
@@ -139,7 +139,7 @@ proxy:
 		}
 		testSecretName := "test-secret"
 		kubeReader := fake.NewClient(createClientSecret(testSecretName, testNamespace))
-		cfg, err := PrepareConfigFile(t.Context(), ec, kubeReader, testToken)
+		cfg, err := PrepareConfigFile(t.Context(), ec, kubeReader, testToken, nil)
 
 		require.NoError(t, err)
 
@@ -158,6 +158,52 @@ secrets:
       from_file: /var/run/secrets/kubernetes.io/serviceaccount/token
       restrict_hosts_to:
         - kubernetes.default.svc.cluster.local
+`
+		assert.Equal(t, expected, string(cfg))
+	})
+	t.Run("Create config with user defined secrets", func(t *testing.T) {
+		ec := &edgeconnect.EdgeConnect{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testName,
+				Namespace: testNamespace,
+			},
+			Spec: edgeconnect.EdgeConnectSpec{
+				APIServer: "abc12345.dynatrace.com",
+				OAuth: edgeconnect.OAuthSpec{
+					Endpoint:     "https://test.com/sso/oauth2/token",
+					Resource:     "urn:dtenvironment:test12345",
+					ClientSecret: "test-secret",
+				},
+				Secrets: []edgeconnect.SecretSpec{
+					{
+						Name:            "MY_API_KEY",
+						SecretRef:       corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "api-key"}, Key: "value"},
+						RestrictHostsTo: []string{"internal.example.org"},
+					},
+				},
+			},
+		}
+		testSecretName := "test-secret"
+		kubeReader := fake.NewClient(createClientSecret(testSecretName, testNamespace))
+		cfg, err := PrepareConfigFile(t.Context(), ec, kubeReader, testToken, map[string]string{"MY_API_KEY": "user-token"})
+
+		require.NoError(t, err)
+
+		expected := `name: test-name-edgeconnect
+api_endpoint_host: abc12345.dynatrace.com
+oauth:
+    endpoint: https://test.com/sso/oauth2/token
+    client_id: created-client-id
+    client_secret: created-client-secret
+    resource: urn:dtenvironment:test12345
+root_certificate_paths:
+    - /var/run/secrets/kubernetes.io/serviceaccount/ca.crt
+secrets:
+    - name: MY_API_KEY
+      token: user-token
+      from_file: /etc/edge_connect_secrets/MY_API_KEY
+      restrict_hosts_to:
+        - internal.example.org
 `
 		assert.Equal(t, expected, string(cfg))
 	})
@@ -6,7 +6,6 @@ package cache
 import (
 	"context"
 	"encoding/json"
-	"slices"
 	"time"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
@@ -203,28 +202,41 @@ func (cache *Cache) UpdateTimestamp(now time.Time) {
 // Prune will collect the nodeNames from the Cache that do not have a corresponding k8s Node in cluster.
 // - We return these nodeNames to the Controller, to send a mark for termination if need, just in case.
 // It will also remove the Entries that have a corresponding k8s Node in cluster, but have not had a OneAgent on them for a over an hour.
-func (cache *Cache) Prune(ctx context.Context, client client.Client, now time.Time) ([]string, error) {
-	var nodeLst corev1.NodeList
-	if err := client.List(ctx, &nodeLst); err != nil {
-		return nil, err
-	}
-
+// The nodeReader is expected to be the cached client of the manager, which already watches the Nodes, so no API requests are sent.
+func (cache *Cache) Prune(ctx context.Context, nodeReader client.Reader, now time.Time) ([]string, error) {
 	toBePruned := []string{}
 
 	for _, cachedNodeName := range cache.Keys() {
-		if slices.ContainsFunc(nodeLst.Items, func(clusterNode corev1.Node) bool { return clusterNode.Name == cachedNodeName }) {
-			// err can be ignored, as the ContainsFunc checks that the `cache` contains that `cachedNodeName`
-			entry, _ := cache.GetEntry(cachedNodeName)
-
-			isNodeDeletable := now.Sub(entry.LastSeen).Hours() > 1 || entry.IPAddress == ""
+		exists, err := nodeExists(ctx, nodeReader, cachedNodeName)
+		if err != nil {
+			return nil, err
+		}
 
-			if isNodeDeletable {
-				cache.DeleteEntry(entry.NodeName)
-			}
-		} else {
+		if !exists {
 			toBePruned = append(toBePruned, cachedNodeName)
+
+			continue
+		}
+
+		// err can be ignored, as the `cache.Keys()` guarantees that the `cache` contains that `cachedNodeName`
+		entry, _ := cache.GetEntry(cachedNodeName)
+
+		isNodeDeletable := now.Sub(entry.LastSeen).Hours() > 1 || entry.IPAddress == ""
+
+		if isNodeDeletable {
+			cache.DeleteEntry(entry.NodeName)
 		}
 	}
 
 	return toBePruned, nil
 }
+
+// nodeExists looks up the full Node, as a metadata only request would start a separate informer on the cached client.
+func nodeExists(ctx context.Context, nodeReader client.Reader, nodeName string) (bool, error) {
+	err := nodeReader.Get(ctx, client.ObjectKey{Name: nodeName}, &corev1.Node{})
+	if k8serrors.IsNotFound(err) {
+		return false, nil
+	}
+
+	return err == nil, errors.WithStack(err)
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
@@ -58,3 +59,24 @@ func TestCache(t *testing.T) {
 		assert.False(t, nodesCache.IsOutdated(time.Now()))
 	})
 }
+
+func TestPrune(t *testing.T) {
+	now := time.Now().UTC()
+
+	nodesCache := &Cache{obj: &corev1.ConfigMap{}}
+	require.NoError(t, nodesCache.SetEntry("present", Entry{IPAddress: "1.2.3.4", LastSeen: now}))
+	require.NoError(t, nodesCache.SetEntry("stale", Entry{IPAddress: "1.2.3.5", LastSeen: now.Add(-2 * time.Hour)}))
+	require.NoError(t, nodesCache.SetEntry("missing", Entry{IPAddress: "1.2.3.6", LastSeen: now}))
+
+	clt := fake.NewClient(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "present"}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "stale"}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "not-cached"}},
+	)
+
+	toBePruned, err := nodesCache.Prune(t.Context(), clt, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"missing"}, toBePruned)
+	assert.ElementsMatch(t, []string{"present", "missing"}, nodesCache.Keys())
+}
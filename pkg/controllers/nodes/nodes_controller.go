@@ -6,7 +6,6 @@ package nodes
 import (
 	"context"
 	"os"
-	"slices"
 	"time"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
@@ -29,17 +28,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
-var (
-	unschedulableTaints = []string{"ToBeDeletedByClusterAutoscaler"}
-)
-
 type Controller struct {
-	client          client.Client
-	apiReader       client.Reader
-	dtClientFactory dynatrace.ClientFactory
-	timeProvider    *timeprovider.Provider
-	podNamespace    string
-	runLocal        bool
+	client             client.Client
+	apiReader          client.Reader
+	dtClientFactory    dynatrace.ClientFactory
+	timeProvider       *timeprovider.Provider
+	podNamespace       string
+	terminationSignals TerminationSignals
+	runLocal           bool
 }
 
 func Add(mgr manager.Manager, _ string) error {
@@ -55,23 +51,25 @@ func (controller *Controller) SetupWithManager(mgr ctrl.Manager) error {
 
 func NewController(mgr manager.Manager) *Controller {
 	return &Controller{
-		client:          mgr.GetClient(),
-		apiReader:       mgr.GetAPIReader(),
-		dtClientFactory: dynatrace.NewClientFromDynakube,
-		runLocal:        system.IsRunLocally(),
-		podNamespace:    os.Getenv(k8senv.PodNamespace),
-		timeProvider:    timeprovider.New(),
+		client:             mgr.GetClient(),
+		apiReader:          mgr.GetAPIReader(),
+		dtClientFactory:    dynatrace.NewClientFromDynakube,
+		runLocal:           system.IsRunLocally(),
+		podNamespace:       os.Getenv(k8senv.PodNamespace),
+		timeProvider:       timeprovider.New(),
+		terminationSignals: NewTerminationSignalsFromEnv(context.Background()),
 	}
 }
 
 func NewControllerFromClient(clt client.Client) *Controller {
 	return &Controller{
-		client:          clt,
-		apiReader:       clt,
-		dtClientFactory: dynatrace.NewClientFromDynakube,
-		runLocal:        system.IsRunLocally(),
-		podNamespace:    os.Getenv(k8senv.PodNamespace),
-		timeProvider:    timeprovider.New(),
+		client:             clt,
+		apiReader:          clt,
+		dtClientFactory:    dynatrace.NewClientFromDynakube,
+		runLocal:           system.IsRunLocally(),
+		podNamespace:       os.Getenv(k8senv.PodNamespace),
+		timeProvider:       timeprovider.New(),
+		terminationSignals: NewTerminationSignalsFromEnv(context.Background()),
 	}
 }
 
@@ -147,8 +145,8 @@ func (controller *Controller) reconcileNodeUpdate(ctx context.Context, dk *dynak
 		cacheEntry.SetLastMarkedForTerminationTimestamp(cached.LastMarkedForTermination)
 	}
 
-	// Handle unschedulable Nodes, if they have a OneAgent instance
-	if isUnschedulable(node) {
+	// Handle Nodes that are about to be removed, if they have a OneAgent instance
+	if controller.terminationSignals.IsTerminating(node) {
 		if err := controller.markForTermination(ctx, dk, &cacheEntry); err != nil {
 			return err
 		}
@@ -254,20 +252,6 @@ func (controller *Controller) markForTermination(ctx context.Context, dk *dynaku
 	return controller.sendMarkedForTermination(ctx, dk, cacheEntry)
 }
 
-func isUnschedulable(node *corev1.Node) bool {
-	return node.Spec.Unschedulable || hasUnschedulableTaint(node)
-}
-
-func hasUnschedulableTaint(node *corev1.Node) bool {
-	for _, taint := range node.Spec.Taints {
-		if slices.Contains(unschedulableTaints, taint.Key) {
-			return true
-		}
-	}
-
-	return false
-}
-
 func (controller *Controller) getCache(ctx context.Context) (*cache.Cache, error) {
 	var owner client.Object
 
@@ -29,6 +29,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -90,6 +91,42 @@ func TestReconcile(t *testing.T) {
 		_, err = nodesCache.GetEntry("node1")
 		require.Error(t, err)
 	})
+	t.Run("Node of DynaKube in watched namespace", func(t *testing.T) {
+		t.Setenv(k8senv.WatchNamespacesEnvVar, "tenant")
+
+		ctx := t.Context()
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+		node.Spec.Taints = []corev1.Taint{{Key: "karpenter.sh/disrupted"}}
+
+		fakeClient := fake.NewClient(
+			node,
+			&dynakube.DynaKube{
+				ObjectMeta: metav1.ObjectMeta{Name: "oneagent1", Namespace: "tenant"},
+				Status: dynakube.DynaKubeStatus{
+					OneAgent: oneagent.Status{
+						Instances: map[string]oneagent.Instance{node.Name: {IPAddress: "1.2.3.4"}},
+					},
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "oneagent1", Namespace: "tenant"},
+				Data:       map[string][]byte{token.APIKey: []byte(testAPIToken)},
+			},
+		)
+
+		ctrl := createDefaultReconciler(t, fakeClient, createDTMockClient(t, "1.2.3.4", "HOST-42"))
+		_, err := ctrl.Reconcile(ctx, createReconcileRequest("node1"))
+		require.NoError(t, err)
+
+		nodesCache, err := cache.New(ctx, fakeClient, testNamespace, nil)
+		require.NoError(t, err)
+
+		entry, err := nodesCache.GetEntry("node1")
+		require.NoError(t, err)
+		assert.Equal(t, "oneagent1", entry.DynaKubeName)
+		assert.False(t, entry.LastMarkedForTermination.IsZero())
+	})
+
 	t.Run("No error if v1 host entity api is not present on tenant ", func(t *testing.T) {
 		ctx := t.Context()
 		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
@@ -246,6 +283,60 @@ func TestReconcile(t *testing.T) {
 		assert.True(t, node.LastMarkedForTermination.Add(time.Minute).After(now))
 	})
 
+	t.Run("Node has karpenter disruption taint", func(t *testing.T) {
+		ctx := t.Context()
+		fakeClient := createDefaultFakeClient()
+		dtClient := createDTMockClient(t, "1.2.3.4", "HOST-42")
+		ctrl := createDefaultReconciler(t, fakeClient, dtClient)
+
+		reconcileAllNodes(t, ctrl, fakeClient)
+
+		node1 := &corev1.Node{}
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: "node1"}, node1))
+
+		node1.Spec.Taints = []corev1.Taint{
+			{Key: "karpenter.sh/disrupted", Effect: corev1.TaintEffectNoSchedule},
+		}
+		require.NoError(t, fakeClient.Update(ctx, node1))
+
+		_, err := ctrl.Reconcile(ctx, createReconcileRequest("node1"))
+		require.NoError(t, err)
+
+		c, err := ctrl.getCache(ctx)
+		require.NoError(t, err)
+
+		node, err := c.GetEntry("node1")
+		require.NoError(t, err)
+		assert.False(t, node.LastMarkedForTermination.IsZero())
+	})
+
+	t.Run("Node has taint of disabled preset", func(t *testing.T) {
+		t.Setenv(k8senv.NodeTerminationPresetsEnvVar, ClusterAutoscalerPreset)
+
+		ctx := t.Context()
+		fakeClient := createDefaultFakeClient()
+		hostClient := hostclientmock.NewClient(t)
+		ctrl := createDefaultReconciler(t, fakeClient, &dynatrace.Client{HostEvent: hostClient})
+
+		node1 := &corev1.Node{}
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: "node1"}, node1))
+
+		node1.Spec.Taints = []corev1.Taint{
+			{Key: "karpenter.sh/disrupted", Effect: corev1.TaintEffectNoSchedule},
+		}
+		require.NoError(t, fakeClient.Update(ctx, node1))
+
+		_, err := ctrl.Reconcile(ctx, createReconcileRequest("node1"))
+		require.NoError(t, err)
+
+		c, err := ctrl.getCache(ctx)
+		require.NoError(t, err)
+
+		node, err := c.GetEntry("node1")
+		require.NoError(t, err)
+		assert.True(t, node.LastMarkedForTermination.IsZero())
+	})
+
 	t.Run("Server error when removing node", func(t *testing.T) {
 		ctx := t.Context()
 		fakeClient := createDefaultFakeClient()
@@ -312,6 +403,29 @@ func TestReconcile(t *testing.T) {
 		require.NoError(t, ctrl.pruneCache(ctx, nodesCache))
 	})
 
+	t.Run("Prune reads nodes from the cached client", func(t *testing.T) {
+		ctx := t.Context()
+		fakeClient := createDefaultFakeClient()
+
+		ctrl := createDefaultReconciler(t, fakeClient, nil)
+		ctrl.apiReader = fake.NewClientWithInterceptors(interceptor.Funcs{
+			Get: func(ctx context.Context, clt client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if _, ok := obj.(*corev1.Node); ok {
+					return errors.New("nodes must not be read from the api server")
+				}
+
+				return clt.Get(ctx, key, obj, opts...)
+			},
+		})
+
+		nodesCache, err := cache.New(ctx, fakeClient, testNamespace, nil)
+		require.NoError(t, err)
+		require.NoError(t, nodesCache.SetEntry("node1", cache.Entry{IPAddress: "1.2.3.4", LastSeen: ctrl.timeProvider.Now().UTC()}))
+
+		require.NoError(t, ctrl.pruneCache(ctx, nodesCache))
+		assert.Equal(t, []string{"node1"}, nodesCache.Keys())
+	})
+
 	t.Run("Skip reconcile when platform token is detected", func(t *testing.T) {
 		fakeClient := fake.NewClient(
 			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}},
@@ -434,12 +548,13 @@ func createDefaultReconciler(t *testing.T, fakeClient client.Client, dtClient *d
 	t.Helper()
 
 	return &Controller{
-		client:          fakeClient,
-		apiReader:       fakeClient,
-		dtClientFactory: newClientFactory(dtClient),
-		podNamespace:    testNamespace,
-		runLocal:        true,
-		timeProvider:    timeprovider.New().Freeze(),
+		client:             fakeClient,
+		apiReader:          fakeClient,
+		dtClientFactory:    newClientFactory(dtClient),
+		podNamespace:       testNamespace,
+		runLocal:           true,
+		timeProvider:       timeprovider.New().Freeze(),
+		terminationSignals: NewTerminationSignalsFromEnv(t.Context()),
 	}
 }
 
@@ -7,7 +7,7 @@ import (
 	"context"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
-	"sigs.k8s.io/controller-runtime/pkg/client"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/watchnamespaces"
 )
 
 func (controller *Controller) determineDynakubeForNode(ctx context.Context, nodeName string) (*dynakube.DynaKube, error) {
@@ -22,7 +22,7 @@ func (controller *Controller) determineDynakubeForNode(ctx context.Context, node
 func (controller *Controller) getDynakubeList(ctx context.Context) (*dynakube.DynaKubeList, error) {
 	var dynakubeList dynakube.DynaKubeList
 
-	err := controller.apiReader.List(ctx, &dynakubeList, client.InNamespace(controller.podNamespace))
+	err := watchnamespaces.List(ctx, controller.apiReader, &dynakubeList, controller.podNamespace)
 	if err != nil {
 		return nil, err
 	}
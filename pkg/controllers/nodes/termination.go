@@ -0,0 +1,182 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package nodes
+
+import (
+	"context"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8senv"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	ClusterAutoscalerPreset         = "cluster-autoscaler"
+	KarpenterPreset                 = "karpenter"
+	AWSNodeTerminationHandlerPreset = "aws-node-termination-handler"
+	GKEPreset                       = "gke"
+	AKSPreset                       = "aks"
+
+	// NoPresets disables all presets, only the custom taints, labels and conditions are used.
+	NoPresets = "none"
+)
+
+// TaintSignal matches a taint by key, and by value if Value is not empty.
+type TaintSignal struct {
+	Key   string
+	Value string
+}
+
+// TerminationSignals describes which node taints, labels and conditions indicate that a node is about to be removed from the cluster.
+type TerminationSignals struct {
+	Labels     map[string]string
+	Taints     []TaintSignal
+	Conditions []corev1.NodeConditionType
+}
+
+var terminationPresets = map[string]TerminationSignals{
+	ClusterAutoscalerPreset: {
+		Taints: []TaintSignal{{Key: "ToBeDeletedByClusterAutoscaler"}},
+	},
+	KarpenterPreset: {
+		Taints: []TaintSignal{
+			{Key: "karpenter.sh/disrupted"},
+			{Key: "karpenter.sh/disruption", Value: "disrupting"}, // karpenter < v1
+		},
+	},
+	AWSNodeTerminationHandlerPreset: {
+		Taints: []TaintSignal{
+			{Key: "aws-node-termination-handler/spot-itn"},
+			{Key: "aws-node-termination-handler/asg-lifecycle-termination"},
+			{Key: "aws-node-termination-handler/scheduled-maintenance"},
+		},
+	},
+	GKEPreset: {
+		Taints: []TaintSignal{{Key: "cloud.google.com/impending-node-termination"}},
+	},
+	AKSPreset: {
+		Conditions: []corev1.NodeConditionType{"PreemptScheduled", "TerminateScheduled"},
+	},
+}
+
+// TerminationPresets returns the names of all known presets, sorted.
+func TerminationPresets() []string {
+	return slices.Sorted(maps.Keys(terminationPresets))
+}
+
+// NewTerminationSignalsFromEnv combines the enabled presets with the custom taints, labels and conditions from the environment.
+// If no presets are configured, all known presets are enabled, NoPresets disables all of them.
+func NewTerminationSignalsFromEnv(ctx context.Context) TerminationSignals {
+	log := logd.FromContext(ctx)
+
+	presets := splitList(os.Getenv(k8senv.NodeTerminationPresetsEnvVar))
+
+	switch {
+	case len(presets) == 0:
+		presets = TerminationPresets()
+	case slices.Contains(presets, NoPresets):
+		if len(presets) > 1 {
+			log.Info("node termination presets are disabled, ignoring the other presets", "presets", presets)
+		}
+
+		presets = nil
+	}
+
+	signals := TerminationSignals{Labels: map[string]string{}}
+
+	for _, name := range presets {
+		preset, ok := terminationPresets[name]
+		if !ok {
+			log.Info("unknown node termination preset, ignoring", "preset", name, "known", TerminationPresets())
+
+			continue
+		}
+
+		signals = signals.merge(preset)
+	}
+
+	custom := TerminationSignals{Labels: map[string]string{}}
+
+	for _, taint := range splitList(os.Getenv(k8senv.NodeTerminationTaintsEnvVar)) {
+		key, value, _ := strings.Cut(taint, "=")
+		custom.Taints = append(custom.Taints, TaintSignal{Key: key, Value: value})
+	}
+
+	for _, label := range splitList(os.Getenv(k8senv.NodeTerminationLabelsEnvVar)) {
+		key, value, ok := strings.Cut(label, "=")
+		if !ok {
+			log.Info("invalid node termination label, expected key=value, ignoring", "label", label)
+
+			continue
+		}
+
+		custom.Labels[key] = value
+	}
+
+	for _, condition := range splitList(os.Getenv(k8senv.NodeTerminationConditionsEnvVar)) {
+		custom.Conditions = append(custom.Conditions, corev1.NodeConditionType(condition))
+	}
+
+	return signals.merge(custom)
+}
+
+func (signals TerminationSignals) merge(other TerminationSignals) TerminationSignals {
+	labels := maps.Clone(signals.Labels)
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	maps.Copy(labels, other.Labels)
+
+	return TerminationSignals{
+		Labels:     labels,
+		Taints:     append(slices.Clone(signals.Taints), other.Taints...),
+		Conditions: append(slices.Clone(signals.Conditions), other.Conditions...),
+	}
+}
+
+// IsTerminating checks if the node is cordoned or carries any of the configured termination signals.
+func (signals TerminationSignals) IsTerminating(node *corev1.Node) bool {
+	if node.Spec.Unschedulable {
+		return true
+	}
+
+	for _, taint := range node.Spec.Taints {
+		if slices.ContainsFunc(signals.Taints, func(signal TaintSignal) bool {
+			return signal.Key == taint.Key && (signal.Value == "" || signal.Value == taint.Value)
+		}) {
+			return true
+		}
+	}
+
+	for key, value := range signals.Labels {
+		if nodeValue, ok := node.Labels[key]; ok && nodeValue == value {
+			return true
+		}
+	}
+
+	for _, condition := range node.Status.Conditions {
+		if condition.Status == corev1.ConditionTrue && slices.Contains(signals.Conditions, condition.Type) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func splitList(raw string) []string {
+	var out []string
+
+	for item := range strings.SplitSeq(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,137 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package nodes
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8senv"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewTerminationSignalsFromEnv(t *testing.T) {
+	t.Run("all presets are enabled by default", func(t *testing.T) {
+		signals := NewTerminationSignalsFromEnv(t.Context())
+
+		for _, preset := range terminationPresets {
+			assert.Subset(t, signals.Taints, preset.Taints)
+			assert.Subset(t, signals.Conditions, preset.Conditions)
+		}
+	})
+
+	t.Run("only selected presets, unknown ones are ignored", func(t *testing.T) {
+		t.Setenv(k8senv.NodeTerminationPresetsEnvVar, "karpenter, unknown")
+
+		signals := NewTerminationSignalsFromEnv(t.Context())
+
+		assert.Equal(t, terminationPresets[KarpenterPreset].Taints, signals.Taints)
+		assert.Empty(t, signals.Conditions)
+		assert.Empty(t, signals.Labels)
+	})
+
+	t.Run("presets are disabled with none", func(t *testing.T) {
+		t.Setenv(k8senv.NodeTerminationPresetsEnvVar, NoPresets)
+		t.Setenv(k8senv.NodeTerminationTaintsEnvVar, "example.com/draining")
+
+		signals := NewTerminationSignalsFromEnv(t.Context())
+
+		assert.Equal(t, []TaintSignal{{Key: "example.com/draining"}}, signals.Taints)
+		assert.Empty(t, signals.Conditions)
+		assert.Empty(t, signals.Labels)
+	})
+
+	t.Run("none takes precedence over other presets", func(t *testing.T) {
+		t.Setenv(k8senv.NodeTerminationPresetsEnvVar, "karpenter,none")
+
+		signals := NewTerminationSignalsFromEnv(t.Context())
+
+		assert.Empty(t, signals.Taints)
+		assert.Empty(t, signals.Conditions)
+	})
+
+	t.Run("custom signals are added to presets", func(t *testing.T) {
+		t.Setenv(k8senv.NodeTerminationPresetsEnvVar, ClusterAutoscalerPreset)
+		t.Setenv(k8senv.NodeTerminationTaintsEnvVar, "example.com/draining,example.com/state=terminating")
+		t.Setenv(k8senv.NodeTerminationLabelsEnvVar, "example.com/lifecycle=terminating,invalid")
+		t.Setenv(k8senv.NodeTerminationConditionsEnvVar, "Terminating")
+
+		signals := NewTerminationSignalsFromEnv(t.Context())
+
+		assert.Equal(t, []TaintSignal{
+			{Key: "ToBeDeletedByClusterAutoscaler"},
+			{Key: "example.com/draining"},
+			{Key: "example.com/state", Value: "terminating"},
+		}, signals.Taints)
+		assert.Equal(t, map[string]string{"example.com/lifecycle": "terminating"}, signals.Labels)
+		assert.Equal(t, []corev1.NodeConditionType{"Terminating"}, signals.Conditions)
+	})
+}
+
+func TestIsTerminating(t *testing.T) {
+	signals := TerminationSignals{
+		Taints:     []TaintSignal{{Key: "any-value"}, {Key: "with-value", Value: "yes"}},
+		Labels:     map[string]string{"lifecycle": "terminating"},
+		Conditions: []corev1.NodeConditionType{"TerminateScheduled"},
+	}
+
+	testCases := []struct {
+		name     string
+		node     corev1.Node
+		expected bool
+	}{
+		{
+			name: "healthy node",
+			node: corev1.Node{},
+		},
+		{
+			name:     "cordoned node",
+			node:     corev1.Node{Spec: corev1.NodeSpec{Unschedulable: true}},
+			expected: true,
+		},
+		{
+			name:     "taint matched by key",
+			node:     corev1.Node{Spec: corev1.NodeSpec{Taints: []corev1.Taint{{Key: "any-value", Value: "whatever"}}}},
+			expected: true,
+		},
+		{
+			name:     "taint matched by key and value",
+			node:     corev1.Node{Spec: corev1.NodeSpec{Taints: []corev1.Taint{{Key: "with-value", Value: "yes"}}}},
+			expected: true,
+		},
+		{
+			name: "taint with different value",
+			node: corev1.Node{Spec: corev1.NodeSpec{Taints: []corev1.Taint{{Key: "with-value", Value: "no"}}}},
+		},
+		{
+			name:     "label matched",
+			node:     corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"lifecycle": "terminating"}}},
+			expected: true,
+		},
+		{
+			name: "label with different value",
+			node: corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"lifecycle": "running"}}},
+		},
+		{
+			name: "condition true",
+			node: corev1.Node{Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: "TerminateScheduled", Status: corev1.ConditionTrue},
+			}}},
+			expected: true,
+		},
+		{
+			name: "condition false",
+			node: corev1.Node{Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: "TerminateScheduled", Status: corev1.ConditionFalse},
+			}}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, signals.IsTerminating(&testCase.node))
+		})
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/oci/dockerkeychain"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/oci/registry"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/oci/workloadidentity"
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/pkg/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -41,7 +42,7 @@ func NewImageInstaller(ctx context.Context, props *Properties) (installer.Instal
 		return nil, err
 	}
 
-	keychain, err := dockerkeychain.NewDockerKeychains(ctx, props.APIReader, props.Dynakube.Namespace, props.Dynakube.PullSecretNames())
+	dockerKeychain, err := dockerkeychain.NewDockerKeychains(ctx, props.APIReader, props.Dynakube.Namespace, props.Dynakube.PullSecretNames())
 	if err != nil {
 		return nil, err
 	}
@@ -50,7 +51,7 @@ func NewImageInstaller(ctx context.Context, props *Properties) (installer.Instal
 		extractor: zip.NewOneAgentExtractor(props.PathResolver),
 		props:     props,
 		transport: transport,
-		keychain:  keychain,
+		keychain:  authn.NewMultiKeychain(dockerKeychain, workloadidentity.NewKeychain(props.Dynakube.Spec.WorkloadIdentityRegistries)),
 	}, nil
 }
 
@@ -7,6 +7,8 @@ import (
 	"context"
 	"encoding/json"
 	goerrors "errors"
+	"maps"
+	"slices"
 	"strconv"
 
 	"github.com/Dynatrace/dynatrace-bootstrapper/pkg/configure/enrichment/endpoint"
@@ -81,7 +83,17 @@ func (s *SecretGenerator) reconcileConfig(ctx context.Context, dk *dynakube.Dyna
 		return err
 	}
 
-	return s.createSecretForNSlist(ctx, consts.BootstrapperInitSecretName, ConfigConditionType, namespaces, dk, data)
+	namespacesByProfile := groupNamespacesByPMCProfile(data, namespaces)
+	for _, profile := range slices.Sorted(maps.Keys(namespacesByProfile)) {
+		profileNamespaces := namespacesByProfile[profile]
+
+		err = s.createSecretForNSlist(ctx, consts.BootstrapperInitSecretName, ConfigConditionType, profileNamespaces, dk, getPMCProfileConfigData(data, profile))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (s *SecretGenerator) reconcileCerts(ctx context.Context, dk *dynakube.DynaKube, namespaces []corev1.Namespace) error {
@@ -221,6 +233,10 @@ func (s *SecretGenerator) generateConfig(ctx context.Context, dk *dynakube.DynaK
 
 		if len(pmcSecret) != 0 {
 			data[pmc.InputFileName] = pmcSecret
+
+			if overridesHash := getPMCOverridesHash(dk); overridesHash != "" {
+				annotations[annotationPMCOverridesHash] = overridesHash
+			}
 		}
 
 		if dk.FF().GetAgentInitialConnectRetry(ptr.Deref(dk.Spec.EnableIstio, false)) > -1 {
@@ -229,6 +245,10 @@ func (s *SecretGenerator) generateConfig(ctx context.Context, dk *dynakube.DynaK
 		}
 	}
 
+	if err := addPMCProfiles(dk, data); err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
 	if NeedsPGC(dk) {
 		if err := s.addPGC(ctx, dk, data, annotations); err != nil {
 			return nil, nil, errors.WithStack(err)
@@ -10,11 +10,13 @@ import (
 
 	"github.com/Dynatrace/dynatrace-bootstrapper/pkg/configure/oneagent/pmc"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
 	oneagentclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/oneagent"
 	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/connectioninfo"
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8sconditions"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8ssecret"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -45,6 +47,10 @@ func (s *SecretGenerator) preparePMC(ctx context.Context, dk *dynakube.DynaKube)
 		k8sconditions.SetSecretOutdated(dk.Conditions(), ConfigConditionType, "secret is outdated, update in progress")
 	}
 
+	if pmcSpec := dk.OneAgent().GetProcessModuleConfig(); pmcSpec != nil {
+		addProcessModuleProperties(pmConfig, pmcSpec.Properties)
+	}
+
 	tenantToken, err := k8ssecret.GetDataFromSecretName(ctx, s.apiReader, types.NamespacedName{
 		Name:      dk.OneAgent().GetTenantSecret(),
 		Namespace: dk.Namespace,
@@ -119,6 +125,8 @@ func (s *SecretGenerator) getCachedPMC(ctx context.Context, dk *dynakube.DynaKub
 			k8sconditions.SetKubeAPIError(dk.Conditions(), ConfigConditionType, err)
 
 			return nil, err
+		} else if err == nil && source.Annotations[annotationPMCOverridesHash] != getPMCOverridesHash(dk) {
+			log.Info("process module config overrides changed, ignoring cached ruxitagentproc content")
 		} else if err == nil && source.Data[pmc.InputFileName] != nil {
 			inputData := source.Data[pmc.InputFileName]
 
@@ -146,3 +154,61 @@ func configFromBytes(response []byte) (*oneagentclient.ProcessModuleConfig, erro
 
 	return &resp, nil
 }
+
+// preparePMCProfiles merges the properties of each process module config profile on top of the effective process module config.
+func preparePMCProfiles(dk *dynakube.DynaKube, pmcData []byte) (map[string][]byte, error) {
+	pmcSpec := dk.OneAgent().GetProcessModuleConfig()
+	if pmcSpec == nil || len(pmcSpec.Profiles) == 0 {
+		return nil, nil
+	}
+
+	profiles := make(map[string][]byte, len(pmcSpec.Profiles))
+
+	for _, profile := range pmcSpec.Profiles {
+		pmConfig, err := configFromBytes(pmcData)
+		if err != nil {
+			return nil, err
+		}
+
+		addProcessModuleProperties(pmConfig, profile.Properties)
+		pmConfig.SortPropertiesByKey()
+
+		marshaled, err := json.Marshal(pmConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		profiles[profile.Name] = marshaled
+	}
+
+	return profiles, nil
+}
+
+// addProcessModuleProperties adds the user defined properties to the process module config,
+// the properties managed by the operator are skipped.
+func addProcessModuleProperties(pmConfig *oneagentclient.ProcessModuleConfig, properties []oneagent.ProcessModuleProperty) {
+	for _, property := range properties {
+		if oneagentclient.IsManagedProperty(property.Section, property.Key) {
+			continue
+		}
+
+		pmConfig.Add(oneagentclient.ProcessModuleProperty{
+			Section: property.Section,
+			Key:     property.Key,
+			Value:   property.Value,
+		})
+	}
+}
+
+// getPMCOverridesHash returns the hash of the process module config overrides, so the cached process module config
+// gets replaced once an override is changed or removed.
+func getPMCOverridesHash(dk *dynakube.DynaKube) string {
+	pmcSpec := dk.OneAgent().GetProcessModuleConfig()
+	if pmcSpec == nil || len(pmcSpec.Properties) == 0 {
+		return ""
+	}
+
+	hash, _ := hasher.GenerateHash(pmcSpec.Properties)
+
+	return hash
+}
@@ -140,6 +140,71 @@ func TestPreparePMC(t *testing.T) {
 		assert.Len(t, pmConfig.Properties, 6) // tenantToken, tenantUUID, endpoints, test-property, host-group, proxy
 	})
 
+	t.Run("applies process module config overrides", func(t *testing.T) {
+		dk := &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testDynakube,
+				Namespace: testNamespace,
+			},
+			Spec: dynakube.DynaKubeSpec{
+				APIURL: testAPIurl,
+				OneAgent: oneagent.Spec{
+					CloudNativeFullStack: &oneagent.CloudNativeFullStackSpec{
+						AppInjectionSpec: oneagent.AppInjectionSpec{
+							ProcessModuleConfig: &oneagent.ProcessModuleConfigSpec{
+								Properties: []oneagent.ProcessModuleProperty{
+									{Section: "general", Key: "logLevel", Value: "debug"},
+									{Section: "test", Key: "test"},
+									{Section: "general", Key: "tenant", Value: "other-tenant"},
+									{Section: "agentType", Key: "proxy", Value: "off"},
+								},
+							},
+						},
+					},
+				},
+			},
+			Status: dynakube.DynaKubeStatus{
+				OneAgent: oneagent.Status{
+					ConnectionInfo: communication.ConnectionInfo{
+						TenantUUID: testUUID,
+						Endpoints:  testCommunicationEndpoint,
+					},
+				},
+			},
+		}
+
+		k8sconditions.SetSecretOutdated(dk.Conditions(), ConfigConditionType, "secret is outdated")
+
+		clt := fake.NewClient(
+			dk,
+			clientSecret(dk.OneAgent().GetTenantSecret(), testNamespace, map[string][]byte{
+				connectioninfo.TenantTokenKey: []byte(testTenantToken),
+			}),
+		)
+
+		mockDTClient := oneagentclientmock.NewClient(t)
+		mockDTClient.EXPECT().GetProcessModuleConfig(t.Context()).
+			Return(&oneagentclient.ProcessModuleConfig{Properties: []oneagentclient.ProcessModuleProperty{
+				{Section: "test", Key: "test", Value: "test"},
+				{Section: "agentType", Key: "logLevel", Value: "info"},
+			}}, nil)
+
+		secretGenerator := NewSecretGenerator(clt, clt, mockDTClient)
+
+		result, err := secretGenerator.preparePMC(t.Context(), dk)
+		require.NoError(t, err)
+
+		pmConfig, err := configFromBytes(result)
+		require.NoError(t, err)
+
+		confMap := pmConfig.ToMap()
+		assert.Equal(t, "debug", confMap["general"]["logLevel"])
+		assert.Equal(t, "info", confMap["agentType"]["logLevel"])
+		assert.Equal(t, "off", confMap["agentType"]["proxy"])
+		assert.Equal(t, testUUID, confMap["general"]["tenant"])
+		assert.NotContains(t, confMap, "test")
+	})
+
 	t.Run("error getting PMC from API", func(t *testing.T) {
 		dk := &dynakube.DynaKube{
 			ObjectMeta: metav1.ObjectMeta{
@@ -368,6 +433,45 @@ func TestGetCachedPMC(t *testing.T) {
 		require.NotNil(t, result)
 	})
 
+	t.Run("returns nil when process module config overrides changed", func(t *testing.T) {
+		dk := &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testDynakube,
+				Namespace: testNamespace,
+			},
+			Spec: dynakube.DynaKubeSpec{
+				OneAgent: oneagent.Spec{
+					ApplicationMonitoring: &oneagent.ApplicationMonitoringSpec{
+						AppInjectionSpec: oneagent.AppInjectionSpec{
+							ProcessModuleConfig: &oneagent.ProcessModuleConfigSpec{
+								Properties: []oneagent.ProcessModuleProperty{{Section: "general", Key: "logLevel", Value: "debug"}},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		k8sconditions.SetSecretCreated(dk.Conditions(), ConfigConditionType, "secret created")
+
+		cachedPMCData, _ := json.Marshal(&oneagentclient.ProcessModuleConfig{Properties: []oneagentclient.ProcessModuleProperty{{Section: "test", Key: "test", Value: "test"}}})
+
+		sourceSecret := clientSecret(GetSourceConfigSecretName(dk.Name), testNamespace, map[string][]byte{
+			pmc.InputFileName: cachedPMCData,
+		})
+		sourceSecret.Annotations = map[string]string{annotationPMCOverridesHash: "outdated"}
+
+		clt := fake.NewClient(dk, sourceSecret)
+		mockDTClient := oneagentclientmock.NewClient(t)
+
+		secretGenerator := NewSecretGenerator(clt, clt, mockDTClient)
+
+		result, err := secretGenerator.getCachedPMC(t.Context(), dk)
+
+		require.NoError(t, err)
+		require.Nil(t, result)
+	})
+
 	t.Run("returns nil when source secret not found", func(t *testing.T) {
 		dk := &dynakube.DynaKube{
 			ObjectMeta: metav1.ObjectMeta{
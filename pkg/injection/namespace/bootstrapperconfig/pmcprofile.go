@@ -0,0 +1,117 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstrapperconfig
+
+import (
+	"maps"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-bootstrapper/pkg/configure/oneagent/pmc"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8sconditions"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// AnnotationProcessModuleProfile selects the process module config profile of the DynaKube for the pods of the namespace.
+	AnnotationProcessModuleProfile = "oneagent.dynatrace.com/process-module-profile"
+
+	pmcProfileKeyPrefix = "pmc-profile."
+
+	annotationPMCOverridesHash = api.InternalFlagPrefix + "pmc-overrides-hash"
+)
+
+// addPMCProfiles adds the process module config of every profile to the source data
+// and records the hashes of the effective process module configs in the status.
+func addPMCProfiles(dk *dynakube.DynaKube, data map[string][]byte) error {
+	dk.Status.CodeModules.ProcessModuleConfigHash = ""
+	dk.Status.CodeModules.ProcessModuleProfileHashes = nil
+
+	pmcData := data[pmc.InputFileName]
+	if len(pmcData) == 0 {
+		return nil
+	}
+
+	hash, err := hasher.GenerateSecureHash(pmcData)
+	if err != nil {
+		return err
+	}
+
+	dk.Status.CodeModules.ProcessModuleConfigHash = hash
+
+	profiles, err := preparePMCProfiles(dk, pmcData)
+	if err != nil {
+		k8sconditions.SetSecretGenFailed(dk.Conditions(), ConfigConditionType, err)
+
+		return err
+	}
+
+	if len(profiles) == 0 {
+		return nil
+	}
+
+	dk.Status.CodeModules.ProcessModuleProfileHashes = make(map[string]string, len(profiles))
+
+	for profile, profileData := range profiles {
+		hash, err := hasher.GenerateSecureHash(profileData)
+		if err != nil {
+			return err
+		}
+
+		data[getPMCProfileKey(profile)] = profileData
+		dk.Status.CodeModules.ProcessModuleProfileHashes[profile] = hash
+	}
+
+	return nil
+}
+
+func getPMCProfileKey(profile string) string {
+	return pmcProfileKeyPrefix + profile
+}
+
+// GetNamespaceConfigData returns the bootstrapper config of the namespace, the process module config is replaced by the
+// one of the profile selected by the namespace annotation. The profiles themselves are only kept in the source secret.
+func GetNamespaceConfigData(data map[string][]byte, namespace corev1.Namespace) map[string][]byte {
+	return getPMCProfileConfigData(data, getNamespacePMCProfile(namespace))
+}
+
+func getPMCProfileConfigData(data map[string][]byte, profile string) map[string][]byte {
+	profileData := maps.Clone(data)
+	maps.DeleteFunc(profileData, func(key string, _ []byte) bool {
+		return strings.HasPrefix(key, pmcProfileKeyPrefix)
+	})
+
+	if profile == "" {
+		return profileData
+	}
+
+	if pmcData, ok := data[getPMCProfileKey(profile)]; ok {
+		profileData[pmc.InputFileName] = pmcData
+	}
+
+	return profileData
+}
+
+func getNamespacePMCProfile(namespace corev1.Namespace) string {
+	return namespace.Annotations[AnnotationProcessModuleProfile]
+}
+
+// groupNamespacesByPMCProfile groups the namespaces by their process module config profile,
+// namespaces selecting an unknown profile get the default process module config.
+func groupNamespacesByPMCProfile(data map[string][]byte, namespaces []corev1.Namespace) map[string][]corev1.Namespace {
+	namespacesByProfile := map[string][]corev1.Namespace{"": nil}
+
+	for _, namespace := range namespaces {
+		profile := getNamespacePMCProfile(namespace)
+		if _, ok := data[getPMCProfileKey(profile)]; !ok {
+			profile = ""
+		}
+
+		namespacesByProfile[profile] = append(namespacesByProfile[profile], namespace)
+	}
+
+	return namespacesByProfile
+}
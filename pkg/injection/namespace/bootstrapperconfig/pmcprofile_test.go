@@ -0,0 +1,143 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstrapperconfig
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-bootstrapper/pkg/configure/oneagent/pmc"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	oneagentclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/oneagent"
+	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/token"
+	oneagentclientmock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/clients/dynatrace/oneagent"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const testProfile = "debug"
+
+func TestGetNamespaceConfigData(t *testing.T) {
+	data := map[string][]byte{
+		pmc.InputFileName:             []byte("default"),
+		getPMCProfileKey(testProfile): []byte("profile"),
+		"other":                       []byte("other"),
+	}
+
+	t.Run("namespace without profile gets default config", func(t *testing.T) {
+		namespaceData := GetNamespaceConfigData(data, corev1.Namespace{})
+
+		assert.Equal(t, map[string][]byte{
+			pmc.InputFileName: []byte("default"),
+			"other":           []byte("other"),
+		}, namespaceData)
+	})
+	t.Run("namespace with profile gets the config of the profile", func(t *testing.T) {
+		namespaceData := GetNamespaceConfigData(data, newProfileNamespace(testNamespace, testProfile))
+
+		assert.Equal(t, map[string][]byte{
+			pmc.InputFileName: []byte("profile"),
+			"other":           []byte("other"),
+		}, namespaceData)
+	})
+	t.Run("namespace with unknown profile gets default config", func(t *testing.T) {
+		namespaceData := GetNamespaceConfigData(data, newProfileNamespace(testNamespace, "unknown"))
+
+		assert.Equal(t, []byte("default"), namespaceData[pmc.InputFileName])
+	})
+	t.Run("source data is not modified", func(t *testing.T) {
+		GetNamespaceConfigData(data, newProfileNamespace(testNamespace, testProfile))
+
+		assert.Equal(t, []byte("default"), data[pmc.InputFileName])
+		assert.Contains(t, data, getPMCProfileKey(testProfile))
+	})
+}
+
+func TestGenerateForDynakubeWithPMCProfiles(t *testing.T) {
+	dk := &dynakube.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testDynakube,
+			Namespace: testNamespaceDynatrace,
+		},
+		Spec: dynakube.DynaKubeSpec{
+			APIURL: testAPIurl,
+			OneAgent: oneagent.Spec{
+				ApplicationMonitoring: &oneagent.ApplicationMonitoringSpec{
+					AppInjectionSpec: oneagent.AppInjectionSpec{
+						CodeModulesImage: "codemodules:latest",
+						ProcessModuleConfig: &oneagent.ProcessModuleConfigSpec{
+							Properties: []oneagent.ProcessModuleProperty{{Section: "general", Key: "logLevel", Value: "info"}},
+							Profiles: []oneagent.ProcessModuleProfile{
+								{Name: testProfile, Properties: []oneagent.ProcessModuleProperty{{Section: "general", Key: "logLevel", Value: "debug"}}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	defaultNamespace := clientInjectedNamespace(testNamespace, testDynakube)
+	profileNamespace := clientInjectedNamespace(testNamespace2, testDynakube)
+	profileNamespace.Annotations = map[string]string{AnnotationProcessModuleProfile: testProfile}
+
+	clt := fake.NewClientWithIndex(
+		dk,
+		defaultNamespace,
+		profileNamespace,
+		clientSecret(testDynakube, testNamespaceDynatrace, map[string][]byte{
+			token.APIKey: []byte(testAPIToken),
+		}),
+		clientSecret(dk.OneAgent().GetTenantSecret(), testNamespaceDynatrace, map[string][]byte{
+			"tenant-token": []byte(testTenantToken),
+		}),
+	)
+
+	mockDTClient := oneagentclientmock.NewClient(t)
+	mockDTClient.EXPECT().GetProcessModuleConfig(mock.Anything).Return(&oneagentclient.ProcessModuleConfig{}, nil).Once()
+
+	secretGenerator := NewSecretGenerator(clt, clt, mockDTClient)
+	err := secretGenerator.GenerateForDynakube(t.Context(), dk, []corev1.Namespace{*defaultNamespace, *profileNamespace})
+	require.NoError(t, err)
+
+	var sourceSecret corev1.Secret
+	err = clt.Get(t.Context(), client.ObjectKey{Name: GetSourceConfigSecretName(dk.Name), Namespace: dk.Namespace}, &sourceSecret)
+	require.NoError(t, err)
+	require.Contains(t, sourceSecret.Data, getPMCProfileKey(testProfile))
+
+	assertLogLevel := func(t *testing.T, namespace, logLevel string) {
+		t.Helper()
+
+		var secret corev1.Secret
+		err := clt.Get(t.Context(), client.ObjectKey{Name: consts.BootstrapperInitSecretName, Namespace: namespace}, &secret)
+		require.NoError(t, err)
+		assert.NotContains(t, secret.Data, getPMCProfileKey(testProfile))
+
+		pmConfig, err := configFromBytes(secret.Data[pmc.InputFileName])
+		require.NoError(t, err)
+		assert.Equal(t, logLevel, pmConfig.ToMap()["general"]["logLevel"])
+	}
+
+	assertLogLevel(t, testNamespace, "info")
+	assertLogLevel(t, testNamespace2, "debug")
+
+	assert.NotEmpty(t, dk.Status.CodeModules.ProcessModuleConfigHash)
+	require.Contains(t, dk.Status.CodeModules.ProcessModuleProfileHashes, testProfile)
+	assert.NotEqual(t, dk.Status.CodeModules.ProcessModuleConfigHash, dk.Status.CodeModules.ProcessModuleProfileHashes[testProfile])
+}
+
+func newProfileNamespace(name, profile string) corev1.Namespace {
+	return corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{AnnotationProcessModuleProfile: profile},
+		},
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/k8ssecret"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/watchnamespaces"
 	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/mutator"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -139,7 +140,7 @@ func (dm *DynakubeMapper) MatchingNamespaces() ([]*corev1.Namespace, error) {
 	}
 
 	dkList := &dynakube.DynaKubeList{}
-	if err := dm.apiReader.List(dm.ctx, dkList, &client.ListOptions{Namespace: dm.operatorNs}); err != nil {
+	if err := watchnamespaces.List(dm.ctx, dm.apiReader, dkList, dm.operatorNs); err != nil {
 		return nil, errors.Cause(err)
 	}
 
@@ -149,7 +150,7 @@ func (dm *DynakubeMapper) MatchingNamespaces() ([]*corev1.Namespace, error) {
 // UnmapFromDynaKube removes the injection label from all provided namespaces and deletes the secrets
 func (dm *DynakubeMapper) UnmapFromDynaKube(namespaces []corev1.Namespace) error {
 	for _, ns := range namespaces {
-		delete(ns.Labels, dtwebhook.InjectionInstanceLabel)
+		removeNamespaceInjectLabel(&ns)
 
 		if err := dm.client.Update(dm.ctx, &ns); err != nil {
 			return errors.WithMessagef(err, "failed to remove label %s from namespace %s", dtwebhook.InjectionInstanceLabel, ns.Name)
@@ -210,7 +211,7 @@ func (dm *DynakubeMapper) mapFromDynakube(nsList *corev1.NamespaceList, dkList *
 	replaced := false
 
 	for i := range dkList.Items {
-		if dkList.Items[i].Name == dm.dk.Name {
+		if dkList.Items[i].Name == dm.dk.Name && dkList.Items[i].Namespace == dm.dk.Namespace {
 			dkList.Items[i] = *dm.dk
 			replaced = true
 
@@ -228,7 +229,7 @@ func (dm *DynakubeMapper) mapFromDynakube(nsList *corev1.NamespaceList, dkList *
 	for i := range nsList.Items {
 		namespace := &nsList.Items[i]
 
-		previouslyInjected := namespace.Labels[dtwebhook.InjectionInstanceLabel] == dm.dk.Name
+		previouslyInjected := isAssignedTo(dm.dk, namespace)
 
 		flags := match(dm.dk, namespace, selectors)
 
@@ -231,17 +231,36 @@ func TestUnmapFromDynaKube(t *testing.T) {
 	t.Run("Remove from no ns => no error", func(t *testing.T) {
 		clt := fake.NewClient()
 
-		namespaces, err := GetNamespacesForDynakube(t.Context(), clt, dk.Name)
+		namespaces, err := GetNamespacesForDynakube(t.Context(), clt, dk)
 		require.NoError(t, err)
 
 		dm := NewDynakubeMapper(t.Context(), clt, clt, "dynatrace", dk)
 		err = dm.UnmapFromDynaKube(namespaces)
 		require.NoError(t, err)
 	})
+	t.Run("Namespaces of a same-named DynaKube in another namespace are not listed", func(t *testing.T) {
+		otherDk := createDynakubeWithAppInject(dk.Name, metav1.LabelSelector{})
+		otherDk.Namespace = "other"
+		otherNamespace := createNamespace("ns-other", map[string]string{
+			dtwebhook.InjectionInstanceLabel:          otherDk.Name,
+			dtwebhook.InjectionInstanceNamespaceLabel: otherDk.Namespace,
+		})
+		clt := fake.NewClient(namespace, otherNamespace)
+
+		namespaces, err := GetNamespacesForDynakube(t.Context(), clt, dk)
+		require.NoError(t, err)
+		require.Len(t, namespaces, 1)
+		assert.Equal(t, namespace.Name, namespaces[0].Name)
+
+		namespaces, err = GetNamespacesForDynakube(t.Context(), clt, otherDk)
+		require.NoError(t, err)
+		require.Len(t, namespaces, 1)
+		assert.Equal(t, otherNamespace.Name, namespaces[0].Name)
+	})
 	t.Run("Remove from everywhere, multiple entries", func(t *testing.T) {
 		clt := fake.NewClient(namespace, namespace2)
 
-		namespaces, err := GetNamespacesForDynakube(t.Context(), clt, dk.Name)
+		namespaces, err := GetNamespacesForDynakube(t.Context(), clt, dk)
 		require.NoError(t, err)
 
 		dm := NewDynakubeMapper(t.Context(), clt, clt, "dynatrace", dk)
@@ -260,7 +279,7 @@ func TestUnmapFromDynaKube(t *testing.T) {
 		clt := fake.NewClient(namespace, namespace2)
 		ctx := t.Context()
 
-		namespaces, err := GetNamespacesForDynakube(ctx, clt, dk.Name)
+		namespaces, err := GetNamespacesForDynakube(ctx, clt, dk)
 		require.NoError(t, err)
 
 		createSecret(t, clt, consts.BootstrapperInitSecretName, namespace.Name)
@@ -296,7 +315,7 @@ func TestUnmapFromDynaKube(t *testing.T) {
 		clt := fake.NewClient(ns, ns2)
 		ctx := t.Context()
 
-		namespaces, err := GetNamespacesForDynakube(ctx, clt, dkAppmonImage.Name)
+		namespaces, err := GetNamespacesForDynakube(ctx, clt, dkAppmonImage)
 		require.NoError(t, err)
 
 		var secretNS1 corev1.Secret
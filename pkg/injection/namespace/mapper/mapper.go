@@ -9,11 +9,13 @@ import (
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8senv"
 	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/mutator"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -45,13 +47,32 @@ func (c *ConflictChecker) check(dk *dynakube.DynaKube) error {
 	return nil
 }
 
-func GetNamespacesForDynakube(ctx context.Context, clt client.Reader, dkName string) ([]corev1.Namespace, error) {
+// GetNamespacesForDynakube lists the namespaces labeled for the DynaKube.
+// The namespace of the DynaKube is matched as well, as DynaKubes with the same name can exist in several watched namespaces.
+func GetNamespacesForDynakube(ctx context.Context, clt client.Reader, dk *dynakube.DynaKube) ([]corev1.Namespace, error) {
+	nameRequirement, err := labels.NewRequirement(dtwebhook.InjectionInstanceLabel, selection.Equals, []string{dk.Name})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var namespaceRequirement *labels.Requirement
+
+	if dkNamespace := getNamespaceLabelValue(dk); dkNamespace != "" {
+		namespaceRequirement, err = labels.NewRequirement(dtwebhook.InjectionInstanceNamespaceLabel, selection.Equals, []string{dkNamespace})
+	} else {
+		namespaceRequirement, err = labels.NewRequirement(dtwebhook.InjectionInstanceNamespaceLabel, selection.DoesNotExist, nil)
+	}
+
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
 	nsList := &corev1.NamespaceList{}
 	listOps := []client.ListOption{
-		client.MatchingLabels(map[string]string{dtwebhook.InjectionInstanceLabel: dkName}),
+		client.MatchingLabelsSelector{Selector: labels.NewSelector().Add(*nameRequirement, *namespaceRequirement)},
 	}
 
-	err := clt.List(ctx, nsList, listOps...)
+	err = clt.List(ctx, nsList, listOps...)
 	if err != nil {
 		return nil, err
 	}
@@ -59,12 +80,39 @@ func GetNamespacesForDynakube(ctx context.Context, clt client.Reader, dkName str
 	return nsList.Items, err
 }
 
-func addNamespaceInjectLabel(dkName string, ns *corev1.Namespace) {
+func addNamespaceInjectLabel(dk *dynakube.DynaKube, ns *corev1.Namespace) {
 	if ns.Labels == nil {
 		ns.Labels = make(map[string]string)
 	}
 
-	ns.Labels[dtwebhook.InjectionInstanceLabel] = dkName
+	ns.Labels[dtwebhook.InjectionInstanceLabel] = dk.Name
+
+	if dkNamespace := getNamespaceLabelValue(dk); dkNamespace != "" {
+		ns.Labels[dtwebhook.InjectionInstanceNamespaceLabel] = dkNamespace
+	} else {
+		delete(ns.Labels, dtwebhook.InjectionInstanceNamespaceLabel)
+	}
+}
+
+// getNamespaceLabelValue returns the value of the namespace label for the DynaKube.
+// The label is only needed for DynaKubes outside the operator namespace, the webhook defaults to its own namespace otherwise.
+func getNamespaceLabelValue(dk *dynakube.DynaKube) string {
+	if dk.Namespace == k8senv.DefaultNamespace() {
+		return ""
+	}
+
+	return dk.Namespace
+}
+
+func removeNamespaceInjectLabel(ns *corev1.Namespace) {
+	delete(ns.Labels, dtwebhook.InjectionInstanceLabel)
+	delete(ns.Labels, dtwebhook.InjectionInstanceNamespaceLabel)
+}
+
+// isAssignedTo checks if the namespace is labeled for the DynaKube.
+func isAssignedTo(dk *dynakube.DynaKube, ns *corev1.Namespace) bool {
+	return ns.Labels[dtwebhook.InjectionInstanceLabel] == dk.Name &&
+		ns.Labels[dtwebhook.InjectionInstanceNamespaceLabel] == getNamespaceLabelValue(dk)
 }
 
 type compiledSelectors struct {
@@ -147,20 +195,20 @@ func updateNamespace(ctx context.Context, namespace *corev1.Namespace, deployedD
 }
 
 func updateLabels(ctx context.Context, dk *dynakube.DynaKube, namespace *corev1.Namespace, matches bool) bool {
-	associatedDynakubeName, instanceLabelFound := namespace.Labels[dtwebhook.InjectionInstanceLabel]
+	assigned := isAssignedTo(dk, namespace)
 	updated := false
 
 	if matches {
-		if !instanceLabelFound || associatedDynakubeName != dk.Name {
+		if !assigned {
 			updated = true
 
-			addNamespaceInjectLabel(dk.Name, namespace)
+			addNamespaceInjectLabel(dk, namespace)
 			logd.FromContext(ctx).Info("started monitoring namespace", "namespace", namespace.Name)
 		}
-	} else if instanceLabelFound && associatedDynakubeName == dk.Name {
+	} else if assigned {
 		updated = true
 
-		delete(namespace.Labels, dtwebhook.InjectionInstanceLabel)
+		removeNamespaceInjectLabel(namespace)
 	}
 
 	return updated
@@ -262,6 +262,34 @@ func TestUpdateNamespace(t *testing.T) {
 		assert.Len(t, namespace.Labels, 1)
 	})
 
+	t.Run("Add namespace label for DynaKube outside the operator namespace", func(t *testing.T) {
+		labels := map[string]string{"test": "selector"}
+		dk := createDynakubeWithAppInject("dk-test", convertToLabelSelector(labels))
+		dk.Namespace = "tenant"
+		namespace := createNamespace("test-namespace", labels)
+
+		updated, err := updateNamespace(t.Context(), namespace, &dynakube.DynaKubeList{Items: []dynakube.DynaKube{*dk}})
+
+		require.NoError(t, err)
+		require.True(t, updated)
+		assert.Len(t, namespace.Labels, 3)
+		assert.Equal(t, dk.Name, namespace.Labels[dtwebhook.InjectionInstanceLabel])
+		assert.Equal(t, "tenant", namespace.Labels[dtwebhook.InjectionInstanceNamespaceLabel])
+	})
+	t.Run("Keep label of DynaKube with same name in another namespace", func(t *testing.T) {
+		labels := map[string]string{"test": "selector"}
+		dk := createDynakubeWithAppInject("dk-test", convertToLabelSelector(map[string]string{"other": "selector"}))
+		namespace := createNamespace("test-namespace", labels)
+		namespace.Labels[dtwebhook.InjectionInstanceLabel] = dk.Name
+		namespace.Labels[dtwebhook.InjectionInstanceNamespaceLabel] = "tenant"
+
+		updated, err := updateNamespace(t.Context(), namespace, &dynakube.DynaKubeList{Items: []dynakube.DynaKube{*dk}})
+
+		require.NoError(t, err)
+		require.False(t, updated)
+		assert.Len(t, namespace.Labels, 3)
+	})
+
 	t.Run("Throw error for conflicting OTLP and OneAgent DynaKubes", func(t *testing.T) {
 		labels := map[string]string{"test": "selector"}
 		otlpDK := createDynakubeWithOTLP("otlp-dk", convertToLabelSelector(labels))
@@ -4,6 +4,9 @@
 package k8saffinity
 
 import (
+	"maps"
+	"slices"
+
 	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -51,3 +54,43 @@ func nodeAffinityRequirementsForArches(arches ...string) []corev1.NodeSelectorRe
 		},
 	}
 }
+
+// ExcludeNodeSelectors extends the required node affinity, so nodes that match any of the given node selectors are not selected anymore.
+// A node matches a node selector if it has all of its labels, so excluding it requires at least one label to differ.
+// Node selector terms are ORed, therefore every term is multiplied by the number of labels of each excluded node selector.
+func ExcludeNodeSelectors(affinity *corev1.Affinity, nodeSelectors ...map[string]string) {
+	if affinity.NodeAffinity == nil {
+		affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+
+	if affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+
+	nodeSelector := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(nodeSelector.NodeSelectorTerms) == 0 {
+		nodeSelector.NodeSelectorTerms = []corev1.NodeSelectorTerm{{}}
+	}
+
+	for _, excluded := range nodeSelectors {
+		if len(excluded) == 0 {
+			continue
+		}
+
+		terms := make([]corev1.NodeSelectorTerm, 0, len(nodeSelector.NodeSelectorTerms)*len(excluded))
+
+		for _, term := range nodeSelector.NodeSelectorTerms {
+			for _, key := range slices.Sorted(maps.Keys(excluded)) {
+				newTerm := *term.DeepCopy()
+				newTerm.MatchExpressions = append(newTerm.MatchExpressions, corev1.NodeSelectorRequirement{
+					Key:      key,
+					Operator: corev1.NodeSelectorOpNotIn,
+					Values:   []string{excluded[key]},
+				})
+				terms = append(terms, newTerm)
+			}
+		}
+
+		nodeSelector.NodeSelectorTerms = terms
+	}
+}
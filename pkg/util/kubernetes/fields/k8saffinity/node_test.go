@@ -37,6 +37,41 @@ func Test_nodeAffinityForArches(t *testing.T) {
 	assert.Contains(t, matchExpression, linuxRequirement())
 }
 
+func TestExcludeNodeSelectors(t *testing.T) {
+	notIn := func(key, value string) corev1.NodeSelectorRequirement {
+		return corev1.NodeSelectorRequirement{Key: key, Operator: corev1.NodeSelectorOpNotIn, Values: []string{value}}
+	}
+
+	t.Run("single label is added to the existing term", func(t *testing.T) {
+		affinity := NewMultiArchNodeAffinity()
+		ExcludeNodeSelectors(&affinity, map[string]string{"pool": "gpu"})
+
+		terms := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+		require.Len(t, terms, 1)
+		assert.Contains(t, terms[0].MatchExpressions, linuxRequirement())
+		assert.Contains(t, terms[0].MatchExpressions, notIn("pool", "gpu"))
+	})
+
+	t.Run("multiple labels need a term each", func(t *testing.T) {
+		affinity := NewMultiArchNodeAffinity()
+		ExcludeNodeSelectors(&affinity, map[string]string{"pool": "gpu", "zone": "a"}, map[string]string{"pool": "arm"})
+
+		terms := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+		require.Len(t, terms, 2)
+		assert.Equal(t, []corev1.NodeSelectorRequirement{notIn("pool", "gpu"), notIn("pool", "arm")}, terms[0].MatchExpressions[2:])
+		assert.Equal(t, []corev1.NodeSelectorRequirement{notIn("zone", "a"), notIn("pool", "arm")}, terms[1].MatchExpressions[2:])
+	})
+
+	t.Run("empty affinity", func(t *testing.T) {
+		affinity := corev1.Affinity{}
+		ExcludeNodeSelectors(&affinity, map[string]string{"pool": "gpu"}, nil)
+
+		terms := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+		require.Len(t, terms, 1)
+		assert.Equal(t, []corev1.NodeSelectorRequirement{notIn("pool", "gpu")}, terms[0].MatchExpressions)
+	})
+}
+
 func linuxRequirement() corev1.NodeSelectorRequirement {
 	return corev1.NodeSelectorRequirement{
 		Key:      kubernetesOS,
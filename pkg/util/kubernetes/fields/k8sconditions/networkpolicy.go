@@ -0,0 +1,23 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sconditions
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	NetworkPolicyCreatedOrUpdatedReason = "NetworkPolicyCreatedOrUpdated"
+)
+
+func SetNetworkPolicyCreatedOrUpdated(conditions *[]metav1.Condition, conditionType, name string) {
+	condition := metav1.Condition{
+		Type:    conditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  NetworkPolicyCreatedOrUpdatedReason,
+		Message: appendCreatedOrUpdatedSuffix(name),
+	}
+	_ = meta.SetStatusCondition(conditions, condition)
+}
@@ -33,6 +33,9 @@ const (
 	minDTClientCacheCleanInterval     = 5 * time.Minute
 	maxDTClientCacheCleanInterval     = 100 * time.Hour
 
+	// DTClientRateLimitsEnvVar holds the JSON encoded rate limits of the Dynatrace API client, see middleware.RateLimits
+	DTClientRateLimitsEnvVar = "DT_CLIENT_RATE_LIMITS"
+
 	DTClientConnectionTimeoutEnvVar = "DT_CLIENT_CONNECTION_TIMEOUT"
 	// DefaultCSIDriverDTClientConnectionTimeout enough time to download an OneAgent package of about 1GB in size
 	DefaultCSIDriverDTClientConnectionTimeout = maxDTClientConnectionTimeout
@@ -65,8 +68,20 @@ const (
 	minWebhookCertsRootDuration     = 7 * 24 * time.Hour
 	maxWebhookCertsRootDuration     = 10 * 365 * 24 * time.Hour
 
+	NodeTerminationPresetsEnvVar    = "DT_NODE_TERMINATION_PRESETS"
+	NodeTerminationTaintsEnvVar     = "DT_NODE_TERMINATION_TAINTS"
+	NodeTerminationLabelsEnvVar     = "DT_NODE_TERMINATION_LABELS"
+	NodeTerminationConditionsEnvVar = "DT_NODE_TERMINATION_CONDITIONS"
+
+	// WatchNamespacesEnvVar is a comma separated list of namespaces (in addition to the operator namespace) whose custom resources are reconciled, "*" means all namespaces.
+	WatchNamespacesEnvVar = "DT_WATCH_NAMESPACES"
+
 	WebhookMetadataSizeLimitEnvVar       = "DT_METADATA_SIZE_LIMIT"
 	defaultWebhookMetadataSizeLimitValue = 24 * 1024
+
+	// ClusterDomainEnvVar is the DNS domain of the cluster, used for the fully qualified names of Services.
+	ClusterDomainEnvVar  = "DT_CLUSTER_DOMAIN"
+	defaultClusterDomain = "cluster.local"
 )
 
 func Find(envVars []corev1.EnvVar, name string) *corev1.EnvVar {
@@ -137,6 +152,16 @@ func DefaultNamespace() string {
 	return namespace
 }
 
+func GetClusterDomain() string {
+	domain := strings.Trim(os.Getenv(ClusterDomainEnvVar), ".")
+
+	if domain == "" {
+		return defaultClusterDomain
+	}
+
+	return domain
+}
+
 func GetNodeName() string {
 	return os.Getenv(NodeName)
 }
@@ -153,6 +178,10 @@ func GetDTClientCacheCleanInterval(ctx context.Context) time.Duration {
 	return getSafeDurationFromEnv(ctx, DTClientCacheCleanInterval, defaultDTClientCacheCleanInterval, minDTClientCacheCleanInterval, maxDTClientCacheCleanInterval)
 }
 
+func GetDTClientRateLimits() string {
+	return os.Getenv(DTClientRateLimitsEnvVar)
+}
+
 func GetOperatorDTClientConnectionTimeout(ctx context.Context) time.Duration {
 	return getSafeDurationFromEnv(ctx, DTClientConnectionTimeoutEnvVar, DefaultOperatorDTClientConnectionTimeout, minDTClientConnectionTimeout, maxDTClientConnectionTimeout)
 }
@@ -89,6 +89,17 @@ func TestDefaultNamespace(t *testing.T) {
 	})
 }
 
+func TestGetClusterDomain(t *testing.T) {
+	t.Run("Get from env var", func(t *testing.T) {
+		t.Setenv(ClusterDomainEnvVar, "cluster.example.")
+
+		assert.Equal(t, "cluster.example", GetClusterDomain())
+	})
+	t.Run("Get cluster.local", func(t *testing.T) {
+		assert.Equal(t, "cluster.local", GetClusterDomain())
+	})
+}
+
 func TestAppend(t *testing.T) {
 	t.Run("append new", func(t *testing.T) {
 		envVars := []corev1.EnvVar{{Name: "a", Value: "A"}}
@@ -20,6 +20,8 @@ const (
 	AppComponentLabel    = "app.kubernetes.io/component"
 	AppVersionLabel      = "app.kubernetes.io/version"
 	OperatorVersionLabel = "internal.dynatrace.com/operator-version"
+	NodePoolLabel        = "internal.dynatrace.com/node-pool"
+	ActiveGateGroupLabel = "internal.dynatrace.com/activegate-group"
 
 	OneAgentComponentLabel      = "oneagent"
 	CodeModuleComponentLabel    = "codemodule"
@@ -0,0 +1,289 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package k8spodtemplate
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/podtemplate"
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+const patchDirective = "$patch"
+
+var (
+	// protectedLabelPrefixes are the prefixes of the labels set by the operator, they are used by selectors and to identify the operands.
+	protectedLabelPrefixes = []string{"app.kubernetes.io/", "internal.dynatrace.com/"}
+
+	// protectedAnnotationPrefixes are the prefixes of the annotations set by the operator, e.g. the hashes that trigger restarts.
+	protectedAnnotationPrefixes = []string{api.InternalFlagPrefix}
+
+	// protectedSpecFields can not be set by a patch, the RBAC of the operands depends on them.
+	protectedSpecFields = []string{"serviceAccountName", "serviceAccount"}
+
+	// protectedJSONPatchPathRegex matches the JSON patch paths that would replace or remove operator-owned content.
+	protectedJSONPatchPathRegex = regexp.MustCompile(`^(/metadata(/labels|/annotations)?|/spec(/(serviceAccountName|serviceAccount|containers|initContainers)(/\d+(/image)?)?)?)?$`)
+
+	// containerIndexPathRegex matches the JSON patch paths of single containers, adding to them inserts a new container.
+	containerIndexPathRegex = regexp.MustCompile(`^/spec/(containers|initContainers)/\d+$`)
+)
+
+// Apply applies the patch to the pod template, which was built by the operator.
+// The patched pod template must keep the operator-owned labels, annotations, service account, containers and images.
+func Apply(template *corev1.PodTemplateSpec, patch *podtemplate.Patch) error {
+	if patch == nil || len(patch.Patch.Raw) == 0 {
+		return nil
+	}
+
+	original, err := json.Marshal(template)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	var patchedJSON []byte
+
+	switch patch.GetType() {
+	case podtemplate.JSONPatchType:
+		decoded, err := jsonpatch.DecodePatch(patch.Patch.Raw)
+		if err != nil {
+			return errors.WithMessage(err, "failed to decode JSON pod template patch")
+		}
+
+		patchedJSON, err = decoded.Apply(original)
+		if err != nil {
+			return errors.WithMessage(err, "failed to apply JSON pod template patch")
+		}
+	default:
+		patchedJSON, err = strategicpatch.StrategicMergePatch(original, patch.Patch.Raw, corev1.PodTemplateSpec{})
+		if err != nil {
+			return errors.WithMessage(err, "failed to apply strategic merge pod template patch")
+		}
+	}
+
+	var patched corev1.PodTemplateSpec
+	if err := json.Unmarshal(patchedJSON, &patched); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := checkProtectedFields(template, &patched); err != nil {
+		return err
+	}
+
+	*template = patched
+
+	return nil
+}
+
+// Validate checks that the patch can be decoded and doesn't target operator-owned fields.
+// Changes of the operator's containers can only be detected when the patch is applied.
+func Validate(patch *podtemplate.Patch) error {
+	if patch == nil {
+		return nil
+	}
+
+	if patch.GetType() == podtemplate.JSONPatchType {
+		return validateJSONPatch(patch.Patch.Raw)
+	}
+
+	return validateStrategicMergePatch(patch.Patch.Raw)
+}
+
+func validateJSONPatch(raw []byte) error {
+	var operations []struct {
+		Op   string `json:"op"`
+		Path string `json:"path"`
+		From string `json:"from"`
+	}
+
+	if err := json.Unmarshal(raw, &operations); err != nil {
+		return errors.New("a JSON patch must be a list of operations")
+	}
+
+	if _, err := jsonpatch.DecodePatch(raw); err != nil {
+		return errors.WithStack(err)
+	}
+
+	for _, operation := range operations {
+		if operation.Op == "move" && isProtectedJSONPatchPath(operation.From, "remove") {
+			return errors.Errorf("the operator-owned field %s can not be moved", operation.From)
+		}
+
+		if isProtectedJSONPatchPath(operation.Path, operation.Op) {
+			return errors.Errorf("the operator-owned field %s can not be patched with %s", operation.Path, operation.Op)
+		}
+	}
+
+	return nil
+}
+
+func isProtectedJSONPatchPath(path, op string) bool {
+	if op == "test" {
+		return false
+	}
+
+	if key, ok := strings.CutPrefix(path, "/metadata/labels/"); ok {
+		return hasAnyPrefix(unescapeJSONPointer(key), protectedLabelPrefixes)
+	}
+
+	if key, ok := strings.CutPrefix(path, "/metadata/annotations/"); ok {
+		return hasAnyPrefix(unescapeJSONPointer(key), protectedAnnotationPrefixes)
+	}
+
+	if !protectedJSONPatchPathRegex.MatchString(path) {
+		return false
+	}
+
+	return op != "add" || !containerIndexPathRegex.MatchString(path)
+}
+
+func validateStrategicMergePatch(raw []byte) error {
+	var template map[string]any
+	if err := json.Unmarshal(raw, &template); err != nil {
+		return errors.New("a strategic merge patch must be a partial pod template")
+	}
+
+	if err := checkDirective(template, "the pod template"); err != nil {
+		return err
+	}
+
+	if metadata, ok := template["metadata"].(map[string]any); ok {
+		if err := checkDirective(metadata, "metadata"); err != nil {
+			return err
+		}
+
+		if err := checkProtectedKeys(metadata["labels"], protectedLabelPrefixes, "label"); err != nil {
+			return err
+		}
+
+		if err := checkProtectedKeys(metadata["annotations"], protectedAnnotationPrefixes, "annotation"); err != nil {
+			return err
+		}
+	}
+
+	spec, ok := template["spec"].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	if err := checkDirective(spec, "spec"); err != nil {
+		return err
+	}
+
+	for _, field := range protectedSpecFields {
+		if _, ok := spec[field]; ok {
+			return errors.Errorf("the operator-owned field spec.%s can not be patched", field)
+		}
+	}
+
+	for _, field := range []string{"containers", "initContainers"} {
+		containers, _ := spec[field].([]any)
+		for _, container := range containers {
+			if containerMap, ok := container.(map[string]any); ok && containerMap[patchDirective] != nil {
+				return errors.Errorf("spec.%s can not be replaced or deleted from, only new containers can be added", field)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkDirective rejects $patch directives, which would replace or delete the operator-built content.
+func checkDirective(object map[string]any, name string) error {
+	if directive, ok := object[patchDirective]; ok {
+		return errors.Errorf("the %s directive %v is not allowed for %s", patchDirective, directive, name)
+	}
+
+	return nil
+}
+
+func checkProtectedKeys(object any, prefixes []string, kind string) error {
+	entries, ok := object.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	for key := range entries {
+		if key == patchDirective || hasAnyPrefix(key, prefixes) {
+			return errors.Errorf("the operator-owned %s %s can not be patched", kind, key)
+		}
+	}
+
+	return nil
+}
+
+func checkProtectedFields(original, patched *corev1.PodTemplateSpec) error {
+	if err := checkProtectedEntries(original.Labels, patched.Labels, protectedLabelPrefixes, "label"); err != nil {
+		return err
+	}
+
+	if err := checkProtectedEntries(original.Annotations, patched.Annotations, protectedAnnotationPrefixes, "annotation"); err != nil {
+		return err
+	}
+
+	if original.Spec.ServiceAccountName != patched.Spec.ServiceAccountName {
+		return errors.New("the pod template patch must not change the operator-owned field spec.serviceAccountName")
+	}
+
+	if err := checkContainers(original.Spec.InitContainers, patched.Spec.InitContainers, "spec.initContainers"); err != nil {
+		return err
+	}
+
+	return checkContainers(original.Spec.Containers, patched.Spec.Containers, "spec.containers")
+}
+
+func checkProtectedEntries(original, patched map[string]string, prefixes []string, kind string) error {
+	for key, value := range original {
+		if hasAnyPrefix(key, prefixes) && patched[key] != value {
+			return errors.Errorf("the pod template patch must not change the operator-owned %s %s", kind, key)
+		}
+	}
+
+	for key := range patched {
+		if _, ok := original[key]; !ok && hasAnyPrefix(key, prefixes) {
+			return errors.Errorf("the pod template patch must not add the operator-owned %s %s", kind, key)
+		}
+	}
+
+	return nil
+}
+
+func checkContainers(original, patched []corev1.Container, field string) error {
+	images := make(map[string]string, len(patched))
+	for _, container := range patched {
+		images[container.Name] = container.Image
+	}
+
+	for _, container := range original {
+		image, ok := images[container.Name]
+		if !ok {
+			return errors.Errorf("the pod template patch must not remove the operator-owned container %s from %s", container.Name, field)
+		}
+
+		if image != container.Image {
+			return errors.Errorf("the pod template patch must not change the image of the operator-owned container %s", container.Name)
+		}
+	}
+
+	return nil
+}
+
+func hasAnyPrefix(key string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func unescapeJSONPointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
@@ -0,0 +1,168 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package k8spodtemplate
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/podtemplate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newPatch(patchType podtemplate.PatchType, raw string) *podtemplate.Patch {
+	return &podtemplate.Patch{
+		Type:  patchType,
+		Patch: apiextensionsv1.JSON{Raw: []byte(raw)},
+	}
+}
+
+func newTemplate() *corev1.PodTemplateSpec {
+	return &corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				"app.kubernetes.io/name":      "dynatrace-operator",
+				"app.kubernetes.io/component": "activegate",
+			},
+			Annotations: map[string]string{
+				"internal.operator.dynatrace.com/custom-properties-hash": "hash",
+			},
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: "dynatrace-activegate",
+			InitContainers: []corev1.Container{
+				{Name: "certificate-loader", Image: "activegate:1.0"},
+			},
+			Containers: []corev1.Container{
+				{
+					Name:  "activegate",
+					Image: "activegate:1.0",
+					Env:   []corev1.EnvVar{{Name: "DT_ID_SEED_NAMESPACE", Value: "dynatrace"}},
+				},
+			},
+		},
+	}
+}
+
+func TestApply(t *testing.T) {
+	t.Run("nil patch", func(t *testing.T) {
+		template := newTemplate()
+
+		require.NoError(t, Apply(template, nil))
+		assert.Equal(t, newTemplate(), template)
+	})
+
+	t.Run("strategic merge patch", func(t *testing.T) {
+		template := newTemplate()
+		patch := newPatch("", `{
+			"metadata": {"labels": {"team": "observability"}},
+			"spec": {
+				"runtimeClassName": "gvisor",
+				"hostAliases": [{"ip": "10.0.0.1", "hostnames": ["tenant.local"]}],
+				"containers": [
+					{"name": "activegate", "env": [{"name": "EXTRA", "value": "1"}], "securityContext": {"readOnlyRootFilesystem": true}},
+					{"name": "sidecar", "image": "sidecar:1.0"}
+				]
+			}
+		}`)
+
+		require.NoError(t, Apply(template, patch))
+
+		assert.Equal(t, "observability", template.Labels["team"])
+		assert.Equal(t, "activegate", template.Labels["app.kubernetes.io/component"])
+		assert.Equal(t, "gvisor", *template.Spec.RuntimeClassName)
+		assert.Len(t, template.Spec.HostAliases, 1)
+		require.Len(t, template.Spec.Containers, 2)
+		assert.Equal(t, "activegate:1.0", template.Spec.Containers[0].Image)
+		assert.Len(t, template.Spec.Containers[0].Env, 2)
+		assert.True(t, *template.Spec.Containers[0].SecurityContext.ReadOnlyRootFilesystem)
+		assert.Equal(t, "sidecar", template.Spec.Containers[1].Name)
+	})
+
+	t.Run("json patch", func(t *testing.T) {
+		template := newTemplate()
+		patch := newPatch(podtemplate.JSONPatchType, `[
+			{"op": "add", "path": "/spec/initContainers/-", "value": {"name": "init", "image": "busybox"}},
+			{"op": "add", "path": "/spec/priorityClassName", "value": "high"}
+		]`)
+
+		require.NoError(t, Apply(template, patch))
+
+		require.Len(t, template.Spec.InitContainers, 2)
+		assert.Equal(t, "init", template.Spec.InitContainers[1].Name)
+		assert.Equal(t, "high", template.Spec.PriorityClassName)
+	})
+
+	t.Run("changes of operator-owned fields are rejected", func(t *testing.T) {
+		patches := map[string]*podtemplate.Patch{
+			"image":           newPatch("", `{"spec": {"containers": [{"name": "activegate", "image": "other:1.0"}]}}`),
+			"label":           newPatch("", `{"metadata": {"labels": {"app.kubernetes.io/name": "other"}}}`),
+			"new label":       newPatch("", `{"metadata": {"labels": {"internal.dynatrace.com/node-pool": "other"}}}`),
+			"annotation":      newPatch("", `{"metadata": {"annotations": {"internal.operator.dynatrace.com/custom-properties-hash": null}}}`),
+			"service account": newPatch("", `{"spec": {"serviceAccountName": "default"}}`),
+			"removed init":    newPatch(podtemplate.JSONPatchType, `[{"op": "remove", "path": "/spec/initContainers/0"}]`),
+			"replaced image":  newPatch(podtemplate.JSONPatchType, `[{"op": "replace", "path": "/spec/containers/0/image", "value": "other:1.0"}]`),
+		}
+
+		for name, patch := range patches {
+			t.Run(name, func(t *testing.T) {
+				template := newTemplate()
+
+				require.Error(t, Apply(template, patch))
+				assert.Equal(t, newTemplate(), template)
+			})
+		}
+	})
+
+	t.Run("invalid patch", func(t *testing.T) {
+		require.Error(t, Apply(newTemplate(), newPatch(podtemplate.JSONPatchType, `{"op": "add"}`)))
+		require.Error(t, Apply(newTemplate(), newPatch(podtemplate.JSONPatchType, `[{"op": "replace", "path": "/spec/notExisting/0", "value": 1}]`)))
+		require.Error(t, Apply(newTemplate(), newPatch("", `[]`)))
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("valid patches", func(t *testing.T) {
+		valid := []*podtemplate.Patch{
+			nil,
+			newPatch("", `{"metadata": {"labels": {"team": "a"}}, "spec": {"hostAliases": [{"ip": "10.0.0.1"}], "containers": [{"name": "sidecar", "image": "sidecar"}]}}`),
+			newPatch(podtemplate.JSONPatchType, `[{"op": "add", "path": "/spec/containers/-", "value": {"name": "sidecar"}}]`),
+			newPatch(podtemplate.JSONPatchType, `[{"op": "add", "path": "/spec/initContainers/0", "value": {"name": "first"}}]`),
+			newPatch(podtemplate.JSONPatchType, `[{"op": "add", "path": "/metadata/labels/team", "value": "a"}]`),
+			newPatch(podtemplate.JSONPatchType, `[{"op": "replace", "path": "/spec/containers/0/securityContext", "value": {}}]`),
+			newPatch(podtemplate.JSONPatchType, `[{"op": "test", "path": "/spec/serviceAccountName", "value": "dynatrace"}]`),
+		}
+
+		for _, patch := range valid {
+			assert.NoError(t, Validate(patch))
+		}
+	})
+
+	t.Run("invalid patches", func(t *testing.T) {
+		invalid := map[string]*podtemplate.Patch{
+			"smp not an object":       newPatch("", `[]`),
+			"smp selector label":      newPatch("", `{"metadata": {"labels": {"app.kubernetes.io/component": "x"}}}`),
+			"smp internal annotation": newPatch("", `{"metadata": {"annotations": {"internal.operator.dynatrace.com/x": "x"}}}`),
+			"smp service account":     newPatch("", `{"spec": {"serviceAccountName": "default"}}`),
+			"smp replace spec":        newPatch("", `{"spec": {"$patch": "replace"}}`),
+			"smp replace containers":  newPatch("", `{"spec": {"containers": [{"$patch": "replace"}]}}`),
+			"smp delete container":    newPatch("", `{"spec": {"initContainers": [{"name": "x", "$patch": "delete"}]}}`),
+			"json not a list":         newPatch(podtemplate.JSONPatchType, `{"op": "add"}`),
+			"json remove container":   newPatch(podtemplate.JSONPatchType, `[{"op": "remove", "path": "/spec/containers/0"}]`),
+			"json replace containers": newPatch(podtemplate.JSONPatchType, `[{"op": "add", "path": "/spec/containers", "value": []}]`),
+			"json image":              newPatch(podtemplate.JSONPatchType, `[{"op": "replace", "path": "/spec/containers/1/image", "value": "x"}]`),
+			"json label":              newPatch(podtemplate.JSONPatchType, `[{"op": "remove", "path": "/metadata/labels/app.kubernetes.io~1name"}]`),
+			"json labels":             newPatch(podtemplate.JSONPatchType, `[{"op": "replace", "path": "/metadata/labels", "value": {}}]`),
+			"json move container":     newPatch(podtemplate.JSONPatchType, `[{"op": "move", "from": "/spec/containers/0", "path": "/spec/ephemeralContainers"}]`),
+			"json root":               newPatch(podtemplate.JSONPatchType, `[{"op": "replace", "path": "", "value": {}}]`),
+		}
+
+		for name, patch := range invalid {
+			assert.Error(t, Validate(patch), name)
+		}
+	})
+}
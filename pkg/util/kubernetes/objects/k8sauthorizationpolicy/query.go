@@ -0,0 +1,36 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sauthorizationpolicy
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/internal/query"
+	istiosecurityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type QueryObject struct {
+	query.Generic[*istiosecurityv1beta1.AuthorizationPolicy, *istiosecurityv1beta1.AuthorizationPolicyList]
+}
+
+func Query(kubeClient client.Client, kubeReader client.Reader) QueryObject {
+	return QueryObject{
+		query.Generic[*istiosecurityv1beta1.AuthorizationPolicy, *istiosecurityv1beta1.AuthorizationPolicyList]{
+			Target:     &istiosecurityv1beta1.AuthorizationPolicy{},
+			ListTarget: &istiosecurityv1beta1.AuthorizationPolicyList{},
+			ToList: func(list *istiosecurityv1beta1.AuthorizationPolicyList) []*istiosecurityv1beta1.AuthorizationPolicy {
+				return list.Items
+			},
+			IsEqual:      isEqual,
+			MustRecreate: func(_, _ *istiosecurityv1beta1.AuthorizationPolicy) bool { return false },
+
+			KubeClient: kubeClient,
+			KubeReader: kubeReader,
+		},
+	}
+}
+
+func isEqual(current, desired *istiosecurityv1beta1.AuthorizationPolicy) bool {
+	return !hasher.IsAnnotationDifferent(current, desired)
+}
@@ -20,8 +20,9 @@ const (
 	apiVersion = "apiextensions.k8s.io/v1"
 	kind       = "CustomResourceDefinition"
 
-	DynaKubeName    = "dynakubes.dynatrace.com"
-	EdgeConnectName = "edgeconnects.dynatrace.com"
+	DynaKubeName     = "dynakubes.dynatrace.com"
+	EdgeConnectName  = "edgeconnects.dynatrace.com"
+	DTPrometheusName = "dtprometheuses.dynatrace.com"
 )
 
 // IsLatestVersion checks if the CRD version matches the application version and logs an error if they do not match.
@@ -0,0 +1,33 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sdaemonset
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// AddHashAnnotationIgnoringResources sets the hash annotation of the DaemonSet without taking the resources of its containers into account.
+// It is used if a VerticalPodAutoscaler manages the resources of the pods,
+// so a change of the configured resources doesn't cause a rollout on every node that would be overruled by the VerticalPodAutoscaler anyway.
+func AddHashAnnotationIgnoringResources(ds *appsv1.DaemonSet) error {
+	withoutResources := ds.DeepCopy()
+	for i := range withoutResources.Spec.Template.Spec.Containers {
+		withoutResources.Spec.Template.Spec.Containers[i].Resources = corev1.ResourceRequirements{}
+	}
+
+	objectHash, err := hasher.GenerateHash(withoutResources)
+	if err != nil {
+		return err
+	}
+
+	if ds.Annotations == nil {
+		ds.Annotations = map[string]string{}
+	}
+
+	ds.Annotations[hasher.AnnotationHash] = objectHash
+
+	return nil
+}
@@ -0,0 +1,44 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sdaemonset
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestAddHashAnnotationIgnoringResources(t *testing.T) {
+	newDaemonSet := func(memory, image string) *appsv1.DaemonSet {
+		ds := &appsv1.DaemonSet{}
+		ds.Spec.Template.Spec.Containers = []corev1.Container{
+			{
+				Name:  "main",
+				Image: image,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(memory)},
+				},
+			},
+		}
+
+		return ds
+	}
+
+	small := newDaemonSet("256Mi", "image:1")
+	large := newDaemonSet("1Gi", "image:1")
+	updated := newDaemonSet("256Mi", "image:2")
+
+	for _, ds := range []*appsv1.DaemonSet{small, large, updated} {
+		require.NoError(t, AddHashAnnotationIgnoringResources(ds))
+	}
+
+	assert.False(t, hasher.IsAnnotationDifferent(small, large))
+	assert.True(t, hasher.IsAnnotationDifferent(small, updated))
+	assert.Equal(t, "256Mi", small.Spec.Template.Spec.Containers[0].Resources.Requests.Memory().String())
+}
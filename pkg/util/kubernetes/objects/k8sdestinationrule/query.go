@@ -0,0 +1,36 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sdestinationrule
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/internal/query"
+	istiov1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type QueryObject struct {
+	query.Generic[*istiov1beta1.DestinationRule, *istiov1beta1.DestinationRuleList]
+}
+
+func Query(kubeClient client.Client, kubeReader client.Reader) QueryObject {
+	return QueryObject{
+		query.Generic[*istiov1beta1.DestinationRule, *istiov1beta1.DestinationRuleList]{
+			Target:     &istiov1beta1.DestinationRule{},
+			ListTarget: &istiov1beta1.DestinationRuleList{},
+			ToList: func(list *istiov1beta1.DestinationRuleList) []*istiov1beta1.DestinationRule {
+				return list.Items
+			},
+			IsEqual:      isEqual,
+			MustRecreate: func(_, _ *istiov1beta1.DestinationRule) bool { return false },
+
+			KubeClient: kubeClient,
+			KubeReader: kubeReader,
+		},
+	}
+}
+
+func isEqual(current, desired *istiov1beta1.DestinationRule) bool {
+	return !hasher.IsAnnotationDifferent(current, desired)
+}
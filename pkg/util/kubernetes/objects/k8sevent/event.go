@@ -4,6 +4,8 @@
 package k8sevent
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -13,8 +15,17 @@ const (
 	crdVersionMismatchReason = "CRDVersionMismatch"
 	crdVersionMismatchNote   = "The CustomResourceDefinition doesn't match version with the operator. Please update the CRD to avoid potential issues"
 	crdVersionMismatchAction = "CRDVersionValidation"
+
+	dynatraceAPIThrottledReason = "DynatraceAPIThrottled"
+	dynatraceAPIThrottledNote   = "%d requests to the Dynatrace API were throttled during the reconciliation, %d of them by the server, delaying them by %s in total"
+	dynatraceAPIThrottledAction = "DynatraceAPIRequest"
 )
 
 func SendCRDVersionMismatch(eventRecorder events.EventRecorder, object client.Object) {
 	eventRecorder.Eventf(object, nil, corev1.EventTypeWarning, crdVersionMismatchReason, crdVersionMismatchAction, crdVersionMismatchNote)
 }
+
+// SendDynatraceAPIThrottled reports requests to the Dynatrace API that had to wait for the client side rate limit or were rejected by the server.
+func SendDynatraceAPIThrottled(eventRecorder events.EventRecorder, object client.Object, throttled, serverThrottled int, delay time.Duration) {
+	eventRecorder.Eventf(object, nil, corev1.EventTypeWarning, dynatraceAPIThrottledReason, dynatraceAPIThrottledAction, dynatraceAPIThrottledNote, throttled, serverThrottled, delay.Round(time.Second))
+}
@@ -5,6 +5,7 @@ package k8sevent
 
 import (
 	"testing"
+	"time"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
@@ -82,3 +83,24 @@ func TestSendCRDVersionMismatch(t *testing.T) {
 		}
 	})
 }
+
+func TestSendDynatraceAPIThrottled(t *testing.T) {
+	recorder := events.NewFakeRecorder(10)
+	dk := &dynakube.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-dynakube",
+			Namespace: "dynatrace",
+		},
+	}
+
+	SendDynatraceAPIThrottled(recorder, dk, 3, 1, 7500*time.Millisecond)
+
+	select {
+	case event := <-recorder.Events:
+		assert.Contains(t, event, corev1.EventTypeWarning)
+		assert.Contains(t, event, dynatraceAPIThrottledReason)
+		assert.Contains(t, event, "3 requests to the Dynatrace API were throttled during the reconciliation, 1 of them by the server, delaying them by 8s in total")
+	default:
+		t.Fatal("Expected event to be recorded, but none was found")
+	}
+}
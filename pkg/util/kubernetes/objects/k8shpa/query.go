@@ -0,0 +1,35 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package k8shpa
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/internal/query"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Query(kubeClient client.Client, kubeReader client.Reader) query.Generic[*autoscalingv2.HorizontalPodAutoscaler, *autoscalingv2.HorizontalPodAutoscalerList] {
+	return query.Generic[*autoscalingv2.HorizontalPodAutoscaler, *autoscalingv2.HorizontalPodAutoscalerList]{
+		Target:     &autoscalingv2.HorizontalPodAutoscaler{},
+		ListTarget: &autoscalingv2.HorizontalPodAutoscalerList{},
+		ToList: func(list *autoscalingv2.HorizontalPodAutoscalerList) []*autoscalingv2.HorizontalPodAutoscaler {
+			out := make([]*autoscalingv2.HorizontalPodAutoscaler, len(list.Items))
+			for i, item := range list.Items {
+				out[i] = &item
+			}
+
+			return out
+		},
+		IsEqual:      isEqual,
+		MustRecreate: func(_, _ *autoscalingv2.HorizontalPodAutoscaler) bool { return false },
+
+		KubeClient: kubeClient,
+		KubeReader: kubeReader,
+	}
+}
+
+func isEqual(current, desired *autoscalingv2.HorizontalPodAutoscaler) bool {
+	return !hasher.IsAnnotationDifferent(current, desired)
+}
@@ -0,0 +1,60 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package k8snetworkpolicy
+
+import (
+	"slices"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/internal/builder"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type Option = builder.Option[*networkingv1.NetworkPolicy]
+
+var (
+	// Mandatory fields, provided in constructor as named params
+	setName      = builder.SetName[*networkingv1.NetworkPolicy]
+	setNamespace = builder.SetNamespace[*networkingv1.NetworkPolicy]
+
+	// Optional fields, provided in constructor as list of options
+	SetLabels = builder.SetLabels[*networkingv1.NetworkPolicy]
+)
+
+// Build creates a NetworkPolicy for the pods matching the selector labels.
+// Both ingress and egress are isolated, so only the traffic of the given rules is allowed.
+func Build(owner metav1.Object, name string, selectorLabels map[string]string, options ...Option) (*networkingv1.NetworkPolicy, error) {
+	neededOpts := slices.Concat([]Option{
+		setName(name),
+		setNamespace(owner.GetNamespace()),
+		setPodSelector(selectorLabels),
+		setPolicyTypes(networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress),
+	}, options)
+
+	return builder.Build(owner, &networkingv1.NetworkPolicy{}, neededOpts...)
+}
+
+func setPodSelector(labels map[string]string) Option {
+	return func(np *networkingv1.NetworkPolicy) {
+		np.Spec.PodSelector = metav1.LabelSelector{MatchLabels: labels}
+	}
+}
+
+func setPolicyTypes(policyTypes ...networkingv1.PolicyType) Option {
+	return func(np *networkingv1.NetworkPolicy) {
+		np.Spec.PolicyTypes = policyTypes
+	}
+}
+
+func AddIngressRules(rules ...networkingv1.NetworkPolicyIngressRule) Option {
+	return func(np *networkingv1.NetworkPolicy) {
+		np.Spec.Ingress = append(np.Spec.Ingress, rules...)
+	}
+}
+
+func AddEgressRules(rules ...networkingv1.NetworkPolicyEgressRule) Option {
+	return func(np *networkingv1.NetworkPolicy) {
+		np.Spec.Egress = append(np.Spec.Egress, rules...)
+	}
+}
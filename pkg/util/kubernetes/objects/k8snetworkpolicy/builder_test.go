@@ -0,0 +1,62 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package k8snetworkpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	testDeploymentName    = "deployment-as-owner-of-network-policy"
+	testNetworkPolicyName = "test-network-policy-name"
+	testNamespace         = "test-namespace"
+)
+
+func createDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testDeploymentName,
+			Namespace: testNamespace,
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	labels := map[string]string{"name": "value"}
+
+	t.Run("create network policy", func(t *testing.T) {
+		networkPolicy, err := Build(createDeployment(), testNetworkPolicyName, labels)
+		require.NoError(t, err)
+		require.Len(t, networkPolicy.OwnerReferences, 1)
+		assert.Equal(t, testDeploymentName, networkPolicy.OwnerReferences[0].Name)
+		assert.Equal(t, testNetworkPolicyName, networkPolicy.Name)
+		assert.Equal(t, testNamespace, networkPolicy.Namespace)
+		assert.Empty(t, networkPolicy.Labels)
+		assert.Equal(t, labels, networkPolicy.Spec.PodSelector.MatchLabels)
+		assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress}, networkPolicy.Spec.PolicyTypes)
+		assert.Empty(t, networkPolicy.Spec.Ingress)
+		assert.Empty(t, networkPolicy.Spec.Egress)
+	})
+
+	t.Run("create network policy with labels and rules", func(t *testing.T) {
+		ingress := networkingv1.NetworkPolicyIngressRule{From: []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{MatchLabels: labels}}}}
+		egress := networkingv1.NetworkPolicyEgressRule{}
+
+		networkPolicy, err := Build(createDeployment(), testNetworkPolicyName, labels,
+			SetLabels(labels),
+			AddIngressRules(ingress),
+			AddEgressRules(egress, egress),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, labels, networkPolicy.Labels)
+		assert.Equal(t, []networkingv1.NetworkPolicyIngressRule{ingress}, networkPolicy.Spec.Ingress)
+		assert.Len(t, networkPolicy.Spec.Egress, 2)
+	})
+}
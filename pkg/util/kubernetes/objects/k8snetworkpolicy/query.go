@@ -0,0 +1,35 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package k8snetworkpolicy
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/internal/query"
+	networkingv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Query(kubeClient client.Client, kubeReader client.Reader) query.Generic[*networkingv1.NetworkPolicy, *networkingv1.NetworkPolicyList] {
+	return query.Generic[*networkingv1.NetworkPolicy, *networkingv1.NetworkPolicyList]{
+		Target:     &networkingv1.NetworkPolicy{},
+		ListTarget: &networkingv1.NetworkPolicyList{},
+		ToList: func(list *networkingv1.NetworkPolicyList) []*networkingv1.NetworkPolicy {
+			out := make([]*networkingv1.NetworkPolicy, len(list.Items))
+			for i, item := range list.Items {
+				out[i] = &item
+			}
+
+			return out
+		},
+		IsEqual:      isEqual,
+		MustRecreate: func(_, _ *networkingv1.NetworkPolicy) bool { return false },
+
+		KubeClient: kubeClient,
+		KubeReader: kubeReader,
+	}
+}
+
+func isEqual(current, desired *networkingv1.NetworkPolicy) bool {
+	return !hasher.IsAnnotationDifferent(current, desired)
+}
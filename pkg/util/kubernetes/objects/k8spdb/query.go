@@ -0,0 +1,35 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package k8spdb
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/internal/query"
+	policyv1 "k8s.io/api/policy/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Query(kubeClient client.Client, kubeReader client.Reader) query.Generic[*policyv1.PodDisruptionBudget, *policyv1.PodDisruptionBudgetList] {
+	return query.Generic[*policyv1.PodDisruptionBudget, *policyv1.PodDisruptionBudgetList]{
+		Target:     &policyv1.PodDisruptionBudget{},
+		ListTarget: &policyv1.PodDisruptionBudgetList{},
+		ToList: func(list *policyv1.PodDisruptionBudgetList) []*policyv1.PodDisruptionBudget {
+			out := make([]*policyv1.PodDisruptionBudget, len(list.Items))
+			for i, item := range list.Items {
+				out[i] = &item
+			}
+
+			return out
+		},
+		IsEqual:      isEqual,
+		MustRecreate: func(_, _ *policyv1.PodDisruptionBudget) bool { return false },
+
+		KubeClient: kubeClient,
+		KubeReader: kubeReader,
+	}
+}
+
+func isEqual(current, desired *policyv1.PodDisruptionBudget) bool {
+	return !hasher.IsAnnotationDifferent(current, desired)
+}
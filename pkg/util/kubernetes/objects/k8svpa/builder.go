@@ -0,0 +1,83 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package k8svpa
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/autoscaling"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// UpdateModeOff only lets the VerticalPodAutoscaler compute recommendations.
+	UpdateModeOff = "Off"
+	// UpdateModeRecreate lets the VerticalPodAutoscaler evict pods to apply its recommendations.
+	UpdateModeRecreate = "Recreate"
+
+	controlledValuesRequestsAndLimits = "RequestsAndLimits"
+	allContainers                     = "*"
+)
+
+var GroupVersionKind = schema.GroupVersionKind{Group: "autoscaling.k8s.io", Version: "v1", Kind: "VerticalPodAutoscaler"}
+
+// New creates an empty VerticalPodAutoscaler, e.g. as target for a Get.
+func New() *unstructured.Unstructured {
+	vpa := &unstructured.Unstructured{}
+	vpa.SetGroupVersionKind(GroupVersionKind)
+
+	return vpa
+}
+
+// Build creates a VerticalPodAutoscaler for the DaemonSet with the given name, the bounds are applied to all of its containers.
+func Build(owner metav1.Object, name string, labels map[string]string, daemonSetName string, spec autoscaling.VerticalPodAutoscalerSpec) *unstructured.Unstructured {
+	vpa := New()
+	vpa.SetName(name)
+	vpa.SetNamespace(owner.GetNamespace())
+	vpa.SetLabels(labels)
+
+	updateMode := UpdateModeOff
+	if spec.IsAutoMode() {
+		updateMode = UpdateModeRecreate
+	}
+
+	containerPolicy := map[string]any{
+		"containerName":    allContainers,
+		"controlledValues": controlledValuesRequestsAndLimits,
+	}
+
+	if len(spec.MinAllowed) > 0 {
+		containerPolicy["minAllowed"] = toUnstructured(spec.MinAllowed)
+	}
+
+	if len(spec.MaxAllowed) > 0 {
+		containerPolicy["maxAllowed"] = toUnstructured(spec.MaxAllowed)
+	}
+
+	vpa.Object["spec"] = map[string]any{
+		"targetRef": map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "DaemonSet",
+			"name":       daemonSetName,
+		},
+		"updatePolicy": map[string]any{
+			"updateMode": updateMode,
+		},
+		"resourcePolicy": map[string]any{
+			"containerPolicies": []any{containerPolicy},
+		},
+	}
+
+	return vpa
+}
+
+func toUnstructured(resources corev1.ResourceList) map[string]any {
+	out := make(map[string]any, len(resources))
+	for name, quantity := range resources {
+		out[string(name)] = quantity.String()
+	}
+
+	return out
+}
@@ -0,0 +1,85 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package k8svpa
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/autoscaling"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	testName          = "test-vpa"
+	testNamespace     = "test-namespace"
+	testDaemonSetName = "test-ds"
+)
+
+func TestBuild(t *testing.T) {
+	owner := &dynakube.DynaKube{ObjectMeta: metav1.ObjectMeta{Name: "dk", Namespace: testNamespace}}
+
+	t.Run("recommend mode", func(t *testing.T) {
+		vpa := Build(owner, testName, nil, testDaemonSetName, autoscaling.VerticalPodAutoscalerSpec{})
+
+		assert.Equal(t, GroupVersionKind, vpa.GroupVersionKind())
+		assert.Equal(t, testNamespace, vpa.GetNamespace())
+
+		targetName, _, _ := unstructured.NestedString(vpa.Object, "spec", "targetRef", "name")
+		assert.Equal(t, testDaemonSetName, targetName)
+
+		updateMode, _, _ := unstructured.NestedString(vpa.Object, "spec", "updatePolicy", "updateMode")
+		assert.Equal(t, UpdateModeOff, updateMode)
+	})
+
+	t.Run("auto mode with bounds", func(t *testing.T) {
+		vpa := Build(owner, testName, nil, testDaemonSetName, autoscaling.VerticalPodAutoscalerSpec{
+			Mode:       autoscaling.AutoMode,
+			MinAllowed: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+			MaxAllowed: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+		})
+
+		updateMode, _, _ := unstructured.NestedString(vpa.Object, "spec", "updatePolicy", "updateMode")
+		assert.Equal(t, UpdateModeRecreate, updateMode)
+
+		policies, _, _ := unstructured.NestedSlice(vpa.Object, "spec", "resourcePolicy", "containerPolicies")
+		require.Len(t, policies, 1)
+
+		policy := policies[0].(map[string]any)
+		assert.Equal(t, allContainers, policy["containerName"])
+		assert.Equal(t, map[string]any{"memory": "256Mi"}, policy["minAllowed"])
+		assert.Equal(t, map[string]any{"cpu": "2"}, policy["maxAllowed"])
+	})
+}
+
+func TestQuery(t *testing.T) {
+	owner := &dynakube.DynaKube{ObjectMeta: metav1.ObjectMeta{Name: "dk", Namespace: testNamespace}}
+	clt := fake.NewClient()
+	query := Query(clt, clt).WithOwner(owner)
+
+	created, err := query.CreateOrUpdate(t.Context(), Build(owner, testName, nil, testDaemonSetName, autoscaling.VerticalPodAutoscalerSpec{}))
+	require.NoError(t, err)
+	assert.True(t, created)
+
+	updated, err := query.CreateOrUpdate(t.Context(), Build(owner, testName, nil, testDaemonSetName, autoscaling.VerticalPodAutoscalerSpec{}))
+	require.NoError(t, err)
+	assert.False(t, updated)
+
+	updated, err = query.CreateOrUpdate(t.Context(), Build(owner, testName, nil, testDaemonSetName, autoscaling.VerticalPodAutoscalerSpec{Mode: autoscaling.AutoMode}))
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	vpa := New()
+	require.NoError(t, clt.Get(t.Context(), types.NamespacedName{Name: testName, Namespace: testNamespace}, vpa))
+	updateMode, _, _ := unstructured.NestedString(vpa.Object, "spec", "updatePolicy", "updateMode")
+	assert.Equal(t, UpdateModeRecreate, updateMode)
+	assert.Len(t, vpa.GetOwnerReferences(), 1)
+}
@@ -0,0 +1,41 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package k8svpa
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/objects/internal/query"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type QueryObject = query.Generic[*unstructured.Unstructured, *unstructured.UnstructuredList]
+
+// Query works on unstructured objects, as the VerticalPodAutoscaler is an optional CRD that is not part of the scheme.
+func Query(kubeClient client.Client, kubeReader client.Reader) QueryObject {
+	listTarget := &unstructured.UnstructuredList{}
+	listTarget.SetGroupVersionKind(GroupVersionKind.GroupVersion().WithKind(GroupVersionKind.Kind + "List"))
+
+	return query.Generic[*unstructured.Unstructured, *unstructured.UnstructuredList]{
+		Target:     New(),
+		ListTarget: listTarget,
+		ToList: func(list *unstructured.UnstructuredList) []*unstructured.Unstructured {
+			out := make([]*unstructured.Unstructured, len(list.Items))
+			for i, item := range list.Items {
+				out[i] = &item
+			}
+
+			return out
+		},
+		IsEqual:      isEqual,
+		MustRecreate: func(_, _ *unstructured.Unstructured) bool { return false },
+
+		KubeClient: kubeClient,
+		KubeReader: kubeReader,
+	}
+}
+
+func isEqual(current, desired *unstructured.Unstructured) bool {
+	return !hasher.IsAnnotationDifferent(current, desired)
+}
@@ -0,0 +1,101 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+// Package watchnamespaces resolves the namespaces the operator components watch for custom resources.
+// By default only the operator namespace is watched, DT_WATCH_NAMESPACES adds further namespaces or enables all namespaces with "*".
+package watchnamespaces
+
+import (
+	"context"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8senv"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const AllNamespaces = "*"
+
+// Get returns the sorted list of watched namespaces, always including the operator namespace.
+// A nil result means that all namespaces are watched.
+func Get(operatorNamespace string) []string {
+	namespaces := []string{operatorNamespace}
+
+	for namespace := range strings.SplitSeq(os.Getenv(k8senv.WatchNamespacesEnvVar), ",") {
+		namespace = strings.TrimSpace(namespace)
+
+		switch {
+		case namespace == AllNamespaces:
+			return nil
+		case namespace != "" && !slices.Contains(namespaces, namespace):
+			namespaces = append(namespaces, namespace)
+		}
+	}
+
+	slices.Sort(namespaces)
+
+	return namespaces
+}
+
+// IsAll checks if all namespaces are watched.
+func IsAll(operatorNamespace string) bool {
+	return Get(operatorNamespace) == nil
+}
+
+// Contains checks if the given namespace is watched.
+func Contains(operatorNamespace, namespace string) bool {
+	namespaces := Get(operatorNamespace)
+
+	return namespaces == nil || slices.Contains(namespaces, namespace)
+}
+
+// CacheConfig returns the value for cache.Options.DefaultNamespaces, a nil map lets the cache watch all namespaces.
+func CacheConfig(operatorNamespace string) map[string]cache.Config {
+	namespaces := Get(operatorNamespace)
+	if namespaces == nil {
+		return nil
+	}
+
+	config := make(map[string]cache.Config, len(namespaces))
+	for _, namespace := range namespaces {
+		config[namespace] = cache.Config{}
+	}
+
+	return config
+}
+
+// List lists the objects of all watched namespaces into list.
+// The reader does not need to be cached, in case of a namespace list one request per namespace is made.
+func List(ctx context.Context, reader client.Reader, list client.ObjectList, operatorNamespace string, opts ...client.ListOption) error {
+	namespaces := Get(operatorNamespace)
+	if namespaces == nil {
+		return errors.WithStack(reader.List(ctx, list, opts...))
+	}
+
+	var items []runtime.Object
+
+	for _, namespace := range namespaces {
+		namespaceList, ok := list.DeepCopyObject().(client.ObjectList)
+		if !ok {
+			return errors.Errorf("unexpected list type %T", list)
+		}
+
+		if err := reader.List(ctx, namespaceList, append(slices.Clone(opts), client.InNamespace(namespace))...); err != nil {
+			return errors.WithStack(err)
+		}
+
+		namespaceItems, err := meta.ExtractList(namespaceList)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		items = append(items, namespaceItems...)
+	}
+
+	return errors.WithStack(meta.SetList(list, items))
+}
@@ -0,0 +1,92 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package watchnamespaces
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubernetes/fields/k8senv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+const operatorNamespace = "dynatrace"
+
+func TestGet(t *testing.T) {
+	t.Run("only operator namespace by default", func(t *testing.T) {
+		assert.Equal(t, []string{operatorNamespace}, Get(operatorNamespace))
+		assert.False(t, IsAll(operatorNamespace))
+	})
+
+	t.Run("list of namespaces", func(t *testing.T) {
+		t.Setenv(k8senv.WatchNamespacesEnvVar, " team-b,team-a,, dynatrace,team-a")
+
+		assert.Equal(t, []string{operatorNamespace, "team-a", "team-b"}, Get(operatorNamespace))
+		assert.True(t, Contains(operatorNamespace, "team-a"))
+		assert.False(t, Contains(operatorNamespace, "team-c"))
+	})
+
+	t.Run("all namespaces", func(t *testing.T) {
+		t.Setenv(k8senv.WatchNamespacesEnvVar, "team-a,*")
+
+		assert.Nil(t, Get(operatorNamespace))
+		assert.True(t, IsAll(operatorNamespace))
+		assert.True(t, Contains(operatorNamespace, "team-c"))
+	})
+}
+
+func TestCacheConfig(t *testing.T) {
+	t.Run("namespaces", func(t *testing.T) {
+		t.Setenv(k8senv.WatchNamespacesEnvVar, "team-a")
+
+		assert.Equal(t, map[string]cache.Config{operatorNamespace: {}, "team-a": {}}, CacheConfig(operatorNamespace))
+	})
+
+	t.Run("all namespaces", func(t *testing.T) {
+		t.Setenv(k8senv.WatchNamespacesEnvVar, AllNamespaces)
+
+		assert.Nil(t, CacheConfig(operatorNamespace))
+	})
+}
+
+func TestList(t *testing.T) {
+	clt := fake.NewClient(
+		&dynakube.DynaKube{ObjectMeta: metav1.ObjectMeta{Name: "dk-operator", Namespace: operatorNamespace}},
+		&dynakube.DynaKube{ObjectMeta: metav1.ObjectMeta{Name: "dk-a", Namespace: "team-a"}},
+		&dynakube.DynaKube{ObjectMeta: metav1.ObjectMeta{Name: "dk-b", Namespace: "team-b"}},
+	)
+
+	list := func(t *testing.T) []string {
+		var dkList dynakube.DynaKubeList
+
+		require.NoError(t, List(t.Context(), clt, &dkList, operatorNamespace))
+
+		names := make([]string, 0, len(dkList.Items))
+		for _, dk := range dkList.Items {
+			names = append(names, dk.Name)
+		}
+
+		return names
+	}
+
+	t.Run("operator namespace", func(t *testing.T) {
+		assert.Equal(t, []string{"dk-operator"}, list(t))
+	})
+
+	t.Run("list of namespaces", func(t *testing.T) {
+		t.Setenv(k8senv.WatchNamespacesEnvVar, "team-a")
+
+		assert.ElementsMatch(t, []string{"dk-operator", "dk-a"}, list(t))
+	})
+
+	t.Run("all namespaces", func(t *testing.T) {
+		t.Setenv(k8senv.WatchNamespacesEnvVar, AllNamespaces)
+
+		assert.ElementsMatch(t, []string{"dk-operator", "dk-a", "dk-b"}, list(t))
+	})
+}
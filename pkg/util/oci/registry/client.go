@@ -33,6 +33,11 @@ type ImageGetter interface {
 	PullImageInfo(ctx context.Context, imageName string) (*containerv1.Image, error)
 }
 
+// ImageCopier copies images between registries, e.g. to mirror them for air-gapped clusters.
+type ImageCopier interface {
+	CopyImage(ctx context.Context, src, dst string) (digest.Digest, error)
+}
+
 type ImageVersion struct {
 	Version string
 	Digest  digest.Digest
@@ -79,7 +84,23 @@ func WithTransport(transport *http.Transport) func(*Client) {
 	}
 }
 
+// WithKeychain sets the keychain directly, e.g. authn.DefaultKeychain to use the local docker config.
+func WithKeychain(keychain authn.Keychain) func(*Client) {
+	return func(c *Client) {
+		c.keychain = keychain
+	}
+}
+
 func NewClient(options ...func(*Client)) (ImageGetter, error) {
+	return newClient(options...)
+}
+
+// NewImageCopier creates a Client for copying images, it accepts the same options as NewClient.
+func NewImageCopier(options ...func(*Client)) (ImageCopier, error) {
+	return newClient(options...)
+}
+
+func newClient(options ...func(*Client)) (*Client, error) {
 	var err error
 
 	c := &Client{}
@@ -103,7 +124,10 @@ func NewClient(options ...func(*Client)) (ImageGetter, error) {
 	return c, nil
 }
 
-var _ ClientBuilder = NewClient
+var (
+	_ ClientBuilder = NewClient
+	_ ImageCopier   = &Client{}
+)
 
 func (c *Client) GetImageVersion(ctx context.Context, imageName string) (ImageVersion, error) {
 	ref, err := name.ParseReference(imageName)
@@ -176,6 +200,60 @@ func (c *Client) PullImageInfo(ctx context.Context, imageName string) (*containe
 	return &image, nil
 }
 
+// CopyImage copies the image from src to dst and returns its digest.
+// Image indexes are copied with all their platforms, so the digest stays the same in the target registry.
+func (c *Client) CopyImage(ctx context.Context, src, dst string) (digest.Digest, error) {
+	srcRef, err := name.ParseReference(src)
+	if err != nil {
+		return "", errors.WithMessagef(err, "parsing reference %q", src)
+	}
+
+	dstRef, err := name.ParseReference(dst)
+	if err != nil {
+		return "", errors.WithMessagef(err, "parsing reference %q", dst)
+	}
+
+	options := []remote.Option{
+		remote.WithContext(ctx),
+	}
+	if c.transport != nil {
+		options = append(options, remote.WithTransport(c.transport))
+	}
+
+	if c.keychain != nil {
+		options = append(options, remote.WithAuthFromKeychain(c.keychain))
+	}
+
+	descriptor, err := remote.Get(srcRef, options...)
+	if err != nil {
+		return "", errors.WithMessagef(err, "getting reference %q", srcRef)
+	}
+
+	if descriptor.MediaType.IsIndex() {
+		index, err := descriptor.ImageIndex()
+		if err != nil {
+			return "", errors.WithMessagef(err, "descriptor.ImageIndex()")
+		}
+
+		err = remote.WriteIndex(dstRef, index, options...)
+		if err != nil {
+			return "", errors.WithMessagef(err, "writing index %q", dstRef)
+		}
+	} else {
+		img, err := descriptor.Image()
+		if err != nil {
+			return "", errors.WithMessagef(err, "descriptor.Image()")
+		}
+
+		err = remote.Write(dstRef, img, options...)
+		if err != nil {
+			return "", errors.WithMessagef(err, "writing image %q", dstRef)
+		}
+	}
+
+	return digest.Digest(descriptor.Digest.String()), nil
+}
+
 func BuildImageIDWithTagAndDigest(taggedRef name.Tag, digest digest.Digest) string {
 	return fmt.Sprintf("%s%s%s", taggedRef.String(), DigestDelimiter, digest.String())
 }
@@ -4,14 +4,22 @@
 package registry
 
 import (
+	"io"
+	"log"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/exp"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube/oneagent"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/value"
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -97,3 +105,72 @@ func checkProxyForURL(t *testing.T, transport http.Transport, proxyRawURL, targe
 		require.Nil(t, url)
 	}
 }
+
+func TestCopyImage(t *testing.T) {
+	server := httptest.NewServer(ggcrregistry.New(ggcrregistry.Logger(log.New(io.Discard, "", 0))))
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	clt := &Client{}
+
+	t.Run("copy image", func(t *testing.T) {
+		img, err := random.Image(1024, 1)
+		require.NoError(t, err)
+
+		src := host + "/source/oneagent:1.0.0"
+		require.NoError(t, remote.Write(parseReference(t, src), img))
+
+		expectedDigest, err := img.Digest()
+		require.NoError(t, err)
+
+		dst := host + "/mirror/oneagent:1.0.0"
+		copiedDigest, err := clt.CopyImage(t.Context(), src, dst)
+		require.NoError(t, err)
+		assert.Equal(t, expectedDigest.String(), copiedDigest.String())
+
+		copied, err := remote.Head(parseReference(t, dst))
+		require.NoError(t, err)
+		assert.Equal(t, expectedDigest, copied.Digest)
+	})
+
+	t.Run("copy index keeps digest", func(t *testing.T) {
+		index, err := random.Index(1024, 1, 2)
+		require.NoError(t, err)
+
+		src := host + "/source/activegate:1.0.0"
+		require.NoError(t, remote.WriteIndex(parseReference(t, src), index))
+
+		expectedDigest, err := index.Digest()
+		require.NoError(t, err)
+
+		copiedDigest, err := clt.CopyImage(t.Context(), src, host+"/mirror/activegate:1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, expectedDigest.String(), copiedDigest.String())
+
+		copied, err := remote.Index(parseReference(t, host+"/mirror/activegate@"+copiedDigest.String()))
+		require.NoError(t, err)
+
+		manifest, err := copied.IndexManifest()
+		require.NoError(t, err)
+		assert.Len(t, manifest.Manifests, 2)
+	})
+
+	t.Run("missing source", func(t *testing.T) {
+		_, err := clt.CopyImage(t.Context(), host+"/source/missing:1.0.0", host+"/mirror/missing:1.0.0")
+		require.Error(t, err)
+	})
+
+	t.Run("invalid reference", func(t *testing.T) {
+		_, err := clt.CopyImage(t.Context(), "::invalid", host+"/mirror/missing:1.0.0")
+		require.Error(t, err)
+	})
+}
+
+func parseReference(t *testing.T, ref string) name.Reference {
+	t.Helper()
+
+	parsed, err := name.ParseReference(ref)
+	require.NoError(t, err)
+
+	return parsed
+}
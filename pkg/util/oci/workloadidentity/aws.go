@@ -0,0 +1,324 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package workloadidentity
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	awsRoleARNEnv              = "AWS_ROLE_ARN"
+	awsWebIdentityTokenFileEnv = "AWS_WEB_IDENTITY_TOKEN_FILE"
+	awsRoleSessionNameEnv      = "AWS_ROLE_SESSION_NAME"
+	awsContainerCredentialsEnv = "AWS_CONTAINER_CREDENTIALS_FULL_URI"
+	awsContainerAuthTokenEnv   = "AWS_CONTAINER_AUTHORIZATION_TOKEN_FILE"
+
+	awsDefaultRoleSessionName = "dynatrace-operator"
+	awsECRService             = "ecr"
+	awsECRTarget              = "AmazonEC2ContainerRegistry_V20150921.GetAuthorizationToken"
+	awsSigningAlgorithm       = "AWS4-HMAC-SHA256"
+	awsDateFormat             = "20060102T150405Z"
+	awsShortDateFormat        = "20060102"
+
+	// awsRegistryUsername is the username for registry logins with an ECR authorization token.
+	awsRegistryUsername = "AWS"
+)
+
+// awsRegistryRegex matches private ECR registries, e.g. 123456789012.dkr.ecr.us-east-1.amazonaws.com.
+var awsRegistryRegex = regexp.MustCompile(`^\d{12}\.dkr\.ecr(-fips)?\.([a-z0-9-]+)\.amazonaws\.com(\.cn)?$`)
+
+type awsCredentials struct {
+	accessKeyID     string
+	secretAccessKey string
+	sessionToken    string
+}
+
+// awsProvider gets AWS credentials with EKS Pod Identity or IAM roles for service accounts
+// and uses them to request an ECR authorization token.
+type awsProvider struct {
+	httpClient *http.Client
+	now        func() time.Time
+	stsURL     func(region, domain string) string
+	ecrURL     func(region, domain string) string
+
+	roleARN                string
+	webIdentityTokenFile   string
+	roleSessionName        string
+	containerCredsURL      string
+	containerAuthTokenFile string
+}
+
+func newAWSProvider(httpClient *http.Client) *awsProvider {
+	roleSessionName := os.Getenv(awsRoleSessionNameEnv)
+	if roleSessionName == "" {
+		roleSessionName = awsDefaultRoleSessionName
+	}
+
+	return &awsProvider{
+		httpClient:             httpClient,
+		now:                    time.Now,
+		roleARN:                os.Getenv(awsRoleARNEnv),
+		webIdentityTokenFile:   os.Getenv(awsWebIdentityTokenFileEnv),
+		roleSessionName:        roleSessionName,
+		containerCredsURL:      os.Getenv(awsContainerCredentialsEnv),
+		containerAuthTokenFile: os.Getenv(awsContainerAuthTokenEnv),
+		stsURL: func(region, domain string) string {
+			return "https://sts." + region + "." + domain + "/"
+		},
+		ecrURL: func(region, domain string) string {
+			return "https://api.ecr." + region + "." + domain + "/"
+		},
+	}
+}
+
+func (p *awsProvider) matches(registry string) bool {
+	return awsRegistryRegex.MatchString(registry)
+}
+
+func (p *awsProvider) getCredentials(ctx context.Context, registry string) (Credentials, error) {
+	match := awsRegistryRegex.FindStringSubmatch(registry)
+	if match == nil {
+		return Credentials{}, errors.WithMessage(UnsupportedRegistryError, registry)
+	}
+
+	region := match[2]
+	domain := "amazonaws.com" + match[3]
+
+	creds, err := p.getAWSCredentials(ctx, region, domain)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	return p.getAuthorizationToken(ctx, creds, region, domain)
+}
+
+func (p *awsProvider) getAWSCredentials(ctx context.Context, region, domain string) (awsCredentials, error) {
+	switch {
+	case p.containerCredsURL != "":
+		return p.getPodIdentityCredentials(ctx)
+	case p.roleARN != "" && p.webIdentityTokenFile != "":
+		return p.assumeRoleWithWebIdentity(ctx, region, domain)
+	default:
+		return awsCredentials{}, errors.Errorf("aws workload identity is not configured, either %s or %s and %s must be set", awsContainerCredentialsEnv, awsRoleARNEnv, awsWebIdentityTokenFileEnv)
+	}
+}
+
+// getPodIdentityCredentials gets credentials from the EKS Pod Identity agent.
+func (p *awsProvider) getPodIdentityCredentials(ctx context.Context) (awsCredentials, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.containerCredsURL, nil)
+	if err != nil {
+		return awsCredentials{}, errors.WithStack(err)
+	}
+
+	if p.containerAuthTokenFile != "" {
+		token, err := readTokenFile(p.containerAuthTokenFile)
+		if err != nil {
+			return awsCredentials{}, err
+		}
+
+		req.Header.Set("Authorization", token)
+	}
+
+	var creds struct {
+		AccessKeyID     string `json:"AccessKeyId"`
+		SecretAccessKey string `json:"SecretAccessKey"`
+		Token           string `json:"Token"`
+	}
+
+	if err := doJSON(p.httpClient, req, &creds); err != nil {
+		return awsCredentials{}, err
+	}
+
+	return awsCredentials{
+		accessKeyID:     creds.AccessKeyID,
+		secretAccessKey: creds.SecretAccessKey,
+		sessionToken:    creds.Token,
+	}, nil
+}
+
+// assumeRoleWithWebIdentity exchanges the projected service account token for credentials of the IAM role (IRSA).
+func (p *awsProvider) assumeRoleWithWebIdentity(ctx context.Context, region, domain string) (awsCredentials, error) {
+	token, err := readTokenFile(p.webIdentityTokenFile)
+	if err != nil {
+		return awsCredentials{}, err
+	}
+
+	query := url.Values{
+		"Action":           {"AssumeRoleWithWebIdentity"},
+		"Version":          {"2011-06-15"},
+		"RoleArn":          {p.roleARN},
+		"RoleSessionName":  {p.roleSessionName},
+		"WebIdentityToken": {token},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.stsURL(region, domain), strings.NewReader(query.Encode()))
+	if err != nil {
+		return awsCredentials{}, errors.WithStack(err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return awsCredentials{}, errors.WithStack(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return awsCredentials{}, errors.WithStack(err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return awsCredentials{}, errors.Errorf("request to %s failed with status %d: %s", req.URL.Host, resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Credentials struct {
+			AccessKeyID     string `xml:"AccessKeyId"`
+			SecretAccessKey string `xml:"SecretAccessKey"`
+			SessionToken    string `xml:"SessionToken"`
+		} `xml:"AssumeRoleWithWebIdentityResult>Credentials"`
+	}
+
+	if err := xml.Unmarshal(body, &result); err != nil {
+		return awsCredentials{}, errors.WithStack(err)
+	}
+
+	return awsCredentials{
+		accessKeyID:     result.Credentials.AccessKeyID,
+		secretAccessKey: result.Credentials.SecretAccessKey,
+		sessionToken:    result.Credentials.SessionToken,
+	}, nil
+}
+
+func (p *awsProvider) getAuthorizationToken(ctx context.Context, creds awsCredentials, region, domain string) (Credentials, error) {
+	body := []byte("{}")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.ecrURL(region, domain), bytes.NewReader(body))
+	if err != nil {
+		return Credentials{}, errors.WithStack(err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", awsECRTarget)
+	signRequest(req, body, creds, region, awsECRService, p.now())
+
+	var result struct {
+		AuthorizationData []struct {
+			AuthorizationToken string  `json:"authorizationToken"`
+			ExpiresAt          float64 `json:"expiresAt"`
+		} `json:"authorizationData"`
+	}
+
+	if err := doJSON(p.httpClient, req, &result); err != nil {
+		return Credentials{}, err
+	}
+
+	if len(result.AuthorizationData) == 0 {
+		return Credentials{}, errors.New("ecr returned no authorization data")
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(result.AuthorizationData[0].AuthorizationToken)
+	if err != nil {
+		return Credentials{}, errors.WithStack(err)
+	}
+
+	username, password, ok := strings.Cut(string(decoded), ":")
+	if !ok || username != awsRegistryUsername {
+		return Credentials{}, errors.New("ecr returned a malformed authorization token")
+	}
+
+	return Credentials{
+		Username:  username,
+		Password:  password,
+		ExpiresAt: time.Unix(int64(result.AuthorizationData[0].ExpiresAt), 0),
+	}, nil
+}
+
+// signRequest adds an AWS Signature Version 4 to the request.
+func signRequest(req *http.Request, body []byte, creds awsCredentials, region, service string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format(awsDateFormat)
+	scope := strings.Join([]string{now.Format(awsShortDateFormat), region, service, "aws4_request"}, "/")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	if creds.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.sessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+
+	headerNames := make([]string, 0, len(headers))
+	for name := range headers {
+		headerNames = append(headerNames, name)
+	}
+
+	sort.Strings(headerNames)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range headerNames {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+
+	signedHeaders := strings.Join(headerNames, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hashHex(body),
+	}, "\n")
+
+	stringToSign := strings.Join([]string{awsSigningAlgorithm, amzDate, scope, hashHex([]byte(canonicalRequest))}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+creds.secretAccessKey), now.Format(awsShortDateFormat))
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", awsSigningAlgorithm+" Credential="+creds.accessKeyID+"/"+scope+", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,185 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package workloadidentity
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testECRRegistry = "123456789012.dkr.ecr.eu-west-1.amazonaws.com"
+	testAccessKeyID = "AKIATEST"
+	testSecretKey   = "secret-key"
+	testSession     = "session-token"
+	testECRPassword = "ecr-password"
+)
+
+func TestAWSProviderMatches(t *testing.T) {
+	p := newAWSProvider(http.DefaultClient)
+
+	assert.True(t, p.matches(testECRRegistry))
+	assert.True(t, p.matches("123456789012.dkr.ecr-fips.us-east-1.amazonaws.com"))
+	assert.True(t, p.matches("123456789012.dkr.ecr.cn-north-1.amazonaws.com.cn"))
+	assert.False(t, p.matches("public.ecr.aws"))
+	assert.False(t, p.matches("dkr.ecr.eu-west-1.amazonaws.com"))
+	assert.False(t, p.matches("123456789012.dkr.ecr.eu-west-1.amazonaws.com.evil.io"))
+}
+
+func TestAWSProviderGetCredentials(t *testing.T) {
+	expiresAt := time.Now().Add(12 * time.Hour).Truncate(time.Second)
+
+	t.Run("irsa", func(t *testing.T) {
+		var ecrRequest *http.Request
+
+		sts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.NoError(t, r.ParseForm())
+			assert.Equal(t, "AssumeRoleWithWebIdentity", r.PostForm.Get("Action"))
+			assert.Equal(t, "arn:aws:iam::123456789012:role/dynatrace", r.PostForm.Get("RoleArn"))
+			assert.Equal(t, "web-identity-token", r.PostForm.Get("WebIdentityToken"))
+
+			_, _ = w.Write([]byte(`<AssumeRoleWithWebIdentityResponse><AssumeRoleWithWebIdentityResult><Credentials>` +
+				`<AccessKeyId>` + testAccessKeyID + `</AccessKeyId><SecretAccessKey>` + testSecretKey + `</SecretAccessKey>` +
+				`<SessionToken>` + testSession + `</SessionToken></Credentials></AssumeRoleWithWebIdentityResult></AssumeRoleWithWebIdentityResponse>`))
+		}))
+		defer sts.Close()
+
+		ecr := newECRServer(t, expiresAt, &ecrRequest)
+		defer ecr.Close()
+
+		p := newTestAWSProvider(sts.URL, ecr.URL)
+		p.roleARN = "arn:aws:iam::123456789012:role/dynatrace"
+		p.webIdentityTokenFile = writeTokenFile(t, "web-identity-token")
+
+		credentials, err := p.getCredentials(t.Context(), testECRRegistry)
+		require.NoError(t, err)
+
+		assert.Equal(t, awsRegistryUsername, credentials.Username)
+		assert.Equal(t, testECRPassword, credentials.Password)
+		assert.Equal(t, expiresAt.Unix(), credentials.ExpiresAt.Unix())
+
+		require.NotNil(t, ecrRequest)
+		assert.Equal(t, awsECRTarget, ecrRequest.Header.Get("X-Amz-Target"))
+		assert.Equal(t, testSession, ecrRequest.Header.Get("X-Amz-Security-Token"))
+		assert.True(t, strings.HasPrefix(ecrRequest.Header.Get("Authorization"), awsSigningAlgorithm+" Credential="+testAccessKeyID+"/20240102/eu-west-1/ecr/aws4_request"))
+	})
+
+	t.Run("pod identity", func(t *testing.T) {
+		var ecrRequest *http.Request
+
+		agent := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "pod-identity-token", r.Header.Get("Authorization"))
+
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"AccessKeyId":     testAccessKeyID,
+				"SecretAccessKey": testSecretKey,
+				"Token":           testSession,
+			})
+		}))
+		defer agent.Close()
+
+		ecr := newECRServer(t, expiresAt, &ecrRequest)
+		defer ecr.Close()
+
+		p := newTestAWSProvider("", ecr.URL)
+		p.containerCredsURL = agent.URL
+		p.containerAuthTokenFile = writeTokenFile(t, "pod-identity-token")
+
+		credentials, err := p.getCredentials(t.Context(), testECRRegistry)
+		require.NoError(t, err)
+
+		assert.Equal(t, testECRPassword, credentials.Password)
+		assert.Equal(t, testSession, ecrRequest.Header.Get("X-Amz-Security-Token"))
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		p := newTestAWSProvider("", "")
+
+		_, err := p.getCredentials(t.Context(), testECRRegistry)
+		require.Error(t, err)
+	})
+
+	t.Run("ecr error", func(t *testing.T) {
+		ecr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer ecr.Close()
+
+		agent := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{}`))
+		}))
+		defer agent.Close()
+
+		p := newTestAWSProvider("", ecr.URL)
+		p.containerCredsURL = agent.URL
+
+		_, err := p.getCredentials(t.Context(), testECRRegistry)
+		require.Error(t, err)
+	})
+}
+
+func TestSignRequest(t *testing.T) {
+	// values of the get-vanilla example of the AWS Signature Version 4 test suite
+	req, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	require.NoError(t, err)
+
+	creds := awsCredentials{
+		accessKeyID:     "AKIDEXAMPLE",
+		secretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}
+
+	signRequest(req, nil, creds, "us-east-1", "service", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+		"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31", req.Header.Get("Authorization"))
+}
+
+func newTestAWSProvider(stsURL, ecrURL string) *awsProvider {
+	p := newAWSProvider(http.DefaultClient)
+	p.roleARN = ""
+	p.webIdentityTokenFile = ""
+	p.containerCredsURL = ""
+	p.containerAuthTokenFile = ""
+	p.now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+	p.stsURL = func(_, _ string) string { return stsURL }
+	p.ecrURL = func(_, _ string) string { return ecrURL }
+
+	return p
+}
+
+func newECRServer(t *testing.T, expiresAt time.Time, request **http.Request) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*request = r
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"authorizationData": []map[string]any{
+				{
+					"authorizationToken": base64.StdEncoding.EncodeToString([]byte(awsRegistryUsername + ":" + testECRPassword)),
+					"expiresAt":          float64(expiresAt.Unix()),
+				},
+			},
+		})
+	}))
+}
+
+func writeTokenFile(t *testing.T, token string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte(token+"\n"), 0600))
+
+	return path
+}
@@ -0,0 +1,184 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package workloadidentity
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	azureClientIDEnv           = "AZURE_CLIENT_ID"
+	azureTenantIDEnv           = "AZURE_TENANT_ID"
+	azureFederatedTokenFileEnv = "AZURE_FEDERATED_TOKEN_FILE"
+	azureAuthorityHostEnv      = "AZURE_AUTHORITY_HOST"
+
+	azureDefaultAuthorityHost = "https://login.microsoftonline.com/"
+	azureRegistryScope        = "https://containerregistry.azure.net/.default"
+	azureClientAssertionType  = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+	// azureRegistryUsername is the username for registry logins with an ACR refresh token.
+	azureRegistryUsername = "00000000-0000-0000-0000-000000000000"
+
+	// azureDefaultRefreshTokenLifetime is used, if the expiry of the ACR refresh token can not be read from it.
+	azureDefaultRefreshTokenLifetime = 3 * time.Hour
+)
+
+var azureRegistrySuffixes = []string{".azurecr.io", ".azurecr.cn", ".azurecr.de", ".azurecr.us"}
+
+// azureProvider exchanges the federated token of Azure Workload Identity for an Entra ID access token,
+// which is then exchanged for an ACR refresh token.
+type azureProvider struct {
+	httpClient    *http.Client
+	exchangeURL   func(registry string) string
+	clientID      string
+	tenantID      string
+	tokenFile     string
+	authorityHost string
+}
+
+func newAzureProvider(httpClient *http.Client) *azureProvider {
+	authorityHost := os.Getenv(azureAuthorityHostEnv)
+	if authorityHost == "" {
+		authorityHost = azureDefaultAuthorityHost
+	}
+
+	return &azureProvider{
+		httpClient:    httpClient,
+		clientID:      os.Getenv(azureClientIDEnv),
+		tenantID:      os.Getenv(azureTenantIDEnv),
+		tokenFile:     os.Getenv(azureFederatedTokenFileEnv),
+		authorityHost: strings.TrimSuffix(authorityHost, "/") + "/",
+		exchangeURL: func(registry string) string {
+			return "https://" + registry + "/oauth2/exchange"
+		},
+	}
+}
+
+func (p *azureProvider) matches(registry string) bool {
+	for _, suffix := range azureRegistrySuffixes {
+		if strings.HasSuffix(registry, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *azureProvider) getCredentials(ctx context.Context, registry string) (Credentials, error) {
+	if p.clientID == "" || p.tenantID == "" || p.tokenFile == "" {
+		return Credentials{}, errors.Errorf("azure workload identity is not configured, %s, %s and %s must be set", azureClientIDEnv, azureTenantIDEnv, azureFederatedTokenFileEnv)
+	}
+
+	accessToken, err := p.getAccessToken(ctx)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	refreshToken, err := p.exchangeAccessToken(ctx, registry, accessToken)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	expiresAt, ok := getJWTExpiry(refreshToken)
+	if !ok {
+		expiresAt = time.Now().Add(azureDefaultRefreshTokenLifetime)
+	}
+
+	return Credentials{
+		Username:  azureRegistryUsername,
+		Password:  refreshToken,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (p *azureProvider) getAccessToken(ctx context.Context) (string, error) {
+	assertion, err := readTokenFile(p.tokenFile)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"client_id":             {p.clientID},
+		"scope":                 {azureRegistryScope},
+		"grant_type":            {"client_credentials"},
+		"client_assertion_type": {azureClientAssertionType},
+		"client_assertion":      {assertion},
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+
+	if err := p.postForm(ctx, p.authorityHost+p.tenantID+"/oauth2/v2.0/token", form, &token); err != nil {
+		return "", err
+	}
+
+	return token.AccessToken, nil
+}
+
+func (p *azureProvider) exchangeAccessToken(ctx context.Context, registry, accessToken string) (string, error) {
+	form := url.Values{
+		"grant_type":   {"access_token"},
+		"service":      {registry},
+		"tenant":       {p.tenantID},
+		"access_token": {accessToken},
+	}
+
+	var token struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+
+	if err := p.postForm(ctx, p.exchangeURL(registry), form, &token); err != nil {
+		return "", err
+	}
+
+	if token.RefreshToken == "" {
+		return "", errors.New("registry returned no refresh token")
+	}
+
+	return token.RefreshToken, nil
+}
+
+func (p *azureProvider) postForm(ctx context.Context, endpoint string, form url.Values, target any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return doJSON(p.httpClient, req, target)
+}
+
+// getJWTExpiry reads the exp claim of a JWT without verifying it.
+func getJWTExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 { //nolint:mnd
+		return time.Time{}, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}, false
+	}
+
+	return time.Unix(claims.Exp, 0), true
+}
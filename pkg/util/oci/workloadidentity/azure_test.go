@@ -0,0 +1,100 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package workloadidentity
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testACRRegistry = "dynatrace.azurecr.io"
+
+func TestAzureProviderMatches(t *testing.T) {
+	p := newAzureProvider(http.DefaultClient)
+
+	assert.True(t, p.matches(testACRRegistry))
+	assert.True(t, p.matches("dynatrace.azurecr.cn"))
+	assert.False(t, p.matches("azurecr.io.evil.io"))
+}
+
+func TestAzureProviderGetCredentials(t *testing.T) {
+	expiresAt := time.Now().Add(3 * time.Hour).Truncate(time.Second)
+	refreshToken := "header." + base64.RawURLEncoding.EncodeToString([]byte(`{"exp":`+strconv.FormatInt(expiresAt.Unix(), 10)+`}`)) + ".signature"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+
+		switch r.URL.Path {
+		case "/tenant-id/oauth2/v2.0/token":
+			assert.Equal(t, "client-id", r.PostForm.Get("client_id"))
+			assert.Equal(t, azureRegistryScope, r.PostForm.Get("scope"))
+			assert.Equal(t, "federated-token", r.PostForm.Get("client_assertion"))
+
+			_, _ = w.Write([]byte(`{"access_token":"aad-token"}`))
+		case "/oauth2/exchange":
+			assert.Equal(t, "aad-token", r.PostForm.Get("access_token"))
+			assert.Equal(t, testACRRegistry, r.PostForm.Get("service"))
+
+			_, _ = w.Write([]byte(`{"refresh_token":"` + refreshToken + `"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	newTestProvider := func() *azureProvider {
+		p := newAzureProvider(http.DefaultClient)
+		p.clientID = "client-id"
+		p.tenantID = "tenant-id"
+		p.tokenFile = writeTokenFile(t, "federated-token")
+		p.authorityHost = server.URL + "/"
+		p.exchangeURL = func(string) string { return server.URL + "/oauth2/exchange" }
+
+		return p
+	}
+
+	t.Run("refresh token from exchange", func(t *testing.T) {
+		credentials, err := newTestProvider().getCredentials(t.Context(), testACRRegistry)
+		require.NoError(t, err)
+
+		assert.Equal(t, azureRegistryUsername, credentials.Username)
+		assert.Equal(t, refreshToken, credentials.Password)
+		assert.Equal(t, expiresAt.Unix(), credentials.ExpiresAt.Unix())
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		p := newTestProvider()
+		p.clientID = ""
+
+		_, err := p.getCredentials(t.Context(), testACRRegistry)
+		require.Error(t, err)
+	})
+
+	t.Run("token file missing", func(t *testing.T) {
+		p := newTestProvider()
+		p.tokenFile = "/not/existing"
+
+		_, err := p.getCredentials(t.Context(), testACRRegistry)
+		require.Error(t, err)
+	})
+}
+
+func TestGetJWTExpiry(t *testing.T) {
+	_, ok := getJWTExpiry("not-a-jwt")
+	assert.False(t, ok)
+
+	_, ok = getJWTExpiry("a." + base64.RawURLEncoding.EncodeToString([]byte(`{}`)) + ".c")
+	assert.False(t, ok)
+
+	expiresAt, ok := getJWTExpiry("a." + base64.RawURLEncoding.EncodeToString([]byte(`{"exp":1700000000}`)) + ".c")
+	assert.True(t, ok)
+	assert.Equal(t, int64(1700000000), expiresAt.Unix())
+}
@@ -0,0 +1,148 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package workloadidentity
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const defaultHTTPTimeout = 30 * time.Second
+
+var (
+	UnsupportedRegistryError = errors.New("registry is not supported by workload identity")
+
+	// sharedResolver is used by all keychains of the process, so the cached credentials are reused across reconciliations.
+	sharedResolver = sync.OnceValue(NewResolver)
+)
+
+// Credentials are short-lived registry credentials obtained with the workload identity.
+type Credentials struct {
+	ExpiresAt time.Time
+	Username  string
+	Password  string
+}
+
+// provider exchanges the workload identity of a cloud for credentials of its container registry.
+type provider interface {
+	// matches reports whether the registry belongs to the cloud of the provider.
+	matches(registry string) bool
+	getCredentials(ctx context.Context, registry string) (Credentials, error)
+}
+
+// RefreshAt returns the time at which credentials obtained at the given time should be replaced,
+// which is after half of their lifetime, so anything using them has enough time to pick up new ones.
+func (c Credentials) RefreshAt(obtainedAt time.Time) time.Time {
+	return obtainedAt.Add(c.ExpiresAt.Sub(obtainedAt) / 2) //nolint:mnd
+}
+
+type cachedCredentials struct {
+	refreshAt   time.Time
+	credentials Credentials
+}
+
+// Resolver gets registry credentials from the provider of the registry and caches them for half of their lifetime.
+type Resolver struct {
+	cache     map[string]cachedCredentials
+	now       func() time.Time
+	providers []provider
+	mutex     sync.Mutex
+}
+
+func NewResolver() *Resolver {
+	httpClient := &http.Client{Timeout: defaultHTTPTimeout}
+
+	return newResolver(newAWSProvider(httpClient), newGCPProvider(httpClient), newAzureProvider(httpClient))
+}
+
+func newResolver(providers ...provider) *Resolver {
+	return &Resolver{
+		cache:     map[string]cachedCredentials{},
+		now:       time.Now,
+		providers: providers,
+	}
+}
+
+// IsSupportedRegistry reports whether credentials for the registry can be obtained with a workload identity,
+// i.e. if it is an Amazon ECR, Google Container Registry/Artifact Registry or Azure Container Registry.
+func IsSupportedRegistry(registry string) bool {
+	return sharedResolver().getProvider(registry) != nil
+}
+
+// GetCredentials returns the credentials for the registry, cached credentials are reused for half of their lifetime.
+func (r *Resolver) GetCredentials(ctx context.Context, registry string) (Credentials, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := r.now()
+
+	if cached, ok := r.cache[registry]; ok && now.Before(cached.refreshAt) {
+		return cached.credentials, nil
+	}
+
+	p := r.getProvider(registry)
+	if p == nil {
+		return Credentials{}, errors.WithMessage(UnsupportedRegistryError, registry)
+	}
+
+	credentials, err := p.getCredentials(ctx, registry)
+	if err != nil {
+		return Credentials{}, errors.WithMessagef(err, "failed to get workload identity credentials for registry %s", registry)
+	}
+
+	r.cache[registry] = cachedCredentials{
+		credentials: credentials,
+		refreshAt:   credentials.RefreshAt(now),
+	}
+
+	return credentials, nil
+}
+
+func (r *Resolver) getProvider(registry string) provider {
+	for _, p := range r.providers {
+		if p.matches(registry) {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// readTokenFile reads a projected service account token, which is rotated by the kubelet, so it is read on every use.
+func readTokenFile(path string) (string, error) {
+	token, err := os.ReadFile(path)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return strings.TrimSpace(string(token)), nil
+}
+
+// doJSON sends the request and decodes the JSON response into the target.
+func doJSON(httpClient *http.Client, req *http.Request, target any) error {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("request to %s failed with status %d: %s", req.URL.Host, resp.StatusCode, string(body))
+	}
+
+	return errors.WithStack(json.Unmarshal(body, target))
+}
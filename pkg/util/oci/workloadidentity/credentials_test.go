@@ -0,0 +1,88 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package workloadidentity
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeProvider struct {
+	err         error
+	credentials Credentials
+	calls       int
+}
+
+func (p *fakeProvider) matches(registry string) bool {
+	return strings.HasSuffix(registry, ".fake.io")
+}
+
+func (p *fakeProvider) getCredentials(_ context.Context, _ string) (Credentials, error) {
+	p.calls++
+
+	return p.credentials, p.err
+}
+
+func TestIsSupportedRegistry(t *testing.T) {
+	assert.True(t, IsSupportedRegistry(testECRRegistry))
+	assert.True(t, IsSupportedRegistry("europe-docker.pkg.dev"))
+	assert.True(t, IsSupportedRegistry(testACRRegistry))
+	assert.False(t, IsSupportedRegistry("docker.io"))
+	assert.False(t, IsSupportedRegistry("abc123.live.dynatrace.com"))
+}
+
+func TestResolverGetCredentials(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("credentials are cached for half of their lifetime", func(t *testing.T) {
+		p := &fakeProvider{credentials: Credentials{Username: "user", Password: "pw", ExpiresAt: now.Add(time.Hour)}}
+		r := newResolver(p)
+		r.now = func() time.Time { return now }
+
+		credentials, err := r.GetCredentials(t.Context(), "registry.fake.io")
+		require.NoError(t, err)
+		assert.Equal(t, "pw", credentials.Password)
+
+		_, err = r.GetCredentials(t.Context(), "registry.fake.io")
+		require.NoError(t, err)
+		assert.Equal(t, 1, p.calls)
+
+		r.now = func() time.Time { return now.Add(29 * time.Minute) }
+
+		_, err = r.GetCredentials(t.Context(), "registry.fake.io")
+		require.NoError(t, err)
+		assert.Equal(t, 1, p.calls)
+
+		r.now = func() time.Time { return now.Add(30 * time.Minute) }
+
+		_, err = r.GetCredentials(t.Context(), "registry.fake.io")
+		require.NoError(t, err)
+		assert.Equal(t, 2, p.calls)
+	})
+
+	t.Run("unsupported registry", func(t *testing.T) {
+		r := newResolver(&fakeProvider{})
+
+		_, err := r.GetCredentials(t.Context(), "docker.io")
+		require.ErrorIs(t, err, UnsupportedRegistryError)
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		p := &fakeProvider{err: errors.New("boom")}
+		r := newResolver(p)
+
+		_, err := r.GetCredentials(t.Context(), "registry.fake.io")
+		require.Error(t, err)
+
+		_, err = r.GetCredentials(t.Context(), "registry.fake.io")
+		require.Error(t, err)
+		assert.Equal(t, 2, p.calls)
+	})
+}
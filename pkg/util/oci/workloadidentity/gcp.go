@@ -0,0 +1,74 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package workloadidentity
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	gcpMetadataHostEnv     = "GCE_METADATA_HOST"
+	gcpDefaultMetadataHost = "metadata.google.internal"
+	gcpTokenPath           = "/computeMetadata/v1/instance/service-accounts/default/token"
+
+	// gcpRegistryUsername is the username for registry logins with an OAuth access token.
+	gcpRegistryUsername = "oauth2accesstoken"
+)
+
+// gcpProvider gets an access token of the Kubernetes service account bound to a Google service account
+// from the GKE metadata server, it is valid for Google Container Registry and Artifact Registry.
+type gcpProvider struct {
+	httpClient  *http.Client
+	metadataURL string
+}
+
+func newGCPProvider(httpClient *http.Client) *gcpProvider {
+	metadataHost := os.Getenv(gcpMetadataHostEnv)
+	if metadataHost == "" {
+		metadataHost = gcpDefaultMetadataHost
+	}
+
+	return &gcpProvider{
+		httpClient:  httpClient,
+		metadataURL: "http://" + metadataHost,
+	}
+}
+
+func (p *gcpProvider) matches(registry string) bool {
+	return registry == "gcr.io" || strings.HasSuffix(registry, ".gcr.io") || strings.HasSuffix(registry, "-docker.pkg.dev")
+}
+
+func (p *gcpProvider) getCredentials(ctx context.Context, _ string) (Credentials, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.metadataURL+gcpTokenPath, nil)
+	if err != nil {
+		return Credentials{}, errors.WithStack(err)
+	}
+
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+
+	if err := doJSON(p.httpClient, req, &token); err != nil {
+		return Credentials{}, err
+	}
+
+	if token.AccessToken == "" {
+		return Credentials{}, errors.New("metadata server returned no access token")
+	}
+
+	return Credentials{
+		Username:  gcpRegistryUsername,
+		Password:  token.AccessToken,
+		ExpiresAt: time.Now().Add(time.Duration(token.ExpiresIn) * time.Second),
+	}, nil
+}
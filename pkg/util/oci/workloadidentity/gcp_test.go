@@ -0,0 +1,59 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package workloadidentity
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGCPProviderMatches(t *testing.T) {
+	p := newGCPProvider(http.DefaultClient)
+
+	assert.True(t, p.matches("gcr.io"))
+	assert.True(t, p.matches("eu.gcr.io"))
+	assert.True(t, p.matches("europe-west3-docker.pkg.dev"))
+	assert.False(t, p.matches("docker.io"))
+	assert.False(t, p.matches("gcr.io.evil.io"))
+}
+
+func TestGCPProviderGetCredentials(t *testing.T) {
+	t.Run("token from metadata server", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, gcpTokenPath, r.URL.Path)
+			assert.Equal(t, "Google", r.Header.Get("Metadata-Flavor"))
+
+			_, _ = w.Write([]byte(`{"access_token":"gcp-token","expires_in":3599,"token_type":"Bearer"}`))
+		}))
+		defer server.Close()
+
+		p := newGCPProvider(http.DefaultClient)
+		p.metadataURL = server.URL
+
+		credentials, err := p.getCredentials(t.Context(), "gcr.io")
+		require.NoError(t, err)
+
+		assert.Equal(t, gcpRegistryUsername, credentials.Username)
+		assert.Equal(t, "gcp-token", credentials.Password)
+		assert.WithinDuration(t, time.Now().Add(3599*time.Second), credentials.ExpiresAt, time.Minute)
+	})
+
+	t.Run("metadata server error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		p := newGCPProvider(http.DefaultClient)
+		p.metadataURL = server.URL
+
+		_, err := p.getCredentials(t.Context(), "gcr.io")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,57 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package workloadidentity
+
+import (
+	"context"
+	"slices"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/google/go-containerregistry/pkg/authn"
+)
+
+// Keychain resolves credentials with the workload identity for the configured registries,
+// every other registry is resolved as anonymous, so it can be combined with other keychains using authn.NewMultiKeychain.
+type Keychain struct {
+	resolver   *Resolver
+	registries []string
+}
+
+var _ authn.ContextKeychain = &Keychain{}
+
+func NewKeychain(registries []string) *Keychain {
+	return newKeychain(sharedResolver(), registries)
+}
+
+func newKeychain(resolver *Resolver, registries []string) *Keychain {
+	return &Keychain{
+		resolver:   resolver,
+		registries: registries,
+	}
+}
+
+func (k *Keychain) Resolve(target authn.Resource) (authn.Authenticator, error) {
+	return k.ResolveContext(context.Background(), target)
+}
+
+func (k *Keychain) ResolveContext(ctx context.Context, target authn.Resource) (authn.Authenticator, error) {
+	registry := target.RegistryStr()
+	if !slices.Contains(k.registries, registry) {
+		return authn.Anonymous, nil
+	}
+
+	ctx, log := logd.NewFromContext(ctx, "oci-workload-identity")
+
+	credentials, err := k.resolver.GetCredentials(ctx, registry)
+	if err != nil {
+		log.Info("failed to resolve workload identity credentials, falling back to other keychains", "registry", registry, "error", err.Error())
+
+		return authn.Anonymous, nil
+	}
+
+	return authn.FromConfig(authn.AuthConfig{
+		Username: credentials.Username,
+		Password: credentials.Password,
+	}), nil
+}
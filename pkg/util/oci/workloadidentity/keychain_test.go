@@ -0,0 +1,58 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package workloadidentity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeychain(t *testing.T) {
+	resolveRegistry := func(t *testing.T, keychain authn.Keychain, registry string) *authn.AuthConfig {
+		t.Helper()
+
+		reg, err := name.NewRegistry(registry)
+		require.NoError(t, err)
+
+		authenticator, err := keychain.Resolve(reg)
+		require.NoError(t, err)
+
+		config, err := authenticator.Authorization()
+		require.NoError(t, err)
+
+		return config
+	}
+
+	t.Run("configured registry resolves workload identity credentials", func(t *testing.T) {
+		p := &fakeProvider{credentials: Credentials{Username: "user", Password: "pw", ExpiresAt: time.Now().Add(time.Hour)}}
+		keychain := newKeychain(newResolver(p), []string{"registry.fake.io"})
+
+		config := resolveRegistry(t, keychain, "registry.fake.io")
+		assert.Equal(t, "user", config.Username)
+		assert.Equal(t, "pw", config.Password)
+	})
+
+	t.Run("other registries are anonymous", func(t *testing.T) {
+		p := &fakeProvider{credentials: Credentials{Username: "user", Password: "pw", ExpiresAt: time.Now().Add(time.Hour)}}
+		keychain := newKeychain(newResolver(p), []string{"registry.fake.io"})
+
+		config := resolveRegistry(t, keychain, "other.fake.io")
+		assert.Empty(t, config.Username)
+		assert.Zero(t, p.calls)
+	})
+
+	t.Run("falls back to anonymous on error", func(t *testing.T) {
+		p := &fakeProvider{err: errors.New("boom")}
+		keychain := newKeychain(newResolver(p), []string{"registry.fake.io"})
+
+		config := resolveRegistry(t, keychain, "registry.fake.io")
+		assert.Empty(t, config.Username)
+	})
+}
@@ -7,6 +7,10 @@ const (
 	// InjectionInstanceLabel can be set in a Namespace and indicates the corresponding DynaKube object assigned to it.
 	InjectionInstanceLabel = "dynakube.internal.dynatrace.com/instance"
 
+	// InjectionInstanceNamespaceLabel is set in a Namespace next to InjectionInstanceLabel and indicates the namespace of the corresponding DynaKube object.
+	// If it is missing, the DynaKube is expected in the namespace of the webhook.
+	InjectionInstanceNamespaceLabel = "dynakube.internal.dynatrace.com/instance-namespace"
+
 	// AnnotationFailurePolicy can be set on a Pod to control what the init container does on failures. When set to
 	// "fail", the init container will exit with error code 1. Defaults to "silent".
 	AnnotationFailurePolicy = "oneagent.dynatrace.com/failure-policy"
@@ -36,6 +36,39 @@ func addNetworkZoneEnv(container *corev1.Container, networkZone string) {
 	)
 }
 
+// getTopologyZone returns the topology zone the pod is pinned to by its nodeSelector or required node affinity.
+// The zone of the node is not known at admission, so pods that are not pinned to a single zone get no topology zone.
+func getTopologyZone(pod *corev1.Pod) string {
+	if zone := pod.Spec.NodeSelector[corev1.LabelTopologyZone]; zone != "" {
+		return zone
+	}
+
+	if pod.Spec.Affinity == nil || pod.Spec.Affinity.NodeAffinity == nil || pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return ""
+	}
+
+	var zone string
+
+	// the node selector terms are ORed, so every term has to pin the same zone
+	for _, term := range pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		var termZone string
+
+		for _, expression := range term.MatchExpressions {
+			if expression.Key == corev1.LabelTopologyZone && expression.Operator == corev1.NodeSelectorOpIn && len(expression.Values) == 1 {
+				termZone = expression.Values[0]
+			}
+		}
+
+		if termZone == "" || (zone != "" && zone != termZone) {
+			return ""
+		}
+
+		zone = termZone
+	}
+
+	return zone
+}
+
 func addVersionDetectionEnvs(container *corev1.Container, namespace corev1.Namespace) {
 	labelMapping := NewVersionLabelMapping(namespace)
 	for envName, fieldPath := range labelMapping {
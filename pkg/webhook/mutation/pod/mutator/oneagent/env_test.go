@@ -93,6 +93,44 @@ func TestAddNetworkZoneEnv(t *testing.T) {
 	})
 }
 
+func TestGetTopologyZone(t *testing.T) {
+	zoneAffinity := func(zones ...[]string) *corev1.Affinity {
+		terms := []corev1.NodeSelectorTerm{}
+		for _, values := range zones {
+			terms = append(terms, corev1.NodeSelectorTerm{
+				MatchExpressions: []corev1.NodeSelectorRequirement{
+					{Key: corev1.LabelTopologyZone, Operator: corev1.NodeSelectorOpIn, Values: values},
+				},
+			})
+		}
+
+		return &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: terms},
+		}}
+	}
+
+	t.Run("no zone", func(t *testing.T) {
+		assert.Empty(t, getTopologyZone(&corev1.Pod{}))
+	})
+
+	t.Run("zone from nodeSelector", func(t *testing.T) {
+		pod := &corev1.Pod{Spec: corev1.PodSpec{NodeSelector: map[string]string{corev1.LabelTopologyZone: "zone-a"}}}
+
+		assert.Equal(t, "zone-a", getTopologyZone(pod))
+	})
+
+	t.Run("zone from required node affinity", func(t *testing.T) {
+		pod := &corev1.Pod{Spec: corev1.PodSpec{Affinity: zoneAffinity([]string{"zone-a"}, []string{"zone-a"})}}
+
+		assert.Equal(t, "zone-a", getTopologyZone(pod))
+	})
+
+	t.Run("no zone if the affinity allows multiple zones", func(t *testing.T) {
+		assert.Empty(t, getTopologyZone(&corev1.Pod{Spec: corev1.PodSpec{Affinity: zoneAffinity([]string{"zone-a", "zone-b"})}}))
+		assert.Empty(t, getTopologyZone(&corev1.Pod{Spec: corev1.PodSpec{Affinity: zoneAffinity([]string{"zone-a"}, []string{"zone-b"})}}))
+	})
+}
+
 func TestAddDeploymentMetadataEnv(t *testing.T) {
 	clusterID := "cluster-id"
 
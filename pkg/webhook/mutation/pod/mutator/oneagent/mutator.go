@@ -134,15 +134,17 @@ func containerIsInjected(container corev1.Container, _ *dtwebhook.BaseRequest) b
 }
 
 func mutateUserContainers(request *dtwebhook.BaseRequest, installPath string, log logd.Logger) bool {
+	networkZone := request.DynaKube.GetTopologyNetworkZone(getTopologyZone(request.Pod))
+
 	newContainers := request.NewContainers(containerIsInjected)
 	for _, container := range newContainers {
-		addOneAgentToContainer(request.DynaKube, container, request.Namespace, installPath, log)
+		addOneAgentToContainer(request.DynaKube, container, request.Namespace, networkZone, installPath, log)
 	}
 
 	return len(newContainers) > 0
 }
 
-func addOneAgentToContainer(dk dynakube.DynaKube, container *corev1.Container, namespace corev1.Namespace, installPath string, log logd.Logger) {
+func addOneAgentToContainer(dk dynakube.DynaKube, container *corev1.Container, namespace corev1.Namespace, networkZone, installPath string, log logd.Logger) {
 	log.Info("adding OneAgent to container", "name", container.Name)
 
 	addVolumeMounts(container, installPath)
@@ -150,8 +152,8 @@ func addOneAgentToContainer(dk dynakube.DynaKube, container *corev1.Container, n
 	addPreloadEnv(container, installPath)
 	addDTStorageEnv(container)
 
-	if dk.Spec.NetworkZone != "" {
-		addNetworkZoneEnv(container, dk.Spec.NetworkZone)
+	if networkZone != "" {
+		addNetworkZoneEnv(container, networkZone)
 	}
 
 	if dk.FF().IsLabelVersionDetection() {
@@ -499,7 +499,7 @@ func TestAddOneAgentToContainer(t *testing.T) {
 			},
 		}
 
-		addOneAgentToContainer(dk, &container, corev1.Namespace{}, installPath, logd.Get())
+		addOneAgentToContainer(dk, &container, corev1.Namespace{}, networkZone, installPath, logd.Get())
 
 		assert.Len(t, container.VolumeMounts, 2) // preload,bin
 
@@ -0,0 +1,236 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+// Package readcache serves the reads of the pod mutation webhook without querying the API server for every admission.
+//
+// Namespaces and DynaKubes are read from the shared informer cache of the manager, which is kept up to date by watches.
+// A miss in the informer cache falls back to the API server, so objects that were created moments before the pod are never missed.
+//
+// All other objects (e.g. the replicated secrets and the owners of the pod) are kept in a lookup cache for a limited time,
+// which bounds how stale such an object can be. Missing objects are never cached.
+package readcache
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// DefaultLookupTTL is the maximum staleness of objects served from the lookup cache.
+const DefaultLookupTTL = 30 * time.Second
+
+var log = logd.Get().WithName("webhook-readcache")
+
+// informerTypes are read from the informer cache, all other types from the lookup cache.
+var informerTypes = []client.Object{
+	&corev1.Namespace{},
+	&dynakube.DynaKube{},
+}
+
+type Option func(*Reader)
+
+// WithLookupTTL sets how long objects are kept in the lookup cache, 0 disables the lookup cache.
+func WithLookupTTL(ttl time.Duration) Option {
+	return func(r *Reader) {
+		r.lookups.ttl = ttl
+	}
+}
+
+// Reader is a client.Reader for the webhook, see the package documentation.
+type Reader struct {
+	informers client.Reader
+	apiReader client.Reader
+	lookups   *lookupCache
+}
+
+var _ client.Reader = &Reader{}
+
+func New(informers, apiReader client.Reader, opts ...Option) *Reader {
+	r := &Reader{
+		informers: informers,
+		apiReader: apiReader,
+		lookups:   newLookupCache(DefaultLookupTTL),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Start registers the informers of the cached types, so they are synced together with the cache and not lazily on the first admission.
+func Start(ctx context.Context, informers cache.Informers) error {
+	for _, obj := range informerTypes {
+		if _, err := informers.GetInformer(ctx, obj, cache.BlockUntilSynced(false)); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Reader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if isInformerType(obj) {
+		err := r.informers.Get(ctx, key, obj, opts...)
+		if err == nil {
+			return nil
+		}
+
+		log.Debug("informer cache miss, falling back to the API server", "key", key, "err", err)
+
+		return r.apiReader.Get(ctx, key, obj, opts...)
+	}
+
+	return r.lookups.get(ctx, r.apiReader, key, obj, opts...)
+}
+
+// List is always served by the API server, the webhook doesn't list objects during admissions.
+func (r *Reader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return r.apiReader.List(ctx, list, opts...)
+}
+
+// CachedClient returns a client whose reads go through the lookup cache of the Reader, e.g. for the owner references of pods.
+// Writes are passed to clt unchanged.
+func (r *Reader) CachedClient(clt client.Client) client.Client {
+	return &cachedClient{Client: clt, lookups: r.lookups}
+}
+
+type cachedClient struct {
+	client.Client
+	lookups *lookupCache
+}
+
+func (c *cachedClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.lookups.get(ctx, c.Client, key, obj, opts...)
+}
+
+func isInformerType(obj client.Object) bool {
+	for _, informerType := range informerTypes {
+		if reflect.TypeOf(obj) == reflect.TypeOf(informerType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type lookupEntry struct {
+	obj     runtime.Object
+	expires time.Time
+}
+
+type lookupCache struct {
+	entries   map[string]lookupEntry
+	lastPrune time.Time
+	ttl       time.Duration
+	mu        sync.Mutex
+}
+
+func newLookupCache(ttl time.Duration) *lookupCache {
+	return &lookupCache{
+		entries: map[string]lookupEntry{},
+		ttl:     ttl,
+	}
+}
+
+func (lc *lookupCache) get(ctx context.Context, source client.Reader, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if lc.ttl <= 0 {
+		return source.Get(ctx, key, obj, opts...)
+	}
+
+	gvk, err := apiutil.GVKForObject(obj, scheme.Scheme)
+	if err != nil {
+		return source.Get(ctx, key, obj, opts...)
+	}
+
+	cacheKey := gvk.String() + "/" + key.String()
+
+	if cached := lc.load(cacheKey); cached != nil {
+		return copyInto(cached, obj)
+	}
+
+	err = source.Get(ctx, key, obj, opts...)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			lc.delete(cacheKey)
+		}
+
+		return err
+	}
+
+	lc.store(cacheKey, obj.DeepCopyObject())
+
+	return nil
+}
+
+func (lc *lookupCache) load(key string) runtime.Object {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	entry, ok := lc.entries[key]
+	if !ok {
+		return nil
+	}
+
+	if time.Now().After(entry.expires) {
+		delete(lc.entries, key)
+
+		return nil
+	}
+
+	return entry.obj
+}
+
+func (lc *lookupCache) store(key string, obj runtime.Object) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	now := time.Now()
+
+	// expired entries are only removed on access, so they are pruned once per ttl to keep the cache from growing with every pod
+	if now.Sub(lc.lastPrune) > lc.ttl {
+		for entryKey, entry := range lc.entries {
+			if now.After(entry.expires) {
+				delete(lc.entries, entryKey)
+			}
+		}
+
+		lc.lastPrune = now
+	}
+
+	lc.entries[key] = lookupEntry{obj: obj, expires: now.Add(lc.ttl)}
+}
+
+func (lc *lookupCache) delete(key string) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	delete(lc.entries, key)
+}
+
+// copyInto sets obj to a deep copy of the cached object, both must be of the same type.
+func copyInto(cached runtime.Object, obj client.Object) error {
+	src := reflect.ValueOf(cached.DeepCopyObject())
+	dst := reflect.ValueOf(obj)
+
+	if src.Type() != dst.Type() {
+		return errors.Errorf("cached object of type %s can't be copied into %s", src.Type(), dst.Type())
+	}
+
+	dst.Elem().Set(src.Elem())
+
+	return nil
+}
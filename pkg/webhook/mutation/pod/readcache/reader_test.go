@@ -0,0 +1,190 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package readcache
+
+import (
+	"context"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+const testNamespace = "test-namespace"
+
+// newCountingClient returns a fake client that counts its Get calls, like an API server would see them.
+func newCountingClient(objs ...client.Object) (client.Client, *int) {
+	gets := 0
+
+	clt := fake.NewClientWithInterceptors(interceptor.Funcs{
+		Get: func(ctx context.Context, clt client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			gets++
+
+			return clt.Get(ctx, key, obj, opts...)
+		},
+	}, objs...)
+
+	return clt, &gets
+}
+
+func newTestSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "dynatrace-bootstrapper-config", Namespace: testNamespace},
+		Data:       map[string][]byte{"key": []byte("value")},
+	}
+}
+
+func TestReaderGet(t *testing.T) {
+	t.Run("namespaces and dynakubes are read from the informer cache", func(t *testing.T) {
+		namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}}
+		dk := &dynakube.DynaKube{ObjectMeta: metav1.ObjectMeta{Name: "dynakube", Namespace: "dynatrace"}}
+
+		informers, informerGets := newCountingClient(namespace, dk)
+		apiReader, apiGets := newCountingClient()
+		reader := New(informers, apiReader)
+
+		var gotNamespace corev1.Namespace
+		require.NoError(t, reader.Get(t.Context(), client.ObjectKeyFromObject(namespace), &gotNamespace))
+		assert.Equal(t, testNamespace, gotNamespace.Name)
+
+		var gotDynakube dynakube.DynaKube
+		require.NoError(t, reader.Get(t.Context(), client.ObjectKeyFromObject(dk), &gotDynakube))
+		assert.Equal(t, "dynakube", gotDynakube.Name)
+
+		assert.Equal(t, 2, *informerGets)
+		assert.Zero(t, *apiGets)
+	})
+
+	t.Run("informer cache miss falls back to the API server", func(t *testing.T) {
+		namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}}
+
+		informers, _ := newCountingClient()
+		apiReader, apiGets := newCountingClient(namespace)
+		reader := New(informers, apiReader)
+
+		var gotNamespace corev1.Namespace
+		require.NoError(t, reader.Get(t.Context(), client.ObjectKeyFromObject(namespace), &gotNamespace))
+		assert.Equal(t, testNamespace, gotNamespace.Name)
+		assert.Equal(t, 1, *apiGets)
+	})
+
+	t.Run("other objects are served from the lookup cache until the ttl expires", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			secret := newTestSecret()
+			informers, informerGets := newCountingClient()
+			apiReader, apiGets := newCountingClient(secret)
+			reader := New(informers, apiReader, WithLookupTTL(time.Minute))
+
+			for range 3 {
+				var got corev1.Secret
+				require.NoError(t, reader.Get(t.Context(), client.ObjectKeyFromObject(secret), &got))
+				assert.Equal(t, secret.Data, got.Data)
+			}
+
+			assert.Equal(t, 1, *apiGets)
+			assert.Zero(t, *informerGets)
+
+			time.Sleep(time.Minute + time.Second)
+
+			var got corev1.Secret
+			require.NoError(t, reader.Get(t.Context(), client.ObjectKeyFromObject(secret), &got))
+			assert.Equal(t, 2, *apiGets)
+		})
+	})
+
+	t.Run("cached objects are copies", func(t *testing.T) {
+		secret := newTestSecret()
+		apiReader, _ := newCountingClient(secret)
+		reader := New(fake.NewClient(), apiReader)
+
+		var first corev1.Secret
+		require.NoError(t, reader.Get(t.Context(), client.ObjectKeyFromObject(secret), &first))
+		first.Data["key"] = []byte("changed")
+
+		var second corev1.Secret
+		require.NoError(t, reader.Get(t.Context(), client.ObjectKeyFromObject(secret), &second))
+		assert.Equal(t, []byte("value"), second.Data["key"])
+	})
+
+	t.Run("missing objects are not cached", func(t *testing.T) {
+		secret := newTestSecret()
+		apiReader, apiGets := newCountingClient()
+		reader := New(fake.NewClient(), apiReader)
+
+		var got corev1.Secret
+		err := reader.Get(t.Context(), client.ObjectKeyFromObject(secret), &got)
+		require.True(t, k8serrors.IsNotFound(err))
+
+		require.NoError(t, apiReader.Create(t.Context(), secret))
+
+		require.NoError(t, reader.Get(t.Context(), client.ObjectKeyFromObject(secret), &got))
+		assert.Equal(t, 2, *apiGets)
+	})
+
+	t.Run("lookup cache can be disabled", func(t *testing.T) {
+		secret := newTestSecret()
+		apiReader, apiGets := newCountingClient(secret)
+		reader := New(fake.NewClient(), apiReader, WithLookupTTL(0))
+
+		for range 2 {
+			var got corev1.Secret
+			require.NoError(t, reader.Get(t.Context(), client.ObjectKeyFromObject(secret), &got))
+		}
+
+		assert.Equal(t, 2, *apiGets)
+	})
+}
+
+func TestCachedClient(t *testing.T) {
+	t.Run("owner lookups are cached", func(t *testing.T) {
+		replicaSet := &appsv1.ReplicaSet{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "ReplicaSet"},
+			ObjectMeta: metav1.ObjectMeta{Name: "workload-abc", Namespace: testNamespace},
+		}
+		metaClient, metaGets := newCountingClient(replicaSet)
+		reader := New(fake.NewClient(), fake.NewClient())
+		cachedClient := reader.CachedClient(metaClient)
+
+		for range 2 {
+			got := &metav1.PartialObjectMetadata{TypeMeta: replicaSet.TypeMeta}
+			require.NoError(t, cachedClient.Get(t.Context(), client.ObjectKeyFromObject(replicaSet), got))
+			assert.Equal(t, "workload-abc", got.Name)
+			assert.Equal(t, "ReplicaSet", got.Kind)
+		}
+
+		assert.Equal(t, 1, *metaGets)
+	})
+
+	t.Run("kinds are cached separately", func(t *testing.T) {
+		replicaSet := &appsv1.ReplicaSet{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "ReplicaSet"},
+			ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: testNamespace},
+		}
+		deployment := &appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: testNamespace},
+		}
+		metaClient, metaGets := newCountingClient(replicaSet, deployment)
+		cachedClient := New(fake.NewClient(), fake.NewClient()).CachedClient(metaClient)
+
+		gotReplicaSet := &metav1.PartialObjectMetadata{TypeMeta: replicaSet.TypeMeta}
+		require.NoError(t, cachedClient.Get(t.Context(), client.ObjectKeyFromObject(replicaSet), gotReplicaSet))
+
+		gotDeployment := &metav1.PartialObjectMetadata{TypeMeta: deployment.TypeMeta}
+		require.NoError(t, cachedClient.Get(t.Context(), client.ObjectKeyFromObject(deployment), gotDeployment))
+
+		assert.Equal(t, "Deployment", gotDeployment.Kind)
+		assert.Equal(t, 2, *metaGets)
+	})
+}
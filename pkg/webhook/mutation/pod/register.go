@@ -18,6 +18,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/mutator/oneagent"
 	otlpexporter "github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/mutator/otlp/exporter"
 	otlpresourceattributes "github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/mutator/otlp/resourceattributes"
+	"github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/readcache"
 	"github.com/pkg/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -31,7 +32,13 @@ func registerInjectEndpoint(ctx context.Context, mgr manager.Manager, webhookNam
 	eventRecorder := mgr.GetEventRecorder("dynatrace-webhook")
 	kubeConfig := mgr.GetConfig()
 	kubeClient := mgr.GetClient()
-	apiReader := mgr.GetAPIReader()
+
+	// the reads of every admission are served from caches, as bursts of pods would otherwise hit the API server for each of them
+	if err := readcache.Start(ctx, mgr.GetCache()); err != nil {
+		return err
+	}
+
+	apiReader := readcache.New(mgr.GetCache(), mgr.GetAPIReader())
 
 	// the injected podMutator.client doesn't have permissions to Get(sth) from a different namespace
 	metaClient, err := client.New(kubeConfig, client.Options{})
@@ -42,7 +49,7 @@ func registerInjectEndpoint(ctx context.Context, mgr manager.Manager, webhookNam
 	wh, err := newWebhook(
 		ctx,
 		kubeClient,
-		metaClient,
+		apiReader.CachedClient(metaClient),
 		apiReader,
 		eventRecorder,
 		admission.NewDecoder(mgr.GetScheme()),
@@ -39,7 +39,7 @@ func (wh *webhook) createMutationRequestBase(ctx context.Context, request admiss
 		return nil, nil //nolint
 	}
 
-	dynakube, err := wh.getDynakube(ctx, dynakubeName)
+	dynakube, err := wh.getDynakube(ctx, wh.getDynakubeNamespace(*namespace), dynakubeName)
 	if err != nil {
 		return nil, err
 	}
@@ -86,12 +86,20 @@ func getDynakubeName(namespace corev1.Namespace) (string, error) {
 	return dynakubeName, nil
 }
 
-func (wh *webhook) getDynakube(ctx context.Context, dynakubeName string) (*dynakube.DynaKube, error) {
+func (wh *webhook) getDynakubeNamespace(namespace corev1.Namespace) string {
+	if dynakubeNamespace := namespace.Labels[dtwebhook.InjectionInstanceNamespaceLabel]; dynakubeNamespace != "" {
+		return dynakubeNamespace
+	}
+
+	return wh.webhookNamespace
+}
+
+func (wh *webhook) getDynakube(ctx context.Context, dynakubeNamespace, dynakubeName string) (*dynakube.DynaKube, error) {
 	var dk dynakube.DynaKube
 
-	err := wh.apiReader.Get(ctx, client.ObjectKey{Name: dynakubeName, Namespace: wh.webhookNamespace}, &dk)
+	err := wh.apiReader.Get(ctx, client.ObjectKey{Name: dynakubeName, Namespace: dynakubeNamespace}, &dk)
 	if k8serrors.IsNotFound(err) {
-		events.SendMissingDynaKubeEvent(wh.recorder, wh.webhookNamespace, dynakubeName)
+		events.SendMissingDynaKubeEvent(wh.recorder, dynakubeNamespace, dynakubeName)
 
 		return nil, err
 	} else if err != nil {
@@ -76,12 +76,27 @@ func TestGetDynakubeName(t *testing.T) {
 	})
 }
 
+func TestGetDynakubeNamespace(t *testing.T) {
+	podWebhook := createTestWebhook(t, handlermock.NewHandler(t), handlermock.NewHandler(t))
+
+	t.Run("should default to the webhook namespace", func(t *testing.T) {
+		assert.Equal(t, testNamespaceName, podWebhook.getDynakubeNamespace(*getTestNamespace()))
+	})
+
+	t.Run("should return the namespace from the label", func(t *testing.T) {
+		namespace := getTestNamespace()
+		namespace.Labels[dtwebhook.InjectionInstanceNamespaceLabel] = "tenant"
+
+		assert.Equal(t, "tenant", podWebhook.getDynakubeNamespace(*namespace))
+	})
+}
+
 func TestGetDynakube(t *testing.T) {
 	t.Run("should return the dynakube struct", func(t *testing.T) {
 		expected := getTestDynakube()
 		podWebhook := createTestWebhook(t, handlermock.NewHandler(t), handlermock.NewHandler(t), expected)
 
-		dynakube, err := podWebhook.getDynakube(t.Context(), testDynakubeName)
+		dynakube, err := podWebhook.getDynakube(t.Context(), testNamespaceName, testDynakubeName)
 		require.NoError(t, err)
 		assert.Equal(t, expected.ObjectMeta, dynakube.ObjectMeta)
 		assert.Equal(t, expected.Spec.OneAgent.CloudNativeFullStack, dynakube.Spec.OneAgent.CloudNativeFullStack)
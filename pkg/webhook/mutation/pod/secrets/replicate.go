@@ -12,10 +12,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// DataFunc adjusts the data of the source secret to the namespace it is replicated to.
+type DataFunc func(data map[string][]byte, namespace corev1.Namespace) map[string][]byte
+
 // EnsureReplicated ensures that a target secret exists in the given namespace.
 // If the target secret does not exist, it tries to replicate it from the provided source secret name
 // Returns nil on success (whether it already existed or was replicated) or an error if replication fails.
-func EnsureReplicated(mutationRequest *dtwebhook.MutationRequest, kubeClient client.Client, apiReader client.Reader, sourceSecretName, targetSecretName string, logger logd.Logger) error { //nolint:revive
+func EnsureReplicated(mutationRequest *dtwebhook.MutationRequest, kubeClient client.Client, apiReader client.Reader, sourceSecretName, targetSecretName string, logger logd.Logger, dataFuncs ...DataFunc) error { //nolint:revive
 	var initSecret corev1.Secret
 
 	secretObjectKey := client.ObjectKey{Name: targetSecretName, Namespace: mutationRequest.Namespace.Name}
@@ -24,18 +27,30 @@ func EnsureReplicated(mutationRequest *dtwebhook.MutationRequest, kubeClient cli
 	if k8serrors.IsNotFound(err) {
 		logger.Info(targetSecretName+" is not available, trying to replicate", "pod", mutationRequest.PodName())
 
-		return k8ssecret.Replicate(
-			mutationRequest.Context,
-			k8ssecret.Query(kubeClient, apiReader),
-			client.ObjectKey{
-				Name:      sourceSecretName,
-				Namespace: mutationRequest.DynaKube.Namespace,
-			},
-			client.ObjectKey{
-				Name:      targetSecretName,
-				Namespace: mutationRequest.Namespace.Name,
-			},
-		)
+		query := k8ssecret.Query(kubeClient, apiReader)
+		sourceKey := client.ObjectKey{
+			Name:      sourceSecretName,
+			Namespace: mutationRequest.DynaKube.Namespace,
+		}
+		targetKey := client.ObjectKey{
+			Name:      targetSecretName,
+			Namespace: mutationRequest.Namespace.Name,
+		}
+
+		if len(dataFuncs) == 0 {
+			return k8ssecret.Replicate(mutationRequest.Context, query, sourceKey, targetKey)
+		}
+
+		secret, err := k8ssecret.GetSecretFromSource(mutationRequest.Context, query, sourceKey, targetKey)
+		if err != nil {
+			return err
+		}
+
+		for _, dataFunc := range dataFuncs {
+			secret.Data = dataFunc(secret.Data, mutationRequest.Namespace)
+		}
+
+		return client.IgnoreAlreadyExists(query.Create(mutationRequest.Context, secret))
 	} else if err != nil {
 		return err
 	}
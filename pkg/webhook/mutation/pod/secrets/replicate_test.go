@@ -59,6 +59,24 @@ func TestEnsureReplicated(t *testing.T) {
 		assert.Equal(t, []byte("bar"), s.Data["foo"])
 	})
 
+	t.Run("target missing + source present -> replication applies data funcs", func(t *testing.T) {
+		clt := fake.NewClient(
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: sourceSecret, Namespace: testNamespace}, Data: map[string][]byte{"foo": []byte("bar")}},
+		)
+
+		req := newRequest(t)
+		dataFunc := func(data map[string][]byte, namespace corev1.Namespace) map[string][]byte {
+			return map[string][]byte{"foo": []byte(string(data["foo"]) + "-" + namespace.Name)}
+		}
+
+		err := EnsureReplicated(req, clt, clt, sourceSecret, targetSecret, logger, dataFunc)
+		require.NoError(t, err)
+
+		var s corev1.Secret
+		require.NoError(t, clt.Get(t.Context(), client.ObjectKey{Name: targetSecret, Namespace: testNamespace}, &s))
+		assert.Equal(t, []byte("bar-"+testNamespace), s.Data["foo"])
+	})
+
 	t.Run("target + source both missing -> returns not found error", func(t *testing.T) {
 		clt := fake.NewClient()
 		req := newRequest(t)
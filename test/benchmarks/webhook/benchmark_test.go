@@ -0,0 +1,109 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook_test
+
+import (
+	"context"
+	"flag"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/mutator"
+	"github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/readcache"
+	"github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/secrets"
+	"github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/workload"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	customNumNamespaces = flag.Int("num-namespaces", 10, "custom number of namespaces for the benchmark")
+	customNumWorkloads  = flag.Int("num-workloads", 10, "custom number of workloads per namespace for the benchmark")
+	customAPILatency    = flag.Duration("api-latency", time.Millisecond, "custom latency of a request to the simulated API server")
+)
+
+func getBenchmarkConfig(b *testing.B) benchmarkConfig {
+	b.Helper()
+
+	return benchmarkConfig{
+		NumNamespaces: *customNumNamespaces,
+		NumWorkloads:  *customNumWorkloads,
+		APILatency:    *customAPILatency,
+	}
+}
+
+// dataAccess are the clients the webhook uses during an admission.
+type dataAccess struct {
+	kubeClient client.Client
+	apiReader  client.Reader
+	metaClient client.Client
+}
+
+// BenchmarkPodWebhook_Admission_Direct measures the data access of pod admissions when every read goes to the API server.
+func BenchmarkPodWebhook_Admission_Direct(b *testing.B) {
+	config := getBenchmarkConfig(b)
+	server := config.SetupAPIServer(b)
+
+	runBenchmarkAdmission(b, config, server, dataAccess{
+		kubeClient: server,
+		apiReader:  server,
+		metaClient: server,
+	})
+}
+
+// BenchmarkPodWebhook_Admission_Cached measures the data access of pod admissions with the informer and lookup caches of the webhook.
+func BenchmarkPodWebhook_Admission_Cached(b *testing.B) {
+	config := getBenchmarkConfig(b)
+	server := config.SetupAPIServer(b)
+	reader := readcache.New(config.SetupInformerCache(b), server)
+
+	runBenchmarkAdmission(b, config, server, dataAccess{
+		kubeClient: server,
+		apiReader:  reader,
+		metaClient: reader.CachedClient(server),
+	})
+}
+
+func runBenchmarkAdmission(b *testing.B, config benchmarkConfig, server *apiServer, access dataAccess) {
+	b.Helper()
+
+	// Benchmark a burst of admissions
+	// The pods are admitted in parallel, like during the rollout of many workloads at once.
+	// The i variable is used to cycle through the workloads, so every namespace and owner chain is hit repeatedly.
+	var i atomic.Int64
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			benchmarkedFunc(b, access, config.Pod(int(i.Add(1))))
+		}
+	})
+	config.ReportMetrics(b, server)
+}
+
+// benchmarkedFunc does the reads of a single admission: namespace, DynaKube, init secret and the owner chain of the pod.
+func benchmarkedFunc(b *testing.B, access dataAccess, pod *corev1.Pod) {
+	ctx := context.Background()
+
+	var namespace corev1.Namespace
+	require.NoError(b, access.apiReader.Get(ctx, client.ObjectKey{Name: pod.Namespace}, &namespace))
+
+	var dk dynakube.DynaKube
+	require.NoError(b, access.apiReader.Get(ctx, client.ObjectKey{Name: namespace.Labels[dtwebhook.InjectionInstanceLabel], Namespace: testDynakubeNamespace}, &dk))
+
+	request := dtwebhook.NewMutationRequest(ctx, namespace, nil, pod, dk)
+
+	err := secrets.EnsureReplicated(request, access.kubeClient, access.apiReader, testSourceSecretName, consts.BootstrapperInitSecretName, logd.Get())
+	require.NoError(b, err)
+
+	info, err := workload.FindRootOwnerOfPod(ctx, access.metaClient, *request.BaseRequest)
+	require.NoError(b, err)
+	require.Equal(b, "deployment", info.Kind)
+}
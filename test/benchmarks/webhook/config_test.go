@@ -0,0 +1,138 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/latest/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
+	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/mutator"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+const (
+	testDynakubeNamespace = "dynatrace"
+	testDynakubeName      = "dynakube"
+	testSourceSecretName  = "dynakube-bootstrapper-config"
+)
+
+// benchmarkConfig holds the configurable parameters for the benchmark
+type benchmarkConfig struct {
+	// Number of namespaces the admitted pods are spread across
+	NumNamespaces int
+	// Number of workloads (Deployment -> ReplicaSet) per namespace
+	NumWorkloads int
+	// Latency of a single request to the simulated API server
+	APILatency time.Duration
+}
+
+// apiServer simulates the API server, every read takes APILatency and is counted.
+type apiServer struct {
+	client.Client
+	reads atomic.Int64
+}
+
+func (bc benchmarkConfig) SetupAPIServer(b *testing.B) *apiServer {
+	b.Helper()
+
+	server := &apiServer{}
+	server.Client = fake.NewClientWithInterceptors(interceptor.Funcs{
+		Get: func(ctx context.Context, clt client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			server.reads.Add(1)
+			time.Sleep(bc.APILatency)
+
+			return clt.Get(ctx, key, obj, opts...)
+		},
+	}, bc.objects()...)
+
+	return server
+}
+
+// SetupInformerCache returns a client that holds the namespaces and DynaKubes in memory, like a synced informer cache.
+func (bc benchmarkConfig) SetupInformerCache(b *testing.B) client.Client {
+	b.Helper()
+
+	return fake.NewClient(bc.objects()...)
+}
+
+func (bc benchmarkConfig) objects() []client.Object {
+	objects := []client.Object{
+		&dynakube.DynaKube{ObjectMeta: metav1.ObjectMeta{Name: testDynakubeName, Namespace: testDynakubeNamespace}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: testSourceSecretName, Namespace: testDynakubeNamespace}},
+	}
+
+	for ns := range bc.NumNamespaces {
+		namespace := genNamespaceName(ns)
+
+		objects = append(objects,
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   namespace,
+				Labels: map[string]string{dtwebhook.InjectionInstanceLabel: testDynakubeName},
+			}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: consts.BootstrapperInitSecretName, Namespace: namespace}},
+		)
+
+		for w := range bc.NumWorkloads {
+			deployment := genWorkloadName(w)
+
+			objects = append(objects,
+				&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: deployment, Namespace: namespace}},
+				&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+					Name:            deployment + "-abc",
+					Namespace:       namespace,
+					OwnerReferences: []metav1.OwnerReference{controllerRef("Deployment", deployment)},
+				}},
+			)
+		}
+	}
+
+	return objects
+}
+
+// Pod returns the i-th pod of the burst, the pods are spread evenly across all workloads.
+func (bc benchmarkConfig) Pod(i int) *corev1.Pod {
+	workload := i % (bc.NumNamespaces * bc.NumWorkloads)
+	deployment := genWorkloadName(workload % bc.NumWorkloads)
+
+	return &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName:    deployment + "-abc-",
+			Namespace:       genNamespaceName(workload / bc.NumWorkloads),
+			OwnerReferences: []metav1.OwnerReference{controllerRef("ReplicaSet", deployment+"-abc")},
+		},
+	}
+}
+
+func (bc benchmarkConfig) ReportMetrics(b *testing.B, server *apiServer) {
+	b.Helper()
+	b.ReportMetric(float64(server.reads.Load())/float64(b.N), "api-reads/op")
+}
+
+func controllerRef(kind, name string) metav1.OwnerReference {
+	return metav1.OwnerReference{
+		APIVersion: "apps/v1",
+		Kind:       kind,
+		Name:       name,
+		Controller: new(true),
+	}
+}
+
+func genNamespaceName(i int) string {
+	return fmt.Sprintf("namespace-%d", i)
+}
+
+func genWorkloadName(i int) string {
+	return fmt.Sprintf("workload-%d", i)
+}
@@ -44,7 +44,7 @@ func Feature(t *testing.T, releaseTag string) features.Feature {
 		edgeconnectComponents.WithName(testECname),
 		edgeconnectComponents.WithAPIServer(edgeconnectSecretConfig.APIServer),
 		edgeconnectComponents.WithOAuthClientSecret(edgeconnectComponents.BuildOAuthClientSecretName(testECname)),
-		edgeconnectComponents.WithOAuthEndpoint("https://sso-dev.dynatracelabs.com/sso/oauth2/token"),
+		edgeconnectComponents.WithOAuthEndpoint(edgeconnectSecretConfig.TokenURL()),
 		edgeconnectComponents.WithOAuthResource(fmt.Sprintf("urn:dtenvironment:%s", edgeconnectSecretConfig.TenantUID)),
 	)
 
@@ -148,7 +148,7 @@ func BuildClient(secret tenant.EdgeConnectSecret) (edgeconnectClient.Client, err
 		clientcredentials.Config{
 			ClientID:     secret.OauthClientID,
 			ClientSecret: secret.OauthClientSecret,
-			TokenURL:     "https://sso-dev.dynatracelabs.com/sso/oauth2/token",
+			TokenURL:     secret.TokenURL(),
 			Scopes: []string{
 				"app-engine:edge-connects:read",
 				"app-engine:edge-connects:write",
@@ -7,10 +7,12 @@ package tenant
 
 import (
 	"context"
+	"net/url"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/faketenant"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/dttoken"
 	"github.com/Dynatrace/dynatrace-operator/test/e2e/project"
 	"github.com/pkg/errors"
@@ -23,6 +25,11 @@ import (
 	"sigs.k8s.io/e2e-framework/pkg/features"
 )
 
+// fakeTenantURLEnv points the tests to a fake tenant (see the fake-tenant command) instead of the tenants of the testdata secrets.
+const fakeTenantURLEnv = "FAKE_TENANT_URL"
+
+const defaultOAuthEndpoint = "https://sso-dev.dynatracelabs.com/sso/oauth2/token"
+
 var (
 	defaultSingleTenant      = filepath.Join(project.TestDataDir(), "secrets/single-tenant.yaml")
 	defaultMultiTenant       = filepath.Join(project.TestDataDir(), "secrets/multi-tenant.yaml")
@@ -59,6 +66,16 @@ type EdgeConnectSecret struct {
 	OauthClientID     string `yaml:"oAuthClientId"`
 	OauthClientSecret string `yaml:"oAuthClientSecret"`
 	Resource          string `yaml:"resource"`
+	OAuthEndpoint     string `yaml:"oAuthEndpoint"`
+}
+
+// TokenURL returns the OAuth endpoint of the tenant, the dev SSO is used if none is configured.
+func (s EdgeConnectSecret) TokenURL() string {
+	if s.OAuthEndpoint != "" {
+		return s.OAuthEndpoint
+	}
+
+	return defaultOAuthEndpoint
 }
 
 func (s Secret) TokensWithSettingsScope() Tokens {
@@ -111,7 +128,22 @@ func newFromConfig(path string) (Secret, error) {
 	return result, errors.WithStack(err)
 }
 
+// fakeTenantSecret returns the secret for the fake tenant at the URL, it uses the default tokens of the fake tenant.
+func fakeTenantSecret(fakeTenantURL string) Secret {
+	return Secret{
+		TenantUID:          faketenant.DefaultTenantUUID,
+		APIURL:             fakeTenantURL + faketenant.APIPath,
+		APIToken:           faketenant.DefaultAPIToken,
+		DataIngestToken:    faketenant.DefaultDataIngestToken,
+		APITokenNoSettings: faketenant.DefaultNoSettingsToken,
+	}
+}
+
 func GetSingleTenantSecret(t *testing.T) Secret {
+	if fakeTenantURL := os.Getenv(fakeTenantURLEnv); fakeTenantURL != "" {
+		return fakeTenantSecret(fakeTenantURL)
+	}
+
 	var tenant = defaultSingleTenant
 
 	if UsePhase3Tenant() {
@@ -127,6 +159,11 @@ func GetSingleTenantSecret(t *testing.T) Secret {
 }
 
 func GetMultiTenantSecret(t *testing.T) []Secret {
+	if fakeTenantURL := os.Getenv(fakeTenantURLEnv); fakeTenantURL != "" {
+		// both DynaKubes are connected to the same fake tenant
+		return []Secret{fakeTenantSecret(fakeTenantURL), fakeTenantSecret(fakeTenantURL)}
+	}
+
 	var tenant = defaultMultiTenant
 
 	if UsePhase3Tenant() {
@@ -142,6 +179,23 @@ func GetMultiTenantSecret(t *testing.T) []Secret {
 }
 
 func GetEdgeConnectTenantSecret(t *testing.T) EdgeConnectSecret {
+	if fakeTenantURL := os.Getenv(fakeTenantURLEnv); fakeTenantURL != "" {
+		parsedURL, err := url.Parse(fakeTenantURL)
+		if err != nil {
+			t.Fatal("Couldn't parse fake tenant url", err)
+		}
+
+		return EdgeConnectSecret{
+			TenantUID:         faketenant.DefaultTenantUUID,
+			Name:              "e2e-fake-tenant",
+			APIServer:         parsedURL.Host,
+			OauthClientID:     faketenant.DefaultOAuthClientID,
+			OauthClientSecret: faketenant.DefaultOAuthClientSecret,
+			Resource:          "urn:dtenvironment:" + faketenant.DefaultTenantUUID,
+			OAuthEndpoint:     fakeTenantURL + faketenant.OAuthTokenPath,
+		}
+	}
+
 	var tenant = defaultEdgeConnectTenant
 
 	if UsePhase3Tenant() {
@@ -31,3 +31,15 @@ func TestNewFromConfig(t *testing.T) {
 	assert.Equal(t, "apiUrl", tenantSecrets.APIURL)
 	assert.Equal(t, "apiToken", tenantSecrets.APIToken)
 }
+
+func TestFakeTenantSecret(t *testing.T) {
+	t.Setenv(fakeTenantURLEnv, "https://faketenant.dynatrace:8443")
+
+	secret := GetSingleTenantSecret(t)
+	assert.Equal(t, "https://faketenant.dynatrace:8443/api", secret.APIURL)
+	assert.NotEmpty(t, secret.APIToken)
+	assert.Len(t, GetMultiTenantSecret(t), 2)
+
+	ecSecret := GetEdgeConnectTenantSecret(t)
+	assert.Equal(t, "faketenant.dynatrace:8443", ecSecret.APIServer)
+}
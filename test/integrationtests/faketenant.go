@@ -0,0 +1,23 @@
+// Copyright Dynatrace LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package integrationtests
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/faketenant"
+)
+
+// SetupFakeTenant starts a fake tenant for the test and returns it together with the API URL to use in DynaKubes.
+// The tenant accepts [faketenant.DefaultAPIToken] as API token.
+func SetupFakeTenant(tb testing.TB, options ...faketenant.Option) (*faketenant.Server, string) {
+	tb.Helper()
+
+	server := faketenant.New(options...)
+	httpServer := httptest.NewServer(server)
+	tb.Cleanup(httpServer.Close)
+
+	return server, httpServer.URL + faketenant.APIPath
+}
@@ -234,6 +234,78 @@ func (_c *Client_GetLatest_Call) RunAndReturn(run func(ctx context.Context, args
 	return _c
 }
 
+// GetNetworkZoneConnectionInfo provides a mock function for the type Client
+func (_mock *Client) GetNetworkZoneConnectionInfo(ctx context.Context, networkZone string, requiredIPs []string) (oneagent.ConnectionInfo, error) {
+	ret := _mock.Called(ctx, networkZone, requiredIPs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNetworkZoneConnectionInfo")
+	}
+
+	var r0 oneagent.ConnectionInfo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) (oneagent.ConnectionInfo, error)); ok {
+		return returnFunc(ctx, networkZone, requiredIPs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) oneagent.ConnectionInfo); ok {
+		r0 = returnFunc(ctx, networkZone, requiredIPs)
+	} else {
+		r0 = ret.Get(0).(oneagent.ConnectionInfo)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = returnFunc(ctx, networkZone, requiredIPs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Client_GetNetworkZoneConnectionInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNetworkZoneConnectionInfo'
+type Client_GetNetworkZoneConnectionInfo_Call struct {
+	*mock.Call
+}
+
+// GetNetworkZoneConnectionInfo is a helper method to define mock.On call
+//   - ctx context.Context
+//   - networkZone string
+//   - requiredIPs []string
+func (_e *Client_Expecter) GetNetworkZoneConnectionInfo(ctx any, networkZone any, requiredIPs any) *Client_GetNetworkZoneConnectionInfo_Call {
+	return &Client_GetNetworkZoneConnectionInfo_Call{Call: _e.mock.On("GetNetworkZoneConnectionInfo", ctx, networkZone, requiredIPs)}
+}
+
+func (_c *Client_GetNetworkZoneConnectionInfo_Call) Run(run func(ctx context.Context, networkZone string, requiredIPs []string)) *Client_GetNetworkZoneConnectionInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Client_GetNetworkZoneConnectionInfo_Call) Return(connectionInfo oneagent.ConnectionInfo, err error) *Client_GetNetworkZoneConnectionInfo_Call {
+	_c.Call.Return(connectionInfo, err)
+	return _c
+}
+
+func (_c *Client_GetNetworkZoneConnectionInfo_Call) RunAndReturn(run func(ctx context.Context, networkZone string, requiredIPs []string) (oneagent.ConnectionInfo, error)) *Client_GetNetworkZoneConnectionInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetProcessGroupingConfig provides a mock function for the type Client
 func (_mock *Client) GetProcessGroupingConfig(ctx context.Context, kubernetesClusterID string, etag string) (*oneagent.ProcessGroupConfig, error) {
 	ret := _mock.Called(ctx, kubernetesClusterID, etag)
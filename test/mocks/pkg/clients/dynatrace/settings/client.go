@@ -503,6 +503,72 @@ func (_c *Client_CreateOrUpdateKubernetesSetting_Call) RunAndReturn(run func(ctx
 	return _c
 }
 
+// CreateSyntheticLocation provides a mock function for the type Client
+func (_mock *Client) CreateSyntheticLocation(ctx context.Context, location settings.SyntheticLocationValue) (string, error) {
+	ret := _mock.Called(ctx, location)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSyntheticLocation")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, settings.SyntheticLocationValue) (string, error)); ok {
+		return returnFunc(ctx, location)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, settings.SyntheticLocationValue) string); ok {
+		r0 = returnFunc(ctx, location)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, settings.SyntheticLocationValue) error); ok {
+		r1 = returnFunc(ctx, location)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Client_CreateSyntheticLocation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSyntheticLocation'
+type Client_CreateSyntheticLocation_Call struct {
+	*mock.Call
+}
+
+// CreateSyntheticLocation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - location settings.SyntheticLocationValue
+func (_e *Client_Expecter) CreateSyntheticLocation(ctx any, location any) *Client_CreateSyntheticLocation_Call {
+	return &Client_CreateSyntheticLocation_Call{Call: _e.mock.On("CreateSyntheticLocation", ctx, location)}
+}
+
+func (_c *Client_CreateSyntheticLocation_Call) Run(run func(ctx context.Context, location settings.SyntheticLocationValue)) *Client_CreateSyntheticLocation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 settings.SyntheticLocationValue
+		if args[1] != nil {
+			arg1 = args[1].(settings.SyntheticLocationValue)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Client_CreateSyntheticLocation_Call) Return(s string, err error) *Client_CreateSyntheticLocation_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *Client_CreateSyntheticLocation_Call) RunAndReturn(run func(ctx context.Context, location settings.SyntheticLocationValue) (string, error)) *Client_CreateSyntheticLocation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteSettings provides a mock function for the type Client
 func (_mock *Client) DeleteSettings(ctx context.Context, settingsID string) error {
 	ret := _mock.Called(ctx, settingsID)
@@ -1039,3 +1105,69 @@ func (_c *Client_GetSettingsForMonitoredEntity_Call) RunAndReturn(run func(ctx c
 	_c.Call.Return(run)
 	return _c
 }
+
+// GetSyntheticLocations provides a mock function for the type Client
+func (_mock *Client) GetSyntheticLocations(ctx context.Context, name string) (settings.SyntheticLocationsResponse, error) {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSyntheticLocations")
+	}
+
+	var r0 settings.SyntheticLocationsResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (settings.SyntheticLocationsResponse, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) settings.SyntheticLocationsResponse); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		r0 = ret.Get(0).(settings.SyntheticLocationsResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Client_GetSyntheticLocations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSyntheticLocations'
+type Client_GetSyntheticLocations_Call struct {
+	*mock.Call
+}
+
+// GetSyntheticLocations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *Client_Expecter) GetSyntheticLocations(ctx any, name any) *Client_GetSyntheticLocations_Call {
+	return &Client_GetSyntheticLocations_Call{Call: _e.mock.On("GetSyntheticLocations", ctx, name)}
+}
+
+func (_c *Client_GetSyntheticLocations_Call) Run(run func(ctx context.Context, name string)) *Client_GetSyntheticLocations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Client_GetSyntheticLocations_Call) Return(syntheticLocationsResponse settings.SyntheticLocationsResponse, err error) *Client_GetSyntheticLocations_Call {
+	_c.Call.Return(syntheticLocationsResponse, err)
+	return _c
+}
+
+func (_c *Client_GetSyntheticLocations_Call) RunAndReturn(run func(ctx context.Context, name string) (settings.SyntheticLocationsResponse, error)) *Client_GetSyntheticLocations_Call {
+	_c.Call.Return(run)
+	return _c
+}